@@ -6,6 +6,7 @@ package main
 
 import (
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,7 +18,9 @@ type addOptions struct {
 	withValidation bool
 	withStatus     bool
 	withVersioning bool
+	withAuth       bool
 	packageName    string
+	fromJSONSchema string
 }
 
 func newAddCommand() *cobra.Command {
@@ -37,6 +40,8 @@ This creates:
 Example:
   fabrica add resource Device
   fabrica add resource Product --with-validation
+  fabrica add resource Device --with-auth
+  fabrica add resource Device --from-json-schema device.schema.json
 `,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
@@ -56,7 +61,9 @@ Example:
 	cmd.Flags().BoolVar(&opts.withValidation, "with-validation", true, "Include validation tags")
 	cmd.Flags().BoolVar(&opts.withStatus, "with-status", true, "Include Status struct")
 	cmd.Flags().BoolVar(&opts.withVersioning, "with-versioning", false, "Enable per-resource spec versioning (snapshots). Status is never versioned.")
+	cmd.Flags().BoolVar(&opts.withAuth, "with-auth", false, "Require a bearer JWT on this resource's routes (needs auth enabled in .fabrica.yaml)")
 	cmd.Flags().StringVar(&opts.packageName, "package", "", "Package name (defaults to lowercase resource name)")
+	cmd.Flags().StringVar(&opts.fromJSONSchema, "from-json-schema", "", "Generate the Spec fields and validation tags from a JSON Schema file")
 
 	return cmd
 }
@@ -88,6 +95,15 @@ func runAddResource(resourceName string, opts *addOptions) error {
 		opts.packageName = strings.ToLower(resourceName)
 	}
 
+	// Parse the schema before touching the filesystem
+	var schema *jsonSchema
+	if opts.fromJSONSchema != "" {
+		var err error
+		if schema, err = loadJSONSchema(opts.fromJSONSchema); err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("📦 Adding resource %s...\n", resourceName)
 
 	// Create package directory
@@ -98,7 +114,7 @@ func runAddResource(resourceName string, opts *addOptions) error {
 
 	// Generate resource file
 	resourceFile := filepath.Join(pkgDir, opts.packageName+".go")
-	if err := generateResourceFile(resourceFile, resourceName, opts); err != nil {
+	if err := generateResourceFile(resourceFile, resourceName, opts, schema); err != nil {
 		return err
 	}
 
@@ -114,9 +130,24 @@ func runAddResource(resourceName string, opts *addOptions) error {
 	return nil
 }
 
-func generateResourceFile(filePath, resourceName string, opts *addOptions) error {
+func generateResourceFile(filePath, resourceName string, opts *addOptions, schema *jsonSchema) error {
 	packageName := opts.packageName
 
+	// Convert the schema first so a bad schema leaves no file behind
+	var specFields []schemaField
+	var nestedStructs []schemaStruct
+	if schema != nil {
+		var warnings []string
+		var err error
+		specFields, nestedStructs, warnings, err = convertJSONSchema(schema, resourceName+"Spec", opts.withValidation)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Printf("  ⚠️  %s\n", warning)
+		}
+	}
+
 	content := fmt.Sprintf(`// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
 //
 // SPDX-License-Identifier: MIT
@@ -155,18 +186,29 @@ type ` + resourceName + ` struct {
 	content += fmt.Sprintf(`// %sSpec defines the desired state of %s
 type %sSpec struct {`, resourceName, resourceName, resourceName)
 
-	if opts.withValidation {
-		content += `
-	Description string ` + "`json:\"description,omitempty\" validate:\"max=200\"`"
+	if schema != nil {
+		var fields strings.Builder
+		fields.WriteString("\n")
+		renderSchemaFields(&fields, specFields)
+		content += fields.String() + "}\n"
+
+		var nested strings.Builder
+		renderSchemaStructs(&nested, nestedStructs)
+		content += nested.String()
 	} else {
-		content += `
+		if opts.withValidation {
+			content += `
+	Description string ` + "`json:\"description,omitempty\" validate:\"max=200\"`"
+		} else {
+			content += `
 	Description string ` + "`json:\"description,omitempty\"`"
-	}
+		}
 
-	content += `
+		content += `
 	// Add your spec fields here
 }
 `
+	}
 
 	// Add a marker comment for per-resource versioning if enabled.
 	// The generator will detect this and enable versioning templates.
@@ -174,6 +216,11 @@ type %sSpec struct {`, resourceName, resourceName, resourceName)
 		content = "// +fabrica:resource-versioning=enabled\n" + content
 	}
 
+	// Marker for routes that require authentication
+	if opts.withAuth {
+		content = "// +fabrica:auth=required\n" + content
+	}
+
 	if opts.withStatus {
 		content += fmt.Sprintf(`
 // %sStatus defines the observed state of %s
@@ -181,6 +228,9 @@ type %sStatus struct {
 	Phase      string `+"`json:\"phase,omitempty\"`"+`
 	Message    string `+"`json:\"message,omitempty\"`"+`
 	Ready      bool   `+"`json:\"ready\"`"+`
+
+	// ObservedGeneration is the metadata.generation last acted on by a reconciler
+	ObservedGeneration int64 `+"`json:\"observedGeneration,omitempty\"`"+`
 `, resourceName, resourceName, resourceName)
 
 		if opts.withVersioning {
@@ -233,5 +283,10 @@ func init() {
 }
 `, resourceName, strings.ToLower(resourceName)[:3])
 
-	return os.WriteFile(filePath, []byte(content), 0644)
+	// Schema-derived fields vary in width; let gofmt align them
+	data, err := format.Source([]byte(content))
+	if err != nil {
+		return fmt.Errorf("generated %s is not valid Go: %w", filePath, err)
+	}
+	return os.WriteFile(filePath, data, 0644)
 }
@@ -0,0 +1,295 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// backupManifestName is the archive entry describing a backup
+const backupManifestName = "manifest.json"
+
+// backupManifest records what a backup archive contains
+type backupManifest struct {
+	FabricaVersion string         `json:"fabricaVersion"`
+	ExportedAt     time.Time      `json:"exportedAt"`
+	ResourceTypes  []string       `json:"resourceTypes"`
+	Counts         map[string]int `json:"counts"`
+}
+
+func newExportCommand() *cobra.Command {
+	var output string
+	var dataDir string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all stored resources to a backup archive",
+		Long: `Export every resource in file storage to a gzipped tarball.
+
+Each resource is written as <Type>/<uid>.json, alongside a manifest.json
+recording the Fabrica version and the exported resource types. Resource
+types are discovered from pkg/resources.
+
+Only file storage is supported; back up Ent databases with the database's
+own tools.
+
+Example:
+  fabrica export --output backup.tar.gz
+  fabrica export --data-dir /var/lib/myapi --output backup.tar.gz
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runExport(cmd.Context(), dataDir, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "backup.tar.gz", "Path of the backup archive to write")
+	cmd.Flags().StringVar(&dataDir, "data-dir", "./data", "Directory used by file storage")
+
+	return cmd
+}
+
+func newImportCommand() *cobra.Command {
+	var dataDir string
+	var overwrite bool
+
+	cmd := &cobra.Command{
+		Use:   "import <archive>",
+		Short: "Restore resources from a backup archive",
+		Long: `Restore resources written by 'fabrica export' into file storage.
+
+Resources that already exist are skipped unless --overwrite is given.
+
+Example:
+  fabrica import backup.tar.gz
+  fabrica import backup.tar.gz --overwrite
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd.Context(), args[0], dataDir, overwrite)
+		},
+	}
+
+	cmd.Flags().StringVar(&dataDir, "data-dir", "./data", "Directory used by file storage")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Replace resources that already exist")
+
+	return cmd
+}
+
+// openBackupStorage opens the project's file storage, refusing Ent projects
+func openBackupStorage(dataDir string) (*storage.FileBackend, error) {
+	if config, err := LoadConfig(""); err == nil && config.Features.Storage.Type == "ent" {
+		return nil, fmt.Errorf("export and import only support file storage; this project uses ent")
+	}
+	return storage.NewFileBackend(dataDir)
+}
+
+func runExport(ctx context.Context, dataDir, output string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	resourceTypes, err := discoverResources()
+	if err != nil {
+		return fmt.Errorf("failed to discover resources: %w", err)
+	}
+	if len(resourceTypes) == 0 {
+		return fmt.Errorf("no resources found in pkg/resources")
+	}
+	sort.Strings(resourceTypes)
+
+	backend, err := openBackupStorage(dataDir)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	manifest := backupManifest{
+		FabricaVersion: version,
+		ExportedAt:     time.Now().UTC(),
+		ResourceTypes:  resourceTypes,
+		Counts:         make(map[string]int, len(resourceTypes)),
+	}
+
+	// List everything up front so the manifest can lead the archive
+	uidsByType := make(map[string][]string, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		uids, err := backend.List(ctx, resourceType)
+		if err != nil {
+			return fmt.Errorf("failed to list %s resources: %w", resourceType, err)
+		}
+		sort.Strings(uids)
+		uidsByType[resourceType] = uids
+		manifest.Counts[resourceType] = len(uids)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeTarEntry(tw, backupManifestName, manifestData); err != nil {
+		return err
+	}
+
+	for _, resourceType := range resourceTypes {
+		for _, uid := range uidsByType[resourceType] {
+			data, err := backend.Load(ctx, resourceType, uid)
+			if err != nil {
+				return fmt.Errorf("failed to load %s %s: %w", resourceType, uid, err)
+			}
+			if err := writeTarEntry(tw, path.Join(resourceType, uid+".json"), data); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("  📦 %s: %d\n", resourceType, manifest.Counts[resourceType])
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	fmt.Printf("✅ Exported %d resource types to %s\n", len(resourceTypes), output)
+	return nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func runImport(ctx context.Context, archive, dataDir string, overwrite bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	backend, err := openBackupStorage(dataDir)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	file, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", archive, err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	var manifest *backupManifest
+	restored, skipped := 0, 0
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", archive, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+
+		// The manifest is the first entry; refuse archives without one
+		// before anything is written to storage
+		if manifest == nil {
+			if header.Name != backupManifestName {
+				return fmt.Errorf("%s has no %s; was it created by 'fabrica export'?", archive, backupManifestName)
+			}
+			manifest = &backupManifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return fmt.Errorf("invalid %s: %w", backupManifestName, err)
+			}
+			if manifest.FabricaVersion != version {
+				fmt.Printf("⚠️  Backup was exported by Fabrica %s (this is %s)\n", manifest.FabricaVersion, version)
+			}
+			continue
+		}
+
+		resourceType, name := path.Split(header.Name)
+		resourceType = strings.TrimSuffix(resourceType, "/")
+		uid := strings.TrimSuffix(name, ".json")
+		if resourceType == "" || strings.Contains(resourceType, "/") || uid == name {
+			return fmt.Errorf("unexpected archive entry %q", header.Name)
+		}
+
+		if !overwrite {
+			exists, err := backend.Exists(ctx, resourceType, uid)
+			if err != nil {
+				return fmt.Errorf("failed to check %s %s: %w", resourceType, uid, err)
+			}
+			if exists {
+				skipped++
+				continue
+			}
+		}
+
+		if err := backend.Save(ctx, resourceType, uid, data); err != nil {
+			return fmt.Errorf("failed to restore %s %s: %w", resourceType, uid, err)
+		}
+		restored++
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("%s is empty", archive)
+	}
+
+	fmt.Printf("✅ Restored %d resources from %s", restored, archive)
+	if skipped > 0 {
+		fmt.Printf(" (%d existing skipped; use --overwrite to replace them)", skipped)
+	}
+	fmt.Println()
+	return nil
+}
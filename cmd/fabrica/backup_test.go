@@ -0,0 +1,155 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/storage"
+)
+
+// backupProjectFiles declares one resource type for export to discover
+var backupProjectFiles = map[string]string{
+	"pkg/resources/device/device.go": `package device
+
+import "github.com/openchami/fabrica/pkg/resource"
+
+type Device struct {
+	resource.Resource
+}
+`,
+}
+
+func saveDevices(t *testing.T, dataDir string, uids ...string) {
+	t.Helper()
+	backend, err := storage.NewFileBackend(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	for _, uid := range uids {
+		data := []byte(`{"metadata":{"uid":"` + uid + `","name":"` + uid + `"}}`)
+		if err := backend.Save(context.Background(), "Device", uid, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func loadDevice(t *testing.T, dataDir, uid string) string {
+	t.Helper()
+	backend, err := storage.NewFileBackend(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	data, err := backend.Load(context.Background(), "Device", uid)
+	if err != nil {
+		t.Fatalf("Load %s: %v", uid, err)
+	}
+	return string(data)
+}
+
+func TestExportImport(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, backupProjectFiles)
+	chdir(t, dir)
+	saveDevices(t, "data", "dev-00000001", "dev-00000002")
+
+	if err := runCommand(newExportCommand(), "--data-dir", "data", "--output", "backup.tar.gz"); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	// Restore into empty storage
+	if err := runCommand(newImportCommand(), "backup.tar.gz", "--data-dir", "restored"); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	for _, uid := range []string{"dev-00000001", "dev-00000002"} {
+		if got := loadDevice(t, "restored", uid); !strings.Contains(got, `"name":"`+uid+`"`) {
+			t.Errorf("restored %s = %s", uid, got)
+		}
+	}
+
+	// Existing resources are kept unless --overwrite is given
+	changed := []byte(`{"metadata":{"uid":"dev-00000001","name":"changed"}}`)
+	backend, err := storage.NewFileBackend("restored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(context.Background(), "Device", "dev-00000001", changed); err != nil {
+		t.Fatal(err)
+	}
+	backend.Close()
+
+	if err := runCommand(newImportCommand(), "backup.tar.gz", "--data-dir", "restored"); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if got := loadDevice(t, "restored", "dev-00000001"); !strings.Contains(got, `"name":"changed"`) {
+		t.Errorf("import without --overwrite replaced dev-00000001: %s", got)
+	}
+	if err := runCommand(newImportCommand(), "backup.tar.gz", "--data-dir", "restored", "--overwrite"); err != nil {
+		t.Fatalf("import --overwrite failed: %v", err)
+	}
+	if got := loadDevice(t, "restored", "dev-00000001"); !strings.Contains(got, `"name":"dev-00000001"`) {
+		t.Errorf("import --overwrite kept dev-00000001: %s", got)
+	}
+}
+
+func TestImport_RejectsForeignArchives(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	if err := runCommand(newImportCommand()); err == nil {
+		t.Error("import without an archive succeeded")
+	}
+
+	// An archive without a manifest first
+	file, err := os.Create("other.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	if err := writeTarEntry(tw, "Device/dev-00000001.json", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+	file.Close()
+
+	err = runCommand(newImportCommand(), "other.tar.gz", "--data-dir", "data")
+	if err == nil || !strings.Contains(err.Error(), "has no manifest.json") {
+		t.Errorf("import of an archive without a manifest = %v", err)
+	}
+	backend, err := storage.NewFileBackend("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	if uids, _ := backend.List(context.Background(), "Device"); len(uids) != 0 {
+		t.Errorf("import wrote resources from a rejected archive: %v", uids)
+	}
+}
+
+func TestExport_Errors(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	err := runCommand(newExportCommand(), "--data-dir", "data")
+	if err == nil || !strings.Contains(err.Error(), "no resources found") {
+		t.Errorf("export without resources = %v", err)
+	}
+
+	writeFiles(t, dir, backupProjectFiles)
+	writeFiles(t, dir, map[string]string{ConfigFileName: "features:\n  storage:\n    enabled: true\n    type: ent\n"})
+	err = runCommand(newExportCommand(), "--data-dir", "data")
+	if err == nil || !strings.Contains(err.Error(), "only support file storage") {
+		t.Errorf("export from an Ent project = %v", err)
+	}
+}
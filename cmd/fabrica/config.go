@@ -5,9 +5,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,10 +18,20 @@ import (
 
 const ConfigFileName = ".fabrica.yaml"
 
+// CurrentConfigSchemaVersion is the .fabrica.yaml schema this Fabrica writes.
+// Bump it, and register a migration in configMigrations, whenever a change
+// would break or silently alter older configs.
+const CurrentConfigSchemaVersion = 1
+
 // FabricaConfig represents the complete configuration for a Fabrica project.
 // This is stored in .fabrica.yaml in the project root.
 type FabricaConfig struct {
+	// SchemaVersion is the config schema the file was written for; configs
+	// from before versioning have none and are treated as version 0
+	SchemaVersion int `yaml:"version"`
+
 	Project    ProjectConfig    `yaml:"project"`
+	API        APIConfig        `yaml:"api,omitempty"`
 	Features   FeaturesConfig   `yaml:"features"`
 	Generation GenerationConfig `yaml:"generation"`
 }
@@ -31,6 +44,14 @@ type ProjectConfig struct {
 	Created     time.Time `yaml:"created"`
 }
 
+// APIConfig controls the wire format of the generated API.
+type APIConfig struct {
+	// FieldNaming is the JSON field naming policy: camel (default, the json
+	// tags as written) or snake, which the server, client, OpenAPI spec and
+	// CLI examples all convert field names to
+	FieldNaming string `yaml:"field_naming,omitempty"`
+}
+
 // FeaturesConfig defines which features are enabled for the project.
 type FeaturesConfig struct {
 	Validation     ValidationConfig     `yaml:"validation"`
@@ -40,6 +61,7 @@ type FeaturesConfig struct {
 	Auth           AuthConfig           `yaml:"auth"`
 	Storage        StorageConfig        `yaml:"storage"`
 	Metrics        MetricsConfig        `yaml:"metrics,omitempty"`
+	Tracing        TracingConfig        `yaml:"tracing,omitempty"`
 	Reconciliation ReconciliationConfig `yaml:"reconciliation,omitempty"`
 }
 
@@ -47,18 +69,21 @@ type FeaturesConfig struct {
 type ValidationConfig struct {
 	Enabled bool   `yaml:"enabled"`
 	Mode    string `yaml:"mode"` // strict, warn, disabled
+
+	// References controls ref:"Kind" checks: enforce (default), warn, disabled
+	References string `yaml:"references,omitempty"`
 }
 
 // EventsConfig controls CloudEvents integration.
 type EventsConfig struct {
 	Enabled bool   `yaml:"enabled"`
-	BusType string `yaml:"bus_type"` // memory, nats, kafka
+	BusType string `yaml:"bus_type"` // memory, nats, kafka, noop
 }
 
 // ConditionalConfig controls ETag and conditional request handling.
 type ConditionalConfig struct {
 	Enabled       bool   `yaml:"enabled"`
-	ETagAlgorithm string `yaml:"etag_algorithm"` // sha256, md5
+	ETagAlgorithm string `yaml:"etag_algorithm"` // sha256, md5, xxhash
 }
 
 // VersioningConfig controls API versioning.
@@ -87,11 +112,21 @@ type MetricsConfig struct {
 	Provider string `yaml:"provider,omitempty"` // prometheus, datadog
 }
 
+// TracingConfig controls OpenTelemetry tracing. Only projects with tracing
+// enabled import the OpenTelemetry SDK.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
 // ReconciliationConfig controls reconciliation framework.
 type ReconciliationConfig struct {
 	Enabled      bool `yaml:"enabled"`
 	WorkerCount  int  `yaml:"worker_count,omitempty"`  // Number of reconciler workers (default: 5)
 	RequeueDelay int  `yaml:"requeue_delay,omitempty"` // Default requeue delay in minutes (default: 5)
+
+	// GenerationFilter registers generated reconcilers with
+	// GenerationChangedPredicate, so status-only updates do not trigger them
+	GenerationFilter bool `yaml:"generation_filter,omitempty"`
 }
 
 // GenerationConfig controls what gets generated.
@@ -103,6 +138,10 @@ type GenerationConfig struct {
 	Events         bool `yaml:"events"`
 	Middleware     bool `yaml:"middleware"`
 	Reconciliation bool `yaml:"reconciliation"`
+
+	// TemplatesDir holds project-local templates that override the embedded
+	// ones by relative path (default: templates)
+	TemplatesDir string `yaml:"templates_dir,omitempty"`
 }
 
 // LoadConfig reads .fabrica.yaml from the specified directory.
@@ -150,78 +189,96 @@ func SaveConfig(targetDir string, config *FabricaConfig) error {
 	return nil
 }
 
-// ValidateConfig validates all configuration fields.
+// ConfigError is a setting in .fabrica.yaml that ValidateConfig rejects
+type ConfigError struct {
+	Path    string // dotted key path, e.g. features.events.bus_type
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidateConfig validates all configuration fields. Every invalid setting
+// is reported, as a *ConfigError, joined with errors.Join.
 func ValidateConfig(config *FabricaConfig) error {
+	var errs []error
+	fail := func(path, format string, args ...interface{}) {
+		errs = append(errs, &ConfigError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	oneOf := func(path, value string, allowed ...string) {
+		if !slices.Contains(allowed, value) {
+			fail(path, "invalid value %q (must be one of: %s)", value, strings.Join(allowed, ", "))
+		}
+	}
+
+	features := &config.Features
+
+	if config.SchemaVersion > CurrentConfigSchemaVersion {
+		fail("version", "schema version %d is newer than this Fabrica supports (%d)", config.SchemaVersion, CurrentConfigSchemaVersion)
+	}
+
 	// Validate project fields
 	if config.Project.Name == "" {
-		return fmt.Errorf("project.name is required")
+		fail("project.name", "is required")
 	}
 	if config.Project.Module == "" {
-		return fmt.Errorf("project.module is required")
+		fail("project.module", "is required")
+	}
+
+	if config.API.FieldNaming != "" {
+		oneOf("api.field_naming", config.API.FieldNaming, "camel", "snake")
 	}
 
-	// Validate validation mode
-	validModes := map[string]bool{"strict": true, "warn": true, "disabled": true}
-	if config.Features.Validation.Mode != "" && !validModes[config.Features.Validation.Mode] {
-		return fmt.Errorf("invalid validation.mode: %s (must be 'strict', 'warn', or 'disabled')",
-			config.Features.Validation.Mode)
+	if features.Validation.Mode != "" {
+		oneOf("features.validation.mode", features.Validation.Mode, "strict", "warn", "disabled")
 	}
 	// Sync enabled flag with mode
-	if config.Features.Validation.Mode == "disabled" {
-		config.Features.Validation.Enabled = false
+	if features.Validation.Mode == "disabled" {
+		features.Validation.Enabled = false
+	}
+	if features.Validation.References != "" {
+		oneOf("features.validation.references", features.Validation.References, "enforce", "warn", "disabled")
 	}
 
-	// Validate event bus type
-	if config.Features.Events.Enabled {
-		validBusTypes := map[string]bool{"memory": true, "nats": true, "kafka": true}
-		if !validBusTypes[config.Features.Events.BusType] {
-			return fmt.Errorf("invalid events.bus_type: %s (must be 'memory', 'nats', or 'kafka')",
-				config.Features.Events.BusType)
-		}
+	if features.Events.Enabled {
+		oneOf("features.events.bus_type", features.Events.BusType, "memory", "nats", "kafka", "noop")
 	}
 
-	// Validate ETag algorithm
-	if config.Features.Conditional.Enabled {
-		validAlgos := map[string]bool{"sha256": true, "md5": true}
-		if config.Features.Conditional.ETagAlgorithm != "" && !validAlgos[config.Features.Conditional.ETagAlgorithm] {
-			return fmt.Errorf("invalid conditional.etag_algorithm: %s (must be 'sha256' or 'md5')",
-				config.Features.Conditional.ETagAlgorithm)
-		}
+	// Generated handlers use the ETag algorithm even when the conditional
+	// feature is disabled
+	if features.Conditional.ETagAlgorithm != "" {
+		oneOf("features.conditional.etag_algorithm", features.Conditional.ETagAlgorithm, "sha256", "md5", "xxhash")
 	}
 
-	// Validate versioning strategy
-	if config.Features.Versioning.Enabled {
-		validStrategies := map[string]bool{"header": true, "url": true, "both": true}
-		if !validStrategies[config.Features.Versioning.Strategy] {
-			return fmt.Errorf("invalid versioning.strategy: %s (must be 'header', 'url', or 'both')",
-				config.Features.Versioning.Strategy)
+	if features.Versioning.Enabled {
+		oneOf("features.versioning.strategy", features.Versioning.Strategy, "header", "url", "both")
+		if features.Versioning.DefaultVersion == "" {
+			fail("features.versioning.default_version", "is required when versioning is enabled")
 		}
 	}
 
-	// Validate storage type
-	if config.Features.Storage.Enabled {
-		validTypes := map[string]bool{"file": true, "ent": true}
-		if !validTypes[config.Features.Storage.Type] {
-			return fmt.Errorf("invalid storage.type: %s (must be 'file' or 'ent')",
-				config.Features.Storage.Type)
+	if features.Storage.Enabled {
+		oneOf("features.storage.type", features.Storage.Type, "file", "ent")
+		if features.Storage.Type == "ent" && features.Storage.DBDriver != "" {
+			oneOf("features.storage.db_driver", features.Storage.DBDriver, "postgres", "mysql", "sqlite", "sqlite3")
 		}
+	}
 
-		// Validate DB driver if using ent
-		if config.Features.Storage.Type == "ent" && config.Features.Storage.DBDriver != "" {
-			validDrivers := map[string]bool{"postgres": true, "mysql": true, "sqlite": true, "sqlite3": true}
-			if !validDrivers[config.Features.Storage.DBDriver] {
-				return fmt.Errorf("invalid storage.db_driver: %s (must be 'postgres', 'mysql', 'sqlite', or 'sqlite3')",
-					config.Features.Storage.DBDriver)
-			}
-		}
+	if features.Reconciliation.WorkerCount < 0 {
+		fail("features.reconciliation.worker_count", "must not be negative, got %d", features.Reconciliation.WorkerCount)
+	}
+	if features.Reconciliation.RequeueDelay < 0 {
+		fail("features.reconciliation.requeue_delay", "must not be negative, got %d", features.Reconciliation.RequeueDelay)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // NewDefaultConfig creates a new config with sensible defaults.
 func NewDefaultConfig(name, module string) *FabricaConfig {
 	return &FabricaConfig{
+		SchemaVersion: CurrentConfigSchemaVersion,
 		Project: ProjectConfig{
 			Name:    name,
 			Module:  module,
@@ -229,8 +286,9 @@ func NewDefaultConfig(name, module string) *FabricaConfig {
 		},
 		Features: FeaturesConfig{
 			Validation: ValidationConfig{
-				Enabled: true,
-				Mode:    "strict",
+				Enabled:    true,
+				Mode:       "strict",
+				References: "enforce",
 			},
 			Events: EventsConfig{
 				Enabled: false,
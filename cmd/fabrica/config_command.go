@@ -0,0 +1,269 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configProblem is one issue found in .fabrica.yaml
+type configProblem struct {
+	Path    string // dotted key path, e.g. features.events.bus_type
+	Message string
+	Warning bool // warnings do not fail validation
+}
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and validate .fabrica.yaml",
+	}
+	cmd.AddCommand(newConfigValidateCommand())
+	cmd.AddCommand(newConfigShowCommand())
+	cmd.AddCommand(newConfigMigrateCommand())
+	return cmd
+}
+
+func newConfigValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check .fabrica.yaml for mistakes before generating",
+		Long: `Load .fabrica.yaml from the current directory and report every problem
+found, with the key path of the offending setting.
+
+Errors (invalid values, unknown keys, missing required settings) make the
+command fail. Warnings flag combinations that are valid but probably not
+what you meant, such as reconciliation enabled without events.
+
+Example:
+  fabrica config validate
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			problems, err := checkConfigFile("")
+			if err != nil {
+				return err
+			}
+
+			errs := 0
+			for _, problem := range problems {
+				if problem.Warning {
+					fmt.Printf("  ⚠️  %s: %s\n", problem.Path, problem.Message)
+					continue
+				}
+				errs++
+				fmt.Printf("  ❌ %s: %s\n", problem.Path, problem.Message)
+			}
+			if errs > 0 {
+				return fmt.Errorf("%s has %d error(s)", ConfigFileName, errs)
+			}
+
+			if len(problems) == 0 {
+				fmt.Printf("✅ %s is valid\n", ConfigFileName)
+			} else {
+				fmt.Printf("✅ %s is valid, with %d warning(s)\n", ConfigFileName, len(problems))
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration with defaults applied",
+		Long: `Print .fabrica.yaml from the current directory as YAML, with every setting
+left empty replaced by the default that generation uses.
+
+Example:
+  fabrica config show
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := LoadConfig("")
+			if err != nil {
+				return err
+			}
+			applyConfigDefaults(config)
+
+			data, err := yaml.Marshal(config)
+			if err != nil {
+				return fmt.Errorf("failed to marshal config: %w", err)
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+}
+
+// checkConfigFile loads .fabrica.yaml from dir (the current directory if
+// empty) and returns every problem found in it, errors first. The error is
+// only set if the file cannot be read or parsed at all.
+func checkConfigFile(dir string) ([]configProblem, error) {
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ConfigFileName, err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ConfigFileName, err)
+	}
+
+	var problems []configProblem
+	if len(root.Content) > 0 {
+		problems = unknownConfigKeys(root.Content[0], reflect.TypeOf(FabricaConfig{}), "")
+	}
+
+	config, err := LoadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, checkConfig(config, dir)...)
+
+	// Errors first, keeping each group in check order
+	sorted := make([]configProblem, 0, len(problems))
+	for _, warnings := range []bool{false, true} {
+		for _, problem := range problems {
+			if problem.Warning == warnings {
+				sorted = append(sorted, problem)
+			}
+		}
+	}
+	return sorted, nil
+}
+
+// checkConfig returns the problems ValidateConfig rejects, with full key
+// paths, followed by cross-checks between settings. Relative paths in the
+// config are resolved against dir.
+func checkConfig(config *FabricaConfig, dir string) []configProblem {
+	var problems []configProblem
+	warn := func(path, format string, args ...interface{}) {
+		problems = append(problems, configProblem{Path: path, Message: fmt.Sprintf(format, args...), Warning: true})
+	}
+
+	// A copy: ValidateConfig turns validation off when its mode is disabled
+	features := config.Features
+
+	if err := ValidateConfig(config); err != nil {
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		for _, err := range errs {
+			var configErr *ConfigError
+			if errors.As(err, &configErr) {
+				problems = append(problems, configProblem{Path: configErr.Path, Message: configErr.Message})
+			} else {
+				problems = append(problems, configProblem{Message: err.Error()})
+			}
+		}
+	}
+
+	if config.SchemaVersion < CurrentConfigSchemaVersion {
+		warn("version", "schema version %d is out of date; run 'fabrica config migrate' to upgrade to %d", config.SchemaVersion, CurrentConfigSchemaVersion)
+	}
+
+	// Cross-checks: valid on their own, but probably not intended together
+	if features.Reconciliation.Enabled && !features.Events.Enabled {
+		warn("features.reconciliation.enabled", "reconcilers are triggered by resource events, but features.events.enabled is false")
+	}
+	if features.Validation.Enabled && features.Validation.Mode == "disabled" {
+		warn("features.validation.enabled", "is true but features.validation.mode is \"disabled\"; validation will be off")
+	}
+	if config.Generation.Storage && !features.Storage.Enabled {
+		warn("generation.storage", "is true but features.storage.enabled is false")
+	}
+	if config.Generation.Events && !features.Events.Enabled {
+		warn("generation.events", "is true but features.events.enabled is false")
+	}
+	if config.Generation.Reconciliation && !features.Reconciliation.Enabled {
+		warn("generation.reconciliation", "is true but features.reconciliation.enabled is false")
+	}
+	if templatesDir := config.Generation.TemplatesDir; templatesDir != "" {
+		if !filepath.IsAbs(templatesDir) {
+			templatesDir = filepath.Join(dir, templatesDir)
+		}
+		if info, err := os.Stat(templatesDir); err != nil || !info.IsDir() {
+			warn("generation.templates_dir", "directory %q does not exist; embedded templates will be used", config.Generation.TemplatesDir)
+		}
+	}
+
+	return problems
+}
+
+// unknownConfigKeys reports mapping keys in node that have no matching yaml
+// field in t, recursing into nested structs. Misspelled keys are otherwise
+// silently ignored.
+func unknownConfigKeys(node *yaml.Node, t reflect.Type, prefix string) []configProblem {
+	if node.Kind != yaml.MappingNode || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+
+	var problems []configProblem
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := prefix + key.Value
+		fieldType, ok := fields[key.Value]
+		if !ok {
+			problems = append(problems, configProblem{Path: path, Message: fmt.Sprintf("unknown key (line %d)", key.Line)})
+			continue
+		}
+		if fieldType != reflect.TypeOf(time.Time{}) {
+			problems = append(problems, unknownConfigKeys(value, fieldType, path+".")...)
+		}
+	}
+	return problems
+}
+
+// applyConfigDefaults fills settings left empty with the values generation
+// falls back to, so the config shows what will actually be used.
+func applyConfigDefaults(config *FabricaConfig) {
+	setDefault := func(value *string, def string) {
+		if *value == "" {
+			*value = def
+		}
+	}
+
+	features := &config.Features
+	setDefault(&features.Validation.Mode, "strict")
+	setDefault(&features.Events.BusType, "memory")
+	setDefault(&features.Conditional.ETagAlgorithm, "sha256")
+	setDefault(&features.Versioning.Strategy, "header")
+	setDefault(&features.Versioning.DefaultVersion, "v1")
+	setDefault(&features.Storage.Type, "file")
+	if features.Storage.Type == "ent" {
+		setDefault(&features.Storage.DBDriver, "sqlite")
+	}
+	if features.Reconciliation.WorkerCount == 0 {
+		features.Reconciliation.WorkerCount = 5
+	}
+	if features.Reconciliation.RequeueDelay == 0 {
+		features.Reconciliation.RequeueDelay = 5
+	}
+	setDefault(&config.Generation.TemplatesDir, "templates")
+}
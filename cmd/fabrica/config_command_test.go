@@ -0,0 +1,85 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateConfig_ReportsEverySetting(t *testing.T) {
+	config := NewDefaultConfig("app", "")
+	config.API.FieldNaming = "kebab"
+	config.Features.Events.Enabled = true
+	config.Features.Events.BusType = "carrier-pigeon"
+	config.Features.Reconciliation.WorkerCount = -1
+
+	err := ValidateConfig(config)
+	if err == nil {
+		t.Fatal("ValidateConfig accepted an invalid config")
+	}
+	var paths []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var configErr *ConfigError
+		if !errors.As(err, &configErr) {
+			t.Fatalf("%v is not a *ConfigError", err)
+		}
+		paths = append(paths, configErr.Path)
+	}
+	want := []string{"project.module", "api.field_naming", "features.events.bus_type", "features.reconciliation.worker_count"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	if err := ValidateConfig(NewDefaultConfig("app", "example.com/app")); err != nil {
+		t.Errorf("ValidateConfig rejected the default config: %v", err)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	config := NewDefaultConfig("app", "example.com/app")
+	config.Features.Storage.Enabled = true
+	config.Features.Storage.Type = "sql"
+	config.Features.Validation.Mode = "disabled"
+	config.Features.Reconciliation.Enabled = true
+	config.Features.Events.Enabled = false
+	config.Generation.TemplatesDir = "missing"
+
+	got := checkConfig(config, t.TempDir())
+	want := []configProblem{
+		{Path: "features.storage.type", Message: `invalid value "sql" (must be one of: file, ent)`},
+		{Path: "features.reconciliation.enabled", Message: "reconcilers are triggered by resource events, but features.events.enabled is false", Warning: true},
+		{Path: "features.validation.enabled", Message: `is true but features.validation.mode is "disabled"; validation will be off`, Warning: true},
+		{Path: "generation.templates_dir", Message: `directory "missing" does not exist; embedded templates will be used`, Warning: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkConfig =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCheckConfigFile_UnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	data := "version: 1\nproject:\n  name: app\n  module: example.com/app\n  nmae: typo\n"
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := checkConfigFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errs []string
+	for _, problem := range problems {
+		if !problem.Warning {
+			errs = append(errs, problem.Path+": "+problem.Message)
+		}
+	}
+	if want := []string{"project.nmae: unknown key (line 5)"}; !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors = %v, want %v", errs, want)
+	}
+}
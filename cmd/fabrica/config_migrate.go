@@ -0,0 +1,213 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configMigrationStep identifies a migration by the schema versions it
+// converts between
+type configMigrationStep struct {
+	From int
+	To   int
+}
+
+// configMigration rewrites a decoded .fabrica.yaml document from one schema
+// version to the next. It works on the raw document rather than
+// FabricaConfig, so it can read keys the current schema no longer has.
+type configMigration struct {
+	Description string
+	Apply       func(doc map[string]interface{}) error
+}
+
+// configMigrations is the registry of schema migrations. Each version has at
+// most one migration out of it, and the chain must reach
+// CurrentConfigSchemaVersion.
+var configMigrations = map[configMigrationStep]configMigration{
+	{From: 0, To: 1}: {
+		Description: "set versioning.default_version and storage.enabled, which older configs left implicit",
+		Apply:       migrateConfigV0ToV1,
+	},
+}
+
+func newConfigMigrateCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade .fabrica.yaml to the current config schema",
+		Long: `Upgrade a .fabrica.yaml written by an older Fabrica to the schema this
+version reads, by applying each migration from the file's version (the
+top-level 'version' key; absent means 0) to the current one.
+
+The original file is kept as .fabrica.yaml.v<N>.bak, where N is the version
+it was written for. Keys the current schema does not know are dropped.
+
+Examples:
+  fabrica config migrate            # Upgrade in place
+  fabrica config migrate --dry-run  # Show the migrations without writing
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			return runConfigMigrate(dir, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the migrations that would run without changing any file")
+
+	return cmd
+}
+
+// runConfigMigrate migrates .fabrica.yaml in dir to CurrentConfigSchemaVersion
+func runConfigMigrate(dir string, dryRun bool) error {
+	configPath := filepath.Join(dir, ConfigFileName)
+	original, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", ConfigFileName, err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(original, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", ConfigFileName, err)
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+
+	from, err := configSchemaVersion(doc)
+	if err != nil {
+		return err
+	}
+	if from == CurrentConfigSchemaVersion {
+		fmt.Printf("✅ %s is already at schema version %d\n", ConfigFileName, from)
+		return nil
+	}
+	if from > CurrentConfigSchemaVersion {
+		return fmt.Errorf("%s has schema version %d, newer than this Fabrica supports (%d); upgrade Fabrica instead",
+			ConfigFileName, from, CurrentConfigSchemaVersion)
+	}
+
+	steps, err := planConfigMigrations(from, CurrentConfigSchemaVersion)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔄 Migrating %s from schema version %d to %d\n", ConfigFileName, from, CurrentConfigSchemaVersion)
+	for _, step := range steps {
+		migration := configMigrations[step]
+		fmt.Printf("  %d → %d: %s\n", step.From, step.To, migration.Description)
+		if err := migration.Apply(doc); err != nil {
+			return fmt.Errorf("migration %d → %d failed: %w", step.From, step.To, err)
+		}
+	}
+	doc["version"] = CurrentConfigSchemaVersion
+
+	// Round-trip through FabricaConfig so the result is checked against the
+	// current schema before anything is written
+	migrated, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	var config FabricaConfig
+	if err := yaml.Unmarshal(migrated, &config); err != nil {
+		return fmt.Errorf("migrated config does not match the current schema: %w", err)
+	}
+	if err := ValidateConfig(&config); err != nil {
+		return fmt.Errorf("migrated config is invalid: %w; fix %s and run 'fabrica config migrate' again", err, ConfigFileName)
+	}
+
+	if dryRun {
+		fmt.Println("(dry run: no files written)")
+		return nil
+	}
+
+	backupPath := fmt.Sprintf("%s.v%d.bak", configPath, from)
+	if err := os.WriteFile(backupPath, original, 0644); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", ConfigFileName, err)
+	}
+	if err := SaveConfig(dir, &config); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Migrated %s (original saved as %s)\n", ConfigFileName, filepath.Base(backupPath))
+	return nil
+}
+
+// configSchemaVersion returns the top-level version key of a decoded config,
+// or 0 if it has none
+func configSchemaVersion(doc map[string]interface{}) (int, error) {
+	raw, ok := doc["version"]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	version, ok := raw.(int)
+	if !ok || version < 0 {
+		return 0, fmt.Errorf("invalid version in %s: %v (must be a non-negative integer)", ConfigFileName, raw)
+	}
+	return version, nil
+}
+
+// planConfigMigrations returns the chain of registered migrations leading
+// from one schema version to another
+func planConfigMigrations(from, to int) ([]configMigrationStep, error) {
+	var steps []configMigrationStep
+	for version := from; version != to; {
+		next, found := configMigrationStep{}, false
+		for step := range configMigrations {
+			if step.From == version && step.To > version && step.To <= to {
+				next, found = step, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no migration registered from config schema version %d", version)
+		}
+		steps = append(steps, next)
+		version = next.To
+	}
+	return steps, nil
+}
+
+// migrateConfigV0ToV1 fills in settings that configs written before schema
+// versioning could leave out, but which the current schema does not default:
+// versioning.default_version (required when versioning is enabled) and
+// storage.enabled (storage was implied by storage.type)
+func migrateConfigV0ToV1(doc map[string]interface{}) error {
+	features := configSection(doc, "features")
+	if features == nil {
+		return nil
+	}
+
+	if versioning := configSection(features, "versioning"); versioning != nil {
+		if enabled, _ := versioning["enabled"].(bool); enabled {
+			if v, _ := versioning["default_version"].(string); v == "" {
+				versioning["default_version"] = "v1"
+			}
+		}
+	}
+
+	if storage := configSection(features, "storage"); storage != nil {
+		if _, ok := storage["enabled"]; !ok && storage["type"] != nil {
+			storage["enabled"] = true
+		}
+	}
+	return nil
+}
+
+// configSection returns the mapping stored under key in a decoded config, or
+// nil if there is none
+func configSection(doc map[string]interface{}, key string) map[string]interface{} {
+	section, _ := doc[key].(map[string]interface{})
+	return section
+}
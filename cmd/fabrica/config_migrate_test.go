@@ -0,0 +1,121 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// v0Config predates schema versioning: no version key, versioning without a
+// default version, and storage implied by its type
+const v0Config = `project:
+  name: app
+  module: example.com/app
+features:
+  versioning:
+    enabled: true
+    strategy: header
+  storage:
+    type: file
+`
+
+func TestConfigMigrateCommand(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{ConfigFileName: v0Config})
+	chdir(t, dir)
+
+	if err := runCommand(newConfigMigrateCommand()); err != nil {
+		t.Fatalf("config migrate failed: %v", err)
+	}
+
+	config, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.SchemaVersion != CurrentConfigSchemaVersion {
+		t.Errorf("version = %d, want %d", config.SchemaVersion, CurrentConfigSchemaVersion)
+	}
+	if config.Features.Versioning.DefaultVersion != "v1" || !config.Features.Storage.Enabled {
+		t.Errorf("migrated features = %+v", config.Features)
+	}
+
+	backup, err := os.ReadFile(filepath.Join(dir, ConfigFileName+".v0.bak"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(backup) != v0Config {
+		t.Errorf("backup = %q, want the original", backup)
+	}
+
+	// A second run has nothing to do
+	if err := runConfigMigrate(dir, false); err != nil {
+		t.Errorf("config migrate of a current config = %v", err)
+	}
+}
+
+func TestRunConfigMigrate_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{ConfigFileName: v0Config})
+
+	if err := runConfigMigrate(dir, true); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != v0Config {
+		t.Errorf("dry run changed %s:\n%s", ConfigFileName, data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ConfigFileName+".v0.bak")); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote a backup: %v", err)
+	}
+}
+
+func TestRunConfigMigrate_Errors(t *testing.T) {
+	tests := []struct {
+		name, config, want string
+	}{
+		{"newer", "version: 99\nproject:\n  name: app\n  module: example.com/app\n", "upgrade Fabrica instead"},
+		{"invalid version", "version: one\n", "must be a non-negative integer"},
+		{"invalid result", "project:\n  name: app\n", "migrated config is invalid: project.module: is required"},
+		{"not YAML", "project: [", "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{ConfigFileName: tt.config})
+
+			err := runConfigMigrate(dir, false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("runConfigMigrate = %v, want an error containing %q", err, tt.want)
+			}
+			data, _ := os.ReadFile(filepath.Join(dir, ConfigFileName))
+			if string(data) != tt.config {
+				t.Errorf("failed migration changed %s:\n%s", ConfigFileName, data)
+			}
+		})
+	}
+
+	if err := runConfigMigrate(t.TempDir(), false); err == nil {
+		t.Error("runConfigMigrate without a config succeeded")
+	}
+}
+
+func TestPlanConfigMigrations(t *testing.T) {
+	steps, err := planConfigMigrations(0, CurrentConfigSchemaVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) == 0 || steps[0].From != 0 || steps[len(steps)-1].To != CurrentConfigSchemaVersion {
+		t.Errorf("steps = %v, want a chain from 0 to %d", steps, CurrentConfigSchemaVersion)
+	}
+	if _, err := planConfigMigrations(CurrentConfigSchemaVersion, CurrentConfigSchemaVersion+1); err == nil {
+		t.Error("planned a migration that is not registered")
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate documentation about the project's resources and API",
+	}
+	cmd.AddCommand(newDocsGraphCommand())
+	cmd.AddCommand(newDocsDiffCommand())
+	return cmd
+}
+
+func newDocsGraphCommand() *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Draw how resources reference each other",
+		Long: `Analyze the resource definitions in pkg/resources and draw the references
+between them as a Graphviz (dot) or Mermaid diagram.
+
+A spec or status field references another resource when:
+  - it is tagged ref:"Kind" (ref:"Kind,owner" marks the referenced resource
+    as the owner; owner edges are drawn dashed)
+  - its name ends in UID or UIDs and the rest names a resource kind, e.g.
+    RackUID or ManagedNodeUIDs (a unique kind ending in the rest also matches,
+    so TemplateUID finds RackTemplate)
+
+Examples:
+  fabrica docs graph | dot -Tsvg > resources.svg
+  fabrica docs graph --format mermaid --output docs/resources.mmd
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if format != "dot" && format != "mermaid" {
+				return fmt.Errorf("invalid --format %q (must be dot or mermaid)", format)
+			}
+
+			graph, err := analyzeResourceGraph("pkg/resources")
+			if err != nil {
+				return err
+			}
+			if len(graph.Kinds) == 0 {
+				return fmt.Errorf("no resources found in pkg/resources/")
+			}
+
+			out := io.Writer(os.Stdout)
+			if output != "" {
+				file, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", output, err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			if format == "mermaid" {
+				graph.writeMermaid(out)
+			} else {
+				graph.writeDot(out)
+			}
+			if output != "" {
+				fmt.Printf("✅ Wrote %d resource(s) and %d reference(s) to %s\n", len(graph.Kinds), len(graph.Edges), output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "dot", "Diagram format: dot or mermaid")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the diagram to this file instead of stdout")
+	return cmd
+}
+
+// resourceGraph is the reference graph between a project's resources
+type resourceGraph struct {
+	// Kinds are the resource kinds, sorted
+	Kinds []string
+
+	// Edges are the references, sorted by source kind and field
+	Edges []resourceEdge
+}
+
+// resourceEdge is a field of one resource holding the UID of another
+type resourceEdge struct {
+	From string
+	To   string
+
+	// Field is the JSON path of the field, e.g. spec.rackUID
+	Field string
+
+	// Many is set for fields holding a list of UIDs
+	Many bool
+
+	// Owner is set for ref:"Kind,owner" fields
+	Owner bool
+}
+
+// resourceDecl is a resource type found in pkg/resources
+type resourceDecl struct {
+	kind    string
+	structs map[string]*ast.StructType // struct types declared in its package
+	fields  *ast.StructType
+}
+
+// analyzeResourceGraph parses the resource definitions under dir, like
+// discoverResources, and finds the references between them
+func analyzeResourceGraph(dir string) (*resourceGraph, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return &resourceGraph{}, nil // No resources directory yet
+	}
+
+	// Struct types per package directory, and the resources among them
+	packages := make(map[string]map[string]*ast.StructType)
+	var decls []resourceDecl
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return nil // Skip files that don't parse, as discoverResources does
+		}
+
+		pkgDir := filepath.Dir(path)
+		if packages[pkgDir] == nil {
+			packages[pkgDir] = make(map[string]*ast.StructType)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			packages[pkgDir][typeSpec.Name.Name] = structType
+			if embedsResource(structType) {
+				decls = append(decls, resourceDecl{kind: typeSpec.Name.Name, structs: packages[pkgDir], fields: structType})
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze resources: %w", err)
+	}
+
+	graph := &resourceGraph{}
+	for _, decl := range decls {
+		graph.Kinds = append(graph.Kinds, decl.kind)
+	}
+	sort.Strings(graph.Kinds)
+
+	for _, decl := range decls {
+		for _, section := range []string{"Spec", "Status"} {
+			structType := decl.localStruct(fieldType(decl.fields, section))
+			if structType == nil {
+				continue
+			}
+			prefix := strings.ToLower(section)
+			graph.Edges = append(graph.Edges, decl.references(graph.Kinds, structType, prefix, map[*ast.StructType]bool{})...)
+		}
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].Field < graph.Edges[j].Field
+	})
+	return graph, nil
+}
+
+// embedsResource reports whether a struct embeds resource.Resource
+func embedsResource(structType *ast.StructType) bool {
+	for _, field := range structType.Fields.List {
+		if len(field.Names) != 0 {
+			continue
+		}
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "resource" && sel.Sel.Name == "Resource" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldType returns the type of the named field of a struct, or nil
+func fieldType(structType *ast.StructType, name string) ast.Expr {
+	for _, field := range structType.Fields.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return field.Type
+			}
+		}
+	}
+	return nil
+}
+
+// localStruct returns the struct type expr names if it is (a pointer to) a
+// struct declared in the resource's package
+func (d resourceDecl) localStruct(expr ast.Expr) *ast.StructType {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return d.structs[ident.Name]
+	}
+	return nil
+}
+
+// references returns the reference fields of structType and the local
+// structs nested in it
+func (d resourceDecl) references(kinds []string, structType *ast.StructType, prefix string, visited map[*ast.StructType]bool) []resourceEdge {
+	if visited[structType] {
+		return nil
+	}
+	visited[structType] = true
+	defer delete(visited, structType)
+
+	var edges []resourceEdge
+	for _, field := range structType.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			if value, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(value)
+			}
+		}
+		jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		// Embedded structs are inlined into their parent
+		if len(field.Names) == 0 {
+			if nested := d.localStruct(field.Type); nested != nil {
+				edges = append(edges, d.references(kinds, nested, prefix, visited)...)
+			}
+			continue
+		}
+
+		elem, many := field.Type, false
+		if array, ok := elem.(*ast.ArrayType); ok {
+			elem, many = array.Elt, true
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			name := jsonName
+			if name == "" {
+				name = ident.Name
+			}
+			path := prefix + "." + name
+
+			if ref, ok := tag.Lookup("ref"); ok {
+				kind, options, _ := strings.Cut(ref, ",")
+				edges = append(edges, resourceEdge{From: d.kind, To: kind, Field: path, Many: many, Owner: options == "owner"})
+				continue
+			}
+			if nested := d.localStruct(elem); nested != nil {
+				edges = append(edges, d.references(kinds, nested, path, visited)...)
+				continue
+			}
+			if kind := kindForUIDField(kinds, ident.Name); kind != "" && isStringType(elem) {
+				edges = append(edges, resourceEdge{From: d.kind, To: kind, Field: path, Many: many || strings.HasSuffix(ident.Name, "UIDs")})
+			}
+		}
+	}
+	return edges
+}
+
+// isStringType reports whether expr is string or []string
+func isStringType(expr ast.Expr) bool {
+	if array, ok := expr.(*ast.ArrayType); ok {
+		expr = array.Elt
+	}
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "string"
+}
+
+// kindForUIDField returns the kind a field named <Something>UID or
+// <Something>UIDs refers to: the longest kind <Something> ends with, or else
+// the only kind ending with <Something>. It returns "" if there is none.
+func kindForUIDField(kinds []string, fieldName string) string {
+	base := strings.TrimSuffix(fieldName, "s")
+	if !strings.HasSuffix(base, "UID") || base == "UID" {
+		return ""
+	}
+	base = strings.ToLower(strings.TrimSuffix(base, "UID"))
+
+	best := ""
+	for _, kind := range kinds {
+		if strings.HasSuffix(base, strings.ToLower(kind)) && len(kind) > len(best) {
+			best = kind
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	var endsWithBase []string
+	for _, kind := range kinds {
+		if strings.HasSuffix(strings.ToLower(kind), base) {
+			endsWithBase = append(endsWithBase, kind)
+		}
+	}
+	if len(endsWithBase) == 1 {
+		return endsWithBase[0]
+	}
+	return ""
+}
+
+// writeDot writes the graph in Graphviz dot syntax
+func (g *resourceGraph) writeDot(w io.Writer) {
+	fmt.Fprintln(w, "digraph resources {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+	for _, kind := range g.Kinds {
+		fmt.Fprintf(w, "  %q;\n", kind)
+	}
+	for _, edge := range g.Edges {
+		attrs := fmt.Sprintf("label=%q", edge.label())
+		if edge.Owner {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(w, "  %q -> %q [%s];\n", edge.From, edge.To, attrs)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeMermaid writes the graph as a Mermaid flowchart
+func (g *resourceGraph) writeMermaid(w io.Writer) {
+	fmt.Fprintln(w, "graph LR")
+	for _, kind := range g.Kinds {
+		fmt.Fprintf(w, "  %s\n", kind)
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Owner {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(w, "  %s %s|%q| %s\n", edge.From, arrow, edge.label(), edge.To)
+	}
+}
+
+// label describes an edge: its field, with [] for lists and an owner prefix
+func (e resourceEdge) label() string {
+	label := e.Field
+	if e.Many {
+		label += "[]"
+	}
+	if e.Owner {
+		label = "owner: " + label
+	}
+	return label
+}
@@ -0,0 +1,259 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/codegen"
+	"github.com/spf13/cobra"
+)
+
+// openAPIDumpFile is written into cmd/server for the length of a docs diff,
+// so the generated GenerateOpenAPISpec can be run without starting a server.
+// Its name sorts last, so its init runs after the package's other inits.
+const openAPIDumpFile = "zz_fabrica_openapi_dump.go"
+
+// openAPIDumpEnv names the file the dump writes the document to. Without it
+// the dump file does nothing, should it be left behind.
+const openAPIDumpEnv = "FABRICA_OPENAPI_DUMP"
+
+const openAPIDumpCode = `// Code generated by fabrica docs diff. DO NOT EDIT.
+// This temporary file is removed when the command finishes.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+func init() {
+	path := os.Getenv("` + openAPIDumpEnv + `")
+	if path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(GenerateOpenAPISpec(), "", "  ")
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		os.Stderr.WriteString(err.Error() + "\n")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+`
+
+func newDocsDiffCommand() *cobra.Command {
+	var (
+		against string
+		format  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff --against <git-ref|file>",
+		Short: "Report the API changes since an earlier generation",
+		Long: `Compare the OpenAPI document of the generated server in the working tree
+with an earlier one, and report added and removed endpoints, changed schemas
+and breaking changes.
+
+--against is either an OpenAPI document (JSON or YAML, e.g. saved from
+/openapi.json) or a git ref. For a git ref, the ref is checked out in a
+temporary worktree and the document of the server generated there is used,
+so the generated code must be committed.
+
+Removed endpoints, schemas and required fields, type changes and fields that
+became required are breaking. The command fails if there are any, so it can
+gate CI. Run 'fabrica generate' first so the working tree is up to date.
+
+Examples:
+  fabrica docs diff --against main
+  fabrica docs diff --against v1.2.0 --format json > api-diff.json
+  fabrica docs diff --against openapi-previous.json
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if against == "" {
+				return fmt.Errorf("--against is required")
+			}
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q (must be text or json)", format)
+			}
+
+			oldDoc, err := againstOpenAPIDocument(against)
+			if err != nil {
+				return err
+			}
+			newDoc, err := generatedOpenAPIDocument(".")
+			if err != nil {
+				return err
+			}
+
+			diff, err := codegen.DiffOpenAPI(oldDoc, newDoc)
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				data, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			} else {
+				diff.WriteText(os.Stdout)
+			}
+
+			if len(diff.Breaking) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d breaking API change(s) since %s", len(diff.Breaking), against)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&against, "against", "", "Git ref or OpenAPI document to compare with")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	return cmd
+}
+
+// againstOpenAPIDocument returns the OpenAPI document to compare with: the
+// file against names, or else that of the server generated at git ref against
+func againstOpenAPIDocument(against string) ([]byte, error) {
+	if info, err := os.Stat(against); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(against)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+		}
+		return data, nil
+	}
+
+	if err := exec.Command("git", "rev-parse", "--verify", "--quiet", against+"^{commit}").Run(); err != nil {
+		return nil, fmt.Errorf("--against %q is neither a file nor a git ref", against)
+	}
+	return gitRefOpenAPIDocument(against)
+}
+
+// gitRefOpenAPIDocument checks ref out in a temporary worktree and returns
+// the OpenAPI document of the server generated there
+func gitRefOpenAPIDocument(ref string) ([]byte, error) {
+	prefix, err := gitOutput("rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "fabrica-docs-diff-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint:errcheck
+
+	worktree := filepath.Join(tmpDir, "tree")
+	if _, err := gitOutput("worktree", "add", "--detach", worktree, ref); err != nil {
+		return nil, err
+	}
+	defer exec.Command("git", "worktree", "remove", "--force", worktree).Run() // nolint:errcheck
+
+	projectDir := filepath.Join(worktree, prefix)
+	if err := absoluteReplaces(projectDir); err != nil {
+		return nil, err
+	}
+
+	data, err := generatedOpenAPIDocument(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("at %s: %w", ref, err)
+	}
+	return data, nil
+}
+
+// absoluteReplaces rewrites the local replace directives of the go.mod in
+// dir, a copy of the project elsewhere, to point where the project's own do
+func absoluteReplaces(dir string) error {
+	cmd := exec.Command("go", "mod", "edit", "-json")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	var mod struct {
+		Replace []struct {
+			Old struct{ Path, Version string }
+			New struct{ Path string }
+		}
+	}
+	if err := json.Unmarshal(out, &mod); err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	for _, replace := range mod.Replace {
+		if !strings.HasPrefix(replace.New.Path, "./") && !strings.HasPrefix(replace.New.Path, "../") {
+			continue
+		}
+		target, err := filepath.Abs(replace.New.Path)
+		if err != nil {
+			return err
+		}
+		old := replace.Old.Path
+		if replace.Old.Version != "" {
+			old += "@" + replace.Old.Version
+		}
+		edit := exec.Command("go", "mod", "edit", "-replace", old+"="+target)
+		edit.Dir = dir
+		if out, err := edit.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to update go.mod: %w: %s", err, out)
+		}
+	}
+	return nil
+}
+
+// generatedOpenAPIDocument returns the OpenAPI document of the server
+// generated in the project at dir, by running its GenerateOpenAPISpec
+func generatedOpenAPIDocument(dir string) ([]byte, error) {
+	serverDir := filepath.Join(dir, "cmd", "server")
+	if _, err := os.Stat(filepath.Join(serverDir, "openapi_generated.go")); err != nil {
+		return nil, fmt.Errorf("no generated OpenAPI spec in %s (run 'fabrica generate')", serverDir)
+	}
+
+	dumpPath := filepath.Join(serverDir, openAPIDumpFile)
+	if err := os.WriteFile(dumpPath, []byte(openAPIDumpCode), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", dumpPath, err)
+	}
+	defer os.Remove(dumpPath) // nolint:errcheck
+
+	outFile, err := os.CreateTemp("", "fabrica-openapi-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	outFile.Close()
+	defer os.Remove(outFile.Name()) // nolint:errcheck
+
+	cmd := exec.Command("go", "run", "./cmd/server")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), openAPIDumpEnv+"="+outFile.Name())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to build the OpenAPI spec: %w\n%s", err, out)
+	}
+
+	data, err := os.ReadFile(outFile.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the OpenAPI spec: %w", err)
+	}
+	return data, nil
+}
+
+// gitOutput runs git with args and returns its trimmed output
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,174 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// diffProjectFiles is the smallest project generatedOpenAPIDocument can
+// run: a server package with GenerateOpenAPISpec and no dependencies
+var diffProjectFiles = map[string]string{
+	"go.mod": "module example.com/app\n\ngo 1.23\n",
+	"cmd/server/main.go": `package main
+
+func main() {}
+`,
+	"cmd/server/openapi_generated.go": `package main
+
+func GenerateOpenAPISpec() map[string]interface{} {
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"paths": map[string]interface{}{
+			"/devices": map[string]interface{}{"get": map[string]interface{}{}},
+		},
+	}
+}
+`,
+}
+
+// openAPIDoc returns a document serving GET at each path
+func openAPIDoc(t *testing.T, paths ...string) string {
+	t.Helper()
+	doc := map[string]interface{}{"openapi": "3.0.3", "paths": map[string]interface{}{}}
+	for _, path := range paths {
+		doc["paths"].(map[string]interface{})[path] = map[string]interface{}{"get": map[string]interface{}{}}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestDocsDiffCommand_Arguments(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "--against is required"},
+		{[]string{"--against", "old.json", "--format", "xml"}, `invalid --format "xml"`},
+		{[]string{"--against", "no-such-ref"}, `--against "no-such-ref" is neither a file nor a git ref`},
+	}
+	for _, tt := range tests {
+		err := runCommand(newDocsDiffCommand(), tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("docs diff %v = %v, want an error containing %q", tt.args, err, tt.want)
+		}
+	}
+
+	// Without a generated server there is nothing to compare
+	writeFiles(t, ".", map[string]string{"old.json": openAPIDoc(t, "/devices")})
+	if err := runCommand(newDocsDiffCommand(), "--against", "old.json"); err == nil || !strings.Contains(err.Error(), "run 'fabrica generate'") {
+		t.Errorf("docs diff without a generated server = %v", err)
+	}
+}
+
+func TestDocsDiffCommand_AgainstFile(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, diffProjectFiles)
+	writeFiles(t, dir, map[string]string{
+		"same.json":    openAPIDoc(t, "/devices"),
+		"removed.json": openAPIDoc(t, "/devices", "/racks"),
+	})
+	chdir(t, dir)
+
+	if err := runCommand(newDocsDiffCommand(), "--against", "same.json"); err != nil {
+		t.Errorf("docs diff against the same API = %v", err)
+	}
+	err := runCommand(newDocsDiffCommand(), "--against", "removed.json", "--format", "json")
+	if err == nil || !strings.Contains(err.Error(), "1 breaking API change(s) since removed.json") {
+		t.Errorf("docs diff with a removed endpoint = %v", err)
+	}
+
+	// The temporary dump file is removed again
+	if _, err := os.Stat(filepath.Join("cmd", "server", openAPIDumpFile)); !os.IsNotExist(err) {
+		t.Errorf("%s left behind: %v", openAPIDumpFile, err)
+	}
+}
+
+func TestAbsoluteReplaces(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.23\n\nreplace example.com/lib => ../lib\n\nreplace example.com/remote => example.com/fork v1.0.0\n",
+	})
+	chdir(t, dir)
+
+	if err := absoluteReplaces("."); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lib, err := filepath.Abs("../lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"example.com/lib => " + lib, "example.com/remote => example.com/fork v1.0.0"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("go.mod missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestDocsDiffCommand_AgainstGitRef(t *testing.T) {
+	for _, tool := range []string{"go", "git"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not found", tool)
+		}
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, diffProjectFiles)
+	chdir(t, dir)
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	if err := runCommand(newDocsDiffCommand(), "--against", "HEAD"); err != nil {
+		t.Errorf("docs diff against an unchanged HEAD = %v", err)
+	}
+
+	// Drop the only endpoint in the working tree
+	writeFiles(t, dir, map[string]string{"cmd/server/openapi_generated.go": `package main
+
+func GenerateOpenAPISpec() map[string]interface{} {
+	return map[string]interface{}{"openapi": "3.0.3", "paths": map[string]interface{}{}}
+}
+`})
+	err := runCommand(newDocsDiffCommand(), "--against", "HEAD")
+	if err == nil || !strings.Contains(err.Error(), "1 breaking API change(s) since HEAD") {
+		t.Errorf("docs diff with a removed endpoint = %v", err)
+	}
+
+	// The temporary worktree is gone
+	out, err := exec.Command("git", "worktree", "list").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(strings.TrimSpace(string(out)), "\n"); lines != 0 {
+		t.Errorf("worktrees left behind:\n%s", out)
+	}
+}
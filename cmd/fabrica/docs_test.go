@@ -0,0 +1,161 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// graphProjectFiles declares resources referencing each other by ref tag,
+// by UID field name, through a nested struct and through a list
+var graphProjectFiles = map[string]string{
+	"pkg/resources/rack/rack.go": `package rack
+
+import "github.com/openchami/fabrica/pkg/resource"
+
+type Rack struct {
+	resource.Resource
+	Spec RackSpec ` + "`json:\"spec\"`" + `
+}
+
+type RackSpec struct {
+	TemplateUID string ` + "`json:\"templateUID\"`" + `
+}
+`,
+	"pkg/resources/racktemplate/racktemplate.go": `package racktemplate
+
+import "github.com/openchami/fabrica/pkg/resource"
+
+type RackTemplate struct {
+	resource.Resource
+}
+`,
+	"pkg/resources/node/node.go": `package node
+
+import "github.com/openchami/fabrica/pkg/resource"
+
+type Node struct {
+	resource.Resource
+	Spec   NodeSpec   ` + "`json:\"spec\"`" + `
+	Status NodeStatus ` + "`json:\"status\"`" + `
+}
+
+type NodeSpec struct {
+	Chassis  string   ` + "`json:\"chassis\" ref:\"Rack,owner\"`" + `
+	Location Location ` + "`json:\"location\"`" + `
+	Ignored  string   ` + "`json:\"-\" ref:\"Rack\"`" + `
+}
+
+type Location struct {
+	RackUID string ` + "`json:\"rackUID\"`" + `
+}
+
+type NodeStatus struct {
+	PeerNodeUIDs []string ` + "`json:\"peerNodeUIDs\"`" + `
+	OtherUID     string   ` + "`json:\"otherUID\"`" + `
+}
+`,
+}
+
+func TestAnalyzeResourceGraph(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, graphProjectFiles)
+	chdir(t, dir)
+
+	graph, err := analyzeResourceGraph("pkg/resources")
+	if err != nil {
+		t.Fatalf("analyzeResourceGraph failed: %v", err)
+	}
+
+	if want := []string{"Node", "Rack", "RackTemplate"}; !reflect.DeepEqual(graph.Kinds, want) {
+		t.Errorf("Expected kinds %v, got %v", want, graph.Kinds)
+	}
+	want := []resourceEdge{
+		{From: "Node", To: "Rack", Field: "spec.chassis", Owner: true},
+		{From: "Node", To: "Rack", Field: "spec.location.rackUID"},
+		{From: "Node", To: "Node", Field: "status.peerNodeUIDs", Many: true},
+		{From: "Rack", To: "RackTemplate", Field: "spec.templateUID"},
+	}
+	if !reflect.DeepEqual(graph.Edges, want) {
+		t.Errorf("Expected edges %+v, got %+v", want, graph.Edges)
+	}
+}
+
+func TestDocsGraphCommand_Arguments(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	err := runCommand(newDocsCommand(), "graph", "--format", "png")
+	if err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Errorf("Expected an invalid format error, got %v", err)
+	}
+
+	err = runCommand(newDocsCommand(), "graph")
+	if err == nil || !strings.Contains(err.Error(), "no resources found") {
+		t.Errorf("Expected a no resources error, got %v", err)
+	}
+}
+
+func TestDocsGraphCommand_Output(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, graphProjectFiles)
+	chdir(t, dir)
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"dot", []string{
+			"digraph resources {",
+			`"RackTemplate";`,
+			`"Node" -> "Rack" [label="owner: spec.chassis", style=dashed];`,
+			`"Node" -> "Node" [label="status.peerNodeUIDs[]"];`,
+			`"Rack" -> "RackTemplate" [label="spec.templateUID"];`,
+		}},
+		{"mermaid", []string{
+			"graph LR",
+			"  RackTemplate\n",
+			`Node -.->|"owner: spec.chassis"| Rack`,
+			`Node -->|"spec.location.rackUID"| Rack`,
+		}},
+	}
+	for _, tt := range tests {
+		output := tt.format + ".txt"
+		if err := runCommand(newDocsCommand(), "graph", "--format", tt.format, "--output", output); err != nil {
+			t.Fatalf("docs graph --format %s failed: %v", tt.format, err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s output missing %q:\n%s", tt.format, want, data)
+			}
+		}
+	}
+}
+
+func TestKindForUIDField(t *testing.T) {
+	kinds := []string{"Node", "ManagedNode", "Rack", "RackTemplate", "BootTemplate"}
+	tests := map[string]string{
+		"RackUID":         "Rack",
+		"ManagedNodeUIDs": "ManagedNode",
+		"ParentNodeUID":   "Node",
+		// Ambiguous: both RackTemplate and BootTemplate end in Template
+		"TemplateUID": "",
+		"UID":         "",
+		"UIDs":        "",
+		"Rack":        "",
+		"SwitchUID":   "",
+	}
+	for field, want := range tests {
+		if got := kindForUIDField(kinds, field); got != want {
+			t.Errorf("kindForUIDField(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
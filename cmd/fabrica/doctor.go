@@ -0,0 +1,304 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// doctorCheck is the outcome of a single project health check
+type doctorCheck struct {
+	Name    string
+	Passed  bool
+	Skipped bool
+	Message string
+	Fix     string
+}
+
+func newDoctorCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common problems in a Fabrica project",
+		Long: `Run a series of checks against the current project and report
+anything that is likely to cause build or startup failures.
+
+Checks:
+  - .fabrica.yaml parses and matches the on-disk layout
+  - register_generated.go lists exactly the discovered resources
+  - generated code version matches the CLI version
+  - storage type in .fabrica.yaml matches the imports in cmd/server/main.go
+  - Ent + SQLite projects enable foreign keys (_fk=1) in the DSN
+
+Example:
+  fabrica doctor
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			fmt.Println("🩺 Checking project health...")
+			fmt.Println()
+
+			checks := runDoctorChecks()
+
+			failed := 0
+			for _, check := range checks {
+				switch {
+				case check.Skipped:
+					fmt.Printf("  ⏭️  %s: %s\n", check.Name, check.Message)
+				case check.Passed:
+					fmt.Printf("  ✅ %s: %s\n", check.Name, check.Message)
+				default:
+					failed++
+					fmt.Printf("  ❌ %s: %s\n", check.Name, check.Message)
+					if check.Fix != "" {
+						fmt.Printf("     💡 %s\n", check.Fix)
+					}
+				}
+			}
+
+			fmt.Println()
+			if failed > 0 {
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+
+			fmt.Println("✅ No problems found")
+			return nil
+		},
+	}
+}
+
+// runDoctorChecks runs all project checks in order
+func runDoctorChecks() []doctorCheck {
+	config, configCheck := checkDoctorConfig()
+
+	return []doctorCheck{
+		configCheck,
+		checkDoctorRegistration(),
+		checkDoctorVersion(),
+		checkDoctorStorage(config),
+		checkDoctorSQLiteDSN(config),
+	}
+}
+
+// checkDoctorConfig verifies .fabrica.yaml parses, validates, and matches the project layout
+func checkDoctorConfig() (*FabricaConfig, doctorCheck) {
+	check := doctorCheck{Name: "Config"}
+
+	config, err := LoadConfig("")
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to load %s: %v", ConfigFileName, err)
+		if errors.Is(err, fs.ErrNotExist) {
+			check.Fix = "Run 'fabrica init .' to create a project configuration"
+		} else {
+			check.Fix = fmt.Sprintf("Fix the YAML syntax in %s", ConfigFileName)
+		}
+		return nil, check
+	}
+
+	if err := ValidateConfig(config); err != nil {
+		check.Message = err.Error()
+		check.Fix = fmt.Sprintf("Correct the invalid value in %s", ConfigFileName)
+		return config, check
+	}
+
+	modulePath, err := getModulePath()
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to read go.mod: %v", err)
+		check.Fix = "Run 'go mod init " + config.Project.Module + "'"
+		return config, check
+	}
+	if modulePath != config.Project.Module {
+		check.Message = fmt.Sprintf("project.module is %q but go.mod declares %q", config.Project.Module, modulePath)
+		check.Fix = fmt.Sprintf("Set project.module to %q in %s", modulePath, ConfigFileName)
+		return config, check
+	}
+
+	for _, dir := range []string{"cmd/server", "pkg/resources"} {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			check.Message = fmt.Sprintf("expected directory %s is missing", dir)
+			check.Fix = "Re-run 'fabrica init .' or restore the directory"
+			return config, check
+		}
+	}
+
+	check.Passed = true
+	check.Message = fmt.Sprintf("%s is valid (module %s)", ConfigFileName, modulePath)
+	return config, check
+}
+
+var registeredResourcePattern = regexp.MustCompile(`gen\.RegisterResource\(&\w+\.(\w+)\{\}\)`)
+
+// checkDoctorRegistration verifies register_generated.go matches the discovered resources
+func checkDoctorRegistration() doctorCheck {
+	check := doctorCheck{Name: "Registration"}
+
+	discovered, err := discoverResources()
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to discover resources: %v", err)
+		return check
+	}
+	if len(discovered) == 0 {
+		check.Skipped = true
+		check.Message = "no resources found in pkg/resources"
+		return check
+	}
+
+	data, err := os.ReadFile("pkg/resources/register_generated.go")
+	if err != nil {
+		check.Message = "pkg/resources/register_generated.go not found"
+		check.Fix = "Run 'fabrica generate' to create the registration file"
+		return check
+	}
+
+	var registered []string
+	for _, match := range registeredResourcePattern.FindAllStringSubmatch(string(data), -1) {
+		registered = append(registered, match[1])
+	}
+
+	missing := stringSetDifference(discovered, registered)
+	stale := stringSetDifference(registered, discovered)
+	if len(missing) > 0 || len(stale) > 0 {
+		var problems []string
+		if len(missing) > 0 {
+			problems = append(problems, "not registered: "+strings.Join(missing, ", "))
+		}
+		if len(stale) > 0 {
+			problems = append(problems, "registered but not found: "+strings.Join(stale, ", "))
+		}
+		check.Message = strings.Join(problems, "; ")
+		check.Fix = "Delete pkg/resources/register_generated.go and run 'fabrica generate'"
+		return check
+	}
+
+	check.Passed = true
+	check.Message = fmt.Sprintf("%d resource(s) registered", len(registered))
+	return check
+}
+
+// checkDoctorVersion verifies generated code was produced by this CLI version
+func checkDoctorVersion() doctorCheck {
+	check := doctorCheck{Name: "Version"}
+
+	generatedVersion := detectGeneratedVersion()
+	if generatedVersion == "" {
+		check.Skipped = true
+		check.Message = "no generated code found"
+		return check
+	}
+
+	if generatedVersion != version {
+		check.Message = fmt.Sprintf("generated code is from Fabrica %s but the CLI is %s", generatedVersion, version)
+		check.Fix = "Run 'fabrica generate --force' to regenerate with the current CLI"
+		return check
+	}
+
+	check.Passed = true
+	check.Message = fmt.Sprintf("generated code matches CLI version %s", version)
+	return check
+}
+
+// checkDoctorStorage verifies the configured storage type matches cmd/server/main.go
+func checkDoctorStorage(config *FabricaConfig) doctorCheck {
+	check := doctorCheck{Name: "Storage"}
+
+	if config == nil || !config.Features.Storage.Enabled {
+		check.Skipped = true
+		check.Message = "storage not configured"
+		return check
+	}
+
+	data, err := os.ReadFile("cmd/server/main.go")
+	if err != nil {
+		check.Message = "cmd/server/main.go not found"
+		check.Fix = "Re-run 'fabrica init .' to restore the server entrypoint"
+		return check
+	}
+
+	usesEnt := strings.Contains(string(data), "internal/storage/ent")
+	configured := config.Features.Storage.Type
+	switch {
+	case configured == "ent" && !usesEnt:
+		check.Message = "storage.type is 'ent' but cmd/server/main.go does not import the Ent client"
+		check.Fix = "Set storage.type to 'file' or re-initialize with 'fabrica init --storage-type ent'"
+		return check
+	case configured != "ent" && usesEnt:
+		check.Message = fmt.Sprintf("storage.type is %q but cmd/server/main.go imports the Ent client", configured)
+		check.Fix = fmt.Sprintf("Set storage.type to 'ent' in %s", ConfigFileName)
+		return check
+	}
+
+	check.Passed = true
+	check.Message = fmt.Sprintf("%s storage matches server imports", configured)
+	return check
+}
+
+var databaseURLPattern = regexp.MustCompile(`(?:DatabaseURL:\s*|String\("database-url",\s*)"([^"]*)"`)
+
+// checkDoctorSQLiteDSN verifies Ent + SQLite projects enable foreign keys in the default DSN
+func checkDoctorSQLiteDSN(config *FabricaConfig) doctorCheck {
+	check := doctorCheck{Name: "SQLite DSN"}
+
+	if config == nil || config.Features.Storage.Type != "ent" ||
+		!strings.HasPrefix(config.Features.Storage.DBDriver, "sqlite") {
+		check.Skipped = true
+		check.Message = "not an Ent + SQLite project"
+		return check
+	}
+
+	data, err := os.ReadFile("cmd/server/main.go")
+	if err != nil {
+		check.Message = "cmd/server/main.go not found"
+		return check
+	}
+
+	var dsns []string
+	for _, match := range databaseURLPattern.FindAllStringSubmatch(string(data), -1) {
+		if match[1] != "" {
+			dsns = append(dsns, match[1])
+		}
+	}
+
+	if len(dsns) == 0 {
+		check.Message = "no default DSN is set in cmd/server/main.go"
+		check.Fix = "Set a default database-url such as file:./data.db?cache=shared&_fk=1"
+		return check
+	}
+
+	for _, dsn := range dsns {
+		if !storage.SQLiteForeignKeysEnabled(dsn) {
+			check.Message = fmt.Sprintf("default DSN %q does not enable foreign keys", dsn)
+			check.Fix = "Append '_fk=1' to the DSN (e.g. file:./data.db?cache=shared&_fk=1)"
+			return check
+		}
+	}
+
+	check.Passed = true
+	check.Message = "foreign keys enabled"
+	return check
+}
+
+// stringSetDifference returns the sorted values in a that are not in b
+func stringSetDifference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, v := range b {
+		seen[v] = true
+	}
+
+	var diff []string
+	for _, v := range a {
+		if !seen[v] {
+			diff = append(diff, v)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
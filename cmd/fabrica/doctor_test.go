@@ -0,0 +1,191 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDoctorProject writes a healthy file-storage project with one
+// registered resource and changes to it
+func writeDoctorProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, backupProjectFiles)
+	writeFiles(t, dir, map[string]string{
+		"go.mod":                              "module example.com/app\n",
+		"cmd/server/main.go":                  "package main\n\nimport _ \"example.com/app/internal/storage\"\n",
+		"pkg/resources/register_generated.go": generateRegistrationCode("example.com/app", []string{"Device"}),
+	})
+
+	config := NewDefaultConfig("app", "example.com/app")
+	config.Features.Storage.Enabled = true
+	config.Features.Storage.Type = "file"
+	if err := SaveConfig(dir, config); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+	return dir
+}
+
+func TestDoctorCommand(t *testing.T) {
+	dir := writeDoctorProject(t)
+	if err := runCommand(newDoctorCommand()); err != nil {
+		t.Errorf("doctor failed on a healthy project: %v", err)
+	}
+
+	// A resource missing from the registration file fails the run
+	writeFiles(t, dir, map[string]string{
+		"pkg/resources/rack/rack.go": "package rack\n\nimport \"github.com/openchami/fabrica/pkg/resource\"\n\ntype Rack struct {\n\tresource.Resource\n}\n",
+	})
+	err := runCommand(newDoctorCommand())
+	if err == nil || !strings.Contains(err.Error(), "1 check(s) failed") {
+		t.Errorf("Expected one failed check, got %v", err)
+	}
+}
+
+func TestCheckDoctorConfig(t *testing.T) {
+	chdir(t, t.TempDir())
+	if config, check := checkDoctorConfig(); config != nil || check.Passed || !strings.Contains(check.Fix, "fabrica init") {
+		t.Errorf("missing config: got %+v, want a failure suggesting 'fabrica init'", check)
+	}
+
+	if err := os.WriteFile(ConfigFileName, []byte("project: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, check := checkDoctorConfig(); check.Passed || !strings.Contains(check.Fix, "YAML syntax") {
+		t.Errorf("malformed config: got %+v, want a failure suggesting a YAML fix", check)
+	}
+
+	dir := writeDoctorProject(t)
+	if _, check := checkDoctorConfig(); !check.Passed {
+		t.Errorf("valid config: got %+v", check)
+	}
+
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/other\n"})
+	if _, check := checkDoctorConfig(); check.Passed || !strings.Contains(check.Message, `go.mod declares "example.com/other"`) {
+		t.Errorf("module mismatch: got %+v", check)
+	}
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/app\n"})
+
+	if err := os.RemoveAll(filepath.Join(dir, "cmd")); err != nil {
+		t.Fatal(err)
+	}
+	if _, check := checkDoctorConfig(); check.Passed || !strings.Contains(check.Message, "cmd/server is missing") {
+		t.Errorf("missing directory: got %+v", check)
+	}
+}
+
+func TestCheckDoctorRegistration(t *testing.T) {
+	chdir(t, t.TempDir())
+	if check := checkDoctorRegistration(); !check.Skipped {
+		t.Errorf("no resources: got %+v, want skipped", check)
+	}
+
+	dir := writeDoctorProject(t)
+	if check := checkDoctorRegistration(); !check.Passed {
+		t.Errorf("registered: got %+v", check)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"pkg/resources/register_generated.go": generateRegistrationCode("example.com/app", []string{"Device", "Rack"}),
+	})
+	if check := checkDoctorRegistration(); check.Passed || check.Message != "registered but not found: Rack" {
+		t.Errorf("stale registration: got %+v", check)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "pkg", "resources", "register_generated.go")); err != nil {
+		t.Fatal(err)
+	}
+	if check := checkDoctorRegistration(); check.Passed || !strings.Contains(check.Fix, "fabrica generate") {
+		t.Errorf("missing registration: got %+v", check)
+	}
+}
+
+func TestCheckDoctorVersion(t *testing.T) {
+	dir := writeDoctorProject(t)
+	if check := checkDoctorVersion(); !check.Skipped {
+		t.Errorf("no generated code: got %+v, want skipped", check)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"cmd/server/routes_generated.go": "// Code generated by Fabrica " + version + ". DO NOT EDIT.\npackage main\n",
+	})
+	if check := checkDoctorVersion(); !check.Passed {
+		t.Errorf("current version: got %+v", check)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"cmd/server/routes_generated.go": "// Code generated by Fabrica v0.0.1. DO NOT EDIT.\npackage main\n",
+	})
+	if check := checkDoctorVersion(); check.Passed || !strings.Contains(check.Message, "v0.0.1") {
+		t.Errorf("old version: got %+v", check)
+	}
+}
+
+func TestCheckDoctorStorage(t *testing.T) {
+	dir := writeDoctorProject(t)
+	config := NewDefaultConfig("app", "example.com/app")
+
+	if check := checkDoctorStorage(nil); !check.Skipped {
+		t.Errorf("no config: got %+v, want skipped", check)
+	}
+
+	config.Features.Storage.Enabled = true
+	config.Features.Storage.Type = "file"
+	if check := checkDoctorStorage(config); !check.Passed {
+		t.Errorf("file storage: got %+v", check)
+	}
+
+	config.Features.Storage.Type = "ent"
+	if check := checkDoctorStorage(config); check.Passed || !strings.Contains(check.Message, "does not import the Ent client") {
+		t.Errorf("ent configured, file imported: got %+v", check)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"cmd/server/main.go": "package main\n\nimport _ \"example.com/app/internal/storage/ent\"\n",
+	})
+	if check := checkDoctorStorage(config); !check.Passed {
+		t.Errorf("ent storage: got %+v", check)
+	}
+
+	config.Features.Storage.Type = "file"
+	if check := checkDoctorStorage(config); check.Passed || !strings.Contains(check.Fix, "storage.type to 'ent'") {
+		t.Errorf("file configured, ent imported: got %+v", check)
+	}
+}
+
+func TestCheckDoctorSQLiteDSN(t *testing.T) {
+	dir := writeDoctorProject(t)
+	config := NewDefaultConfig("app", "example.com/app")
+	config.Features.Storage.Enabled = true
+	config.Features.Storage.Type = "ent"
+	config.Features.Storage.DBDriver = "postgres"
+
+	if check := checkDoctorSQLiteDSN(config); !check.Skipped {
+		t.Errorf("postgres: got %+v, want skipped", check)
+	}
+
+	config.Features.Storage.DBDriver = "sqlite"
+	tests := []struct {
+		main   string
+		passed bool
+		want   string
+	}{
+		{`serveCmd.Flags().String("database-url", "file:./data.db?cache=shared&_fk=1", "DSN")`, true, "foreign keys enabled"},
+		{`DatabaseURL: "file:./data.db?cache=shared"`, false, "does not enable foreign keys"},
+		{`serveCmd.Flags().String("database-url", "", "DSN")`, false, "no default DSN"},
+	}
+	for _, tt := range tests {
+		writeFiles(t, dir, map[string]string{"cmd/server/main.go": "package main\n\n// " + tt.main + "\n"})
+		check := checkDoctorSQLiteDSN(config)
+		if check.Passed != tt.passed || !strings.Contains(check.Message, tt.want) {
+			t.Errorf("%s: got %+v, want passed=%v mentioning %q", tt.main, check, tt.passed, tt.want)
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 
+	fabricastorage "github.com/openchami/fabrica/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -43,6 +44,9 @@ func newGenerateCommand() *cobra.Command {
 		all      bool
 		debug    bool
 		force    bool
+		watch    bool
+		grpc     bool
+		bench    bool
 	)
 
 	cmd := &cobra.Command{
@@ -55,6 +59,10 @@ Examples:
   fabrica generate                    # Generate all
   fabrica generate --handlers         # Just handlers
   fabrica generate --client --openapi # Client + OpenAPI
+  fabrica generate --grpc             # Everything plus gRPC services
+  fabrica generate --bench            # Everything plus storage benchmarks in bench/
+  fabrica generate --watch            # Regenerate whenever resources change
+  fabrica generate client --from-openapi spec.yaml  # Client for an external API
 `,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			if !handlers && !storage && !client && !openapi {
@@ -116,6 +124,12 @@ Examples:
 				}
 			}
 
+			// Older configs still load, but may be missing settings the current schema expects
+			if config, err := readFabricaConfig(); err == nil && config != nil && config.SchemaVersion < CurrentConfigSchemaVersion {
+				fmt.Printf("⚠️  %s uses config schema version %d; run 'fabrica config migrate' to upgrade it to %d\n",
+					ConfigFileName, config.SchemaVersion, CurrentConfigSchemaVersion)
+			}
+
 			// Auto-generate registration file if missing
 			if needsRegistration {
 				fmt.Println()
@@ -124,60 +138,43 @@ Examples:
 					return fmt.Errorf("failed to generate registration file: %w", err)
 				}
 				fmt.Println()
-			} else if debug {
-				fmt.Printf("📝 Registration file exists: %s\n", regFile)
-			}
-
-			// Note: We don't run go mod tidy here because:
-			// 1. Generated code may introduce new imports
-			// 2. The user should run it after generation completes
-			// This avoids circular dependency issues with code generators like Ent
-
-			// Generate server code (handlers, storage, openapi)
-			if all || handlers || storage || openapi {
-				if debug {
-					fmt.Println("📦 Generating server code...")
+			} else {
+				// Rewrite a registration file from an older fabrica, which
+				// may not read every resource marker
+				updated, err := refreshRegistrationFile(modulePath, resources)
+				if err != nil {
+					return fmt.Errorf("failed to update registration file: %w", err)
 				}
-				if err := generateCodeWithRunner(modulePath, "cmd/server", "main", all || handlers, all || storage, all || openapi, false, debug); err != nil {
-					return fmt.Errorf("failed to generate server code: %w", err)
+				if updated {
+					fmt.Printf("📝 Updated %s\n", regFile)
+				} else if debug {
+					fmt.Printf("📝 Registration file exists: %s\n", regFile)
 				}
 			}
 
-			// Generate client code
-			if all || client {
-				fmt.Println("📦 Generating client code...")
-				if err := generateCodeWithRunner(modulePath, "pkg/client", "client", false, false, false, true, debug); err != nil {
-					return fmt.Errorf("failed to generate client code: %w", err)
-				}
-			}
-
-			// Check if reconciliation is enabled in config
-			config, err := readFabricaConfig()
-			if err == nil && config != nil && config.Features.Reconciliation.Enabled {
-				fmt.Println("🔄 Generating reconciliation code...")
-				if err := generateCodeWithRunner(modulePath, "pkg/reconcilers", "reconcile", false, false, false, false, debug); err != nil {
-					return fmt.Errorf("failed to generate reconciliation code: %w", err)
-				}
+			opts := generateOptions{
+				handlers: all || handlers,
+				storage:  all || storage,
+				client:   all || client,
+				openapi:  all || openapi,
+				grpc:     grpc,
+				bench:    bench,
+				debug:    debug,
+				force:    force,
 			}
-
-			// Auto-generate Ent client code if using Ent storage
-			storageType := detectStorageType()
-			if storageType == "ent" && (all || storage) {
-				fmt.Println("🔄 Generating Ent client code...")
-
-				if err := generateEntCode(debug); err != nil {
-					return fmt.Errorf("failed to generate ent code: %w", err)
-				}
-
-				if debug {
-					fmt.Println("  ✅ Ent client code generated")
-				}
+			if err := runGenerationSteps(modulePath, opts); err != nil {
+				return err
 			}
 
 			fmt.Println("  └─ Done!")
 			fmt.Println()
 			fmt.Println("✅ Code generation complete!")
 			fmt.Println()
+
+			if watch {
+				return watchResources(modulePath, resources, opts)
+			}
+
 			fmt.Println("Next steps:")
 			fmt.Println("  go mod tidy                     # Update dependencies")
 			fmt.Println("  go run ./cmd/server       # Start the server")
@@ -191,11 +188,95 @@ Examples:
 	cmd.Flags().BoolVar(&client, "client", false, "Generate client code")
 	cmd.Flags().BoolVar(&openapi, "openapi", false, "Generate OpenAPI spec")
 	cmd.Flags().BoolVar(&debug, "debug", false, "Enable debug output showing detailed generation steps")
-	cmd.Flags().BoolVar(&force, "force", false, "Force regeneration even with version warnings")
+	cmd.Flags().BoolVar(&force, "force", false, "Force regeneration even with version warnings or unchanged inputs")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Watch pkg/resources and regenerate on changes")
+	cmd.Flags().BoolVar(&grpc, "grpc", false, "Generate protobuf definitions and gRPC services")
+	cmd.Flags().BoolVar(&bench, "bench", false, "Generate storage benchmarks and HTTP load test targets in bench/")
+
+	cmd.AddCommand(newGenerateClientCommand())
+	cmd.AddCommand(newGenerateGatewayCommand())
 
 	return cmd
 }
 
+// generateOptions selects which artifacts a generation run produces
+type generateOptions struct {
+	handlers bool
+	storage  bool
+	client   bool
+	openapi  bool
+	grpc     bool
+	bench    bool
+	debug    bool
+	force    bool
+}
+
+// runGenerationSteps runs the selected generators against the registered resources
+func runGenerationSteps(modulePath string, opts generateOptions) error {
+	// Note: We don't run go mod tidy here because:
+	// 1. Generated code may introduce new imports
+	// 2. The user should run it after generation completes
+	// This avoids circular dependency issues with code generators like Ent
+
+	// Generate server code (handlers, storage, openapi)
+	if opts.handlers || opts.storage || opts.openapi || opts.grpc {
+		if opts.debug {
+			fmt.Println("📦 Generating server code...")
+		}
+		if err := generateCodeWithRunner(modulePath, "cmd/server", "main", opts.handlers, opts.storage, opts.openapi, false, opts.grpc, opts.debug, opts.force); err != nil {
+			return fmt.Errorf("failed to generate server code: %w", err)
+		}
+	}
+
+	// Compile protobuf definitions if the protoc toolchain is installed
+	if opts.grpc {
+		if err := generateProtoCode(opts.debug); err != nil {
+			return fmt.Errorf("failed to compile protobuf definitions: %w", err)
+		}
+	}
+
+	// Generate client code
+	if opts.client {
+		fmt.Println("📦 Generating client code...")
+		if err := generateCodeWithRunner(modulePath, "pkg/client", "client", false, false, false, true, false, opts.debug, opts.force); err != nil {
+			return fmt.Errorf("failed to generate client code: %w", err)
+		}
+	}
+
+	// Generate benchmarks and load test targets
+	if opts.bench {
+		fmt.Println("📦 Generating benchmarks...")
+		if err := generateCodeWithRunner(modulePath, "bench", "bench", false, false, false, false, false, opts.debug, opts.force); err != nil {
+			return fmt.Errorf("failed to generate benchmarks: %w", err)
+		}
+	}
+
+	// Check if reconciliation is enabled in config
+	config, err := readFabricaConfig()
+	if err == nil && config != nil && config.Features.Reconciliation.Enabled {
+		fmt.Println("🔄 Generating reconciliation code...")
+		if err := generateCodeWithRunner(modulePath, "pkg/reconcilers", "reconcile", false, false, false, false, false, opts.debug, opts.force); err != nil {
+			return fmt.Errorf("failed to generate reconciliation code: %w", err)
+		}
+	}
+
+	// Auto-generate Ent client code if using Ent storage
+	storageType := detectStorageType()
+	if storageType == "ent" && opts.storage {
+		fmt.Println("🔄 Generating Ent client code...")
+
+		if err := generateEntCode(opts.debug); err != nil {
+			return fmt.Errorf("failed to generate ent code: %w", err)
+		}
+
+		if opts.debug {
+			fmt.Println("  ✅ Ent client code generated")
+		}
+	}
+
+	return nil
+}
+
 // getModulePath reads the module path from go.mod
 func getModulePath() (string, error) {
 	data, err := os.ReadFile("go.mod")
@@ -241,8 +322,32 @@ func detectStorageType() string {
 	return "file"
 }
 
+// detectDBDriver returns the Ent driver of the project: storage.db_driver
+// from .fabrica.yaml, else the SQL driver imported by cmd/server/main.go,
+// else SQLite. The result is normalized by storage.EntDriverName.
+func detectDBDriver() (string, error) {
+	if config, err := readFabricaConfig(); err == nil && config != nil && config.Features.Storage.DBDriver != "" {
+		driver, err := fabricastorage.EntDriverName(config.Features.Storage.DBDriver)
+		if err != nil {
+			return "", fmt.Errorf("invalid storage.db_driver in .fabrica.yaml: %w", err)
+		}
+		return driver, nil
+	}
+
+	if data, err := os.ReadFile("cmd/server/main.go"); err == nil {
+		content := string(data)
+		switch {
+		case strings.Contains(content, `"github.com/lib/pq"`):
+			return "postgres", nil
+		case strings.Contains(content, `"github.com/go-sql-driver/mysql"`):
+			return "mysql", nil
+		}
+	}
+	return "sqlite3", nil
+}
+
 // generateCodeWithRunner creates and runs a temporary codegen program
-func generateCodeWithRunner(modulePath, outputDir, packageName string, handlers, storage, openapi, client, debug bool) error {
+func generateCodeWithRunner(modulePath, outputDir, packageName string, handlers, storage, openapi, client, grpc, debug, force bool) error {
 	// Create output directory if it doesn't exist
 	if debug {
 		fmt.Printf("  Creating output directory: %s\n", outputDir)
@@ -269,7 +374,18 @@ func generateCodeWithRunner(modulePath, outputDir, packageName string, handlers,
 		fmt.Printf("  Detected storage type: %s\n", storageType)
 	}
 
-	runnerCode := generateRunnerCode(modulePath, outputDir, packageName, handlers, storage, openapi, client, debug, storageType)
+	dbDriver := ""
+	if storageType == "ent" {
+		var err error
+		if dbDriver, err = detectDBDriver(); err != nil {
+			return err
+		}
+		if debug {
+			fmt.Printf("  Detected database driver: %s\n", dbDriver)
+		}
+	}
+
+	runnerCode := generateRunnerCode(modulePath, outputDir, packageName, handlers, storage, openapi, client, grpc, debug, force, storageType, dbDriver)
 
 	runnerPath := filepath.Join(runnerDir, "main.go")
 	if err := os.WriteFile(runnerPath, []byte(runnerCode), 0644); err != nil {
@@ -295,7 +411,7 @@ func generateCodeWithRunner(modulePath, outputDir, packageName string, handlers,
 }
 
 // generateRunnerCode creates the source code for the temporary codegen runner
-func generateRunnerCode(modulePath, outputDir, packageName string, handlers, storage, openapi, client, debug bool, storageType string) string {
+func generateRunnerCode(modulePath, outputDir, packageName string, handlers, storage, openapi, client, grpc, debug, force bool, storageType, dbDriver string) string {
 	var generationCalls strings.Builder
 
 	if packageName == "main" {
@@ -337,6 +453,15 @@ func generateRunnerCode(modulePath, outputDir, packageName string, handlers, sto
 			generationCalls.WriteString("\t}\n")
 		}
 
+		if grpc {
+			generationCalls.WriteString("\tif err := gen.GenerateProto(); err != nil {\n")
+			generationCalls.WriteString("\t\tlog.Fatalf(\"Failed to generate protobuf definitions: %v\", err)\n")
+			generationCalls.WriteString("\t}\n")
+			generationCalls.WriteString("\tif err := gen.GenerateGRPCServer(); err != nil {\n")
+			generationCalls.WriteString("\t\tlog.Fatalf(\"Failed to generate gRPC services: %v\", err)\n")
+			generationCalls.WriteString("\t}\n")
+		}
+
 		// Always generate routes and models if doing server-side generation
 		generationCalls.WriteString("\tif err := gen.GenerateRoutes(); err != nil {\n")
 		generationCalls.WriteString("\t\tlog.Fatalf(\"Failed to generate routes: %v\", err)\n")
@@ -403,19 +528,30 @@ func generateRunnerCode(modulePath, outputDir, packageName string, handlers, sto
 		generationCalls.WriteString("\tif err := gen.GenerateEventHandlers(); err != nil {\n")
 		generationCalls.WriteString("\t\tlog.Fatalf(\"Failed to generate event handlers: %v\", err)\n")
 		generationCalls.WriteString("\t}\n")
+	} else if packageName == "bench" {
+		// Benchmark and load test generation
+		if debug {
+			generationCalls.WriteString("\tfmt.Println(\"  Loading templates...\")\n")
+		}
+		generationCalls.WriteString("\tif err := gen.LoadTemplates(); err != nil {\n")
+		generationCalls.WriteString("\t\tlog.Fatalf(\"Failed to load templates: %v\", err)\n")
+		generationCalls.WriteString("\t}\n\n")
+
+		generationCalls.WriteString("\tif err := gen.GenerateBenchmarks(); err != nil {\n")
+		generationCalls.WriteString("\t\tlog.Fatalf(\"Failed to generate benchmarks: %v\", err)\n")
+		generationCalls.WriteString("\t}\n")
 	}
 
 	verboseFlag := "false"
-	fmtImport := ""
 	if debug {
 		verboseFlag = "true"
-		fmtImport = "\t\"fmt\"\n"
 	}
 
 	return fmt.Sprintf(`package main
 
 import (
-%s	"log"
+	"fmt"
+	"log"
 	"os"
 
 	"github.com/openchami/fabrica/pkg/codegen"
@@ -425,20 +561,35 @@ import (
 
 // FabricaConfig structures to load .fabrica.yaml
 type FabricaConfig struct {
-	Features FeaturesConfig `+"`yaml:\"features\"`"+`
+	API        APIConfig        `+"`yaml:\"api\"`"+`
+	Features   FeaturesConfig   `+"`yaml:\"features\"`"+`
+	Generation GenerationConfig `+"`yaml:\"generation\"`"+`
+}
+
+type APIConfig struct {
+	FieldNaming string `+"`yaml:\"field_naming\"`"+`
+}
+
+type GenerationConfig struct {
+	TemplatesDir string `+"`yaml:\"templates_dir\"`"+`
 }
 
 type FeaturesConfig struct {
-	Validation  ValidationConfig  `+"`yaml:\"validation\"`"+`
-	Conditional ConditionalConfig `+"`yaml:\"conditional\"`"+`
-	Versioning  VersioningConfig  `+"`yaml:\"versioning\"`"+`
-	Events      EventsConfig      `+"`yaml:\"events\"`"+`
-	Storage     StorageConfig     `+"`yaml:\"storage\"`"+`
+	Validation     ValidationConfig     `+"`yaml:\"validation\"`"+`
+	Conditional    ConditionalConfig    `+"`yaml:\"conditional\"`"+`
+	Versioning     VersioningConfig     `+"`yaml:\"versioning\"`"+`
+	Events         EventsConfig         `+"`yaml:\"events\"`"+`
+	Storage        StorageConfig        `+"`yaml:\"storage\"`"+`
+	Tracing        TracingConfig        `+"`yaml:\"tracing\"`"+`
+	Metrics        MetricsConfig        `+"`yaml:\"metrics\"`"+`
+	Auth           AuthConfig           `+"`yaml:\"auth\"`"+`
+	Reconciliation ReconciliationConfig `+"`yaml:\"reconciliation\"`"+`
 }
 
 type ValidationConfig struct {
-	Enabled bool   `+"`yaml:\"enabled\"`"+`
-	Mode    string `+"`yaml:\"mode\"`"+`
+	Enabled    bool   `+"`yaml:\"enabled\"`"+`
+	Mode       string `+"`yaml:\"mode\"`"+`
+	References string `+"`yaml:\"references\"`"+`
 }
 
 type ConditionalConfig struct {
@@ -456,9 +607,25 @@ type VersioningConfig struct {
 	Strategy string `+"`yaml:\"strategy\"`"+`
 }
 
+type TracingConfig struct {
+	Enabled bool `+"`yaml:\"enabled\"`"+`
+}
+
+type MetricsConfig struct {
+	Enabled bool `+"`yaml:\"enabled\"`"+`
+}
+
+type AuthConfig struct {
+	Enabled bool `+"`yaml:\"enabled\"`"+`
+}
+
+type ReconciliationConfig struct {
+	Enabled          bool `+"`yaml:\"enabled\"`"+`
+	GenerationFilter bool `+"`yaml:\"generation_filter\"`"+`
+}
+
 type StorageConfig struct {
-	Type     string `+"`yaml:\"type\"`"+`
-	DBDriver string `+"`yaml:\"db_driver\"`"+`
+	Type string `+"`yaml:\"type\"`"+`
 }
 
 func loadConfig() (*FabricaConfig, error) {
@@ -483,38 +650,73 @@ func main() {
 	// Configure storage type - passed from main generate command
 	gen.SetStorageType("%s")
 	if "%s" == "ent" {
-		gen.SetDBDriver("sqlite") // Default to sqlite for now
+		// Resolved from .fabrica.yaml's storage.db_driver by the generate command
+		gen.SetDBDriver("%s")
+		gen.Config.DBDriver = "%s"
 	}
 
+	// Project-local templates override embedded ones of the same path
+	templatesDir := "templates"
+
 	// Load .fabrica.yaml and apply configuration to generator
 	if config, err := loadConfig(); err == nil {
 		// Update generator config from .fabrica.yaml
 		gen.Config.ValidationEnabled = config.Features.Validation.Enabled
 		gen.Config.ValidationMode = config.Features.Validation.Mode
+		if config.Features.Validation.References != "" {
+			gen.Config.ReferenceMode = config.Features.Validation.References
+		}
 		gen.Config.ConditionalEnabled = config.Features.Conditional.Enabled
 		gen.Config.ETagAlgorithm = config.Features.Conditional.ETagAlgorithm
 		gen.Config.VersioningEnabled = config.Features.Versioning.Enabled
 		gen.Config.VersionStrategy = config.Features.Versioning.Strategy
 		gen.Config.EventsEnabled = config.Features.Events.Enabled
 		gen.Config.EventBusType = config.Features.Events.BusType
+		gen.Config.TracingEnabled = config.Features.Tracing.Enabled
+		gen.Config.MetricsEnabled = config.Features.Metrics.Enabled
+		gen.Config.ReconcileEnabled = config.Features.Reconciliation.Enabled
+		gen.Config.ReconcileGenerationFilter = config.Features.Reconciliation.GenerationFilter
+		gen.Config.AuthEnabled = config.Features.Auth.Enabled
+		if config.API.FieldNaming != "" {
+			gen.Config.FieldNaming = config.API.FieldNaming
+		}
 
 		// Override storage config from .fabrica.yaml if present
 		if config.Features.Storage.Type != "" {
 			gen.SetStorageType(config.Features.Storage.Type)
 			gen.Config.StorageType = config.Features.Storage.Type
 		}
-		if config.Features.Storage.DBDriver != "" {
-			gen.SetDBDriver(config.Features.Storage.DBDriver)
-			gen.Config.DBDriver = config.Features.Storage.DBDriver
+		if config.Generation.TemplatesDir != "" {
+			templatesDir = config.Generation.TemplatesDir
 		}
 	}
+	gen.SetTemplateOverrideDir(templatesDir)
 
 	if err := resources.RegisterAllResources(gen); err != nil {
 		log.Fatalf("Failed to register resources: %%v", err)
 	}
 
-%s}
-`, fmtImport, modulePath, outputDir, packageName, modulePath, verboseFlag, version, storageType, storageType, generationCalls.String())
+	// Generate resources that reference other resources' spec types last
+	if err := gen.SortResourcesByDependency(); err != nil {
+		log.Fatalf("Failed to order resources: %%v", err)
+	}
+
+	// Skip files whose inputs have not changed since the last run
+	cache, err := codegen.LoadGenerationCache(codegen.CacheFileName)
+	if err != nil {
+		log.Fatalf("Failed to load generation cache: %%v", err)
+	}
+	gen.Cache = cache
+	gen.Force = %t
+
+%s
+	if err := cache.Save(); err != nil {
+		log.Fatalf("Failed to save generation cache: %%v", err)
+	}
+	regenerated, unchanged := cache.Counts()
+	fmt.Printf("  %%d regenerated, %%d unchanged\n", regenerated, unchanged)
+}
+`, modulePath, outputDir, packageName, modulePath, verboseFlag, version, storageType, storageType, dbDriver, dbDriver, force, generationCalls.String())
 }
 
 // discoverResources scans pkg/resources for resource definitions
@@ -612,19 +814,10 @@ func generateRegistrationFile(debug bool) error {
 		fmt.Printf("📦 Found %d resource(s): %s\n", len(resources), strings.Join(resources, ", "))
 	}
 
-	// 3. Generate registration file
-	content := generateRegistrationCode(modulePath, resources)
-
-	// 4. Ensure pkg/resources directory exists
-	resourcesDir := filepath.Join("pkg", "resources")
-	if err := os.MkdirAll(resourcesDir, 0755); err != nil {
-		return fmt.Errorf("failed to create resources directory: %w", err)
-	}
-
-	// 5. Write to pkg/resources/register_generated.go
-	outputPath := filepath.Join(resourcesDir, "register_generated.go")
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write registration file: %w", err)
+	// 3. Generate and write registration file
+	outputPath, err := writeRegistrationFile(modulePath, resources)
+	if err != nil {
+		return err
 	}
 
 	fmt.Println()
@@ -638,6 +831,41 @@ func generateRegistrationFile(debug bool) error {
 	return nil
 }
 
+// writeRegistrationFile writes pkg/resources/register_generated.go for the given resources
+func writeRegistrationFile(modulePath string, resources []string) (string, error) {
+	content := generateRegistrationCode(modulePath, resources)
+
+	// Ensure pkg/resources directory exists
+	resourcesDir := filepath.Join("pkg", "resources")
+	if err := os.MkdirAll(resourcesDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create resources directory: %w", err)
+	}
+
+	outputPath := filepath.Join(resourcesDir, "register_generated.go")
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write registration file: %w", err)
+	}
+
+	return outputPath, nil
+}
+
+// refreshRegistrationFile rewrites pkg/resources/register_generated.go if it
+// differs from what this version of fabrica generates for resources, and
+// reports whether it did
+func refreshRegistrationFile(modulePath string, resources []string) (bool, error) {
+	existing, err := os.ReadFile(filepath.Join("pkg", "resources", "register_generated.go"))
+	if err != nil {
+		return false, err
+	}
+	if string(existing) == generateRegistrationCode(modulePath, resources) {
+		return false, nil
+	}
+	if _, err := writeRegistrationFile(modulePath, resources); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // generateRegistrationCode creates the content of the registration file
 func generateRegistrationCode(modulePath string, resources []string) string {
 	var imports strings.Builder
@@ -651,11 +879,52 @@ func generateRegistrationCode(modulePath string, resources []string) string {
 		registrations.WriteString("\t}\n")
 
 		// After registration, set per-resource tags if markers are present.
-		// Marker: // +fabrica:resource-versioning=enabled on the resource source file
+		// Markers on the resource source file:
+		//   // +fabrica:resource-versioning=enabled
+		//   // +fabrica:ttl=enabled
+		//   // +fabrica:auth=required
+		//   // +fabrica:scale=enabled
+		//   // +fabrica:routing=name
+		//   // +fabrica:path=<path>
+		//   // +fabrica:plural=<plural>
+		//   // +fabrica:unique=<spec.field>[,<spec.field>...]
+		//   // +fabrica:shortnames=<name>[,<name>...]
 		registrations.WriteString("\t// Set per-resource tags based on source markers\n")
-		registrations.WriteString(fmt.Sprintf("\tif hasVersioningMarker(\"%s\") {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\tif hasMarker(\"%s\", \"+fabrica:resource-versioning=enabled\") {\n", resource))
 		registrations.WriteString(fmt.Sprintf("\t\tgen.SetResourceTag(\"%s\", \"versioning\", \"enabled\")\n", resource))
 		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif hasMarker(\"%s\", \"+fabrica:ttl=enabled\") {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tgen.SetResourceTag(\"%s\", \"ttl\", \"enabled\")\n", resource))
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif hasMarker(\"%s\", \"+fabrica:auth=required\") {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tgen.EnableAuthForResource(\"%s\")\n", resource))
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif hasMarker(\"%s\", \"+fabrica:scale=enabled\") {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tgen.SetResourceTag(\"%s\", \"scale\", \"enabled\")\n", resource))
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif hasMarker(\"%s\", \"+fabrica:routing=name\") {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tgen.SetResourceTag(\"%s\", \"routing\", \"name\")\n", resource))
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif path := markerValue(\"%s\", \"+fabrica:path\"); path != \"\" {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tif err := gen.SetResourcePath(\"%s\", path); err != nil {\n", resource))
+		registrations.WriteString("\t\t\treturn err\n")
+		registrations.WriteString("\t\t}\n")
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif plural := markerValue(\"%s\", \"+fabrica:plural\"); plural != \"\" {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tif err := gen.SetResourcePlural(\"%s\", plural); err != nil {\n", resource))
+		registrations.WriteString("\t\t\treturn err\n")
+		registrations.WriteString("\t\t}\n")
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif names := markerValue(\"%s\", \"+fabrica:shortnames\"); names != \"\" {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tif err := gen.SetResourceShortNames(\"%s\", strings.Split(names, \",\")...); err != nil {\n", resource))
+		registrations.WriteString("\t\t\treturn err\n")
+		registrations.WriteString("\t\t}\n")
+		registrations.WriteString("\t}\n")
+		registrations.WriteString(fmt.Sprintf("\tif fields := markerValue(\"%s\", \"+fabrica:unique\"); fields != \"\" {\n", resource))
+		registrations.WriteString(fmt.Sprintf("\t\tif err := gen.SetResourceUnique(\"%s\", strings.Split(fields, \",\")...); err != nil {\n", resource))
+		registrations.WriteString("\t\t\treturn err\n")
+		registrations.WriteString("\t\t}\n")
+		registrations.WriteString("\t}\n")
 	}
 
 	return fmt.Sprintf(`// Code generated by fabrica codegen init. DO NOT EDIT.
@@ -677,8 +946,8 @@ func RegisterAllResources(gen *codegen.Generator) error {
 	return nil
 }
 
-	// hasVersioningMarker inspects the resource source file for the versioning marker comment.
-	func hasVersioningMarker(resourceName string) bool {
+	// hasMarker inspects the resource source file for a marker comment.
+	func hasMarker(resourceName, marker string) bool {
 		// Derive path: pkg/resources/<lower(resourceName)>/<lower(resourceName)>.go
 		pkg := strings.ToLower(resourceName)
 		path := filepath.Join("pkg", "resources", pkg, pkg+".go")
@@ -686,12 +955,53 @@ func RegisterAllResources(gen *codegen.Generator) error {
 		if err != nil {
 			return false
 		}
-		content := string(data)
-		return strings.Contains(content, "+fabrica:resource-versioning=enabled")
+		return strings.Contains(string(data), marker)
+	}
+
+	// markerValue returns the value of a "+fabrica:key=value" marker comment
+	// in the resource source file, or "" if it has none.
+	func markerValue(resourceName, marker string) string {
+		pkg := strings.ToLower(resourceName)
+		path := filepath.Join("pkg", "resources", pkg, pkg+".go")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+		_, rest, found := strings.Cut(string(data), marker+"=")
+		if !found {
+			return ""
+		}
+		value, _, _ := strings.Cut(rest, "\n")
+		return strings.TrimSpace(value)
 	}
 `, imports.String(), registrations.String())
 }
 
+// generateProtoCode runs 'go generate ./api/proto' to compile the generated
+// protobuf definitions into Go packages under pkg/grpc. It only prints
+// instructions if the protoc toolchain is not installed.
+func generateProtoCode(debug bool) error {
+	for _, tool := range []string{"protoc", "protoc-gen-go", "protoc-gen-go-grpc"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			fmt.Printf("⚠️  %s not found on PATH; skipping protobuf compilation\n", tool)
+			fmt.Println("   Install protoc, then run:")
+			fmt.Println("     go install google.golang.org/protobuf/cmd/protoc-gen-go@latest")
+			fmt.Println("     go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest")
+			fmt.Println("     go generate ./api/proto")
+			return nil
+		}
+	}
+
+	fmt.Println("🔄 Compiling protobuf definitions...")
+	protoCmd := exec.Command("go", "generate", "./api/proto")
+	if debug {
+		protoCmd.Stdout = os.Stdout
+	}
+	protoCmd.Stderr = os.Stderr
+
+	return protoCmd.Run()
+}
+
 // generateEntCode runs 'go generate ./internal/storage' to generate Ent client code
 // This is automatically called by 'fabrica generate' when Ent storage is detected
 func generateEntCode(debug bool) error {
@@ -0,0 +1,144 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/codegen"
+	"github.com/spf13/cobra"
+)
+
+// maxOpenAPIDocumentBytes bounds an OpenAPI document fetched from a service
+const maxOpenAPIDocumentBytes = 16 << 20
+
+func newGenerateGatewayCommand() *cobra.Command {
+	var (
+		services []string
+		specs    []string
+		output   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gateway --service <name>=<url> ...",
+		Short: "Generate a reverse proxy that serves several services from one endpoint",
+		Long: `Generate a chi-based gateway that routes each resource collection
+(/devices, /racks, ...) to the Fabrica service that owns it.
+
+The resource paths of each service are read from its OpenAPI document,
+fetched from <url>/openapi.json unless --spec names a local copy. The path of
+the document's server URL (e.g. /api/v1, set with the service's --api-prefix)
+is added when forwarding. Two services may not own the same path.
+
+The gateway forwards requests unchanged apart from the path, so Authorization
+and conditional headers (If-Match, If-None-Match) reach the backend.
+
+The routing table is written to <output>/gateway_generated.go on every run.
+<output>/main.go is only written if it does not exist yet.
+
+Example:
+  fabrica generate gateway \
+    --service inventory=http://inventory:8080 \
+    --service power=http://power:8080 --spec power=specs/power.json
+  go run ./cmd/gateway --port 8000
+`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if len(services) == 0 {
+				return fmt.Errorf("at least one --service is required")
+			}
+			specFiles, err := parseNameValues("--spec", specs)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("🌐 Generating gateway...")
+			var gatewayServices []codegen.GatewayService
+			for _, value := range services {
+				name, baseURL, ok := strings.Cut(value, "=")
+				if !ok {
+					return fmt.Errorf("invalid --service %q: want name=url", value)
+				}
+				data, source, err := readServiceDocument(baseURL, specFiles[name])
+				if err != nil {
+					return fmt.Errorf("service %s: %w", name, err)
+				}
+				delete(specFiles, name)
+
+				service, err := codegen.ParseGatewayService(name, baseURL, data)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("  📄 %s (%s): %d resource path(s)\n", name, source, len(service.Resources))
+				gatewayServices = append(gatewayServices, *service)
+			}
+			for name := range specFiles {
+				return fmt.Errorf("--spec given for unknown service %q", name)
+			}
+
+			if err := codegen.GenerateGateway(gatewayServices, output); err != nil {
+				return err
+			}
+
+			fmt.Println()
+			fmt.Println("✅ Gateway generation complete!")
+			fmt.Printf("  go run ./%s --port 8000\n", strings.TrimPrefix(output, "./"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&services, "service", nil, "Backend service as name=url (repeatable)")
+	cmd.Flags().StringArrayVar(&specs, "spec", nil, "Read a service's OpenAPI document from a file, as name=path (repeatable)")
+	cmd.Flags().StringVar(&output, "output", "cmd/gateway", "Output directory")
+
+	return cmd
+}
+
+// parseNameValues parses repeated name=value flag values into a map
+func parseNameValues(flag string, values []string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, "=")
+		if !ok || name == "" || v == "" {
+			return nil, fmt.Errorf("invalid %s %q: want name=value", flag, value)
+		}
+		result[name] = v
+	}
+	return result, nil
+}
+
+// readServiceDocument returns the OpenAPI document of the service at
+// baseURL, read from specPath if set and fetched from the service otherwise,
+// and where it came from
+func readServiceDocument(baseURL, specPath string) ([]byte, string, error) {
+	if specPath != "" {
+		data, err := os.ReadFile(specPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read OpenAPI document: %w", err)
+		}
+		return data, specPath, nil
+	}
+
+	docURL := strings.TrimRight(baseURL, "/") + "/openapi.json"
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(docURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch OpenAPI document (use --spec to read it from a file): %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch %s: %s", docURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOpenAPIDocumentBytes))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", docURL, err)
+	}
+	return data, docURL, nil
+}
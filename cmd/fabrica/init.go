@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/openchami/fabrica/pkg/codegen"
+	"github.com/openchami/fabrica/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -26,12 +27,13 @@ type initOptions struct {
 	withAuth    bool // Enable authentication
 	withStorage bool // Enable storage backend
 	withMetrics bool // Enable metrics/monitoring
+	withTracing bool // Enable OpenTelemetry tracing
 	withVersion bool // Enable version command
 
 	// New feature flags for core features
 	validationMode  string // strict, warn, disabled
 	withEvents      bool   // Enable CloudEvents support
-	eventBusType    string // memory, nats, kafka
+	eventBusType    string // memory, nats, kafka, noop
 	versionStrategy string // header, url, both
 
 	// Reconciliation options
@@ -52,6 +54,7 @@ type templateData struct {
 	WithAuth         bool
 	WithStorage      bool
 	WithMetrics      bool
+	WithTracing      bool
 	WithVersion      bool
 	WithReconcile    bool
 	WithEvents       bool
@@ -84,6 +87,7 @@ Instead of complex modes, use feature flags to customize your project:
   --auth          Enable authentication with TokenSmith
   --storage       Enable persistent storage (file or database)
   --metrics       Enable Prometheus metrics
+  --tracing       Enable OpenTelemetry tracing
 
 The interactive flag launches a guided wizard to help you choose.
 
@@ -96,6 +100,10 @@ or by providing the name of an existing directory.`,
 				projectName = args[0]
 			}
 
+			if _, err := storage.EntDriverName(opts.dbDriver); err != nil {
+				return fmt.Errorf("invalid --db: %w", err)
+			}
+
 			// If a non-default database driver is specified, automatically use ent storage
 			if opts.dbDriver == "postgres" || opts.dbDriver == "mysql" {
 				opts.storageType = "ent"
@@ -118,12 +126,13 @@ or by providing the name of an existing directory.`,
 	cmd.Flags().BoolVar(&opts.withAuth, "auth", false, "Enable authentication with TokenSmith")
 	cmd.Flags().BoolVar(&opts.withStorage, "storage", true, "Enable persistent storage")
 	cmd.Flags().BoolVar(&opts.withMetrics, "metrics", false, "Enable Prometheus metrics")
+	cmd.Flags().BoolVar(&opts.withTracing, "tracing", false, "Enable OpenTelemetry tracing")
 	cmd.Flags().BoolVar(&opts.withVersion, "version", true, "Enable version command")
 
 	// Core feature configuration
 	cmd.Flags().StringVar(&opts.validationMode, "validation-mode", "strict", "Validation mode: strict, warn, or disabled")
 	cmd.Flags().BoolVar(&opts.withEvents, "events", false, "Enable CloudEvents support")
-	cmd.Flags().StringVar(&opts.eventBusType, "events-bus", "memory", "Event bus type: memory, nats, or kafka")
+	cmd.Flags().StringVar(&opts.eventBusType, "events-bus", "memory", "Event bus type: memory, nats, kafka, or noop (nats and kafka fall back to memory unless a factory is registered)")
 	cmd.Flags().StringVar(&opts.versionStrategy, "version-strategy", "header", "API versioning strategy: header, url, or both")
 
 	// Reconciliation configuration
@@ -223,6 +232,11 @@ func runInteractiveInit(projectName string, opts *initOptions) error {
 	input, _ = reader.ReadString('\n')
 	opts.withMetrics = strings.HasPrefix(strings.ToLower(strings.TrimSpace(input)), "y")
 
+	// Tracing
+	fmt.Print("Enable OpenTelemetry tracing? [y/N]: ")
+	input, _ = reader.ReadString('\n')
+	opts.withTracing = strings.HasPrefix(strings.ToLower(strings.TrimSpace(input)), "y")
+
 	// Summary
 	fmt.Println()
 	fmt.Println("📋 Summary:")
@@ -243,6 +257,7 @@ func runInteractiveInit(projectName string, opts *initOptions) error {
 		fmt.Printf("    Storage: disabled\n")
 	}
 	fmt.Printf("    Metrics: %s\n", map[bool]string{true: "enabled", false: "disabled"}[opts.withMetrics])
+	fmt.Printf("    Tracing: %s\n", map[bool]string{true: "enabled", false: "disabled"}[opts.withTracing])
 
 	fmt.Print("\nProceed? [Y/n]: ")
 	input, _ = reader.ReadString('\n')
@@ -316,9 +331,9 @@ func runInit(projectName string, opts *initOptions) error {
 
 func createProjectStructure(targetDir, projectName string, opts *initOptions) error {
 	// Normalize database driver name (sqlite -> sqlite3 for Go driver compatibility)
-	dbDriver := opts.dbDriver
-	if dbDriver == "sqlite" {
-		dbDriver = "sqlite3"
+	dbDriver, err := storage.EntDriverName(opts.dbDriver)
+	if err != nil {
+		return err
 	}
 
 	// Template data
@@ -329,6 +344,7 @@ func createProjectStructure(targetDir, projectName string, opts *initOptions) er
 		WithAuth:         opts.withAuth,
 		WithStorage:      opts.withStorage,
 		WithMetrics:      opts.withMetrics,
+		WithTracing:      opts.withTracing,
 		WithVersion:      opts.withVersion,
 		WithReconcile:    opts.withReconcile,
 		WithEvents:       opts.withEvents,
@@ -440,6 +456,9 @@ func generateFeaturesText(data templateData) string {
 	if data.WithMetrics {
 		features = append(features, "- 📊 Prometheus metrics")
 	}
+	if data.WithTracing {
+		features = append(features, "- 🔭 OpenTelemetry tracing")
+	}
 
 	if len(features) == 0 {
 		return "- Basic REST API server"
@@ -461,13 +480,14 @@ func createFabricaConfig(targetDir string, opts *initOptions) error {
 	}
 
 	// Normalize database driver name (sqlite -> sqlite3 for Go driver compatibility)
-	dbDriver := opts.dbDriver
-	if dbDriver == "sqlite" {
-		dbDriver = "sqlite3"
+	dbDriver, err := storage.EntDriverName(opts.dbDriver)
+	if err != nil {
+		return err
 	}
 
 	// Build configuration from options
 	config := &FabricaConfig{
+		SchemaVersion: CurrentConfigSchemaVersion,
 		Project: ProjectConfig{
 			Name:        projectName,
 			Module:      opts.modulePath,
@@ -476,8 +496,9 @@ func createFabricaConfig(targetDir string, opts *initOptions) error {
 		},
 		Features: FeaturesConfig{
 			Validation: ValidationConfig{
-				Enabled: opts.validationMode != "disabled",
-				Mode:    opts.validationMode,
+				Enabled:    opts.validationMode != "disabled",
+				Mode:       opts.validationMode,
+				References: "enforce",
 			},
 			Events: EventsConfig{
 				Enabled: opts.withEvents,
@@ -503,6 +524,9 @@ func createFabricaConfig(targetDir string, opts *initOptions) error {
 			Metrics: MetricsConfig{
 				Enabled: opts.withMetrics,
 			},
+			Tracing: TracingConfig{
+				Enabled: opts.withTracing,
+			},
 			Reconciliation: ReconciliationConfig{
 				Enabled:      opts.withReconcile,
 				WorkerCount:  opts.reconcileWorkers,
@@ -0,0 +1,616 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// jsonSchema is the subset of JSON Schema understood by
+// 'fabrica add resource --from-json-schema'
+type jsonSchema struct {
+	Title                string                 `json:"title"`
+	Description          string                 `json:"description"`
+	Type                 schemaTypes            `json:"type"`
+	Format               string                 `json:"format"`
+	Ref                  string                 `json:"$ref"`
+	Properties           schemaProperties       `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	ExclusiveMinimum     json.RawMessage        `json:"exclusiveMinimum"`
+	ExclusiveMaximum     json.RawMessage        `json:"exclusiveMaximum"`
+	AllOf                []*jsonSchema          `json:"allOf"`
+	AnyOf                []*jsonSchema          `json:"anyOf"`
+	OneOf                []*jsonSchema          `json:"oneOf"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+}
+
+// schemaTypes accepts both "type": "string" and "type": ["string", "null"]
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = multiple
+	return nil
+}
+
+// schemaProperty is one entry of "properties"
+type schemaProperty struct {
+	name   string
+	schema *jsonSchema
+}
+
+// schemaProperties keeps properties in document order, so generated fields
+// appear in the order the schema author wrote them
+type schemaProperties []schemaProperty
+
+func (p *schemaProperties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("properties must be an object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		schema := &jsonSchema{}
+		if err := dec.Decode(schema); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+		*p = append(*p, schemaProperty{name: name, schema: schema})
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// loadJSONSchema reads and parses a JSON Schema file
+func loadJSONSchema(path string) (*jsonSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON Schema: %w", err)
+	}
+	schema := &jsonSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON Schema %s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// schemaField is a Go struct field generated from a schema property
+type schemaField struct {
+	comments []string
+	name     string
+	goType   string
+	tags     []string
+}
+
+// schemaStruct is a Go struct generated from an object schema
+type schemaStruct struct {
+	name   string
+	doc    string
+	fields []schemaField
+}
+
+// schemaConverter turns a JSON Schema into Go struct declarations.
+// Constructs without a Go equivalent become interface{} fields with a
+// warning comment instead of failing the conversion.
+type schemaConverter struct {
+	root           *jsonSchema
+	withValidation bool
+
+	// nested holds struct declarations for nested objects, in order
+	nested   []schemaStruct
+	used     map[string]bool
+	refs     map[string]string
+	warnings []string
+}
+
+// convertJSONSchema converts the root object schema into the fields of
+// specName and the declarations of any nested structs it needs
+func convertJSONSchema(root *jsonSchema, specName string, withValidation bool) ([]schemaField, []schemaStruct, []string, error) {
+	c := &schemaConverter{
+		root:           root,
+		withValidation: withValidation,
+		used:           map[string]bool{specName: true},
+		refs:           make(map[string]string),
+	}
+
+	schema, err := c.resolve(root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !schema.isObject() || len(schema.Properties) == 0 {
+		return nil, nil, nil, fmt.Errorf("JSON Schema root must be an object with properties")
+	}
+
+	fields := c.fields(specName, schema)
+	return fields, c.nested, c.warnings, nil
+}
+
+// resolve follows local $ref pointers and single-element allOf wrappers
+func (c *schemaConverter) resolve(s *jsonSchema) (*jsonSchema, error) {
+	for depth := 0; ; depth++ {
+		if depth > 32 {
+			return nil, fmt.Errorf("$ref chain too deep")
+		}
+		switch {
+		case s.Ref != "":
+			target, _, err := c.lookupRef(s.Ref)
+			if err != nil {
+				return nil, err
+			}
+			s = target
+		case len(s.AllOf) == 1 && s.isEmptyBesidesAllOf():
+			s = s.AllOf[0]
+		default:
+			return s, nil
+		}
+	}
+}
+
+// lookupRef finds a "#/definitions/Name" or "#/$defs/Name" target
+func (c *schemaConverter) lookupRef(ref string) (*jsonSchema, string, error) {
+	for prefix, defs := range map[string]map[string]*jsonSchema{
+		"#/definitions/": c.root.Definitions,
+		"#/$defs/":       c.root.Defs,
+	} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			if target, ok := defs[name]; ok {
+				return target, name, nil
+			}
+			return nil, "", fmt.Errorf("$ref %s not found", ref)
+		}
+	}
+	return nil, "", fmt.Errorf("$ref %s is not a local definition", ref)
+}
+
+func (c *schemaConverter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// fields converts the properties of an object schema owned by structName
+func (c *schemaConverter) fields(structName string, s *jsonSchema) []schemaField {
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	fields := make([]schemaField, 0, len(s.Properties))
+	seen := make(map[string]bool, len(s.Properties))
+	for _, prop := range s.Properties {
+		name := goFieldName(prop.name)
+		for base, i := name, 2; seen[name]; i++ {
+			name = base + strconv.Itoa(i)
+		}
+		seen[name] = true
+
+		field := schemaField{name: name}
+		if desc := strings.Join(strings.Fields(prop.schema.Description), " "); desc != "" {
+			field.comments = append(field.comments, desc)
+		}
+
+		goType, kind, rules, warnings := c.goType(structName+name, prop.schema)
+		for _, w := range warnings {
+			c.warn("%s.%s: %s", structName, prop.name, w)
+			field.comments = append(field.comments, "Warning: "+w)
+		}
+		field.goType = goType
+
+		jsonTag := prop.name
+		if !required[prop.name] {
+			jsonTag += ",omitempty"
+		}
+		field.tags = append(field.tags, tagPair("json", jsonTag))
+
+		if c.withValidation {
+			var validate []string
+			switch {
+			case required[prop.name] && kind.requirable():
+				validate = append(validate, "required")
+			case !required[prop.name] && len(rules.validate) > 0:
+				validate = append(validate, "omitempty")
+			}
+			validate = append(validate, rules.validate...)
+			if len(validate) > 0 {
+				field.tags = append(field.tags, tagPair("validate", strings.Join(validate, ",")))
+			}
+			if rules.pattern != "" {
+				field.tags = append(field.tags, tagPair("pattern", rules.pattern))
+			}
+		}
+
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// goKind classifies a generated type for choosing validate tags
+type goKind int
+
+const (
+	kindScalar goKind = iota
+	kindString
+	kindCollection
+	kindStruct
+	kindAny
+)
+
+// requirable reports whether "required" means "present" for the kind.
+// Numbers and booleans are excluded because required rejects their zero
+// values, and structs because validator ignores required on them.
+func (k goKind) requirable() bool {
+	return k == kindString || k == kindCollection || k == kindAny
+}
+
+// schemaRules are the validation rules derived from a schema
+type schemaRules struct {
+	validate []string
+	pattern  string
+}
+
+// goType returns the Go type for s, naming any nested struct typeName
+func (c *schemaConverter) goType(typeName string, s *jsonSchema) (string, goKind, schemaRules, []string) {
+	var warnings []string
+	var rules schemaRules
+
+	if s.Ref != "" {
+		target, defName, err := c.lookupRef(s.Ref)
+		if err != nil {
+			return "interface{}", kindAny, rules, []string{err.Error()}
+		}
+		if target.isObject() && len(target.Properties) > 0 {
+			return c.refStruct(defName, target), kindStruct, rules, nil
+		}
+		return c.goType(typeName, target)
+	}
+	if len(s.AllOf) == 1 && s.isEmptyBesidesAllOf() {
+		return c.goType(typeName, s.AllOf[0])
+	}
+	if len(s.AllOf) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 {
+		return "interface{}", kindAny, rules, []string{"allOf/anyOf/oneOf is not supported; using interface{}"}
+	}
+
+	schemaType, ok := s.primaryType()
+	if !ok {
+		return "interface{}", kindAny, rules, []string{fmt.Sprintf("type %v is not supported; using interface{}", []string(s.Type))}
+	}
+
+	switch schemaType {
+	case "string":
+		rules.validate = appendLength(rules.validate, s.MinLength, s.MaxLength)
+		if tag, ok := formatTags[s.Format]; ok {
+			rules.validate = append(rules.validate, tag)
+		} else if s.Format != "" {
+			warnings = append(warnings, fmt.Sprintf("format %q is not enforced", s.Format))
+		}
+		if s.Pattern != "" {
+			rules.pattern = s.Pattern
+			rules.validate = append(rules.validate, "pattern")
+		}
+		if tag, warning := enumTag(s.Enum); tag != "" {
+			rules.validate = append(rules.validate, tag)
+		} else if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		return "string", kindString, rules, warnings
+
+	case "integer", "number":
+		goType := "int64"
+		if schemaType == "number" {
+			goType = "float64"
+		}
+		rules.validate = appendBound(rules.validate, "gte", "gt", s.Minimum, s.ExclusiveMinimum)
+		rules.validate = appendBound(rules.validate, "lte", "lt", s.Maximum, s.ExclusiveMaximum)
+		if tag, warning := enumTag(s.Enum); tag != "" {
+			rules.validate = append(rules.validate, tag)
+		} else if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		return goType, kindScalar, rules, warnings
+
+	case "boolean":
+		return "bool", kindScalar, rules, nil
+
+	case "array":
+		rules.validate = appendLength(rules.validate, s.MinItems, s.MaxItems)
+		if s.Items == nil {
+			return "[]interface{}", kindCollection, rules, []string{"array without items; using []interface{}"}
+		}
+		elemType, _, elemRules, elemWarnings := c.goType(typeName+"Item", s.Items)
+		if len(elemRules.validate) > 0 {
+			rules.validate = append(rules.validate, "dive")
+			rules.validate = append(rules.validate, elemRules.validate...)
+		}
+		rules.pattern = elemRules.pattern
+		return "[]" + elemType, kindCollection, rules, elemWarnings
+
+	case "object":
+		if len(s.Properties) > 0 {
+			return c.nestedStruct(typeName, s), kindStruct, rules, nil
+		}
+		valueType, valueWarning := "interface{}", ""
+		if extra := s.additionalSchema(); extra != nil {
+			var valueWarnings []string
+			valueType, _, _, valueWarnings = c.goType(typeName+"Value", extra)
+			if len(valueWarnings) > 0 {
+				valueWarning = strings.Join(valueWarnings, "; ")
+			}
+		}
+		if valueWarning != "" {
+			warnings = append(warnings, valueWarning)
+		}
+		return "map[string]" + valueType, kindCollection, rules, warnings
+	}
+
+	return "interface{}", kindAny, rules, []string{fmt.Sprintf("type %q is not supported; using interface{}", schemaType)}
+}
+
+// nestedStruct declares a struct for an inline object schema
+func (c *schemaConverter) nestedStruct(name string, s *jsonSchema) string {
+	for base, i := name, 2; c.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	c.used[name] = true
+
+	// Reserve the slot before converting fields so parents precede children
+	index := len(c.nested)
+	c.nested = append(c.nested, schemaStruct{})
+	c.nested[index] = schemaStruct{name: name, doc: s.Description, fields: c.fields(name, s)}
+	return name
+}
+
+// refStruct declares (once) a struct for a shared definition
+func (c *schemaConverter) refStruct(defName string, s *jsonSchema) string {
+	if name, ok := c.refs[defName]; ok {
+		return name
+	}
+	name := goFieldName(defName)
+	for base, i := name, 2; c.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	c.used[name] = true
+	c.refs[defName] = name
+
+	index := len(c.nested)
+	c.nested = append(c.nested, schemaStruct{})
+	c.nested[index] = schemaStruct{name: name, doc: s.Description, fields: c.fields(name, s)}
+	return name
+}
+
+func (s *jsonSchema) isObject() bool {
+	t, ok := s.primaryType()
+	return ok && t == "object"
+}
+
+// primaryType returns the schema's type, ignoring "null" and inferring the
+// type from properties, items or enum values when it is omitted
+func (s *jsonSchema) primaryType() (string, bool) {
+	var types []string
+	for _, t := range s.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	switch len(types) {
+	case 1:
+		return types[0], true
+	case 0:
+		switch {
+		case len(s.Properties) > 0:
+			return "object", true
+		case s.Items != nil:
+			return "array", true
+		case len(s.Enum) > 0:
+			if _, ok := s.Enum[0].(string); ok {
+				return "string", true
+			}
+			if _, ok := s.Enum[0].(float64); ok {
+				return "number", true
+			}
+		}
+	}
+	return "", false
+}
+
+// isEmptyBesidesAllOf reports whether allOf is the schema's only content
+func (s *jsonSchema) isEmptyBesidesAllOf() bool {
+	return len(s.Type) == 0 && len(s.Properties) == 0 && s.Items == nil && s.Ref == ""
+}
+
+// additionalSchema returns the additionalProperties schema, if it is one
+func (s *jsonSchema) additionalSchema() *jsonSchema {
+	if len(s.AdditionalProperties) == 0 || s.AdditionalProperties[0] != '{' {
+		return nil
+	}
+	extra := &jsonSchema{}
+	if err := json.Unmarshal(s.AdditionalProperties, extra); err != nil {
+		return nil
+	}
+	return extra
+}
+
+// formatTags maps JSON Schema string formats to validator tags
+var formatTags = map[string]string{
+	"email":     "email",
+	"uri":       "url",
+	"hostname":  "hostname",
+	"ipv4":      "ipv4",
+	"ipv6":      "ipv6",
+	"mac":       "mac",
+	"uuid":      "uuid",
+	"date-time": "datetime=2006-01-02T15:04:05Z07:00",
+	"date":      "datetime=2006-01-02",
+}
+
+func appendLength(rules []string, minimum, maximum *int) []string {
+	if minimum != nil && *minimum > 0 {
+		rules = append(rules, fmt.Sprintf("min=%d", *minimum))
+	}
+	if maximum != nil {
+		rules = append(rules, fmt.Sprintf("max=%d", *maximum))
+	}
+	return rules
+}
+
+// appendBound adds an inclusive or exclusive numeric bound. exclusive is a
+// number (draft 6+) or a boolean modifying bound (draft 4).
+func appendBound(rules []string, inclusiveTag, exclusiveTag string, bound *float64, exclusive json.RawMessage) []string {
+	var exclusiveValue float64
+	if json.Unmarshal(exclusive, &exclusiveValue) == nil {
+		return append(rules, exclusiveTag+"="+formatNumber(exclusiveValue))
+	}
+	if bound == nil {
+		return rules
+	}
+	var isExclusive bool
+	if json.Unmarshal(exclusive, &isExclusive) == nil && isExclusive {
+		return append(rules, exclusiveTag+"="+formatNumber(*bound))
+	}
+	return append(rules, inclusiveTag+"="+formatNumber(*bound))
+}
+
+// enumTag converts enum values to a oneof tag, or explains why it cannot
+func enumTag(values []interface{}) (tag, warning string) {
+	if len(values) == 0 {
+		return "", ""
+	}
+	params := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			switch {
+			case strings.ContainsAny(v, ",|'"):
+				return "", fmt.Sprintf("enum value %q cannot be expressed with oneof", v)
+			case v == "" || strings.ContainsFunc(v, unicode.IsSpace):
+				params = append(params, "'"+v+"'")
+			default:
+				params = append(params, v)
+			}
+		case float64:
+			params = append(params, formatNumber(v))
+		default:
+			return "", fmt.Sprintf("enum value %v is not supported", value)
+		}
+	}
+	return "oneof=" + strings.Join(params, " "), ""
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func tagPair(key, value string) string {
+	return key + ":" + strconv.Quote(value)
+}
+
+// commonInitialisms are written in upper case in Go field names
+var commonInitialisms = map[string]bool{
+	"API": true, "BMC": true, "CPU": true, "DNS": true, "GPU": true, "HTTP": true,
+	"ID": true, "IP": true, "JSON": true, "MAC": true, "OS": true,
+	"UID": true, "URI": true, "URL": true, "UUID": true,
+}
+
+// goFieldName converts a property name like "mac_address" or "ipAddr" to an
+// exported Go identifier ("MACAddress", "IPAddr")
+func goFieldName(name string) string {
+	var words []string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words = append(words, splitCamel(part)...)
+	}
+
+	var b strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	result := b.String()
+	if result == "" {
+		return "Field"
+	}
+	if unicode.IsDigit([]rune(result)[0]) {
+		result = "F" + result
+	}
+	return result
+}
+
+// splitCamel splits "ipAddr" into "ip" and "Addr"
+func splitCamel(word string) []string {
+	var words []string
+	runes := []rune(word)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// renderSchemaFields writes struct fields, one per line
+func renderSchemaFields(b *strings.Builder, fields []schemaField) {
+	for _, field := range fields {
+		for _, comment := range field.comments {
+			fmt.Fprintf(b, "\t// %s\n", comment)
+		}
+		fmt.Fprintf(b, "\t%s %s %s\n", field.name, field.goType, tagLiteral(strings.Join(field.tags, " ")))
+	}
+}
+
+// tagLiteral writes a struct tag as a raw string literal, or as an
+// interpreted one if the tag contains a backquote (e.g. from a pattern)
+func tagLiteral(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// renderSchemaStructs writes the declarations of nested structs
+func renderSchemaStructs(b *strings.Builder, structs []schemaStruct) {
+	for _, s := range structs {
+		fmt.Fprintf(b, "\n// %s is generated from the JSON Schema\n", s.name)
+		if doc := strings.Join(strings.Fields(s.doc), " "); doc != "" {
+			fmt.Fprintf(b, "// %s\n", doc)
+		}
+		fmt.Fprintf(b, "type %s struct {\n", s.name)
+		renderSchemaFields(b, s.fields)
+		b.WriteString("}\n")
+	}
+}
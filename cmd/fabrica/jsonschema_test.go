@@ -0,0 +1,100 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// TestGenerateResourceFile_JSONSchema converts each testdata/jsonschema/*.json
+// and compares the resource file with the .golden file next to it. Run with
+// -update to rewrite the golden files.
+func TestGenerateResourceFile_JSONSchema(t *testing.T) {
+	schemas, err := filepath.Glob(filepath.Join("testdata", "jsonschema", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemas) == 0 {
+		t.Fatal("no schemas in testdata/jsonschema")
+	}
+
+	for _, schemaPath := range schemas {
+		name := strings.TrimSuffix(filepath.Base(schemaPath), ".json")
+		t.Run(name, func(t *testing.T) {
+			schema, err := loadJSONSchema(schemaPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := filepath.Join(t.TempDir(), name+".go")
+			opts := &addOptions{packageName: name, withValidation: true}
+			if err := generateResourceFile(out, "Device", opts, schema); err != nil {
+				t.Fatalf("generateResourceFile failed: %v", err)
+			}
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := strings.TrimSuffix(schemaPath, ".json") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("%s differs from %s:\n%s", out, golden, got)
+			}
+		})
+	}
+}
+
+func TestTagLiteral(t *testing.T) {
+	tests := []struct {
+		tag, want string
+	}{
+		{`json:"name"`, "`json:\"name\"`"},
+		{"pattern:\"^[a-z`]+$\"", `"pattern:\"^[a-z` + "`" + `]+$\""`},
+	}
+	for _, tt := range tests {
+		if got := tagLiteral(tt.tag); got != tt.want {
+			t.Errorf("tagLiteral(%q) = %s, want %s", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestGoFieldName(t *testing.T) {
+	tests := map[string]string{
+		"mac_address": "MACAddress",
+		"ipAddr":      "IPAddr",
+		"speed-gbps":  "SpeedGbps",
+		"2fa":         "F2fa",
+		"_":           "Field",
+	}
+	for name, want := range tests {
+		if got := goFieldName(name); got != want {
+			t.Errorf("goFieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLoadJSONSchema_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(`{"type": "object", "properties": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadJSONSchema(path); err == nil {
+		t.Error("loadJSONSchema accepted truncated JSON")
+	}
+}
@@ -40,6 +40,11 @@ The CLI provides commands for:
 	rootCmd.AddCommand(newAddCommand())
 	rootCmd.AddCommand(newGenerateCommand())
 	rootCmd.AddCommand(newEntCommand())
+	rootCmd.AddCommand(newDoctorCommand())
+	rootCmd.AddCommand(newDocsCommand())
+	rootCmd.AddCommand(newConfigCommand())
+	rootCmd.AddCommand(newExportCommand())
+	rootCmd.AddCommand(newImportCommand())
 	rootCmd.AddCommand(newVersionCommand())
 
 	if err := rootCmd.Execute(); err != nil {
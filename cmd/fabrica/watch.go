@@ -0,0 +1,186 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long to wait after the last change before regenerating,
+// so that a burst of saves (or a multi-file refactor) triggers a single run
+const watchDebounce = 500 * time.Millisecond
+
+// watchResources watches pkg/resources and re-runs generation when resource
+// definitions change. It blocks until interrupted with Ctrl-C.
+//
+// Generation errors are reported without exiting so the next save can fix
+// them. The registration file is only rewritten when resources are added or
+// removed; other changes re-run the generators selected by opts.
+func watchResources(modulePath string, resources []string, opts generateOptions) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close() //nolint:errcheck
+
+	resourcesDir := filepath.Join("pkg", "resources")
+	if err := addWatchDirs(watcher, resourcesDir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", resourcesDir, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("👀 Watching %s/ for changes (Ctrl-C to stop)...\n", resourcesDir)
+
+	changed := make(map[string]bool)
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			fmt.Println("👋 Stopped watching")
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			// fsnotify is not recursive; pick up newly added resource packages
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addWatchDirs(watcher, event.Name); err != nil {
+						fmt.Printf("⚠️  Failed to watch %s: %v\n", event.Name, err)
+					}
+				}
+			}
+
+			if event.Op == fsnotify.Chmod || !isResourceSource(event.Name) {
+				continue
+			}
+			changed[event.Name] = true
+			debounce = time.After(watchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("⚠️  Watch error: %v\n", err)
+
+		case <-debounce:
+			debounce = nil
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			changed = make(map[string]bool)
+
+			resources = regenerateChanged(modulePath, resources, files, opts)
+		}
+	}
+}
+
+// regenerateChanged re-runs generation after the given files changed and
+// returns the current resource list
+func regenerateChanged(modulePath string, previous, files []string, opts generateOptions) []string {
+	fmt.Println()
+	fmt.Printf("🔄 Changed: %s\n", strings.Join(files, ", "))
+
+	resources, err := discoverResources()
+	if err != nil {
+		fmt.Printf("❌ Failed to discover resources: %v\n", err)
+		return previous
+	}
+	if len(resources) == 0 {
+		fmt.Println("⚠️  No resources found in pkg/resources/")
+		return resources
+	}
+
+	// Only rewrite the registration file when the set of resources changed
+	if !slices.Equal(resources, previous) {
+		outputPath, err := writeRegistrationFile(modulePath, resources)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return previous
+		}
+		fmt.Printf("📝 Updated %s (%s)\n", outputPath, strings.Join(resources, ", "))
+	}
+
+	start := time.Now()
+	if err := runGenerationSteps(modulePath, opts); err != nil {
+		fmt.Printf("❌ Regeneration failed: %v\n", err)
+		fmt.Println("👀 Still watching...")
+		return resources
+	}
+
+	fmt.Printf("✅ Regenerated %s in %s\n", strings.Join(regeneratedArtifacts(opts), ", "), time.Since(start).Round(time.Millisecond))
+	fmt.Println("👀 Still watching...")
+	return resources
+}
+
+// regeneratedArtifacts describes what a generation run with opts produces
+func regeneratedArtifacts(opts generateOptions) []string {
+	var artifacts []string
+	if opts.handlers {
+		artifacts = append(artifacts, "handlers")
+	}
+	if opts.storage {
+		artifacts = append(artifacts, "storage")
+	}
+	if opts.openapi {
+		artifacts = append(artifacts, "openapi")
+	}
+	if opts.handlers || opts.storage || opts.openapi || opts.grpc {
+		artifacts = append(artifacts, "routes", "models")
+	}
+	if opts.grpc {
+		artifacts = append(artifacts, "grpc")
+	}
+	if opts.client {
+		artifacts = append(artifacts, "client")
+	}
+	return artifacts
+}
+
+// addWatchDirs adds root and all of its subdirectories to the watcher
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// isResourceSource reports whether a changed file can affect generated code.
+// Generated files, tests and editor temporaries are ignored so that
+// regeneration does not trigger itself.
+func isResourceSource(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasSuffix(name, ".go") &&
+		!strings.HasSuffix(name, "_generated.go") &&
+		!strings.HasSuffix(name, "_test.go") &&
+		!strings.HasPrefix(name, ".")
+}
@@ -0,0 +1,141 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestGenerateWatch_Arguments(t *testing.T) {
+	// Outside a Go module there is nothing to watch
+	chdir(t, t.TempDir())
+	err := runCommand(newGenerateCommand(), "--watch")
+	if err == nil || !strings.Contains(err.Error(), "failed to read module path") {
+		t.Errorf("Expected a module path error, got %v", err)
+	}
+
+	// Without resources the command returns instead of watching
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/watch\n"})
+	chdir(t, dir)
+	if err := runCommand(newGenerateCommand(), "--watch"); err != nil {
+		t.Errorf("Expected no error without resources, got %v", err)
+	}
+}
+
+func TestRegenerateChanged(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, backupProjectFiles)
+	chdir(t, dir)
+
+	regFile := filepath.Join("pkg", "resources", "register_generated.go")
+	changed := []string{"pkg/resources/device/device.go"}
+
+	// A new resource rewrites the registration file
+	resources := regenerateChanged("example.com/watch", nil, changed, generateOptions{})
+	if !reflect.DeepEqual(resources, []string{"Device"}) {
+		t.Fatalf("Expected [Device], got %v", resources)
+	}
+	data, err := os.ReadFile(regFile)
+	if err != nil {
+		t.Fatalf("Registration file not written: %v", err)
+	}
+	if !strings.Contains(string(data), "example.com/watch/pkg/resources/device") {
+		t.Errorf("Registration file does not import the resource:\n%s", data)
+	}
+
+	// An edit that keeps the same resources leaves it alone
+	if err := os.Remove(regFile); err != nil {
+		t.Fatal(err)
+	}
+	if resources := regenerateChanged("example.com/watch", resources, changed, generateOptions{}); !reflect.DeepEqual(resources, []string{"Device"}) {
+		t.Errorf("Expected [Device], got %v", resources)
+	}
+	if _, err := os.Stat(regFile); !os.IsNotExist(err) {
+		t.Error("Registration file rewritten without a resource change")
+	}
+
+	// Removing the last resource is reported, not regenerated
+	if err := os.RemoveAll(filepath.Join("pkg", "resources", "device")); err != nil {
+		t.Fatal(err)
+	}
+	if resources := regenerateChanged("example.com/watch", resources, changed, generateOptions{}); len(resources) != 0 {
+		t.Errorf("Expected no resources, got %v", resources)
+	}
+}
+
+func TestRegeneratedArtifacts(t *testing.T) {
+	tests := []struct {
+		opts generateOptions
+		want []string
+	}{
+		{generateOptions{}, nil},
+		{generateOptions{client: true}, []string{"client"}},
+		{generateOptions{handlers: true, storage: true, openapi: true, client: true},
+			[]string{"handlers", "storage", "openapi", "routes", "models", "client"}},
+		{generateOptions{grpc: true}, []string{"routes", "models", "grpc"}},
+	}
+	for _, tt := range tests {
+		if got := regeneratedArtifacts(tt.opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("regeneratedArtifacts(%+v) = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestAddWatchDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, sub := range []string{"device", "rack/v2", ".git/objects"} {
+		if err := os.MkdirAll(filepath.Join(root, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close() //nolint:errcheck
+
+	if err := addWatchDirs(watcher, root); err != nil {
+		t.Fatalf("addWatchDirs failed: %v", err)
+	}
+
+	got := watcher.WatchList()
+	slices.Sort(got)
+	want := []string{root, filepath.Join(root, "device"), filepath.Join(root, "rack"), filepath.Join(root, "rack", "v2")}
+	slices.Sort(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if err := addWatchDirs(watcher, filepath.Join(root, "missing")); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestIsResourceSource(t *testing.T) {
+	tests := map[string]bool{
+		"pkg/resources/device/device.go":          true,
+		"pkg/resources/device/types.go":           true,
+		"pkg/resources/register_generated.go":     false,
+		"pkg/resources/device/device_test.go":     false,
+		"pkg/resources/device/.device.go.swp":     false,
+		"pkg/resources/device/.#device.go":        false,
+		"pkg/resources/device/device.go~":         false,
+		"pkg/resources/device/device.schema.json": false,
+	}
+	for path, want := range tests {
+		if got := isResourceSource(path); got != want {
+			t.Errorf("isResourceSource(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package auth authenticates requests with bearer JWTs.
+//
+// A Verifier checks a token's signature against a shared secret (HS256,
+// HS384, HS512), a static public key, or the keys published at a JWKS URL
+// (RS*, PS*, ES*, EdDSA), then checks its exp, nbf, iss and aud claims.
+// Tokens without exp are rejected unless Config.AllowMissingExpiry is set.
+// Middleware rejects requests without a valid token with 401 and stores the
+// verified Claims in the request context:
+//
+//	verifier, err := auth.NewVerifier(auth.Config{
+//	    JWKSURL:  "https://tokensmith.example.com/.well-known/jwks.json",
+//	    Issuer:   "https://tokensmith.example.com",
+//	    Audience: "inventory",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	r.With(auth.Middleware(verifier)).Get("/devices", ListDevices)
+//
+//	func ListDevices(w http.ResponseWriter, r *http.Request) {
+//	    subject, _ := auth.SubjectFromContext(r.Context())
+//	    ...
+//	}
+//
+// The generated server applies the middleware only to the routes of
+// resources marked with "+fabrica:auth=required", using the Verifier
+// installed with SetDefault.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/openchami/fabrica/pkg/httperror"
+	"github.com/openchami/fabrica/pkg/logging"
+)
+
+// SubjectKey is the log attribute that holds the authenticated subject.
+const SubjectKey = "subject"
+
+// ErrMissingToken is returned when a request carries no bearer token.
+var ErrMissingToken = errors.New("missing bearer token")
+
+type claimsKey struct{}
+
+// WithClaims attaches verified claims to ctx.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims stored by Middleware.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// SubjectFromContext returns the "sub" claim of the authenticated caller.
+// It reports false for unauthenticated requests, including requests to
+// routes that do not require authentication.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.Subject == "" {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer" header.
+func BearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", ErrMissingToken
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", ErrMissingToken
+	}
+	return token, nil
+}
+
+var (
+	defaultVerifier      *Verifier
+	defaultVerifierMutex sync.RWMutex
+)
+
+// SetDefault installs the verifier used by Middleware(nil). Pass nil to
+// disable authentication.
+func SetDefault(v *Verifier) {
+	defaultVerifierMutex.Lock()
+	defer defaultVerifierMutex.Unlock()
+	defaultVerifier = v
+}
+
+// Default returns the verifier used by Middleware(nil), or nil if none is set.
+func Default() *Verifier {
+	defaultVerifierMutex.RLock()
+	defer defaultVerifierMutex.RUnlock()
+	return defaultVerifier
+}
+
+// Middleware requires a valid bearer token on every request.
+//
+// Requests without one get a 401 problem response with a WWW-Authenticate
+// header. Authenticated requests carry their Claims in the context, and the
+// request logger (see logging.FromContext) gains a subject attribute.
+//
+// A nil v uses Default() at request time; if that is also nil, requests pass
+// through unauthenticated. With Config.NonEnforcing, failures are logged and
+// the request is served without claims.
+func Middleware(v *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			verifier := v
+			if verifier == nil {
+				verifier = Default()
+			}
+			if verifier == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			token, err := BearerToken(r)
+			var claims *Claims
+			if err == nil {
+				claims, err = verifier.Verify(ctx, token)
+			}
+			if err != nil {
+				logger := logging.FromContext(ctx)
+				if verifier.config.NonEnforcing {
+					logger.Warn("authentication failed; serving request (non-enforcing)", "error", err)
+					next.ServeHTTP(w, r)
+					return
+				}
+				logger.Warn("authentication failed", "error", err)
+				writeUnauthorized(w, r, err)
+				return
+			}
+
+			ctx = WithClaims(ctx, claims)
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(SubjectKey, claims.Subject))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeUnauthorized sends a 401 problem with the RFC 6750 challenge.
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	challenge := "Bearer"
+	if !errors.Is(err, ErrMissingToken) {
+		challenge = `Bearer error="invalid_token"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	httperror.WriteError(w, r, http.StatusUnauthorized, err)
+}
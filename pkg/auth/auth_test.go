@@ -0,0 +1,347 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signHS256 builds an HS256 token for claims.
+func signHS256(t *testing.T, secret []byte, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signAsymmetric builds an RS256 or ES256 token for claims.
+func signAsymmetric(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, map[string]string{"alg": alg, "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifier_SharedSecret(t *testing.T) {
+	secret := []byte("s3cret")
+	verifier, err := NewVerifier(Config{Secret: secret, Issuer: "tokensmith", Audience: "inventory"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	claims, err := verifier.Verify(context.Background(), signHS256(t, secret, map[string]interface{}{
+		"sub": "alice", "iss": "tokensmith", "aud": []string{"inventory", "other"}, "exp": exp, "role": "admin",
+	}))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if claims.Subject != "alice" || claims.ExpiresAt.Unix() != exp || claims.Raw["role"] != "admin" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"wrong secret", signHS256(t, []byte("other"), map[string]interface{}{"sub": "alice", "iss": "tokensmith", "aud": "inventory", "exp": exp}), "signature"},
+		{"expired", signHS256(t, secret, map[string]interface{}{"sub": "alice", "iss": "tokensmith", "aud": "inventory", "exp": time.Now().Add(-time.Minute).Unix()}), "expired"},
+		{"no expiry", signHS256(t, secret, map[string]interface{}{"sub": "alice", "iss": "tokensmith", "aud": "inventory"}), `no "exp" claim`},
+		{"not yet valid", signHS256(t, secret, map[string]interface{}{"sub": "alice", "iss": "tokensmith", "aud": "inventory", "exp": exp, "nbf": time.Now().Add(time.Hour).Unix()}), "not yet valid"},
+		{"wrong issuer", signHS256(t, secret, map[string]interface{}{"sub": "alice", "iss": "evil", "aud": "inventory", "exp": exp}), "issuer"},
+		{"wrong audience", signHS256(t, secret, map[string]interface{}{"sub": "alice", "iss": "tokensmith", "aud": "billing", "exp": exp}), "audience"},
+		{"unsigned", encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, map[string]string{"sub": "alice"}) + ".", "unsigned"},
+		{"malformed", "not-a-jwt", "malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), tt.token)
+			if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify() error = %v, want ErrInvalidToken mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifier_AllowMissingExpiry(t *testing.T) {
+	secret := []byte("s3cret")
+	verifier, err := NewVerifier(Config{Secret: secret, AllowMissingExpiry: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := verifier.Verify(context.Background(), signHS256(t, secret, map[string]interface{}{"sub": "alice"}))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !claims.ExpiresAt.IsZero() {
+		t.Errorf("ExpiresAt = %v, want zero", claims.ExpiresAt)
+	}
+
+	// An exp claim that is present is still enforced
+	expired := signHS256(t, secret, map[string]interface{}{"sub": "alice", "exp": time.Now().Add(-time.Minute).Unix()})
+	if _, err := verifier.Verify(context.Background(), expired); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Verify() error = %v, want expired", err)
+	}
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+			"keys": []map[string]string{
+				{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+				{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+				{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": b64(rsaKey.N.Bytes()), "e": "AQAB"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	verifier, err := NewVerifier(Config{JWKSURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]interface{}{"sub": "bob", "exp": time.Now().Add(time.Hour).Unix()}
+
+	for _, token := range []string{
+		signAsymmetric(t, "RS256", "rsa-1", rsaKey, claims),
+		signAsymmetric(t, "ES256", "ec-1", ecKey, claims),
+	} {
+		got, err := verifier.Verify(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if got.Subject != "bob" {
+			t.Errorf("Subject = %q, want bob", got.Subject)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("JWKS fetched %d times, want 1 (cached)", n)
+	}
+
+	// A key published for encryption, an unknown kid, and an RSA key used
+	// with an EC algorithm are all rejected
+	for _, token := range []string{
+		signAsymmetric(t, "RS256", "enc-1", rsaKey, claims),
+		signAsymmetric(t, "RS256", "missing", rsaKey, claims),
+		signAsymmetric(t, "ES256", "rsa-1", ecKey, claims),
+	} {
+		if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+		}
+	}
+
+	// HMAC tokens are not accepted without a shared secret, so a public
+	// key can't be used as one
+	if _, err := verifier.Verify(context.Background(), signHS256(t, rsaKey.N.Bytes(), claims)); err == nil {
+		t.Error("HS256 token accepted by a JWKS-only verifier")
+	}
+}
+
+func TestVerifier_JWKSSingleFlight(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+	var fetches atomic.Int32
+	block := make(chan struct{})
+	blocking := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			blocking <- struct{}{}
+			<-block
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+			"keys": []map[string]string{
+				{"kty": "RSA", "kid": "rsa-1", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			},
+		})
+	}))
+	defer server.Close()
+
+	verifier, err := NewVerifier(Config{JWKSURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]interface{}{"sub": "bob", "exp": time.Now().Add(time.Hour).Unix()}
+	known := signAsymmetric(t, "RS256", "rsa-1", rsaKey, claims)
+	unknown := signAsymmetric(t, "RS256", "rotated", rsaKey, claims)
+
+	if _, err := verifier.Verify(context.Background(), known); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	// Let an unknown kid trigger a refresh, then hold it at the server
+	verifier.keys.mu.Lock()
+	verifier.keys.fetched = time.Now().Add(-2 * minJWKSRefetch)
+	verifier.keys.mu.Unlock()
+
+	const waiters = 8
+	errs := make(chan error, waiters)
+	for i := 0; i < waiters; i++ {
+		go func() {
+			_, err := verifier.Verify(context.Background(), unknown)
+			errs <- err
+		}()
+	}
+	<-blocking
+
+	// Cached keys are served while the refresh is in flight
+	done := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(context.Background(), known)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Verify() with a cached key blocked on the JWKS fetch")
+	}
+
+	// A caller that gives up does not cancel the fetch for the others
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := verifier.Verify(ctx, unknown); !errors.Is(err, context.Canceled) {
+		t.Errorf("Verify() error = %v, want context.Canceled", err)
+	}
+
+	close(block)
+	for i := 0; i < waiters; i++ {
+		if err := <-errs; err == nil || !strings.Contains(err.Error(), `no key "rotated"`) {
+			t.Errorf("Verify() error = %v, want no key", err)
+		}
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2 (one shared refresh)", n)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	secret := []byte("s3cret")
+	verifier, err := NewVerifier(Config{Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var subject string
+	handler := Middleware(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject, _ = SubjectFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		subject = ""
+		req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("Bearer " + signHS256(t, secret, map[string]interface{}{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}))
+	if rec.Code != http.StatusOK || subject != "alice" {
+		t.Errorf("valid token: status = %d, subject = %q", rec.Code, subject)
+	}
+
+	rec = serve("")
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("missing token: status = %d, challenge = %q", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+
+	rec = serve("Bearer " + signHS256(t, []byte("wrong"), map[string]interface{}{"sub": "alice"}))
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Header().Get("WWW-Authenticate"), "invalid_token") {
+		t.Errorf("invalid token: status = %d, challenge = %q", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	// Without a verifier, Middleware(nil) falls back to the default and
+	// passes requests through when none is installed
+	SetDefault(nil)
+	rec = httptest.NewRecorder()
+	Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/devices", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("no default verifier: status = %d, want 200", rec.Code)
+	}
+
+	nonEnforcing, err := NewVerifier(Config{Secret: secret, NonEnforcing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	Middleware(nonEnforcing)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := SubjectFromContext(r.Context()); ok {
+			t.Error("non-enforcing request without token has a subject")
+		}
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/devices", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("non-enforcing: status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minJWKSRefetch limits refreshes triggered by unknown key IDs.
+const minJWKSRefetch = time.Minute
+
+// maxJWKSSize bounds the JWKS response body.
+const maxJWKSSize = 1 << 20
+
+// keySet caches the signing keys published at a JWKS URL.
+type keySet struct {
+	url     string
+	client  *http.Client
+	refresh time.Duration
+
+	mu      sync.Mutex
+	keys    map[string]interface{}
+	fetched time.Time
+
+	// inflight is the fetch in progress, shared by every caller that needs
+	// the set refreshed while it runs
+	inflight *jwksFetch
+}
+
+// jwksFetch is one fetch of the key set. done is closed once err is set and,
+// on success, the keys are installed.
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// jwk is one JSON Web Key (RFC 7517). Only public signing keys are used.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// get returns the key with the given ID, fetching the set when it is stale
+// or does not contain the ID. A token without a kid matches a set with a
+// single key.
+func (k *keySet) get(ctx context.Context, kid string) (interface{}, error) {
+	k.mu.Lock()
+	since := time.Since(k.fetched)
+	key, found := k.lookup(kid)
+	if k.keys != nil && since < k.refresh && (found || since < minJWKSRefetch) {
+		k.mu.Unlock()
+		if !found {
+			return nil, fmt.Errorf("no key %q in JWKS", kid)
+		}
+		return key, nil
+	}
+	call := k.inflight
+	if call == nil {
+		call = &jwksFetch{done: make(chan struct{})}
+		k.inflight = call
+		// The fetch outlives a caller that gives up waiting, so that the
+		// others still get its result; the client's timeout bounds it
+		go k.fetch(context.WithoutCancel(ctx), call)
+	}
+	k.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		if found {
+			return key, nil
+		}
+		return nil, fmt.Errorf("fetch JWKS: %w", ctx.Err())
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	// A failed fetch keeps the previous keys, so they are still found here
+	if key, found := k.lookup(kid); found {
+		return key, nil
+	}
+	if call.err != nil {
+		return nil, call.err
+	}
+	return nil, fmt.Errorf("no key %q in JWKS", kid)
+}
+
+func (k *keySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// fetch runs call, replacing the cached keys with the current set. It does
+// not hold the lock while the set is downloaded. On failure the previous keys
+// stay in use.
+func (k *keySet) fetch(ctx context.Context, call *jwksFetch) {
+	keys, err := k.download(ctx)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err == nil {
+		k.keys = keys
+		k.fetched = time.Now()
+	}
+	call.err = err
+	k.inflight = nil
+	close(call.done)
+}
+
+// download fetches and decodes the key set.
+func (k *keySet) download(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: %s returned %s", k.url, resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		// Keys of unsupported types are skipped rather than failing the set
+		if public, err := key.publicKey(); err == nil {
+			keys[key.Kid] = public
+		}
+	}
+	return keys, nil
+}
+
+// publicKey converts the JWK to an *rsa.PublicKey, *ecdsa.PublicKey or
+// ed25519.PublicKey.
+func (j jwk) publicKey() (interface{}, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,380 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	// Register the hash functions used by the supported algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// ErrInvalidToken is wrapped by every token verification failure.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Config configures a Verifier. At least one of Secret, PublicKey or JWKSURL
+// must be set; the token's alg header selects which one is used.
+type Config struct {
+	// Secret verifies HS256, HS384 and HS512 tokens
+	Secret []byte
+
+	// PublicKey (*rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey)
+	// verifies asymmetric tokens; see ParsePublicKeyPEM
+	PublicKey crypto.PublicKey
+
+	// JWKSURL is fetched for the keys of asymmetric tokens, matched by kid
+	JWKSURL string
+
+	// JWKSRefreshInterval is how long fetched keys are cached (default 15m).
+	// Unknown key IDs trigger an earlier refresh at most once a minute.
+	JWKSRefreshInterval time.Duration
+
+	// HTTPClient fetches the JWKS (default: a client with a 10s timeout)
+	HTTPClient *http.Client
+
+	// Issuer, if set, must equal the token's iss claim
+	Issuer string
+
+	// Audience, if set, must appear in the token's aud claim
+	Audience string
+
+	// Leeway tolerates clock skew when checking exp and nbf
+	Leeway time.Duration
+
+	// AllowMissingExpiry accepts tokens without an exp claim. By default
+	// they are rejected, since they would be valid forever.
+	AllowMissingExpiry bool
+
+	// NonEnforcing makes Middleware log authentication failures and serve
+	// the request anyway, for rolling out authentication
+	NonEnforcing bool
+}
+
+// Claims are the verified claims of a token.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ID        string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	IssuedAt  time.Time
+
+	// Raw holds every claim, including custom ones, as decoded JSON
+	Raw map[string]interface{}
+}
+
+// Verifier checks bearer JWTs. It is safe for concurrent use.
+type Verifier struct {
+	config Config
+	keys   *keySet
+	now    func() time.Time
+}
+
+// NewVerifier creates a verifier for config.
+func NewVerifier(config Config) (*Verifier, error) {
+	if len(config.Secret) == 0 && config.PublicKey == nil && config.JWKSURL == "" {
+		return nil, errors.New("auth: one of Secret, PublicKey or JWKSURL is required")
+	}
+	if config.PublicKey != nil {
+		switch config.PublicKey.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("auth: unsupported public key type %T", config.PublicKey)
+		}
+	}
+
+	v := &Verifier{config: config, now: time.Now}
+	if config.JWKSURL != "" {
+		client := config.HTTPClient
+		if client == nil {
+			client = &http.Client{Timeout: 10 * time.Second}
+		}
+		refresh := config.JWKSRefreshInterval
+		if refresh <= 0 {
+			refresh = 15 * time.Minute
+		}
+		v.keys = &keySet{url: config.JWKSURL, client: client, refresh: refresh}
+	}
+	return v, nil
+}
+
+// Verify checks the token's signature and registered claims and returns its
+// claims. Errors wrap ErrInvalidToken.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims, err := v.verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v *Verifier) verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+
+	key, err := v.key(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("malformed claims: %w", err)
+	}
+	claims, err := parseClaims(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// key returns the verification key for the token's algorithm.
+func (v *Verifier) key(ctx context.Context, header tokenHeader) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(header.Alg, "HS"):
+		if len(v.config.Secret) == 0 {
+			return nil, fmt.Errorf("algorithm %s not accepted: no shared secret configured", header.Alg)
+		}
+		return v.config.Secret, nil
+	case header.Alg == "" || strings.EqualFold(header.Alg, "none"):
+		return nil, errors.New("unsigned tokens are not accepted")
+	case v.keys != nil && (header.Kid != "" || v.config.PublicKey == nil):
+		return v.keys.get(ctx, header.Kid)
+	case v.config.PublicKey != nil:
+		return v.config.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("algorithm %s not accepted: no public key configured", header.Alg)
+	}
+}
+
+// verifySignature checks signature over signed with the algorithm's key type.
+func verifySignature(alg string, key interface{}, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "HS256", "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "HS384", "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "HS512", "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(edKey, signed, signature) {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	errSignature := errors.New("signature verification failed")
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return errSignature
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errSignature
+		}
+	case "RS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok || rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature) != nil {
+			return errSignature
+		}
+	case "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok || rsa.VerifyPSS(rsaKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) != nil {
+			return errSignature
+		}
+	case "ES":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errSignature
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errSignature
+		}
+	}
+	return nil
+}
+
+// parseClaims extracts the registered claims from the decoded payload.
+func parseClaims(raw map[string]interface{}) (*Claims, error) {
+	claims := &Claims{Raw: raw}
+	var err error
+	if claims.Subject, err = stringClaim(raw, "sub"); err != nil {
+		return nil, err
+	}
+	if claims.Issuer, err = stringClaim(raw, "iss"); err != nil {
+		return nil, err
+	}
+	if claims.ID, err = stringClaim(raw, "jti"); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt, err = timeClaim(raw, "exp"); err != nil {
+		return nil, err
+	}
+	if claims.NotBefore, err = timeClaim(raw, "nbf"); err != nil {
+		return nil, err
+	}
+	if claims.IssuedAt, err = timeClaim(raw, "iat"); err != nil {
+		return nil, err
+	}
+
+	switch aud := raw["aud"].(type) {
+	case nil:
+	case string:
+		claims.Audience = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			s, ok := a.(string)
+			if !ok {
+				return nil, errors.New(`claim "aud" must be a string or array of strings`)
+			}
+			claims.Audience = append(claims.Audience, s)
+		}
+	default:
+		return nil, errors.New(`claim "aud" must be a string or array of strings`)
+	}
+	return claims, nil
+}
+
+// validate checks the time, issuer and audience claims.
+func (v *Verifier) validate(claims *Claims) error {
+	now := v.now()
+	if claims.ExpiresAt.IsZero() {
+		if !v.config.AllowMissingExpiry {
+			return errors.New(`token has no "exp" claim`)
+		}
+	} else if !now.Before(claims.ExpiresAt.Add(v.config.Leeway)) {
+		return errors.New("token expired")
+	}
+	if !claims.NotBefore.IsZero() && now.Add(v.config.Leeway).Before(claims.NotBefore) {
+		return errors.New("token not yet valid")
+	}
+	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
+		return fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if v.config.Audience != "" {
+		for _, aud := range claims.Audience {
+			if aud == v.config.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf("token audience does not include %q", v.config.Audience)
+	}
+	return nil
+}
+
+func stringClaim(raw map[string]interface{}, name string) (string, error) {
+	switch value := raw[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	default:
+		return "", fmt.Errorf("claim %q must be a string", name)
+	}
+}
+
+func timeClaim(raw map[string]interface{}, name string) (time.Time, error) {
+	switch value := raw[name].(type) {
+	case nil:
+		return time.Time{}, nil
+	case json.Number:
+		seconds, err := value.Float64()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("claim %q must be a number", name)
+		}
+		return time.Unix(0, int64(seconds*float64(time.Second))), nil
+	default:
+		return time.Time{}, fmt.Errorf("claim %q must be a number", name)
+	}
+}
+
+// decodeSegment decodes a base64url JSON token segment, keeping numbers exact.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// ParsePublicKeyPEM parses a PEM-encoded PKIX public key or certificate for
+// Config.PublicKey.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("auth: no PEM block found")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("auth: parse certificate: %w", err)
+		}
+		return cert.PublicKey, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("auth: parse public key: %w", err)
+	}
+	return key, nil
+}
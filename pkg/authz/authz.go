@@ -0,0 +1,301 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package authz authorizes authenticated requests against an RBAC policy.
+//
+// Each request is checked as a (subject, resource, action) triple: the
+// subject is the caller authenticated by package auth, the resource is the
+// resource type ("Device"), and the action is derived from the HTTP method
+// and path (see Action). The policy itself is evaluated by an Enforcer, an
+// interface satisfied by *casbin.Enforcer:
+//
+//	enforcer, err := casbin.NewEnforcer("policies/model.conf", "policies/policy.csv")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	authz.SetDefault(authz.New(enforcer, authz.Options{}))
+//	r.With(auth.Middleware(verifier), authz.Middleware(nil, "Device", "/devices")).Get("/devices", ListDevices)
+//
+// A subject without a grant of its own is also checked under each role in
+// its token's roles claim, as "role:<name>". A token issued with
+// roles ["admin"] therefore passes a policy line "p, role:admin, *, *"
+// without any per-user mapping. A subject that starts with "role:" is only
+// checked under its roles, so a token cannot name a role as its subject.
+//
+// Services that only need OAuth2 scopes can use NewScopes instead of a
+// policy engine; it requires a scope such as "devices:write" in the token.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/openchami/fabrica/pkg/auth"
+	"github.com/openchami/fabrica/pkg/httperror"
+	"github.com/openchami/fabrica/pkg/logging"
+)
+
+// AnonymousSubject is checked for requests without an authenticated subject,
+// such as requests let through by non-enforcing authentication.
+const AnonymousSubject = "anonymous"
+
+// RolePrefix is prepended to roles from the token when they are checked as
+// subjects.
+const RolePrefix = "role:"
+
+// DefaultRolesClaim is the token claim holding the caller's roles.
+const DefaultRolesClaim = "roles"
+
+// Actions checked by Middleware.
+const (
+	ActionList         = "list"
+	ActionGet          = "get"
+	ActionCreate       = "create"
+	ActionUpdate       = "update"
+	ActionUpdateStatus = "update_status"
+	ActionScale        = "scale"
+	ActionDelete       = "delete"
+	ActionResync       = "resync"
+)
+
+// Enforcer evaluates a policy for a (subject, resource, action) request.
+// *casbin.Enforcer implements it.
+type Enforcer interface {
+	Enforce(rvals ...interface{}) (bool, error)
+}
+
+// Options configure an Authorizer.
+type Options struct {
+	// RolesClaim is the token claim holding the caller's roles, as a string
+	// or array of strings (default "roles")
+	RolesClaim string
+
+	// ScopeClaim is the token claim holding the caller's scopes, used by
+	// NewScopes (default "scope")
+	ScopeClaim string
+
+	// ScopePattern names the scope a request requires, used by NewScopes
+	// (default "{resource}:{access}")
+	ScopePattern string
+
+	// NonEnforcing makes Middleware log denials and serve the request anyway
+	NonEnforcing bool
+}
+
+// Request describes the operation being authorized.
+type Request struct {
+	// ResourceType is the resource's type name ("Device")
+	ResourceType string
+
+	// Resource is the resource's URL path segment ("devices")
+	Resource string
+
+	// Action is the operation, one of the Action constants
+	Action string
+}
+
+// Authorizer checks requests against an Enforcer, or against the caller's
+// scopes if created by NewScopes. It is safe for concurrent use if the
+// Enforcer is.
+type Authorizer struct {
+	enforcer Enforcer
+	options  Options
+}
+
+// New creates an authorizer for enforcer.
+func New(enforcer Enforcer, options Options) *Authorizer {
+	if options.RolesClaim == "" {
+		options.RolesClaim = DefaultRolesClaim
+	}
+	return &Authorizer{enforcer: enforcer, options: options}
+}
+
+// Authorize reports whether the caller in ctx may perform req. With an
+// Enforcer, the caller's subject is checked first, then each of its roles
+// prefixed with RolePrefix; otherwise the caller must hold the scope named
+// by RequiredScope. A subject that itself starts with RolePrefix is not
+// checked, since it would share the roles' policy namespace.
+func (a *Authorizer) Authorize(ctx context.Context, req Request) (bool, error) {
+	if a.enforcer == nil {
+		return a.authorizeScope(ctx, req), nil
+	}
+
+	subject, ok := auth.SubjectFromContext(ctx)
+	if !ok {
+		subject = AnonymousSubject
+	}
+	if !strings.HasPrefix(subject, RolePrefix) {
+		allowed, err := a.enforcer.Enforce(subject, req.ResourceType, req.Action)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	for _, role := range a.roles(ctx) {
+		allowed, err := a.enforcer.Enforce(RolePrefix+role, req.ResourceType, req.Action)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+	return false, nil
+}
+
+// roles returns the roles claim of the authenticated caller.
+func (a *Authorizer) roles(ctx context.Context) []string {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	switch value := claims.Raw[a.options.RolesClaim].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		roles := make([]string, 0, len(value))
+		for _, role := range value {
+			if s, ok := role.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
+
+var (
+	defaultAuthorizer      *Authorizer
+	defaultAuthorizerMutex sync.RWMutex
+)
+
+// SetDefault installs the authorizer used by Middleware(nil, ...). Pass nil
+// to disable authorization. Replacing the default is how a reloaded policy
+// takes effect.
+func SetDefault(a *Authorizer) {
+	defaultAuthorizerMutex.Lock()
+	defer defaultAuthorizerMutex.Unlock()
+	defaultAuthorizer = a
+}
+
+// Default returns the authorizer used by Middleware(nil, ...), or nil if none
+// is set.
+func Default() *Authorizer {
+	defaultAuthorizerMutex.RLock()
+	defer defaultAuthorizerMutex.RUnlock()
+	return defaultAuthorizer
+}
+
+// Action maps a request on a resource's routes to a policy action. subpath
+// is the request path below the resource's URL path:
+//
+//	GET    /devices                 list
+//	POST   /devices                 create
+//	GET    /devices/{uid}           get
+//	PUT    /devices/{uid}           update (also PATCH)
+//	DELETE /devices/{uid}           delete
+//	PUT    /devices/{uid}/status    update_status (also PATCH)
+//	PUT    /devices/{uid}/scale     scale
+//	GET    /devices/{uid}/versions  list
+//	POST   /devices:resync          resync
+//
+// Other requests map to the lower-cased method.
+func Action(method, subpath string) string {
+	var segments []string
+	if trimmed := strings.Trim(subpath, "/"); trimmed != "" {
+		segments = strings.Split(trimmed, "/")
+	}
+	collection := len(segments) == 0 || (len(segments) == 2 && segments[1] == "versions")
+	status := len(segments) == 2 && segments[1] == "status"
+	scale := len(segments) == 2 && segments[1] == "scale"
+
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		if collection {
+			return ActionList
+		}
+		return ActionGet
+	case http.MethodPost:
+		if subpath == ":resync" {
+			return ActionResync
+		}
+		if collection {
+			return ActionCreate
+		}
+	case http.MethodPut, http.MethodPatch:
+		if status {
+			return ActionUpdateStatus
+		}
+		if scale {
+			return ActionScale
+		}
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	}
+	return strings.ToLower(method)
+}
+
+// Middleware checks every request on a resource's routes, mounted at
+// urlPath, against the authorizer, and rejects denied requests with 403.
+// Scope denials also name the missing scope in a WWW-Authenticate
+// insufficient_scope challenge. It must run after auth.Middleware.
+//
+// A nil a uses Default() at request time; if that is also nil, requests
+// pass through.
+func Middleware(a *Authorizer, resourceType, urlPath string) func(http.Handler) http.Handler {
+	return MiddlewareAt(a, resourceType, urlPath, urlPath)
+}
+
+// MiddlewareAt is Middleware for a resource's routes mounted at mountPath
+// rather than at its canonical urlPath, such as a short-name alias. The
+// action comes from the request path below mountPath; the resource name
+// used for scopes still comes from urlPath.
+func MiddlewareAt(a *Authorizer, resourceType, urlPath, mountPath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorizer := a
+			if authorizer == nil {
+				authorizer = Default()
+			}
+			if authorizer == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			action := Action(r.Method, strings.TrimPrefix(r.URL.Path, mountPath))
+			req := Request{ResourceType: resourceType, Resource: path.Base(urlPath), Action: action}
+			allowed, err := authorizer.Authorize(r.Context(), req)
+			if err != nil {
+				logging.FromContext(r.Context()).Error("authorization failed", "resource", resourceType, "action", action, "error", err)
+				httperror.WriteError(w, r, http.StatusInternalServerError, fmt.Errorf("authorization failed"))
+				return
+			}
+			if !allowed {
+				subject, ok := auth.SubjectFromContext(r.Context())
+				if !ok {
+					subject = AnonymousSubject
+				}
+				logger := logging.FromContext(r.Context())
+				if authorizer.options.NonEnforcing {
+					logger.Warn("authorization denied; serving request (non-enforcing)", "resource", resourceType, "action", action)
+					next.ServeHTTP(w, r)
+					return
+				}
+				logger.Warn("authorization denied", "resource", resourceType, "action", action)
+				if authorizer.enforcer == nil {
+					scope := RequiredScope(authorizer.options.ScopePattern, req)
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+					httperror.WriteError(w, r, http.StatusForbidden, fmt.Errorf("%s lacks scope %s", subject, scope))
+					return
+				}
+				httperror.WriteError(w, r, http.StatusForbidden, fmt.Errorf("%s may not %s %s", subject, action, resourceType))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
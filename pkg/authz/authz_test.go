@@ -0,0 +1,301 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/auth"
+)
+
+// grants is an Enforcer allowing "subject resource action" triples, with
+// "*" matching any resource or action.
+type grants map[string]bool
+
+func (g grants) Enforce(rvals ...interface{}) (bool, error) {
+	sub, obj, act := rvals[0].(string), rvals[1].(string), rvals[2].(string)
+	for _, key := range []string{sub + " " + obj + " " + act, sub + " * " + act, sub + " " + obj + " *", sub + " * *"} {
+		if g[key] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func withCaller(ctx context.Context, subject string, roles ...interface{}) context.Context {
+	return auth.WithClaims(ctx, &auth.Claims{
+		Subject: subject,
+		Raw:     map[string]interface{}{"sub": subject, "roles": roles},
+	})
+}
+
+func TestAction(t *testing.T) {
+	tests := []struct {
+		method, subpath, want string
+	}{
+		{http.MethodGet, "", ActionList},
+		{http.MethodGet, "/", ActionList},
+		{http.MethodPost, "/", ActionCreate},
+		{http.MethodGet, "/dev-1", ActionGet},
+		{http.MethodHead, "/dev-1", ActionGet},
+		{http.MethodPut, "/dev-1", ActionUpdate},
+		{http.MethodPatch, "/dev-1/", ActionUpdate},
+		{http.MethodDelete, "/dev-1", ActionDelete},
+		{http.MethodPut, "/dev-1/status", ActionUpdateStatus},
+		{http.MethodPatch, "/dev-1/status", ActionUpdateStatus},
+		{http.MethodGet, "/dev-1/scale", ActionGet},
+		{http.MethodPut, "/dev-1/scale", ActionScale},
+		{http.MethodGet, "/dev-1/versions", ActionList},
+		{http.MethodGet, "/dev-1/versions/v2", ActionGet},
+		{http.MethodDelete, "/dev-1/versions/v2", ActionDelete},
+		{http.MethodPost, ":resync", ActionResync},
+		{http.MethodOptions, "/", "options"},
+	}
+	for _, tt := range tests {
+		if got := Action(tt.method, tt.subpath); got != tt.want {
+			t.Errorf("Action(%s, %q) = %q, want %q", tt.method, tt.subpath, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorize_RoleFallback(t *testing.T) {
+	authorizer := New(grants{
+		"alice Device update": true,
+		"role:admin * *":      true,
+		"role:viewer * get":   true,
+	}, Options{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		action string
+		want   bool
+	}{
+		{"direct grant", withCaller(ctx, "alice"), ActionUpdate, true},
+		{"no grant", withCaller(ctx, "alice"), ActionDelete, false},
+		{"admin role", withCaller(ctx, "bob", "admin"), ActionDelete, true},
+		{"viewer role", withCaller(ctx, "carol", "viewer"), ActionGet, true},
+		{"viewer may not write", withCaller(ctx, "carol", "viewer"), ActionUpdate, false},
+		{"anonymous", ctx, ActionGet, false},
+		{"subject named as a role", withCaller(ctx, "role:admin"), ActionDelete, false},
+		{"subject named as a role with the role", withCaller(ctx, "role:admin", "viewer"), ActionGet, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authorizer.Authorize(tt.ctx, Request{ResourceType: "Device", Resource: "devices", Action: tt.action})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorize(%s) = %v, want %v", tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func withScopes(ctx context.Context, scope interface{}) context.Context {
+	return auth.WithClaims(ctx, &auth.Claims{
+		Subject: "svc",
+		Raw:     map[string]interface{}{"sub": "svc", "scope": scope},
+	})
+}
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		pattern, action, want string
+	}{
+		{DefaultScopePattern, ActionList, "devices:read"},
+		{DefaultScopePattern, ActionGet, "devices:read"},
+		{DefaultScopePattern, ActionCreate, "devices:write"},
+		{DefaultScopePattern, ActionUpdateStatus, "devices:write"},
+		{"{type}.{action}", ActionUpdateStatus, "device.update_status"},
+		{"inventory/{access}", ActionDelete, "inventory/write"},
+	}
+	for _, tt := range tests {
+		req := Request{ResourceType: "Device", Resource: "devices", Action: tt.action}
+		if got := RequiredScope(tt.pattern, req); got != tt.want {
+			t.Errorf("RequiredScope(%q, %s) = %q, want %q", tt.pattern, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorize_Scopes(t *testing.T) {
+	authorizer := NewScopes(Options{})
+	ctx := context.Background()
+	read := Request{ResourceType: "Device", Resource: "devices", Action: ActionGet}
+	write := Request{ResourceType: "Device", Resource: "devices", Action: ActionDelete}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  Request
+		want bool
+	}{
+		{"space-separated", withScopes(ctx, "openid devices:read"), read, true},
+		{"read does not imply write", withScopes(ctx, "devices:read"), write, false},
+		{"array claim", withScopes(ctx, []interface{}{"devices:write"}), write, true},
+		{"other resource", withScopes(ctx, "racks:read"), read, false},
+		{"roles are ignored", withCaller(ctx, "bob", "admin"), read, false},
+		{"anonymous", ctx, read, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authorizer.Authorize(tt.ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorize(%s) = %v, want %v", tt.req.Action, got, tt.want)
+			}
+		})
+	}
+}
+
+type failingEnforcer struct{}
+
+func (failingEnforcer) Enforce(...interface{}) (bool, error) {
+	return false, errors.New("policy unavailable")
+}
+
+func TestMiddleware(t *testing.T) {
+	authorizer := New(grants{"role:viewer Device list": true}, Options{})
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(a *Authorizer, method, path string, ctx context.Context) int {
+		req := httptest.NewRequest(method, path, nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		Middleware(a, "Device", "/devices")(ok).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	viewer := withCaller(context.Background(), "carol", "viewer")
+	if code := serve(authorizer, http.MethodGet, "/devices", viewer); code != http.StatusOK {
+		t.Errorf("list as viewer: status = %d, want 200", code)
+	}
+	if code := serve(authorizer, http.MethodDelete, "/devices/dev-1", viewer); code != http.StatusForbidden {
+		t.Errorf("delete as viewer: status = %d, want 403", code)
+	}
+	if code := serve(New(failingEnforcer{}, Options{}), http.MethodGet, "/devices", viewer); code != http.StatusInternalServerError {
+		t.Errorf("enforcer error: status = %d, want 500", code)
+	}
+	if code := serve(New(grants{}, Options{NonEnforcing: true}), http.MethodDelete, "/devices/dev-1", viewer); code != http.StatusOK {
+		t.Errorf("non-enforcing: status = %d, want 200", code)
+	}
+
+	// Scope denials name the missing scope
+	req := httptest.NewRequest(http.MethodPost, "/devices", nil).WithContext(withScopes(context.Background(), "devices:read"))
+	rec := httptest.NewRecorder()
+	Middleware(NewScopes(Options{}), "Device", "/devices")(ok).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("create with read scope: status = %d, want 403", rec.Code)
+	}
+	if got, want := rec.Header().Get("WWW-Authenticate"), `Bearer error="insufficient_scope", scope="devices:write"`; got != want {
+		t.Errorf("WWW-Authenticate = %q, want %q", got, want)
+	}
+
+	// Middleware(nil, ...) follows the default, and passes through without one
+	SetDefault(nil)
+	if code := serve(nil, http.MethodDelete, "/devices/dev-1", viewer); code != http.StatusOK {
+		t.Errorf("no default: status = %d, want 200", code)
+	}
+	SetDefault(authorizer)
+	defer SetDefault(nil)
+	if code := serve(nil, http.MethodDelete, "/devices/dev-1", viewer); code != http.StatusForbidden {
+		t.Errorf("default authorizer: status = %d, want 403", code)
+	}
+}
+
+func TestMiddlewareAt_ShortName(t *testing.T) {
+	// A policy that may update devices, but not their status or scale, and
+	// may get but not list them
+	authorizer := New(grants{"alice Device update": true, "alice Device get": true}, Options{})
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	alice := withCaller(context.Background(), "alice")
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodPut, "/api/dev/dev-1", http.StatusOK},
+		{http.MethodGet, "/api/dev/dev-1", http.StatusOK},
+		{http.MethodPut, "/api/dev/dev-1/status", http.StatusForbidden},
+		{http.MethodPut, "/api/dev/dev-1/scale", http.StatusForbidden},
+		{http.MethodGet, "/api/dev", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil).WithContext(alice)
+		rec := httptest.NewRecorder()
+		MiddlewareAt(authorizer, "Device", "/api/devices", "/api/dev")(ok).ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+		}
+	}
+
+	// Scopes still name the canonical resource
+	req := httptest.NewRequest(http.MethodPost, "/dev", nil).WithContext(withScopes(context.Background(), "devices:read"))
+	rec := httptest.NewRecorder()
+	MiddlewareAt(NewScopes(Options{}), "Device", "/devices", "/dev")(ok).ServeHTTP(rec, req)
+	if got, want := rec.Header().Get("WWW-Authenticate"), `Bearer error="insufficient_scope", scope="devices:write"`; got != want {
+		t.Errorf("WWW-Authenticate = %q, want %q", got, want)
+	}
+}
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "policy.csv")
+	if err := os.WriteFile(policy, []byte("p, role:admin, *, *\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloads := make(chan struct{}, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- WatchFiles(ctx, []string{policy}, func() error {
+			reloads <- struct{}{}
+			return nil
+		})
+	}()
+
+	// Unrelated files in the directory are ignored; the watcher may not be
+	// registered yet, so rewrite the policy (slower than the debounce) until a
+	// reload arrives
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.After(5 * time.Second)
+	tick := time.NewTicker(4 * watchDebounce)
+	defer tick.Stop()
+wait:
+	for {
+		select {
+		case <-reloads:
+			break wait
+		case <-tick.C:
+			if err := os.WriteFile(policy, []byte("p, role:admin, *, *\np, role:viewer, *, get\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		case <-deadline:
+			t.Fatal("policy change did not trigger a reload")
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("WatchFiles() error = %v", err)
+	}
+}
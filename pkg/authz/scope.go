@@ -0,0 +1,93 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package authz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/auth"
+)
+
+// DefaultScopeClaim is the token claim holding the caller's OAuth2 scopes.
+const DefaultScopeClaim = "scope"
+
+// DefaultScopePattern names the scope required for a request: "devices:read"
+// for list and get, "devices:write" for everything else.
+const DefaultScopePattern = "{resource}:{access}"
+
+// Access levels substituted for {access} in a scope pattern.
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+)
+
+// NewScopes creates an authorizer that requires an OAuth2 scope instead of
+// evaluating a policy. The scope for each request is options.ScopePattern
+// with these placeholders replaced:
+//
+//	{resource}  the resource's URL path segment ("devices")
+//	{type}      the lower-cased resource type ("device")
+//	{action}    the action, e.g. "list" or "update_status"
+//	{access}    "read" for list and get, "write" otherwise
+//
+// The caller's scopes are read from options.ScopeClaim, as a
+// space-separated string (RFC 8693) or an array of strings.
+func NewScopes(options Options) *Authorizer {
+	if options.ScopeClaim == "" {
+		options.ScopeClaim = DefaultScopeClaim
+	}
+	if options.ScopePattern == "" {
+		options.ScopePattern = DefaultScopePattern
+	}
+	return &Authorizer{options: options}
+}
+
+// RequiredScope returns the scope req needs under pattern.
+func RequiredScope(pattern string, req Request) string {
+	access := AccessWrite
+	if req.Action == ActionList || req.Action == ActionGet {
+		access = AccessRead
+	}
+	return strings.NewReplacer(
+		"{resource}", req.Resource,
+		"{type}", strings.ToLower(req.ResourceType),
+		"{action}", req.Action,
+		"{access}", access,
+	).Replace(pattern)
+}
+
+// authorizeScope reports whether the caller holds the scope req requires.
+func (a *Authorizer) authorizeScope(ctx context.Context, req Request) bool {
+	required := RequiredScope(a.options.ScopePattern, req)
+	for _, scope := range a.scopes(ctx) {
+		if scope == required {
+			return true
+		}
+	}
+	return false
+}
+
+// scopes returns the scope claim of the authenticated caller.
+func (a *Authorizer) scopes(ctx context.Context) []string {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	switch value := claims.Raw[a.options.ScopeClaim].(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		scopes := make([]string, 0, len(value))
+		for _, scope := range value {
+			if s, ok := scope.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package authz
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups the events of one save (editors often write, rename
+// and chmod in quick succession) into a single reload.
+const watchDebounce = 200 * time.Millisecond
+
+// WatchFiles calls reload whenever one of paths is written, created or
+// replaced, until ctx is done. Reload errors are logged; the caller keeps
+// the previous policy in that case.
+//
+// The files' directories are watched rather than the files themselves, so
+// editors that save by renaming a temporary file are handled.
+func WatchFiles(ctx context.Context, paths []string, reload func() error) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create policy watcher: %w", err)
+	}
+	defer watcher.Close() //nolint:errcheck
+
+	watched := make(map[string]bool, len(paths))
+	dirs := make(map[string]bool)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		watched[abs] = true
+		dirs[filepath.Dir(abs)] = true
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if abs, err := filepath.Abs(event.Name); err == nil && watched[abs] {
+				debounce = time.After(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("policy watch error", "error", err)
+
+		case <-debounce:
+			debounce = nil
+			if err := reload(); err != nil {
+				slog.Error("failed to reload authorization policy; keeping the previous policy", "error", err)
+				continue
+			}
+			slog.Info("reloaded authorization policy")
+		}
+	}
+}
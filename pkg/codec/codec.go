@@ -0,0 +1,263 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package codec negotiates and converts between the JSON and YAML
+// representations of resources.
+//
+// JSON is the canonical wire format: YAML documents are converted to JSON
+// before decoding and JSON is converted to YAML for encoding, so field names,
+// omitempty handling and unknown-field behavior are identical for both formats
+// and only the json struct tags matter.
+//
+// Usage:
+//
+//	// Decode a request body according to its Content-Type
+//	if err := codec.DecodeRequest(r, &req); err != nil {
+//	    return err
+//	}
+//
+//	// Encode a response in the format the client asked for
+//	mediaType := codec.Negotiate(r)
+//	body, err := codec.Marshal(mediaType, device)
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported media types.
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeYAML = "application/yaml"
+
+	// MediaTypeNDJSON is newline-delimited JSON, one document per line. It is
+	// only offered for lists; see NegotiateList.
+	MediaTypeNDJSON = "application/x-ndjson"
+)
+
+// yamlMediaTypes lists accepted spellings of the YAML media type.
+var yamlMediaTypes = map[string]bool{
+	MediaTypeYAML:        true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+	"text/x-yaml":        true,
+}
+
+// IsYAML reports whether a Content-Type or Accept entry names YAML.
+// Parameters such as charset or version are ignored.
+func IsYAML(mediaType string) bool {
+	return yamlMediaTypes[baseMediaType(mediaType)]
+}
+
+// Negotiate returns the response media type for a request based on its
+// Accept header: MediaTypeYAML if YAML is preferred, MediaTypeJSON otherwise.
+// Entries are ranked by their q value; ties keep header order.
+func Negotiate(r *http.Request) string {
+	return negotiate(r, false)
+}
+
+// NegotiateList is Negotiate for list responses, which can also be sent as
+// NDJSON: it returns MediaTypeNDJSON if that is preferred. The spellings
+// "application/ndjson" and "application/jsonlines" are accepted too.
+func NegotiateList(r *http.Request) string {
+	return negotiate(r, true)
+}
+
+// ndjsonMediaTypes lists accepted spellings of the NDJSON media type.
+var ndjsonMediaTypes = map[string]bool{
+	MediaTypeNDJSON:         true,
+	"application/ndjson":    true,
+	"application/jsonlines": true,
+}
+
+// negotiate implements Negotiate and NegotiateList
+func negotiate(r *http.Request, allowNDJSON bool) string {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return MediaTypeJSON
+	}
+
+	type candidate struct {
+		mediaType string
+		q         float64
+	}
+	var candidates []candidate
+	for _, entry := range strings.Split(accept, ",") {
+		mediaType := baseMediaType(entry)
+		if mediaType == "" {
+			continue
+		}
+		candidates = append(candidates, candidate{mediaType: mediaType, q: qValue(entry)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if c.q <= 0 {
+			break
+		}
+		if yamlMediaTypes[c.mediaType] {
+			return MediaTypeYAML
+		}
+		if allowNDJSON && ndjsonMediaTypes[c.mediaType] {
+			return MediaTypeNDJSON
+		}
+		if c.mediaType == MediaTypeJSON || c.mediaType == "application/*" || c.mediaType == "*/*" {
+			return MediaTypeJSON
+		}
+	}
+	return MediaTypeJSON
+}
+
+// Marshal encodes v in the given media type.
+func Marshal(mediaType string, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if !IsYAML(mediaType) {
+		return data, nil
+	}
+	return JSONToYAML(data)
+}
+
+// DecodeRequest decodes the request body into v according to its
+// Content-Type. YAML bodies are decoded exactly like the equivalent JSON.
+func DecodeRequest(r *http.Request, v interface{}) error {
+	if !IsYAML(r.Header.Get("Content-Type")) {
+		return json.NewDecoder(r.Body).Decode(v)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+	data, err := YAMLToJSON(body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// JSONToYAML converts a JSON document to block-style YAML, preserving key order.
+func JSONToYAML(data []byte) ([]byte, error) {
+	// JSON is valid YAML, so parse it into a node tree and re-emit it
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	clearStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// YAMLToJSON converts a YAML document to JSON.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	v, err := jsonCompatible(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// clearStyle switches flow-style JSON collections to block style, keeping
+// string quoting so values such as "true" or "1.0" stay strings.
+func clearStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// jsonCompatible converts maps with non-string keys produced by the YAML
+// decoder into map[string]interface{}.
+func jsonCompatible(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			converted, err := jsonCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = converted
+		}
+		return val, nil
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid YAML: non-string key %v", k)
+			}
+			converted, err := jsonCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case []interface{}:
+		for i, item := range val {
+			converted, err := jsonCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = converted
+		}
+		return val, nil
+	default:
+		return val, nil
+	}
+}
+
+// baseMediaType strips parameters and normalizes case.
+func baseMediaType(mediaType string) string {
+	if idx := strings.Index(mediaType, ";"); idx != -1 {
+		mediaType = mediaType[:idx]
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// qValue returns the q parameter of an Accept entry (default 1).
+func qValue(entry string) float64 {
+	params := strings.Split(entry, ";")
+	for _, param := range params[1:] {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.ToLower(strings.TrimSpace(name)) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
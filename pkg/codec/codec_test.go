@@ -0,0 +1,199 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codec
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testSpec struct {
+	Name      string            `json:"name"`
+	IPAddress string            `json:"ipAddress,omitempty"`
+	Enabled   string            `json:"enabled,omitempty"`
+	Ports     []int             `json:"ports,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", MediaTypeJSON},
+		{"*/*", MediaTypeJSON},
+		{"application/json", MediaTypeJSON},
+		{"application/yaml", MediaTypeYAML},
+		{"application/x-yaml", MediaTypeYAML},
+		{"text/yaml;charset=utf-8", MediaTypeYAML},
+		{"application/yaml;version=v2", MediaTypeYAML},
+		{"application/json, application/yaml", MediaTypeJSON},
+		{"application/json;q=0.5, application/yaml", MediaTypeYAML},
+		{"application/yaml;q=0, */*", MediaTypeJSON},
+		{"text/html", MediaTypeJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := Negotiate(r); got != tt.want {
+				t.Errorf("Negotiate(%q) = %s, want %s", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiateList(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", MediaTypeJSON},
+		{"application/x-ndjson", MediaTypeNDJSON},
+		{"application/ndjson", MediaTypeNDJSON},
+		{"application/json;q=0.5, application/x-ndjson", MediaTypeNDJSON},
+		{"application/json, application/x-ndjson", MediaTypeJSON},
+		{"application/yaml", MediaTypeYAML},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", tt.accept)
+		if got := NegotiateList(r); got != tt.want {
+			t.Errorf("NegotiateList(%q) = %s, want %s", tt.accept, got, tt.want)
+		}
+	}
+
+	// Single resources are never NDJSON
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", MediaTypeNDJSON)
+	if got := Negotiate(r); got != MediaTypeJSON {
+		t.Errorf("Negotiate(NDJSON) = %s, want JSON", got)
+	}
+}
+
+func TestMarshalYAML_UsesJSONFieldNames(t *testing.T) {
+	spec := testSpec{Name: "node-1", IPAddress: "10.0.0.1", Enabled: "true", Ports: []int{22, 443}}
+
+	data, err := Marshal(MediaTypeYAML, spec)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `name: node-1
+ipAddress: 10.0.0.1
+enabled: "true"
+ports:
+  - 22
+  - 443
+`
+	if string(data) != want {
+		t.Errorf("Marshal YAML =\n%s\nwant\n%s", data, want)
+	}
+
+	jsonData, err := Marshal(MediaTypeJSON, spec)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.HasPrefix(string(jsonData), `{"name":"node-1"`) {
+		t.Errorf("Marshal JSON = %s", jsonData)
+	}
+}
+
+func TestDecodeRequest(t *testing.T) {
+	yamlBody := `name: node-1
+ipAddress: 10.0.0.1
+enabled: "true"
+unknownField: ignored
+labels:
+  rack: r12
+`
+	jsonBody := `{"name":"node-1","ipAddress":"10.0.0.1","enabled":"true","unknownField":"ignored","labels":{"rack":"r12"}}`
+
+	var fromYAML, fromJSON testSpec
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(yamlBody))
+	r.Header.Set("Content-Type", "application/yaml")
+	if err := DecodeRequest(r, &fromYAML); err != nil {
+		t.Fatalf("DecodeRequest(yaml) failed: %v", err)
+	}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(jsonBody))
+	r.Header.Set("Content-Type", "application/json")
+	if err := DecodeRequest(r, &fromJSON); err != nil {
+		t.Fatalf("DecodeRequest(json) failed: %v", err)
+	}
+
+	if fromYAML.Name != fromJSON.Name || fromYAML.IPAddress != fromJSON.IPAddress ||
+		fromYAML.Enabled != fromJSON.Enabled || fromYAML.Labels["rack"] != "r12" {
+		t.Errorf("YAML and JSON decode differ: %+v vs %+v", fromYAML, fromJSON)
+	}
+}
+
+func TestYAMLToJSON_Errors(t *testing.T) {
+	if _, err := YAMLToJSON([]byte("name: [unclosed")); err == nil {
+		t.Error("Expected error for malformed YAML")
+	}
+	if _, err := YAMLToJSON([]byte("? [a, b]\n: one\n")); err == nil {
+		t.Error("Expected error for non-string key")
+	}
+}
+
+func TestLimitBody(t *testing.T) {
+	SetMaxBodyBytes(64)
+	defer SetMaxBodyBytes(DefaultMaxBodyBytes)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		LimitBody(w, r)
+		var spec testSpec
+		if err := DecodeRequest(r, &spec); err != nil {
+			if IsBodyTooLarge(err) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"small JSON", MediaTypeJSON, `{"name": "node-1"}`, http.StatusCreated},
+		{"oversized JSON", MediaTypeJSON, `{"name": "` + strings.Repeat("x", 1<<20) + `"}`, http.StatusRequestEntityTooLarge},
+		{"oversized YAML", MediaTypeYAML, "name: " + strings.Repeat("x", 100), http.StatusRequestEntityTooLarge},
+		{"malformed", MediaTypeJSON, `{"name":`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/devices", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitBody_Disabled(t *testing.T) {
+	SetMaxBodyBytes(0)
+	defer SetMaxBodyBytes(DefaultMaxBodyBytes)
+
+	req := httptest.NewRequest(http.MethodPost, "/devices", strings.NewReader(strings.Repeat("x", 128)))
+	body := req.Body
+	LimitBody(httptest.NewRecorder(), req)
+	if req.Body != body {
+		t.Error("LimitBody should leave the body alone when the limit is disabled")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codec
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// DefaultMaxBodyBytes is the request body limit applied by LimitBody until
+// SetMaxBodyBytes is called.
+const DefaultMaxBodyBytes int64 = 4 << 20 // 4 MiB
+
+var maxBodyBytes atomic.Int64
+
+func init() {
+	maxBodyBytes.Store(DefaultMaxBodyBytes)
+}
+
+// SetMaxBodyBytes sets the request body limit applied by LimitBody.
+// Zero or a negative value disables the limit.
+//
+// The generated server calls this at startup with the max_request_body_bytes
+// setting.
+func SetMaxBodyBytes(n int64) {
+	maxBodyBytes.Store(n)
+}
+
+// MaxBodyBytes returns the request body limit applied by LimitBody, or a
+// value <= 0 if bodies are unlimited.
+func MaxBodyBytes() int64 {
+	return maxBodyBytes.Load()
+}
+
+// LimitBody caps the request body at MaxBodyBytes. Reading past the limit
+// fails with an error for which IsBodyTooLarge is true, and the server closes
+// the connection after the response instead of draining the rest of the body.
+//
+// Call it before decoding:
+//
+//	codec.LimitBody(w, r)
+//	if err := codec.DecodeRequest(r, &req); err != nil {
+//	    if codec.IsBodyTooLarge(err) {
+//	        // 413 Request Entity Too Large
+//	    }
+//	}
+func LimitBody(w http.ResponseWriter, r *http.Request) {
+	if limit := MaxBodyBytes(); limit > 0 && r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+	}
+}
+
+// IsBodyTooLarge reports whether err was caused by reading past the limit
+// set by LimitBody.
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
@@ -0,0 +1,611 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Field naming policies of the wire format, as set by api.field_naming in
+// .fabrica.yaml.
+const (
+	// CamelCase uses the json tags of the Go types as they are (the default)
+	CamelCase = "camel"
+
+	// SnakeCase converts field names to snake_case on the wire
+	SnakeCase = "snake"
+)
+
+// SnakeCaseName returns name in snake_case. Acronyms stay one word, with a
+// trailing plural "s": "parentUID" gives "parent_uid", "IPAddress" gives
+// "ip_address" and "nodeUIDs" gives "node_uids". Names already in
+// snake_case are returned unchanged.
+func SnakeCaseName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && startsWord(runes, i) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// startsWord reports whether the upper case rune at i starts a new word:
+// it follows a lower case letter or digit, or it ends an acronym and is
+// followed by a lower case word other than a plural "s"
+func startsWord(runes []rune, i int) bool {
+	prev := runes[i-1]
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+	if !unicode.IsUpper(prev) || i+1 >= len(runes) || !unicode.IsLower(runes[i+1]) {
+		return false
+	}
+	pluralAcronym := runes[i+1] == 's' && (i+2 == len(runes) || !unicode.IsLower(runes[i+2]))
+	return !pluralAcronym
+}
+
+// keyMode is how the keys of a JSON object are rewritten
+type keyMode int
+
+const (
+	// renameKeys renames keys that are field names
+	renameKeys keyMode = iota
+	// keepKeys keeps the keys of a map but rewrites its values
+	keepKeys
+	// keepAll leaves free-form JSON (interface{} and json.RawMessage
+	// fields) untouched
+	keepAll
+)
+
+// KeyMapper rewrites the object keys of JSON documents between the json tag
+// names of Go types and snake_case. It learns field names from the types it
+// is built from, so the keys of map fields, such as labels and annotations,
+// and of free-form JSON fields are left alone.
+//
+// Example:
+//
+//	keys := codec.NewSnakeCaseMapper(&device.Device{})
+//	wire, err := keys.ToWire(body)   // {"metadata":{"createdAt":...}} -> {"metadata":{"created_at":...}}
+//	body, err = keys.FromWire(wire)  // and back
+type KeyMapper struct {
+	// fromWire maps the snake_case names of known fields to their json names
+	fromWire map[string]string
+	// modes holds how the values of map and free-form fields are rewritten,
+	// by both their json and snake_case names
+	modes map[string]keyMode
+}
+
+// NewSnakeCaseMapper returns a KeyMapper for the fields of the types of
+// samples (pointers to zero values are fine), including the types they
+// contain.
+func NewSnakeCaseMapper(samples ...interface{}) *KeyMapper {
+	m := &KeyMapper{fromWire: map[string]string{}, modes: map[string]keyMode{}}
+	seen := map[reflect.Type]bool{}
+	for _, sample := range samples {
+		m.learn(reflect.TypeOf(sample), seen)
+	}
+	return m
+}
+
+var (
+	rawMessageType    = reflect.TypeOf(json.RawMessage(nil))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// learn records the field names of t and of the types it contains
+func (m *KeyMapper) learn(t reflect.Type, seen map[reflect.Type]bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		m.learn(t.Elem(), seen)
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		// Encodes itself, e.g. time.Time
+		return
+	}
+	m.learnFields(t, map[string]bool{}, seen)
+}
+
+// learnFields records the fields of struct type t, except those named in
+// shadowed. As in encoding/json, fields of embedded structs are promoted
+// unless the outer struct has a field of the same name.
+func (m *KeyMapper) learnFields(t reflect.Type, shadowed map[string]bool, seen map[reflect.Type]bool) {
+	var embedded []reflect.Type
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded = append(embedded, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if shadowed[name] {
+			continue
+		}
+		names = append(names, name)
+
+		m.fromWire[SnakeCaseName(name)] = name
+		if mode := valueMode(field.Type); mode != renameKeys && m.modes[name] < mode {
+			m.modes[name] = mode
+			m.modes[SnakeCaseName(name)] = mode
+		}
+		m.learn(field.Type, seen)
+	}
+
+	if len(embedded) == 0 {
+		return
+	}
+	inner := make(map[string]bool, len(shadowed)+len(names))
+	for name := range shadowed {
+		inner[name] = true
+	}
+	for _, name := range names {
+		inner[name] = true
+	}
+	for _, fieldType := range embedded {
+		m.learnFields(fieldType, inner, seen)
+	}
+}
+
+// valueMode returns how the keys of a value of type t are rewritten
+func valueMode(t reflect.Type) keyMode {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		if t == rawMessageType {
+			return keepAll
+		}
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return keepAll
+	case reflect.Map:
+		if valueMode(t.Elem()) == keepAll {
+			return keepAll
+		}
+		return keepKeys
+	}
+	return renameKeys
+}
+
+// WireName returns the snake_case name of a field
+func (m *KeyMapper) WireName(name string) string {
+	return SnakeCaseName(name)
+}
+
+// FieldName returns the json tag name of the field whose snake_case name is
+// wireName. Other snake_case names are converted to lowerCamelCase
+// ("next_cursor" gives "nextCursor"), and names without underscores are
+// returned as they are.
+func (m *KeyMapper) FieldName(wireName string) string {
+	if name, ok := m.fromWire[wireName]; ok {
+		return name
+	}
+	words := strings.Split(wireName, "_")
+	if words[0] == "" {
+		// "_links" and the like are not snake_case field names
+		return wireName
+	}
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// ToWire converts the keys of a JSON document from json tag names to
+// snake_case. Keys of unknown fields, such as those of list envelopes, are
+// converted too.
+func (m *KeyMapper) ToWire(data []byte) ([]byte, error) {
+	return m.rewrite(data, m.WireName)
+}
+
+// FromWire converts the keys of a JSON document from snake_case to json tag
+// names, as FieldName does. Keys already in the json tag form are kept.
+func (m *KeyMapper) FromWire(data []byte) ([]byte, error) {
+	return m.rewrite(data, m.FieldName)
+}
+
+// FromWirePath converts the segments of a dotted field path ("spec.parent_uid")
+// or, with sep "/", of a JSON Pointer, to json tag names
+func (m *KeyMapper) FromWirePath(path, sep string) string {
+	segments := strings.Split(path, sep)
+	for i, segment := range segments {
+		segments[i] = m.FieldName(segment)
+	}
+	return strings.Join(segments, sep)
+}
+
+// rewrite renames the keys of a JSON document, keeping their order
+func (m *KeyMapper) rewrite(data []byte, rename func(string) string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out bytes.Buffer
+	if err := m.rewriteValue(dec, &out, rename, renameKeys); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the document")
+	}
+	return out.Bytes(), nil
+}
+
+// rewriteValue copies the next JSON value from dec to out, renaming the keys
+// of objects according to mode
+func (m *KeyMapper) rewriteValue(dec *json.Decoder, out *bytes.Buffer, rename func(string) string, mode keyMode) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		value, err := json.Marshal(tok)
+		if err != nil {
+			return err
+		}
+		out.Write(value)
+		return nil
+	}
+
+	switch delim {
+	case '[':
+		out.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := m.rewriteValue(dec, out, rename, mode); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+	case '{':
+		out.WriteByte('{')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := tok.(string)
+			valueMode := mode
+			switch mode {
+			case renameKeys:
+				valueMode = m.modes[key]
+				key = rename(key)
+			case keepKeys:
+				valueMode = renameKeys
+			}
+			encoded, _ := json.Marshal(key)
+			out.Write(encoded)
+			out.WriteByte(':')
+			if err := m.rewriteValue(dec, out, rename, valueMode); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+	}
+	// The closing delimiter
+	_, err = dec.Token()
+	return err
+}
+
+// rewriteYAML renames the keys of a YAML document, keeping their order and
+// the document's style
+func (m *KeyMapper) rewriteYAML(data []byte, rename func(string) string) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	m.rewriteNode(&node, rename, renameKeys)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// rewriteNode renames the mapping keys below node according to mode, as
+// rewriteValue does for JSON
+func (m *KeyMapper) rewriteNode(node *yaml.Node, rename func(string) string, mode keyMode) {
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			m.rewriteNode(child, rename, mode)
+		}
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		valueMode := mode
+		switch mode {
+		case renameKeys:
+			valueMode = m.modes[key.Value]
+			key.Value = rename(key.Value)
+		case keepKeys:
+			valueMode = renameKeys
+		}
+		m.rewriteNode(value, rename, valueMode)
+	}
+}
+
+// Middleware serves the handlers of next with snake_case field names on the
+// wire. Request bodies are converted to json tag names before next reads
+// them: JSON and YAML documents, merge and shorthand patches by their keys,
+// and JSON Patch operations by their paths. JSON and YAML responses are
+// converted to snake_case, which buffers them; NDJSON responses are
+// converted line by line as they are written, so they still stream and
+// flush. Bodies that are not
+// valid, or larger than MaxBodyBytes, are passed through for next to
+// reject.
+//
+// Example:
+//
+//	keys := codec.NewSnakeCaseMapper(&device.Device{}, &CreateDeviceRequest{})
+//	r.Use(keys.Middleware)
+func (m *KeyMapper) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil && r.Body != http.NoBody {
+			m.rewriteRequest(r)
+		}
+		rec := &wireRecorder{ResponseWriter: w, mapper: m, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		rec.finish()
+	})
+}
+
+// rewriteRequest replaces the body of r with its fields renamed
+func (m *KeyMapper) rewriteRequest(r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodyBytes()+1))
+	rest := r.Body
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if err != nil || int64(len(body)) > MaxBodyBytes() {
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	var converted []byte
+	switch baseMediaType(contentType) {
+	case "application/json-patch+json":
+		converted, err = m.fromWirePatch(body)
+	case "application/shorthand-patch+json":
+		converted, err = m.fromWireShorthand(body)
+	default:
+		if IsYAML(contentType) {
+			converted, err = m.rewriteYAML(body, m.FieldName)
+		} else {
+			converted, err = m.FromWire(body)
+		}
+	}
+	if err != nil {
+		return
+	}
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{bytes.NewReader(converted), rest}
+	r.ContentLength = int64(len(converted))
+	r.Header.Del("Content-Length")
+}
+
+// fromWirePatch converts the paths of JSON Patch operations
+func (m *KeyMapper) fromWirePatch(body []byte) ([]byte, error) {
+	var ops []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &ops); err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		for _, key := range []string{"path", "from"} {
+			var pointer string
+			if raw, ok := op[key]; !ok || json.Unmarshal(raw, &pointer) != nil {
+				continue
+			}
+			op[key], _ = json.Marshal(m.FromWirePath(pointer, "/"))
+		}
+		if value, ok := op["value"]; ok {
+			converted, err := m.FromWire(value)
+			if err != nil {
+				return nil, err
+			}
+			op["value"] = converted
+		}
+	}
+	return json.Marshal(ops)
+}
+
+// fromWireShorthand converts the dotted paths of a shorthand patch, keeping
+// their order and any array operators ("tags[]", "ports[0]")
+func (m *KeyMapper) fromWireShorthand(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("shorthand patch is not a JSON object")
+	}
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if value, err = m.FromWire(value); err != nil {
+			return nil, err
+		}
+		segments := strings.Split(tok.(string), ".")
+		for i, segment := range segments {
+			end := strings.IndexByte(segment, '[')
+			if end == -1 {
+				end = len(segment)
+			}
+			segments[i] = m.FieldName(segment[:end]) + segment[end:]
+		}
+		key, _ := json.Marshal(strings.Join(segments, "."))
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		out.Write(key)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+// wireRecorder buffers a response so its fields can be renamed. NDJSON
+// responses are streamed instead: each complete line is renamed and written
+// through as it arrives, and Flush reaches the underlying writer.
+type wireRecorder struct {
+	http.ResponseWriter
+	mapper      *KeyMapper
+	status      int
+	wroteHeader bool
+	streaming   bool
+	body        bytes.Buffer // the whole response, or the unfinished line when streaming
+}
+
+func (w *wireRecorder) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	if baseMediaType(w.Header().Get("Content-Type")) == MediaTypeNDJSON {
+		w.streaming = true
+		w.Header().Del("Content-Length")
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *wireRecorder) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(p)
+	if !w.streaming {
+		return len(p), nil
+	}
+	for {
+		end := bytes.IndexByte(w.body.Bytes(), '\n')
+		if end == -1 {
+			return len(p), nil
+		}
+		line := w.mapper.toWireLine(w.body.Next(end + 1)[:end:end])
+		if _, err := w.ResponseWriter.Write(append(line, '\n')); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush sends the lines written so far when streaming; otherwise it is a
+// no-op, since the response is sent by finish
+func (w *wireRecorder) Flush() {
+	if w.streaming {
+		_ = http.NewResponseController(w.ResponseWriter).Flush()
+	}
+}
+
+// finish writes the buffered response with its fields renamed, or the
+// unterminated last line of a streamed one
+func (w *wireRecorder) finish() {
+	m := w.mapper
+	body := w.body.Bytes()
+	if w.streaming {
+		if len(body) > 0 {
+			w.ResponseWriter.Write(m.toWireLine(body)) //nolint:errcheck
+		}
+		return
+	}
+	contentType := w.Header().Get("Content-Type")
+	base := baseMediaType(contentType)
+
+	var converted []byte
+	var err error
+	switch {
+	case len(body) == 0:
+	case IsYAML(contentType):
+		converted, err = m.rewriteYAML(body, m.WireName)
+	case base == MediaTypeJSON || strings.HasSuffix(base, "+json"):
+		converted, err = m.ToWire(body)
+		if err == nil && bytes.HasSuffix(body, []byte("\n")) {
+			converted = append(converted, '\n')
+		}
+	}
+	if converted != nil && err == nil {
+		body = converted
+	}
+
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(body) //nolint:errcheck
+}
+
+// toWireLine renames the fields of one NDJSON line, leaving a line that is
+// not valid JSON as it is
+func (m *KeyMapper) toWireLine(line []byte) []byte {
+	converted, err := m.ToWire(line)
+	if err != nil {
+		return line
+	}
+	return converted
+}
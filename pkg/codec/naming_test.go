@@ -0,0 +1,176 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codec
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSnakeCaseName(t *testing.T) {
+	tests := map[string]string{
+		"name":               "name",
+		"apiVersion":         "api_version",
+		"parentUID":          "parent_uid",
+		"nodeUIDs":           "node_uids",
+		"IPAddress":          "ip_address",
+		"ipv4Address":        "ipv4_address",
+		"observedGeneration": "observed_generation",
+		"bus_type":           "bus_type",
+		"UIDs":               "uids",
+		"ABCSet":             "abc_set",
+	}
+	for name, want := range tests {
+		if got := SnakeCaseName(name); got != want {
+			t.Errorf("SnakeCaseName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+type namedMetadata struct {
+	CreatedAt string            `json:"createdAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type namedSpec struct {
+	ParentUID  string                 `json:"parentUID"`
+	Ports      []namedPort            `json:"ports,omitempty"`
+	Interfaces map[string]namedPort   `json:"interfaces,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Raw        json.RawMessage        `json:"rawConfig,omitempty"`
+}
+
+type namedPort struct {
+	PortNumber int `json:"portNumber"`
+}
+
+type namedBase struct {
+	Spec interface{} `json:"spec"`
+}
+
+type namedResource struct {
+	namedBase
+	Metadata namedMetadata `json:"metadata"`
+	Spec     namedSpec     `json:"spec"`
+}
+
+func TestKeyMapper(t *testing.T) {
+	keys := NewSnakeCaseMapper(&namedResource{})
+	doc := `{"metadata":{"createdAt":"now","labels":{"rackName":"r1"}},"spec":{"parentUID":"p","ports":[{"portNumber":1}],` +
+		`"interfaces":{"eth0":{"portNumber":2}},"properties":{"someKey":{"innerKey":1.50}},"rawConfig":{"keepMe":true}},"nextCursor":"c"}`
+	wire := `{"metadata":{"created_at":"now","labels":{"rackName":"r1"}},"spec":{"parent_uid":"p","ports":[{"port_number":1}],` +
+		`"interfaces":{"eth0":{"port_number":2}},"properties":{"someKey":{"innerKey":1.50}},"raw_config":{"keepMe":true}},"next_cursor":"c"}`
+
+	got, err := keys.ToWire([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != wire {
+		t.Errorf("ToWire =\n%s\nwant\n%s", got, wire)
+	}
+
+	back, err := keys.FromWire([]byte(wire))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(back) != doc {
+		t.Errorf("FromWire =\n%s\nwant\n%s", back, doc)
+	}
+
+	yamlDoc := "metadata:\n  createdAt: now\n  labels:\n    rackName: r1\nspec:\n  parentUID: p\n"
+	yamlWire := "metadata:\n  created_at: now\n  labels:\n    rackName: r1\nspec:\n  parent_uid: p\n"
+	if got, err := keys.rewriteYAML([]byte(yamlDoc), keys.WireName); err != nil || string(got) != yamlWire {
+		t.Errorf("rewriteYAML =\n%s\n%v, want\n%s", got, err, yamlWire)
+	}
+
+	if _, err := keys.ToWire([]byte(`{"a":1} {}`)); err == nil {
+		t.Error("ToWire accepted trailing data")
+	}
+	if got := keys.FromWirePath("/spec/ports/0/port_number", "/"); got != "/spec/ports/0/portNumber" {
+		t.Errorf("FromWirePath = %q", got)
+	}
+}
+
+func TestKeyMapper_Middleware(t *testing.T) {
+	keys := NewSnakeCaseMapper(&namedResource{})
+	var received string
+	handler := keys.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"spec":{"parentUID":"p"}}` + "\n")) //nolint:errcheck
+	}))
+
+	tests := []struct {
+		contentType, body, want string
+	}{
+		{"application/json", `{"spec":{"parent_uid":"p"}}`, `{"spec":{"parentUID":"p"}}`},
+		{"application/merge-patch+json", `{"parent_uid":"p","parentUID":"q"}`, `{"parentUID":"p","parentUID":"q"}`},
+		{"application/json-patch+json", `[{"op":"add","path":"/ports/0","value":{"port_number":1}}]`, `[{"op":"add","path":"/ports/0","value":{"portNumber":1}}]`},
+		{"application/shorthand-patch+json", `{"spec.parent_uid":"p","ports[0].port_number":1,"ports[]":{"port_number":2}}`, `{"spec.parentUID":"p","ports[0].portNumber":1,"ports[]":{"portNumber":2}}`},
+		{"application/json", `{"spec":`, `{"spec":`},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", tt.contentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if received != tt.want {
+			t.Errorf("%s body %s reached the handler as %s, want %s", tt.contentType, tt.body, received, tt.want)
+		}
+		if w.Code != http.StatusCreated || w.Body.String() != `{"spec":{"parent_uid":"p"}}`+"\n" {
+			t.Errorf("response = %d %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestKeyMapper_MiddlewareStreamsNDJSON(t *testing.T) {
+	keys := NewSnakeCaseMapper(&namedResource{})
+	server := httptest.NewServer(keys.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MediaTypeNDJSON)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"spec":{"parentUID":"a"}}` + "\n{\"spec\":")) //nolint:errcheck
+		http.NewResponseController(w).Flush()                          //nolint:errcheck
+		<-r.Context().Done()
+	})))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	// The first line arrives, renamed, while the handler is still running
+	line := make([]byte, len(`{"spec":{"parent_uid":"a"}}`+"\n"))
+	if _, err := io.ReadFull(resp.Body, line); err != nil {
+		t.Fatal(err)
+	}
+	if string(line) != `{"spec":{"parent_uid":"a"}}`+"\n" {
+		t.Errorf("first line = %q", line)
+	}
+}
+
+func TestKeyMapper_MiddlewareNDJSONLines(t *testing.T) {
+	keys := NewSnakeCaseMapper(&namedResource{})
+	handler := keys.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MediaTypeNDJSON)
+		// Lines split across writes, and a last line without a newline
+		w.Write([]byte(`{"spec":{"paren`))                                   //nolint:errcheck
+		w.Write([]byte(`tUID":"a"}}` + "\n" + `{"spec":{"parentUID":"b"}}`)) //nolint:errcheck
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := `{"spec":{"parent_uid":"a"}}` + "\n" + `{"spec":{"parent_uid":"b"}}`
+	if w.Code != http.StatusOK || w.Body.String() != want {
+		t.Errorf("response = %d %q, want %q", w.Code, w.Body.String(), want)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codec
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ListWriter writes the elements of a list response one at a time
+type ListWriter interface {
+	// Write encodes v as the next element
+	Write(v interface{}) error
+
+	// Close finishes the list
+	Close() error
+
+	// Count returns the number of elements written
+	Count() int
+}
+
+// NewListWriter returns a ListWriter for mediaType: a LineWriter for
+// MediaTypeNDJSON, an ArrayWriter otherwise.
+func NewListWriter(w io.Writer, mediaType string) ListWriter {
+	if mediaType == MediaTypeNDJSON {
+		return NewLineWriter(w)
+	}
+	return NewArrayWriter(w)
+}
+
+// ArrayWriter writes a JSON array one element at a time, so a large
+// collection can be sent without holding all of it in memory:
+//
+//	array := codec.NewArrayWriter(w)
+//	for _, device := range devices {
+//	    if err := array.Write(device); err != nil {
+//	        return err
+//	    }
+//	}
+//	return array.Close()
+//
+// The output is byte-for-byte what json.Encoder produces for the whole slice.
+type ArrayWriter struct {
+	w     io.Writer
+	count int
+	err   error
+}
+
+// NewArrayWriter returns an ArrayWriter writing to w. Nothing is written
+// until the first call to Write or Close.
+func NewArrayWriter(w io.Writer) *ArrayWriter {
+	return &ArrayWriter{w: w}
+}
+
+// Write encodes v as the next array element. After an error, every later
+// call returns the same error.
+func (a *ArrayWriter) Write(v interface{}) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		a.err = err
+		return err
+	}
+	sep := []byte{','}
+	if a.count == 0 {
+		sep[0] = '['
+	}
+	if _, err := a.w.Write(append(sep, data...)); err != nil {
+		a.err = err
+		return err
+	}
+	a.count++
+	return nil
+}
+
+// Close ends the array, followed by a newline. An array with no elements is
+// written as [].
+func (a *ArrayWriter) Close() error {
+	if a.err != nil {
+		return a.err
+	}
+
+	end := "]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	}
+	_, a.err = io.WriteString(a.w, end)
+	return a.err
+}
+
+// Count returns the number of elements written
+func (a *ArrayWriter) Count() int {
+	return a.count
+}
+
+// LineWriter writes newline-delimited JSON (MediaTypeNDJSON): each element on
+// its own line, with nothing before the first or after the last.
+type LineWriter struct {
+	w     io.Writer
+	count int
+	err   error
+}
+
+// NewLineWriter returns a LineWriter writing to w
+func NewLineWriter(w io.Writer) *LineWriter {
+	return &LineWriter{w: w}
+}
+
+// Write encodes v as the next line. After an error, every later call returns
+// the same error.
+func (l *LineWriter) Write(v interface{}) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		l.err = err
+		return err
+	}
+	if _, err := l.w.Write(append(data, '\n')); err != nil {
+		l.err = err
+		return err
+	}
+	l.count++
+	return nil
+}
+
+// Close returns the first error Write encountered; it writes nothing
+func (l *LineWriter) Close() error {
+	return l.err
+}
+
+// Count returns the number of lines written
+func (l *LineWriter) Count() int {
+	return l.count
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestArrayWriter_MatchesEncoder(t *testing.T) {
+	for _, items := range [][]testSpec{
+		nil,
+		{{Name: "node-1"}},
+		{{Name: "node-1", Ports: []int{22}}, {Name: "<node-2>"}},
+	} {
+		var streamed bytes.Buffer
+		array := NewArrayWriter(&streamed)
+		for _, item := range items {
+			if err := array.Write(item); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+		}
+		if err := array.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		var encoded bytes.Buffer
+		if err := json.NewEncoder(&encoded).Encode(append([]testSpec{}, items...)); err != nil {
+			t.Fatal(err)
+		}
+		if streamed.String() != encoded.String() {
+			t.Errorf("Streamed %q, want %q", streamed.String(), encoded.String())
+		}
+		if array.Count() != len(items) {
+			t.Errorf("Count = %d, want %d", array.Count(), len(items))
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestArrayWriter_StickyError(t *testing.T) {
+	array := NewArrayWriter(failingWriter{})
+	first := array.Write(testSpec{Name: "node-1"})
+	if first == nil {
+		t.Fatal("Expected a write error")
+	}
+	if err := array.Write(testSpec{Name: "node-2"}); err != first {
+		t.Errorf("Second Write = %v, want %v", err, first)
+	}
+	if err := array.Close(); err != first {
+		t.Errorf("Close = %v, want %v", err, first)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	lines := NewListWriter(&buf, MediaTypeNDJSON)
+	for _, item := range []testSpec{{Name: "node-1"}, {Name: "node-2", Ports: []int{22}}} {
+		if err := lines.Write(item); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := lines.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := `{"name":"node-1"}` + "\n" + `{"name":"node-2","ports":[22]}` + "\n"
+	if buf.String() != want || lines.Count() != 2 {
+		t.Errorf("NDJSON = %q (%d lines), want %q", buf.String(), lines.Count(), want)
+	}
+
+	// An empty list is an empty body
+	buf.Reset()
+	if err := NewLineWriter(&buf).Close(); err != nil || buf.Len() != 0 {
+		t.Errorf("Empty NDJSON = %q, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// CacheFileName is the incremental generation cache, relative to the project root
+const CacheFileName = ".fabrica-cache"
+
+// GenerationCache records the inputs each generated file was produced from,
+// so that files whose inputs have not changed can be skipped on the next run.
+//
+// Inputs are hashed from the embedded template set, the Fabrica version, the
+// generator configuration, and the definitions of the resources the file is
+// generated from. Per-resource files (e.g. handlers) only depend on their own
+// resource; files covering all resources (models, routes, storage) depend on
+// every resource.
+type GenerationCache struct {
+	// Files maps a generated file path to the hash of its inputs
+	Files map[string]string `json:"files"`
+
+	path        string
+	regenerated int
+	unchanged   int
+}
+
+// LoadGenerationCache reads the cache at path. A missing or corrupt cache is
+// not an error; it yields an empty cache, so everything is regenerated.
+func LoadGenerationCache(path string) (*GenerationCache, error) {
+	cache := &GenerationCache{Files: make(map[string]string), path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read generation cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil || cache.Files == nil {
+		// A corrupt cache only costs a full regeneration
+		cache.Files = make(map[string]string)
+	}
+	return cache, nil
+}
+
+// Save writes the cache back to the path it was loaded from.
+func (c *GenerationCache) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode generation cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write generation cache: %w", err)
+	}
+	return nil
+}
+
+// Counts returns how many files were regenerated and how many were skipped
+// as unchanged since the cache was loaded.
+func (c *GenerationCache) Counts() (regenerated, unchanged int) {
+	return c.regenerated, c.unchanged
+}
+
+// upToDate reports whether filename exists and was last generated from the
+// given inputs. It always returns false when no cache is set or Force is on.
+func (g *Generator) upToDate(filename, inputs string) bool {
+	if g.Cache == nil || g.Force || g.Cache.Files[filename] != inputs {
+		return false
+	}
+	if _, err := os.Stat(filename); err != nil {
+		return false
+	}
+
+	g.Cache.unchanged++
+	if g.Verbose {
+		fmt.Printf("  · Unchanged %s\n", filename)
+	}
+	return true
+}
+
+// recordGenerated stores the inputs filename was generated from.
+func (g *Generator) recordGenerated(filename, inputs string) {
+	if g.Cache == nil {
+		return
+	}
+	g.Cache.Files[filename] = inputs
+	g.Cache.regenerated++
+}
+
+// inputsHash hashes everything a generated file depends on: the template
+// set, the generator settings, and the given resources.
+func (g *Generator) inputsHash(resources ...ResourceMetadata) string {
+	h := sha256.New()
+
+	settings, _ := json.Marshal(struct {
+		Templates   string
+		Version     string
+		ModulePath  string
+		PackageName string
+		OutputDir   string
+		StorageType string
+		DBDriver    string
+		Config      *GeneratorConfig
+	}{g.templateHash, g.Version, g.ModulePath, g.PackageName, g.OutputDir, g.StorageType, g.DBDriver, g.Config})
+	h.Write(settings)
+
+	for _, res := range resources {
+		definition, _ := json.Marshal(res)
+		h.Write(definition)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashTemplates hashes the contents of every embedded template.
+func hashTemplates() (string, error) {
+	h := sha256.New()
+	err := fs.WalkDir(embeddedTemplates, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := embeddedTemplates.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", path, len(content))
+		h.Write(content)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash templates: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// withTemplateOverrides folds the contents of overridden templates into the
+// embedded template set hash.
+func withTemplateOverrides(templateHash string, overrides []string) string {
+	if len(overrides) == 0 {
+		return templateHash
+	}
+	sort.Strings(overrides)
+
+	h := sha256.New()
+	h.Write([]byte(templateHash))
+	for _, override := range overrides {
+		h.Write([]byte{0})
+		h.Write([]byte(override))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// typeFingerprint hashes the structure of a resource type: field names,
+// types and struct tags, recursively. Changing a nested spec type changes
+// the fingerprint even if the top-level spec fields stay the same.
+func typeFingerprint(t reflect.Type) string {
+	h := sha256.New()
+	writeTypeSignature(h, t, make(map[reflect.Type]bool))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeTypeSignature(h hash.Hash, t reflect.Type, seen map[reflect.Type]bool) {
+	if t.Name() != "" {
+		fmt.Fprintf(h, "%s.%s", t.PkgPath(), t.Name())
+		// Predeclared types need no expansion; named types are expanded once
+		if t.PkgPath() == "" || seen[t] {
+			return
+		}
+		seen[t] = true
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		fmt.Fprintf(h, "(%s", t.Kind())
+		if t.Kind() == reflect.Array {
+			fmt.Fprintf(h, "%d", t.Len())
+		}
+		writeTypeSignature(h, t.Elem(), seen)
+		h.Write([]byte(")"))
+	case reflect.Map:
+		h.Write([]byte("(map "))
+		writeTypeSignature(h, t.Key(), seen)
+		writeTypeSignature(h, t.Elem(), seen)
+		h.Write([]byte(")"))
+	case reflect.Struct:
+		h.Write([]byte("{"))
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fmt.Fprintf(h, "%s %q ", field.Name, field.Tag)
+			writeTypeSignature(h, field.Type, seen)
+			h.Write([]byte(";"))
+		}
+		h.Write([]byte("}"))
+	default:
+		fmt.Fprintf(h, "(%s)", t.Kind())
+	}
+}
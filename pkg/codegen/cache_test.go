@@ -0,0 +1,121 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/node"
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/rack"
+)
+
+// newCachedGenerator returns a generator for the rack and node test
+// resources that uses the cache stored in dir.
+func newCachedGenerator(t *testing.T, dir string) *Generator {
+	t.Helper()
+
+	gen := NewGenerator(dir, "main", "example.com/app")
+	cache, err := LoadGenerationCache(filepath.Join(dir, CacheFileName))
+	if err != nil {
+		t.Fatalf("LoadGenerationCache failed: %v", err)
+	}
+	gen.Cache = cache
+
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource(Rack) failed: %v", err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatalf("RegisterResource(Node) failed: %v", err)
+	}
+	return gen
+}
+
+func generateHandlers(t *testing.T, gen *Generator) (regenerated, unchanged int) {
+	t.Helper()
+
+	if err := gen.GenerateHandlers(); err != nil {
+		t.Fatalf("GenerateHandlers failed: %v", err)
+	}
+	if err := gen.Cache.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	return gen.Cache.Counts()
+}
+
+func TestGenerationCache_SkipsUnchangedResources(t *testing.T) {
+	dir := t.TempDir()
+
+	if regenerated, unchanged := generateHandlers(t, newCachedGenerator(t, dir)); regenerated != 2 || unchanged != 0 {
+		t.Errorf("First run: %d regenerated, %d unchanged; want 2, 0", regenerated, unchanged)
+	}
+
+	if regenerated, unchanged := generateHandlers(t, newCachedGenerator(t, dir)); regenerated != 0 || unchanged != 2 {
+		t.Errorf("Second run: %d regenerated, %d unchanged; want 0, 2", regenerated, unchanged)
+	}
+
+	// Changing one resource only regenerates that resource's handlers
+	gen := newCachedGenerator(t, dir)
+	gen.SetResourceTag("Rack", "versioning", "enabled")
+	if regenerated, unchanged := generateHandlers(t, gen); regenerated != 1 || unchanged != 1 {
+		t.Errorf("After changing Rack: %d regenerated, %d unchanged; want 1, 1", regenerated, unchanged)
+	}
+
+	// Deleted output files are regenerated
+	if err := os.Remove(filepath.Join(dir, "node_handlers_generated.go")); err != nil {
+		t.Fatal(err)
+	}
+	gen = newCachedGenerator(t, dir)
+	gen.SetResourceTag("Rack", "versioning", "enabled")
+	if regenerated, unchanged := generateHandlers(t, gen); regenerated != 1 || unchanged != 1 {
+		t.Errorf("After deleting node handlers: %d regenerated, %d unchanged; want 1, 1", regenerated, unchanged)
+	}
+
+	gen = newCachedGenerator(t, dir)
+	gen.Force = true
+	if regenerated, unchanged := generateHandlers(t, gen); regenerated != 2 || unchanged != 0 {
+		t.Errorf("Forced run: %d regenerated, %d unchanged; want 2, 0", regenerated, unchanged)
+	}
+}
+
+func TestGenerationCache_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CacheFileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := LoadGenerationCache(path)
+	if err != nil {
+		t.Fatalf("LoadGenerationCache failed: %v", err)
+	}
+	if len(cache.Files) != 0 {
+		t.Errorf("Files = %v, want empty", cache.Files)
+	}
+}
+
+func TestTypeFingerprint_NestedChange(t *testing.T) {
+	v1 := reflect.TypeOf(struct {
+		Inner struct {
+			Slots int `json:"slots"`
+		}
+	}{})
+	v2 := reflect.TypeOf(struct {
+		Inner struct {
+			Slots int `json:"slots,omitempty"`
+		}
+	}{})
+
+	if typeFingerprint(v1) == typeFingerprint(v2) {
+		t.Error("Fingerprint should change when a nested field tag changes")
+	}
+	if typeFingerprint(reflect.TypeOf(rack.Rack{})) != typeFingerprint(reflect.TypeOf(rack.Rack{})) {
+		t.Error("Fingerprint should be stable for the same type")
+	}
+}
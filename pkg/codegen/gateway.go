@@ -0,0 +1,200 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GatewayService is a backend service behind a generated gateway. The
+// gateway forwards requests for each of its resource paths to URL.
+type GatewayService struct {
+	// Name identifies the service, e.g. "inventory"
+	Name string
+
+	// URL is the base URL requests are forwarded to
+	URL string
+
+	// Prefix is the path the service serves its API under, taken from the
+	// server URL in its OpenAPI document (e.g. "/api/v1"; "" for the root)
+	Prefix string
+
+	// Title is the title of the service's OpenAPI document
+	Title string
+
+	// Resources are the collection paths the service owns
+	Resources []GatewayResource
+}
+
+// GatewayResource is a collection path owned by a gateway backend
+type GatewayResource struct {
+	// Kind is the resource kind, from the tags of the path's operations
+	Kind string
+
+	// Path is the collection path, e.g. "/devices"
+	Path string
+}
+
+// gatewayTemplateData drives the gateway templates
+type gatewayTemplateData struct {
+	Services []GatewayService
+}
+
+var gatewayServiceName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// ParseGatewayService builds the gateway description of the service at
+// baseURL from its OpenAPI 3 document. Every top-level path segment of the
+// document becomes a resource path of the service: /devices, /devices/count
+// and /devices/{uid} all belong to /devices.
+func ParseGatewayService(name, baseURL string, data []byte) (*GatewayService, error) {
+	if !gatewayServiceName.MatchString(name) {
+		return nil, fmt.Errorf("invalid service name %q: use lower-case letters, digits and dashes", name)
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q for service %s: must be absolute, e.g. http://%s:8080", baseURL, name, name)
+	}
+
+	var doc oaDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document of service %s: %w", name, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("service %s: not an OpenAPI 3 document (openapi: %q)", name, doc.OpenAPI)
+	}
+
+	service := &GatewayService{
+		Name:  name,
+		URL:   strings.TrimRight(baseURL, "/"),
+		Title: doc.Info.Title,
+	}
+	if len(doc.Servers) > 0 {
+		// Only the path matters: the host is wherever the service runs
+		if server, err := url.Parse(doc.Servers[0].URL); err == nil {
+			service.Prefix = strings.TrimRight(server.Path, "/")
+		}
+	}
+	// A base URL that already includes the prefix (the document was
+	// fetched from <url><prefix>/openapi.json) must not get it twice
+	if service.Prefix != "" && strings.HasSuffix(service.URL, service.Prefix) {
+		service.URL = strings.TrimSuffix(service.URL, service.Prefix)
+	}
+
+	kinds := map[string]string{}
+	for _, path := range sortedKeys(doc.Paths) {
+		segment := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+		if segment == "" || strings.Contains(segment, "{") {
+			continue
+		}
+		collection := "/" + segment
+		if _, ok := kinds[collection]; !ok {
+			kinds[collection] = ""
+		}
+		ops := doc.Paths[path].operations()
+		for _, method := range sortedKeys(ops) {
+			if kinds[collection] == "" && len(ops[method].Tags) > 0 {
+				kinds[collection] = ops[method].Tags[0]
+			}
+		}
+	}
+	for _, path := range sortedKeys(kinds) {
+		service.Resources = append(service.Resources, GatewayResource{Kind: kinds[path], Path: path})
+	}
+	if len(service.Resources) == 0 {
+		return nil, fmt.Errorf("service %s defines no resource paths", name)
+	}
+
+	return service, nil
+}
+
+// GenerateGateway generates a chi-based reverse proxy that serves the
+// resources of every service under one router. The routing table is written
+// to outputDir/gateway_generated.go and regenerated on every run; the
+// outputDir/main.go that serves it belongs to the project once written, so
+// an existing one is kept.
+func GenerateGateway(services []GatewayService, outputDir string) error {
+	if len(services) == 0 {
+		return fmt.Errorf("no services given")
+	}
+
+	owners := map[string]string{}
+	names := map[string]bool{}
+	for _, service := range services {
+		if names[service.Name] {
+			return fmt.Errorf("service %s is listed twice", service.Name)
+		}
+		names[service.Name] = true
+		for _, res := range service.Resources {
+			if owner, ok := owners[res.Path]; ok {
+				return fmt.Errorf("%s is served by both %s and %s", res.Path, owner, service.Name)
+			}
+			owners[res.Path] = service.Name
+		}
+	}
+	sorted := append([]GatewayService(nil), services...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	data := gatewayTemplateData{Services: sorted}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for _, file := range []struct {
+		templatePath, filename string
+		keepExisting           bool
+	}{
+		{"templates/gateway/gateway.go.tmpl", "gateway_generated.go", false},
+		{"templates/gateway/main.go.tmpl", "main.go", true},
+	} {
+		path := filepath.Join(outputDir, file.filename)
+		if file.keepExisting {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				continue
+			}
+		}
+		if err := renderGoFile(file.templatePath, path, data); err != nil {
+			return err
+		}
+		fmt.Printf("  ✓ Generated %s\n", path)
+	}
+
+	return nil
+}
+
+// renderGoFile executes an embedded template and writes the formatted result
+func renderGoFile(templatePath, path string, data interface{}) error {
+	content, err := embeddedTemplates.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to read embedded template: %w", err)
+	}
+	name := strings.TrimPrefix(templatePath, "templates/")
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, formatted, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testInventoryDoc = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Inventory", "version": "1.0.0"},
+  "servers": [{"url": "http://localhost:8080/api/v1"}],
+  "paths": {
+    "/devices": {"get": {"tags": ["Device"]}, "post": {"tags": ["Device"]}},
+    "/devices/count": {"get": {"tags": ["Device"]}},
+    "/devices/{uid}": {"get": {"tags": ["Device"]}},
+    "/racks/{uid}": {"get": {"tags": ["Rack"]}},
+    "/{kind}": {"get": {}}
+  }
+}`
+
+const testPowerDoc = `openapi: 3.0.3
+info:
+  title: Power
+paths:
+  /outlets:
+    get:
+      tags: [Outlet]
+`
+
+func TestParseGatewayService(t *testing.T) {
+	service, err := ParseGatewayService("inventory", "http://inventory:8080/", []byte(testInventoryDoc))
+	if err != nil {
+		t.Fatalf("ParseGatewayService failed: %v", err)
+	}
+	if service.URL != "http://inventory:8080" || service.Prefix != "/api/v1" || service.Title != "Inventory" {
+		t.Errorf("service = %+v", service)
+	}
+	want := []GatewayResource{{Kind: "Device", Path: "/devices"}, {Kind: "Rack", Path: "/racks"}}
+	if len(service.Resources) != len(want) {
+		t.Fatalf("Resources = %+v, want %+v", service.Resources, want)
+	}
+	for i := range want {
+		if service.Resources[i] != want[i] {
+			t.Errorf("Resources[%d] = %+v, want %+v", i, service.Resources[i], want[i])
+		}
+	}
+
+	// The prefix is not added twice when the URL already includes it
+	service, err = ParseGatewayService("inventory", "http://inventory:8080/api/v1", []byte(testInventoryDoc))
+	if err != nil {
+		t.Fatalf("ParseGatewayService failed: %v", err)
+	}
+	if service.URL != "http://inventory:8080" {
+		t.Errorf("URL = %q, want the prefix stripped", service.URL)
+	}
+}
+
+func TestParseGatewayService_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name, serviceName, url, doc string
+	}{
+		{"bad name", "Inventory", "http://inventory:8080", testInventoryDoc},
+		{"relative URL", "inventory", "/inventory", testInventoryDoc},
+		{"swagger", "inventory", "http://inventory:8080", `{"swagger": "2.0", "paths": {}}`},
+		{"no paths", "inventory", "http://inventory:8080", `{"openapi": "3.0.0", "paths": {}}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseGatewayService(tt.serviceName, tt.url, []byte(tt.doc)); err == nil {
+				t.Error("ParseGatewayService succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestGenerateGateway(t *testing.T) {
+	inventory, err := ParseGatewayService("inventory", "http://inventory:8080", []byte(testInventoryDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	power, err := ParseGatewayService("power", "http://power:8080", []byte(testPowerDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "gateway")
+	if err := GenerateGateway([]GatewayService{*power, *inventory}, out); err != nil {
+		t.Fatalf("GenerateGateway failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(out, "gateway_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`Prefix: "/api/v1"`,
+		`{Kind: "Device", Path: "/devices"}`,
+		`{Kind: "Outlet", Path: "/outlets"}`,
+		"func RegisterGatewayRoutes(r chi.Router, prefix string) error",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Generated gateway missing %q", want)
+		}
+	}
+	if strings.Index(string(content), `Name:   "inventory"`) > strings.Index(string(content), `Name:   "power"`) {
+		t.Error("Backends are not sorted by name")
+	}
+
+	// main.go belongs to the project once written
+	mainPath := filepath.Join(out, "main.go")
+	if err := os.WriteFile(mainPath, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateGateway([]GatewayService{*inventory}, out); err != nil {
+		t.Fatalf("GenerateGateway failed: %v", err)
+	}
+	if content, _ := os.ReadFile(mainPath); string(content) != "package main\n" {
+		t.Error("Existing main.go was overwritten")
+	}
+}
+
+func TestGenerateGateway_RejectsSharedPaths(t *testing.T) {
+	inventory, err := ParseGatewayService("inventory", "http://inventory:8080", []byte(testInventoryDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := *inventory
+	other.Name = "inventory-b"
+
+	err = GenerateGateway([]GatewayService{*inventory, other}, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "/devices is served by both inventory and inventory-b") {
+		t.Errorf("GenerateGateway error = %v", err)
+	}
+}
@@ -37,15 +37,19 @@ package codegen
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"go/format"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/openchami/fabrica/pkg/codec"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -79,6 +83,16 @@ type SpecField struct {
 	Type         string // Go type (e.g., "string", "int")
 	Required     bool   // Whether field is required
 	ExampleValue string // Example value for documentation
+	Scalar       bool   // Whether the field is a string, bool or number (printable in a table column)
+	Scale        bool   // Whether the field is tagged scaleField (exposed by the scale subresource)
+	RefKind      string // Kind named by the field's ref:"Kind" tag, if any
+	Unique       bool   // Whether no two resources may share the field's value (see SetResourceUnique)
+
+	// gRPC mapping (see GenerateProto)
+	ProtoName       string // proto3 field name (e.g., "ip_address")
+	ProtoGoName     string // Field name in protoc-generated Go code (e.g., "IpAddress")
+	ProtoType       string // proto3 type (e.g., "int64", "repeated string"); empty if not serialized
+	ProtoConversion string // How the gRPC server converts the field: direct, cast, time or json
 }
 
 // ResourceMetadata holds metadata about a resource type for code generation
@@ -94,11 +108,74 @@ type ResourceMetadata struct {
 	StorageName  string            // e.g., "User" for storage function names
 	Tags         map[string]string // Additional metadata
 	SpecFields   []SpecField       // Fields in the Spec struct
+	StatusFields []SpecField       // Fields in the Status struct
+	Fingerprint  string            // Hash of the resource's Go type definition
+	OwnerKinds   []string          // Kinds named by ref:"Kind,owner" fields
+
+	// Dependency tracking for generation order
+	ReferencedPackages []string // Import paths of named types used by Spec fields
+	Dependencies       []string // Names of resources whose packages this resource references
 
 	// Multi-version support
 	Versions        []SchemaVersion // Multiple schema versions
 	DefaultVersion  string          // Default schema version
 	APIGroupVersion string          // API group version (e.g., "v2")
+
+	// RequiresAuth puts the resource's routes behind the auth middleware
+	// when GeneratorConfig.AuthEnabled is set
+	RequiresAuth bool
+}
+
+// ScaleField returns the spec field served by the resource's scale
+// subresource: the field tagged scaleField, when the resource is marked
+// "+fabrica:scale=enabled". It returns nil otherwise.
+func (r ResourceMetadata) ScaleField() *SpecField {
+	if r.Tags["scale"] != "enabled" {
+		return nil
+	}
+	for i := range r.SpecFields {
+		if r.SpecFields[i].Scale {
+			field := r.SpecFields[i]
+			return &field
+		}
+	}
+	return nil
+}
+
+// UniqueFields returns the spec fields made unique with SetResourceUnique
+func (r ResourceMetadata) UniqueFields() []SpecField {
+	var fields []SpecField
+	for _, field := range r.SpecFields {
+		if field.Unique {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// NameRouting reports whether the resource's item routes take its
+// metadata.name instead of its UID (/devices/{name}), as for resources
+// marked "+fabrica:routing=name". Names of such resources are unique.
+func (r ResourceMetadata) NameRouting() bool {
+	return r.Tags["routing"] == "name"
+}
+
+// ShortNames returns the short names set with SetResourceShortNames
+// ("+fabrica:shortnames=<name>,..."), or nil
+func (r ResourceMetadata) ShortNames() []string {
+	if r.Tags["shortnames"] == "" {
+		return nil
+	}
+	return strings.Split(r.Tags["shortnames"], ",")
+}
+
+// PathParam returns the name of the path parameter identifying one
+// resource in its item routes: "name" with NameRouting, "uid" otherwise
+func (r ResourceMetadata) PathParam() string {
+	if r.NameRouting() {
+		return "name"
+	}
+	return "uid"
 }
 
 // GeneratorConfig holds configuration values for code generation
@@ -108,9 +185,13 @@ type GeneratorConfig struct {
 	ValidationEnabled bool
 	ValidationMode    string // strict, warn, disabled
 
+	// ReferenceMode is how generated handlers treat ref:"Kind" fields naming
+	// resources that do not exist: enforce (reject with 422), warn, disabled
+	ReferenceMode string
+
 	// Conditional requests configuration
 	ConditionalEnabled bool
-	ETagAlgorithm      string // sha256, md5
+	ETagAlgorithm      string // sha256, md5, xxhash
 
 	// Versioning configuration
 	VersioningEnabled bool
@@ -120,6 +201,27 @@ type GeneratorConfig struct {
 	EventsEnabled bool
 	EventBusType  string // memory, nats, kafka
 
+	// Tracing configuration; generated code imports OpenTelemetry only when enabled
+	TracingEnabled bool
+
+	// Metrics configuration; generates the HTTP request metrics middleware
+	MetricsEnabled bool
+
+	// Reconciliation configuration; with the generation filter, generated
+	// reconcilers skip updates that do not change metadata.generation.
+	// ReconcileEnabled adds the POST /<plural>:resync endpoints.
+	ReconcileEnabled          bool
+	ReconcileGenerationFilter bool
+
+	// Authentication configuration; resources with RequiresAuth get a bearer JWT check
+	AuthEnabled bool
+
+	// FieldNaming is the JSON field naming policy on the wire: camel (the
+	// json tags as written) or snake, which generated servers and clients
+	// convert to with codec.KeyMapper and the OpenAPI spec and CLI examples
+	// use
+	FieldNaming string
+
 	// Storage configuration
 	StorageType string // file, ent
 	DBDriver    string // postgres, mysql, sqlite
@@ -137,6 +239,12 @@ type Generator struct {
 	Verbose     bool             // Enable verbose output showing files being generated
 	Config      *GeneratorConfig // Configuration for generation
 	Version     string           // Fabrica version used for generation
+	Cache       *GenerationCache // Optional; skips files whose inputs are unchanged
+	Force       bool             // Regenerate every file, ignoring the cache
+
+	templateOverrideDir string // Project directory whose templates replace embedded ones
+	templateHash        string // Hash of the template set in use
+	resourcesSorted     bool   // Resources is in dependency order; cleared by RegisterResource
 }
 
 // NewGenerator creates a new code generator
@@ -152,6 +260,7 @@ func NewGenerator(outputDir, packageName, modulePath string) *Generator {
 		Config: &GeneratorConfig{
 			ValidationEnabled:  true,
 			ValidationMode:     "strict",
+			ReferenceMode:      "enforce",
 			ConditionalEnabled: true,
 			ETagAlgorithm:      "sha256",
 			VersioningEnabled:  true,
@@ -160,6 +269,7 @@ func NewGenerator(outputDir, packageName, modulePath string) *Generator {
 			EventBusType:       "memory",
 			StorageType:        "file",
 			DBDriver:           "sqlite",
+			FieldNaming:        "camel",
 		},
 	}
 }
@@ -174,6 +284,16 @@ func (g *Generator) SetDBDriver(driver string) {
 	g.DBDriver = driver
 }
 
+// SetTemplateOverrideDir sets a directory of project-local templates.
+//
+// A file in dir replaces the embedded template at the same relative path, so
+// dir/server/handlers.go.tmpl overrides the embedded server/handlers.go.tmpl.
+// Templates without an override fall back to the embedded version. A missing
+// directory is not an error. Call before LoadTemplates.
+func (g *Generator) SetTemplateOverrideDir(dir string) {
+	g.templateOverrideDir = dir
+}
+
 // templateData creates a standardized data structure for template execution
 // This ensures all templates have access to version, timestamp, and template name
 func (g *Generator) templateData(resource ResourceMetadata, templateName string) map[string]interface{} {
@@ -198,6 +318,10 @@ func (g *Generator) templateData(resource ResourceMetadata, templateName string)
 		"Tags":                  resource.Tags,
 		"PerResourceVersioning": perResVersioning,
 		"SpecFields":            resource.SpecFields,
+		"ScaleField":            resource.ScaleField(),
+		"NameRouting":           resource.NameRouting(),
+		"UniqueFields":          resource.UniqueFields(),
+		"PathParam":             resource.PathParam(),
 		"Versions":              resource.Versions,
 		"DefaultVersion":        resource.DefaultVersion,
 		"APIGroupVersion":       resource.APIGroupVersion,
@@ -234,6 +358,8 @@ func (g *Generator) middlewareData(templateName string) map[string]interface{} {
 		"VersionStrategy":   g.Config.VersionStrategy,
 		"EventBusType":      g.Config.EventBusType,
 		"EventsEnabled":     g.Config.EventsEnabled,
+		"TracingEnabled":    g.Config.TracingEnabled,
+		"ModulePath":        g.ModulePath,
 		"Version":           g.Version,
 		"GeneratedAt":       time.Now().Format(time.RFC3339),
 		"Template":          templateName,
@@ -249,7 +375,7 @@ func (g *Generator) RegisterResource(resourceType interface{}) error {
 
 	// Extract resource metadata
 	name := t.Name()
-	pluralName := strings.ToLower(name) + "s"
+	pluralName := Pluralize(name)
 
 	// Determine spec type name
 	specTypeName := name + "Spec"
@@ -271,8 +397,16 @@ func (g *Generator) RegisterResource(resourceType interface{}) error {
 		packageImport = pkgPath
 	}
 
-	// Extract spec fields using reflection
+	if err := checkScaleField(t); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	// Extract spec fields using reflection. This is deliberately not cached:
+	// each type is registered once per generator process, and the runner is a
+	// new process on every run, --watch included (see BenchmarkRegisterResource)
 	specFields := extractSpecFields(t)
+	statusFields := extractStructFields(t, "Status")
+	referencedPackages := extractSpecPackages(t)
 
 	// Initialize default version metadata
 	defaultVersion := SchemaVersion{
@@ -287,24 +421,38 @@ func (g *Generator) RegisterResource(resourceType interface{}) error {
 		Transforms: []string{},
 	}
 
+	if err := g.checkNamesFree(-1, pluralName, "/"+pluralName); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	for _, res := range g.Resources {
+		if slices.Contains(res.ShortNames(), strings.ToLower(name)) {
+			return fmt.Errorf("%s: command %q is a short name of %s", name, strings.ToLower(name), res.Name)
+		}
+	}
+
 	metadata := ResourceMetadata{
-		Name:            name,
-		PluralName:      pluralName,
-		Package:         packageImport,
-		PackageAlias:    typePrefix,
-		TypeName:        fmt.Sprintf("*%s.%s", typePrefix, name),
-		SpecType:        fmt.Sprintf("%s.%s", typePrefix, specTypeName),
-		StatusType:      fmt.Sprintf("%s.%sStatus", typePrefix, name),
-		URLPath:         fmt.Sprintf("/%s", pluralName),
-		StorageName:     storageName,
-		Tags:            make(map[string]string),
-		SpecFields:      specFields,
-		Versions:        []SchemaVersion{defaultVersion},
-		DefaultVersion:  "v1",
-		APIGroupVersion: "v1", // Default API group version
+		Name:               name,
+		PluralName:         pluralName,
+		Package:            packageImport,
+		PackageAlias:       typePrefix,
+		TypeName:           fmt.Sprintf("*%s.%s", typePrefix, name),
+		SpecType:           fmt.Sprintf("%s.%s", typePrefix, specTypeName),
+		StatusType:         fmt.Sprintf("%s.%sStatus", typePrefix, name),
+		URLPath:            fmt.Sprintf("/%s", pluralName),
+		StorageName:        storageName,
+		Tags:               make(map[string]string),
+		SpecFields:         specFields,
+		StatusFields:       statusFields,
+		Fingerprint:        typeFingerprint(t),
+		OwnerKinds:         extractOwnerKinds(t),
+		ReferencedPackages: referencedPackages,
+		Versions:           []SchemaVersion{defaultVersion},
+		DefaultVersion:     "v1",
+		APIGroupVersion:    "v1", // Default API group version
 	}
 
 	g.Resources = append(g.Resources, metadata)
+	g.resourcesSorted = false
 	return nil
 }
 
@@ -322,18 +470,73 @@ func (g *Generator) SetResourceTag(resourceName, key, value string) {
 	}
 }
 
+// EnableAuthForResource makes a registered resource's routes require
+// authentication. If the resource isn't found, this is a no-op.
+func (g *Generator) EnableAuthForResource(resourceName string) {
+	for i := range g.Resources {
+		if g.Resources[i].Name == resourceName {
+			g.Resources[i].RequiresAuth = true
+			return
+		}
+	}
+}
+
+// SetResourceUnique makes spec fields of a registered resource unique: the
+// generated handlers reject a create or update with 409 Conflict if another
+// resource of the kind has the same value, and file storage indexes the
+// fields to enforce it. Fields are named by JSON path ("spec.serial"). It is
+// called for resources marked "+fabrica:unique=spec.serial,spec.mac".
+//
+// Returns:
+//   - error: If the resource is not registered, or a field is not a
+//     top-level string or number field of its spec
+func (g *Generator) SetResourceUnique(resourceName string, fields ...string) error {
+	index, err := g.resourceIndex(resourceName)
+	if err != nil {
+		return err
+	}
+	res := &g.Resources[index]
+
+	for _, field := range fields {
+		jsonName, ok := strings.CutPrefix(strings.TrimSpace(field), "spec.")
+		found := false
+		for i := range res.SpecFields {
+			if ok && res.SpecFields[i].JSONName == jsonName {
+				if !res.SpecFields[i].Scalar || res.SpecFields[i].Type == "bool" {
+					return fmt.Errorf("%s: unique field %s must be a string or number", resourceName, field)
+				}
+				res.SpecFields[i].Unique = true
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: unique field %q is not a field of the spec (use spec.<jsonName>)", resourceName, field)
+		}
+	}
+	return nil
+}
+
 // extractSpecFields uses reflection to extract field information from a Spec struct
 func extractSpecFields(resourceType reflect.Type) []SpecField {
+	return extractStructFields(resourceType, "Spec")
+}
+
+// extractStructFields extracts field information from the named struct field
+// of a resource (e.g., "Spec" or "Status")
+func extractStructFields(resourceType reflect.Type, fieldName string) []SpecField {
 	var fields []SpecField
 
-	// Find the Spec field in the resource
+	// Find the struct field in the resource
 	for i := 0; i < resourceType.NumField(); i++ {
 		field := resourceType.Field(i)
-		if field.Name == "Spec" {
+		if field.Name == fieldName {
 			specType := field.Type
 			if specType.Kind() == reflect.Ptr {
 				specType = specType.Elem()
 			}
+			if specType.Kind() != reflect.Struct {
+				break
+			}
 
 			// Iterate through spec fields
 			for j := 0; j < specType.NumField(); j++ {
@@ -362,12 +565,27 @@ func extractSpecFields(resourceType reflect.Type) []SpecField {
 				// Generate example value based on type
 				exampleValue := generateExampleValue(specField.Type, specField.Name)
 
+				protoType, protoConversion := protoFieldType(specField.Type, resourceType.PkgPath())
+				if strings.Split(jsonTag, ",")[0] == "-" {
+					// Not serialized, so not part of the proto message either
+					protoType, protoConversion = "", ""
+				}
+				protoName := protoFieldName(jsonName)
+				refKind, _, _ := strings.Cut(specField.Tag.Get("ref"), ",")
+
 				fields = append(fields, SpecField{
-					Name:         specField.Name,
-					JSONName:     jsonName,
-					Type:         specField.Type.String(),
-					Required:     required,
-					ExampleValue: exampleValue,
+					Name:            specField.Name,
+					JSONName:        jsonName,
+					Type:            specField.Type.String(),
+					Required:        required,
+					ExampleValue:    exampleValue,
+					Scalar:          isScalarKind(specField.Type.Kind()) && jsonTag != "-",
+					Scale:           fieldName == "Spec" && hasTag(specField, "scaleField"),
+					RefKind:         refKind,
+					ProtoName:       protoName,
+					ProtoGoName:     protoGoName(protoName),
+					ProtoType:       protoType,
+					ProtoConversion: protoConversion,
 				})
 			}
 			break
@@ -377,6 +595,229 @@ func extractSpecFields(resourceType reflect.Type) []SpecField {
 	return fields
 }
 
+// hasTag reports whether a struct field has the named tag, with any value
+func hasTag(field reflect.StructField, key string) bool {
+	_, ok := field.Tag.Lookup(key)
+	return ok
+}
+
+// checkScaleField checks that at most one Spec field of a resource is tagged
+// scaleField, and that it is an integer
+func checkScaleField(resourceType reflect.Type) error {
+	specField, ok := resourceType.FieldByName("Spec")
+	if !ok {
+		return nil
+	}
+	specType := specField.Type
+	if specType.Kind() == reflect.Ptr {
+		specType = specType.Elem()
+	}
+	if specType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	found := ""
+	for i := 0; i < specType.NumField(); i++ {
+		field := specType.Field(i)
+		if !field.IsExported() || !hasTag(field, "scaleField") {
+			continue
+		}
+		if !isIntegerKind(field.Type.Kind()) {
+			return fmt.Errorf("scaleField %s must be an integer, not %s", field.Name, field.Type)
+		}
+		if found != "" {
+			return fmt.Errorf("only one spec field may be tagged scaleField, found %s and %s", found, field.Name)
+		}
+		found = field.Name
+	}
+	return nil
+}
+
+// isIntegerKind reports whether kind is a signed or unsigned integer
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// isScalarKind reports whether values of kind print as a single short value
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// extractSpecPackages returns the import paths of named types reachable from
+// the resource's Spec field, excluding the resource's own package. Used to
+// order resources by dependency.
+func extractSpecPackages(resourceType reflect.Type) []string {
+	field, ok := resourceType.FieldByName("Spec")
+	if !ok {
+		return nil
+	}
+
+	packages := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+
+		if t.PkgPath() != "" && t.PkgPath() != resourceType.PkgPath() {
+			packages[t.PkgPath()] = true
+		}
+
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			walk(t.Elem())
+		case reflect.Map:
+			walk(t.Key())
+			walk(t.Elem())
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				walk(t.Field(i).Type)
+			}
+		}
+	}
+	walk(field.Type)
+
+	result := make([]string, 0, len(packages))
+	for pkg := range packages {
+		result = append(result, pkg)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// extractOwnerKinds returns the kinds named by ref:"Kind,owner" tags in the
+// Spec and Status of a resource, sorted
+func extractOwnerKinds(resourceType reflect.Type) []string {
+	kinds := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			walk(t.Elem())
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				if kind, options, _ := strings.Cut(field.Tag.Get("ref"), ","); kind != "" && options == "owner" {
+					kinds[kind] = true
+				}
+				walk(field.Type)
+			}
+		}
+	}
+	for _, name := range []string{"Spec", "Status"} {
+		if field, ok := resourceType.FieldByName(name); ok {
+			walk(field.Type)
+		}
+	}
+
+	result := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		result = append(result, kind)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SortResourcesByDependency orders Resources so that every resource comes
+// after the resources whose types its Spec references.
+//
+// References are detected from the reflection data gathered by
+// RegisterResource: resource A depends on resource B when a named type in A's
+// Spec (at any depth) lives in B's package. Resources without a dependency
+// between them keep their registration order, so output is deterministic.
+// Dependencies is filled in on each resource.
+//
+// The order is computed once: later calls return immediately until
+// RegisterResource adds another resource, so GenerateAll can sort
+// unconditionally after a caller already has.
+//
+// Returns an error if the references form a cycle.
+func (g *Generator) SortResourcesByDependency() error {
+	if g.resourcesSorted {
+		return nil
+	}
+
+	byPackage := make(map[string][]int)
+	for i, res := range g.Resources {
+		byPackage[res.Package] = append(byPackage[res.Package], i)
+	}
+
+	// Build edges: dependents[b] lists resources that must come after b
+	dependents := make([][]int, len(g.Resources))
+	inDegree := make([]int, len(g.Resources))
+	for i := range g.Resources {
+		seen := make(map[int]bool)
+		g.Resources[i].Dependencies = nil
+		for _, pkg := range g.Resources[i].ReferencedPackages {
+			for _, dep := range byPackage[pkg] {
+				if dep == i || seen[dep] {
+					continue
+				}
+				seen[dep] = true
+				dependents[dep] = append(dependents[dep], i)
+				inDegree[i]++
+				g.Resources[i].Dependencies = append(g.Resources[i].Dependencies, g.Resources[dep].Name)
+			}
+		}
+	}
+
+	// Kahn's algorithm, always taking the earliest-registered ready resource
+	done := make([]bool, len(g.Resources))
+	order := make([]int, 0, len(g.Resources))
+	for len(order) < len(g.Resources) {
+		next := -1
+		for i := range g.Resources {
+			if !done[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var cycle []string
+			for i, res := range g.Resources {
+				if !done[i] {
+					cycle = append(cycle, res.Name)
+				}
+			}
+			return fmt.Errorf("circular resource dependency between: %s", strings.Join(cycle, ", "))
+		}
+
+		done[next] = true
+		order = append(order, next)
+		for _, dependent := range dependents[next] {
+			inDegree[dependent]--
+		}
+	}
+
+	sorted := make([]ResourceMetadata, 0, len(order))
+	for _, i := range order {
+		sorted = append(sorted, g.Resources[i])
+	}
+	g.Resources = sorted
+	g.resourcesSorted = true
+	return nil
+}
+
 // generateExampleValue creates an example value based on the field type and name
 func generateExampleValue(t reflect.Type, fieldName string) string {
 	// Handle common types
@@ -469,10 +910,18 @@ func (g *Generator) GenerateAll() error {
 		return err
 	}
 
+	// Emit referenced resources before the resources that use their types
+	if err := g.SortResourcesByDependency(); err != nil {
+		return err
+	}
+
 	// Generate based on package type
 	switch g.PackageName {
 	case "main":
 		// Server code - handlers, routes, models, storage, and openapi
+		if err := g.checkStorageSupport(); err != nil {
+			return err
+		}
 
 		// Generate Ent schemas first if using Ent storage
 		if g.StorageType == "ent" {
@@ -528,8 +977,26 @@ func (g *Generator) GenerateAll() error {
 	return nil
 }
 
+// checkStorageSupport rejects resource markers the storage type cannot
+// honor. Ent storage keeps no metadata.expiresAt and has no reaper, so
+// +fabrica:ttl=enabled resources would never expire.
+func (g *Generator) checkStorageSupport() error {
+	if g.StorageType != "ent" {
+		return nil
+	}
+	for _, res := range g.Resources {
+		if res.Tags["ttl"] == "enabled" {
+			return fmt.Errorf("resource %s is marked +fabrica:ttl=enabled, which requires file storage; Ent storage does not expire resources", res.Name)
+		}
+	}
+	return nil
+}
+
 // GenerateStorage generates storage operations for server
 func (g *Generator) GenerateStorage() error {
+	if err := g.checkStorageSupport(); err != nil {
+		return err
+	}
 	fmt.Printf("📁 Generating storage layer (%s)...\n", g.StorageType)
 	var buf bytes.Buffer
 
@@ -541,6 +1008,20 @@ func (g *Generator) GenerateStorage() error {
 		templatePath = "storage/ent.go.tmpl"
 	}
 
+	// Write storage to internal/storage directory instead of output directory
+	storageDir := filepath.Join("internal", "storage")
+	if g.Config.TracingEnabled {
+		if err := g.generateStorageTracing(storageDir); err != nil {
+			return err
+		}
+	}
+
+	filename := filepath.Join(storageDir, "storage_generated.go")
+	inputs := g.inputsHash(g.Resources...)
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
+
 	data := g.globalTemplateData(templatePath)
 
 	if err := g.Templates[templateName].Execute(&buf, data); err != nil {
@@ -552,16 +1033,46 @@ func (g *Generator) GenerateStorage() error {
 		return fmt.Errorf("failed to format generated storage code: %w", err)
 	}
 
-	// Write storage to internal/storage directory instead of output directory
-	storageDir := filepath.Join("internal", "storage")
 	if err := os.MkdirAll(storageDir, 0755); err != nil {
 		return fmt.Errorf("failed to create storage directory: %w", err)
 	}
 
-	filename := filepath.Join(storageDir, "storage_generated.go")
 	if err := os.WriteFile(filename, formatted, 0644); err != nil {
 		return fmt.Errorf("failed to write storage file: %w", err)
 	}
+	g.recordGenerated(filename, inputs)
+
+	fmt.Printf("  ✓ Generated %s\n", filename)
+
+	return nil
+}
+
+// generateStorageTracing generates the span helpers used by the storage
+// functions when tracing is enabled.
+func (g *Generator) generateStorageTracing(storageDir string) error {
+	filename := filepath.Join(storageDir, "tracing_generated.go")
+	inputs := g.inputsHash()
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := g.Templates["storageTracing"].Execute(&buf, g.globalTemplateData("storage/tracing.go.tmpl")); err != nil {
+		return fmt.Errorf("failed to execute storage tracing template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated storage tracing code: %w", err)
+	}
+
+	if err := os.MkdirAll(storageDir, 0755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	if err := os.WriteFile(filename, formatted, 0644); err != nil {
+		return fmt.Errorf("failed to write storage tracing file: %w", err)
+	}
+	g.recordGenerated(filename, inputs)
 
 	fmt.Printf("  ✓ Generated %s\n", filename)
 
@@ -572,6 +1083,11 @@ func (g *Generator) GenerateStorage() error {
 func (g *Generator) GenerateClientModels() error {
 	fmt.Printf("📊 Generating client models...\n")
 	var buf bytes.Buffer
+	filename := filepath.Join(g.OutputDir, "models_generated.go")
+	inputs := g.inputsHash(g.Resources...)
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
 	data := g.globalTemplateData("client/models.go.tmpl")
 
 	if err := g.Templates["clientModels"].Execute(&buf, data); err != nil {
@@ -583,10 +1099,10 @@ func (g *Generator) GenerateClientModels() error {
 		return fmt.Errorf("failed to format generated client models code: %w", err)
 	}
 
-	filename := filepath.Join(g.OutputDir, "models_generated.go")
 	if err := os.WriteFile(filename, formatted, 0644); err != nil {
 		return fmt.Errorf("failed to write client models file: %w", err)
 	}
+	g.recordGenerated(filename, inputs)
 
 	// Always show client generation output (not just in verbose mode)
 	fmt.Printf("  ✓ Generated %s\n", filename)
@@ -597,22 +1113,26 @@ func (g *Generator) GenerateClientModels() error {
 // GenerateReconcilers generates reconciler code for all resources
 func (g *Generator) GenerateReconcilers() error {
 	for _, resource := range g.Resources {
-		// Generate the boilerplate file (always regenerated)
-		var buf bytes.Buffer
-		data := g.templateData(resource, "reconciliation/reconciler.go.tmpl")
+		// Generate the boilerplate file (regenerated when its inputs change)
+		filename := filepath.Join(g.OutputDir, fmt.Sprintf("%s_reconciler_generated.go", strings.ToLower(resource.Name)))
+		inputs := g.inputsHash(resource)
+		if !g.upToDate(filename, inputs) {
+			var buf bytes.Buffer
+			data := g.templateData(resource, "reconciliation/reconciler.go.tmpl")
 
-		if err := g.Templates["reconciler"].Execute(&buf, data); err != nil {
-			return fmt.Errorf("failed to execute reconciler template for %s: %w", resource.Name, err)
-		}
+			if err := g.Templates["reconciler"].Execute(&buf, data); err != nil {
+				return fmt.Errorf("failed to execute reconciler template for %s: %w", resource.Name, err)
+			}
 
-		formatted, err := format.Source(buf.Bytes())
-		if err != nil {
-			return fmt.Errorf("failed to format generated reconciler code for %s: %w", resource.Name, err)
-		}
+			formatted, err := format.Source(buf.Bytes())
+			if err != nil {
+				return fmt.Errorf("failed to format generated reconciler code for %s: %w", resource.Name, err)
+			}
 
-		filename := filepath.Join(g.OutputDir, fmt.Sprintf("%s_reconciler_generated.go", strings.ToLower(resource.Name)))
-		if err := os.WriteFile(filename, formatted, 0644); err != nil {
-			return fmt.Errorf("failed to write reconciler file for %s: %w", resource.Name, err)
+			if err := os.WriteFile(filename, formatted, 0644); err != nil {
+				return fmt.Errorf("failed to write reconciler file for %s: %w", resource.Name, err)
+			}
+			g.recordGenerated(filename, inputs)
 		}
 
 		// Generate the user-editable stub file (only if it doesn't exist)
@@ -699,10 +1219,11 @@ func (g *Generator) LoadTemplates() error {
 		"clientCmd":    "client/cmd.go.tmpl",
 
 		// Storage templates
-		"storage":    "storage/file.go.tmpl",
-		"storageEnt": "storage/ent.go.tmpl",
-		"entAdapter": "storage/adapter.go.tmpl",
-		"generate":   "storage/generate.go.tmpl",
+		"storage":        "storage/file.go.tmpl",
+		"storageEnt":     "storage/ent.go.tmpl",
+		"entAdapter":     "storage/adapter.go.tmpl",
+		"generate":       "storage/generate.go.tmpl",
+		"storageTracing": "storage/tracing.go.tmpl",
 
 		// Ent schema templates
 		"entSchemaResource":   "ent/schema/resource.go.tmpl",
@@ -713,6 +1234,11 @@ func (g *Generator) LoadTemplates() error {
 		"middlewareValidation":  "middleware/validation.go.tmpl",
 		"middlewareConditional": "middleware/conditional.go.tmpl",
 		"middlewareVersioning":  "middleware/versioning.go.tmpl",
+		"middlewareLogging":     "middleware/logging.go.tmpl",
+		"middlewareAuth":        "middleware/auth.go.tmpl",
+		"middlewareAuthz":       "middleware/authz.go.tmpl",
+		"middlewareTracing":     "middleware/tracing.go.tmpl",
+		"middlewareMetrics":     "middleware/metrics.go.tmpl",
 		"eventBus":              "middleware/event-bus.go.tmpl",
 
 		// Reconciliation templates
@@ -720,16 +1246,48 @@ func (g *Generator) LoadTemplates() error {
 		"reconcilerStub":         "reconciliation/stub.go.tmpl",
 		"reconcilerRegistration": "reconciliation/registration.go.tmpl",
 		"eventHandlers":          "reconciliation/event-handlers.go.tmpl",
+
+		// Authorization templates
+		"casbinModel":  "authorization/model.conf.tmpl",
+		"casbinPolicy": "authorization/policy.csv.tmpl",
+
+		// gRPC templates
+		"grpcProto":        "grpc/resource.proto.tmpl",
+		"grpcServer":       "grpc/server.go.tmpl",
+		"grpcRegistration": "grpc/registration.go.tmpl",
+		"grpcGenerate":     "grpc/generate.go.tmpl",
+
+		// Benchmark templates
+		"benchmarks":   "bench/bench_test.go.tmpl",
+		"benchTargets": "bench/targets.txt.tmpl",
+	}
+
+	templateHash, err := hashTemplates()
+	if err != nil {
+		return err
 	}
+	overrides := make([]string, 0)
 
 	g.Templates = make(map[string]*template.Template)
 	for name, filename := range templateFiles {
 		templatePath := filepath.Join("templates", filename)
 
-		// Read template content from embedded filesystem
-		content, err := embeddedTemplates.ReadFile(templatePath)
+		// Prefer a project-local override, then the embedded filesystem
+		content, overridePath, err := g.readOverrideTemplate(filename)
 		if err != nil {
-			return fmt.Errorf("failed to read embedded template %s: %w", templatePath, err)
+			return err
+		}
+		if overridePath != "" {
+			templatePath = overridePath
+			overrides = append(overrides, filename+"\x00"+string(content))
+			if g.Verbose {
+				fmt.Printf("  Using template override %s\n", overridePath)
+			}
+		} else {
+			content, err = embeddedTemplates.ReadFile(templatePath)
+			if err != nil {
+				return fmt.Errorf("failed to read embedded template %s: %w", templatePath, err)
+			}
 		}
 
 		// Parse template with functions
@@ -740,13 +1298,45 @@ func (g *Generator) LoadTemplates() error {
 		g.Templates[name] = tmpl
 	}
 
+	// Overrides are part of the inputs of every generated file
+	g.templateHash = withTemplateOverrides(templateHash, overrides)
+
 	return nil
 }
 
+// readOverrideTemplate reads the override for an embedded template, if the
+// override directory has one. It returns the path read, or "" if there is
+// no override.
+func (g *Generator) readOverrideTemplate(filename string) ([]byte, string, error) {
+	if g.templateOverrideDir == "" {
+		return nil, "", nil
+	}
+
+	overridePath := filepath.Join(g.templateOverrideDir, filepath.FromSlash(filename))
+	content, err := os.ReadFile(overridePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to read template override %s: %w", overridePath, err)
+	}
+	return content, overridePath, nil
+}
+
 // GenerateHandlers generates REST API handlers for all resources
 func (g *Generator) GenerateHandlers() error {
 	fmt.Printf("🛠️  Generating handlers...\n")
 	for _, resource := range g.Resources {
+		if resource.Tags["scale"] == "enabled" && resource.ScaleField() == nil {
+			return fmt.Errorf("%s is marked +fabrica:scale=enabled but no spec field is tagged scaleField", resource.Name)
+		}
+
+		filename := filepath.Join(g.OutputDir, fmt.Sprintf("%s_handlers_generated.go", strings.ToLower(resource.Name)))
+		inputs := g.inputsHash(resource)
+		if g.upToDate(filename, inputs) {
+			continue
+		}
+
 		var buf bytes.Buffer
 		data := g.templateData(resource, "server/handlers.go.tmpl")
 
@@ -759,10 +1349,10 @@ func (g *Generator) GenerateHandlers() error {
 			return fmt.Errorf("failed to format generated code for %s: %w", resource.Name, err)
 		}
 
-		filename := filepath.Join(g.OutputDir, fmt.Sprintf("%s_handlers_generated.go", strings.ToLower(resource.Name)))
 		if err := os.WriteFile(filename, formatted, 0644); err != nil {
 			return fmt.Errorf("failed to write handlers file for %s: %w", resource.Name, err)
 		}
+		g.recordGenerated(filename, inputs)
 
 		fmt.Printf("  ✓ Generated %s\n", filename)
 	}
@@ -780,6 +1370,12 @@ func (g *Generator) GenerateMiddleware() error {
 		return fmt.Errorf("failed to create middleware directory: %w", err)
 	}
 
+	// Request logging is always generated; the server's main.go uses it
+	loggingData := g.middlewareData("middleware/logging.go.tmpl")
+	if err := g.generateMiddlewareFile("middlewareLogging", "logging_middleware_generated.go", middlewareDir, loggingData); err != nil {
+		return err
+	}
+
 	// Generate validation middleware if enabled
 	if g.Config.ValidationEnabled {
 		data := g.middlewareData("middleware/validation.go.tmpl")
@@ -804,6 +1400,37 @@ func (g *Generator) GenerateMiddleware() error {
 		}
 	}
 
+	// Generate auth and authorization middleware if enabled
+	if g.Config.AuthEnabled {
+		data := g.middlewareData("middleware/auth.go.tmpl")
+		if err := g.generateMiddlewareFile("middlewareAuth", "auth_middleware_generated.go", middlewareDir, data); err != nil {
+			return err
+		}
+		data = g.middlewareData("middleware/authz.go.tmpl")
+		if err := g.generateMiddlewareFile("middlewareAuthz", "authz_middleware_generated.go", middlewareDir, data); err != nil {
+			return err
+		}
+		if err := g.GenerateCasbinPolicies(); err != nil {
+			return err
+		}
+	}
+
+	// Generate tracing middleware if enabled
+	if g.Config.TracingEnabled {
+		data := g.middlewareData("middleware/tracing.go.tmpl")
+		if err := g.generateMiddlewareFile("middlewareTracing", "tracing_middleware_generated.go", middlewareDir, data); err != nil {
+			return err
+		}
+	}
+
+	// Generate HTTP metrics middleware if enabled
+	if g.Config.MetricsEnabled {
+		data := g.middlewareData("middleware/metrics.go.tmpl")
+		if err := g.generateMiddlewareFile("middlewareMetrics", "metrics_middleware_generated.go", middlewareDir, data); err != nil {
+			return err
+		}
+	}
+
 	// Generate event bus if enabled
 	if g.Config.EventsEnabled {
 		data := g.middlewareData("middleware/event-bus.go.tmpl")
@@ -815,6 +1442,91 @@ func (g *Generator) GenerateMiddleware() error {
 	return nil
 }
 
+// GenerateCasbinPolicies writes the Casbin model and a default policy
+// (policies/model.conf and policies/policy.csv) for AuthorizationMiddleware.
+// They belong to the project once written, so existing files are kept.
+func (g *Generator) GenerateCasbinPolicies() error {
+	policyDir := "policies"
+	if err := os.MkdirAll(policyDir, 0755); err != nil {
+		return fmt.Errorf("failed to create policies directory: %w", err)
+	}
+
+	for _, file := range []struct{ templateName, filename string }{
+		{"casbinModel", "model.conf"},
+		{"casbinPolicy", "policy.csv"},
+	} {
+		path := filepath.Join(policyDir, file.filename)
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := g.Templates[file.templateName].Execute(&buf, g.globalTemplateData("authorization/"+file.filename+".tmpl")); err != nil {
+			return fmt.Errorf("failed to execute %s template: %w", file.templateName, err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("  ✓ Generated %s\n", path)
+	}
+
+	return nil
+}
+
+// GenerateBenchmarks generates storage benchmarks for every resource in
+// OutputDir (bench_generated_test.go), plus HTTP load test targets in the
+// vegeta format (targets.txt) and the request bodies they send
+// (payloads/<plural>.json). Payloads are built from the example spec of each
+// resource.
+func (g *Generator) GenerateBenchmarks() error {
+	fmt.Printf("⏱️  Generating benchmarks...\n")
+	payloadDir := filepath.Join(g.OutputDir, "payloads")
+	if err := os.MkdirAll(payloadDir, 0755); err != nil {
+		return fmt.Errorf("failed to create payloads directory: %w", err)
+	}
+
+	inputs := g.inputsHash(g.Resources...)
+	for _, file := range []struct{ templateName, filename, source string }{
+		{"benchmarks", "bench_generated_test.go", "bench/bench_test.go.tmpl"},
+		{"benchTargets", "targets.txt", "bench/targets.txt.tmpl"},
+	} {
+		path := filepath.Join(g.OutputDir, file.filename)
+		if g.upToDate(path, inputs) {
+			continue
+		}
+		if err := g.executeTemplate(file.templateName, path, g.globalTemplateData(file.source)); err != nil {
+			return err
+		}
+		g.recordGenerated(path, inputs)
+		fmt.Printf("  ✓ Generated %s\n", path)
+	}
+
+	for _, res := range g.Resources {
+		path := filepath.Join(payloadDir, res.PluralName+".json")
+		resInputs := g.inputsHash(res)
+		if g.upToDate(path, resInputs) {
+			continue
+		}
+
+		var spec map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(exampleSpecJSON(res.SpecFields)), &spec); err != nil {
+			return fmt.Errorf("failed to build %s payload: %w", res.Name, err)
+		}
+		spec["name"] = json.RawMessage(fmt.Sprintf("%q", "bench-"+strings.ToLower(res.Name)))
+		payload, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to build %s payload: %w", res.Name, err)
+		}
+		if err := os.WriteFile(path, append(payload, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		g.recordGenerated(path, resInputs)
+		fmt.Printf("  ✓ Generated %s\n", path)
+	}
+
+	return nil
+}
+
 // generateMiddlewareFile generates a single middleware file from a template
 func (g *Generator) generateMiddlewareFile(templateName, filename, outputDir string, data interface{}) error {
 	var buf bytes.Buffer
@@ -845,6 +1557,11 @@ func (g *Generator) GenerateClient() error {
 	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
+	filename := filepath.Join(g.OutputDir, "client_generated.go")
+	inputs := g.inputsHash(g.Resources...)
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
 	data := g.globalTemplateData("client/client.go.tmpl")
 
 	if err := g.Templates["client"].Execute(&buf, data); err != nil {
@@ -856,10 +1573,10 @@ func (g *Generator) GenerateClient() error {
 		return fmt.Errorf("failed to format generated client code: %w", err)
 	}
 
-	filename := filepath.Join(g.OutputDir, "client_generated.go")
 	if err := os.WriteFile(filename, formatted, 0644); err != nil {
 		return fmt.Errorf("failed to write client file: %w", err)
 	}
+	g.recordGenerated(filename, inputs)
 
 	// Always show client generation output (not just in verbose mode)
 	fmt.Printf("  ✓ Generated %s\n", filename)
@@ -872,6 +1589,11 @@ func (g *Generator) GenerateModels() error {
 	fmt.Printf("📊 Generating models...\n")
 	var buf bytes.Buffer
 
+	filename := filepath.Join(g.OutputDir, "models_generated.go")
+	inputs := g.inputsHash(g.Resources...)
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
 	data := g.globalTemplateData("server/models.go.tmpl")
 
 	if err := g.Templates["models"].Execute(&buf, data); err != nil {
@@ -883,10 +1605,10 @@ func (g *Generator) GenerateModels() error {
 		return fmt.Errorf("failed to format generated models code: %w", err)
 	}
 
-	filename := filepath.Join(g.OutputDir, "models_generated.go")
 	if err := os.WriteFile(filename, formatted, 0644); err != nil {
 		return fmt.Errorf("failed to write models file: %w", err)
 	}
+	g.recordGenerated(filename, inputs)
 
 	fmt.Printf("  ✓ Generated %s\n", filename)
 
@@ -897,6 +1619,11 @@ func (g *Generator) GenerateModels() error {
 func (g *Generator) GenerateRoutes() error {
 	fmt.Printf("🛣️  Generating routes...\n")
 	var buf bytes.Buffer
+	filename := filepath.Join(g.OutputDir, "routes_generated.go")
+	inputs := g.inputsHash(g.Resources...)
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
 	data := g.globalTemplateData("server/routes.go.tmpl")
 
 	if err := g.Templates["routes"].Execute(&buf, data); err != nil {
@@ -908,10 +1635,10 @@ func (g *Generator) GenerateRoutes() error {
 		return fmt.Errorf("failed to format generated routes code: %w", err)
 	}
 
-	filename := filepath.Join(g.OutputDir, "routes_generated.go")
 	if err := os.WriteFile(filename, formatted, 0644); err != nil {
 		return fmt.Errorf("failed to write routes file: %w", err)
 	}
+	g.recordGenerated(filename, inputs)
 
 	fmt.Printf("  ✓ Generated %s\n", filename)
 
@@ -955,6 +1682,11 @@ func (g *Generator) GenerateClientCmd() error {
 func (g *Generator) GenerateOpenAPI() error {
 	fmt.Printf("📋 Generating OpenAPI specification...\n")
 	var buf bytes.Buffer
+	filename := filepath.Join(g.OutputDir, "openapi_generated.go")
+	inputs := g.inputsHash(g.Resources...)
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
 	data := g.globalTemplateData("server/openapi.go.tmpl")
 
 	if err := g.Templates["openapi"].Execute(&buf, data); err != nil {
@@ -966,10 +1698,10 @@ func (g *Generator) GenerateOpenAPI() error {
 		return fmt.Errorf("failed to format generated openapi code: %w", err)
 	}
 
-	filename := filepath.Join(g.OutputDir, "openapi_generated.go")
 	if err := os.WriteFile(filename, formatted, 0644); err != nil {
 		return fmt.Errorf("failed to write openapi file: %w", err)
 	}
+	g.recordGenerated(filename, inputs)
 
 	fmt.Printf("  ✓ Generated %s\n", filename)
 
@@ -1104,6 +1836,35 @@ func formatJSONValue(goType, value string) string {
 	}
 }
 
+// exampleSpecJSON returns a JSON object of the example values of fields.
+// Example values that are not JSON themselves (plain strings) are quoted.
+// Reference fields are left out, since their example would name a resource
+// that does not exist.
+func exampleSpecJSON(fields []SpecField) string {
+	spec := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if f.RefKind != "" || strings.HasSuffix(f.Type, "time.Time") {
+			continue
+		}
+		value := json.RawMessage(f.ExampleValue)
+		if !json.Valid(value) {
+			value, _ = json.Marshal(f.ExampleValue)
+		}
+		spec[f.JSONName] = value
+	}
+	data, _ := json.Marshal(spec)
+	return string(data)
+}
+
+// wireName returns the JSON field name sent on the wire for name under the
+// naming policy: snake_case for "snake", name itself otherwise
+func wireName(naming, name string) string {
+	if naming == codec.SnakeCase {
+		return codec.SnakeCaseName(name)
+	}
+	return name
+}
+
 // extractProjectName extracts a project name from the module path
 func (g *Generator) extractProjectName() string {
 	// Extract the last component of the module path
@@ -1134,13 +1895,24 @@ var templateFuncs = template.FuncMap{
 		}
 		return s[len(s)-1]
 	},
+	"protoGoType": func(protoType string) string {
+		return protoGoTypes[protoType]
+	},
+	"add": func(a, b int) int {
+		return a + b
+	},
 	"camelCase": func(s string) string {
 		if len(s) == 0 {
 			return s
 		}
 		return strings.ToLower(s[:1]) + s[1:]
 	},
-	"specToJSON": func(fields []SpecField) string {
+	"exampleSpecJSON": exampleSpecJSON,
+	// wireName returns a JSON field name as sent under the naming policy
+	// (GeneratorConfig.FieldNaming)
+	"wireName": wireName,
+	// specToJSON takes an optional naming policy for the field names
+	"specToJSON": func(fields []SpecField, naming ...string) string {
 		if len(fields) == 0 {
 			return `{"name": "example"}`
 		}
@@ -1149,10 +1921,27 @@ var templateFuncs = template.FuncMap{
 		for _, f := range fields {
 			// Format the value based on type
 			value := formatJSONValue(f.Type, f.ExampleValue)
-			parts = append(parts, fmt.Sprintf(`"%s": %s`, f.JSONName, value))
+			name := f.JSONName
+			if len(naming) > 0 {
+				name = wireName(naming[0], name)
+			}
+			parts = append(parts, fmt.Sprintf(`"%s": %s`, name, value))
 		}
 		return "{" + strings.Join(parts, ", ") + "}"
 	},
+	// tableColumns returns up to n scalar fields, used as CLI table columns
+	"tableColumns": func(fields []SpecField, n int) []SpecField {
+		var columns []SpecField
+		for _, f := range fields {
+			if len(columns) == n {
+				break
+			}
+			if f.Scalar {
+				columns = append(columns, f)
+			}
+		}
+		return columns
+	},
 	"specToJSONPretty": func(fields []SpecField) string {
 		if len(fields) == 0 {
 			return `{
@@ -0,0 +1,1029 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/node"
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/rack"
+)
+
+func resourceNames(resources []ResourceMetadata) []string {
+	names := make([]string, 0, len(resources))
+	for _, res := range resources {
+		names = append(names, res.Name)
+	}
+	return names
+}
+
+func TestSortResourcesByDependency(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+
+	// Register the dependent resource first
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatalf("RegisterResource(Node) failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource(Rack) failed: %v", err)
+	}
+
+	nodeRes, _ := gen.GetResourceByName("Node")
+	rackPkg := reflect.TypeOf(rack.Rack{}).PkgPath()
+	found := false
+	for _, pkg := range nodeRes.ReferencedPackages {
+		if pkg == rackPkg {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Node ReferencedPackages = %v, want to include %s", nodeRes.ReferencedPackages, rackPkg)
+	}
+
+	if err := gen.SortResourcesByDependency(); err != nil {
+		t.Fatalf("SortResourcesByDependency failed: %v", err)
+	}
+
+	if got := resourceNames(gen.Resources); !reflect.DeepEqual(got, []string{"Rack", "Node"}) {
+		t.Errorf("Sorted order = %v, want [Rack Node]", got)
+	}
+	nodeRes, _ = gen.GetResourceByName("Node")
+	if !reflect.DeepEqual(nodeRes.Dependencies, []string{"Rack"}) {
+		t.Errorf("Node.Dependencies = %v, want [Rack]", nodeRes.Dependencies)
+	}
+}
+
+func TestSortResourcesByDependency_KeepsRegistrationOrder(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	gen.Resources = []ResourceMetadata{
+		{Name: "C", Package: "example.com/app/c"},
+		{Name: "A", Package: "example.com/app/a"},
+		{Name: "B", Package: "example.com/app/b", ReferencedPackages: []string{"example.com/app/c", "time"}},
+	}
+
+	if err := gen.SortResourcesByDependency(); err != nil {
+		t.Fatalf("SortResourcesByDependency failed: %v", err)
+	}
+	if got := resourceNames(gen.Resources); !reflect.DeepEqual(got, []string{"C", "A", "B"}) {
+		t.Errorf("Sorted order = %v, want [C A B]", got)
+	}
+}
+
+func TestSortResourcesByDependency_ComputedOnce(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.SortResourcesByDependency(); err != nil {
+		t.Fatalf("SortResourcesByDependency failed: %v", err)
+	}
+
+	// A second call reuses the order instead of recomputing it
+	gen.Resources[0].Dependencies = []string{"sentinel"}
+	if err := gen.SortResourcesByDependency(); err != nil {
+		t.Fatalf("SortResourcesByDependency failed: %v", err)
+	}
+	if !reflect.DeepEqual(gen.Resources[0].Dependencies, []string{"sentinel"}) {
+		t.Errorf("Dependencies recomputed: %v", gen.Resources[0].Dependencies)
+	}
+
+	// Registering a resource invalidates it
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.SortResourcesByDependency(); err != nil {
+		t.Fatalf("SortResourcesByDependency failed: %v", err)
+	}
+	if got := resourceNames(gen.Resources); !reflect.DeepEqual(got, []string{"Rack", "Node"}) {
+		t.Errorf("Sorted order = %v, want [Rack Node]", got)
+	}
+}
+
+func TestSortResourcesByDependency_Cycle(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	gen.Resources = []ResourceMetadata{
+		{Name: "A", Package: "example.com/app/a", ReferencedPackages: []string{"example.com/app/b"}},
+		{Name: "B", Package: "example.com/app/b", ReferencedPackages: []string{"example.com/app/a"}},
+	}
+
+	if err := gen.SortResourcesByDependency(); err == nil {
+		t.Error("Expected error for circular dependency")
+	}
+}
+
+func TestSetTemplateOverrideDir(t *testing.T) {
+	overrideDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(overrideDir, "server"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	custom := "package {{.PackageName}}\n\n// Custom routes for {{len .Resources}} resource(s)\n"
+	if err := os.WriteFile(filepath.Join(overrideDir, "server", "routes.go.tmpl"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	embedded := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := embedded.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+
+	outputDir := t.TempDir()
+	gen := NewGenerator(outputDir, "main", "example.com/app")
+	gen.SetTemplateOverrideDir(overrideDir)
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+	if err := gen.GenerateRoutes(); err != nil {
+		t.Fatalf("GenerateRoutes failed: %v", err)
+	}
+
+	routes, err := os.ReadFile(filepath.Join(outputDir, "routes_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(routes), "Custom routes for 1 resource(s)") {
+		t.Errorf("Routes were not generated from the override:\n%s", routes)
+	}
+
+	// Templates without an override fall back to the embedded version
+	if gen.Templates["handlers"].Tree.Root.String() != embedded.Templates["handlers"].Tree.Root.String() {
+		t.Error("handlers template should fall back to the embedded version")
+	}
+
+	// Overrides invalidate the generation cache
+	if gen.templateHash == embedded.templateHash {
+		t.Error("templateHash should change when a template is overridden")
+	}
+}
+
+func TestSetTemplateOverrideDir_Missing(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	gen.SetTemplateOverrideDir(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err := gen.LoadTemplates(); err != nil {
+		t.Errorf("LoadTemplates with missing override dir failed: %v", err)
+	}
+}
+
+func TestGenerate_Tracing(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+	for _, enabled := range []bool{false, true} {
+		projectDir := t.TempDir()
+		if err := os.Chdir(projectDir); err != nil {
+			t.Fatal(err)
+		}
+
+		gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+		gen.Config.TracingEnabled = enabled
+		if err := gen.LoadTemplates(); err != nil {
+			t.Fatalf("LoadTemplates failed: %v", err)
+		}
+		if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+			t.Fatalf("RegisterResource failed: %v", err)
+		}
+		if err := gen.GenerateStorage(); err != nil {
+			t.Fatalf("GenerateStorage failed: %v", err)
+		}
+		if err := gen.GenerateMiddleware(); err != nil {
+			t.Fatalf("GenerateMiddleware failed: %v", err)
+		}
+
+		storage, err := os.ReadFile(filepath.Join("internal", "storage", "storage_generated.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(string(storage), `startSpan(ctx, "Load", "Rack", uid)`); got != enabled {
+			t.Errorf("tracing=%v: storage Load span present = %v", enabled, got)
+		}
+
+		for _, file := range []string{
+			filepath.Join("internal", "storage", "tracing_generated.go"),
+			filepath.Join("internal", "middleware", "tracing_middleware_generated.go"),
+		} {
+			_, err := os.Stat(file)
+			if exists := err == nil; exists != enabled {
+				t.Errorf("tracing=%v: %s exists = %v", enabled, file, exists)
+			}
+		}
+	}
+}
+
+func TestGenerate_MetricsMiddleware(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+	for _, enabled := range []bool{false, true} {
+		projectDir := t.TempDir()
+		if err := os.Chdir(projectDir); err != nil {
+			t.Fatal(err)
+		}
+
+		gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+		gen.Config.MetricsEnabled = enabled
+		if err := gen.LoadTemplates(); err != nil {
+			t.Fatalf("LoadTemplates failed: %v", err)
+		}
+		if err := gen.GenerateMiddleware(); err != nil {
+			t.Fatalf("GenerateMiddleware failed: %v", err)
+		}
+
+		middleware, err := os.ReadFile(filepath.Join("internal", "middleware", "metrics_middleware_generated.go"))
+		if exists := err == nil; exists != enabled {
+			t.Fatalf("metrics=%v: metrics middleware exists = %v", enabled, exists)
+		}
+		if enabled && !strings.Contains(string(middleware), "rctx.RoutePattern()") {
+			t.Error("metrics middleware does not label requests by route pattern")
+		}
+	}
+}
+
+func TestGenerate_MiddlewareProblemResponses(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+	for _, algorithm := range []string{"sha256", "xxhash"} {
+		projectDir := t.TempDir()
+		if err := os.Chdir(projectDir); err != nil {
+			t.Fatal(err)
+		}
+
+		gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+		gen.Config.ETagAlgorithm = algorithm
+		if err := gen.LoadTemplates(); err != nil {
+			t.Fatalf("LoadTemplates failed: %v", err)
+		}
+		if err := gen.GenerateMiddleware(); err != nil {
+			t.Fatalf("GenerateMiddleware failed: %v", err)
+		}
+
+		for file, want := range map[string]string{
+			"conditional_middleware_generated.go": "httperror.WriteError(w, r, http.StatusPreconditionFailed,",
+			"versioning_middleware_generated.go":  "httperror.WriteError(w, r, http.StatusNotAcceptable,",
+		} {
+			data, err := os.ReadFile(filepath.Join("internal", "middleware", file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s: %s does not contain %q", algorithm, file, want)
+			}
+			if strings.Contains(string(data), `"error":`) {
+				t.Errorf("%s: %s writes an ad-hoc error body", algorithm, file)
+			}
+		}
+	}
+}
+
+func TestGenerateBenchmarks(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator("bench", "bench", "example.com/app")
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.GenerateBenchmarks(); err != nil {
+		t.Fatalf("GenerateBenchmarks failed: %v", err)
+	}
+
+	benchmarks, err := os.ReadFile(filepath.Join("bench", "bench_generated_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"func BenchmarkCreateRack(", "func BenchmarkGetRack(", "func BenchmarkListRack(", "NewMemoryBackend()"} {
+		if !strings.Contains(string(benchmarks), want) {
+			t.Errorf("benchmarks do not contain %q", want)
+		}
+	}
+
+	targets, err := os.ReadFile(filepath.Join("bench", "targets.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(targets), "POST http://localhost:8080/racks\nContent-Type: application/json\n@bench/payloads/racks.json\n") {
+		t.Errorf("targets.txt = %s", targets)
+	}
+
+	payload, err := os.ReadFile(filepath.Join("bench", "payloads", "racks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(payload, &body); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if body["name"] != "bench-rack" || body["location"] != "DataCenter A" || body["units"] != 42.0 {
+		t.Errorf("payload = %v", body)
+	}
+
+	// Ent projects benchmark the project's database
+	gen.SetStorageType("ent")
+	gen.SetDBDriver("postgres")
+	gen.Force = true
+	if err := gen.GenerateBenchmarks(); err != nil {
+		t.Fatalf("GenerateBenchmarks (ent) failed: %v", err)
+	}
+	benchmarks, err = os.ReadFile(filepath.Join("bench", "bench_generated_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(benchmarks), `os.Getenv("BENCH_DATABASE_URL")`) || !strings.Contains(string(benchmarks), `"github.com/lib/pq"`) {
+		t.Error("ent benchmarks do not connect to BENCH_DATABASE_URL with the postgres driver")
+	}
+}
+
+func TestExampleSpecJSON(t *testing.T) {
+	got := exampleSpecJSON([]SpecField{
+		{JSONName: "hostname", Type: "string", ExampleValue: "example-name"},
+		{JSONName: "tags", Type: "[]string", ExampleValue: `["item1","item2"]`},
+		{JSONName: "enabled", Type: "bool", ExampleValue: "true"},
+		{JSONName: "rackUID", Type: "string", ExampleValue: "example-value", RefKind: "Rack"},
+		{JSONName: "seenAt", Type: "time.Time", ExampleValue: "{}"},
+	})
+	if want := `{"enabled":true,"hostname":"example-name","tags":["item1","item2"]}`; got != want {
+		t.Errorf("exampleSpecJSON = %s, want %s", got, want)
+	}
+}
+
+func TestGenerate_EntStorageValidatesUIDs(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+	gen.SetStorageType("ent")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+	if err := gen.GenerateStorage(); err != nil {
+		t.Fatalf("GenerateStorage failed: %v", err)
+	}
+
+	storage, err := os.ReadFile(filepath.Join("internal", "storage", "storage_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range []string{"func LoadRack(", "func LoadManyRacks(", "func StatRack(", "func SaveRack(", "func DeleteRack("} {
+		start := strings.Index(string(storage), fn)
+		if start < 0 {
+			t.Fatalf("%s not generated", fn)
+		}
+		body := string(storage[start:])
+		if end := strings.Index(body, "\n}\n"); end >= 0 {
+			body = body[:end]
+		}
+		if !strings.Contains(body, "fabricaStorage.ValidateUID(") {
+			t.Errorf("%s does not validate the UID:\n%s", fn, body)
+		}
+	}
+
+	// Ent storage records the storage metrics itself
+	for _, want := range []string{"func EnableMetrics()", `observe("Rack", "LoadMany", start, err)`, `observe(kind, "Exists", start, err)`} {
+		if !strings.Contains(string(storage), want) {
+			t.Errorf("Ent storage missing %s", want)
+		}
+	}
+}
+
+func TestGenerateStorage_EntRejectsTTL(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+	gen.SetStorageType("ent")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+	gen.SetResourceTag("Rack", "ttl", "enabled")
+
+	err = gen.GenerateStorage()
+	if err == nil || !strings.Contains(err.Error(), "+fabrica:ttl=enabled") {
+		t.Errorf("GenerateStorage = %v, want an error naming the ttl marker", err)
+	}
+
+	gen.SetStorageType("file")
+	if err := gen.GenerateStorage(); err != nil {
+		t.Errorf("GenerateStorage (file) failed: %v", err)
+	}
+}
+
+func TestGenerateReconcilerRegistration_GenerationFilter(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		outputDir := t.TempDir()
+		gen := NewGenerator(outputDir, "reconcilers", "example.com/app")
+		gen.Config.ReconcileGenerationFilter = enabled
+		if err := gen.LoadTemplates(); err != nil {
+			t.Fatalf("LoadTemplates failed: %v", err)
+		}
+		if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+			t.Fatalf("RegisterResource failed: %v", err)
+		}
+		if err := gen.GenerateReconcilerRegistration(); err != nil {
+			t.Fatalf("GenerateReconcilerRegistration failed: %v", err)
+		}
+
+		registration, err := os.ReadFile(filepath.Join(outputDir, "registration_generated.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(string(registration), "reconcile.GenerationChangedPredicate{}"); got != enabled {
+			t.Errorf("generation filter=%v: predicate registered = %v:\n%s", enabled, got, registration)
+		}
+	}
+}
+
+func TestGenerateRoutes_Resync(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+		gen.Config.ReconcileEnabled = enabled
+		gen.Config.AuthEnabled = true
+		if err := gen.LoadTemplates(); err != nil {
+			t.Fatalf("LoadTemplates failed: %v", err)
+		}
+		if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+			t.Fatalf("RegisterResource failed: %v", err)
+		}
+		if err := gen.GenerateRoutes(); err != nil {
+			t.Fatalf("GenerateRoutes failed: %v", err)
+		}
+
+		routes, err := os.ReadFile(filepath.Join(gen.OutputDir, "routes_generated.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Resync is an admin operation: auth applies even without +fabrica:auth=required
+		want := `r.With(AuthMiddleware, AuthorizationMiddleware("Rack", prefix+"/racks")).Post(prefix+"/racks:resync", resyncHandler("Rack"))`
+		if got := strings.Contains(string(routes), want); got != enabled {
+			t.Errorf("reconcile enabled=%v: resync route registered = %v:\n%s", enabled, got, routes)
+		}
+	}
+}
+
+func TestGenerate_AuthForResource(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+	gen.Config.AuthEnabled = true
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	for _, res := range []interface{}{&rack.Rack{}, &node.Node{}} {
+		if err := gen.RegisterResource(res); err != nil {
+			t.Fatalf("RegisterResource failed: %v", err)
+		}
+	}
+	gen.EnableAuthForResource("Rack")
+	gen.EnableAuthForResource("Missing") // no-op
+
+	if err := os.MkdirAll(gen.OutputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.GenerateRoutes(); err != nil {
+		t.Fatalf("GenerateRoutes failed: %v", err)
+	}
+	if err := gen.GenerateMiddleware(); err != nil {
+		t.Fatalf("GenerateMiddleware failed: %v", err)
+	}
+
+	routes, err := os.ReadFile(filepath.Join("cmd", "server", "routes_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the Rack routes are wrapped
+	if n := strings.Count(string(routes), "r.Use(AuthMiddleware)"); n != 1 {
+		t.Errorf("AuthMiddleware applied %d times, want 1:\n%s", n, routes)
+	}
+	rackRoutes := strings.Index(string(routes), `r.Route(prefix+"/racks"`)
+	nodeRoutes := strings.Index(string(routes), `r.Route(prefix+"/nodes"`)
+	auth := strings.Index(string(routes), "r.Use(AuthMiddleware)")
+	if rackRoutes < 0 || nodeRoutes < 0 || auth < rackRoutes || (nodeRoutes > rackRoutes && auth > nodeRoutes) {
+		t.Errorf("AuthMiddleware not applied to the Rack routes:\n%s", routes)
+	}
+	if !strings.Contains(string(routes), `r.Use(AuthorizationMiddleware("Rack", prefix+"/racks"))`) {
+		t.Errorf("AuthorizationMiddleware not applied to the Rack routes:\n%s", routes)
+	}
+	for _, file := range []string{"auth_middleware_generated.go", "authz_middleware_generated.go"} {
+		if _, err := os.Stat(filepath.Join("internal", "middleware", file)); err != nil {
+			t.Errorf("%s not generated: %v", file, err)
+		}
+	}
+
+	// The policy files are scaffolded once and then left to the project
+	policy := filepath.Join("policies", "policy.csv")
+	data, err := os.ReadFile(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "p, role:admin, *, *") {
+		t.Errorf("default policy missing admin grant:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join("policies", "model.conf")); err != nil {
+		t.Errorf("model.conf not generated: %v", err)
+	}
+	if err := os.WriteFile(policy, []byte("p, alice, Rack, get\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.GenerateMiddleware(); err != nil {
+		t.Fatalf("GenerateMiddleware failed: %v", err)
+	}
+	if data, _ := os.ReadFile(policy); string(data) != "p, alice, Rack, get\n" {
+		t.Errorf("edited policy overwritten:\n%s", data)
+	}
+}
+
+func TestGenerateRoutes_ShortNamesAuthorizedPerMount(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	gen.Config.AuthEnabled = true
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+	if err := gen.SetResourceShortNames("Rack", "rk"); err != nil {
+		t.Fatal(err)
+	}
+	gen.EnableAuthForResource("Rack")
+	if err := gen.GenerateRoutes(); err != nil {
+		t.Fatalf("GenerateRoutes failed: %v", err)
+	}
+
+	routes, err := os.ReadFile(filepath.Join(gen.OutputDir, "routes_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each mount derives actions from its own path, so PUT /rk/{uid}/status
+	// is update_status rather than update
+	for _, want := range []string{
+		`r.Use(AuthorizationMiddlewareAt("Rack", prefix+"/racks", mountPath))`,
+		`r.Route(prefix+"/racks", routes(prefix+"/racks"))`,
+		`r.Route(prefix+"/rk", routes(prefix+"/rk"))`,
+	} {
+		if !strings.Contains(string(routes), want) {
+			t.Errorf("routes missing %s:\n%s", want, routes)
+		}
+	}
+}
+
+type scalePoolSpec struct {
+	Image    string `json:"image"`
+	Replicas int32  `json:"replicas" scaleField:""`
+}
+
+type scalePool struct {
+	Spec scalePoolSpec `json:"spec"`
+}
+
+type badScalePool struct {
+	Spec struct {
+		Image string `json:"image" scaleField:""`
+	} `json:"spec"`
+}
+
+type twoScalePool struct {
+	Spec struct {
+		Replicas int `json:"replicas" scaleField:""`
+		Workers  int `json:"workers" scaleField:""`
+	} `json:"spec"`
+}
+
+func TestRegisterResource_ScaleField(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.RegisterResource(&scalePool{}); err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+
+	// The tagged field is only served once the resource is marked
+	if field := gen.Resources[0].ScaleField(); field != nil {
+		t.Errorf("ScaleField = %+v before the scale marker, want nil", field)
+	}
+	gen.SetResourceTag("scalePool", "scale", "enabled")
+	field := gen.Resources[0].ScaleField()
+	if field == nil || field.Name != "Replicas" || field.JSONName != "replicas" || field.Type != "int32" {
+		t.Errorf("ScaleField = %+v, want the Replicas field", field)
+	}
+
+	for _, res := range []interface{}{&badScalePool{}, &twoScalePool{}} {
+		if err := gen.RegisterResource(res); err == nil {
+			t.Errorf("RegisterResource(%T) succeeded, want an error", res)
+		}
+	}
+}
+
+func TestGenerateHandlers_ScaleWithoutField(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	gen.SetResourceTag("Rack", "scale", "enabled")
+
+	err := gen.GenerateHandlers()
+	if err == nil || !strings.Contains(err.Error(), "scaleField") {
+		t.Errorf("GenerateHandlers = %v, want a missing scaleField error", err)
+	}
+}
+
+func TestGenerateHandlers_ListCapsPageSize(t *testing.T) {
+	outputDir := t.TempDir()
+	gen := NewGenerator(outputDir, "main", "example.com/app")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.GenerateHandlers(); err != nil {
+		t.Fatalf("GenerateHandlers failed: %v", err)
+	}
+
+	handlers, err := os.ReadFile(filepath.Join(outputDir, "rack_handlers_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The cap applies before either read path, so no list holds the whole collection
+	capped := strings.Index(string(handlers), "limit = fabricaStorage.PageLimit(limit)")
+	paged := strings.Index(string(handlers), "fabricaStorage.PaginateEach(each, matches, cursor, limit)")
+	sorted := strings.Index(string(handlers), "fabricaStorage.PaginateSorted(matched, sortKeys, cursor, limit)")
+	if capped < 0 || paged < capped || sorted < capped {
+		t.Errorf("GetRacks does not cap the page size before reading (cap at %d, reads at %d and %d)", capped, paged, sorted)
+	}
+}
+
+func TestGenerateHandlers_PatchMutatesThenValidates(t *testing.T) {
+	outputDir := t.TempDir()
+	gen := NewGenerator(outputDir, "main", "example.com/app")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.GenerateHandlers(); err != nil {
+		t.Fatalf("GenerateHandlers failed: %v", err)
+	}
+
+	handlers, err := os.ReadFile(filepath.Join(outputDir, "rack_handlers_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := strings.Index(string(handlers), "func PatchRack(")
+	if start < 0 {
+		t.Fatal("PatchRack not generated")
+	}
+	body := string(handlers[start:])
+	if end := strings.Index(body, "\n}\n"); end >= 0 {
+		body = body[:end]
+	}
+
+	// PATCH runs the same sequence as PUT on the patched resource
+	last := -1
+	for _, step := range []string{
+		"rack.Spec = patchedSpec",
+		`resource.RunMutators(r.Context(), "Rack", rack)`,
+		"resource.CheckImmutable(previousSpec, rack.Spec)",
+		"checkMetadata(w, r, &rack.Resource)",
+		"validation.ValidateResource(rack)",
+		"validation.ValidateWithContext(r.Context(), rack)",
+		`validation.ValidateWithWebhooks(r.Context(), "Rack", "UPDATE", rack)`,
+		"checkReferences(ctx, w, r, rack, previousSpec)",
+		"storage.SaveRack(ctx, rack)",
+	} {
+		i := strings.Index(body, step)
+		if i < 0 {
+			t.Fatalf("PatchRack missing %s", step)
+		}
+		if i < last {
+			t.Errorf("PatchRack calls %s out of order", step)
+		}
+		last = i
+	}
+}
+
+func TestGenerate_NameRouting(t *testing.T) {
+	for _, storageType := range []string{"file", "ent"} {
+		t.Run(storageType, func(t *testing.T) {
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+			projectDir := t.TempDir()
+			if err := os.Chdir(projectDir); err != nil {
+				t.Fatal(err)
+			}
+
+			gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+			gen.SetStorageType(storageType)
+			if err := gen.LoadTemplates(); err != nil {
+				t.Fatalf("LoadTemplates failed: %v", err)
+			}
+			for _, res := range []interface{}{&rack.Rack{}, &node.Node{}} {
+				if err := gen.RegisterResource(res); err != nil {
+					t.Fatalf("RegisterResource failed: %v", err)
+				}
+			}
+			gen.SetResourceTag("Rack", "routing", "name")
+
+			if err := os.MkdirAll(gen.OutputDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, step := range []func() error{gen.GenerateRoutes, gen.GenerateHandlers, gen.GenerateStorage} {
+				if err := step(); err != nil {
+					t.Fatalf("generation failed: %v", err)
+				}
+			}
+
+			routes, err := os.ReadFile(filepath.Join("cmd", "server", "routes_generated.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`r.Route("/{name}"`, "r.Use(resolveRackName)", `r.Route("/{uid}"`} {
+				if strings.Count(string(routes), want) != 1 {
+					t.Errorf("routes should contain %s once:\n%s", want, routes)
+				}
+			}
+
+			handlers, err := os.ReadFile(filepath.Join("cmd", "server", "rack_handlers_generated.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"func resolveRackName(", "storage.FindRackByName(ctx, rack.GetName())", "Rack name is required", `case "count":`} {
+				if !strings.Contains(string(handlers), want) {
+					t.Errorf("Rack handlers missing %s", want)
+				}
+			}
+			// Create and update (renames) both check the name
+			if n := strings.Count(string(handlers), "if !checkRackName(w, r, rack) {"); n != 2 {
+				t.Errorf("checkRackName called %d times, want 2", n)
+			}
+
+			storage, err := os.ReadFile(filepath.Join("internal", "storage", "storage_generated.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(storage), "func FindRackByName(") || strings.Contains(string(storage), "func FindNodeByName(") {
+				t.Errorf("FindByName should be generated for Rack only:\n%s", storage)
+			}
+		})
+	}
+}
+
+func TestGenerate_UniqueFields(t *testing.T) {
+	for _, storageType := range []string{"file", "ent"} {
+		t.Run(storageType, func(t *testing.T) {
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+			projectDir := t.TempDir()
+			if err := os.Chdir(projectDir); err != nil {
+				t.Fatal(err)
+			}
+
+			gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+			gen.SetStorageType(storageType)
+			if err := gen.LoadTemplates(); err != nil {
+				t.Fatalf("LoadTemplates failed: %v", err)
+			}
+			for _, res := range []interface{}{&rack.Rack{}, &node.Node{}} {
+				if err := gen.RegisterResource(res); err != nil {
+					t.Fatalf("RegisterResource failed: %v", err)
+				}
+			}
+			if err := gen.SetResourceUnique("Rack", "spec.location"); err != nil {
+				t.Fatalf("SetResourceUnique failed: %v", err)
+			}
+
+			if err := os.MkdirAll(gen.OutputDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, step := range []func() error{gen.GenerateHandlers, gen.GenerateStorage} {
+				if err := step(); err != nil {
+					t.Fatalf("generation failed: %v", err)
+				}
+			}
+
+			handlers, err := os.ReadFile(filepath.Join("cmd", "server", "rack_handlers_generated.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := strings.Count(string(handlers), "checkRackUnique(ctx, w, r, rack)"); n != 3 {
+				t.Errorf("Rack handlers should check uniqueness on create, update and patch, found %d", n)
+			}
+			if !strings.Contains(string(handlers), "storage.FindRackByLocation(ctx, res.Spec.Location)") {
+				t.Errorf("Rack handlers missing the spec.location lookup:\n%s", handlers)
+			}
+
+			storage, err := os.ReadFile(filepath.Join("internal", "storage", "storage_generated.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(storage), "func FindRackByLocation(ctx context.Context, value string)") || strings.Contains(string(storage), "func FindNodeBy") {
+				t.Errorf("FindByLocation should be generated for Rack only:\n%s", storage)
+			}
+		})
+	}
+}
+
+func TestGenerate_FieldNaming(t *testing.T) {
+	for _, naming := range []string{"camel", "snake"} {
+		t.Run(naming, func(t *testing.T) {
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+
+			projectDir := t.TempDir()
+			if err := os.Chdir(projectDir); err != nil {
+				t.Fatal(err)
+			}
+
+			gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+			gen.Config.FieldNaming = naming
+			if err := gen.LoadTemplates(); err != nil {
+				t.Fatalf("LoadTemplates failed: %v", err)
+			}
+			for _, res := range []interface{}{&rack.Rack{}, &node.Node{}} {
+				if err := gen.RegisterResource(res); err != nil {
+					t.Fatalf("RegisterResource failed: %v", err)
+				}
+			}
+
+			if err := os.MkdirAll(gen.OutputDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, step := range []func() error{gen.GenerateModels, gen.GenerateRoutes, gen.GenerateOpenAPI, gen.GenerateClient, gen.GenerateClientCmd} {
+				if err := step(); err != nil {
+					t.Fatalf("generation failed: %v", err)
+				}
+			}
+
+			files := map[string]string{
+				filepath.Join("cmd", "server", "models_generated.go"):  "codec.NewSnakeCaseMapper(",
+				filepath.Join("cmd", "server", "routes_generated.go"):  "r.Use(wireKeys.Middleware)",
+				filepath.Join("cmd", "server", "openapi_generated.go"): "snakeCaseSchemas(spec)",
+				filepath.Join("cmd", "server", "client_generated.go"):  "WireKeys.FromWire(",
+				filepath.Join("cmd", "client", "main.go"):              "wireJSON{data}",
+			}
+			for file, marker := range files {
+				content, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := strings.Contains(string(content), marker); got != (naming == "snake") {
+					t.Errorf("%s: contains %q = %v with %s field naming", file, marker, got, naming)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateModels_ReconcileController(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		outputDir := t.TempDir()
+		gen := NewGenerator(outputDir, "main", "example.com/app")
+		gen.Config.ReconcileEnabled = enabled
+		if err := gen.LoadTemplates(); err != nil {
+			t.Fatalf("LoadTemplates failed: %v", err)
+		}
+		if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+			t.Fatalf("RegisterResource failed: %v", err)
+		}
+		if err := gen.GenerateModels(); err != nil {
+			t.Fatalf("GenerateModels failed: %v", err)
+		}
+
+		models, err := os.ReadFile(filepath.Join(outputDir, "models_generated.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Without reconciliation the import would be unused
+		for _, marker := range []string{`"github.com/openchami/fabrica/pkg/reconcile"`, "var ReconcileController *reconcile.Controller"} {
+			if got := strings.Contains(string(models), marker); got != enabled {
+				t.Errorf("reconcile enabled = %v: contains %s = %v", enabled, marker, got)
+			}
+		}
+	}
+}
+
+func TestSetResourceUnique(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gen.SetResourceUnique("Rack", "spec.location", " spec.units"); err != nil {
+		t.Fatalf("SetResourceUnique failed: %v", err)
+	}
+	res, _ := gen.GetResourceByName("Rack")
+	if fields := res.UniqueFields(); len(fields) != 2 {
+		t.Errorf("UniqueFields = %+v, want location and units", fields)
+	}
+
+	for _, field := range []string{"location", "spec.Location", "spec.missing", "status.phase"} {
+		if err := gen.SetResourceUnique("Rack", field); err == nil {
+			t.Errorf("SetResourceUnique(%q) succeeded, want an error", field)
+		}
+	}
+	if err := gen.SetResourceUnique("Missing", "spec.location"); err == nil {
+		t.Error("SetResourceUnique accepted an unregistered resource")
+	}
+}
+
+// Reflection runs once per resource type in RegisterResource; the generate
+// runner is a fresh process on every run (--watch included), so a cache keyed
+// by reflect.Type could never hit. These benchmarks compare that cost with
+// rendering the resource's files.
+func BenchmarkRegisterResource(b *testing.B) {
+	outputDir := b.TempDir()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		gen := NewGenerator(outputDir, "main", "example.com/app")
+		if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateHandlers(b *testing.B) {
+	gen := NewGenerator(b.TempDir(), "main", "example.com/app")
+	if err := gen.LoadTemplates(); err != nil {
+		b.Fatal(err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := gen.GenerateHandlers(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
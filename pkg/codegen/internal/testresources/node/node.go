@@ -0,0 +1,29 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package node is a test resource whose spec embeds the rack spec.
+package node
+
+import (
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/rack"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Node is a test resource.
+type Node struct {
+	resource.Resource
+	Spec   NodeSpec   `json:"spec"`
+	Status NodeStatus `json:"status,omitempty"`
+}
+
+// NodeSpec is the desired state of a Node.
+type NodeSpec struct {
+	rack.RackSpec `json:",inline"`
+	Hostname      string `json:"hostname"`
+}
+
+// NodeStatus is the observed state of a Node.
+type NodeStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
@@ -0,0 +1,26 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package rack is a test resource referenced by the node test resource.
+package rack
+
+import "github.com/openchami/fabrica/pkg/resource"
+
+// Rack is a test resource.
+type Rack struct {
+	resource.Resource
+	Spec   RackSpec   `json:"spec"`
+	Status RackStatus `json:"status,omitempty"`
+}
+
+// RackSpec is the desired state of a Rack.
+type RackSpec struct {
+	Location string `json:"location"`
+	Units    int    `json:"units"`
+}
+
+// RackStatus is the observed state of a Rack.
+type RackStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
@@ -0,0 +1,1003 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExternalAPI describes a client generated from an external OpenAPI 3
+// document rather than from registered resources. It plays the role
+// ResourceMetadata plays for resource clients: the openapi client template
+// is driven entirely by it.
+type ExternalAPI struct {
+	// PackageName is the Go package of the generated client
+	PackageName string
+
+	// Source is the document the client was generated from
+	Source string
+
+	// Title and APIVersion come from the document's info section
+	Title      string
+	APIVersion string
+
+	// ServerURL is the first server listed in the document, if any
+	ServerURL string
+
+	// Models are the request and response types, in declaration order
+	Models []ExternalModel
+
+	// Operations become client methods, sorted by path then method
+	Operations []ExternalOperation
+
+	// Auth lists the security schemes the client can authenticate with
+	Auth []ExternalAuth
+
+	// UsesTime is true if any model has a date-time field
+	UsesTime bool
+
+	// Warnings describes constructs the generator could only approximate
+	Warnings []string
+}
+
+// HasAuth reports whether the document declares a scheme of the given kind
+// ("basic", "bearer" or "apiKey").
+func (a *ExternalAPI) HasAuth(kind string) bool {
+	for _, auth := range a.Auth {
+		if auth.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalModel is a named Go type generated from a schema.
+type ExternalModel struct {
+	Name string
+	Doc  string
+
+	// Fields is set for object schemas, which become structs
+	Fields   []ExternalField
+	IsStruct bool
+
+	// Type is the underlying type of non-object schemas (e.g., "[]Pet")
+	Type string
+}
+
+// ExternalField is a struct field of a model.
+type ExternalField struct {
+	Name     string
+	JSONName string
+	Type     string
+	Doc      string
+	Required bool
+}
+
+// ExternalOperation is one OpenAPI operation, generated as a client method.
+type ExternalOperation struct {
+	// Name is the Go method name, from operationId or the method and path
+	Name   string
+	Method string
+	Path   string
+	Doc    string
+
+	// Deprecated is set for operations marked deprecated in the document
+	Deprecated bool
+
+	// PathParams become method arguments in the order they appear in Path
+	PathParams []ExternalParam
+
+	// QueryParams and HeaderParams become fields of the ParamsType struct
+	QueryParams  []ExternalParam
+	HeaderParams []ExternalParam
+	ParamsType   string
+
+	// BodyType is the Go type of the JSON request body, if any
+	BodyType string
+
+	// ResultType is the Go type of the JSON success response, if any.
+	// ResultPointer is true when the method returns *ResultType.
+	ResultType    string
+	ResultPointer bool
+}
+
+// PathExpr returns a Go expression building the escaped request path from
+// the path parameter arguments.
+func (op ExternalOperation) PathExpr() string {
+	var parts []string
+	rest := op.Path
+	for _, param := range op.PathParams {
+		placeholder := "{" + param.WireName + "}"
+		i := strings.Index(rest, placeholder)
+		if i < 0 {
+			continue
+		}
+		if i > 0 {
+			parts = append(parts, strconv.Quote(rest[:i]))
+		}
+		value := param.Name
+		if param.Type != "string" {
+			value = "fmt.Sprint(" + param.Name + ")"
+		}
+		parts = append(parts, "url.PathEscape("+value+")")
+		rest = rest[i+len(placeholder):]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// ExternalParam is a path, query or header parameter.
+type ExternalParam struct {
+	// Name is the Go identifier: a camelCase argument for path parameters,
+	// an exported field name otherwise
+	Name     string
+	WireName string
+	Type     string
+	Doc      string
+	Required bool
+
+	// IsSlice is true for array parameters, sent as repeated values
+	IsSlice bool
+}
+
+// ExternalAuth is a security scheme from components.securitySchemes.
+type ExternalAuth struct {
+	// Name is the scheme's key in the document
+	Name string
+
+	// GoName names the client method that sets the credential
+	GoName string
+
+	// Kind is "basic", "bearer" or "apiKey". OAuth2 and OpenID Connect
+	// schemes send bearer tokens and are reported as "bearer".
+	Kind string
+
+	// In and ParamName locate an API key: a header, query or cookie name
+	In        string
+	ParamName string
+}
+
+// OpenAPI document structure, limited to what the client and gateway
+// generators use
+
+type oaDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      map[string]*oaPathItem `yaml:"paths"`
+	Components struct {
+		Schemas         map[string]*oaSchema         `yaml:"schemas"`
+		Parameters      map[string]*oaParameter      `yaml:"parameters"`
+		RequestBodies   map[string]*oaRequestBody    `yaml:"requestBodies"`
+		Responses       map[string]*oaResponse       `yaml:"responses"`
+		SecuritySchemes map[string]*oaSecurityScheme `yaml:"securitySchemes"`
+	} `yaml:"components"`
+}
+
+type oaPathItem struct {
+	Parameters []*oaParameter `yaml:"parameters"`
+	Get        *oaOperation   `yaml:"get"`
+	Put        *oaOperation   `yaml:"put"`
+	Post       *oaOperation   `yaml:"post"`
+	Delete     *oaOperation   `yaml:"delete"`
+	Patch      *oaOperation   `yaml:"patch"`
+	Head       *oaOperation   `yaml:"head"`
+	Options    *oaOperation   `yaml:"options"`
+}
+
+// operations returns the item's operations keyed by HTTP method
+func (p *oaPathItem) operations() map[string]*oaOperation {
+	ops := map[string]*oaOperation{}
+	for method, op := range map[string]*oaOperation{
+		"GET": p.Get, "PUT": p.Put, "POST": p.Post, "DELETE": p.Delete,
+		"PATCH": p.Patch, "HEAD": p.Head, "OPTIONS": p.Options,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+type oaOperation struct {
+	OperationID string                 `yaml:"operationId"`
+	Summary     string                 `yaml:"summary"`
+	Description string                 `yaml:"description"`
+	Deprecated  bool                   `yaml:"deprecated"`
+	Tags        []string               `yaml:"tags"`
+	Parameters  []*oaParameter         `yaml:"parameters"`
+	RequestBody *oaRequestBody         `yaml:"requestBody"`
+	Responses   map[string]*oaResponse `yaml:"responses"`
+}
+
+type oaParameter struct {
+	Ref         string    `yaml:"$ref"`
+	Name        string    `yaml:"name"`
+	In          string    `yaml:"in"`
+	Description string    `yaml:"description"`
+	Required    bool      `yaml:"required"`
+	Schema      *oaSchema `yaml:"schema"`
+}
+
+type oaRequestBody struct {
+	Ref      string                  `yaml:"$ref"`
+	Required bool                    `yaml:"required"`
+	Content  map[string]*oaMediaType `yaml:"content"`
+}
+
+type oaResponse struct {
+	Ref     string                  `yaml:"$ref"`
+	Content map[string]*oaMediaType `yaml:"content"`
+}
+
+type oaMediaType struct {
+	Schema *oaSchema `yaml:"schema"`
+}
+
+type oaSecurityScheme struct {
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+	In     string `yaml:"in"`
+	Name   string `yaml:"name"`
+}
+
+type oaSchema struct {
+	Ref                  string        `yaml:"$ref"`
+	Type                 oaSchemaTypes `yaml:"type"`
+	Format               string        `yaml:"format"`
+	Description          string        `yaml:"description"`
+	Nullable             bool          `yaml:"nullable"`
+	Properties           oaProperties  `yaml:"properties"`
+	Required             []string      `yaml:"required"`
+	Items                *oaSchema     `yaml:"items"`
+	AdditionalProperties yaml.Node     `yaml:"additionalProperties"`
+	Enum                 []interface{} `yaml:"enum"`
+	AllOf                []*oaSchema   `yaml:"allOf"`
+	OneOf                []*oaSchema   `yaml:"oneOf"`
+	AnyOf                []*oaSchema   `yaml:"anyOf"`
+}
+
+// oaSchemaTypes accepts "type: string" (3.0) and "type: [string, null]" (3.1)
+type oaSchemaTypes []string
+
+func (t *oaSchemaTypes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = oaSchemaTypes{node.Value}
+		return nil
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+// oaProperty is one entry of a schema's properties
+type oaProperty struct {
+	name   string
+	schema *oaSchema
+}
+
+// oaProperties keeps properties in document order so generated struct
+// fields follow the document
+type oaProperties []oaProperty
+
+func (p *oaProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		schema := &oaSchema{}
+		if err := node.Content[i+1].Decode(schema); err != nil {
+			return err
+		}
+		*p = append(*p, oaProperty{name: node.Content[i].Value, schema: schema})
+	}
+	return nil
+}
+
+// primaryType returns the schema type, ignoring "null" and inferring
+// objects and arrays from properties and items
+func (s *oaSchema) primaryType() string {
+	for _, t := range s.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	switch {
+	case len(s.Properties) > 0 || s.AdditionalProperties.Kind != 0:
+		return "object"
+	case s.Items != nil:
+		return "array"
+	}
+	return ""
+}
+
+// additionalSchema returns the additionalProperties schema, if it is one
+func (s *oaSchema) additionalSchema() *oaSchema {
+	if s.AdditionalProperties.Kind != yaml.MappingNode {
+		return nil
+	}
+	extra := &oaSchema{}
+	if err := s.AdditionalProperties.Decode(extra); err != nil {
+		return nil
+	}
+	return extra
+}
+
+// ParseOpenAPI builds the client description of an OpenAPI 3 document in
+// YAML or JSON. Constructs without a direct Go equivalent (oneOf, non-JSON
+// bodies, cookie parameters) are approximated or skipped and reported in
+// Warnings rather than failing the parse.
+func ParseOpenAPI(data []byte, packageName string) (*ExternalAPI, error) {
+	var doc oaDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if doc.Swagger != "" {
+		return nil, fmt.Errorf("swagger %s documents are not supported; convert to OpenAPI 3 first", doc.Swagger)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("not an OpenAPI 3 document (openapi: %q)", doc.OpenAPI)
+	}
+
+	p := &openAPIParser{
+		doc: &doc,
+		api: &ExternalAPI{
+			PackageName: packageName,
+			Title:       doc.Info.Title,
+			APIVersion:  doc.Info.Version,
+		},
+		models:     make(map[string]int),
+		refModels:  make(map[string]string),
+		operations: make(map[string]bool),
+	}
+	if len(doc.Servers) > 0 {
+		p.api.ServerURL = doc.Servers[0].URL
+	}
+
+	// Named schemas first, so references resolve to their declared names
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		p.refModel(name)
+	}
+
+	for _, path := range sortedKeys(doc.Paths) {
+		item := doc.Paths[path]
+		ops := item.operations()
+		for _, method := range sortedKeys(ops) {
+			p.operation(path, method, item, ops[method])
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Components.SecuritySchemes) {
+		p.securityScheme(name, doc.Components.SecuritySchemes[name])
+	}
+
+	return p.api, nil
+}
+
+// openAPIParser accumulates models and operations while walking a document
+type openAPIParser struct {
+	doc *oaDocument
+	api *ExternalAPI
+
+	// models indexes api.Models by name
+	models map[string]int
+
+	// refModels maps component schema names to model names
+	refModels map[string]string
+
+	operations map[string]bool
+}
+
+func (p *openAPIParser) warn(format string, args ...interface{}) {
+	p.api.Warnings = append(p.api.Warnings, fmt.Sprintf(format, args...))
+}
+
+// refModel declares (once) the model for a component schema
+func (p *openAPIParser) refModel(name string) string {
+	if model, ok := p.refModels[name]; ok {
+		return model
+	}
+	schema, ok := p.doc.Components.Schemas[name]
+	if !ok {
+		p.warn("schema %q not found; using interface{}", name)
+		return "interface{}"
+	}
+	model := p.uniqueModelName(goIdentifier(name))
+	p.refModels[name] = model
+	p.declareModel(model, schema)
+	return model
+}
+
+// uniqueModelName reserves a model name, adding a numeric suffix on clashes
+func (p *openAPIParser) uniqueModelName(name string) string {
+	for base, i := name, 2; ; i++ {
+		if _, taken := p.models[name]; !taken {
+			p.models[name] = -1
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// declareModel adds a named model for schema. The slot is reserved before
+// converting fields, so self-referencing schemas terminate.
+func (p *openAPIParser) declareModel(name string, schema *oaSchema) {
+	index := len(p.api.Models)
+	p.models[name] = index
+	p.api.Models = append(p.api.Models, ExternalModel{Name: name})
+
+	model := ExternalModel{Name: name, Doc: oneLine(schema.Description)}
+	if fields, ok := p.structFields(name, schema); ok {
+		model.IsStruct = true
+		model.Fields = fields
+	} else {
+		model.Type = p.goType(name+"Value", schema)
+		if len(schema.Enum) > 0 {
+			model.Doc = strings.TrimSpace(model.Doc + " One of: " + enumList(schema.Enum) + ".")
+		}
+	}
+	p.api.Models[index] = model
+}
+
+// resolveSchema follows a component schema reference
+func (p *openAPIParser) resolveSchema(s *oaSchema) (*oaSchema, string) {
+	if s.Ref == "" {
+		return s, ""
+	}
+	name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+	if !ok {
+		p.warn("reference %s is not a component schema; using interface{}", s.Ref)
+		return &oaSchema{}, ""
+	}
+	target, ok := p.doc.Components.Schemas[name]
+	if !ok {
+		p.warn("reference %s not found; using interface{}", s.Ref)
+		return &oaSchema{}, ""
+	}
+	return target, name
+}
+
+// structFields returns the fields of an object schema, merging allOf parts.
+// ok is false if the schema is not an object with properties.
+func (p *openAPIParser) structFields(owner string, s *oaSchema) ([]ExternalField, bool) {
+	if len(s.AllOf) > 0 {
+		var fields []ExternalField
+		for _, part := range s.AllOf {
+			resolved, ref := p.resolveSchema(part)
+			if ref != "" {
+				// Reuse the referenced model's fields, so its inline types are shared
+				if index := p.models[p.refModel(ref)]; index >= 0 && p.api.Models[index].IsStruct {
+					fields = append(fields, p.api.Models[index].Fields...)
+					continue
+				}
+			}
+			partFields, ok := p.structFields(owner, resolved)
+			if !ok {
+				p.warn("%s: allOf part is not an object; ignored", owner)
+				continue
+			}
+			fields = append(fields, partFields...)
+		}
+		if own, ok := p.structFields(owner, &oaSchema{Properties: s.Properties, Required: s.Required}); ok {
+			fields = append(fields, own...)
+		}
+		return dedupeFields(fields), true
+	}
+	if s.primaryType() != "object" || len(s.Properties) == 0 {
+		return nil, false
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	fields := make([]ExternalField, 0, len(s.Properties))
+	for _, prop := range s.Properties {
+		name := goIdentifier(prop.name)
+		goType := p.goType(owner+name, prop.schema)
+
+		resolved, _ := p.resolveSchema(prop.schema)
+		isRequired := required[prop.name]
+		if !isRequired && p.pointerable(goType) {
+			goType = "*" + goType
+		}
+
+		doc := oneLine(prop.schema.Description)
+		if doc == "" {
+			doc = oneLine(resolved.Description)
+		}
+		if len(resolved.Enum) > 0 && prop.schema.Ref == "" {
+			doc = strings.TrimSpace(doc + " One of: " + enumList(resolved.Enum) + ".")
+		}
+
+		fields = append(fields, ExternalField{
+			Name:     name,
+			JSONName: prop.name,
+			Type:     goType,
+			Doc:      doc,
+			Required: isRequired,
+		})
+	}
+	return fields, true
+}
+
+// pointerable reports whether an optional field of goType should be a
+// pointer, so an absent value can be told apart from the zero value
+func (p *openAPIParser) pointerable(goType string) bool {
+	switch goType {
+	case "string", "bool", "int32", "int64", "float32", "float64", "time.Time":
+		return true
+	}
+	index, ok := p.models[goType]
+	if !ok {
+		return false
+	}
+	if index < 0 {
+		return true
+	}
+	// A model still being declared has neither fields nor a type yet; it is
+	// a self-reference, which must be a pointer
+	model := p.api.Models[index]
+	return model.IsStruct || model.Type == ""
+}
+
+// goType returns the Go type for a schema, declaring a model named
+// inlineName for inline objects
+func (p *openAPIParser) goType(inlineName string, s *oaSchema) string {
+	if s.Ref != "" {
+		_, name := p.resolveSchema(s)
+		if name == "" {
+			return "interface{}"
+		}
+		return p.refModel(name)
+	}
+
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 {
+		return p.goType(inlineName, s.AllOf[0])
+	}
+	if len(s.AllOf) > 0 {
+		name := p.uniqueModelName(inlineName)
+		p.declareModel(name, s)
+		return name
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		p.warn("%s: oneOf/anyOf is not supported; using json.RawMessage", inlineName)
+		return "json.RawMessage"
+	}
+
+	switch s.primaryType() {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			p.api.UsesTime = true
+			return "time.Time"
+		case "binary":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + p.goType(inlineName+"Item", s.Items)
+	case "object":
+		if len(s.Properties) > 0 {
+			name := p.uniqueModelName(inlineName)
+			p.declareModel(name, s)
+			return name
+		}
+		if extra := s.additionalSchema(); extra != nil {
+			return "map[string]" + p.goType(inlineName+"Value", extra)
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// operation converts one operation into a client method
+func (p *openAPIParser) operation(path, method string, item *oaPathItem, op *oaOperation) {
+	name := goIdentifier(op.OperationID)
+	if op.OperationID == "" {
+		name = operationName(method, path)
+	}
+	for base, i := name, 2; p.operations[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	p.operations[name] = true
+
+	out := ExternalOperation{
+		Name:   name,
+		Method: method,
+		Path:   path,
+		Doc:    oneLine(op.Summary),
+	}
+	if out.Doc == "" {
+		out.Doc = oneLine(op.Description)
+	}
+	out.Deprecated = op.Deprecated
+
+	// Operation parameters override path-level ones with the same name and location
+	params := map[string]*oaParameter{}
+	var order []string
+	for _, list := range [][]*oaParameter{item.Parameters, op.Parameters} {
+		for _, param := range list {
+			param = p.resolveParameter(param)
+			if param == nil {
+				continue
+			}
+			key := param.In + "\x00" + param.Name
+			if _, seen := params[key]; !seen {
+				order = append(order, key)
+			}
+			params[key] = param
+		}
+	}
+
+	for _, key := range order {
+		param := params[key]
+		schema := param.Schema
+		if schema == nil {
+			schema = &oaSchema{Type: oaSchemaTypes{"string"}}
+		}
+		resolved, _ := p.resolveSchema(schema)
+		goType := paramGoType(resolved)
+
+		converted := ExternalParam{
+			Name:     goIdentifier(param.Name),
+			WireName: param.Name,
+			Type:     goType,
+			Doc:      oneLine(param.Description),
+			Required: param.Required || param.In == "path",
+			IsSlice:  strings.HasPrefix(goType, "[]"),
+		}
+
+		switch param.In {
+		case "path":
+			converted.Name = lowerFirst(converted.Name)
+			if goKeywords[converted.Name] {
+				converted.Name += "Param"
+			}
+			out.PathParams = append(out.PathParams, converted)
+		case "query":
+			out.QueryParams = append(out.QueryParams, converted)
+		case "header":
+			out.HeaderParams = append(out.HeaderParams, converted)
+		default:
+			p.warn("%s: %s parameter %q is not supported; skipped", name, param.In, param.Name)
+		}
+	}
+	sort.SliceStable(out.PathParams, func(i, j int) bool {
+		return strings.Index(path, "{"+out.PathParams[i].WireName+"}") < strings.Index(path, "{"+out.PathParams[j].WireName+"}")
+	})
+	if len(out.QueryParams) > 0 || len(out.HeaderParams) > 0 {
+		out.ParamsType = p.uniqueModelName(name + "Params")
+	}
+
+	if op.RequestBody != nil {
+		body := p.resolveRequestBody(op.RequestBody)
+		if body != nil {
+			if media := jsonMedia(body.Content); media != nil && media.Schema != nil {
+				out.BodyType = p.goType(name+"Request", media.Schema)
+			} else if len(body.Content) > 0 {
+				p.warn("%s: request body has no JSON content; operation skipped", name)
+				return
+			}
+		}
+	}
+
+	out.ResultType, out.ResultPointer = p.resultType(name, op.Responses)
+	p.api.Operations = append(p.api.Operations, out)
+}
+
+// resultType returns the Go type of the first 2xx JSON response
+func (p *openAPIParser) resultType(opName string, responses map[string]*oaResponse) (string, bool) {
+	for _, code := range sortedKeys(responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		response := p.resolveResponse(responses[code])
+		if response == nil {
+			continue
+		}
+		media := jsonMedia(response.Content)
+		if media == nil || media.Schema == nil {
+			continue
+		}
+		goType := p.goType(opName+"Response", media.Schema)
+		index, isModel := p.models[goType]
+		return goType, isModel && index >= 0 && p.api.Models[index].IsStruct
+	}
+	return "", false
+}
+
+func (p *openAPIParser) resolveParameter(param *oaParameter) *oaParameter {
+	if param.Ref == "" {
+		return param
+	}
+	name, _ := strings.CutPrefix(param.Ref, "#/components/parameters/")
+	if target, ok := p.doc.Components.Parameters[name]; ok {
+		return target
+	}
+	p.warn("parameter reference %s not found; skipped", param.Ref)
+	return nil
+}
+
+func (p *openAPIParser) resolveRequestBody(body *oaRequestBody) *oaRequestBody {
+	if body.Ref == "" {
+		return body
+	}
+	name, _ := strings.CutPrefix(body.Ref, "#/components/requestBodies/")
+	if target, ok := p.doc.Components.RequestBodies[name]; ok {
+		return target
+	}
+	p.warn("request body reference %s not found; skipped", body.Ref)
+	return nil
+}
+
+func (p *openAPIParser) resolveResponse(response *oaResponse) *oaResponse {
+	if response == nil || response.Ref == "" {
+		return response
+	}
+	name, _ := strings.CutPrefix(response.Ref, "#/components/responses/")
+	if target, ok := p.doc.Components.Responses[name]; ok {
+		return target
+	}
+	p.warn("response reference %s not found; skipped", response.Ref)
+	return nil
+}
+
+// securityScheme records a scheme the client can authenticate with
+func (p *openAPIParser) securityScheme(name string, scheme *oaSecurityScheme) {
+	auth := ExternalAuth{Name: name}
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		auth.Kind = "basic"
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"),
+		scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+		auth.Kind = "bearer"
+	case scheme.Type == "apiKey":
+		auth.Kind = "apiKey"
+		auth.In = scheme.In
+		auth.ParamName = scheme.Name
+		auth.GoName = "With" + goIdentifier(name)
+	default:
+		p.warn("security scheme %q (%s %s) is not supported", name, scheme.Type, scheme.Scheme)
+		return
+	}
+	p.api.Auth = append(p.api.Auth, auth)
+}
+
+// paramGoType maps a parameter schema to a type that formats with fmt.Sprint
+func paramGoType(s *oaSchema) string {
+	switch s.primaryType() {
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]string"
+		}
+		return "[]" + paramGoType(s.Items)
+	}
+	return "string"
+}
+
+// jsonMedia returns the JSON media type of a content map
+func jsonMedia(content map[string]*oaMediaType) *oaMediaType {
+	for _, mediaType := range sortedKeys(content) {
+		base := strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0])
+		if base == "application/json" || strings.HasSuffix(base, "+json") {
+			return content[mediaType]
+		}
+	}
+	return nil
+}
+
+// dedupeFields keeps the last definition of each field, as allOf overrides
+func dedupeFields(fields []ExternalField) []ExternalField {
+	last := make(map[string]int, len(fields))
+	for i, field := range fields {
+		last[field.JSONName] = i
+	}
+	result := make([]ExternalField, 0, len(last))
+	for i, field := range fields {
+		if last[field.JSONName] == i {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// operationName derives a method name from the method and path, e.g.
+// GET /pets/{petId} becomes GetPetsByPetID
+func operationName(method, path string) string {
+	name := goIdentifier(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name += "By" + goIdentifier(strings.Trim(segment, "{}"))
+		} else if segment != "" {
+			name += goIdentifier(segment)
+		}
+	}
+	return name
+}
+
+// openAPIInitialisms are written in upper case in generated identifiers
+var openAPIInitialisms = map[string]bool{
+	"API": true, "CPU": true, "DNS": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "MAC": true, "UID": true,
+	"URI": true, "URL": true, "UUID": true,
+}
+
+// goKeywords cannot be used as argument names
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true,
+	"default": true, "defer": true, "else": true, "fallthrough": true,
+	"for": true, "func": true, "go": true, "goto": true, "if": true,
+	"import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true,
+	"switch": true, "type": true, "var": true,
+}
+
+// goIdentifier converts a name like "pet_id", "petId" or "list-pets" to an
+// exported Go identifier ("PetID", "PetID", "ListPets")
+func goIdentifier(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i <= len(runes); i++ {
+			if i < len(runes) && !(unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1])) {
+				continue
+			}
+			word := string(runes[start:i])
+			if upper := strings.ToUpper(word); openAPIInitialisms[upper] {
+				b.WriteString(upper)
+			} else {
+				b.WriteRune(unicode.ToUpper(runes[start]))
+				b.WriteString(string(runes[start+1 : i]))
+			}
+			start = i
+		}
+	}
+
+	result := b.String()
+	if result == "" {
+		return "Value"
+	}
+	if unicode.IsDigit(rune(result[0])) {
+		result = "N" + result
+	}
+	return result
+}
+
+// lowerFirst makes an identifier unexported, keeping leading initialisms
+// readable ("PetID" becomes "petID", "IDToken" becomes "idToken")
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	if i == 0 {
+		return name
+	}
+	if i > 1 && i < len(runes) {
+		// The last upper-case letter starts the next word
+		i--
+	}
+	return strings.ToLower(string(runes[:i])) + string(runes[i:])
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func enumList(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprint(value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GenerateOpenAPIClient generates a Go client package from an OpenAPI 3
+// document. The client is written to outputDir/client_generated.go; any
+// warnings about approximated constructs are returned alongside.
+func GenerateOpenAPIClient(specPath, outputDir, packageName string) ([]string, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	api, err := ParseOpenAPI(data, packageName)
+	if err != nil {
+		return nil, err
+	}
+	api.Source = filepath.ToSlash(specPath)
+	if len(api.Operations) == 0 {
+		return api.Warnings, fmt.Errorf("%s defines no operations", specPath)
+	}
+
+	content, err := embeddedTemplates.ReadFile("templates/client/openapi.go.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded template: %w", err)
+	}
+	tmpl, err := template.New("openapiClient").Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template client/openapi.go.tmpl: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, api); err != nil {
+		return nil, fmt.Errorf("failed to execute openapi client template: %w", err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated client code: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	filename := filepath.Join(outputDir, "client_generated.go")
+	if err := os.WriteFile(filename, formatted, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write client file: %w", err)
+	}
+	fmt.Printf("  ✓ Generated %s\n", filename)
+
+	return api.Warnings, nil
+}
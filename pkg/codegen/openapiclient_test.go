@@ -0,0 +1,183 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testOpenAPIDoc = `openapi: 3.0.3
+info: {title: Inventory, version: 2.1.0}
+servers:
+  - url: https://inventory.example.com/api
+paths:
+  /nodes/{xname}:
+    parameters:
+      - {name: xname, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: get_node
+      parameters:
+        - {name: verbose, in: query, schema: {type: boolean}}
+        - {name: fields, in: query, schema: {type: array, items: {type: string}}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Node"}
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Node"}
+      responses:
+        "204": {description: updated}
+  /upload:
+    post:
+      requestBody:
+        content:
+          application/octet-stream: {}
+      responses:
+        "204": {description: ok}
+components:
+  securitySchemes:
+    basicAuth: {type: http, scheme: basic}
+    apiKey: {type: apiKey, in: query, name: key}
+  schemas:
+    Node:
+      type: object
+      required: [xname]
+      properties:
+        xname: {type: string}
+        cpu_count: {type: integer, format: int32}
+        location:
+          type: object
+          properties:
+            rack: {type: string}
+        extra: {anyOf: [{type: string}, {type: number}]}
+`
+
+func TestParseOpenAPI(t *testing.T) {
+	api, err := ParseOpenAPI([]byte(testOpenAPIDoc), "inventory")
+	if err != nil {
+		t.Fatalf("ParseOpenAPI failed: %v", err)
+	}
+
+	if api.Title != "Inventory" || api.APIVersion != "2.1.0" || api.ServerURL != "https://inventory.example.com/api" {
+		t.Errorf("Unexpected info: %+v", api)
+	}
+
+	if len(api.Models) != 2 || api.Models[0].Name != "Node" || api.Models[1].Name != "NodeLocation" {
+		t.Fatalf("Unexpected models: %+v", api.Models)
+	}
+	fields := api.Models[0].Fields
+	want := []struct{ name, goType string }{
+		{"Xname", "string"},
+		{"CPUCount", "*int32"},
+		{"Location", "*NodeLocation"},
+		{"Extra", "json.RawMessage"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("Expected %d fields, got %+v", len(want), fields)
+	}
+	for i, w := range want {
+		if fields[i].Name != w.name || fields[i].Type != w.goType {
+			t.Errorf("Field %d = %s %s, want %s %s", i, fields[i].Name, fields[i].Type, w.name, w.goType)
+		}
+	}
+
+	// The octet-stream upload is skipped
+	if len(api.Operations) != 2 {
+		t.Fatalf("Expected 2 operations, got %+v", api.Operations)
+	}
+	get := api.Operations[0]
+	if get.Name != "GetNode" || get.Method != "GET" || get.ResultType != "Node" || !get.ResultPointer {
+		t.Errorf("Unexpected get operation: %+v", get)
+	}
+	if len(get.PathParams) != 1 || get.PathParams[0].Name != "xname" {
+		t.Errorf("Path-level parameter not inherited: %+v", get.PathParams)
+	}
+	if get.ParamsType != "GetNodeParams" || len(get.QueryParams) != 2 || !get.QueryParams[1].IsSlice {
+		t.Errorf("Unexpected query parameters: %+v", get)
+	}
+	if got := get.PathExpr(); got != `"/nodes/" + url.PathEscape(xname)` {
+		t.Errorf("PathExpr = %s", got)
+	}
+
+	put := api.Operations[1]
+	if put.Name != "PutNodesByXname" || put.BodyType != "Node" || put.ResultType != "" {
+		t.Errorf("Unexpected put operation: %+v", put)
+	}
+
+	if !api.HasAuth("basic") || !api.HasAuth("apiKey") || api.HasAuth("bearer") {
+		t.Errorf("Unexpected auth: %+v", api.Auth)
+	}
+
+	warnings := strings.Join(api.Warnings, "\n")
+	for _, w := range []string{"anyOf", "no JSON content"} {
+		if !strings.Contains(warnings, w) {
+			t.Errorf("Expected a warning about %s, got:\n%s", w, warnings)
+		}
+	}
+}
+
+func TestParseOpenAPI_RejectsSwagger(t *testing.T) {
+	if _, err := ParseOpenAPI([]byte("swagger: \"2.0\"\npaths: {}\n"), "x"); err == nil {
+		t.Error("Expected an error for a Swagger 2.0 document")
+	}
+}
+
+func TestGoIdentifier(t *testing.T) {
+	for in, want := range map[string]string{
+		"pet_id":     "PetID",
+		"petId":      "PetID",
+		"list-pets":  "ListPets",
+		"X-API-Key":  "XAPIKey",
+		"2fa":        "N2fa",
+		"getHTTPUrl": "GetHTTPUrl",
+	} {
+		if got := goIdentifier(in); got != want {
+			t.Errorf("goIdentifier(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for in, want := range map[string]string{"PetID": "petID", "ID": "id", "IDToken": "idToken"} {
+		if got := lowerFirst(in); got != want {
+			t.Errorf("lowerFirst(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateOpenAPIClient(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "inventory.yaml")
+	if err := os.WriteFile(spec, []byte(testOpenAPIDoc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "client")
+	if _, err := GenerateOpenAPIClient(spec, out, "inventory"); err != nil {
+		t.Fatalf("GenerateOpenAPIClient failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(out, "client_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package inventory",
+		`const DefaultServerURL = "https://inventory.example.com/api"`,
+		"func (c *Client) GetNode(ctx context.Context, xname string, params GetNodeParams) (*Node, error)",
+		"func (c *Client) WithBasicAuth(username, password string) *Client",
+		"func (c *Client) WithAPIKey(key string) *Client",
+		`query.Set("key", key)`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Generated client missing %q", want)
+		}
+	}
+}
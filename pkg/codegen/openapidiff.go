@@ -0,0 +1,294 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIDiff is the API impact of a regeneration: what changed between two
+// OpenAPI documents of the same service.
+type OpenAPIDiff struct {
+	// AddedEndpoints and RemovedEndpoints are "METHOD /path" strings, sorted
+	AddedEndpoints   []string `json:"addedEndpoints"`
+	RemovedEndpoints []string `json:"removedEndpoints"`
+
+	// AddedSchemas and RemovedSchemas name components.schemas entries
+	AddedSchemas   []string `json:"addedSchemas"`
+	RemovedSchemas []string `json:"removedSchemas"`
+
+	// SchemaChanges are the field changes of schemas in both documents,
+	// sorted by schema then field
+	SchemaChanges []SchemaChange `json:"schemaChanges"`
+
+	// Breaking describes every change that can break existing clients:
+	// removed endpoints and schemas, and the breaking schema changes
+	Breaking []string `json:"breaking"`
+}
+
+// SchemaChange is one changed field of a schema
+type SchemaChange struct {
+	Schema string `json:"schema"`
+
+	// Field is the dotted path of the field in the schema; array items
+	// are written field[] and map values field{}
+	Field string `json:"field"`
+
+	// Change says what happened, e.g. "added" or "type changed from
+	// string to integer"
+	Change string `json:"change"`
+
+	Breaking bool `json:"breaking"`
+}
+
+// Empty reports whether the documents describe the same API
+func (d *OpenAPIDiff) Empty() bool {
+	return len(d.AddedEndpoints) == 0 && len(d.RemovedEndpoints) == 0 &&
+		len(d.AddedSchemas) == 0 && len(d.RemovedSchemas) == 0 && len(d.SchemaChanges) == 0
+}
+
+// DiffOpenAPI compares two OpenAPI 3 documents in YAML or JSON, typically
+// the GenerateOpenAPI output of two generations.
+//
+// Removing an endpoint, a schema or a required field, changing a field's
+// type and making a field required are breaking; additions and the removal
+// of optional fields are not. Schemas are compared structurally, following
+// properties, array items and map values but not $ref targets, which are
+// compared as schemas of their own.
+func DiffOpenAPI(oldData, newData []byte) (*OpenAPIDiff, error) {
+	oldDoc, err := parseOpenAPIDocument(oldData)
+	if err != nil {
+		return nil, fmt.Errorf("old document: %w", err)
+	}
+	newDoc, err := parseOpenAPIDocument(newData)
+	if err != nil {
+		return nil, fmt.Errorf("new document: %w", err)
+	}
+
+	d := &OpenAPIDiff{SchemaChanges: []SchemaChange{}, Breaking: []string{}}
+	d.AddedEndpoints, d.RemovedEndpoints = diffKeys(endpoints(oldDoc), endpoints(newDoc))
+	d.AddedSchemas, d.RemovedSchemas = diffKeys(oldDoc.Components.Schemas, newDoc.Components.Schemas)
+
+	for _, name := range sortedKeys(oldDoc.Components.Schemas) {
+		if newSchema, ok := newDoc.Components.Schemas[name]; ok {
+			d.compareSchema(name, "", oldDoc.Components.Schemas[name], newSchema)
+		}
+	}
+	sort.SliceStable(d.SchemaChanges, func(i, j int) bool {
+		if d.SchemaChanges[i].Schema != d.SchemaChanges[j].Schema {
+			return d.SchemaChanges[i].Schema < d.SchemaChanges[j].Schema
+		}
+		return d.SchemaChanges[i].Field < d.SchemaChanges[j].Field
+	})
+
+	for _, endpoint := range d.RemovedEndpoints {
+		d.Breaking = append(d.Breaking, "removed endpoint "+endpoint)
+	}
+	for _, name := range d.RemovedSchemas {
+		d.Breaking = append(d.Breaking, "removed schema "+name)
+	}
+	for _, change := range d.SchemaChanges {
+		if change.Breaking {
+			d.Breaking = append(d.Breaking, fmt.Sprintf("%s: %s", change.location(), change.Change))
+		}
+	}
+	return d, nil
+}
+
+// parseOpenAPIDocument parses an OpenAPI 3 document in YAML or JSON
+func parseOpenAPIDocument(data []byte) (*oaDocument, error) {
+	var doc oaDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("not an OpenAPI 3 document (openapi: %q)", doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// endpoints returns the document's operations keyed by "METHOD /path"
+func endpoints(doc *oaDocument) map[string]bool {
+	ops := map[string]bool{}
+	for path, item := range doc.Paths {
+		for method := range item.operations() {
+			ops[method+" "+path] = true
+		}
+	}
+	return ops
+}
+
+// diffKeys returns the sorted keys only in newMap and only in oldMap
+func diffKeys[V, W any](oldMap map[string]V, newMap map[string]W) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for _, key := range sortedKeys(newMap) {
+		if _, ok := oldMap[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	for _, key := range sortedKeys(oldMap) {
+		if _, ok := newMap[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	return added, removed
+}
+
+// compareSchema records the changes between two versions of the schema at
+// field (empty for the schema itself)
+func (d *OpenAPIDiff) compareSchema(schema, field string, oldSchema, newSchema *oaSchema) {
+	if oldType, newType := schemaTypeName(oldSchema), schemaTypeName(newSchema); oldType != newType {
+		d.addChange(schema, field, fmt.Sprintf("type changed from %s to %s", oldType, newType), true)
+		return
+	}
+	if oldSchema.Ref != "" {
+		return
+	}
+
+	oldRequired := stringSet(oldSchema.Required)
+	newRequired := stringSet(newSchema.Required)
+	newProperties := map[string]*oaSchema{}
+	for _, property := range newSchema.Properties {
+		newProperties[property.name] = property.schema
+	}
+
+	oldProperties := map[string]bool{}
+	for _, property := range oldSchema.Properties {
+		oldProperties[property.name] = true
+		path := joinField(field, property.name)
+		newProperty, ok := newProperties[property.name]
+		switch {
+		case !ok && oldRequired[property.name]:
+			d.addChange(schema, path, "removed required field", true)
+		case !ok:
+			d.addChange(schema, path, "removed", false)
+		default:
+			if newRequired[property.name] && !oldRequired[property.name] {
+				d.addChange(schema, path, "became required", true)
+			}
+			d.compareSchema(schema, path, property.schema, newProperty)
+		}
+	}
+	for _, property := range newSchema.Properties {
+		if oldProperties[property.name] {
+			continue
+		}
+		if newRequired[property.name] {
+			d.addChange(schema, joinField(field, property.name), "added required field", true)
+		} else {
+			d.addChange(schema, joinField(field, property.name), "added", false)
+		}
+	}
+
+	if oldSchema.Items != nil && newSchema.Items != nil {
+		d.compareSchema(schema, field+"[]", oldSchema.Items, newSchema.Items)
+	}
+	if oldValues, newValues := oldSchema.additionalSchema(), newSchema.additionalSchema(); oldValues != nil && newValues != nil {
+		d.compareSchema(schema, field+"{}", oldValues, newValues)
+	}
+}
+
+func (d *OpenAPIDiff) addChange(schema, field, change string, breaking bool) {
+	d.SchemaChanges = append(d.SchemaChanges, SchemaChange{Schema: schema, Field: field, Change: change, Breaking: breaking})
+}
+
+// schemaTypeName describes a schema's type for comparison: the referenced
+// schema for a $ref, otherwise the type and format, e.g. "integer (int64)"
+func schemaTypeName(s *oaSchema) string {
+	if s.Ref != "" {
+		return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	}
+	name := s.primaryType()
+	if name == "" {
+		name = "any"
+	}
+	if s.Format != "" {
+		name += " (" + s.Format + ")"
+	}
+	return name
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// location names the changed field, or the schema for a change to its type
+func (c SchemaChange) location() string {
+	if c.Field == "" {
+		return c.Schema
+	}
+	return c.Schema + "." + c.Field
+}
+
+// WriteText writes the diff for people to read, e.g.
+//
+//	Endpoints added:
+//	  + GET /devices/{uid}/events
+//	Schemas changed:
+//	  ~ Device.spec.location: type changed from string to integer (breaking)
+//	  + Device.spec.tags: added
+//
+//	1 breaking change(s)
+func (d *OpenAPIDiff) WriteText(w io.Writer) {
+	if d.Empty() {
+		fmt.Fprintln(w, "No API changes")
+		return
+	}
+
+	section := func(title, mark string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintln(w, title+":")
+		for _, item := range items {
+			fmt.Fprintf(w, "  %s %s\n", mark, item)
+		}
+	}
+	section("Endpoints added", "+", d.AddedEndpoints)
+	section("Endpoints removed", "-", d.RemovedEndpoints)
+	section("Schemas added", "+", d.AddedSchemas)
+	section("Schemas removed", "-", d.RemovedSchemas)
+
+	if len(d.SchemaChanges) > 0 {
+		fmt.Fprintln(w, "Schemas changed:")
+		for _, change := range d.SchemaChanges {
+			mark := "~"
+			switch {
+			case strings.HasPrefix(change.Change, "added"):
+				mark = "+"
+			case strings.HasPrefix(change.Change, "removed"):
+				mark = "-"
+			}
+			line := fmt.Sprintf("  %s %s: %s", mark, change.location(), change.Change)
+			if change.Breaking {
+				line += " (breaking)"
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if len(d.Breaking) == 0 {
+		fmt.Fprintln(w, "No breaking changes")
+	} else {
+		fmt.Fprintf(w, "%d breaking change(s)\n", len(d.Breaking))
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const diffOldDoc = `openapi: 3.0.3
+info: {title: Inventory, version: 1.0.0}
+paths:
+  /devices:
+    get: {responses: {"200": {description: ok}}}
+    post: {responses: {"201": {description: created}}}
+  /racks/{uid}:
+    delete: {responses: {"200": {description: deleted}}}
+components:
+  schemas:
+    Device:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        location: {type: string}
+        slot: {type: integer, format: int32}
+        tags: {type: array, items: {type: string}}
+        labels: {type: object, additionalProperties: {type: string}}
+        rack: {$ref: "#/components/schemas/Rack"}
+    Rack:
+      type: object
+      properties:
+        name: {type: string}
+    Legacy:
+      type: object
+`
+
+const diffNewDoc = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Inventory", "version": "1.1.0"},
+  "paths": {
+    "/devices": {
+      "get": {"responses": {"200": {"description": "ok"}}},
+      "post": {"responses": {"201": {"description": "created"}}}
+    },
+    "/devices/{uid}/events": {
+      "get": {"responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Device": {
+        "type": "object",
+        "required": ["location", "serial"],
+        "properties": {
+          "location": {"type": "string"},
+          "slot": {"type": "integer", "format": "int64"},
+          "tags": {"type": "array", "items": {"type": "integer"}},
+          "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+          "rack": {"$ref": "#/components/schemas/Rack"},
+          "serial": {"type": "string"},
+          "notes": {"type": "string"}
+        }
+      },
+      "Rack": {"type": "object", "properties": {"name": {"type": "string"}, "row": {"type": "string"}}},
+      "Chassis": {"type": "object"}
+    }
+  }
+}`
+
+func TestDiffOpenAPI(t *testing.T) {
+	diff, err := DiffOpenAPI([]byte(diffOldDoc), []byte(diffNewDoc))
+	if err != nil {
+		t.Fatalf("DiffOpenAPI failed: %v", err)
+	}
+
+	if want := []string{"GET /devices/{uid}/events"}; !reflect.DeepEqual(diff.AddedEndpoints, want) {
+		t.Errorf("AddedEndpoints = %v, want %v", diff.AddedEndpoints, want)
+	}
+	if want := []string{"DELETE /racks/{uid}"}; !reflect.DeepEqual(diff.RemovedEndpoints, want) {
+		t.Errorf("RemovedEndpoints = %v, want %v", diff.RemovedEndpoints, want)
+	}
+	if want := []string{"Chassis"}; !reflect.DeepEqual(diff.AddedSchemas, want) {
+		t.Errorf("AddedSchemas = %v, want %v", diff.AddedSchemas, want)
+	}
+	if want := []string{"Legacy"}; !reflect.DeepEqual(diff.RemovedSchemas, want) {
+		t.Errorf("RemovedSchemas = %v, want %v", diff.RemovedSchemas, want)
+	}
+
+	want := []SchemaChange{
+		{Schema: "Device", Field: "location", Change: "became required", Breaking: true},
+		{Schema: "Device", Field: "name", Change: "removed required field", Breaking: true},
+		{Schema: "Device", Field: "notes", Change: "added"},
+		{Schema: "Device", Field: "serial", Change: "added required field", Breaking: true},
+		{Schema: "Device", Field: "slot", Change: "type changed from integer (int32) to integer (int64)", Breaking: true},
+		{Schema: "Device", Field: "tags[]", Change: "type changed from string to integer", Breaking: true},
+		{Schema: "Rack", Field: "row", Change: "added"},
+	}
+	if !reflect.DeepEqual(diff.SchemaChanges, want) {
+		t.Errorf("SchemaChanges =\n%+v\nwant\n%+v", diff.SchemaChanges, want)
+	}
+
+	wantBreaking := []string{
+		"removed endpoint DELETE /racks/{uid}",
+		"removed schema Legacy",
+		"Device.location: became required",
+		"Device.name: removed required field",
+		"Device.serial: added required field",
+		"Device.slot: type changed from integer (int32) to integer (int64)",
+		"Device.tags[]: type changed from string to integer",
+	}
+	if !reflect.DeepEqual(diff.Breaking, wantBreaking) {
+		t.Errorf("Breaking =\n%v\nwant\n%v", diff.Breaking, wantBreaking)
+	}
+
+	var out bytes.Buffer
+	diff.WriteText(&out)
+	for _, line := range []string{
+		"Endpoints added:\n  + GET /devices/{uid}/events\n",
+		"  - Device.name: removed required field (breaking)\n",
+		"  + Rack.row: added\n",
+		"7 breaking change(s)\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("text output is missing %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestDiffOpenAPI_NoChanges(t *testing.T) {
+	diff, err := DiffOpenAPI([]byte(diffOldDoc), []byte(diffOldDoc))
+	if err != nil {
+		t.Fatalf("DiffOpenAPI failed: %v", err)
+	}
+	if !diff.Empty() || len(diff.Breaking) != 0 {
+		t.Errorf("diff of a document with itself = %+v", diff)
+	}
+
+	if _, err := DiffOpenAPI([]byte(`swagger: "2.0"`), []byte(diffOldDoc)); err == nil {
+		t.Error("DiffOpenAPI should reject documents that are not OpenAPI 3")
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// irregularPlurals maps words whose plural does not follow the suffix rules
+var irregularPlurals = map[string]string{
+	"person": "people",
+	"child":  "children",
+	"man":    "men",
+	"woman":  "women",
+	"mouse":  "mice",
+	"goose":  "geese",
+	"foot":   "feet",
+	"tooth":  "teeth",
+	"ox":     "oxen",
+	"quiz":   "quizzes",
+	"leaf":   "leaves",
+	"life":   "lives",
+	"knife":  "knives",
+	"wife":   "wives",
+	"half":   "halves",
+	"shelf":  "shelves",
+	"hero":   "heroes",
+	"echo":   "echoes",
+	"potato": "potatoes",
+	"tomato": "tomatoes",
+}
+
+// uncountablePlurals lists words that are their own plural
+var uncountablePlurals = map[string]bool{
+	"equipment":   true,
+	"information": true,
+	"metadata":    true,
+	"series":      true,
+	"species":     true,
+	"sheep":       true,
+	"fish":        true,
+	"deer":        true,
+	"news":        true,
+}
+
+// pluralNamePattern is what a plural override must look like, since it is
+// used in URL paths, file names and generated identifiers
+var pluralNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// urlPathPattern is what a URL path override must look like
+var urlPathPattern = regexp.MustCompile(`^(/[a-z][a-z0-9-]*)+$`)
+
+// Pluralize returns the lowercase plural of a resource name, as used in its
+// URL path and generated names. Only the last word of a CamelCase name is
+// pluralized, so "NetworkPolicy" becomes "networkpolicies" and "NodeChild"
+// becomes "nodechildren". Trailing acronyms ("NodeBMC") only get an "s" or
+// "es" suffix.
+//
+// Example:
+//
+//	codegen.Pluralize("Category") // "categories"
+//	codegen.Pluralize("Status")   // "statuses"
+//	codegen.Pluralize("Gateway")  // "gateways"
+func Pluralize(name string) string {
+	word := lastWord(name)
+	lower := strings.ToLower(name)
+	last := strings.ToLower(word)
+	acronym := strings.ToUpper(word) == word
+
+	if !acronym {
+		stem := lower[:len(lower)-len(last)]
+		if plural, ok := irregularPlurals[last]; ok {
+			return stem + plural
+		}
+		if uncountablePlurals[last] {
+			return lower
+		}
+	}
+
+	switch {
+	case !acronym && len(last) > 1 && strings.HasSuffix(last, "y") && !strings.ContainsRune("aeiou", rune(last[len(last)-2])):
+		return lower[:len(lower)-1] + "ies"
+	case strings.HasSuffix(last, "s"), strings.HasSuffix(last, "x"), strings.HasSuffix(last, "z"),
+		strings.HasSuffix(last, "ch"), strings.HasSuffix(last, "sh"):
+		return lower + "es"
+	default:
+		return lower + "s"
+	}
+}
+
+// lastWord returns the last word of a CamelCase name: "IPAddress" gives
+// "Address" and "NodeBMC" gives "BMC"
+func lastWord(name string) string {
+	runes := []rune(name)
+	for i := len(runes) - 1; i > 0; i-- {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		// A word starts at an upper case letter after a lower case one, or
+		// at the last upper case letter of an acronym followed by a word
+		if unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			return string(runes[i:])
+		}
+	}
+	return name
+}
+
+// SetResourcePlural overrides the plural name of a registered resource. It
+// also sets the URL path, unless SetResourcePath has. It is called for
+// resources marked "+fabrica:plural=<plural>".
+//
+// Returns:
+//   - error: If the plural is not lowercase letters and digits or is the
+//     singular name, the resource is not registered, or another resource
+//     already uses the plural
+func (g *Generator) SetResourcePlural(resourceName, plural string) error {
+	if !pluralNamePattern.MatchString(plural) {
+		return fmt.Errorf("%s: invalid plural %q (must be lowercase letters and digits)", resourceName, plural)
+	}
+	if plural == strings.ToLower(resourceName) {
+		return fmt.Errorf("%s: plural %q is the singular name", resourceName, plural)
+	}
+	index, err := g.resourceIndex(resourceName)
+	if err != nil {
+		return err
+	}
+
+	urlPath := g.Resources[index].URLPath
+	if g.Resources[index].Tags["path"] == "" {
+		urlPath = "/" + plural
+	}
+	return g.setResourceNames(index, plural, urlPath)
+}
+
+// SetResourcePath overrides the URL path of a registered resource, so that
+// e.g. NetworkInterface is served at "/nics". The plural name, used in
+// client and CLI names, becomes the last path segment without hyphens
+// ("/network-interfaces" gives "networkinterfaces") unless
+// SetResourcePlural is called afterwards. It is called for resources marked
+// "+fabrica:path=<path>".
+//
+// Returns:
+//   - error: If the path is not lowercase segments, the resource is not
+//     registered, or another resource already uses the path or plural
+func (g *Generator) SetResourcePath(resourceName, urlPath string) error {
+	if !urlPathPattern.MatchString(urlPath) {
+		return fmt.Errorf("%s: invalid path %q (must be /-separated segments of lowercase letters, digits and hyphens)", resourceName, urlPath)
+	}
+	index, err := g.resourceIndex(resourceName)
+	if err != nil {
+		return err
+	}
+
+	plural := strings.ReplaceAll(urlPath[strings.LastIndex(urlPath, "/")+1:], "-", "")
+	if err := g.setResourceNames(index, plural, urlPath); err != nil {
+		return err
+	}
+	g.Resources[index].Tags["path"] = urlPath
+	return nil
+}
+
+// SetResourceShortNames sets short names of a registered resource, like
+// kubectl's "po" for pods. The generated CLI accepts them as aliases of the
+// resource's command, and the server serves the resource's routes at
+// /<short name> as well as at its path. It is called for resources marked
+// "+fabrica:shortnames=<name>[,<name>...]". The names are stored in the
+// resource's "shortnames" tag.
+//
+// Returns:
+//   - error: If a name is not lowercase letters and digits, the resource is
+//     not registered, or the name is the command, plural or first path
+//     segment of a resource, or a short name of another resource
+func (g *Generator) SetResourceShortNames(resourceName string, names ...string) error {
+	index, err := g.resourceIndex(resourceName)
+	if err != nil {
+		return err
+	}
+
+	var shortNames []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !pluralNamePattern.MatchString(name) {
+			return fmt.Errorf("%s: invalid short name %q (must be lowercase letters and digits)", resourceName, name)
+		}
+		if slices.Contains(shortNames, name) {
+			return fmt.Errorf("%s: short name %q is listed twice", resourceName, name)
+		}
+		if err := g.checkShortNameFree(index, name); err != nil {
+			return fmt.Errorf("%s: %w", resourceName, err)
+		}
+		shortNames = append(shortNames, name)
+	}
+	g.Resources[index].Tags["shortnames"] = strings.Join(shortNames, ",")
+	return nil
+}
+
+// resourceIndex returns the index of the named resource in g.Resources
+func (g *Generator) resourceIndex(resourceName string) (int, error) {
+	for i := range g.Resources {
+		if g.Resources[i].Name == resourceName {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("resource %s not found", resourceName)
+}
+
+// setResourceNames sets the plural and URL path of g.Resources[index] if no
+// other resource uses them
+func (g *Generator) setResourceNames(index int, plural, urlPath string) error {
+	if err := g.checkNamesFree(index, plural, urlPath); err != nil {
+		return fmt.Errorf("%s: %w", g.Resources[index].Name, err)
+	}
+	g.Resources[index].PluralName = plural
+	g.Resources[index].URLPath = urlPath
+	return nil
+}
+
+// checkNamesFree returns an error if a resource other than g.Resources[skip]
+// has the plural or a URL path that is, or nests with, urlPath
+func (g *Generator) checkNamesFree(skip int, plural, urlPath string) error {
+	for i, res := range g.Resources {
+		switch {
+		case i == skip:
+		case res.PluralName == plural:
+			return fmt.Errorf("plural %q is already used by %s", plural, res.Name)
+		case res.URLPath == urlPath:
+			return fmt.Errorf("path %q is already served by %s", urlPath, res.Name)
+		case strings.HasPrefix(urlPath, res.URLPath+"/"), strings.HasPrefix(res.URLPath, urlPath+"/"):
+			// One resource's item routes would shadow the other's
+			return fmt.Errorf("path %q overlaps %s at %q", urlPath, res.Name, res.URLPath)
+		case slices.Contains(res.ShortNames(), plural):
+			return fmt.Errorf("plural %q is a short name of %s", plural, res.Name)
+		case slices.Contains(res.ShortNames(), strings.Split(urlPath, "/")[1]):
+			return fmt.Errorf("path %q overlaps the short name %q of %s", urlPath, strings.Split(urlPath, "/")[1], res.Name)
+		}
+	}
+	return nil
+}
+
+// checkShortNameFree returns an error if name is the command, plural or
+// first path segment of any resource, or a short name of a resource other
+// than g.Resources[skip]. Short names are mounted at /<name>, so they must
+// not overlap any resource's path.
+func (g *Generator) checkShortNameFree(skip int, name string) error {
+	for i, res := range g.Resources {
+		switch {
+		case strings.ToLower(res.Name) == name:
+			return fmt.Errorf("short name %q is the command of %s", name, res.Name)
+		case res.PluralName == name:
+			return fmt.Errorf("short name %q is the plural of %s", name, res.Name)
+		case res.URLPath == "/"+name, strings.HasPrefix(res.URLPath, "/"+name+"/"):
+			return fmt.Errorf("short name %q overlaps %s at %q", name, res.Name, res.URLPath)
+		case i != skip && slices.Contains(res.ShortNames(), name):
+			return fmt.Errorf("short name %q is already used by %s", name, res.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/node"
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/rack"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"Device":        "devices",
+		"Category":      "categories",
+		"Gateway":       "gateways",
+		"Key":           "keys",
+		"Status":        "statuses",
+		"IPAddress":     "ipaddresses",
+		"Box":           "boxes",
+		"Quiz":          "quizzes",
+		"Switch":        "switches",
+		"Mesh":          "meshes",
+		"NetworkPolicy": "networkpolicies",
+		"Person":        "people",
+		"NodeChild":     "nodechildren",
+		"Shelf":         "shelves",
+		"Series":        "series",
+		"Metadata":      "metadata",
+		"Photo":         "photos",
+		"BMC":           "bmcs",
+		"NodeBMC":       "nodebmcs",
+		"DNS":           "dnses",
+		"Manifest":      "manifests",
+	}
+	for name, want := range tests {
+		if got := Pluralize(name); got != want {
+			t.Errorf("Pluralize(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSetResourcePlural(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gen.SetResourcePlural("Rack", "rackses"); err != nil {
+		t.Fatalf("SetResourcePlural failed: %v", err)
+	}
+	res, _ := gen.GetResourceByName("Rack")
+	if res.PluralName != "rackses" || res.URLPath != "/rackses" {
+		t.Errorf("PluralName, URLPath = %q, %q", res.PluralName, res.URLPath)
+	}
+
+	for _, plural := range []string{"Racks", "rack-units", "", "rack"} {
+		if err := gen.SetResourcePlural("Rack", plural); err == nil {
+			t.Errorf("SetResourcePlural(%q) succeeded, want an error", plural)
+		}
+	}
+	if err := gen.SetResourcePlural("Node", "rackses"); err == nil {
+		t.Error("SetResourcePlural accepted a plural used by another resource")
+	}
+	if err := gen.SetResourcePlural("Missing", "missings"); err == nil {
+		t.Error("SetResourcePlural accepted an unregistered resource")
+	}
+}
+
+func TestGenerateStorage_UncountablePlural(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) }) //nolint:errcheck
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(filepath.Join(projectDir, "cmd", "server"), "main", "example.com/app")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	// As for "Equipment" or "Series", whose plural is the singular
+	gen.Resources[0].PluralName = "rack"
+	if err := gen.GenerateStorage(); err != nil {
+		t.Fatalf("GenerateStorage failed: %v", err)
+	}
+
+	storage, err := os.ReadFile(filepath.Join("internal", "storage", "storage_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The slice and its elements must not share an identifier
+	for _, want := range []string{"items = append(items, item)", "items[uid] = item"} {
+		if !strings.Contains(string(storage), want) {
+			t.Errorf("storage missing %s:\n%s", want, storage)
+		}
+	}
+}
+
+func TestSetResourcePath(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gen.SetResourcePath("Node", "/compute/compute-nodes"); err != nil {
+		t.Fatalf("SetResourcePath failed: %v", err)
+	}
+	res, _ := gen.GetResourceByName("Node")
+	if res.URLPath != "/compute/compute-nodes" || res.PluralName != "computenodes" {
+		t.Errorf("URLPath, PluralName = %q, %q", res.URLPath, res.PluralName)
+	}
+
+	// A plural marker renames the resource without moving it
+	if err := gen.SetResourcePlural("Node", "hosts"); err != nil {
+		t.Fatal(err)
+	}
+	res, _ = gen.GetResourceByName("Node")
+	if res.URLPath != "/compute/compute-nodes" || res.PluralName != "hosts" {
+		t.Errorf("after SetResourcePlural: URLPath, PluralName = %q, %q", res.URLPath, res.PluralName)
+	}
+
+	for _, path := range []string{"nics", "/NICs", "/nics/", "/compute/compute-nodes", "/compute", "/compute/compute-nodes/ports"} {
+		if err := gen.SetResourcePath("Rack", path); err == nil {
+			t.Errorf("SetResourcePath(%q) succeeded, want an error", path)
+		}
+	}
+	if err := gen.SetResourcePath("Rack", "/compute/racks"); err != nil {
+		t.Errorf("SetResourcePath of a sibling path failed: %v", err)
+	}
+}
+
+func TestSetResourceShortNames(t *testing.T) {
+	gen := NewGenerator(t.TempDir(), "main", "example.com/app")
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gen.SetResourceShortNames("Rack", "rk", " rck"); err != nil {
+		t.Fatalf("SetResourceShortNames failed: %v", err)
+	}
+	res, _ := gen.GetResourceByName("Rack")
+	if names := res.ShortNames(); len(names) != 2 || names[0] != "rk" || names[1] != "rck" {
+		t.Errorf("ShortNames = %v, want [rk rck]", names)
+	}
+
+	for _, names := range [][]string{{"RK"}, {"r-k"}, {"rack"}, {"racks"}, {"rk2", "rk2"}} {
+		if err := gen.SetResourceShortNames("Rack", names...); err == nil {
+			t.Errorf("SetResourceShortNames(%q) succeeded, want an error", names)
+		}
+	}
+	if err := gen.SetResourceShortNames("Missing", "ms"); err == nil {
+		t.Error("SetResourceShortNames accepted an unregistered resource")
+	}
+
+	// Short names collide with other resources in either order
+	if err := gen.SetResourceShortNames("Rack", "node"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err == nil {
+		t.Error("RegisterResource accepted a command used as a short name")
+	}
+	if err := gen.SetResourceShortNames("Rack", "rk", "nodes"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err == nil {
+		t.Error("RegisterResource accepted a plural used as a short name")
+	}
+	if err := gen.SetResourceShortNames("Rack", "rk"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"rk", "node", "nodes"} {
+		if err := gen.SetResourceShortNames("Node", name); err == nil {
+			t.Errorf("SetResourceShortNames(Node, %q) succeeded, want an error", name)
+		}
+	}
+	if err := gen.SetResourcePath("Node", "/rk"); err == nil {
+		t.Error("SetResourcePath accepted a path at another resource's short name")
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ProtoDir is where GenerateProto writes .proto files, relative to the project root
+const ProtoDir = "api/proto"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// protoScalars maps Go scalar kinds to proto3 scalar types
+var protoScalars = map[reflect.Kind]string{
+	reflect.String:  "string",
+	reflect.Bool:    "bool",
+	reflect.Int:     "int64",
+	reflect.Int8:    "int32",
+	reflect.Int16:   "int32",
+	reflect.Int32:   "int32",
+	reflect.Int64:   "int64",
+	reflect.Uint:    "uint64",
+	reflect.Uint8:   "uint32",
+	reflect.Uint16:  "uint32",
+	reflect.Uint32:  "uint32",
+	reflect.Uint64:  "uint64",
+	reflect.Float32: "float",
+	reflect.Float64: "double",
+}
+
+// protoGoTypes maps proto3 scalar types to the Go types protoc-gen-go uses
+var protoGoTypes = map[string]string{
+	"string": "string",
+	"bool":   "bool",
+	"int32":  "int32",
+	"int64":  "int64",
+	"uint32": "uint32",
+	"uint64": "uint64",
+	"float":  "float32",
+	"double": "float64",
+}
+
+// protoFieldType maps a Go field type to a proto3 type and the conversion the
+// generated gRPC server uses between the two:
+//   - direct: the protoc-generated field has the same Go type
+//   - cast: a scalar that converts with a Go type conversion (e.g. int to int64)
+//   - time: time.Time as google.protobuf.Timestamp
+//   - json: anything else, carried as a google.protobuf.Value with the same
+//     JSON representation as the REST API
+//
+// ownPkg is the resource's package; named types from it can be converted
+// directly because the generated server imports it.
+func protoFieldType(t reflect.Type, ownPkg string) (protoType, conversion string) {
+	if t == timeType {
+		return "google.protobuf.Timestamp", "time"
+	}
+
+	if scalar, ok := exactProtoScalar(t); ok {
+		return scalar, "direct"
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && t.Elem().PkgPath() == "" {
+			return "bytes", "direct"
+		}
+		if scalar, ok := exactProtoScalar(t.Elem()); ok && t.PkgPath() == "" {
+			return "repeated " + scalar, "direct"
+		}
+	case reflect.Map:
+		key, keyOK := exactProtoScalar(t.Key())
+		value, valueOK := exactProtoScalar(t.Elem())
+		if keyOK && valueOK && key != "bool" && key != "float" && key != "double" && t.PkgPath() == "" {
+			return fmt.Sprintf("map<%s, %s>", key, value), "direct"
+		}
+	case reflect.Ptr:
+		if scalar, ok := exactProtoScalar(t.Elem()); ok {
+			return "optional " + scalar, "direct"
+		}
+	default:
+		if scalar, ok := protoScalars[t.Kind()]; ok && (t.PkgPath() == "" || t.PkgPath() == ownPkg) {
+			return scalar, "cast"
+		}
+	}
+
+	return "google.protobuf.Value", "json"
+}
+
+// exactProtoScalar returns the proto3 scalar type for t if protoc-gen-go
+// represents it with exactly the same Go type.
+func exactProtoScalar(t reflect.Type) (string, bool) {
+	scalar, ok := protoScalars[t.Kind()]
+	if !ok || t.PkgPath() != "" || t.Name() != protoGoTypes[scalar] {
+		return "", false
+	}
+	return scalar, true
+}
+
+// protoFieldName converts a JSON field name to a proto3 field name
+// (e.g., "ipAddress" -> "ip_address", "rackID" -> "rack_id").
+func protoFieldName(jsonName string) string {
+	runes := []rune(jsonName)
+	var b strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "field_" + name
+	}
+	return name
+}
+
+// protoGoName returns the Go field name protoc-gen-go generates for a proto
+// field name, following its GoCamelCase rules (e.g., "ip_address" -> "IpAddress").
+func protoGoName(protoName string) string {
+	var b []byte
+	for i := 0; i < len(protoName); i++ {
+		c := protoName[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(protoName) && isASCIILower(protoName[i+1]):
+			// Skip the underscore; the next letter is capitalized
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(protoName) && isASCIILower(protoName[i+1]); i++ {
+				b = append(b, protoName[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isASCIIDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// protoPackage returns the proto package for a resource (e.g., "inventory.device.v1")
+func (g *Generator) protoPackage(resource ResourceMetadata) string {
+	return fmt.Sprintf("%s.%s.v1", strings.ToLower(g.extractProjectName()), strings.ToLower(resource.Name))
+}
+
+// protoTemplateData extends the per-resource template data with gRPC settings
+func (g *Generator) protoTemplateData(resource ResourceMetadata, templateName string) map[string]interface{} {
+	data := g.templateData(resource, templateName)
+	data["ProtoPackage"] = g.protoPackage(resource)
+	data["ProtoFile"] = fmt.Sprintf("%s/v1/%s.proto", strings.ToLower(resource.Name), strings.ToLower(resource.Name))
+	data["GoPackage"] = fmt.Sprintf("%s/pkg/grpc/%spb", g.ModulePath, strings.ToLower(resource.Name))
+	data["GoPackageName"] = strings.ToLower(resource.Name) + "pb"
+	data["StatusFields"] = resource.StatusFields
+
+	usesValue := false
+	for _, field := range append(append([]SpecField{}, resource.SpecFields...), resource.StatusFields...) {
+		if field.ProtoConversion == "json" {
+			usesValue = true
+		}
+	}
+	data["UsesValue"] = usesValue
+	return data
+}
+
+// GenerateProto generates a .proto file per resource under api/proto, with
+// messages for the resource's metadata, spec and status and a CRUD service,
+// plus an api/proto/generate.go that runs protoc via 'go generate'.
+//
+// Spec and status fields are numbered in declaration order, so add new
+// fields at the end of the Go struct to keep the wire format compatible.
+func (g *Generator) GenerateProto() error {
+	fmt.Printf("📜 Generating protobuf definitions...\n")
+
+	for _, resource := range g.Resources {
+		data := g.protoTemplateData(resource, "grpc/resource.proto.tmpl")
+		filename := filepath.Join(ProtoDir, filepath.FromSlash(data["ProtoFile"].(string)))
+		inputs := g.inputsHash(resource)
+		if g.upToDate(filename, inputs) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return fmt.Errorf("failed to create proto directory for %s: %w", resource.Name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := g.Templates["grpcProto"].Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to execute proto template for %s: %w", resource.Name, err)
+		}
+		if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write proto file for %s: %w", resource.Name, err)
+		}
+		g.recordGenerated(filename, inputs)
+
+		fmt.Printf("  ✓ Generated %s\n", filename)
+	}
+
+	var protoFiles []string
+	for _, resource := range g.Resources {
+		protoFiles = append(protoFiles, g.protoTemplateData(resource, "")["ProtoFile"].(string))
+	}
+	data := g.globalTemplateData("grpc/generate.go.tmpl")
+	data["ProtoFiles"] = protoFiles
+
+	return g.writeGoFile("grpcGenerate", filepath.Join(ProtoDir, "generate.go"), data, g.inputsHash(g.Resources...))
+}
+
+// GenerateGRPCServer generates gRPC service implementations for all resources
+// in the server package. They use the same storage layer, mutators,
+// validation and events as the REST handlers. The code depends on the Go
+// packages protoc generates from GenerateProto's output.
+func (g *Generator) GenerateGRPCServer() error {
+	fmt.Printf("📡 Generating gRPC services...\n")
+
+	for _, resource := range g.Resources {
+		filename := filepath.Join(g.OutputDir, fmt.Sprintf("%s_grpc_generated.go", strings.ToLower(resource.Name)))
+		data := g.protoTemplateData(resource, "grpc/server.go.tmpl")
+		if err := g.writeGoFile("grpcServer", filename, data, g.inputsHash(resource)); err != nil {
+			return fmt.Errorf("failed to generate gRPC service for %s: %w", resource.Name, err)
+		}
+	}
+
+	data := g.globalTemplateData("grpc/registration.go.tmpl")
+	var packages []map[string]string
+	for _, resource := range g.Resources {
+		protoData := g.protoTemplateData(resource, "")
+		packages = append(packages, map[string]string{
+			"Name":          resource.Name,
+			"GoPackage":     protoData["GoPackage"].(string),
+			"GoPackageName": protoData["GoPackageName"].(string),
+		})
+	}
+	data["GRPCPackages"] = packages
+
+	return g.writeGoFile("grpcRegistration", filepath.Join(g.OutputDir, "grpc_generated.go"), data, g.inputsHash(g.Resources...))
+}
+
+// writeGoFile renders a Go template to filename unless the cache shows its
+// inputs are unchanged.
+func (g *Generator) writeGoFile(templateName, filename string, data interface{}, inputs string) error {
+	if g.upToDate(filename, inputs) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := g.Templates[templateName].Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute %s template: %w", templateName, err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s code: %w", templateName, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", filename, err)
+	}
+	if err := os.WriteFile(filename, formatted, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	g.recordGenerated(filename, inputs)
+
+	fmt.Printf("  ✓ Generated %s\n", filename)
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/node"
+	"github.com/openchami/fabrica/pkg/codegen/internal/testresources/rack"
+)
+
+type protoPhase string
+
+type protoTestSpec struct {
+	Name     string
+	Count    int
+	Ratio    float64
+	Tags     []string
+	Ports    []int
+	Labels   map[string]string
+	Data     []byte
+	Owner    *string
+	Phase    protoPhase
+	Seen     time.Time
+	Timeout  time.Duration
+	Children []rack.RackSpec
+}
+
+func TestProtoFieldType(t *testing.T) {
+	ownPkg := reflect.TypeOf(protoTestSpec{}).PkgPath()
+	specType := reflect.TypeOf(protoTestSpec{})
+
+	tests := []struct {
+		field      string
+		protoType  string
+		conversion string
+	}{
+		{"Name", "string", "direct"},
+		{"Count", "int64", "cast"},
+		{"Ratio", "double", "direct"},
+		{"Tags", "repeated string", "direct"},
+		{"Ports", "google.protobuf.Value", "json"},
+		{"Labels", "map<string, string>", "direct"},
+		{"Data", "bytes", "direct"},
+		{"Owner", "optional string", "direct"},
+		{"Phase", "string", "cast"},
+		{"Seen", "google.protobuf.Timestamp", "time"},
+		{"Timeout", "google.protobuf.Value", "json"},
+		{"Children", "google.protobuf.Value", "json"},
+	}
+
+	for _, tt := range tests {
+		field, _ := specType.FieldByName(tt.field)
+		protoType, conversion := protoFieldType(field.Type, ownPkg)
+		if protoType != tt.protoType || conversion != tt.conversion {
+			t.Errorf("%s: got (%q, %q), want (%q, %q)", tt.field, protoType, conversion, tt.protoType, tt.conversion)
+		}
+	}
+}
+
+func TestProtoFieldNames(t *testing.T) {
+	tests := []struct {
+		jsonName  string
+		protoName string
+		goName    string
+	}{
+		{"hostname", "hostname", "Hostname"},
+		{"ipAddress", "ip_address", "IpAddress"},
+		{"rackID", "rack_id", "RackId"},
+		{"IPAddress", "ip_address", "IpAddress"},
+		{"slot2Count", "slot2_count", "Slot2Count"},
+		{"bmc-mac", "bmc_mac", "BmcMac"},
+	}
+
+	for _, tt := range tests {
+		protoName := protoFieldName(tt.jsonName)
+		if protoName != tt.protoName {
+			t.Errorf("protoFieldName(%q) = %q, want %q", tt.jsonName, protoName, tt.protoName)
+		}
+		if goName := protoGoName(protoName); goName != tt.goName {
+			t.Errorf("protoGoName(%q) = %q, want %q", protoName, goName, tt.goName)
+		}
+	}
+}
+
+func TestGenerateProtoAndGRPCServer(t *testing.T) {
+	projectDir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	gen := NewGenerator(filepath.Join("cmd", "server"), "main", "example.com/inventory")
+	if err := gen.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if err := gen.RegisterResource(&rack.Rack{}); err != nil {
+		t.Fatalf("RegisterResource(Rack) failed: %v", err)
+	}
+	if err := gen.RegisterResource(&node.Node{}); err != nil {
+		t.Fatalf("RegisterResource(Node) failed: %v", err)
+	}
+
+	if err := gen.GenerateProto(); err != nil {
+		t.Fatalf("GenerateProto failed: %v", err)
+	}
+	if err := gen.GenerateGRPCServer(); err != nil {
+		t.Fatalf("GenerateGRPCServer failed: %v", err)
+	}
+
+	proto, err := os.ReadFile(filepath.Join(ProtoDir, "node", "v1", "node.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package inventory.node.v1;",
+		`option go_package = "example.com/inventory/pkg/grpc/nodepb;nodepb";`,
+		`google.protobuf.Value rack_spec = 1 [json_name = "RackSpec"];`,
+		`string hostname = 2 [json_name = "hostname"];`,
+		`string phase = 1 [json_name = "phase"];`,
+		"rpc CreateNode(CreateNodeRequest) returns (Node);",
+	} {
+		if !strings.Contains(string(proto), want) {
+			t.Errorf("node.proto missing %q:\n%s", want, proto)
+		}
+	}
+
+	server, err := os.ReadFile(filepath.Join("cmd", "server", "rack_grpc_generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"spec.Units = int64(obj.Spec.Units)",
+		"spec.Units = int(msg.Units)",
+		"func (s *rackGRPCServer) DeleteRack(",
+	} {
+		if !strings.Contains(string(server), want) {
+			t.Errorf("rack_grpc_generated.go missing %q", want)
+		}
+	}
+
+	// Update validates the resource like Create and the REST handlers
+	update := string(server)[strings.Index(string(server), "func (s *rackGRPCServer) UpdateRack("):]
+	update = update[:strings.Index(update, "storage.SaveRack(")]
+	for _, want := range []string{"validation.ValidateResource(obj)", `validation.ValidateWithWebhooks(ctx, "Rack", "UPDATE", obj)`} {
+		if !strings.Contains(update, want) {
+			t.Errorf("UpdateRack does not call %s before saving:\n%s", want, update)
+		}
+	}
+
+	for _, path := range []string{
+		filepath.Join(ProtoDir, "generate.go"),
+		filepath.Join("cmd", "server", "grpc_generated.go"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be generated: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package conditional
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// CompressMiddleware compresses responses for clients that accept gzip or
+// deflate, using chi's compressor at the given level (1-9).
+//
+// Every response passing through it carries "Vary: Accept-Encoding", not only
+// the compressed ones: an identity response is still the result of
+// negotiation, and a cache that stored it without Vary would serve it to
+// clients asking for gzip (and the other way round). The header is merged
+// with any Vary values set by handlers, as SetVary does.
+func CompressMiddleware(level int, types ...string) func(http.Handler) http.Handler {
+	compress := middleware.Compress(level, types...)
+	return func(next http.Handler) http.Handler {
+		compressed := compress(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			SetVary(w, "Accept-Encoding")
+			compressed.ServeHTTP(&varyResponseWriter{ResponseWriter: w}, r)
+		})
+	}
+}
+
+// varyResponseWriter folds the Vary values added while the response was
+// built (the compressor appends its own line) into a single header
+type varyResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (vw *varyResponseWriter) WriteHeader(code int) {
+	if !vw.wroteHeader {
+		vw.wroteHeader = true
+		SetVary(vw.ResponseWriter, "Accept-Encoding")
+	}
+	vw.ResponseWriter.WriteHeader(code)
+}
+
+func (vw *varyResponseWriter) Write(data []byte) (int, error) {
+	if !vw.wroteHeader {
+		vw.WriteHeader(http.StatusOK)
+	}
+	return vw.ResponseWriter.Write(data)
+}
+
+// Flush lets streaming handlers flush through the compressor
+func (vw *varyResponseWriter) Flush() {
+	if flusher, ok := vw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (vw *varyResponseWriter) Unwrap() http.ResponseWriter {
+	return vw.ResponseWriter
+}
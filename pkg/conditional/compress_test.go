@@ -0,0 +1,49 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package conditional
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressMiddleware_Vary(t *testing.T) {
+	body := strings.Repeat(`{"name":"test"}`, 100)
+	handler := CompressMiddleware(5)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetVary(w, "Accept", "X-API-Version")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body)) //nolint:errcheck
+	}))
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{name: "compressed", acceptEncoding: "gzip", wantEncoding: "gzip"},
+		{name: "identity", acceptEncoding: "", wantEncoding: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+			if test.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if encoding := w.Header().Get("Content-Encoding"); encoding != test.wantEncoding {
+				t.Errorf("Expected Content-Encoding %q, got %q", test.wantEncoding, encoding)
+			}
+			vary := w.Header().Values("Vary")
+			if len(vary) != 1 || vary[0] != "Accept-Encoding, Accept, X-API-Version" {
+				t.Errorf("Expected a single merged Vary header, got %q", vary)
+			}
+		})
+	}
+}
@@ -9,8 +9,12 @@
 //
 // Usage:
 //
-//	// Add ETags to responses
-//	handler := conditional.ETagMiddleware(myHandler)
+//	// Add ETags to responses, hashed with the configured algorithm
+//	generator, err := conditional.NewETagGenerator("sha256")
+//	if err != nil {
+//	    return err
+//	}
+//	handler := conditional.ETagMiddleware(generator)(myHandler)
 //
 //	// Check conditional request headers
 //	if conditional.CheckConditionalRequest(w, r, etag, lastModified) {
@@ -19,13 +23,18 @@
 package conditional
 
 import (
+	"crypto/md5"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/cespare/xxhash/v2"
 )
 
 // ETagGenerator is a function that generates an ETag for a resource
@@ -37,6 +46,50 @@ func DefaultETagGenerator(data []byte) string {
 	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:16])) // Use first 16 bytes for brevity
 }
 
+// MD5ETagGenerator generates ETags using MD5 hash. ETags are validators, not
+// signatures, so MD5's broken collision resistance does not matter here.
+func MD5ETagGenerator(data []byte) string {
+	hash := md5.Sum(data)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:]))
+}
+
+// XXHashETagGenerator generates ETags using the 64-bit xxHash. It is several
+// times faster than SHA-256 on large responses; its weaker collision
+// resistance is acceptable because ETags are only cache validators.
+func XXHashETagGenerator(data []byte) string {
+	return fmt.Sprintf(`"%016x"`, xxhash.Sum64(data))
+}
+
+// NewETagGenerator returns the ETag generator for a hash algorithm, as set
+// by conditional.etag_algorithm in .fabrica.yaml
+//
+// Supported algorithms:
+//   - "sha256" (or ""): DefaultETagGenerator
+//   - "md5": MD5ETagGenerator
+//   - "xxhash": XXHashETagGenerator
+func NewETagGenerator(algorithm string) (ETagGenerator, error) {
+	switch algorithm {
+	case "", "sha256":
+		return DefaultETagGenerator, nil
+	case "md5":
+		return MD5ETagGenerator, nil
+	case "xxhash":
+		return XXHashETagGenerator, nil
+	default:
+		return nil, fmt.Errorf("unknown ETag algorithm %q (must be sha256, md5 or xxhash)", algorithm)
+	}
+}
+
+// MustETagGenerator is like NewETagGenerator but panics if algorithm is not
+// supported. It is meant for package-level variables in generated code.
+func MustETagGenerator(algorithm string) ETagGenerator {
+	generator, err := NewETagGenerator(algorithm)
+	if err != nil {
+		panic(err)
+	}
+	return generator
+}
+
 // WeakETagGenerator generates weak ETags
 func WeakETagGenerator(data []byte) string {
 	hash := sha256.Sum256(data)
@@ -141,9 +194,10 @@ func SetETag(w http.ResponseWriter, etag string) {
 	w.Header().Set("ETag", etag)
 }
 
-// SetLastModified sets the Last-Modified header on the response
+// SetLastModified sets the Last-Modified header on the response, in the
+// IMF-fixdate format HTTP requires (e.g. "Mon, 02 Jan 2006 15:04:05 GMT")
 func SetLastModified(w http.ResponseWriter, t time.Time) {
-	w.Header().Set("Last-Modified", t.UTC().Format(time.RFC1123))
+	w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
 }
 
 // ETagMiddleware automatically adds ETags to responses
@@ -256,6 +310,66 @@ func GenerateResourceETag(resourceData []byte, resourceVersion string, modifiedT
 	return DefaultETagGenerator([]byte(combined))
 }
 
+// Taggable is implemented by resources that can contribute to a collection ETag
+type Taggable interface {
+	GetUID() string
+	GetModifiedAt() time.Time
+}
+
+// CollectionETag generates a weak ETag for a collection of resources.
+//
+// The ETag is derived from the item count, the most recent modification time,
+// and the member UIDs, so it changes whenever an item is added, removed, or
+// modified. List handlers can compare it against If-None-Match and return
+// 304 Not Modified without serializing the collection.
+func CollectionETag(items []Taggable) string {
+	present := make([]Taggable, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			present = append(present, item)
+		}
+	}
+	sort.Slice(present, func(i, j int) bool { return present[i].GetUID() < present[j].GetUID() })
+
+	builder := NewCollectionETagBuilder()
+	for _, item := range present {
+		builder.Add(item)
+	}
+	return builder.ETag()
+}
+
+// CollectionETagBuilder computes a CollectionETag one item at a time, for
+// responses streamed before the whole collection has been read. Items must be
+// added in UID order; the ETag then equals CollectionETag of the same items.
+type CollectionETagBuilder struct {
+	uids        hash.Hash
+	count       int
+	maxModified time.Time
+}
+
+// NewCollectionETagBuilder returns a builder for the ETag of an empty collection
+func NewCollectionETagBuilder() *CollectionETagBuilder {
+	return &CollectionETagBuilder{uids: sha256.New()}
+}
+
+// Add adds an item to the collection
+func (b *CollectionETagBuilder) Add(item Taggable) {
+	if m := item.GetModifiedAt(); m.After(b.maxModified) {
+		b.maxModified = m
+	}
+	if b.count > 0 {
+		b.uids.Write([]byte{','})
+	}
+	b.uids.Write([]byte(item.GetUID()))
+	b.count++
+}
+
+// ETag returns the weak ETag of the items added so far
+func (b *CollectionETagBuilder) ETag() string {
+	combined := fmt.Sprintf("%d|%d|%x", b.count, b.maxModified.UnixNano(), b.uids.Sum(nil))
+	return WeakETagGenerator([]byte(combined))
+}
+
 // CacheControlOptions defines caching behavior
 type CacheControlOptions struct {
 	MaxAge          int  // Maximum age in seconds
@@ -314,11 +428,47 @@ func SetCacheControl(w http.ResponseWriter, opts CacheControlOptions) {
 
 // VaryHeader adds a Vary header to indicate which request headers affect the response
 func VaryHeader(w http.ResponseWriter, headers ...string) {
-	existing := w.Header().Get("Vary")
-	if existing != "" {
-		headers = append([]string{existing}, headers...)
+	SetVary(w, headers...)
+}
+
+// SetVary merges the given request headers into the response's Vary header.
+//
+// Existing values are preserved and duplicates are dropped (case-insensitively),
+// so handlers and middleware can each declare the headers they negotiate on
+// without clobbering one another. A "*" value overrides everything else.
+func SetVary(w http.ResponseWriter, headers ...string) {
+	var values []string
+	seen := make(map[string]bool)
+
+	add := func(h string) {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			return
+		}
+		key := strings.ToLower(h)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		values = append(values, h)
+	}
+
+	for _, existing := range w.Header().Values("Vary") {
+		for _, h := range strings.Split(existing, ",") {
+			add(h)
+		}
+	}
+	for _, h := range headers {
+		add(h)
+	}
+
+	if len(values) == 0 {
+		return
+	}
+	if seen["*"] {
+		values = []string{"*"}
 	}
-	w.Header().Set("Vary", strings.Join(headers, ", "))
+	w.Header().Set("Vary", strings.Join(values, ", "))
 }
 
 // GetResourceVersion extracts resource version from metadata or computes it
@@ -5,8 +5,10 @@
 package conditional
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -46,6 +48,76 @@ func TestWeakETagGenerator(t *testing.T) {
 	}
 }
 
+func TestNewETagGenerator(t *testing.T) {
+	data := []byte(`{"name":"test"}`)
+
+	for algorithm, want := range map[string]string{
+		"":       DefaultETagGenerator(data),
+		"sha256": DefaultETagGenerator(data),
+		"md5":    MD5ETagGenerator(data),
+		"xxhash": XXHashETagGenerator(data),
+	} {
+		generator, err := NewETagGenerator(algorithm)
+		if err != nil {
+			t.Fatalf("NewETagGenerator(%q) failed: %v", algorithm, err)
+		}
+		if got := generator(data); got != want {
+			t.Errorf("NewETagGenerator(%q) ETag = %s, want %s", algorithm, got, want)
+		}
+	}
+
+	if _, err := NewETagGenerator("crc32"); err == nil {
+		t.Error("Expected an error for an unknown algorithm")
+	}
+}
+
+func TestMD5ETagGenerator(t *testing.T) {
+	// md5("") = d41d8cd98f00b204e9800998ecf8427e
+	if etag := MD5ETagGenerator(nil); etag != `"d41d8cd98f00b204e9800998ecf8427e"` {
+		t.Errorf("MD5ETagGenerator(nil) = %s", etag)
+	}
+}
+
+func TestXXHashETagGenerator(t *testing.T) {
+	// xxh64("") = ef46db3751d8e999
+	if etag := XXHashETagGenerator(nil); etag != `"ef46db3751d8e999"` {
+		t.Errorf("XXHashETagGenerator(nil) = %s", etag)
+	}
+	if XXHashETagGenerator([]byte("a")) == XXHashETagGenerator([]byte("b")) {
+		t.Error("Different data should generate different ETags")
+	}
+}
+
+func TestETagMiddleware_Generator(t *testing.T) {
+	handler := ETagMiddleware(MustETagGenerator("md5"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := w.Header().Get("ETag"); got != MD5ETagGenerator([]byte("hello")) {
+		t.Errorf("ETag = %s, want the MD5 ETag", got)
+	}
+}
+
+func BenchmarkETagGenerators(b *testing.B) {
+	for _, algorithm := range []string{"sha256", "md5", "xxhash"} {
+		generator := MustETagGenerator(algorithm)
+		for _, size := range []int{1 << 10, 1 << 20, 10 << 20} {
+			data := make([]byte, size)
+			for i := range data {
+				data[i] = byte(i)
+			}
+			b.Run(fmt.Sprintf("%s/%dKB", algorithm, size>>10), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for i := 0; i < b.N; i++ {
+					generator(data)
+				}
+			})
+		}
+	}
+}
+
 func TestParseETag(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -256,6 +328,9 @@ func TestSetLastModified(t *testing.T) {
 	if header == "" {
 		t.Error("Last-Modified header should be set")
 	}
+	if !strings.HasSuffix(header, " GMT") {
+		t.Errorf("Last-Modified %q is not in GMT", header)
+	}
 
 	// Parse and verify
 	parsed, err := ParseHTTPDate(header)
@@ -285,6 +360,11 @@ func TestSetCacheControl(t *testing.T) {
 			opts:     CacheControlOptions{Private: true, MaxAge: 3600},
 			expected: "private, max-age=3600",
 		},
+		{
+			name:     "private no-store",
+			opts:     CacheControlOptions{Private: true, NoStore: true},
+			expected: "no-store, private",
+		},
 		{
 			name:     "public with must-revalidate",
 			opts:     CacheControlOptions{Public: true, MustRevalidate: true},
@@ -323,6 +403,53 @@ func TestVaryHeader(t *testing.T) {
 	}
 }
 
+func TestSetVary(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		headers  []string
+		expected string
+	}{
+		{
+			name:     "sets headers",
+			headers:  []string{"Accept", "X-API-Version"},
+			expected: "Accept, X-API-Version",
+		},
+		{
+			name:     "merges with existing",
+			existing: "Accept-Encoding",
+			headers:  []string{"Accept"},
+			expected: "Accept-Encoding, Accept",
+		},
+		{
+			name:     "drops duplicates case-insensitively",
+			existing: "Accept, X-API-Version",
+			headers:  []string{"accept", "X-Api-Version", "Accept-Encoding"},
+			expected: "Accept, X-API-Version, Accept-Encoding",
+		},
+		{
+			name:     "wildcard wins",
+			existing: "Accept",
+			headers:  []string{"*"},
+			expected: "*",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if test.existing != "" {
+				w.Header().Set("Vary", test.existing)
+			}
+			SetVary(w, test.headers...)
+
+			if header := w.Header().Get("Vary"); header != test.expected {
+				t.Errorf("Expected Vary %q, got %q", test.expected, header)
+			}
+		})
+	}
+}
+
 func TestGenerateResourceETag(t *testing.T) {
 	data := []byte(`{"name":"test"}`)
 	version := "v1"
@@ -346,6 +473,85 @@ func TestGenerateResourceETag(t *testing.T) {
 	}
 }
 
+type taggableItem struct {
+	uid      string
+	modified time.Time
+}
+
+func (t taggableItem) GetUID() string           { return t.uid }
+func (t taggableItem) GetModifiedAt() time.Time { return t.modified }
+
+func TestCollectionETag(t *testing.T) {
+	now := time.Now()
+	items := []Taggable{
+		taggableItem{uid: "dev-1", modified: now.Add(-time.Hour)},
+		taggableItem{uid: "dev-2", modified: now},
+	}
+
+	etag1 := CollectionETag(items)
+	if !hasPrefix(etag1, `W/"`) {
+		t.Errorf("Collection ETag should be weak, got %s", etag1)
+	}
+
+	// Order should not matter
+	reordered := []Taggable{items[1], items[0]}
+	if etag2 := CollectionETag(reordered); etag1 != etag2 {
+		t.Error("Same collection in different order should generate same ETag")
+	}
+
+	// Modifying an item should change the ETag
+	modified := []Taggable{items[0], taggableItem{uid: "dev-2", modified: now.Add(time.Second)}}
+	if etag3 := CollectionETag(modified); etag1 == etag3 {
+		t.Error("Modified collection should generate different ETag")
+	}
+
+	// Removing an item should change the ETag
+	if etag4 := CollectionETag(items[1:]); etag1 == etag4 {
+		t.Error("Smaller collection should generate different ETag")
+	}
+
+	// Empty collections still produce a stable ETag
+	if CollectionETag(nil) != CollectionETag([]Taggable{}) {
+		t.Error("Empty collections should generate same ETag")
+	}
+}
+
+func TestCollectionETagBuilder(t *testing.T) {
+	now := time.Now()
+	items := []Taggable{
+		taggableItem{uid: "dev-2", modified: now},
+		taggableItem{uid: "dev-1", modified: now.Add(-time.Hour)},
+		taggableItem{uid: "dev-3", modified: now.Add(-time.Minute)},
+	}
+
+	builder := NewCollectionETagBuilder()
+	if builder.ETag() != CollectionETag(nil) {
+		t.Error("Empty builder should match the empty collection ETag")
+	}
+	for _, i := range []int{1, 0, 2} {
+		builder.Add(items[i])
+	}
+	if got, want := builder.ETag(), CollectionETag(items); got != want {
+		t.Errorf("Builder ETag = %s, want CollectionETag %s", got, want)
+	}
+}
+
+func TestCollectionETag_IfNoneMatch(t *testing.T) {
+	items := []Taggable{taggableItem{uid: "dev-1", modified: time.Now()}}
+	etag := CollectionETag(items)
+
+	req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+	req.Header.Set("If-None-Match", etag)
+	w := httptest.NewRecorder()
+
+	if !CheckConditionalRequest(w, req, etag, time.Time{}) {
+		t.Error("Expected matching collection ETag to short-circuit")
+	}
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", w.Code)
+	}
+}
+
 // Helper functions
 func hasPrefix(s, prefix string) bool {
 	return len(s) >= len(prefix) && s[:len(prefix)] == prefix
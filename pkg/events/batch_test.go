@@ -0,0 +1,92 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func batchOf(t *testing.T, n int) []Event {
+	t.Helper()
+	batch := make([]Event, n)
+	for i := range batch {
+		event, err := NewEvent("io.fabrica.blade.created", "/test", map[string]int{"index": i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		batch[i] = *event
+	}
+	return batch
+}
+
+func TestInMemoryEventBus_PublishBatch(t *testing.T) {
+	ctx := context.Background()
+
+	// Not started, so nothing drains the queue
+	bus := NewInMemoryEventBus(4, 1)
+	if err := bus.Publish(ctx, batchOf(t, 1)[0]); err != nil {
+		t.Fatal(err)
+	}
+	err := bus.PublishBatch(ctx, batchOf(t, 4))
+	if err == nil || !strings.Contains(err.Error(), "room for 3 of 4") {
+		t.Errorf("PublishBatch = %v, want the batch rejected", err)
+	}
+	if len(bus.eventQueue) != 1 {
+		t.Errorf("queue holds %d events after a rejected batch, want 1", len(bus.eventQueue))
+	}
+	if err := bus.PublishBatch(ctx, batchOf(t, 3)); err != nil {
+		t.Errorf("PublishBatch failed: %v", err)
+	}
+
+	received := make(chan Event, 4)
+	if _, err := bus.Subscribe("io.fabrica.blade.*", func(_ context.Context, event Event) error {
+		received <- event
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bus.Start()
+	for i := 0; i < 4; i++ {
+		select {
+		case <-received:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %d of 4 events", i)
+		}
+	}
+
+	bus.Close() //nolint:errcheck
+	if err := bus.PublishBatch(ctx, batchOf(t, 1)); err == nil {
+		t.Error("PublishBatch on a closed bus succeeded")
+	}
+}
+
+// failingBus fails the publish after the first n
+type failingBus struct {
+	recordingBus
+	n int
+}
+
+func (b *failingBus) Publish(ctx context.Context, event Event) error {
+	if len(b.published) == b.n {
+		return errors.New("broker unavailable")
+	}
+	return b.recordingBus.Publish(ctx, event)
+}
+
+func TestPublishEach(t *testing.T) {
+	bus := &failingBus{n: 2}
+	err := PublishEach(context.Background(), bus, batchOf(t, 3))
+	if err == nil || err.Error() != fmt.Sprintf("published 2 of 3 events: %s", "broker unavailable") {
+		t.Errorf("PublishEach = %v", err)
+	}
+	if len(bus.published) != 2 {
+		t.Errorf("published %d events, want 2", len(bus.published))
+	}
+}
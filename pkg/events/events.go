@@ -46,6 +46,10 @@ type EventConfig struct {
 	// Source sets the default source identifier for events
 	// Example: "fabrica-api" or "inventory-system"
 	Source string `json:"source" yaml:"source"`
+
+	// ValidateSchemas checks event data against the schemas registered with
+	// RegisterEventSchema before publishing, failing the publish on mismatch
+	ValidateSchemas bool `json:"validateSchemas" yaml:"validateSchemas"`
 }
 
 // DefaultEventConfig returns sensible defaults for event configuration
@@ -84,6 +88,7 @@ func GetEventConfig() *EventConfig {
 		EventTypePrefix:        globalEventConfig.EventTypePrefix,
 		ConditionEventPrefix:   globalEventConfig.ConditionEventPrefix,
 		Source:                 globalEventConfig.Source,
+		ValidateSchemas:        globalEventConfig.ValidateSchemas,
 	}
 }
 
@@ -228,22 +233,12 @@ func NewConditionEvent(conditionType, status, resourceKind, resourceUID string,
 
 // ResourceKind returns the resource kind extension attribute
 func (e *Event) ResourceKind() string {
-	if val, ok := e.Extensions()["resourcekind"]; ok {
-		if s, ok := val.(string); ok {
-			return s
-		}
-	}
-	return ""
+	return e.extensionString("resourcekind")
 }
 
 // ResourceUID returns the resource UID extension attribute
 func (e *Event) ResourceUID() string {
-	if val, ok := e.Extensions()["resourceuid"]; ok {
-		if s, ok := val.(string); ok {
-			return s
-		}
-	}
-	return ""
+	return e.extensionString("resourceuid")
 }
 
 // EventHandler processes CloudEvents
@@ -257,6 +252,11 @@ type EventBus interface {
 	// Publish a CloudEvent
 	Publish(ctx context.Context, event Event) error
 
+	// PublishBatch publishes a set of related events together. Buses that
+	// can publish them atomically do so; see each implementation for its
+	// delivery semantics, and PublishEach for a non-atomic implementation.
+	PublishBatch(ctx context.Context, events []Event) error
+
 	// Subscribe to events by type pattern (supports wildcards)
 	Subscribe(eventType string, handler EventHandler) (SubscriptionID, error)
 
@@ -267,6 +267,27 @@ type EventBus interface {
 	Close() error
 }
 
+// PublishEach publishes events one at a time with bus.Publish, stopping at
+// the first failure. Buses that cannot publish atomically can implement
+// PublishBatch with it; events before the failed one stay published.
+//
+// Returns:
+//   - error: The first publish error, noting how many events were published
+//
+// Example:
+//
+//	func (b *MyBus) PublishBatch(ctx context.Context, batch []events.Event) error {
+//	    return events.PublishEach(ctx, b, batch)
+//	}
+func PublishEach(ctx context.Context, bus EventBus, events []Event) error {
+	for i, event := range events {
+		if err := bus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("published %d of %d events: %w", i, len(events), err)
+		}
+	}
+	return nil
+}
+
 // GlobalEventBus holds the system-wide event bus instance
 var globalEventBus EventBus
 var busMutex sync.RWMutex
@@ -291,14 +312,16 @@ func GetGlobalEventBus() EventBus {
 // the global event configuration and only publishes if events are enabled.
 //
 // Parameters:
-//   - ctx: Context for the publish operation
+//   - ctx: Context for the publish operation (its trace context and request ID are attached)
 //   - action: The action that occurred (e.g., "created", "updated", "deleted")
 //   - resourceKind: Kind of resource (e.g., "Device", "User")
 //   - resourceUID: Unique identifier of the resource
 //   - data: Event payload data
 //
 // Returns:
-//   - error: If event creation or publishing fails, or if events are disabled
+//   - error: If event creation or publishing fails, or if events are disabled,
+//     or if EventConfig.ValidateSchemas is set and data does not match the
+//     event type's schema (see RegisterEventSchema)
 //
 // Example:
 //
@@ -331,6 +354,10 @@ func PublishResourceEvent(ctx context.Context, action, resourceKind, resourceUID
 	if err != nil {
 		return fmt.Errorf("failed to create resource event: %w", err)
 	}
+	if err := validateIfEnabled(event); err != nil {
+		return err
+	}
+	applyTraceContext(ctx, event)
 
 	return bus.Publish(ctx, *event)
 }
@@ -341,7 +368,7 @@ func PublishResourceEvent(ctx context.Context, action, resourceKind, resourceUID
 // It respects both the general event enable flag and the condition-specific flag.
 //
 // Parameters:
-//   - ctx: Context for the publish operation
+//   - ctx: Context for the publish operation (its trace context and request ID are attached)
 //   - conditionType: The type of condition (e.g., "Ready", "Healthy")
 //   - status: The new condition status ("True", "False", "Unknown")
 //   - resourceKind: Kind of resource (e.g., "Device", "User")
@@ -349,7 +376,9 @@ func PublishResourceEvent(ctx context.Context, action, resourceKind, resourceUID
 //   - data: Condition change data (reason, message, previous status, etc.)
 //
 // Returns:
-//   - error: If event creation or publishing fails, or if condition events are disabled
+//   - error: If event creation or publishing fails, or if condition events are disabled,
+//     or if EventConfig.ValidateSchemas is set and data does not match the
+//     event type's schema (see RegisterEventSchema)
 //
 // Example:
 //
@@ -369,6 +398,10 @@ func PublishConditionEvent(ctx context.Context, conditionType, status, resourceK
 	if err != nil {
 		return fmt.Errorf("failed to create condition event: %w", err)
 	}
+	if err := validateIfEnabled(event); err != nil {
+		return err
+	}
+	applyTraceContext(ctx, event)
 
 	return bus.Publish(ctx, *event)
 }
@@ -422,6 +455,11 @@ type ResourceChangeData struct {
 
 	// Resource contains the full resource data (optional, for create/update events)
 	Resource interface{} `json:"resource,omitempty"`
+
+	// ChangedFields lists the paths of the fields an update or patch changed,
+	// as computed by resource.Diff (e.g. "spec.network.ip"). Empty if the
+	// publisher did not compute them.
+	ChangedFields []string `json:"changedFields,omitempty"`
 }
 
 // PublishResourceCreated publishes a "created" event for a resource
@@ -438,16 +476,24 @@ func PublishResourceCreated(ctx context.Context, resourceKind, resourceUID, reso
 	return PublishResourceEvent(ctx, "created", resourceKind, resourceUID, data)
 }
 
-// PublishResourceUpdated publishes an "updated" event for a resource
-func PublishResourceUpdated(ctx context.Context, resourceKind, resourceUID, resourceName string, resource interface{}, metadata map[string]interface{}) error {
+// PublishResourceUpdated publishes an "updated" event for a resource.
+// changedFields, if given, are the paths of the fields the update changed, so
+// consumers can tell whether the event concerns them without diffing.
+//
+// Example:
+//
+//	changes, _ := resource.Diff(previous, device)
+//	err := events.PublishResourceUpdated(ctx, "Device", uid, name, device, nil, resource.ChangedPaths(changes)...)
+func PublishResourceUpdated(ctx context.Context, resourceKind, resourceUID, resourceName string, resource interface{}, metadata map[string]interface{}, changedFields ...string) error {
 	data := ResourceChangeData{
-		Action:       "updated",
-		ResourceKind: resourceKind,
-		ResourceUID:  resourceUID,
-		ResourceName: resourceName,
-		ChangeTime:   time.Now(),
-		Resource:     resource,
-		Metadata:     metadata,
+		Action:        "updated",
+		ResourceKind:  resourceKind,
+		ResourceUID:   resourceUID,
+		ResourceName:  resourceName,
+		ChangeTime:    time.Now(),
+		Resource:      resource,
+		Metadata:      metadata,
+		ChangedFields: changedFields,
 	}
 
 	return PublishResourceEvent(ctx, "updated", resourceKind, resourceUID, data)
@@ -467,20 +513,22 @@ func PublishResourceDeleted(ctx context.Context, resourceKind, resourceUID, reso
 	return PublishResourceEvent(ctx, "deleted", resourceKind, resourceUID, data)
 }
 
-// PublishResourcePatched publishes a "patched" event for a resource (for partial updates)
-func PublishResourcePatched(ctx context.Context, resourceKind, resourceUID, resourceName string, resource interface{}, patchData map[string]interface{}) error {
+// PublishResourcePatched publishes a "patched" event for a resource (for
+// partial updates). changedFields are as for PublishResourceUpdated.
+func PublishResourcePatched(ctx context.Context, resourceKind, resourceUID, resourceName string, resource interface{}, patchData map[string]interface{}, changedFields ...string) error {
 	metadata := map[string]interface{}{
 		"patchData": patchData,
 	}
 
 	data := ResourceChangeData{
-		Action:       "patched",
-		ResourceKind: resourceKind,
-		ResourceUID:  resourceUID,
-		ResourceName: resourceName,
-		ChangeTime:   time.Now(),
-		Resource:     resource,
-		Metadata:     metadata,
+		Action:        "patched",
+		ResourceKind:  resourceKind,
+		ResourceUID:   resourceUID,
+		ResourceName:  resourceName,
+		ChangeTime:    time.Now(),
+		Resource:      resource,
+		Metadata:      metadata,
+		ChangedFields: changedFields,
 	}
 
 	return PublishResourceEvent(ctx, "patched", resourceKind, resourceUID, data)
@@ -0,0 +1,75 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPublishResourceUpdated_ChangedFields(t *testing.T) {
+	bus := NewInMemoryEventBus(10, 1)
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	previousBus := GetGlobalEventBus()
+	previousConfig := GetEventConfig()
+	SetGlobalEventBus(bus)
+	config := DefaultEventConfig()
+	config.Enabled = true
+	config.LifecycleEventsEnabled = true
+	SetEventConfig(config)
+	t.Cleanup(func() {
+		SetGlobalEventBus(previousBus)
+		SetEventConfig(previousConfig)
+	})
+
+	received := make(chan Event, 3)
+	if _, err := bus.Subscribe("**", func(_ context.Context, event Event) error {
+		received <- event
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	ctx := context.Background()
+	changed := []string{"spec.network.ip", "metadata.labels['app.io/tier']"}
+
+	publishers := map[string]func() error{
+		"updated": func() error {
+			return PublishResourceUpdated(ctx, "Device", "dev-1", "d1", nil, nil, changed...)
+		},
+		"patched": func() error {
+			return PublishResourcePatched(ctx, "Device", "dev-1", "d1", nil, nil, changed...)
+		},
+	}
+	for action, publish := range publishers {
+		if err := publish(); err != nil {
+			t.Fatalf("%s: publish failed: %v", action, err)
+		}
+		published := <-received
+		var data ResourceChangeData
+		if err := published.DataAs(&data); err != nil {
+			t.Fatalf("%s: DataAs failed: %v", action, err)
+		}
+		if data.Action != action || !reflect.DeepEqual(data.ChangedFields, changed) {
+			t.Errorf("%s: action = %q, changedFields = %v", action, data.Action, data.ChangedFields)
+		}
+	}
+
+	// Without changed fields the key is omitted
+	if err := PublishResourceUpdated(ctx, "Device", "dev-1", "d1", nil, nil); err != nil {
+		t.Fatalf("PublishResourceUpdated failed: %v", err)
+	}
+	published := <-received
+	var data map[string]interface{}
+	if err := published.DataAs(&data); err != nil {
+		t.Fatalf("DataAs failed: %v", err)
+	}
+	if _, ok := data["changedFields"]; ok {
+		t.Errorf("changedFields should be omitted when not given, got %v", data["changedFields"])
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Event bus types understood by NewBusFromConfig.
+const (
+	BusTypeMemory = "memory"
+	BusTypeNATS   = "nats"
+	BusTypeKafka  = "kafka"
+	BusTypeNoop   = "noop"
+)
+
+// ErrBusNotRegistered is returned by NewBusFromConfig for the nats and kafka
+// bus types when no package has registered a factory for them. Callers that
+// want the pre-factory behaviour can fall back to the memory bus on it.
+var ErrBusNotRegistered = errors.New("event bus type is not registered")
+
+// BusOptions holds settings passed to event bus constructors.
+type BusOptions struct {
+	// BufferSize is the event queue size for buffered buses (memory)
+	BufferSize int
+
+	// WorkerCount is the number of dispatch goroutines (memory)
+	WorkerCount int
+
+	// URL is the broker address for networked buses (nats, kafka)
+	URL string
+
+	// Retention is the number of recent events kept for replay (memory, 0 disables)
+	Retention int
+
+	// PerResourceOrdering delivers each resource's events in publish order (memory)
+	PerResourceOrdering bool
+}
+
+// BusOption configures BusOptions.
+type BusOption func(*BusOptions)
+
+// WithBufferSize sets the event queue size.
+func WithBufferSize(size int) BusOption {
+	return func(o *BusOptions) { o.BufferSize = size }
+}
+
+// WithWorkerCount sets the number of dispatch goroutines.
+func WithWorkerCount(count int) BusOption {
+	return func(o *BusOptions) { o.WorkerCount = count }
+}
+
+// WithRetention keeps the last n events for Replay and WithReplay subscribers.
+func WithRetention(n int) BusOption {
+	return func(o *BusOptions) { o.Retention = n }
+}
+
+// WithPerResourceOrdering makes the memory bus deliver the events of each
+// resource in the order they were published: events with the same resource
+// UID go to the same worker, which waits for their handlers before taking
+// the next event. Different resources are still handled in parallel.
+func WithPerResourceOrdering() BusOption {
+	return func(o *BusOptions) { o.PerResourceOrdering = true }
+}
+
+// WithURL sets the broker address for networked buses.
+func WithURL(url string) BusOption {
+	return func(o *BusOptions) { o.URL = url }
+}
+
+// BusFactory constructs an event bus from options.
+//
+// The returned bus must be ready to publish (already started/connected).
+type BusFactory func(opts BusOptions) (EventBus, error)
+
+var (
+	busFactoriesMu sync.RWMutex
+	busFactories   = map[string]BusFactory{
+		BusTypeMemory: newMemoryBusFromOptions,
+		BusTypeNoop: func(_ BusOptions) (EventBus, error) {
+			return NewNoopEventBus(), nil
+		},
+	}
+)
+
+// RegisterBusFactory makes an event bus type available to NewBusFromConfig.
+//
+// Fabrica ships memory and noop buses. Broker-backed buses such as nats and
+// kafka live outside the core module to avoid pulling in client libraries;
+// their packages register themselves here, typically from init().
+//
+// Example:
+//
+//	func init() {
+//	    events.RegisterBusFactory(events.BusTypeNATS, func(opts events.BusOptions) (events.EventBus, error) {
+//	        return natsbus.Connect(opts.URL)
+//	    })
+//	}
+func RegisterBusFactory(busType string, factory BusFactory) {
+	busFactoriesMu.Lock()
+	defer busFactoriesMu.Unlock()
+	busFactories[strings.ToLower(busType)] = factory
+}
+
+// NewBusFromConfig creates an event bus by type name.
+//
+// This centralizes bus construction so switching buses is a configuration
+// change (e.g. events.bus_type in .fabrica.yaml). The returned bus is ready
+// to use; there is no need to call Start on it. An empty busType selects the
+// in-memory bus.
+//
+// Parameters:
+//   - busType: One of memory, nats, kafka, noop, or a registered custom type
+//   - opts: Optional settings (buffer size, worker count, broker URL)
+//
+// Returns:
+//   - EventBus: Ready-to-use event bus
+//   - error: ErrBusNotRegistered for nats or kafka without a registered
+//     factory; another error if the bus type is unknown or construction fails
+//
+// Example:
+//
+//	bus, err := events.NewBusFromConfig("memory", events.WithBufferSize(1000), events.WithWorkerCount(10))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer bus.Close()
+func NewBusFromConfig(busType string, opts ...BusOption) (EventBus, error) {
+	if busType == "" {
+		busType = BusTypeMemory
+	}
+	busType = strings.ToLower(busType)
+
+	options := BusOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	busFactoriesMu.RLock()
+	factory, ok := busFactories[busType]
+	busFactoriesMu.RUnlock()
+
+	if !ok {
+		if busType == BusTypeNATS || busType == BusTypeKafka {
+			return nil, fmt.Errorf("%w: %s (import a package that calls RegisterBusFactory)", ErrBusNotRegistered, busType)
+		}
+		return nil, fmt.Errorf("unsupported event bus type: %s (available: %s)", busType, strings.Join(availableBusTypes(), ", "))
+	}
+
+	bus, err := factory(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s event bus: %w", busType, err)
+	}
+	return bus, nil
+}
+
+// newMemoryBusFromOptions creates and starts an in-memory bus.
+func newMemoryBusFromOptions(opts BusOptions) (EventBus, error) {
+	busOpts := []BusOption{WithRetention(opts.Retention)}
+	if opts.PerResourceOrdering {
+		busOpts = append(busOpts, WithPerResourceOrdering())
+	}
+	bus := NewInMemoryEventBus(opts.BufferSize, opts.WorkerCount, busOpts...)
+	bus.Start()
+	return bus, nil
+}
+
+// availableBusTypes lists registered bus types in sorted order.
+func availableBusTypes() []string {
+	busFactoriesMu.RLock()
+	defer busFactoriesMu.RUnlock()
+
+	types := make([]string, 0, len(busFactories))
+	for busType := range busFactories {
+		types = append(types, busType)
+	}
+	sort.Strings(types)
+	return types
+}
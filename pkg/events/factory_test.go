@@ -0,0 +1,146 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNoopEventBus(t *testing.T) {
+	var bus EventBus = NewNoopEventBus()
+	ctx := context.Background()
+
+	called := false
+	id, err := bus.Subscribe("**", func(_ context.Context, _ Event) error {
+		called = true
+		return nil
+	})
+	if err != nil || id == "" {
+		t.Fatalf("Subscribe() = %q, %v; want an ID and no error", id, err)
+	}
+
+	event, err := NewEvent("io.fabrica.device.created", "test", map[string]string{"uid": "dev-1"})
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	if err := bus.Publish(ctx, *event); err != nil {
+		t.Errorf("Publish() error = %v", err)
+	}
+	if called {
+		t.Error("Noop bus should never invoke handlers")
+	}
+
+	if err := bus.Unsubscribe(id); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+	if err := bus.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestNewBusFromConfig(t *testing.T) {
+	tests := []struct {
+		busType string
+		want    interface{}
+		wantErr bool
+	}{
+		{"", &InMemoryEventBus{}, false},
+		{"memory", &InMemoryEventBus{}, false},
+		{"Memory", &InMemoryEventBus{}, false},
+		{"noop", &NoopEventBus{}, false},
+		{"nats", nil, true},
+		{"kafka", nil, true},
+		{"carrier-pigeon", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.busType, func(t *testing.T) {
+			bus, err := NewBusFromConfig(tt.busType, WithBufferSize(10), WithWorkerCount(1))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for bus type %q", tt.busType)
+				}
+				// Only the known broker types can fall back to memory
+				wantNotRegistered := tt.busType == BusTypeNATS || tt.busType == BusTypeKafka
+				if errors.Is(err, ErrBusNotRegistered) != wantNotRegistered {
+					t.Errorf("errors.Is(%v, ErrBusNotRegistered) = %v, want %v", err, !wantNotRegistered, wantNotRegistered)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewBusFromConfig(%q) error = %v", tt.busType, err)
+			}
+			defer bus.Close() //nolint:errcheck
+
+			switch tt.want.(type) {
+			case *InMemoryEventBus:
+				if _, ok := bus.(*InMemoryEventBus); !ok {
+					t.Errorf("Expected *InMemoryEventBus, got %T", bus)
+				}
+			case *NoopEventBus:
+				if _, ok := bus.(*NoopEventBus); !ok {
+					t.Errorf("Expected *NoopEventBus, got %T", bus)
+				}
+			}
+		})
+	}
+}
+
+func TestNewBusFromConfig_MemoryBusIsStarted(t *testing.T) {
+	bus, err := NewBusFromConfig(BusTypeMemory, WithBufferSize(10), WithWorkerCount(1))
+	if err != nil {
+		t.Fatalf("NewBusFromConfig() error = %v", err)
+	}
+	defer bus.Close() //nolint:errcheck
+
+	delivered := make(chan struct{}, 1)
+	if _, err := bus.Subscribe("io.fabrica.*", func(_ context.Context, _ Event) error {
+		delivered <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	event, _ := NewEvent("io.fabrica.test", "test", nil)
+	if err := bus.Publish(context.Background(), *event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	<-delivered
+}
+
+func TestRegisterBusFactory(t *testing.T) {
+	t.Cleanup(func() {
+		busFactoriesMu.Lock()
+		delete(busFactories, BusTypeNATS)
+		busFactoriesMu.Unlock()
+	})
+
+	var gotURL string
+	RegisterBusFactory(BusTypeNATS, func(opts BusOptions) (EventBus, error) {
+		gotURL = opts.URL
+		return NewNoopEventBus(), nil
+	})
+
+	if _, err := NewBusFromConfig("nats", WithURL("nats://localhost:4222")); err != nil {
+		t.Fatalf("NewBusFromConfig() error = %v", err)
+	}
+	if gotURL != "nats://localhost:4222" {
+		t.Errorf("Factory received URL %q", gotURL)
+	}
+
+	RegisterBusFactory("broken", func(_ BusOptions) (EventBus, error) {
+		return nil, errors.New("connection refused")
+	})
+	t.Cleanup(func() {
+		busFactoriesMu.Lock()
+		delete(busFactories, "broken")
+		busFactoriesMu.Unlock()
+	})
+	if _, err := NewBusFromConfig("broken"); err == nil {
+		t.Error("Expected factory error to be returned")
+	}
+}
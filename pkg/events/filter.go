@@ -0,0 +1,80 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// EventFilter reports whether a subscription wants an event. Filters run for
+// every event matching the subscription's type pattern, so they should be
+// cheap and must not block.
+type EventFilter func(event Event) bool
+
+// WithFilter only delivers events for which filter returns true, including
+// replayed events. The in-memory bus evaluates it before starting the
+// handler, so filtered-out events cost no handler goroutine.
+//
+// Example:
+//
+//	id, err := bus.SubscribeWithOptions("io.fabrica.*.deleted", handler,
+//	    events.WithFilter(events.HasExtensions(map[string]string{"resourcekind": "Device"})))
+func WithFilter(filter EventFilter) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.filter = filter
+	}
+}
+
+// SubscribeWithFilter subscribes handler to events matching pattern for
+// which filter returns true. It is SubscribeWithOptions with WithFilter.
+//
+// Example:
+//
+//	// Only deletions of devices
+//	id, err := bus.SubscribeWithFilter("io.fabrica.**", func(e events.Event) bool {
+//	    return e.ResourceKind() == "Device" && e.Extensions()["action"] == "deleted"
+//	}, handler)
+func (b *InMemoryEventBus) SubscribeWithFilter(pattern string, filter EventFilter, handler EventHandler) (SubscriptionID, error) {
+	return b.SubscribeWithOptions(pattern, handler, WithFilter(filter))
+}
+
+// FilterHandler wraps handler so it ignores events for which filter returns
+// false. Use it with buses that cannot filter before delivery, such as
+// broker-backed buses, where the event is still received and decoded.
+//
+// Example:
+//
+//	id, err := bus.Subscribe("io.fabrica.device.*", events.FilterHandler(
+//	    events.HasExtensions(map[string]string{"action": "deleted"}), handler))
+func FilterHandler(filter EventFilter, handler EventHandler) EventHandler {
+	return func(ctx context.Context, event Event) error {
+		if !filter(event) {
+			return nil
+		}
+		return handler(ctx, event)
+	}
+}
+
+// HasExtensions returns a filter matching events whose extension attributes
+// have all of the given values, e.g. {"resourcekind": "Device",
+// "action": "deleted"} for device deletions. Extension names are lowercase
+// in CloudEvents.
+func HasExtensions(attributes map[string]string) EventFilter {
+	return func(event Event) bool {
+		extensions := event.Extensions()
+		for name, want := range attributes {
+			value, ok := extensions[name]
+			if !ok {
+				return false
+			}
+			if got, err := types.ToString(value); err != nil || got != want {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func kindActionEvent(t *testing.T, kind, action string) Event {
+	t.Helper()
+	event, err := NewEvent("io.fabrica."+kind+"."+action, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.SetExtension("resourcekind", kind)
+	event.SetExtension("action", action)
+	return *event
+}
+
+func TestHasExtensions(t *testing.T) {
+	filter := HasExtensions(map[string]string{"resourcekind": "Device", "action": "deleted"})
+
+	if !filter(kindActionEvent(t, "Device", "deleted")) {
+		t.Error("filter rejected a device deletion")
+	}
+	if filter(kindActionEvent(t, "Device", "created")) || filter(kindActionEvent(t, "Rack", "deleted")) {
+		t.Error("filter accepted an event with other attributes")
+	}
+	plain, _ := NewEvent("io.fabrica.device.deleted", "test", nil)
+	if filter(*plain) {
+		t.Error("filter accepted an event without the extensions")
+	}
+}
+
+func TestInMemoryEventBus_SubscribeWithFilter(t *testing.T) {
+	bus := NewInMemoryEventBus(100, 2, WithRetention(10))
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+	ctx := context.Background()
+
+	// Retained before subscribing, so it arrives through replay
+	if err := bus.Publish(ctx, kindActionEvent(t, "Device", "deleted")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(ctx, kindActionEvent(t, "Rack", "deleted")); err != nil {
+		t.Fatal(err)
+	}
+	waitForRetained(t, bus, 2)
+
+	received := make(chan Event, 10)
+	handler := func(_ context.Context, event Event) error {
+		received <- event
+		return nil
+	}
+	filter := HasExtensions(map[string]string{"resourcekind": "Device", "action": "deleted"})
+	if _, err := bus.SubscribeWithOptions("io.fabrica.**", handler, WithReplay(time.Time{}), WithFilter(filter)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bus.SubscribeWithFilter("io.fabrica.**", filter, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, event := range []Event{
+		kindActionEvent(t, "Device", "created"),
+		kindActionEvent(t, "Rack", "deleted"),
+		kindActionEvent(t, "Device", "deleted"),
+	} {
+		if err := bus.Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// One replayed and two live (one per subscription) device deletions
+	for i := 0; i < 3; i++ {
+		select {
+		case event := <-received:
+			if event.ResourceKind() != "Device" || event.Extensions()["action"] != "deleted" {
+				t.Errorf("received filtered-out event %s", event.Type())
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %d of 3 events", i)
+		}
+	}
+	select {
+	case event := <-received:
+		t.Errorf("received unexpected event %s", event.Type())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFilterHandler(t *testing.T) {
+	var calls int
+	handler := FilterHandler(HasExtensions(map[string]string{"action": "deleted"}), func(context.Context, Event) error {
+		calls++
+		return nil
+	})
+	handler(context.Background(), kindActionEvent(t, "Device", "created")) //nolint:errcheck
+	handler(context.Background(), kindActionEvent(t, "Device", "deleted")) //nolint:errcheck
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
@@ -8,8 +8,10 @@ package events
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"strings"
 	"sync"
+	"time"
 )
 
 // InMemoryEventBus implements EventBus with in-memory channels.
@@ -24,9 +26,13 @@ import (
 //   - No durability (events lost on restart)
 //   - Thread-safe
 //   - Support for wildcard subscriptions
+//   - Optional retention of recent events for replay (see WithRetention)
+//   - Optional in-order delivery per resource (see WithPerResourceOrdering)
 type InMemoryEventBus struct {
 	subscribers map[string][]subscription
 	eventQueue  chan Event
+	shards      []chan Event // one queue per worker with WithPerResourceOrdering
+	publishMu   sync.Mutex   // lets PublishBatch check for room before queueing
 	bufferSize  int
 	workerCount int
 	mu          sync.RWMutex
@@ -35,13 +41,16 @@ type InMemoryEventBus struct {
 	wg          sync.WaitGroup
 	nextSubID   int
 	subIDMu     sync.Mutex
+	retained    *eventRing
 }
 
 // subscription represents an event subscription
 type subscription struct {
 	id      SubscriptionID
 	pattern string
+	filter  EventFilter // nil delivers every matching event
 	handler EventHandler
+	ready   <-chan struct{} // closed once replay has finished (nil if no replay)
 }
 
 // NewInMemoryEventBus creates a new in-memory event bus
@@ -49,10 +58,20 @@ type subscription struct {
 // Parameters:
 //   - bufferSize: Size of the event queue buffer (default: 1000)
 //   - workerCount: Number of worker goroutines (default: 10)
+//   - opts: Optional settings; WithRetention enables replay of recent events,
+//     WithPerResourceOrdering delivers each resource's events in order
 //
 // Returns:
 //   - *InMemoryEventBus: Initialized event bus (must call Start())
-func NewInMemoryEventBus(bufferSize, workerCount int) *InMemoryEventBus {
+//
+// Example:
+//
+//	// Keep the last 500 events for Replay and WithReplay subscribers
+//	bus := NewInMemoryEventBus(1000, 10, WithRetention(500))
+//
+//	// Deliver a resource's created event before its updated events
+//	bus := NewInMemoryEventBus(1000, 10, WithPerResourceOrdering())
+func NewInMemoryEventBus(bufferSize, workerCount int, opts ...BusOption) *InMemoryEventBus {
 	if bufferSize <= 0 {
 		bufferSize = 1000
 	}
@@ -60,9 +79,14 @@ func NewInMemoryEventBus(bufferSize, workerCount int) *InMemoryEventBus {
 		workerCount = 10
 	}
 
+	options := BusOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &InMemoryEventBus{
+	bus := &InMemoryEventBus{
 		subscribers: make(map[string][]subscription),
 		eventQueue:  make(chan Event, bufferSize),
 		bufferSize:  bufferSize,
@@ -71,6 +95,18 @@ func NewInMemoryEventBus(bufferSize, workerCount int) *InMemoryEventBus {
 		cancel:      cancel,
 		nextSubID:   1,
 	}
+	if options.Retention > 0 {
+		bus.retained = newEventRing(options.Retention)
+	}
+	if options.PerResourceOrdering {
+		// Split the buffer between the workers' queues
+		shardSize := (bufferSize + workerCount - 1) / workerCount
+		bus.shards = make([]chan Event, workerCount)
+		for i := range bus.shards {
+			bus.shards[i] = make(chan Event, shardSize)
+		}
+	}
+	return bus
 }
 
 // Start begins processing events
@@ -79,49 +115,89 @@ func NewInMemoryEventBus(bufferSize, workerCount int) *InMemoryEventBus {
 // It starts worker goroutines that process events from the queue.
 func (b *InMemoryEventBus) Start() {
 	for i := 0; i < b.workerCount; i++ {
+		queue := b.eventQueue
+		if b.shards != nil {
+			queue = b.shards[i]
+		}
 		b.wg.Add(1)
-		go b.worker()
+		go b.worker(queue)
 	}
 }
 
-// worker processes events from the queue
-func (b *InMemoryEventBus) worker() {
+// worker processes events from queue. With per-resource ordering each worker
+// owns a queue and waits for an event's handlers before taking the next.
+func (b *InMemoryEventBus) worker(queue <-chan Event) {
 	defer b.wg.Done()
 
 	for {
 		select {
 		case <-b.ctx.Done():
 			return
-		case event := <-b.eventQueue:
-			b.dispatch(event)
+		case event := <-queue:
+			handled := b.dispatch(event)
+			if b.shards != nil {
+				handled.Wait()
+			}
 		}
 	}
 }
 
-// dispatch sends an event to all matching subscribers
-func (b *InMemoryEventBus) dispatch(event Event) {
+// dispatch sends an event to all matching subscribers. The returned
+// WaitGroup is done once every handler has returned.
+func (b *InMemoryEventBus) dispatch(event Event) *sync.WaitGroup {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
+	// Retain while holding the subscriber lock so a replaying subscriber sees
+	// each event exactly once: either in its replay or live
+	if b.retained != nil {
+		b.retained.add(event)
+	}
+
 	eventType := event.Type()
+	handled := &sync.WaitGroup{}
 
 	// Find all subscriptions that match this event type
 	for _, subs := range b.subscribers {
 		for _, sub := range subs {
-			if matchesPattern(eventType, sub.pattern) {
+			if matchesPattern(eventType, sub.pattern) && (sub.filter == nil || sub.filter(event)) {
 				// Call handler in a goroutine to avoid blocking
-				go func(h EventHandler) {
+				handled.Add(1)
+				go func(sub subscription) {
+					defer handled.Done()
+					// Live events wait until replayed events have been delivered
+					if sub.ready != nil {
+						<-sub.ready
+					}
 					// Create a new context for this handler
 					ctx := context.Background()
-					if err := h(ctx, event); err != nil {
+					if err := sub.handler(ctx, event); err != nil {
+						handlerErrorsTotal.Inc(event.Type())
 						// Log error but don't stop processing
 						// In production, this should use a proper logger
 						fmt.Printf("Error handling event %s: %v\n", event.ID(), err)
 					}
-				}(sub.handler)
+				}(sub)
 			}
 		}
 	}
+	return handled
+}
+
+// queueFor returns the queue event goes to. With per-resource ordering,
+// events of one resource (or, for events without one, of one source) always
+// go to the same worker.
+func (b *InMemoryEventBus) queueFor(event Event) chan Event {
+	if b.shards == nil {
+		return b.eventQueue
+	}
+	key := event.ResourceUID()
+	if key == "" {
+		key = event.Source()
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key)) //nolint:errcheck
+	return b.shards[h.Sum32()%uint32(len(b.shards))]
 }
 
 // Publish publishes an event to the bus
@@ -135,18 +211,71 @@ func (b *InMemoryEventBus) dispatch(event Event) {
 // Returns:
 //   - error: If the event queue is full or the bus is closed
 func (b *InMemoryEventBus) Publish(ctx context.Context, event Event) error {
+	b.publishMu.Lock()
+	defer b.publishMu.Unlock()
+
 	select {
 	case <-b.ctx.Done():
+		recordPublish(event, publishClosed)
 		return fmt.Errorf("event bus is closed")
 	case <-ctx.Done():
+		recordPublish(event, publishCanceled)
 		return ctx.Err()
-	case b.eventQueue <- event:
+	case b.queueFor(event) <- event:
+		recordPublish(event, publishOK)
 		return nil
 	default:
+		recordPublish(event, publishQueueFull)
 		return fmt.Errorf("event queue is full")
 	}
 }
 
+// PublishBatch queues all of events or none of them
+//
+// The events are queued in order and processed asynchronously like events
+// from Publish; workers may dispatch them concurrently. The batch is
+// rejected as a whole if the queue has no room for all of it, so subscribers
+// never see part of a batch. Delivery is in memory only and lost on restart.
+//
+// Returns:
+//   - error: If the queue cannot take the whole batch or the bus is closed
+func (b *InMemoryEventBus) PublishBatch(ctx context.Context, events []Event) error {
+	b.publishMu.Lock()
+	defer b.publishMu.Unlock()
+
+	result := publishOK
+	defer func() {
+		for _, event := range events {
+			recordPublish(event, result)
+		}
+	}()
+
+	if err := b.ctx.Err(); err != nil {
+		result = publishClosed
+		return fmt.Errorf("event bus is closed")
+	}
+	if err := ctx.Err(); err != nil {
+		result = publishCanceled
+		return err
+	}
+	// Workers only take from the queues, so the room checked here can only
+	// grow until every event is queued
+	needed := make(map[chan Event]int)
+	for _, event := range events {
+		needed[b.queueFor(event)]++
+	}
+	for queue, n := range needed {
+		if free := cap(queue) - len(queue); n > free {
+			result = publishQueueFull
+			return fmt.Errorf("event queue has room for %d of %d events", free, n)
+		}
+	}
+	for _, event := range events {
+		b.queueFor(event) <- event
+	}
+	return nil
+}
+
 // Subscribe subscribes to events matching a pattern
 //
 // Pattern Syntax:
@@ -170,6 +299,30 @@ func (b *InMemoryEventBus) Publish(ctx context.Context, event Event) error {
 //	    return nil
 //	})
 func (b *InMemoryEventBus) Subscribe(eventType string, handler EventHandler) (SubscriptionID, error) {
+	return b.SubscribeWithOptions(eventType, handler)
+}
+
+// SubscribeWithOptions subscribes to events matching a pattern with options.
+//
+// With WithReplay, retained events matching the pattern are delivered to the
+// handler in publish order before any live events. Replay requires the bus
+// to be created with WithRetention. With WithFilter, only events the filter
+// accepts are delivered.
+//
+// Example:
+//
+//	// Catch up on the last 10 minutes of device events, then follow live
+//	id, err := bus.SubscribeWithOptions("io.example.device.*", handler,
+//	    WithReplay(time.Now().Add(-10*time.Minute)))
+func (b *InMemoryEventBus) SubscribeWithOptions(eventType string, handler EventHandler, opts ...SubscribeOption) (SubscriptionID, error) {
+	options := subscribeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.replay && b.retained == nil {
+		return "", fmt.Errorf("replay requires event retention (see WithRetention)")
+	}
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -183,14 +336,34 @@ func (b *InMemoryEventBus) Subscribe(eventType string, handler EventHandler) (Su
 	sub := subscription{
 		id:      id,
 		pattern: eventType,
+		filter:  options.filter,
 		handler: handler,
 	}
 
+	// Snapshot under the subscriber lock so no event is missed or duplicated
+	if options.replay {
+		ready := make(chan struct{})
+		sub.ready = ready
+		backlog := b.retained.since(options.replaySince)
+		go func() {
+			defer close(ready)
+			for _, event := range backlog {
+				if !matchesPattern(event.Type(), eventType) || (options.filter != nil && !options.filter(event)) {
+					continue
+				}
+				if err := handler(b.ctx, event); err != nil {
+					fmt.Printf("Error replaying event %s: %v\n", event.ID(), err)
+				}
+			}
+		}()
+	}
+
 	// Add to subscribers map
 	if b.subscribers[eventType] == nil {
 		b.subscribers[eventType] = []subscription{}
 	}
 	b.subscribers[eventType] = append(b.subscribers[eventType], sub)
+	subscriberCount.Add(1)
 
 	return id, nil
 }
@@ -212,6 +385,7 @@ func (b *InMemoryEventBus) Unsubscribe(id SubscriptionID) error {
 			if sub.id == id {
 				// Remove subscription from slice
 				b.subscribers[pattern] = append(subs[:i], subs[i+1:]...)
+				subscriberCount.Add(-1)
 				return nil
 			}
 		}
@@ -220,6 +394,35 @@ func (b *InMemoryEventBus) Unsubscribe(id SubscriptionID) error {
 	return fmt.Errorf("subscription not found: %s", id)
 }
 
+// Replay delivers retained events published at or after since, in order.
+//
+// Events are passed to handler synchronously; the first handler error stops
+// the replay and is returned. This lets reconcilers that restart catch up on
+// recent changes without subscribing.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - since: Only events with a time at or after this are replayed
+//   - handler: Function called for each retained event
+//
+// Returns:
+//   - error: If retention is disabled, ctx is done, or handler fails
+func (b *InMemoryEventBus) Replay(ctx context.Context, since time.Time, handler EventHandler) error {
+	if b.retained == nil {
+		return fmt.Errorf("replay requires event retention (see WithRetention)")
+	}
+
+	for _, event := range b.retained.since(since) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := handler(ctx, event); err != nil {
+			return fmt.Errorf("replay of event %s failed: %w", event.ID(), err)
+		}
+	}
+	return nil
+}
+
 // Close shuts down the event bus
 //
 // This stops all workers and waits for them to finish processing.
@@ -227,7 +430,18 @@ func (b *InMemoryEventBus) Unsubscribe(id SubscriptionID) error {
 func (b *InMemoryEventBus) Close() error {
 	b.cancel()
 	b.wg.Wait()
+
+	b.mu.Lock()
+	for _, subs := range b.subscribers {
+		subscriberCount.Add(-int64(len(subs)))
+	}
+	b.subscribers = make(map[string][]subscription)
+	b.mu.Unlock()
+
 	close(b.eventQueue)
+	for _, shard := range b.shards {
+		close(shard)
+	}
 	return nil
 }
 
@@ -0,0 +1,59 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"sync/atomic"
+
+	"github.com/openchami/fabrica/pkg/metrics"
+)
+
+// Results of a publish, for the result label of fabrica_event_publish_total
+const (
+	publishOK        = "published"
+	publishQueueFull = "queue_full"
+	publishClosed    = "closed"
+	publishCanceled  = "canceled"
+	publishInvalid   = "invalid"
+)
+
+var (
+	publishTotal = metrics.NewCounterVec(
+		"fabrica_event_publish_total",
+		"Events published by event type and result (published, queue_full, closed, canceled, invalid).",
+		"event_type", "result")
+
+	handlerErrorsTotal = metrics.NewCounterVec(
+		"fabrica_event_handler_errors_total",
+		"Subscriber handler errors by event type.",
+		"event_type")
+
+	subscriberCount atomic.Int64
+)
+
+// MetricsCollectors returns the event metrics:
+//   - fabrica_event_publish_total{event_type, result}: publishes to in-memory
+//     buses, and events rejected by schema validation (result "invalid")
+//   - fabrica_event_handler_errors_total{event_type}: handler errors on
+//     in-memory buses
+//   - fabrica_event_subscribers: subscriptions on open in-memory buses
+//
+// Example:
+//
+//	http.Handle("/metrics", metrics.Handler(events.MetricsCollectors()...))
+func MetricsCollectors() []metrics.Collector {
+	return []metrics.Collector{
+		publishTotal,
+		handlerErrorsTotal,
+		metrics.NewGaugeFunc("fabrica_event_subscribers",
+			"Subscriptions on open in-memory event buses.",
+			func() float64 { return float64(subscriberCount.Load()) }),
+	}
+}
+
+// recordPublish counts one publish of event with result
+func recordPublish(event Event, result string) {
+	publishTotal.Inc(event.Type(), result)
+}
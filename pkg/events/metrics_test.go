@@ -0,0 +1,74 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/metrics"
+)
+
+func TestInMemoryEventBus_Metrics(t *testing.T) {
+	const eventType = "io.fabrica.metricsprobe.created"
+	published := publishTotal.Value(eventType, publishOK)
+	failed := handlerErrorsTotal.Value(eventType)
+	subscribers := subscriberCount.Load()
+
+	bus := NewInMemoryEventBus(100, 1)
+	bus.Start()
+
+	handled := make(chan struct{}, 1)
+	id, err := bus.Subscribe(eventType, func(context.Context, Event) error {
+		handled <- struct{}{}
+		return errors.New("handler failed")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bus.Subscribe("io.fabrica.**", func(context.Context, Event) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if got := subscriberCount.Load() - subscribers; got != 2 {
+		t.Errorf("subscriber gauge rose by %d, want 2", got)
+	}
+
+	event, _ := NewEvent(eventType, "test", nil)
+	if err := bus.Publish(context.Background(), *event); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-handled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	if err := bus.Unsubscribe(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := subscriberCount.Load(); got != subscribers {
+		t.Errorf("subscriber gauge = %d after Close, want %d", got, subscribers)
+	}
+	if got := publishTotal.Value(eventType, publishOK); got != published+1 {
+		t.Errorf("published count = %v, want %v", got, published+1)
+	}
+	if got := handlerErrorsTotal.Value(eventType); got != failed+1 {
+		t.Errorf("handler error count = %v, want %v", got, failed+1)
+	}
+
+	var out strings.Builder
+	if err := metrics.WriteText(&out, MetricsCollectors()...); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `fabrica_event_publish_total{event_type="`+eventType+`",result="published"}`) {
+		t.Errorf("exposition is missing the publish count:\n%s", out.String())
+	}
+}
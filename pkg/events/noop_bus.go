@@ -0,0 +1,50 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// NoopEventBus implements EventBus by discarding everything.
+//
+// Use it when events are disabled so callers always have a non-nil bus and
+// don't need to branch on whether events are configured. Publish succeeds
+// silently, and subscriptions are accepted but never invoked.
+type NoopEventBus struct {
+	nextSubID atomic.Int64
+}
+
+// NewNoopEventBus creates an event bus that drops all events.
+func NewNoopEventBus() *NoopEventBus {
+	return &NoopEventBus{}
+}
+
+// Publish discards the event.
+func (b *NoopEventBus) Publish(_ context.Context, _ Event) error {
+	return nil
+}
+
+// PublishBatch discards the events.
+func (b *NoopEventBus) PublishBatch(_ context.Context, _ []Event) error {
+	return nil
+}
+
+// Subscribe returns a subscription ID; the handler is never called.
+func (b *NoopEventBus) Subscribe(_ string, _ EventHandler) (SubscriptionID, error) {
+	return SubscriptionID(fmt.Sprintf("noop-%d", b.nextSubID.Add(1))), nil
+}
+
+// Unsubscribe does nothing.
+func (b *NoopEventBus) Unsubscribe(_ SubscriptionID) error {
+	return nil
+}
+
+// Close does nothing.
+func (b *NoopEventBus) Close() error {
+	return nil
+}
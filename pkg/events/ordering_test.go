@@ -0,0 +1,116 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func resourceEvent(t *testing.T, uid string, seq int) Event {
+	t.Helper()
+	event, err := NewEvent("io.fabrica.device.updated", "test", map[string]int{"seq": seq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.SetID(fmt.Sprintf("%s-%d", uid, seq))
+	event.SetExtension("resourceuid", uid)
+	return *event
+}
+
+func TestInMemoryEventBus_PerResourceOrdering(t *testing.T) {
+	bus := NewInMemoryEventBus(1000, 4, WithPerResourceOrdering())
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	const perResource = 25
+	uids := []string{"dev-a", "dev-b", "dev-c", "dev-d", "dev-e"}
+
+	var mu sync.Mutex
+	seen := make(map[string][]string)
+	done := make(chan struct{})
+	total := 0
+	if _, err := bus.Subscribe("io.fabrica.device.*", func(_ context.Context, event Event) error {
+		// Jitter so out-of-order delivery would show
+		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
+		mu.Lock()
+		defer mu.Unlock()
+		uid := event.ResourceUID()
+		seen[uid] = append(seen[uid], event.ID())
+		if total++; total == perResource*len(uids) {
+			close(done)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Interleave the resources' events
+	for seq := 0; seq < perResource; seq++ {
+		for _, uid := range uids {
+			if err := bus.Publish(context.Background(), resourceEvent(t, uid, seq)); err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, uid := range uids {
+		for seq, id := range seen[uid] {
+			if want := fmt.Sprintf("%s-%d", uid, seq); id != want {
+				t.Fatalf("%s: event %d is %s, want %s (order %v)", uid, seq, id, want, seen[uid])
+			}
+		}
+	}
+}
+
+func TestInMemoryEventBus_PerResourceOrderingKeepsParallelism(t *testing.T) {
+	bus := NewInMemoryEventBus(100, 4, WithPerResourceOrdering())
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	// Find two resources handled by different workers
+	blocked := resourceEvent(t, "dev-0", 0)
+	var other Event
+	for i := 1; ; i++ {
+		other = resourceEvent(t, fmt.Sprintf("dev-%d", i), 0)
+		if bus.queueFor(other) != bus.queueFor(blocked) {
+			break
+		}
+	}
+
+	release := make(chan struct{})
+	otherHandled := make(chan struct{})
+	if _, err := bus.Subscribe("io.fabrica.device.*", func(_ context.Context, event Event) error {
+		if event.ID() == blocked.ID() {
+			<-release
+		} else {
+			close(otherHandled)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer close(release)
+
+	if err := bus.PublishBatch(context.Background(), []Event{blocked, other}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-otherHandled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("a slow handler for one resource blocked another resource")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	"github.com/openchami/fabrica/pkg/httperror"
+)
+
+// DefaultSignatureHeader is the header NewReceiverHandler reads the request
+// signature from when ReceiverOptions.SignatureHeader is empty.
+const DefaultSignatureHeader = "X-Fabrica-Signature"
+
+// DefaultReceiverMaxBodyBytes is the largest event NewReceiverHandler
+// accepts when ReceiverOptions.MaxBodyBytes is zero.
+const DefaultReceiverMaxBodyBytes = 1 << 20
+
+// ReceiverOptions configure NewReceiverHandler.
+type ReceiverOptions struct {
+	// Secret, when set, requires every request to be signed: the signature
+	// header must hold "sha256=" followed by the hex HMAC-SHA256 of the
+	// request body keyed with Secret. Without it, events are accepted from
+	// anyone who can reach the handler.
+	Secret []byte
+
+	// SignatureHeader is the header holding the signature
+	// (default DefaultSignatureHeader)
+	SignatureHeader string
+
+	// AllowedTypes lists the event types accepted, as patterns with the
+	// wildcards of EventBus.Subscribe (e.g. "com.example.**"). Empty accepts
+	// every type.
+	AllowedTypes []string
+
+	// AllowedSources lists the event sources accepted; an entry ending in *
+	// matches sources starting with the rest (e.g. "/bmc/*"). Empty accepts
+	// every source.
+	AllowedSources []string
+
+	// MaxBodyBytes is the largest request body accepted
+	// (default DefaultReceiverMaxBodyBytes)
+	MaxBodyBytes int64
+}
+
+// NewReceiverHandler returns an HTTP handler that accepts CloudEvents from
+// external systems and publishes them on bus, so subscribers such as
+// reconcilers react to them like to local events.
+//
+// Events may be sent in binary mode (attributes in ce-* headers, data in the
+// body) or structured mode (the whole event as an application/cloudevents+json
+// body). Accepted events are published with the request's context and
+// answered with 202 Accepted; their traceparent extension, if any, is kept.
+//
+// Rejected requests get a problem response:
+//   - 405 for methods other than POST
+//   - 413 for bodies over MaxBodyBytes
+//   - 401 for a missing or wrong signature, when Secret is set
+//   - 400 for requests that are not valid CloudEvents
+//   - 403 for types or sources outside AllowedTypes and AllowedSources
+//   - 503 if publishing fails
+//
+// The signature covers the body only. In binary mode the attributes are in
+// headers, so restrict AllowedTypes and AllowedSources as well when they
+// matter.
+//
+// Example:
+//
+//	r.Method(http.MethodPost, "/events", events.NewReceiverHandler(bus, events.ReceiverOptions{
+//	    Secret:       []byte(os.Getenv("EVENTS_SECRET")),
+//	    AllowedTypes: []string{"com.example.bmc.**"},
+//	}))
+func NewReceiverHandler(bus EventBus, opts ReceiverOptions) http.Handler {
+	if opts.SignatureHeader == "" {
+		opts.SignatureHeader = DefaultSignatureHeader
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultReceiverMaxBodyBytes
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			httperror.WriteError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				httperror.WriteError(w, r, http.StatusRequestEntityTooLarge, fmt.Errorf("event exceeds %d bytes", opts.MaxBodyBytes))
+				return
+			}
+			httperror.WriteError(w, r, http.StatusBadRequest, fmt.Errorf("failed to read event: %w", err))
+			return
+		}
+
+		if len(opts.Secret) > 0 && !validSignature(opts.Secret, body, r.Header.Get(opts.SignatureHeader)) {
+			httperror.WriteError(w, r, http.StatusUnauthorized, fmt.Errorf("missing or invalid %s signature", opts.SignatureHeader))
+			return
+		}
+
+		// The SDK reads binary and structured mode from the request
+		parsed := r.Clone(r.Context())
+		parsed.Body = io.NopCloser(bytes.NewReader(body))
+		ce, err := cehttp.NewEventFromHTTPRequest(parsed)
+		if err != nil {
+			httperror.WriteError(w, r, http.StatusBadRequest, fmt.Errorf("invalid CloudEvent: %w", err))
+			return
+		}
+		if err := ce.Validate(); err != nil {
+			httperror.WriteError(w, r, http.StatusBadRequest, fmt.Errorf("invalid CloudEvent: %w", err))
+			return
+		}
+
+		if !allowedType(opts.AllowedTypes, ce.Type()) {
+			httperror.WriteError(w, r, http.StatusForbidden, fmt.Errorf("event type %q is not accepted", ce.Type()))
+			return
+		}
+		if !allowedSource(opts.AllowedSources, ce.Source()) {
+			httperror.WriteError(w, r, http.StatusForbidden, fmt.Errorf("event source %q is not accepted", ce.Source()))
+			return
+		}
+
+		event := Event{Event: *ce}
+		if err := bus.Publish(ContextFromEvent(r.Context(), event), event); err != nil {
+			httperror.WriteError(w, r, http.StatusServiceUnavailable, fmt.Errorf("failed to publish event: %w", err))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// validSignature reports whether signature is "sha256=" followed by the hex
+// HMAC-SHA256 of body keyed with secret
+func validSignature(secret, body []byte, signature string) bool {
+	sum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sum)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// allowedType reports whether eventType matches one of patterns, or
+// patterns is empty
+func allowedType(patterns []string, eventType string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matchesPattern(eventType, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedSource reports whether source is one of sources, or starts with an
+// entry ending in *, or sources is empty
+func allowedSource(sources []string, source string) bool {
+	if len(sources) == 0 {
+		return true
+	}
+	for _, allowed := range sources {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(source, prefix) {
+				return true
+			}
+		} else if source == allowed {
+			return true
+		}
+	}
+	return false
+}
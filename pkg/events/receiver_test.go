@@ -0,0 +1,155 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordingBus records published events
+type recordingBus struct {
+	NoopEventBus
+	published []Event
+	contexts  []context.Context
+	err       error
+}
+
+func (b *recordingBus) Publish(ctx context.Context, event Event) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, event)
+	b.contexts = append(b.contexts, ctx)
+	return nil
+}
+
+const structuredEvent = `{
+	"specversion": "1.0",
+	"id": "evt-1",
+	"type": "com.example.bmc.power.off",
+	"source": "/bmc/x1000c0s0b0",
+	"datacontenttype": "application/json",
+	"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	"data": {"state": "off"}
+}`
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestReceiverHandler_StructuredMode(t *testing.T) {
+	bus := &recordingBus{}
+	handler := NewReceiverHandler(bus, ReceiverOptions{})
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(structuredEvent))
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", rec.Code, rec.Body)
+	}
+	if len(bus.published) != 1 {
+		t.Fatalf("published %d events, want 1", len(bus.published))
+	}
+	event := bus.published[0]
+	var data map[string]string
+	if err := event.DataAs(&data); err != nil || event.ID() != "evt-1" || event.Type() != "com.example.bmc.power.off" || data["state"] != "off" {
+		t.Errorf("unexpected event: %s", event)
+	}
+	if tc, ok := TraceContextFromContext(bus.contexts[0]); !ok || tc.TraceParent == "" {
+		t.Error("trace context not carried to the publish context")
+	}
+}
+
+func TestReceiverHandler_BinaryMode(t *testing.T) {
+	bus := &recordingBus{}
+	handler := NewReceiverHandler(bus, ReceiverOptions{})
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`{"state":"on"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", "1.0")
+	req.Header.Set("ce-id", "evt-2")
+	req.Header.Set("ce-type", "com.example.bmc.power.on")
+	req.Header.Set("ce-source", "/bmc/x1000c0s0b1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", rec.Code, rec.Body)
+	}
+	if len(bus.published) != 1 || bus.published[0].ID() != "evt-2" || string(bus.published[0].Data()) != `{"state":"on"}` {
+		t.Errorf("unexpected events: %v", bus.published)
+	}
+}
+
+func TestReceiverHandler_Rejections(t *testing.T) {
+	opts := ReceiverOptions{
+		Secret:         []byte("s3cret"),
+		AllowedTypes:   []string{"com.example.bmc.**"},
+		AllowedSources: []string{"/bmc/*"},
+		MaxBodyBytes:   1024,
+	}
+	otherType := strings.Replace(structuredEvent, "com.example.bmc", "com.other", 1)
+	otherSource := strings.Replace(structuredEvent, `"/bmc/`, `"/pdu/`, 1)
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		signature string
+		want      int
+	}{
+		{"accepted", http.MethodPost, structuredEvent, sign("s3cret", structuredEvent), http.StatusAccepted},
+		{"wrong method", http.MethodGet, "", "", http.StatusMethodNotAllowed},
+		{"unsigned", http.MethodPost, structuredEvent, "", http.StatusUnauthorized},
+		{"wrong secret", http.MethodPost, structuredEvent, sign("other", structuredEvent), http.StatusUnauthorized},
+		{"too large", http.MethodPost, strings.Repeat(" ", 2048), "", http.StatusRequestEntityTooLarge},
+		{"not an event", http.MethodPost, `{"hello":"world"}`, sign("s3cret", `{"hello":"world"}`), http.StatusBadRequest},
+		{"type not allowed", http.MethodPost, otherType, sign("s3cret", otherType), http.StatusForbidden},
+		{"source not allowed", http.MethodPost, otherSource, sign("s3cret", otherSource), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := &recordingBus{}
+			req := httptest.NewRequest(tt.method, "/events", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/cloudevents+json")
+			if tt.signature != "" {
+				req.Header.Set(DefaultSignatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			NewReceiverHandler(bus, opts).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if published := len(bus.published) > 0; published != (tt.want == http.StatusAccepted) {
+				t.Errorf("published = %v for status %d", published, rec.Code)
+			}
+		})
+	}
+}
+
+func TestReceiverHandler_PublishError(t *testing.T) {
+	bus := &recordingBus{err: errors.New("bus closed")}
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(structuredEvent))
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	rec := httptest.NewRecorder()
+	NewReceiverHandler(bus, ReceiverOptions{}).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// SubscribeOption configures a subscription made with SubscribeWithOptions.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	replay      bool
+	replaySince time.Time
+	filter      EventFilter
+}
+
+// WithReplay delivers retained events at or after since to the new
+// subscriber before live events. Pass the zero time to replay everything
+// that is retained.
+func WithReplay(since time.Time) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.replay = true
+		o.replaySince = since
+	}
+}
+
+// eventRing retains the most recent events in a fixed-size ring buffer.
+type eventRing struct {
+	events []retainedEvent
+	next   int
+	full   bool
+	mu     sync.Mutex
+}
+
+type retainedEvent struct {
+	event Event
+	at    time.Time
+}
+
+func newEventRing(size int) *eventRing {
+	return &eventRing{events: make([]retainedEvent, size)}
+}
+
+// add retains an event, evicting the oldest when full.
+func (r *eventRing) add(event Event) {
+	at := event.Time()
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[r.next] = retainedEvent{event: event, at: at}
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// since returns retained events at or after t, oldest first.
+func (r *eventRing) since(t time.Time) []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start, count := 0, r.next
+	if r.full {
+		start, count = r.next, len(r.events)
+	}
+
+	result := make([]Event, 0, count)
+	for i := 0; i < count; i++ {
+		retained := r.events[(start+i)%len(r.events)]
+		if !retained.at.Before(t) {
+			result = append(result, retained.event)
+		}
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func publishTestEvents(t *testing.T, bus *InMemoryEventBus, eventType string, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		event, err := NewEvent(eventType, "test", map[string]int{"seq": i})
+		if err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		event.SetID(fmt.Sprintf("%s-%d", eventType, i))
+		if err := bus.Publish(context.Background(), *event); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+}
+
+func waitForRetained(t *testing.T, bus *InMemoryEventBus, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for len(bus.retained.since(time.Time{})) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d retained events", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestEventRing_EvictsOldest(t *testing.T) {
+	ring := newEventRing(3)
+	for i := 0; i < 5; i++ {
+		event, _ := NewEvent("io.fabrica.test", "test", nil)
+		event.SetID(fmt.Sprintf("e%d", i))
+		ring.add(*event)
+	}
+
+	retained := ring.since(time.Time{})
+	if len(retained) != 3 {
+		t.Fatalf("Retained %d events, want 3", len(retained))
+	}
+	for i, want := range []string{"e2", "e3", "e4"} {
+		if retained[i].ID() != want {
+			t.Errorf("retained[%d] = %s, want %s", i, retained[i].ID(), want)
+		}
+	}
+}
+
+func TestInMemoryEventBus_Replay(t *testing.T) {
+	bus := NewInMemoryEventBus(100, 1, WithRetention(10))
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	publishTestEvents(t, bus, "io.fabrica.device.created", 5)
+	waitForRetained(t, bus, 5)
+
+	var ids []string
+	err := bus.Replay(context.Background(), time.Time{}, func(_ context.Context, event Event) error {
+		ids = append(ids, event.ID())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if len(ids) != 5 || ids[0] != "io.fabrica.device.created-0" || ids[4] != "io.fabrica.device.created-4" {
+		t.Errorf("Unexpected replay order: %v", ids)
+	}
+
+	// Future cutoff replays nothing
+	count := 0
+	_ = bus.Replay(context.Background(), time.Now().Add(time.Hour), func(_ context.Context, _ Event) error {
+		count++
+		return nil
+	})
+	if count != 0 {
+		t.Errorf("Replayed %d events after cutoff, want 0", count)
+	}
+
+	// Handler errors stop the replay
+	wantErr := errors.New("boom")
+	if err := bus.Replay(context.Background(), time.Time{}, func(_ context.Context, _ Event) error {
+		return wantErr
+	}); !errors.Is(err, wantErr) {
+		t.Errorf("Expected handler error, got %v", err)
+	}
+}
+
+func TestInMemoryEventBus_ReplayRequiresRetention(t *testing.T) {
+	bus := NewInMemoryEventBus(10, 1)
+
+	if err := bus.Replay(context.Background(), time.Time{}, func(context.Context, Event) error { return nil }); err == nil {
+		t.Error("Expected error when retention is disabled")
+	}
+	if _, err := bus.SubscribeWithOptions("**", func(context.Context, Event) error { return nil }, WithReplay(time.Time{})); err == nil {
+		t.Error("Expected error subscribing with replay when retention is disabled")
+	}
+}
+
+func TestInMemoryEventBus_SubscribeWithReplay(t *testing.T) {
+	bus := NewInMemoryEventBus(100, 1, WithRetention(10))
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	publishTestEvents(t, bus, "io.fabrica.device.created", 3)
+	publishTestEvents(t, bus, "io.fabrica.user.created", 2)
+	waitForRetained(t, bus, 5)
+
+	var mu sync.Mutex
+	var ids []string
+	done := make(chan struct{})
+	_, err := bus.SubscribeWithOptions("io.fabrica.device.*", func(_ context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, event.ID())
+		if len(ids) == 4 {
+			close(done)
+		}
+		return nil
+	}, WithReplay(time.Time{}))
+	if err != nil {
+		t.Fatalf("SubscribeWithOptions failed: %v", err)
+	}
+
+	// One live event after subscribing
+	live, _ := NewEvent("io.fabrica.device.updated", "test", nil)
+	live.SetID("live")
+	if err := bus.Publish(context.Background(), *live); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for replayed and live events")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"io.fabrica.device.created-0",
+		"io.fabrica.device.created-1",
+		"io.fabrica.device.created-2",
+		"live",
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("ids[%d] = %s, want %s (replayed events must precede live ones)", i, ids[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,535 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidEventData is matched by errors.Is for errors returned when event
+// data does not match the schema registered for its type
+var ErrInvalidEventData = errors.New("event data does not match schema")
+
+// ResourceChangeDataSchema is the JSON Schema of ResourceChangeData, the
+// data of the events published by PublishResourceCreated and the other
+// PublishResource* helpers
+var ResourceChangeDataSchema = []byte(`{
+	"type": "object",
+	"required": ["action", "resourceKind", "resourceUID", "changeTime"],
+	"properties": {
+		"action": {"type": "string", "minLength": 1},
+		"resourceKind": {"type": "string", "minLength": 1},
+		"resourceUID": {"type": "string", "minLength": 1},
+		"resourceName": {"type": "string"},
+		"changeTime": {"type": "string", "format": "date-time"},
+		"metadata": {"type": "object"},
+		"resource": {"type": "object"},
+		"changedFields": {"type": "array", "items": {"type": "string"}}
+	},
+	"additionalProperties": false
+}`)
+
+// ConditionChangeDataSchema is the JSON Schema of ConditionChangeData, the
+// data of condition change events
+var ConditionChangeDataSchema = []byte(`{
+	"type": "object",
+	"required": ["conditionType", "status", "transitionTime", "resourceKind", "resourceUID"],
+	"properties": {
+		"conditionType": {"type": "string", "minLength": 1},
+		"status": {"enum": ["True", "False", "Unknown"]},
+		"previousStatus": {"type": "string"},
+		"reason": {"type": "string"},
+		"message": {"type": "string"},
+		"transitionTime": {"type": "string", "format": "date-time"},
+		"resourceKind": {"type": "string", "minLength": 1},
+		"resourceUID": {"type": "string", "minLength": 1}
+	},
+	"additionalProperties": false
+}`)
+
+// registeredSchema is a schema registered for an event type pattern
+type registeredSchema struct {
+	pattern string
+	schema  *jsonSchema
+}
+
+var (
+	eventSchemas   []registeredSchema
+	eventSchemasMu sync.RWMutex
+
+	builtinResourceSchema  = mustCompileSchema(ResourceChangeDataSchema)
+	builtinConditionSchema = mustCompileSchema(ConditionChangeDataSchema)
+)
+
+// RegisterEventSchema registers a JSON Schema that the data of events of
+// eventType must match. eventType may be a pattern with the wildcards of
+// EventBus.Subscribe (e.g. "io.fabrica.device.*"); an event is checked
+// against every schema whose pattern matches its type. Registering a pattern
+// again replaces its schema.
+//
+// Schemas are only checked when EventConfig.ValidateSchemas is set, by
+// PublishResourceEvent, PublishConditionEvent and ValidateEvent.
+// Resource lifecycle events and condition events whose type has no
+// registered schema are checked against ResourceChangeDataSchema and
+// ConditionChangeDataSchema.
+//
+// The supported keywords are type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, minLength, maxLength,
+// pattern, format (date-time only), minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, allOf, anyOf and oneOf; other keywords, including $ref,
+// are ignored.
+//
+// Returns:
+//   - nil on success
+//   - an error if schema is not valid JSON or holds an invalid pattern
+//
+// Example:
+//
+//	err := events.RegisterEventSchema("io.fabrica.bmc.poweroff", []byte(`{
+//	    "type": "object",
+//	    "required": ["xname"],
+//	    "properties": {"xname": {"type": "string"}}
+//	}`))
+func RegisterEventSchema(eventType string, schema []byte) error {
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema for %s: %w", eventType, err)
+	}
+
+	eventSchemasMu.Lock()
+	defer eventSchemasMu.Unlock()
+	for i := range eventSchemas {
+		if eventSchemas[i].pattern == eventType {
+			eventSchemas[i].schema = compiled
+			return nil
+		}
+	}
+	eventSchemas = append(eventSchemas, registeredSchema{pattern: eventType, schema: compiled})
+	return nil
+}
+
+// ValidateEvent checks the data of event against the schemas registered for
+// its type, or the built-in schema for resource and condition events, even
+// when EventConfig.ValidateSchemas is not set. Events without a schema pass.
+//
+// Returns:
+//   - nil if the data matches
+//   - an error matching ErrInvalidEventData listing the mismatches otherwise
+func ValidateEvent(event *Event) error {
+	schemas := schemasFor(event.Type())
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	var data interface{}
+	if raw := event.Data(); len(raw) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&data); err != nil {
+			return fmt.Errorf("%w: %s data is not JSON: %v", ErrInvalidEventData, event.Type(), err)
+		}
+	}
+
+	var problems []string
+	for _, schema := range schemas {
+		problems = append(problems, schema.validate(data, "")...)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidEventData, event.Type(), strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateIfEnabled runs ValidateEvent when EventConfig.ValidateSchemas is set
+func validateIfEnabled(event *Event) error {
+	if !GetEventConfig().ValidateSchemas {
+		return nil
+	}
+	err := ValidateEvent(event)
+	if err != nil {
+		recordPublish(*event, publishInvalid)
+	}
+	return err
+}
+
+// schemasFor returns the registered schemas matching eventType or, if there
+// are none, the built-in schema for the event types of the current config
+func schemasFor(eventType string) []*jsonSchema {
+	eventSchemasMu.RLock()
+	var schemas []*jsonSchema
+	for _, registered := range eventSchemas {
+		if matchesPattern(eventType, registered.pattern) {
+			schemas = append(schemas, registered.schema)
+		}
+	}
+	eventSchemasMu.RUnlock()
+	if len(schemas) > 0 {
+		return schemas
+	}
+
+	config := GetEventConfig()
+	if rest, ok := strings.CutPrefix(eventType, config.EventTypePrefix+"."); ok {
+		// <prefix>.<kind>.<action>
+		if parts := strings.Split(rest, "."); len(parts) == 2 {
+			switch parts[1] {
+			case "created", "updated", "patched", "deleted":
+				return []*jsonSchema{builtinResourceSchema}
+			}
+		}
+	}
+	if rest, ok := strings.CutPrefix(eventType, config.ConditionEventPrefix+"."); ok && !strings.Contains(rest, ".") {
+		return []*jsonSchema{builtinConditionSchema}
+	}
+	return nil
+}
+
+// jsonSchema is a compiled JSON Schema, limited to the keywords listed on
+// RegisterEventSchema
+type jsonSchema struct {
+	// always is set for the boolean schemas true and false
+	always *bool
+
+	Type                 schemaTypes            `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Const                json.RawMessage        `json:"const"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+	Format               string                 `json:"format"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum"`
+	AllOf                []*jsonSchema          `json:"allOf"`
+	AnyOf                []*jsonSchema          `json:"anyOf"`
+	OneOf                []*jsonSchema          `json:"oneOf"`
+
+	pattern  *regexp.Regexp
+	constVal interface{}
+}
+
+// schemaTypes is the type keyword, a single type name or a list of them
+type schemaTypes []string
+
+// UnmarshalJSON accepts "string" as well as ["string", "null"]
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = list
+	return nil
+}
+
+// UnmarshalJSON decodes a schema object or one of the boolean schemas
+func (s *jsonSchema) UnmarshalJSON(data []byte) error {
+	var always bool
+	if err := json.Unmarshal(data, &always); err == nil {
+		s.always = &always
+		return nil
+	}
+	type plain jsonSchema
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // enum values are compared as json.Number
+	return decoder.Decode((*plain)(s))
+}
+
+func compileSchema(data []byte) (*jsonSchema, error) {
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func mustCompileSchema(data []byte) *jsonSchema {
+	schema, err := compileSchema(data)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// compile prepares the patterns and const values of s and its subschemas
+func (s *jsonSchema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	if s.Const != nil {
+		decoder := json.NewDecoder(bytes.NewReader(s.Const))
+		decoder.UseNumber()
+		if err := decoder.Decode(&s.constVal); err != nil {
+			return fmt.Errorf("invalid const: %w", err)
+		}
+	}
+
+	subschemas := append(append(append([]*jsonSchema{s.AdditionalProperties, s.Items}, s.AllOf...), s.AnyOf...), s.OneOf...)
+	for _, property := range s.Properties {
+		subschemas = append(subschemas, property)
+	}
+	for _, sub := range subschemas {
+		if sub == nil {
+			continue
+		}
+		if err := sub.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate returns the ways value, found at path, does not match s
+func (s *jsonSchema) validate(value interface{}, path string) []string {
+	if s.always != nil {
+		if *s.always {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s is not allowed", displayPath(path))}
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(value) {
+		return []string{fmt.Sprintf("%s must be of type %s", displayPath(path), strings.Join(s.Type, " or "))}
+	}
+
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, displayPath(path)+" "+fmt.Sprintf(format, args...))
+	}
+
+	if s.Enum != nil && !containsJSON(s.Enum, value) {
+		add("must be one of %s", formatJSONList(s.Enum))
+	}
+	if s.Const != nil && !equalJSON(s.constVal, value) {
+		add("must be %s", s.Const)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s is required", displayPath(joinPath(path, name))))
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				problems = append(problems, property.validate(v[name], joinPath(path, name))...)
+			} else if s.AdditionalProperties != nil {
+				if s.AdditionalProperties.always != nil && !*s.AdditionalProperties.always {
+					problems = append(problems, fmt.Sprintf("%s is not an allowed property", displayPath(joinPath(path, name))))
+				} else {
+					problems = append(problems, s.AdditionalProperties.validate(v[name], joinPath(path, name))...)
+				}
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			add("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			add("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				problems = append(problems, s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case string:
+		length := len([]rune(v))
+		if s.MinLength != nil && length < *s.MinLength {
+			add("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			add("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			add("must match %q", s.Pattern)
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				add("must be an RFC 3339 date-time")
+			}
+		}
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			add("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			add("must be at most %v", *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && n <= *s.ExclusiveMinimum {
+			add("must be greater than %v", *s.ExclusiveMinimum)
+		}
+		if s.ExclusiveMaximum != nil && n >= *s.ExclusiveMaximum {
+			add("must be less than %v", *s.ExclusiveMaximum)
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		problems = append(problems, sub.validate(value, path)...)
+	}
+	if len(s.AnyOf) > 0 {
+		matched := 0
+		for _, sub := range s.AnyOf {
+			if len(sub.validate(value, path)) == 0 {
+				matched++
+			}
+		}
+		if matched == 0 {
+			add("must match at least one schema of anyOf")
+		}
+	}
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sub := range s.OneOf {
+			if len(sub.validate(value, path)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			add("must match exactly one schema of oneOf, matched %d", matched)
+		}
+	}
+	return problems
+}
+
+// matches reports whether value has one of the types t lists
+func (t schemaTypes) matches(value interface{}) bool {
+	for _, name := range t {
+		switch v := value.(type) {
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case json.Number:
+			if name == "number" {
+				return true
+			}
+			if name == "integer" {
+				if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+					return true
+				}
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// equalJSON compares decoded JSON values, numbers by value
+func equalJSON(a, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, aErr := av.Float64()
+		bf, bErr := bv.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalJSON(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !equalJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if equalJSON(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatJSONList(values []interface{}) string {
+	encoded, _ := json.Marshal(values)
+	return string(encoded)
+}
+
+// joinPath appends a property name to a dotted path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// displayPath names the value at path in error messages
+func displayPath(path string) string {
+	if path == "" {
+		return "data"
+	}
+	return path
+}
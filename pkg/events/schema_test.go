@@ -0,0 +1,170 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useSchemaConfig enables events with schema validation on a recording bus
+// and restores the previous configuration and schemas when t ends
+func useSchemaConfig(t *testing.T, validate bool) *recordingBus {
+	t.Helper()
+	bus := &recordingBus{}
+	previousBus := GetGlobalEventBus()
+	previousConfig := GetEventConfig()
+	eventSchemasMu.Lock()
+	previousSchemas := eventSchemas
+	eventSchemas = nil
+	eventSchemasMu.Unlock()
+
+	config := DefaultEventConfig()
+	config.Enabled = true
+	config.ValidateSchemas = validate
+	SetEventConfig(config)
+	SetGlobalEventBus(bus)
+	t.Cleanup(func() {
+		SetGlobalEventBus(previousBus)
+		SetEventConfig(previousConfig)
+		eventSchemasMu.Lock()
+		eventSchemas = previousSchemas
+		eventSchemasMu.Unlock()
+	})
+	return bus
+}
+
+func TestRegisterEventSchema(t *testing.T) {
+	bus := useSchemaConfig(t, true)
+	err := RegisterEventSchema("io.fabrica.bmc.*", []byte(`{
+		"type": "object",
+		"required": ["xname", "state"],
+		"properties": {
+			"xname": {"type": "string", "pattern": "^x[0-9]+"},
+			"state": {"enum": ["on", "off"]},
+			"watts": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2}
+		},
+		"additionalProperties": false
+	}`))
+	if err != nil {
+		t.Fatalf("RegisterEventSchema failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		problem string
+	}{
+		{"valid", `{"xname": "x1000", "state": "on", "watts": 350, "tags": ["a"]}`, ""},
+		{"missing property", `{"xname": "x1000"}`, "state is required"},
+		{"not in enum", `{"xname": "x1000", "state": "dim"}`, `state must be one of ["on","off"]`},
+		{"pattern", `{"xname": "node1", "state": "on"}`, `xname must match "^x[0-9]+"`},
+		{"not an integer", `{"xname": "x1", "state": "on", "watts": 1.5}`, "watts must be of type integer"},
+		{"below minimum", `{"xname": "x1", "state": "on", "watts": -1}`, "watts must be at least 0"},
+		{"item type", `{"xname": "x1", "state": "on", "tags": [1]}`, "tags[0] must be of type string"},
+		{"too many items", `{"xname": "x1", "state": "on", "tags": ["a", "b", "c"]}`, "tags must have at most 2 items"},
+		{"additional property", `{"xname": "x1", "state": "on", "extra": true}`, "extra is not an allowed property"},
+		{"wrong type", `["x1"]`, "data must be of type object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := NewEvent("io.fabrica.bmc.power", "/bmc", []byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ValidateEvent(event)
+			if tt.problem == "" {
+				if err != nil {
+					t.Errorf("ValidateEvent failed: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidEventData) || !strings.Contains(err.Error(), tt.problem) {
+				t.Errorf("ValidateEvent error = %v, want one containing %q", err, tt.problem)
+			}
+		})
+	}
+
+	if event, _ := NewEvent("io.other.power", "/bmc", []byte(`{}`)); ValidateEvent(event) != nil {
+		t.Error("an event type without a schema should pass")
+	}
+	if len(bus.published) != 0 {
+		t.Error("ValidateEvent should not publish")
+	}
+
+	if err := RegisterEventSchema("io.fabrica.bad", []byte(`{"pattern": "("}`)); err == nil {
+		t.Error("RegisterEventSchema accepted an invalid pattern")
+	}
+	if err := RegisterEventSchema("io.fabrica.bad", []byte(`{"type": 1}`)); err == nil {
+		t.Error("RegisterEventSchema accepted an invalid type")
+	}
+}
+
+func TestPublishResourceEvent_SchemaValidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("built-in schema", func(t *testing.T) {
+		bus := useSchemaConfig(t, true)
+		if err := PublishResourceCreated(ctx, "Device", "dev-1", "dev", map[string]string{"uid": "dev-1"}); err != nil {
+			t.Fatalf("PublishResourceCreated failed: %v", err)
+		}
+		err := PublishResourceEvent(ctx, "created", "Device", "dev-1", map[string]string{"uid": "dev-1"})
+		if !errors.Is(err, ErrInvalidEventData) {
+			t.Errorf("publishing a bare resource as change data = %v, want ErrInvalidEventData", err)
+		}
+		if len(bus.published) != 1 {
+			t.Errorf("published %d events, want 1", len(bus.published))
+		}
+	})
+
+	t.Run("registered schema replaces the built-in one", func(t *testing.T) {
+		bus := useSchemaConfig(t, true)
+		if err := RegisterEventSchema("io.fabrica.device.created", []byte(`{"required": ["uid"]}`)); err != nil {
+			t.Fatal(err)
+		}
+		if err := PublishResourceEvent(ctx, "created", "Device", "dev-1", map[string]string{"uid": "dev-1"}); err != nil {
+			t.Errorf("PublishResourceEvent failed: %v", err)
+		}
+		if len(bus.published) != 1 {
+			t.Errorf("published %d events, want 1", len(bus.published))
+		}
+	})
+
+	t.Run("condition events", func(t *testing.T) {
+		bus := useSchemaConfig(t, true)
+		data := ConditionChangeData{
+			ConditionType:  "Ready",
+			Status:         "Maybe",
+			TransitionTime: time.Now(),
+			ResourceKind:   "Device",
+			ResourceUID:    "dev-1",
+		}
+		err := PublishConditionEvent(ctx, "Ready", "Maybe", "Device", "dev-1", data)
+		if !errors.Is(err, ErrInvalidEventData) || !strings.Contains(err.Error(), "status must be one of") {
+			t.Errorf("PublishConditionEvent = %v, want a status mismatch", err)
+		}
+		data.Status = "True"
+		if err := PublishConditionEvent(ctx, "Ready", "True", "Device", "dev-1", data); err != nil {
+			t.Errorf("PublishConditionEvent failed: %v", err)
+		}
+		if len(bus.published) != 1 {
+			t.Errorf("published %d events, want 1", len(bus.published))
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		bus := useSchemaConfig(t, false)
+		if err := PublishResourceEvent(ctx, "created", "Device", "dev-1", map[string]string{"uid": "dev-1"}); err != nil {
+			t.Errorf("PublishResourceEvent failed: %v", err)
+		}
+		if len(bus.published) != 1 {
+			t.Errorf("published %d events, want 1", len(bus.published))
+		}
+	})
+}
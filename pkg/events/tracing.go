@@ -0,0 +1,177 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/logging"
+)
+
+// CloudEvents distributed tracing extension attributes.
+//
+// See https://github.com/cloudevents/spec/blob/main/cloudevents/extensions/distributed-tracing.md
+const (
+	ExtensionTraceParent = "traceparent"
+	ExtensionTraceState  = "tracestate"
+)
+
+// ExtensionRequestID carries the ID of the HTTP request that published an
+// event, as attached by logging.Middleware, so consumers can log with it.
+const ExtensionRequestID = "requestid"
+
+// TraceContext is a W3C Trace Context (https://www.w3.org/TR/trace-context/)
+// carried from the request that published an event to its consumers.
+type TraceContext struct {
+	// TraceParent is the W3C traceparent header value,
+	// e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	TraceParent string
+
+	// TraceState is the optional vendor-specific tracestate header value
+	TraceState string
+}
+
+// IsValid reports whether TraceParent is a well-formed W3C traceparent.
+func (tc TraceContext) IsValid() bool {
+	return validTraceParent(tc.TraceParent)
+}
+
+type traceContextKey struct{}
+
+// WithTraceContext attaches a W3C trace context to ctx.
+//
+// Events published through PublishResourceEvent and PublishConditionEvent
+// (and the PublishResource* helpers) with the returned context carry the
+// trace as CloudEvents distributed tracing extensions. Fabrica does not
+// depend on OpenTelemetry; bridge the active span with the W3C propagator:
+//
+//	carrier := propagation.MapCarrier{}
+//	propagation.TraceContext{}.Inject(ctx, carrier)
+//	ctx = events.WithTraceContext(ctx, carrier.Get("traceparent"), carrier.Get("tracestate"))
+//
+// An empty or malformed traceparent leaves ctx unchanged.
+func WithTraceContext(ctx context.Context, traceparent, tracestate string) context.Context {
+	tc := TraceContext{TraceParent: traceparent, TraceState: tracestate}
+	if !tc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the trace context attached by WithTraceContext.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// SetTraceContext sets the distributed tracing extensions on the event.
+// It is a no-op when tc is not valid.
+func (e *Event) SetTraceContext(tc TraceContext) {
+	if !tc.IsValid() {
+		return
+	}
+	e.SetExtension(ExtensionTraceParent, tc.TraceParent)
+	if tc.TraceState != "" {
+		e.SetExtension(ExtensionTraceState, tc.TraceState)
+	}
+}
+
+// TraceContext returns the distributed tracing extensions carried by the event.
+func (e *Event) TraceContext() (TraceContext, bool) {
+	tc := TraceContext{
+		TraceParent: e.extensionString(ExtensionTraceParent),
+		TraceState:  e.extensionString(ExtensionTraceState),
+	}
+	if !tc.IsValid() {
+		return TraceContext{}, false
+	}
+	return tc, true
+}
+
+// RequestID returns the ID of the request that published the event, or "".
+func (e *Event) RequestID() string {
+	return e.extensionString(ExtensionRequestID)
+}
+
+// ContextFromEvent returns ctx with the event's trace context and request ID
+// attached, so consumers such as reconcilers can continue the publishing
+// request's trace and log with its ID through logging.FromContext. Anything
+// the event does not carry is left unset.
+//
+// Example:
+//
+//	bus.Subscribe("io.fabrica.device.*", func(ctx context.Context, event events.Event) error {
+//	    ctx = events.ContextFromEvent(ctx, event)
+//	    logging.FromContext(ctx).Info("device changed", "uid", event.ResourceUID())
+//	    tc, _ := events.TraceContextFromContext(ctx)
+//	    // extract tc.TraceParent with your tracer's propagator and start a child span
+//	    return nil
+//	})
+func ContextFromEvent(ctx context.Context, event Event) context.Context {
+	ctx = logging.WithRequestID(ctx, event.RequestID())
+	tc, ok := event.TraceContext()
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// applyTraceContext copies the trace context and request ID from ctx onto
+// the event.
+func applyTraceContext(ctx context.Context, event *Event) {
+	if tc, ok := TraceContextFromContext(ctx); ok {
+		event.SetTraceContext(tc)
+	}
+	if id, ok := logging.RequestIDFromContext(ctx); ok {
+		event.SetExtension(ExtensionRequestID, id)
+	}
+}
+
+// extensionString returns a string extension attribute or "".
+func (e *Event) extensionString(name string) string {
+	if val, ok := e.Extensions()[name]; ok {
+		if s, ok := val.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// validTraceParent checks the version-trace_id-parent_id-flags format.
+func validTraceParent(traceparent string) bool {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) < 4 {
+		return false
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	if len(version) != 2 || !isLowerHex(version) || version == "ff" {
+		return false
+	}
+	// Version 00 has exactly four fields; later versions may append more
+	if version == "00" && len(parts) != 4 {
+		return false
+	}
+	if len(traceID) != 32 || !isLowerHex(traceID) || strings.Trim(traceID, "0") == "" {
+		return false
+	}
+	if len(parentID) != 16 || !isLowerHex(parentID) || strings.Trim(parentID, "0") == "" {
+		return false
+	}
+	return len(flags) == 2 && isLowerHex(flags)
+}
+
+func isLowerHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/logging"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestValidTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceparent string
+		want        bool
+	}{
+		{"valid", testTraceParent, true},
+		{"future version with extra field", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true},
+		{"empty", "", false},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-01", false},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"zero parent id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"short trace id", "00-4bf92f3577b34da6-00f067aa0ba902b7-01", false},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"version 00 with extra field", testTraceParent + "-extra", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validTraceParent(tt.traceparent); got != tt.want {
+				t.Errorf("validTraceParent(%q) = %v, want %v", tt.traceparent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTraceContext(t *testing.T) {
+	ctx := WithTraceContext(context.Background(), testTraceParent, "vendor=value")
+	tc, ok := TraceContextFromContext(ctx)
+	if !ok {
+		t.Fatal("Expected trace context in ctx")
+	}
+	if tc.TraceParent != testTraceParent || tc.TraceState != "vendor=value" {
+		t.Errorf("Unexpected trace context: %+v", tc)
+	}
+
+	// Malformed traceparent is ignored
+	ctx = WithTraceContext(context.Background(), "not-a-trace", "")
+	if _, ok := TraceContextFromContext(ctx); ok {
+		t.Error("Malformed traceparent should not be attached")
+	}
+}
+
+func TestEventTraceContext_RoundTrip(t *testing.T) {
+	event, err := NewEvent("io.fabrica.device.created", "test", nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	if _, ok := event.TraceContext(); ok {
+		t.Error("New event should carry no trace context")
+	}
+	if got := ContextFromEvent(context.Background(), *event); got != context.Background() {
+		t.Error("ContextFromEvent should return ctx unchanged when no trace is present")
+	}
+
+	event.SetTraceContext(TraceContext{TraceParent: testTraceParent})
+	if got := event.Extensions()[ExtensionTraceParent]; got != testTraceParent {
+		t.Errorf("traceparent extension = %v", got)
+	}
+	if _, ok := event.Extensions()[ExtensionTraceState]; ok {
+		t.Error("Empty tracestate should not be set")
+	}
+
+	ctx := ContextFromEvent(context.Background(), *event)
+	tc, ok := TraceContextFromContext(ctx)
+	if !ok || tc.TraceParent != testTraceParent {
+		t.Errorf("ContextFromEvent did not carry trace: %+v, %v", tc, ok)
+	}
+}
+
+func TestPublishResourceEvent_PropagatesTrace(t *testing.T) {
+	bus := NewInMemoryEventBus(10, 1)
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	previousBus := GetGlobalEventBus()
+	previousConfig := GetEventConfig()
+	SetGlobalEventBus(bus)
+	config := DefaultEventConfig()
+	config.Enabled = true
+	config.LifecycleEventsEnabled = true
+	SetEventConfig(config)
+	t.Cleanup(func() {
+		SetGlobalEventBus(previousBus)
+		SetEventConfig(previousConfig)
+	})
+
+	received := make(chan Event, 2)
+	if _, err := bus.Subscribe("**", func(_ context.Context, event Event) error {
+		received <- event
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	ctx := WithTraceContext(context.Background(), testTraceParent, "vendor=value")
+	if err := PublishResourceEvent(ctx, "created", "Device", "dev-1", nil); err != nil {
+		t.Fatalf("PublishResourceEvent failed: %v", err)
+	}
+	published := <-received
+	tc, ok := published.TraceContext()
+	if !ok || tc.TraceParent != testTraceParent || tc.TraceState != "vendor=value" {
+		t.Errorf("Published event trace = %+v, %v", tc, ok)
+	}
+
+	// No trace in ctx is a no-op
+	if err := PublishResourceEvent(context.Background(), "created", "Device", "dev-2", nil); err != nil {
+		t.Fatalf("PublishResourceEvent failed: %v", err)
+	}
+	published = <-received
+	if _, ok := published.TraceContext(); ok {
+		t.Error("Event published without trace should carry no trace context")
+	}
+}
+
+func TestPublishResourceEvent_PropagatesRequestID(t *testing.T) {
+	bus := NewInMemoryEventBus(10, 1)
+	bus.Start()
+	defer bus.Close() //nolint:errcheck
+
+	previousBus := GetGlobalEventBus()
+	previousConfig := GetEventConfig()
+	SetGlobalEventBus(bus)
+	config := DefaultEventConfig()
+	config.Enabled = true
+	config.LifecycleEventsEnabled = true
+	SetEventConfig(config)
+	t.Cleanup(func() {
+		SetGlobalEventBus(previousBus)
+		SetEventConfig(previousConfig)
+	})
+
+	received := make(chan Event, 2)
+	if _, err := bus.Subscribe("**", func(_ context.Context, event Event) error {
+		received <- event
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	ctx := logging.WithRequestID(context.Background(), "req-123")
+	if err := PublishResourceEvent(ctx, "created", "Device", "dev-1", nil); err != nil {
+		t.Fatalf("PublishResourceEvent failed: %v", err)
+	}
+	published := <-received
+	if got := published.RequestID(); got != "req-123" {
+		t.Errorf("Published event request ID = %q, want req-123", got)
+	}
+	consumerCtx := ContextFromEvent(context.Background(), published)
+	if id, ok := logging.RequestIDFromContext(consumerCtx); !ok || id != "req-123" {
+		t.Errorf("ContextFromEvent request ID = %q, %v", id, ok)
+	}
+
+	if err := PublishResourceEvent(context.Background(), "created", "Device", "dev-2", nil); err != nil {
+		t.Fatalf("PublishResourceEvent failed: %v", err)
+	}
+	published = <-received
+	if _, ok := published.Extensions()[ExtensionRequestID]; ok {
+		t.Error("Event published without a request ID should not carry one")
+	}
+}
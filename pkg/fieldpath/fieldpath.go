@@ -0,0 +1,196 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package fieldpath evaluates simple JSONPath-like field selectors against
+// decoded JSON documents.
+//
+// A path is a dot-separated list of object keys, with optional array indices
+// and quoted keys for names containing dots:
+//
+//	metadata.name
+//	.status.conditions[0].type
+//	{.spec.location}
+//	metadata.labels['app.kubernetes.io/name']
+//
+// A leading dot and kubectl-style braces are accepted and ignored. Only
+// selection is supported: no wildcards, filters or recursive descent.
+//
+// Example:
+//
+//	var doc interface{}
+//	_ = json.Unmarshal(data, &doc)
+//	phase, ok := fieldpath.Lookup(doc, "status.phase")
+package fieldpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is one step of a path: an object key or an array index
+type segment struct {
+	key   string
+	index int
+	isIdx bool
+}
+
+// Path is a parsed field selector.
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// String returns the expression the path was parsed from.
+func (p Path) String() string {
+	return p.expr
+}
+
+// Name returns the last object key in the path (e.g., "phase" for
+// "status.phase"), or "" if the path has no keys.
+func (p Path) Name() string {
+	for i := len(p.segments) - 1; i >= 0; i-- {
+		if !p.segments[i].isIdx {
+			return p.segments[i].key
+		}
+	}
+	return ""
+}
+
+// Tokens returns the steps of the path as JSON Pointer (RFC 6901) reference
+// tokens, unescaped: object keys verbatim and array indices in decimal.
+// "spec.ports[2]" gives ["spec", "ports", "2"].
+func (p Path) Tokens() []string {
+	tokens := make([]string, len(p.segments))
+	for i, seg := range p.segments {
+		if seg.isIdx {
+			tokens[i] = strconv.Itoa(seg.index)
+		} else {
+			tokens[i] = seg.key
+		}
+	}
+	return tokens
+}
+
+// Parse parses a field selector. The empty path selects the whole document.
+func Parse(expr string) (Path, error) {
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	s = strings.TrimPrefix(s, ".")
+
+	p := Path{expr: expr}
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '.':
+			if i == len(s)-1 || s[i+1] == '.' || s[i+1] == '[' {
+				return Path{}, fmt.Errorf("invalid path %q: empty field name", expr)
+			}
+			i++
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return Path{}, fmt.Errorf("invalid path %q: unclosed [", expr)
+			}
+			inner := s[i+1 : i+end]
+			seg, err := parseBracket(inner)
+			if err != nil {
+				return Path{}, fmt.Errorf("invalid path %q: %w", expr, err)
+			}
+			p.segments = append(p.segments, seg)
+			i += end + 1
+		default:
+			end := strings.IndexAny(s[i:], ".[")
+			if end < 0 {
+				end = len(s) - i
+			}
+			p.segments = append(p.segments, segment{key: s[i : i+end]})
+			i += end
+		}
+	}
+	return p, nil
+}
+
+// parseBracket parses the inside of [...]: an index or a quoted key
+func parseBracket(inner string) (segment, error) {
+	if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+		return segment{key: inner[1 : len(inner)-1]}, nil
+	}
+	index, err := strconv.Atoi(inner)
+	if err != nil || index < 0 {
+		return segment{}, fmt.Errorf("[%s] is not an array index or quoted key", inner)
+	}
+	return segment{index: index, isIdx: true}, nil
+}
+
+// MustParse is like Parse but panics if the path is invalid. It is intended
+// for paths known at compile time.
+func MustParse(expr string) Path {
+	p, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Get returns the value at the path in doc, a document decoded from JSON
+// into interface{} (objects as map[string]interface{}, arrays as
+// []interface{}). ok is false if any step of the path is missing.
+func (p Path) Get(doc interface{}) (value interface{}, ok bool) {
+	current := doc
+	for _, seg := range p.segments {
+		if seg.isIdx {
+			arr, isArr := current.([]interface{})
+			if !isArr || seg.index >= len(arr) {
+				return nil, false
+			}
+			current = arr[seg.index]
+			continue
+		}
+		obj, isObj := current.(map[string]interface{})
+		if !isObj {
+			return nil, false
+		}
+		if current, ok = obj[seg.key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Lookup parses expr and returns the value at that path in doc.
+// Invalid paths are reported as missing.
+func Lookup(doc interface{}, expr string) (interface{}, bool) {
+	p, err := Parse(expr)
+	if err != nil {
+		return nil, false
+	}
+	return p.Get(doc)
+}
+
+// Format renders a value selected from a JSON document as a single line:
+// strings verbatim, numbers without exponents, null as "", and objects or
+// arrays as compact JSON.
+func Format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package fieldpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testDoc = `{
+	"metadata": {
+		"name": "node-1",
+		"labels": {"app.kubernetes.io/name": "bmc", "rack": "r1"}
+	},
+	"spec": {"location": "dc1", "ports": [22, 443], "nested": {"a": 1}},
+	"status": {
+		"ready": true,
+		"conditions": [{"type": "Ready", "status": "True"}],
+		"message": null
+	}
+}`
+
+func decode(t *testing.T) interface{} {
+	t.Helper()
+	var doc interface{}
+	if err := json.Unmarshal([]byte(testDoc), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestLookup(t *testing.T) {
+	doc := decode(t)
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"metadata.name", "node-1", true},
+		{".metadata.name", "node-1", true},
+		{"{.spec.location}", "dc1", true},
+		{"spec.ports[1]", "443", true},
+		{"status.conditions[0].type", "Ready", true},
+		{"metadata.labels['app.kubernetes.io/name']", "bmc", true},
+		{`metadata.labels["rack"]`, "r1", true},
+		{"status.ready", "true", true},
+		{"status.message", "", true},
+		{"spec.nested", `{"a":1}`, true},
+		{"spec.missing", "", false},
+		{"spec.ports[5]", "", false},
+		{"spec.location.deeper", "", false},
+		{"metadata[0]", "", false},
+		{"spec..location", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Lookup(doc, tt.path)
+		if ok != tt.wantOK {
+			t.Errorf("Lookup(%q) ok = %v, want %v", tt.path, ok, tt.wantOK)
+			continue
+		}
+		if formatted := Format(got); formatted != tt.want {
+			t.Errorf("Lookup(%q) = %q, want %q", tt.path, formatted, tt.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{"spec.", "spec[", "spec[x]", "spec[-1]", "a..b", "a.[0]"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) should fail", expr)
+		}
+	}
+}
+
+func TestPath_Name(t *testing.T) {
+	for expr, want := range map[string]string{
+		"status.phase":                   "phase",
+		"status.conditions[0]":           "conditions",
+		"metadata.labels['app.io/name']": "app.io/name",
+		"":                               "",
+	} {
+		if got := MustParse(expr).Name(); got != want {
+			t.Errorf("Name(%q) = %q, want %q", expr, got, want)
+		}
+	}
+}
+
+func TestPath_Tokens(t *testing.T) {
+	got := MustParse("status.conditions[0]['app.io/name']").Tokens()
+	want := []string{"status", "conditions", "0", "app.io/name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokens = %q, want %q", got, want)
+	}
+	if tokens := MustParse("").Tokens(); len(tokens) != 0 {
+		t.Errorf("Empty path tokens = %q, want none", tokens)
+	}
+}
+
+func TestParse_EmptySelectsDocument(t *testing.T) {
+	doc := decode(t)
+	got, ok := MustParse("").Get(doc)
+	if !ok || got == nil {
+		t.Errorf("Empty path should select the document, got %v, %v", got, ok)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if got := Format(float64(1234567)); got != "1234567" {
+		t.Errorf("Format(1234567) = %q", got)
+	}
+	if got := Format(1.5); got != "1.5" {
+		t.Errorf("Format(1.5) = %q", got)
+	}
+	if got := Format([]interface{}{"a", "b"}); got != `["a","b"]` {
+		t.Errorf("Format(array) = %q", got)
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package filter evaluates small boolean expressions over decoded resource
+// JSON, as accepted by the ?filter= parameter of generated list endpoints.
+//
+// An expression compares field paths (see pkg/fieldpath) with literals and
+// combines the comparisons with &&, || and !, grouped with parentheses:
+//
+//	spec.location==DC1 && status.phase!=Ready
+//	spec.cores>=16 || !(metadata.labels.tier=="gold")
+//	metadata.labels['app.kubernetes.io/name']=="api"
+//
+// Operators are ==, !=, <, <=, > and >=. The left side is always a field path
+// and the right side a literal: a quoted string ('x' or "x"), a number,
+// true, false, null, or a bare word taken as a string.
+//
+// Comparison rules:
+//   - Numbers compare numerically when both sides are numbers
+//   - Otherwise == and != compare the field's text form with the literal
+//   - <, <=, > and >= compare strings lexically and are false for any other
+//     combination of types
+//   - A missing or null field only matches == null and != <anything but null>
+//
+// There are no functions, wildcards or arithmetic, so evaluation is linear
+// in the size of the expression.
+//
+// Example:
+//
+//	f, err := filter.Parse(r.URL.Query().Get("filter"))
+//	if err != nil {
+//	    return err
+//	}
+//	if f.MatchObject(device) {
+//	    ...
+//	}
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/fieldpath"
+)
+
+// Limits keep hostile expressions cheap to parse and evaluate
+const (
+	// MaxLength is the longest expression Parse accepts, in bytes
+	MaxLength = 4096
+
+	// MaxDepth is the deepest nesting of parentheses and ! Parse accepts
+	MaxDepth = 32
+)
+
+// Filter is a parsed filter expression. The nil *Filter matches everything.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Parse parses a filter expression. The empty expression returns a nil
+// filter, which matches everything.
+func Parse(expr string) (*Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	if len(expr) > MaxLength {
+		return nil, fmt.Errorf("invalid filter: longer than %d bytes", MaxLength)
+	}
+
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expr
+}
+
+// Match reports whether doc, a document decoded from JSON into interface{},
+// satisfies the filter.
+func (f *Filter) Match(doc interface{}) bool {
+	if f == nil {
+		return true
+	}
+	return f.root.eval(doc)
+}
+
+// MatchObject encodes v as JSON and reports whether it satisfies the filter.
+// Values that cannot be encoded never match a non-nil filter.
+func (f *Filter) MatchObject(v interface{}) bool {
+	if f == nil {
+		return true
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+	return f.root.eval(doc)
+}
+
+// node is one element of a parsed expression
+type node interface {
+	eval(doc interface{}) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(doc interface{}) bool { return n.left.eval(doc) && n.right.eval(doc) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(doc interface{}) bool { return n.left.eval(doc) || n.right.eval(doc) }
+
+type notNode struct{ inner node }
+
+func (n notNode) eval(doc interface{}) bool { return !n.inner.eval(doc) }
+
+// literal is the right side of a comparison
+type literal struct {
+	text  string      // the literal as written, without quotes
+	value interface{} // string, float64, bool, or nil for null
+	null  bool
+}
+
+// comparison tests the value at a field path against a literal
+type comparison struct {
+	path fieldpath.Path
+	op   string
+	lit  literal
+}
+
+func (c comparison) eval(doc interface{}) bool {
+	value, ok := c.path.Get(doc)
+	if !ok || value == nil {
+		switch c.op {
+		case "==":
+			return c.lit.null
+		case "!=":
+			return !c.lit.null
+		}
+		return false
+	}
+	if c.lit.null {
+		return c.op == "!="
+	}
+
+	if n, isNum := value.(float64); isNum {
+		if want, ok := c.lit.value.(float64); ok {
+			switch {
+			case n < want:
+				return holds(-1, c.op)
+			case n > want:
+				return holds(1, c.op)
+			}
+			return holds(0, c.op)
+		}
+	}
+
+	switch c.op {
+	case "==":
+		return fieldpath.Format(value) == c.lit.text
+	case "!=":
+		return fieldpath.Format(value) != c.lit.text
+	}
+	s, isStr := value.(string)
+	if !isStr {
+		return false
+	}
+	return holds(strings.Compare(s, c.lit.text), c.op)
+}
+
+// holds reports whether a three-way comparison result satisfies op
+func holds(cmp int, op string) bool {
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// token kinds
+const (
+	tokWord = iota
+	tokString
+	tokOp
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+// tokenize splits an expression into tokens
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")"})
+			i++
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, token{tokAnd, "&&"})
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, token{tokOr, "||"})
+			i += 2
+		case strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="),
+			strings.HasPrefix(expr[i:], "<="), strings.HasPrefix(expr[i:], ">="):
+			tokens = append(tokens, token{tokOp, expr[i : i+2]})
+			i += 2
+		case c == '<' || c == '>':
+			tokens = append(tokens, token{tokOp, expr[i : i+1]})
+			i++
+		case c == '!':
+			tokens = append(tokens, token{tokNot, "!"})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string starting at offset %d", i)
+			}
+			tokens = append(tokens, token{tokString, expr[i+1 : i+1+end]})
+			i += end + 2
+		case c == '&' || c == '|' || c == '=':
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\n\r()&|=!<>\"'", rune(expr[i])) {
+				if expr[i] == '[' {
+					// Bracketed keys may contain any character, as in labels['a.b/c']
+					end := strings.IndexByte(expr[i:], ']')
+					if end < 0 {
+						return nil, fmt.Errorf("unterminated '[' at offset %d", i)
+					}
+					i += end
+				}
+				i++
+			}
+			tokens = append(tokens, token{tokWord, expr[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive-descent parser over tokens. Precedence, lowest
+// first: ||, &&, !, comparison.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if tok, ok := p.peek(); !ok || tok.kind != tokOr {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if tok, ok := p.peek(); !ok || tok.kind != tokAnd {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("nested deeper than %d", MaxDepth)
+	}
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	switch tok.kind {
+	case tokNot:
+		p.pos++
+		inner, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	case tokLParen:
+		p.pos++
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tok, ok := p.peek(); !ok || tok.kind != tokRParen {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return inner, nil
+	case tokWord:
+		return p.parseComparison()
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+func (p *parser) parseComparison() (node, error) {
+	field := p.tokens[p.pos]
+	path, err := fieldpath.Parse(field.text)
+	if err != nil {
+		return nil, err
+	}
+	p.pos++
+
+	op, ok := p.peek()
+	if !ok || op.kind != tokOp {
+		return nil, fmt.Errorf("expected comparison operator after %q", field.text)
+	}
+	p.pos++
+
+	value, ok := p.peek()
+	if !ok || (value.kind != tokWord && value.kind != tokString) {
+		return nil, fmt.Errorf("expected value after %s%s", field.text, op.text)
+	}
+	p.pos++
+
+	return comparison{path: path, op: op.text, lit: parseLiteral(value)}, nil
+}
+
+// parseLiteral types a value token: quoted strings stay strings, bare words
+// become numbers, booleans or null where they parse as one
+func parseLiteral(tok token) literal {
+	lit := literal{text: tok.text, value: tok.text}
+	if tok.kind == tokString {
+		return lit
+	}
+	switch tok.text {
+	case "true":
+		lit.value = true
+	case "false":
+		lit.value = false
+	case "null":
+		lit.value, lit.null = nil, true
+	default:
+		if n, err := strconv.ParseFloat(tok.text, 64); err == nil && !math.IsInf(n, 0) && !math.IsNaN(n) {
+			lit.value = n
+		}
+	}
+	return lit
+}
@@ -0,0 +1,166 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package filter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const sample = `{
+	"metadata": {"name": "node-1", "labels": {"app.io/tier": "gold"}},
+	"spec": {"location": "DC1", "cores": 16, "version": "10", "enabled": true, "rack": null},
+	"status": {"phase": "Ready", "temps": [41.5, 38]}
+}`
+
+func decode(t *testing.T) interface{} {
+	t.Helper()
+	var doc interface{}
+	if err := json.Unmarshal([]byte(sample), &doc); err != nil {
+		t.Fatalf("decode sample: %v", err)
+	}
+	return doc
+}
+
+func TestMatch(t *testing.T) {
+	doc := decode(t)
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"spec.location==DC1", true},
+		{`spec.location=="DC1"`, true},
+		{"spec.location!=DC1", false},
+		{"spec.location==DC1 && status.phase!=Ready", false},
+		{"spec.location==DC1 || status.phase!=Ready", true},
+		{"!(status.phase==Ready)", false},
+		{"metadata.labels['app.io/tier']==gold", true},
+		{"spec.enabled==true", true},
+		{"status.temps[0]>40", true},
+
+		// && binds tighter than ||
+		{"spec.location==DC2 && spec.cores==16 || status.phase==Ready", true},
+		{"spec.location==DC2 && (spec.cores==16 || status.phase==Ready)", false},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+		}
+		if got := f.Match(doc); got != tt.want {
+			t.Errorf("%q: expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestMatch_NumericVersusString(t *testing.T) {
+	doc := decode(t)
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		// Numbers compare numerically
+		{"spec.cores==16", true},
+		{"spec.cores==16.0", true},
+		{"spec.cores>9", true},
+		{"spec.cores<100", true},
+		{"spec.cores>=16", true},
+
+		// Strings compare lexically, even when they look like numbers
+		{"spec.version==10", true},
+		{"spec.version>9", false},
+		{`spec.version<"9"`, true},
+		{"spec.location<DC2", true},
+
+		// Ordering across types is never true
+		{"spec.cores>abc", false},
+		{"spec.cores<abc", false},
+		{"spec.enabled>false", false},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+		}
+		if got := f.Match(doc); got != tt.want {
+			t.Errorf("%q: expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestMatch_MissingFields(t *testing.T) {
+	doc := decode(t)
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"spec.missing==x", false},
+		{"spec.missing!=x", true},
+		{"spec.missing==null", true},
+		{"spec.missing!=null", false},
+		{"spec.missing>0", false},
+		{"spec.missing<0", false},
+		{"spec.rack==null", true},
+		{"spec.location!=null", true},
+		{"status.temps[5]==38", false},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+		}
+		if got := f.Match(doc); got != tt.want {
+			t.Errorf("%q: expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"spec.location",
+		"spec.location==",
+		"==DC1",
+		"spec.location=DC1",
+		"spec.location==DC1 &&",
+		"(spec.location==DC1",
+		"spec.location==DC1)",
+		`spec.location=="DC1`,
+		"spec..location==DC1",
+		"spec.location==DC1 & status.phase==Ready",
+		strings.Repeat("!", MaxDepth+2) + "spec.cores==1",
+		strings.Repeat("a", MaxLength+1),
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q): expected error", expr)
+		}
+	}
+}
+
+func TestNilFilterMatchesEverything(t *testing.T) {
+	f, err := Parse("  ")
+	if err != nil || f != nil {
+		t.Fatalf("Expected nil filter for empty expression, got %v, %v", f, err)
+	}
+	if !f.Match(nil) || !f.MatchObject(struct{}{}) {
+		t.Error("Nil filter should match everything")
+	}
+}
+
+func TestMatchObject(t *testing.T) {
+	type spec struct {
+		Location string `json:"location"`
+	}
+	f, err := Parse("spec.location==DC1")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !f.MatchObject(map[string]interface{}{"spec": spec{Location: "DC1"}}) {
+		t.Error("Expected MatchObject to match encoded struct")
+	}
+	if f.MatchObject(map[string]interface{}{"spec": spec{Location: "DC2"}}) {
+		t.Error("Expected MatchObject not to match")
+	}
+}
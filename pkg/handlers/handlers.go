@@ -0,0 +1,626 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package handlers provides generic CRUD HTTP handlers for Fabrica
+// resources, for projects that use Fabrica as a library instead of running
+// the code generator.
+//
+// The handlers follow the generated ones: the same request and response
+// bodies, admission steps (defaults, mutators, immutable fields, struct tag,
+// custom and webhook validation, quotas), ?dryRun=All, ETags and conditional
+// requests, and lifecycle events. Generated-only features such as field
+// managers, idempotency keys, version snapshots and API version conversion
+// are not included.
+//
+// Example:
+//
+//	type Device struct {
+//	    resource.Resource
+//	    Spec   DeviceSpec   `json:"spec"`
+//	    Status DeviceStatus `json:"status,omitempty"`
+//	}
+//
+//	func init() {
+//	    resource.RegisterResourcePrefix("Device", "dev")
+//	}
+//
+//	store := storage.NewResourceStorage[*Device](backend, "Device")
+//	h := handlers.NewResourceHandlers(store, handlers.HandlerOptions{Kind: "Device"})
+//
+//	r := chi.NewRouter()
+//	r.Get("/devices", h.List)
+//	r.Post("/devices", h.Create)
+//	r.Get("/devices/{uid}", h.Get)
+//	r.Put("/devices/{uid}", h.Update)
+//	r.Patch("/devices/{uid}", h.Patch)
+//	r.Delete("/devices/{uid}", h.Delete)
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/codec"
+	"github.com/openchami/fabrica/pkg/conditional"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/filter"
+	"github.com/openchami/fabrica/pkg/patch"
+	"github.com/openchami/fabrica/pkg/quota"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/storage"
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+// DryRunAll is the only accepted ?dryRun= value, as in Kubernetes
+const DryRunAll = "All"
+
+// DryRunHeader is set on responses to dry-run requests
+const DryRunHeader = "X-Dry-Run"
+
+// ChangedFieldsHeader lists, comma-separated, the paths of the fields an
+// update or patch changed (see resource.Diff)
+const ChangedFieldsHeader = "X-Changed-Fields"
+
+// Object is the method set the handlers need from a resource. A pointer to
+// any type that embeds resource.Resource implements it.
+type Object interface {
+	GetUID() string
+	GetName() string
+	GetLabels() map[string]string
+	MatchesLabels(selector map[string]string) bool
+	GetMetadata() *resource.Metadata
+	GetModifiedAt() time.Time
+	Touch()
+}
+
+// ObjectPointer constrains P to be *T and to implement Object, so the
+// handlers can create new resources without reflection.
+type ObjectPointer[T any] interface {
+	*T
+	Object
+}
+
+// HandlerOptions configures NewResourceHandlers.
+type HandlerOptions struct {
+	// Kind is the resource kind (e.g., "Device"). It is required, and must
+	// have a UID prefix registered with resource.RegisterResourcePrefix.
+	Kind string
+
+	// APIVersion is set on created resources (default "v1")
+	APIVersion string
+
+	// UIDParam is the chi URL parameter holding the resource UID (default "uid")
+	UIDParam string
+
+	// ETagGenerator computes resource ETags (default
+	// conditional.DefaultETagGenerator). Use conditional.NewETagGenerator to
+	// select one by algorithm name.
+	ETagGenerator conditional.ETagGenerator
+
+	// References, if set, is used to check that fields tagged ref:"Kind"
+	// name existing resources on create and update; broken references are
+	// rejected with 422. A storage.StorageBackend can be used directly.
+	References validation.ReferenceResolver
+}
+
+// ResourceHandlers serves one resource kind from a storage.ResourceStorage.
+// Each method is an http.HandlerFunc.
+type ResourceHandlers[T any, P ObjectPointer[T]] struct {
+	store storage.ResourceStorage[P]
+	opts  HandlerOptions
+}
+
+// NewResourceHandlers returns the CRUD handlers for the resources in store.
+// T is inferred from store, whose element type must be *T.
+//
+// Panics:
+//   - If opts.Kind is empty
+func NewResourceHandlers[T any, P ObjectPointer[T]](store storage.ResourceStorage[P], opts HandlerOptions) *ResourceHandlers[T, P] {
+	if opts.Kind == "" {
+		panic("resource kind cannot be empty")
+	}
+	if opts.APIVersion == "" {
+		opts.APIVersion = "v1"
+	}
+	if opts.UIDParam == "" {
+		opts.UIDParam = "uid"
+	}
+	if opts.ETagGenerator == nil {
+		opts.ETagGenerator = conditional.DefaultETagGenerator
+	}
+	return &ResourceHandlers[T, P]{store: store, opts: opts}
+}
+
+// List returns the resources, ordered by UID unless sort is given
+//
+// Query parameters:
+//   - labelSelector: only return resources with these labels (e.g. "env=prod,role=server")
+//   - filter: only return resources matching a field expression (e.g. "spec.location==DC1")
+//   - sort: comma-separated field paths, each with an optional :asc or :desc
+//   - limit: maximum number of resources to return
+//   - cursor: continue after the previous page; its value is sent in the X-Next-Cursor header
+func (h *ResourceHandlers[T, P]) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	selector, err := resource.ParseLabelSelector(query.Get("labelSelector"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	limit := 0
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		if limit, err = strconv.Atoi(rawLimit); err != nil || limit < 1 {
+			respondError(w, r, http.StatusBadRequest, fmt.Errorf("limit must be a positive integer, got %q", rawLimit))
+			return
+		}
+	}
+	where, err := filter.Parse(query.Get("filter"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	sortKeys, err := storage.ParseSort(query.Get("sort"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := storage.WithOperationTimeout(r.Context())
+	defer cancel()
+
+	all, err := h.store.LoadAll(ctx)
+	if err != nil {
+		respondStorageError(w, r, fmt.Errorf("failed to load %s resources: %w", h.opts.Kind, err))
+		return
+	}
+
+	matched := make([]P, 0, len(all))
+	for _, item := range all {
+		if item.MatchesLabels(selector) && where.MatchObject(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	page, next, err := storage.PaginateSorted(matched, sortKeys, query.Get("cursor"), limit)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if next != "" {
+		w.Header().Set("X-Next-Cursor", next)
+	}
+
+	taggables := make([]conditional.Taggable, 0, len(page))
+	for _, item := range page {
+		taggables = append(taggables, item)
+	}
+	etag := conditional.CollectionETag(taggables)
+	conditional.SetETag(w, etag)
+	setVaryHeaders(w)
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && conditional.MatchesETag(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	respondNegotiated(w, r, http.StatusOK, page)
+}
+
+// Get returns a resource by UID. It honors If-None-Match and
+// If-Modified-Since with 304 Not Modified.
+func (h *ResourceHandlers[T, P]) Get(w http.ResponseWriter, r *http.Request) {
+	uid, ok := h.uid(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := storage.WithOperationTimeout(r.Context())
+	defer cancel()
+
+	obj, stored, ok := h.load(ctx, w, r, uid)
+	if !ok {
+		return
+	}
+	if checkPreconditions(w, r, h.opts.ETagGenerator, stored, obj) {
+		return
+	}
+	respondResource(w, r, h.opts.ETagGenerator, http.StatusOK, obj)
+}
+
+// Create creates a resource. The body holds the spec fields inline, next to
+// name, labels and annotations, as in the generated Create<Kind>Request.
+func (h *ResourceHandlers[T, P]) Create(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	codec.LimitBody(w, r)
+	if err := codec.DecodeRequest(r, &fields); err != nil {
+		respondBodyError(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	metadata := splitMetadata(fields)
+	var name string
+	if raw, ok := metadata["name"]; ok {
+		_ = json.Unmarshal(raw, &name)
+	}
+	if name == "" {
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("name is required"))
+		return
+	}
+
+	uid, err := resource.GenerateUIDForResource(h.opts.Kind)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to generate UID: %w", err))
+		return
+	}
+
+	doc, err := json.Marshal(map[string]interface{}{
+		"apiVersion": h.opts.APIVersion,
+		"kind":       h.opts.Kind,
+		"metadata":   metadata,
+		"spec":       fields,
+	})
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to encode %s: %w", h.opts.Kind, err))
+		return
+	}
+	obj := P(new(T))
+	if err := json.Unmarshal(doc, obj); err != nil {
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	obj.GetMetadata().Initialize(name, uid)
+	if !checkProtectedKeys(w, r, nil, obj.GetMetadata()) {
+		return
+	}
+
+	if err := resource.ApplyDefaults(obj); err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to apply defaults: %w", err))
+		return
+	}
+	if err := resource.RunMutators(r.Context(), h.opts.Kind, obj); err != nil {
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("mutation failed: %w", err))
+		return
+	}
+	if !h.validate(w, r, obj, "CREATE") {
+		return
+	}
+
+	ctx, cancel := storage.WithOperationTimeout(r.Context())
+	defer cancel()
+
+	// Quota admission: counts existing resources, so it runs last
+	if err := quota.Check(ctx, h.opts.Kind, obj.GetLabels(), h.store.LoadAll); err != nil {
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			respondError(w, r, http.StatusForbidden, err)
+		} else {
+			respondStorageError(w, r, err)
+		}
+		return
+	}
+
+	if dryRun {
+		w.Header().Set(DryRunHeader, DryRunAll)
+		respondResource(w, r, h.opts.ETagGenerator, http.StatusCreated, obj)
+		return
+	}
+
+	if err := h.store.Save(ctx, obj); err != nil {
+		respondStorageError(w, r, fmt.Errorf("failed to save %s: %w", h.opts.Kind, err))
+		return
+	}
+
+	if err := events.PublishResourceCreated(r.Context(), h.opts.Kind, obj.GetUID(), obj.GetName(), obj); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource created event for %s %s: %v\n", h.opts.Kind, obj.GetUID(), err)
+	}
+
+	respondResource(w, r, h.opts.ETagGenerator, http.StatusCreated, obj)
+}
+
+// Update replaces the spec of a resource, and sets its name (if not empty)
+// and the given labels and annotations. The body has the same form as for
+// Create. With ?applyMode=merge the body is merged into the stored resource
+// instead, so spec fields the client did not send are kept.
+func (h *ResourceHandlers[T, P]) Update(w http.ResponseWriter, r *http.Request) {
+	h.write(w, r, "updated", func(stored, body []byte) ([]byte, error) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+		metadata := splitMetadata(fields)
+		if raw, ok := metadata["name"]; ok && string(raw) == `""` {
+			delete(metadata, "name")
+		}
+
+		incoming := map[string]interface{}{"metadata": metadata}
+		if r.URL.Query().Get("applyMode") == resource.ApplyModeMerge {
+			incoming["spec"] = fields
+		} else {
+			spec, err := json.Marshal(fields)
+			if err != nil {
+				return nil, err
+			}
+			if stored, err = replaceField(stored, "spec", spec); err != nil {
+				return nil, err
+			}
+		}
+		encoded, err := json.Marshal(incoming)
+		if err != nil {
+			return nil, err
+		}
+		return resource.ApplyMerge(stored, encoded)
+	})
+}
+
+// Patch patches the spec of a resource with a JSON Merge Patch, JSON Patch
+// or shorthand patch, selected by Content-Type as in pkg/patch.
+func (h *ResourceHandlers[T, P]) Patch(w http.ResponseWriter, r *http.Request) {
+	h.write(w, r, "patched", func(stored, body []byte) ([]byte, error) {
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(stored, &doc); err != nil {
+			return nil, err
+		}
+		spec := doc["spec"]
+		if len(spec) == 0 || string(spec) == "null" {
+			spec = json.RawMessage("{}")
+		}
+		result, err := patch.ApplyPatchWithOptions(spec, body, patch.DetectPatchType(r.Header.Get("Content-Type")), patch.PatchOptions{
+			AllowAddFields:    true,
+			AllowRemoveFields: true,
+		})
+		if err != nil {
+			return nil, &unprocessableError{fmt.Errorf("failed to apply patch to spec: %w", err)}
+		}
+		return replaceField(stored, "spec", result.Updated)
+	})
+}
+
+// Delete deletes a resource by UID. It honors If-Match and
+// If-Unmodified-Since with 412 Precondition Failed.
+func (h *ResourceHandlers[T, P]) Delete(w http.ResponseWriter, r *http.Request) {
+	uid, ok := h.uid(w, r)
+	if !ok {
+		return
+	}
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := storage.WithOperationTimeout(r.Context())
+	defer cancel()
+
+	obj, stored, ok := h.load(ctx, w, r, uid)
+	if !ok {
+		return
+	}
+	if checkPreconditions(w, r, h.opts.ETagGenerator, stored, obj) {
+		return
+	}
+
+	if dryRun {
+		w.Header().Set(DryRunHeader, DryRunAll)
+		respondJSON(w, http.StatusOK, DeleteResponse{
+			Message: h.opts.Kind + " would be deleted (dry run)",
+			UID:     uid,
+		})
+		return
+	}
+
+	if err := h.store.Delete(ctx, uid); err != nil {
+		respondStorageError(w, r, fmt.Errorf("failed to delete %s: %w", h.opts.Kind, err))
+		return
+	}
+
+	deleteMetadata := map[string]interface{}{
+		"deletedAt": time.Now(),
+	}
+	if err := events.PublishResourceDeleted(r.Context(), h.opts.Kind, uid, obj.GetName(), deleteMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource deleted event for %s %s: %v\n", h.opts.Kind, uid, err)
+	}
+
+	respondJSON(w, http.StatusOK, DeleteResponse{
+		Message: h.opts.Kind + " deleted successfully",
+		UID:     uid,
+	})
+}
+
+// DeleteResponse represents a successful deletion response
+type DeleteResponse struct {
+	Message string `json:"message"`
+	UID     string `json:"uid"`
+}
+
+// write runs an update or patch: it loads the resource, checks
+// preconditions, has apply compute the new JSON form from the stored one
+// and the request body, then admits, saves and publishes the result
+func (h *ResourceHandlers[T, P]) write(w http.ResponseWriter, r *http.Request, action string, apply func(stored, body []byte) ([]byte, error)) {
+	uid, ok := h.uid(w, r)
+	if !ok {
+		return
+	}
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := storage.WithOperationTimeout(r.Context())
+	defer cancel()
+
+	previous, stored, ok := h.load(ctx, w, r, uid)
+	if !ok {
+		return
+	}
+	if checkPreconditions(w, r, h.opts.ETagGenerator, stored, previous) {
+		return
+	}
+
+	codec.LimitBody(w, r)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondBodyError(w, r, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	updated, err := apply(stored, body)
+	if err != nil {
+		var unprocessable *unprocessableError
+		switch {
+		case errors.Is(err, patch.ErrPatchTooLarge):
+			respondError(w, r, http.StatusRequestEntityTooLarge, err)
+		case errors.As(err, &unprocessable):
+			respondError(w, r, http.StatusUnprocessableEntity, err)
+		default:
+			respondError(w, r, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	// Decode into a fresh value so the stored resource's maps and slices are
+	// not reused
+	obj := P(new(T))
+	if err := json.Unmarshal(updated, obj); err != nil {
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("invalid %s: %w", h.opts.Kind, err))
+		return
+	}
+	if !checkProtectedKeys(w, r, previous.GetMetadata(), obj.GetMetadata()) {
+		return
+	}
+
+	if err := resource.RunMutators(r.Context(), h.opts.Kind, obj); err != nil {
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("mutation failed: %w", err))
+		return
+	}
+
+	// Reject changes to fields tagged validate:"immutable"
+	changed, err := resource.CheckImmutable(previous, obj)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to check immutable fields: %w", err))
+		return
+	}
+	if len(changed) > 0 {
+		respondImmutableError(w, r, changed)
+		return
+	}
+	if !h.validate(w, r, obj, "UPDATE") {
+		return
+	}
+
+	// Bump generation only on real spec changes so reconcilers can skip no-ops
+	if specChanged(stored, obj) {
+		obj.GetMetadata().IncrementGeneration()
+	}
+	obj.Touch()
+
+	changes, err := resource.Diff(json.RawMessage(stored), obj)
+	if err != nil {
+		fmt.Printf("Warning: failed to compute changed fields: %v\n", err)
+	}
+	setChangedFieldsHeader(w, changes)
+
+	if dryRun {
+		w.Header().Set(DryRunHeader, DryRunAll)
+		respondResource(w, r, h.opts.ETagGenerator, http.StatusOK, obj)
+		return
+	}
+
+	if err := h.store.Save(ctx, obj); err != nil {
+		respondStorageError(w, r, fmt.Errorf("failed to save %s: %w", h.opts.Kind, err))
+		return
+	}
+
+	eventMetadata := map[string]interface{}{
+		"updatedAt":  obj.GetMetadata().UpdatedAt,
+		"generation": obj.GetMetadata().Generation,
+	}
+	paths := resource.ChangedPaths(changes)
+	if action == "patched" {
+		err = events.PublishResourcePatched(r.Context(), h.opts.Kind, uid, obj.GetName(), obj, eventMetadata, paths...)
+	} else {
+		err = events.PublishResourceUpdated(r.Context(), h.opts.Kind, uid, obj.GetName(), obj, eventMetadata, paths...)
+	}
+	if err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource %s event for %s %s: %v\n", action, h.opts.Kind, uid, err)
+	}
+
+	respondResource(w, r, h.opts.ETagGenerator, http.StatusOK, obj)
+}
+
+// uid returns the request's validated resource UID. Returns false after
+// responding with an error.
+func (h *ResourceHandlers[T, P]) uid(w http.ResponseWriter, r *http.Request) (string, bool) {
+	uid := chi.URLParam(r, h.opts.UIDParam)
+	if uid == "" {
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("%s UID is required", h.opts.Kind))
+		return "", false
+	}
+	if err := storage.ValidateUID(uid); err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return "", false
+	}
+	return uid, true
+}
+
+// load loads a resource and its JSON form. Returns false after responding
+// with an error.
+func (h *ResourceHandlers[T, P]) load(ctx context.Context, w http.ResponseWriter, r *http.Request, uid string) (P, []byte, bool) {
+	obj, err := h.store.Load(ctx, uid)
+	if err != nil {
+		respondStorageError(w, r, fmt.Errorf("%s not found: %w", h.opts.Kind, err))
+		return nil, nil, false
+	}
+	stored, err := json.Marshal(obj)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to encode stored %s: %w", h.opts.Kind, err))
+		return nil, nil, false
+	}
+	return obj, stored, true
+}
+
+// validate checks labels and annotations, runs struct tag, custom and
+// webhook validation, then checks references if HandlerOptions.References is
+// set. Returns false after responding with an error.
+func (h *ResourceHandlers[T, P]) validate(w http.ResponseWriter, r *http.Request, obj P, operation string) bool {
+	metadata := obj.GetMetadata()
+	if err := resource.ValidateLabels(metadata.Labels); err != nil {
+		respondMetadataError(w, r, err)
+		return false
+	}
+	if err := resource.ValidateAnnotations(metadata.Annotations); err != nil {
+		respondMetadataError(w, r, err)
+		return false
+	}
+	if err := validation.ValidateResource(obj); err != nil {
+		respondValidationError(w, r, err)
+		return false
+	}
+	if err := validation.ValidateWithContext(r.Context(), obj); err != nil {
+		respondValidationError(w, r, err)
+		return false
+	}
+	if err := validation.ValidateWithWebhooks(r.Context(), h.opts.Kind, operation, obj); err != nil {
+		respondValidationError(w, r, err)
+		return false
+	}
+	if h.opts.References != nil {
+		if err := validation.CheckReferences(r.Context(), h.opts.References, obj); err != nil {
+			respondReferenceError(w, r, err)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,312 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/storage"
+)
+
+type widgetSpec struct {
+	Color  string `json:"color" validate:"required"`
+	Serial string `json:"serial,omitempty" validate:"immutable"`
+	Parent string `json:"parent,omitempty" ref:"Widget"`
+}
+
+type widgetStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+type widget struct {
+	resource.Resource
+	Spec   widgetSpec   `json:"spec"`
+	Status widgetStatus `json:"status,omitempty"`
+}
+
+func init() {
+	resource.RegisterResourcePrefix("Widget", "wdg")
+}
+
+func newTestRouter(t *testing.T) http.Handler {
+	backend, err := storage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+	h := NewResourceHandlers(storage.NewResourceStorage[*widget](backend, "Widget"), HandlerOptions{Kind: "Widget"})
+
+	r := chi.NewRouter()
+	r.Get("/widgets", h.List)
+	r.Post("/widgets", h.Create)
+	r.Get("/widgets/{uid}", h.Get)
+	r.Put("/widgets/{uid}", h.Update)
+	r.Patch("/widgets/{uid}", h.Patch)
+	r.Delete("/widgets/{uid}", h.Delete)
+	return r
+}
+
+func do(t *testing.T, router http.Handler, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeWidget(t *testing.T, rec *httptest.ResponseRecorder) widget {
+	t.Helper()
+	var w widget
+	if err := json.Unmarshal(rec.Body.Bytes(), &w); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return w
+}
+
+func TestResourceHandlers_Lifecycle(t *testing.T) {
+	router := newTestRouter(t)
+
+	rec := do(t, router, http.MethodPost, "/widgets", `{"name":"w1","labels":{"env":"prod"},"color":"red","serial":"S1"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Create status = %d: %s", rec.Code, rec.Body.String())
+	}
+	created := decodeWidget(t, rec)
+	uid := created.GetUID()
+	if !strings.HasPrefix(uid, "wdg-") || created.Kind != "Widget" || created.APIVersion != "v1" {
+		t.Errorf("Created widget = %+v", created.Resource)
+	}
+	if created.Spec.Color != "red" || created.GetName() != "w1" || created.Metadata.Generation != 1 {
+		t.Errorf("Created widget spec = %+v, name %q, generation %d", created.Spec, created.GetName(), created.Metadata.Generation)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Create response has no ETag")
+	}
+
+	// Conditional GET
+	rec = do(t, router, http.MethodGet, "/widgets/"+uid, "", map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("GET with current ETag status = %d, want 304", rec.Code)
+	}
+
+	// Update with a stale ETag is rejected
+	rec = do(t, router, http.MethodPut, "/widgets/"+uid, `{"color":"blue","serial":"S1"}`, map[string]string{"If-Match": `"stale"`})
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("PUT with stale ETag status = %d, want 412", rec.Code)
+	}
+
+	rec = do(t, router, http.MethodPut, "/widgets/"+uid, `{"color":"blue","serial":"S1","labels":{"tier":"gold"}}`, map[string]string{"If-Match": etag})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d: %s", rec.Code, rec.Body.String())
+	}
+	updated := decodeWidget(t, rec)
+	if updated.Spec.Color != "blue" || updated.Metadata.Generation != 2 || updated.Metadata.Labels["env"] != "prod" || updated.Metadata.Labels["tier"] != "gold" {
+		t.Errorf("Updated widget = %+v", updated)
+	}
+	if got := rec.Header().Get(ChangedFieldsHeader); got != "metadata.labels.tier,spec.color" {
+		t.Errorf("%s = %q", ChangedFieldsHeader, got)
+	}
+
+	// Patch the spec; status is untouched
+	rec = do(t, router, http.MethodPatch, "/widgets/"+uid, `{"color":"green"}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if patched := decodeWidget(t, rec); patched.Spec.Color != "green" || patched.Spec.Serial != "S1" || patched.Metadata.Generation != 3 {
+		t.Errorf("Patched widget = %+v", patched)
+	}
+
+	// Immutable fields cannot change
+	rec = do(t, router, http.MethodPatch, "/widgets/"+uid, `{"serial":"S2"}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "spec.serial") {
+		t.Errorf("PATCH of immutable field status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Validation runs on writes
+	rec = do(t, router, http.MethodPut, "/widgets/"+uid, `{"serial":"S1"}`, nil)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"field":"color"`) {
+		t.Errorf("PUT without required color status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(t, router, http.MethodDelete, "/widgets/"+uid, "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("DELETE status = %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(t, router, http.MethodGet, "/widgets/"+uid, "", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET after delete status = %d, want 404", rec.Code)
+	}
+}
+
+func TestResourceHandlers_CreateRequiresName(t *testing.T) {
+	router := newTestRouter(t)
+
+	rec := do(t, router, http.MethodPost, "/widgets", `{"color":"red"}`, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Create without name status = %d, want 400", rec.Code)
+	}
+}
+
+func TestResourceHandlers_InvalidLabels(t *testing.T) {
+	router := newTestRouter(t)
+
+	rec := do(t, router, http.MethodPost, "/widgets", `{"name":"w1","labels":{"env":"prod uction"},"color":"red"}`, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"field":"metadata.labels[env]"`) {
+		t.Fatalf("Create with an invalid label status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(t, router, http.MethodPost, "/widgets", `{"name":"w1","color":"red"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Create status = %d: %s", rec.Code, rec.Body.String())
+	}
+	created := decodeWidget(t, rec)
+
+	rec = do(t, router, http.MethodPut, "/widgets/"+created.GetUID(), `{"color":"red","annotations":{"bad key":"x"}}`, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"field":"metadata.annotations[bad key]"`) {
+		t.Errorf("Update with an invalid annotation key status = %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestResourceHandlers_ProtectedKeys(t *testing.T) {
+	backend, err := storage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+	store := storage.NewResourceStorage[*widget](backend, "Widget")
+	h := NewResourceHandlers(store, HandlerOptions{Kind: "Widget"})
+	router := chi.NewRouter()
+	router.Post("/widgets", h.Create)
+	router.Put("/widgets/{uid}", h.Update)
+
+	rec := do(t, router, http.MethodPost, "/widgets", `{"name":"w1","labels":{"fabrica.io/managed-by":"me"},"color":"red"}`, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"field":"metadata.labels[fabrica.io/managed-by]"`) {
+		t.Fatalf("Create with a protected label status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// The system sets protected keys through storage
+	managed := &widget{Spec: widgetSpec{Color: "red"}}
+	managed.Metadata.Initialize("w1", "wdg-00000001")
+	managed.SetLabel("fabrica.io/managed-by", "controller")
+	if err := store.Save(context.Background(), managed); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	tests := []struct {
+		name, body string
+		code       int
+	}{
+		{"change a protected label", `{"color":"red","labels":{"fabrica.io/managed-by":"me"}}`, http.StatusUnprocessableEntity},
+		{"remove a protected label", `{"color":"red","labels":{"fabrica.io/managed-by":null}}`, http.StatusUnprocessableEntity},
+		{"add a protected annotation", `{"color":"red","annotations":{"fabrica.io/note":"x"}}`, http.StatusUnprocessableEntity},
+		{"other labels", `{"color":"blue","labels":{"env":"prod"}}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rec := do(t, router, http.MethodPut, "/widgets/wdg-00000001", tt.body, nil); rec.Code != tt.code {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, rec.Code, tt.code, rec.Body.String())
+		}
+	}
+
+	stored, err := store.Load(context.Background(), "wdg-00000001")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if stored.Metadata.Labels["fabrica.io/managed-by"] != "controller" || stored.Metadata.Labels["env"] != "prod" {
+		t.Errorf("labels = %v", stored.Metadata.Labels)
+	}
+}
+
+func TestResourceHandlers_DryRun(t *testing.T) {
+	router := newTestRouter(t)
+
+	rec := do(t, router, http.MethodPost, "/widgets?dryRun=All", `{"name":"w1","color":"red"}`, nil)
+	if rec.Code != http.StatusCreated || rec.Header().Get(DryRunHeader) != DryRunAll {
+		t.Fatalf("Dry-run create status = %d, %s = %q", rec.Code, DryRunHeader, rec.Header().Get(DryRunHeader))
+	}
+	dryRunWidget := decodeWidget(t, rec)
+	uid := dryRunWidget.GetUID()
+	if rec = do(t, router, http.MethodGet, "/widgets/"+uid, "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("Dry-run create was saved: GET status = %d", rec.Code)
+	}
+}
+
+func TestResourceHandlers_References(t *testing.T) {
+	backend, err := storage.NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+	h := NewResourceHandlers(storage.NewResourceStorage[*widget](backend, "Widget"), HandlerOptions{Kind: "Widget", References: backend})
+	router := chi.NewRouter()
+	router.Post("/widgets", h.Create)
+	router.Patch("/widgets/{uid}", h.Patch)
+
+	rec := do(t, router, http.MethodPost, "/widgets", `{"name":"w1","color":"red","parent":"wdg-missing"}`, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"field":"spec.parent"`) {
+		t.Fatalf("Create with broken reference status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(t, router, http.MethodPost, "/widgets", `{"name":"parent","color":"red"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Create status = %d: %s", rec.Code, rec.Body.String())
+	}
+	parent := decodeWidget(t, rec)
+
+	rec = do(t, router, http.MethodPost, "/widgets", `{"name":"child","color":"red","parent":"`+parent.GetUID()+`"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Create with valid reference status = %d: %s", rec.Code, rec.Body.String())
+	}
+	child := decodeWidget(t, rec)
+
+	rec = do(t, router, http.MethodPatch, "/widgets/"+child.GetUID(), `{"parent":"wdg-gone"}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("PATCH to a broken reference status = %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestResourceHandlers_List(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, body := range []string{
+		`{"name":"a","labels":{"env":"prod"},"color":"red"}`,
+		`{"name":"b","labels":{"env":"dev"},"color":"blue"}`,
+		`{"name":"c","labels":{"env":"prod"},"color":"green"}`,
+	} {
+		if rec := do(t, router, http.MethodPost, "/widgets", body, nil); rec.Code != http.StatusCreated {
+			t.Fatalf("Create status = %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := do(t, router, http.MethodGet, "/widgets?labelSelector=env=prod&sort=metadata.name:desc", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("List status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var items []widget
+	if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+		t.Fatalf("invalid list body: %v", err)
+	}
+	if len(items) != 2 || items[0].GetName() != "c" || items[1].GetName() != "a" {
+		t.Errorf("List returned %d items: %+v", len(items), items)
+	}
+
+	etag := rec.Header().Get("ETag")
+	rec = do(t, router, http.MethodGet, "/widgets?labelSelector=env=prod&sort=metadata.name:desc", "", map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("List with current ETag status = %d, want 304", rec.Code)
+	}
+
+	rec = do(t, router, http.MethodGet, "/widgets?limit=0", "", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("List with limit=0 status = %d, want 400", rec.Code)
+	}
+}
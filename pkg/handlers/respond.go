@@ -0,0 +1,257 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/codec"
+	"github.com/openchami/fabrica/pkg/conditional"
+	"github.com/openchami/fabrica/pkg/httperror"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/storage"
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+// maxChangedFieldsInHeader keeps X-Changed-Fields within common header size limits
+const maxChangedFieldsInHeader = 100
+
+// unprocessableError marks a request error reported as 422 rather than 400
+type unprocessableError struct {
+	err error
+}
+
+func (e *unprocessableError) Error() string { return e.err.Error() }
+func (e *unprocessableError) Unwrap() error { return e.err }
+
+// parseDryRun reports whether the request asks for a dry run with
+// ?dryRun=All
+func parseDryRun(r *http.Request) (bool, error) {
+	switch value := r.URL.Query().Get("dryRun"); value {
+	case "":
+		return false, nil
+	case DryRunAll:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid dryRun value %q: only %q is supported", value, DryRunAll)
+	}
+}
+
+// splitMetadata moves the name, labels and annotations of a create or
+// update request body out of fields, leaving only the inline spec fields
+func splitMetadata(fields map[string]json.RawMessage) map[string]json.RawMessage {
+	metadata := map[string]json.RawMessage{}
+	for _, key := range []string{"name", "labels", "annotations"} {
+		if value, ok := fields[key]; ok {
+			delete(fields, key)
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
+// replaceField returns the JSON object doc with key set to value
+func replaceField(doc []byte, key string, value json.RawMessage) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, err
+	}
+	fields[key] = value
+	return json.Marshal(fields)
+}
+
+// specChanged reports whether obj's spec differs from the spec in stored,
+// the JSON form of the resource before the change
+func specChanged(stored []byte, obj interface{}) bool {
+	current, err := json.Marshal(obj)
+	if err != nil {
+		return true
+	}
+	var before, after struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if json.Unmarshal(stored, &before) != nil || json.Unmarshal(current, &after) != nil {
+		return true
+	}
+	return resource.SpecChanged(before.Spec, after.Spec)
+}
+
+// checkPreconditions evaluates If-Match, If-None-Match, If-Unmodified-Since
+// and If-Modified-Since against the resource whose JSON form is stored.
+// Returns true after responding with 304 or 412.
+func checkPreconditions(w http.ResponseWriter, r *http.Request, generator conditional.ETagGenerator, stored []byte, obj Object) bool {
+	etag := generator(stored)
+	if !conditional.CheckConditionalRequest(w, r, etag, obj.GetModifiedAt()) {
+		return false
+	}
+	conditional.SetETag(w, etag)
+	return true
+}
+
+// setVaryHeaders declares the request headers that select a response variant
+func setVaryHeaders(w http.ResponseWriter) {
+	conditional.SetVary(w, "Accept")
+}
+
+// setChangedFieldsHeader reports the changed field paths in the
+// X-Changed-Fields response header
+func setChangedFieldsHeader(w http.ResponseWriter, changes []resource.FieldChange) {
+	paths := resource.ChangedPaths(changes)
+	if len(paths) > maxChangedFieldsInHeader {
+		paths = append(paths[:maxChangedFieldsInHeader], "...")
+	}
+	w.Header().Set(ChangedFieldsHeader, strings.Join(paths, ","))
+}
+
+// respondJSON sends a JSON response
+func respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	setVaryHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// respondNegotiated sends data as JSON or YAML, based on the Accept header
+func respondNegotiated(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	if codec.Negotiate(r) != codec.MediaTypeYAML {
+		respondJSON(w, status, data)
+		return
+	}
+
+	body, err := codec.Marshal(codec.MediaTypeYAML, data)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to encode response: %w", err))
+		return
+	}
+	setVaryHeaders(w)
+	w.Header().Set("Content-Type", codec.MediaTypeYAML)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// respondResource sends a single resource as JSON or YAML. The ETag is
+// computed from the canonical JSON so it does not depend on the format.
+func respondResource(w http.ResponseWriter, r *http.Request, generator conditional.ETagGenerator, status int, data interface{}) {
+	canonical, err := json.Marshal(data)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to encode response: %w", err))
+		return
+	}
+	conditional.SetETag(w, generator(canonical))
+	respondNegotiated(w, r, status, data)
+}
+
+// respondError sends an application/problem+json error response
+func respondError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	setVaryHeaders(w)
+	httperror.WriteError(w, r, status, err)
+}
+
+// respondBodyError reports a request body that could not be read or
+// decoded: 413 if it exceeded codec.MaxBodyBytes, 400 with err otherwise.
+func respondBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	if codec.IsBodyTooLarge(err) {
+		respondError(w, r, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("request body exceeds %d bytes", codec.MaxBodyBytes()))
+		return
+	}
+	respondError(w, r, http.StatusBadRequest, err)
+}
+
+// respondStorageError reports a failed storage call with the status its
+// error calls for: 404 for storage.ErrNotFound, 409 for storage.ErrConflict
+// and storage.ErrAlreadyExists, 504 past storage.OperationTimeout, 503 with
+// Retry-After if it may succeed on retry, and 500 otherwise.
+func respondStorageError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, storage.ErrConflict), errors.Is(err, storage.ErrAlreadyExists):
+		respondError(w, r, http.StatusConflict, err)
+	case storage.IsTimeout(err):
+		respondError(w, r, http.StatusGatewayTimeout,
+			fmt.Errorf("storage operation exceeded %s: %w", storage.OperationTimeout(), err))
+	case storage.IsTransient(err):
+		w.Header().Set("Retry-After", "1")
+		respondError(w, r, http.StatusServiceUnavailable, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// respondValidationError sends a validation problem with per-field details.
+// An unreachable fail-closed validation webhook is reported as 503 instead.
+func respondValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	setVaryHeaders(w)
+	if errors.Is(err, validation.ErrWebhookUnavailable) {
+		httperror.WriteError(w, r, http.StatusServiceUnavailable, err)
+		return
+	}
+	httperror.WriteValidationProblem(w, r, err)
+}
+
+// respondMetadataError rejects invalid labels or annotations with a 422
+// problem listing each one
+func respondMetadataError(w http.ResponseWriter, r *http.Request, err error) {
+	setVaryHeaders(w)
+	httperror.Write(w, httperror.Metadata(err).WithInstance(r))
+}
+
+// respondReferenceError rejects broken references with a 422 problem listing
+// each one. A failed lookup is reported as a storage error.
+func respondReferenceError(w http.ResponseWriter, r *http.Request, err error) {
+	if !errors.Is(err, validation.ErrBrokenReference) {
+		respondStorageError(w, r, err)
+		return
+	}
+	setVaryHeaders(w)
+	httperror.Write(w, httperror.References(err).WithInstance(r))
+}
+
+// respondImmutableError rejects changes to fields tagged validate:"immutable"
+// with a 422 problem listing each changed field.
+func respondImmutableError(w http.ResponseWriter, r *http.Request, fields []string) {
+	setVaryHeaders(w)
+	problem := httperror.New(http.StatusUnprocessableEntity, "immutable fields cannot be changed").WithInstance(r)
+	for _, field := range fields {
+		problem.Errors = append(problem.Errors, validation.FieldError{
+			Field:   field,
+			Tag:     resource.ImmutableTag,
+			Message: fmt.Sprintf("%s is immutable and cannot be changed", field),
+		})
+	}
+	httperror.Write(w, problem)
+}
+
+// checkProtectedKeys rejects a client's changes to protected labels and
+// annotations (see resource.IsProtectedLabelKey) with a 422 problem listing
+// each one, unless the request has resource.WithSystemAccess. old is nil on
+// create. Returns false after responding with an error.
+func checkProtectedKeys(w http.ResponseWriter, r *http.Request, old, new *resource.Metadata) bool {
+	if resource.HasSystemAccess(r.Context()) {
+		return true
+	}
+	changed := resource.CheckProtectedKeys(old, new)
+	if len(changed) == 0 {
+		return true
+	}
+	setVaryHeaders(w)
+	problem := httperror.New(http.StatusUnprocessableEntity, "protected labels and annotations cannot be changed").WithInstance(r)
+	for _, field := range changed {
+		problem.Errors = append(problem.Errors, validation.FieldError{
+			Field:   field,
+			Tag:     resource.ProtectedTag,
+			Message: fmt.Sprintf("%s is managed by the system and cannot be changed", field),
+		})
+	}
+	httperror.Write(w, problem)
+	return false
+}
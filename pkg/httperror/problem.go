@@ -0,0 +1,157 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package httperror writes HTTP error responses as RFC 7807 problem details.
+//
+// Every error response has the same machine-parseable shape, served as
+// application/problem+json:
+//
+//	{
+//	    "type": "about:blank",
+//	    "title": "Not Found",
+//	    "status": 404,
+//	    "detail": "Device not found: dev-123",
+//	    "instance": "/devices/dev-123"
+//	}
+//
+// Validation failures additionally carry per-field details in "errors".
+//
+// Usage:
+//
+//	httperror.WriteProblem(w, http.StatusNotFound, "Device not found")
+//
+//	if err := validation.ValidateResource(device); err != nil {
+//	    httperror.WriteValidationProblem(w, r, err)
+//	    return
+//	}
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+// ContentType is the media type of problem details responses.
+const ContentType = "application/problem+json"
+
+// DefaultType is the problem type used when no more specific type applies.
+// Per RFC 7807 the title is then the HTTP status text.
+const DefaultType = "about:blank"
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	// Type is a URI identifying the problem type
+	Type string `json:"type"`
+
+	// Title is a short, human-readable summary of the problem type
+	Title string `json:"title"`
+
+	// Status is the HTTP status code
+	Status int `json:"status"`
+
+	// Detail is a human-readable explanation specific to this occurrence
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is a URI reference identifying this occurrence, typically the request path
+	Instance string `json:"instance,omitempty"`
+
+	// Errors lists per-field failures for validation problems
+	Errors []validation.FieldError `json:"errors,omitempty"`
+}
+
+// New creates a problem of the default type for an HTTP status.
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   DefaultType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// WithInstance sets the instance from the request path and returns the problem.
+func (p *Problem) WithInstance(r *http.Request) *Problem {
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+	return p
+}
+
+// Error implements the error interface.
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return p.Title
+	}
+	return p.Title + ": " + p.Detail
+}
+
+// Write sends the problem as an application/problem+json response.
+func Write(w http.ResponseWriter, p *Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// WriteProblem sends a problem of the default type for an HTTP status.
+func WriteProblem(w http.ResponseWriter, status int, detail string) {
+	Write(w, New(status, detail))
+}
+
+// WriteError sends err as a problem, using the request path as the instance.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	Write(w, New(status, err.Error()).WithInstance(r))
+}
+
+// Validation returns a 400 problem for a validation failure.
+//
+// Field errors from the validation package are listed in Errors; any other
+// error (e.g. from a CustomValidator) is reported in Detail only.
+func Validation(err error) *Problem {
+	p := New(http.StatusBadRequest, err.Error())
+	p.Title = "Validation Failed"
+
+	var fieldErrs validation.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		p.Detail = "request failed validation"
+		p.Errors = fieldErrs.Errors
+	}
+	return p
+}
+
+// References returns a 422 problem listing the broken references in a
+// validation.ReferenceErrors; any other error is reported in Detail only.
+func References(err error) *Problem {
+	p := New(http.StatusUnprocessableEntity, err.Error())
+	p.Title = "Broken References"
+
+	var refErrs validation.ReferenceErrors
+	if errors.As(err, &refErrs) {
+		p.Detail = "request references resources that do not exist"
+		p.Errors = refErrs.Errors
+	}
+	return p
+}
+
+// Metadata returns a 422 problem for invalid labels or annotations (see
+// resource.ValidateLabels). Field errors are listed in Errors; any other
+// error is reported in Detail only.
+func Metadata(err error) *Problem {
+	p := New(http.StatusUnprocessableEntity, err.Error())
+	p.Title = "Invalid Metadata"
+
+	var fieldErrs validation.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		p.Detail = "request has invalid labels or annotations"
+		p.Errors = fieldErrs.Errors
+	}
+	return p
+}
+
+// WriteValidationProblem sends a validation failure as a problem.
+func WriteValidationProblem(w http.ResponseWriter, r *http.Request, err error) {
+	Write(w, Validation(err).WithInstance(r))
+}
@@ -0,0 +1,127 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ContentType)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	return body
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, http.StatusNotFound, "Device not found")
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	body := decodeProblem(t, rec)
+	if body["type"] != DefaultType || body["title"] != "Not Found" || body["status"] != float64(404) {
+		t.Errorf("Unexpected problem: %v", body)
+	}
+	if body["detail"] != "Device not found" {
+		t.Errorf("detail = %v", body["detail"])
+	}
+	if _, ok := body["instance"]; ok {
+		t.Error("instance should be omitted when unset")
+	}
+}
+
+func TestWriteError_SetsInstance(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/devices/dev-1?x=1", nil)
+	rec := httptest.NewRecorder()
+	WriteError(rec, req, http.StatusInternalServerError, errors.New("disk full"))
+
+	body := decodeProblem(t, rec)
+	if body["instance"] != "/devices/dev-1" {
+		t.Errorf("instance = %v, want /devices/dev-1", body["instance"])
+	}
+	if body["detail"] != "disk full" {
+		t.Errorf("detail = %v", body["detail"])
+	}
+}
+
+func TestWriteValidationProblem(t *testing.T) {
+	fieldErrs := validation.ValidationErrors{Errors: []validation.FieldError{
+		{Field: "name", Tag: "required", Message: "name is required"},
+		{Field: "ipAddress", Tag: "ip", Value: "nope", Message: "ipAddress must be a valid IP address"},
+	}}
+
+	req := httptest.NewRequest(http.MethodPost, "/devices", nil)
+	rec := httptest.NewRecorder()
+	WriteValidationProblem(rec, req, fmt.Errorf("validation failed: %w", fieldErrs))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	body := decodeProblem(t, rec)
+	if body["title"] != "Validation Failed" {
+		t.Errorf("title = %v", body["title"])
+	}
+	errs, ok := body["errors"].([]interface{})
+	if !ok || len(errs) != 2 {
+		t.Fatalf("errors = %v, want 2 field errors", body["errors"])
+	}
+	first := errs[0].(map[string]interface{})
+	if first["field"] != "name" || first["message"] != "name is required" {
+		t.Errorf("Unexpected field error: %v", first)
+	}
+}
+
+func TestValidation_PlainError(t *testing.T) {
+	p := Validation(errors.New("spec.port conflicts with an existing device"))
+	if p.Status != http.StatusBadRequest || len(p.Errors) != 0 {
+		t.Errorf("Unexpected problem: %+v", p)
+	}
+	if p.Detail != "spec.port conflicts with an existing device" {
+		t.Errorf("detail = %q", p.Detail)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	refErrs := validation.ReferenceErrors{Errors: []validation.FieldError{
+		{Field: "spec.chassisUID", Tag: "ref", Value: "chs-1", Message: "spec.chassisUID references Chassis chs-1, which does not exist"},
+	}}
+
+	p := References(refErrs)
+	if p.Status != http.StatusUnprocessableEntity || p.Title != "Broken References" {
+		t.Errorf("Unexpected problem: %+v", p)
+	}
+	if len(p.Errors) != 1 || p.Errors[0].Field != "spec.chassisUID" {
+		t.Errorf("errors = %+v", p.Errors)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	fieldErrs := validation.ValidationErrors{Errors: []validation.FieldError{
+		{Field: "metadata.labels[env]", Tag: "labelvalue", Value: "prod uction", Message: "label value \"prod uction\" is invalid"},
+	}}
+
+	p := Metadata(fieldErrs)
+	if p.Status != http.StatusUnprocessableEntity || p.Title != "Invalid Metadata" {
+		t.Errorf("Unexpected problem: %+v", p)
+	}
+	if len(p.Errors) != 1 || p.Errors[0].Field != "metadata.labels[env]" {
+		t.Errorf("errors = %+v", p.Errors)
+	}
+}
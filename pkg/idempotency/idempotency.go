@@ -0,0 +1,214 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package idempotency makes create requests safely retryable.
+//
+// A client sends an Idempotency-Key header with a POST. The generated create
+// handler reserves the key before creating anything and records the UID it
+// created. A retry with the same key and body gets the original resource back
+// instead of creating a duplicate; a retry while the first request is still
+// running gets 409, and reusing a key with a different body gets 422.
+//
+// Keys are remembered for a window (DefaultTTL unless configured), after
+// which the same key creates a new resource.
+//
+// Example:
+//
+//	idempotency.SetDefault(idempotency.NewMemoryStore(time.Hour, 10000))
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Header is the request header carrying the idempotency key.
+const Header = "Idempotency-Key"
+
+// ReplayedHeader is set to "true" on responses replayed for a repeated key.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// DefaultTTL is how long the default store remembers keys.
+const DefaultTTL = 24 * time.Hour
+
+// DefaultMaxEntries bounds the number of keys the default store remembers.
+const DefaultMaxEntries = 10000
+
+// MaxKeyLength is the longest accepted key, in bytes.
+const MaxKeyLength = 255
+
+var (
+	// ErrInProgress is returned by Reserve when another request holding the
+	// same key has not completed yet.
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+
+	// ErrKeyReused is returned by Reserve when the key was used for a
+	// request with a different body.
+	ErrKeyReused = errors.New("idempotency key was already used for a different request")
+)
+
+// Record is what a store remembers about a key.
+type Record struct {
+	// Fingerprint identifies the request body the key was first used with
+	Fingerprint string
+
+	// UID is the resource the request created, or "" while it is in progress
+	UID string
+
+	// CreatedAt is when the key was reserved
+	CreatedAt time.Time
+}
+
+// Store remembers idempotency keys. Implementations must be safe for
+// concurrent use; Reserve must be atomic so two requests with the same key
+// cannot both reserve it.
+type Store interface {
+	// Reserve claims key for a request with the given fingerprint.
+	//
+	// It returns reserved=true if the key was unknown (or expired) and is now
+	// held by the caller, who must later call Complete or Release. If the key
+	// is already recorded with the same fingerprint and a UID, it returns
+	// that record with reserved=false. It returns ErrInProgress if the key is
+	// held by an unfinished request, and ErrKeyReused if the fingerprint
+	// differs.
+	Reserve(ctx context.Context, key, fingerprint string) (record Record, reserved bool, err error)
+
+	// Complete records the UID created by the request holding key.
+	Complete(ctx context.Context, key, uid string) error
+
+	// Release forgets a key whose request failed, so a retry can proceed.
+	Release(ctx context.Context, key string) error
+}
+
+// Fingerprint returns a stable digest of a request body for Reserve.
+func Fingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// MemoryStore is an in-process Store that remembers keys for a fixed TTL and
+// evicts the least recently used key once full. Keys are lost on restart and
+// are not shared between replicas.
+type MemoryStore struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key    string
+	record Record
+}
+
+// NewMemoryStore creates a MemoryStore. A non-positive ttl uses DefaultTTL
+// and a non-positive maxEntries uses DefaultMaxEntries.
+func NewMemoryStore(ttl time.Duration, maxEntries int) *MemoryStore {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &MemoryStore{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Reserve implements Store.
+func (s *MemoryStore) Reserve(_ context.Context, key, fingerprint string) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elem, ok := s.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		if now.Sub(entry.record.CreatedAt) < s.ttl {
+			s.order.MoveToFront(elem)
+			switch {
+			case entry.record.Fingerprint != fingerprint:
+				return Record{}, false, ErrKeyReused
+			case entry.record.UID == "":
+				return Record{}, false, ErrInProgress
+			}
+			return entry.record, false, nil
+		}
+		s.remove(elem)
+	}
+
+	record := Record{Fingerprint: fingerprint, CreatedAt: now}
+	s.entries[key] = s.order.PushFront(&memoryEntry{key: key, record: record})
+	for s.order.Len() > s.maxEntries {
+		s.remove(s.order.Back())
+	}
+	return record, true, nil
+}
+
+// Complete implements Store.
+func (s *MemoryStore) Complete(_ context.Context, key, uid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.entries[key]; ok {
+		elem.Value.(*memoryEntry).record.UID = uid
+	}
+	return nil
+}
+
+// Release implements Store.
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+	return nil
+}
+
+// Len returns the number of keys held, including expired ones not yet evicted.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *MemoryStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*memoryEntry).key)
+}
+
+// defaultStore is used by the generated create handlers.
+var defaultStore Store = NewMemoryStore(DefaultTTL, DefaultMaxEntries)
+var defaultStoreMutex sync.RWMutex
+
+// SetDefault installs the store used by the generated create handlers. Pass
+// nil to ignore Idempotency-Key headers.
+//
+// Example:
+//
+//	idempotency.SetDefault(idempotency.NewMemoryStore(time.Hour, 0))
+func SetDefault(s Store) {
+	defaultStoreMutex.Lock()
+	defer defaultStoreMutex.Unlock()
+	defaultStore = s
+}
+
+// Default returns the store used by the generated create handlers, or nil if
+// idempotency keys are disabled.
+func Default() Store {
+	defaultStoreMutex.RLock()
+	defer defaultStoreMutex.RUnlock()
+	return defaultStore
+}
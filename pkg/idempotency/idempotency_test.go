@@ -0,0 +1,92 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_ReplaysCompletedKey(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(time.Hour, 10)
+	fp := Fingerprint([]byte(`{"name":"a"}`))
+
+	if _, reserved, err := s.Reserve(ctx, "k1", fp); err != nil || !reserved {
+		t.Fatalf("First Reserve: reserved=%v err=%v", reserved, err)
+	}
+	if _, _, err := s.Reserve(ctx, "k1", fp); !errors.Is(err, ErrInProgress) {
+		t.Errorf("Expected ErrInProgress before Complete, got %v", err)
+	}
+
+	if err := s.Complete(ctx, "k1", "dev-1"); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	record, reserved, err := s.Reserve(ctx, "k1", fp)
+	if err != nil || reserved || record.UID != "dev-1" {
+		t.Errorf("Expected replay of dev-1, got record=%+v reserved=%v err=%v", record, reserved, err)
+	}
+
+	if _, _, err := s.Reserve(ctx, "k1", Fingerprint([]byte(`{"name":"b"}`))); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Expected ErrKeyReused for a different body, got %v", err)
+	}
+}
+
+func TestMemoryStore_ReleaseAllowsRetry(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(time.Hour, 10)
+
+	if _, _, err := s.Reserve(ctx, "k1", "fp"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Release(ctx, "k1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, reserved, err := s.Reserve(ctx, "k1", "other"); err != nil || !reserved {
+		t.Errorf("Expected released key to be reservable, got reserved=%v err=%v", reserved, err)
+	}
+}
+
+func TestMemoryStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryStore(time.Minute, 10)
+	s.now = func() time.Time { return now }
+
+	if _, _, err := s.Reserve(ctx, "k1", "fp"); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Complete(ctx, "k1", "dev-1")
+
+	now = now.Add(2 * time.Minute)
+	if _, reserved, err := s.Reserve(ctx, "k1", "fp"); err != nil || !reserved {
+		t.Errorf("Expected expired key to be reservable again, got reserved=%v err=%v", reserved, err)
+	}
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(time.Hour, 2)
+
+	for _, key := range []string{"a", "b"} {
+		_, _, _ = s.Reserve(ctx, key, "fp")
+		_ = s.Complete(ctx, key, "uid-"+key)
+	}
+	// Touch a so b is the least recently used
+	_, _, _ = s.Reserve(ctx, "a", "fp")
+	_, _, _ = s.Reserve(ctx, "c", "fp")
+
+	if s.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", s.Len())
+	}
+	if record, reserved, _ := s.Reserve(ctx, "a", "fp"); reserved || record.UID != "uid-a" {
+		t.Error("Expected a to survive eviction")
+	}
+	if _, reserved, _ := s.Reserve(ctx, "b", "fp"); !reserved {
+		t.Error("Expected b to have been evicted")
+	}
+}
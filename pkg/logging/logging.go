@@ -0,0 +1,192 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package logging provides request-scoped structured logging with
+// correlation IDs.
+//
+// Middleware assigns every request an ID, taken from an incoming X-Request-ID
+// header or generated, and stores a log/slog logger carrying it in the request
+// context. Handlers, reconcilers and event consumers retrieve it with
+// FromContext, so every line logged for one request shares the same
+// request_id:
+//
+//	r.Use(logging.Middleware(slog.Default()))
+//
+//	func GetDevice(w http.ResponseWriter, r *http.Request) {
+//	    logging.FromContext(r.Context()).Info("loading device", "uid", uid)
+//	}
+//
+// Events published with the request context carry the ID as the "requestid"
+// CloudEvents extension (see events.ExtensionRequestID).
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestIDHeader is the header that carries the request ID in both
+// directions.
+const RequestIDHeader = "X-Request-ID"
+
+// MaxRequestIDLength is the longest incoming request ID that is honored.
+// Longer or non-printable IDs are replaced with a generated one.
+const MaxRequestIDLength = 128
+
+// RequestIDKey is the log attribute that holds the request ID.
+const RequestIDKey = "request_id"
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+// WithRequestID attaches a request ID to ctx. An empty id leaves ctx
+// unchanged.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID attached by WithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// WithLogger attaches a logger to ctx.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger attached by WithLogger or Middleware.
+//
+// Without one it returns slog.Default(), with the request ID attached by
+// WithRequestID if there is one. Reconcilers and event consumers get
+// correlated logs this way from a context built by events.ContextFromEvent.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return slog.Default().With(RequestIDKey, id)
+	}
+	return slog.Default()
+}
+
+// NewRequestID returns a random 128-bit request ID in hex.
+func NewRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Middleware assigns each request an ID, echoes it in the X-Request-ID
+// response header, and stores a logger with the ID in the request context.
+// After the request completes it logs the method, path, status, response
+// size, duration and remote address at info level (error level for 5xx).
+//
+// An incoming X-Request-ID is honored if it is at most MaxRequestIDLength
+// printable ASCII characters. A nil base uses slog.Default().
+func Middleware(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = NewRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			logger := base
+			if logger == nil {
+				logger = slog.Default()
+			}
+			logger = logger.With(RequestIDKey, id)
+
+			ctx := WithLogger(WithRequestID(r.Context(), id), logger)
+			recorder := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "request completed",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int64("bytes", recorder.bytes),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+		})
+	}
+}
+
+// validRequestID accepts non-empty printable ASCII IDs up to MaxRequestIDLength.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// statusRecorder captures the status code and body size written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(b)
+	s.bytes += int64(n)
+	return n, err
+}
+
+// Flush lets streaming handlers flush through the recorder.
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware_AssignsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	var handlerID string
+	handler := Middleware(base)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerID, _ = RequestIDFromContext(r.Context())
+		FromContext(r.Context()).Info("handling")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("ok")) //nolint:errcheck
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/devices", nil))
+
+	id := rec.Header().Get(RequestIDHeader)
+	if len(id) != 32 {
+		t.Fatalf("generated request ID = %q, want 32 hex characters", id)
+	}
+	if handlerID != id {
+		t.Errorf("context request ID = %q, want %q", handlerID, id)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	var handling, completed map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &handling); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &completed); err != nil {
+		t.Fatal(err)
+	}
+	if handling[RequestIDKey] != id || completed[RequestIDKey] != id {
+		t.Errorf("log lines not correlated with %q: %v / %v", id, handling, completed)
+	}
+	if completed["method"] != "POST" || completed["path"] != "/devices" ||
+		completed["status"] != float64(201) || completed["bytes"] != float64(2) {
+		t.Errorf("unexpected request log: %v", completed)
+	}
+	if _, ok := completed["duration"]; !ok {
+		t.Error("request log has no duration")
+	}
+}
+
+func TestMiddleware_HonorsIncomingRequestID(t *testing.T) {
+	handler := Middleware(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name     string
+		incoming string
+		honored  bool
+	}{
+		{"valid", "abc-123", true},
+		{"too long", strings.Repeat("a", MaxRequestIDLength+1), false},
+		{"control characters", "abc\x01", false},
+		{"spaces", "abc 123", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+			req.Header.Set(RequestIDHeader, tt.incoming)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if (got == tt.incoming) != tt.honored {
+				t.Errorf("response request ID = %q, honored = %v, want %v", got, got == tt.incoming, tt.honored)
+			}
+			if got == "" {
+				t.Error("response has no request ID")
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("FromContext without logger or request ID should return slog.Default()")
+	}
+
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(previous)
+
+	FromContext(WithRequestID(context.Background(), "req-1")).Info("reconciling")
+	if !strings.Contains(buf.String(), `"request_id":"req-1"`) {
+		t.Errorf("log line missing request ID: %s", buf.String())
+	}
+
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	if FromContext(WithLogger(context.Background(), logger)) != logger {
+		t.Error("FromContext should return the attached logger")
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package metrics provides the counters, gauges and histograms Fabrica's
+// packages use to report on themselves, and an HTTP handler that serves them
+// in the Prometheus text exposition format.
+//
+// It has no dependencies, so packages can expose metrics without pulling in a
+// Prometheus client. Prometheus scrapes the handler directly; services that
+// already use a client library can read the same values through Collect.
+//
+// Example:
+//
+//	collectors := append(storage.MetricsCollectors(), events.MetricsCollectors()...)
+//	http.Handle("/metrics", metrics.Handler(collectors...))
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Type is the type of a metric family
+type Type string
+
+// Metric types, named as in the Prometheus exposition format
+const (
+	Counter   Type = "counter"
+	Gauge     Type = "gauge"
+	Histogram Type = "histogram"
+)
+
+// DefaultBuckets are histogram upper bounds in seconds suited to request and
+// storage latencies (the Prometheus client defaults)
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Collector produces metric families when metrics are gathered
+type Collector interface {
+	Collect() Family
+}
+
+// Family is a named metric with one sample per label combination
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+// Sample is the value of a family for one combination of label values
+type Sample struct {
+	// Labels are the label names and values, in the family's label order
+	Labels []Label
+
+	// Value is the counter or gauge value
+	Value float64
+
+	// Buckets, Sum and Count are the histogram observations; bucket counts
+	// are cumulative
+	Buckets []Bucket
+	Sum     float64
+	Count   uint64
+}
+
+// Label is a label name and value
+type Label struct {
+	Name, Value string
+}
+
+// Bucket counts the observations at or below UpperBound
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// CounterVec is a counter partitioned by label values
+type CounterVec struct {
+	name, help string
+	labels     []string
+
+	mu     sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+// NewCounterVec creates a counter with the given label names
+//
+// Example:
+//
+//	requests := metrics.NewCounterVec("myapp_requests_total", "Requests handled.", "method", "code")
+//	requests.Inc("GET", "200")
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{name: name, help: help, labels: labels, values: make(map[string]*counterValue)}
+}
+
+// Inc adds one to the counter for labelValues
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta, which must not be negative, to the counter for labelValues
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	checkLabels(c.name, c.labels, labelValues)
+	key := strings.Join(labelValues, "\xff")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		value = &counterValue{labels: append([]string(nil), labelValues...)}
+		c.values[key] = value
+	}
+	value.value += delta
+}
+
+// Value returns the counter for labelValues
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value, ok := c.values[strings.Join(labelValues, "\xff")]; ok {
+		return value.value
+	}
+	return 0
+}
+
+// Collect implements Collector
+func (c *CounterVec) Collect() Family {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	family := Family{Name: c.name, Help: c.help, Type: Counter}
+	for _, value := range c.values {
+		family.Samples = append(family.Samples, Sample{Labels: pairLabels(c.labels, value.labels), Value: value.value})
+	}
+	sortSamples(family.Samples)
+	return family
+}
+
+// HistogramVec is a histogram partitioned by label values
+type HistogramVec struct {
+	name, help string
+	labels     []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labels []string
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewHistogramVec creates a histogram with the given bucket upper bounds
+// (DefaultBuckets if nil) and label names
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &HistogramVec{name: name, help: help, labels: labels, buckets: buckets, values: make(map[string]*histogramValue)}
+}
+
+// Observe records v in the histogram for labelValues
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	checkLabels(h.name, h.labels, labelValues)
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	value, ok := h.values[key]
+	if !ok {
+		value = &histogramValue{labels: append([]string(nil), labelValues...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = value
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		value.counts[i]++
+	}
+	value.sum += v
+	value.count++
+}
+
+// Count returns the number of observations for labelValues
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if value, ok := h.values[strings.Join(labelValues, "\xff")]; ok {
+		return value.count
+	}
+	return 0
+}
+
+// Collect implements Collector
+func (h *HistogramVec) Collect() Family {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	family := Family{Name: h.name, Help: h.help, Type: Histogram}
+	for _, value := range h.values {
+		sample := Sample{Labels: pairLabels(h.labels, value.labels), Sum: value.sum, Count: value.count}
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += value.counts[i]
+			sample.Buckets = append(sample.Buckets, Bucket{UpperBound: bound, Count: cumulative})
+		}
+		family.Samples = append(family.Samples, sample)
+	}
+	sortSamples(family.Samples)
+	return family
+}
+
+// GaugeFunc is a gauge whose value is read when metrics are gathered
+type GaugeFunc struct {
+	name, help string
+	value      func() float64
+}
+
+// NewGaugeFunc creates a gauge that reports value()
+func NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	return &GaugeFunc{name: name, help: help, value: value}
+}
+
+// Collect implements Collector
+func (g *GaugeFunc) Collect() Family {
+	return Family{Name: g.name, Help: g.help, Type: Gauge, Samples: []Sample{{Value: g.value()}}}
+}
+
+// WriteText writes the families of collectors to w in the Prometheus text
+// exposition format, sorted by name
+func WriteText(w io.Writer, collectors ...Collector) error {
+	families := make([]Family, 0, len(collectors))
+	for _, collector := range collectors {
+		families = append(families, collector.Collect())
+	}
+	sort.SliceStable(families, func(i, j int) bool { return families[i].Name < families[j].Name })
+
+	buf := bufio.NewWriter(w)
+	for _, family := range families {
+		fmt.Fprintf(buf, "# HELP %s %s\n", family.Name, helpEscaper.Replace(family.Help))
+		fmt.Fprintf(buf, "# TYPE %s %s\n", family.Name, family.Type)
+		for _, sample := range family.Samples {
+			if family.Type != Histogram {
+				fmt.Fprintf(buf, "%s%s %s\n", family.Name, formatLabels(sample.Labels), formatFloat(sample.Value))
+				continue
+			}
+			for _, bucket := range sample.Buckets {
+				labels := append(append([]Label(nil), sample.Labels...), Label{"le", formatFloat(bucket.UpperBound)})
+				fmt.Fprintf(buf, "%s_bucket%s %d\n", family.Name, formatLabels(labels), bucket.Count)
+			}
+			labels := append(append([]Label(nil), sample.Labels...), Label{"le", "+Inf"})
+			fmt.Fprintf(buf, "%s_bucket%s %d\n", family.Name, formatLabels(labels), sample.Count)
+			fmt.Fprintf(buf, "%s_sum%s %s\n", family.Name, formatLabels(sample.Labels), formatFloat(sample.Sum))
+			fmt.Fprintf(buf, "%s_count%s %d\n", family.Name, formatLabels(sample.Labels), sample.Count)
+		}
+	}
+	return buf.Flush()
+}
+
+// Handler serves the families of collectors in the Prometheus text
+// exposition format
+func Handler(collectors ...Collector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WriteText(w, collectors...)
+	})
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = fmt.Sprintf(`%s="%s"`, label.Name, labelValueEscaper.Replace(label.Value))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func pairLabels(names, values []string) []Label {
+	labels := make([]Label, len(names))
+	for i, name := range names {
+		labels[i] = Label{Name: name, Value: values[i]}
+	}
+	return labels
+}
+
+// sortSamples orders samples by label values so output is stable
+func sortSamples(samples []Sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i].Labels, samples[j].Labels
+		for k := range a {
+			if a[k].Value != b[k].Value {
+				return a[k].Value < b[k].Value
+			}
+		}
+		return false
+	})
+}
+
+// checkLabels panics on a label count mismatch, a programming error
+func checkLabels(name string, names, values []string) {
+	if len(names) != len(values) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", name, len(names), len(values)))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	ops := NewCounterVec("test_operations_total", "Operations by type.\nSecond line.", "type", "result")
+	ops.Inc("Device", "ok")
+	ops.Add(2, "Device", "ok")
+	ops.Inc(`Odd"Kind`, "error")
+
+	latency := NewHistogramVec("test_duration_seconds", "Latency.", []float64{0.1, 1}, "op")
+	latency.Observe(0.05, "load")
+	latency.Observe(0.5, "load")
+	latency.Observe(3, "load")
+
+	gauge := NewGaugeFunc("test_subscribers", "Subscribers.", func() float64 { return 4 })
+
+	var out strings.Builder
+	if err := WriteText(&out, ops, latency, gauge); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP test_duration_seconds Latency.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{op="load",le="0.1"} 1
+test_duration_seconds_bucket{op="load",le="1"} 2
+test_duration_seconds_bucket{op="load",le="+Inf"} 3
+test_duration_seconds_sum{op="load"} 3.55
+test_duration_seconds_count{op="load"} 3
+# HELP test_operations_total Operations by type.\nSecond line.
+# TYPE test_operations_total counter
+test_operations_total{type="Device",result="ok"} 3
+test_operations_total{type="Odd\"Kind",result="error"} 1
+# HELP test_subscribers Subscribers.
+# TYPE test_subscribers gauge
+test_subscribers 4
+`
+	if out.String() != want {
+		t.Errorf("WriteText output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	if ops.Value("Device", "ok") != 3 || latency.Count("load") != 3 || ops.Value("Rack", "ok") != 0 {
+		t.Error("Value and Count do not match the observations")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ops := NewCounterVec("test_total", "Test.", "op")
+	ops.Inc("save")
+
+	rec := httptest.NewRecorder()
+	Handler(ops).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `test_total{op="save"} 1`) {
+		t.Errorf("unexpected body:\n%s", rec.Body)
+	}
+}
+
+func TestCounterVec_LabelMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc with the wrong number of label values did not panic")
+		}
+	}()
+	NewCounterVec("test_total", "Test.", "op").Inc()
+}
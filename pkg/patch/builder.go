@@ -0,0 +1,240 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Kinds of change a Builder records
+const (
+	changeSet         = "set"
+	changeRemove      = "remove"
+	changeAppend      = "append"
+	changeRemoveValue = "removeValue"
+)
+
+// PreferredPatchTypes lists the patch formats Builder can encode, richest
+// first. Negotiation picks the first one the server accepts that can
+// express the changes.
+var PreferredPatchTypes = []PatchType{JSONPatch, ShorthandPatch, JSONMergePatch}
+
+// Builder records changes to a document so they can be sent in whichever
+// patch format the server accepts. Paths use the shorthand patch grammar:
+// dotted field names, with [n] to index arrays.
+//
+// Example:
+//
+//	changes := patch.NewBuilder().
+//		Set("location", "row-4").
+//		Set("ports[0].speed", 100).
+//		Append("tags", "gpu").
+//		Remove("description")
+//
+//	body, err := changes.Encode(patch.JSONPatch)
+//	// [{"op":"add","path":"/location","value":"row-4"},
+//	//  {"op":"replace","path":"/ports/0/speed","value":100},
+//	//  {"op":"add","path":"/tags/-","value":"gpu"},
+//	//  {"op":"remove","path":"/description"}]
+//
+// The formats differ where the document does not match the changes: JSON
+// Patch and shorthand patches fail to set a field below a missing object or
+// to remove a missing field, where a JSON Merge Patch creates the object or
+// does nothing.
+type Builder struct {
+	changes []change
+}
+
+type change struct {
+	kind  string
+	path  string
+	value interface{}
+}
+
+// NewBuilder returns an empty Builder
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Set sets the field at path to value. A nil value removes the field, as
+// in JSON Merge Patch and shorthand patches.
+func (b *Builder) Set(path string, value interface{}) *Builder {
+	if value == nil {
+		return b.Remove(path)
+	}
+	b.changes = append(b.changes, change{kind: changeSet, path: path, value: value})
+	return b
+}
+
+// Remove removes the field or array element at path
+func (b *Builder) Remove(path string) *Builder {
+	b.changes = append(b.changes, change{kind: changeRemove, path: path})
+	return b
+}
+
+// Append appends value to the array at path
+func (b *Builder) Append(path string, value interface{}) *Builder {
+	b.changes = append(b.changes, change{kind: changeAppend, path: path, value: value})
+	return b
+}
+
+// RemoveValue removes every element equal to value from the array at path.
+// Only shorthand patches can express it.
+func (b *Builder) RemoveValue(path string, value interface{}) *Builder {
+	b.changes = append(b.changes, change{kind: changeRemoveValue, path: path, value: value})
+	return b
+}
+
+// Empty reports whether no changes were recorded
+func (b *Builder) Empty() bool {
+	return len(b.changes) == 0
+}
+
+// Supports reports whether the changes can be encoded as patchType. JSON
+// Merge Patch cannot index, append to or remove from arrays, and JSON Patch
+// cannot remove by value.
+func (b *Builder) Supports(patchType PatchType) bool {
+	for _, c := range b.changes {
+		switch patchType {
+		case JSONMergePatch:
+			if c.kind == changeAppend || c.kind == changeRemoveValue || strings.Contains(c.path, "[") {
+				return false
+			}
+		case JSONPatch:
+			if c.kind == changeRemoveValue {
+				return false
+			}
+		case ShorthandPatch:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Choose returns the first of PreferredPatchTypes that is in accepted and
+// can express the changes, and false if there is none
+func (b *Builder) Choose(accepted []PatchType) (PatchType, bool) {
+	for _, patchType := range PreferredPatchTypes {
+		if slices.Contains(accepted, patchType) && b.Supports(patchType) {
+			return patchType, true
+		}
+	}
+	return "", false
+}
+
+// Encode returns the changes as a patchType document
+func (b *Builder) Encode(patchType PatchType) ([]byte, error) {
+	if !b.Supports(patchType) {
+		return nil, fmt.Errorf("changes cannot be expressed as %s", patchType)
+	}
+	for _, c := range b.changes {
+		key, err := parseShorthandKey(c.path)
+		if err != nil {
+			return nil, err
+		}
+		if key.operator != shorthandSet {
+			return nil, fmt.Errorf("invalid path %q: use Append or RemoveValue for array operators", c.path)
+		}
+	}
+	switch patchType {
+	case JSONPatch:
+		return b.encodeJSONPatch()
+	case ShorthandPatch:
+		return b.encodeShorthand()
+	default:
+		return b.encodeMergePatch()
+	}
+}
+
+func (b *Builder) encodeJSONPatch() ([]byte, error) {
+	ops := make([]Operation, 0, len(b.changes))
+	for _, c := range b.changes {
+		key, _ := parseShorthandKey(c.path)
+		switch c.kind {
+		case changeSet:
+			// add replaces an existing member but inserts into an array
+			op := "add"
+			if strings.HasSuffix(c.path, "]") {
+				op = "replace"
+			}
+			ops = append(ops, Operation{Op: op, Path: key.pointer, Value: c.value})
+		case changeRemove:
+			ops = append(ops, Operation{Op: "remove", Path: key.pointer})
+		case changeAppend:
+			ops = append(ops, Operation{Op: "add", Path: key.pointer + "/-", Value: c.value})
+		}
+	}
+	return json.Marshal(ops)
+}
+
+func (b *Builder) encodeShorthand() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, c := range b.changes {
+		key := c.path
+		switch c.kind {
+		case changeAppend:
+			key += shorthandAppend
+		case changeRemoveValue:
+			key += shorthandRemove
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(c.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", c.path, err)
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (b *Builder) encodeMergePatch() ([]byte, error) {
+	doc := map[string]interface{}{}
+	for _, c := range b.changes {
+		segments := strings.Split(c.path, ".")
+		parent := doc
+		for _, segment := range segments[:len(segments)-1] {
+			child, ok := parent[segment].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				parent[segment] = child
+			}
+			parent = child
+		}
+		parent[segments[len(segments)-1]] = c.value
+	}
+	return json.Marshal(doc)
+}
+
+// ParseAcceptPatch returns the patch formats listed in Accept-Patch headers
+// (RFC 5789), which may repeat the header or separate formats with commas.
+// Media type parameters are ignored.
+func ParseAcceptPatch(header http.Header) []PatchType {
+	var types []PatchType
+	for _, value := range header.Values("Accept-Patch") {
+		for _, mediaType := range strings.Split(value, ",") {
+			mediaType, _, _ = strings.Cut(mediaType, ";")
+			if mediaType = strings.ToLower(strings.TrimSpace(mediaType)); mediaType != "" {
+				types = append(types, PatchType(mediaType))
+			}
+		}
+	}
+	return types
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	original := []byte(`{"location":"row-1","description":"old","ports":[{"speed":10}],"tags":["a"],"bmc":{"ip":"10.0.0.1"}}`)
+	want := `{"bmc":{"ip":"10.0.0.2"},"location":"row-4","ports":[{"speed":100}],"tags":["a","gpu"]}`
+
+	changes := NewBuilder().
+		Set("location", "row-4").
+		Set("bmc.ip", "10.0.0.2").
+		Set("ports[0].speed", 100).
+		Append("tags", "gpu").
+		Set("description", nil)
+
+	for _, patchType := range []PatchType{JSONPatch, ShorthandPatch} {
+		body, err := changes.Encode(patchType)
+		if err != nil {
+			t.Fatalf("Encode(%s) failed: %v", patchType, err)
+		}
+		got, err := ApplyPatch(original, body, patchType)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", patchType, body, err)
+		}
+		if !jsonEqual(t, got, want) {
+			t.Errorf("%s %s gave %s, want %s", patchType, body, got, want)
+		}
+	}
+
+	if changes.Supports(JSONMergePatch) {
+		t.Error("JSON Merge Patch cannot index or append to arrays")
+	}
+	if _, err := changes.Encode(JSONMergePatch); err == nil {
+		t.Error("Encode(JSONMergePatch) should fail for array changes")
+	}
+
+	merge := NewBuilder().Set("location", "row-4").Set("bmc.ip", "10.0.0.2").Remove("description")
+	body, err := merge.Encode(JSONMergePatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"bmc":{"ip":"10.0.0.2"},"description":null,"location":"row-4"}` {
+		t.Errorf("merge patch = %s", body)
+	}
+
+	if _, err := NewBuilder().Set("tags[]", "x").Encode(ShorthandPatch); err == nil {
+		t.Error("Encode should reject array operators in Set paths")
+	}
+	if _, err := NewBuilder().Set("ports[x]", 1).Encode(JSONPatch); err == nil {
+		t.Error("Encode should reject invalid paths")
+	}
+}
+
+func TestBuilder_Choose(t *testing.T) {
+	all := []PatchType{JSONMergePatch, JSONPatch, ShorthandPatch}
+
+	tests := []struct {
+		name     string
+		changes  *Builder
+		accepted []PatchType
+		want     PatchType
+		ok       bool
+	}{
+		{"richest accepted", NewBuilder().Set("a", 1), all, JSONPatch, true},
+		{"server order does not matter", NewBuilder().Set("a", 1), []PatchType{ShorthandPatch, JSONMergePatch}, ShorthandPatch, true},
+		{"only merge patch", NewBuilder().Set("a", 1), []PatchType{JSONMergePatch}, JSONMergePatch, true},
+		{"remove by value needs shorthand", NewBuilder().RemoveValue("tags", "a"), all, ShorthandPatch, true},
+		{"append without a format for it", NewBuilder().Append("tags", "a"), []PatchType{JSONMergePatch}, "", false},
+		{"unknown formats are skipped", NewBuilder().Set("a", 1), []PatchType{StrategicMergePatch}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.changes.Choose(tt.accepted)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: Choose = %q %v, want %q %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseAcceptPatch(t *testing.T) {
+	header := http.Header{}
+	header.Add("Accept-Patch", "application/merge-patch+json")
+	header.Add("Accept-Patch", "Application/JSON-Patch+JSON; charset=utf-8, application/shorthand-patch+json")
+
+	want := []PatchType{JSONMergePatch, JSONPatch, ShorthandPatch}
+	if got := ParseAcceptPatch(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAcceptPatch = %v, want %v", got, want)
+	}
+	if got := ParseAcceptPatch(http.Header{}); len(got) != 0 {
+		t.Errorf("ParseAcceptPatch of no header = %v", got)
+	}
+}
+
+// jsonEqual reports whether got is the JSON document want, whatever the
+// order of its object members
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var gotDoc, wantDoc interface{}
+	if err := json.Unmarshal(got, &gotDoc); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantDoc); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(gotDoc, wantDoc)
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrPatchTooLarge is returned by ApplyPatch and ValidateJSONPatch for a
+// patch over one of the current Limits. Generated PATCH handlers report it
+// as 413 Request Entity Too Large.
+var ErrPatchTooLarge = errors.New("patch too large")
+
+// Limits bounds the patches ApplyPatch and ValidateJSONPatch accept, so a
+// single request cannot make the server apply thousands of operations or
+// recurse through deeply nested values. A zero or negative field disables
+// that limit.
+type Limits struct {
+	// MaxBytes is the largest patch document, in bytes
+	MaxBytes int64
+
+	// MaxOperations is the most operations in a JSON Patch, or keys in a
+	// shorthand patch
+	MaxOperations int
+
+	// MaxDepth is the deepest nesting of objects and arrays in a patch
+	// document, and the most segments in a shorthand patch key
+	MaxDepth int
+}
+
+// DefaultLimits are the limits applied until SetLimits is called. They are
+// far above what a hand-written or generated patch needs.
+var DefaultLimits = Limits{
+	MaxBytes:      1 << 20, // 1 MiB
+	MaxOperations: 1000,
+	MaxDepth:      64,
+}
+
+var limits atomic.Pointer[Limits]
+
+func init() {
+	SetLimits(DefaultLimits)
+}
+
+// SetLimits sets the limits applied by ApplyPatch and ValidateJSONPatch.
+func SetLimits(l Limits) {
+	limits.Store(&l)
+}
+
+// CurrentLimits returns the limits applied by ApplyPatch and
+// ValidateJSONPatch.
+func CurrentLimits() Limits {
+	return *limits.Load()
+}
+
+// checkLimits checks a patch document against the current limits. Invalid
+// JSON is left for the patch parser to report.
+func checkLimits(patch []byte, patchType PatchType) error {
+	l := CurrentLimits()
+	if l.MaxBytes > 0 && int64(len(patch)) > l.MaxBytes {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrPatchTooLarge, len(patch), l.MaxBytes)
+	}
+
+	elements, depth, ok := scanPatch(patch)
+	if !ok {
+		return nil
+	}
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("%w: nested %d levels deep, limit is %d", ErrPatchTooLarge, depth, l.MaxDepth)
+	}
+	if l.MaxOperations > 0 && (patchType == JSONPatch || patchType == ShorthandPatch) && elements > l.MaxOperations {
+		return fmt.Errorf("%w: %d operations, limit is %d", ErrPatchTooLarge, elements, l.MaxOperations)
+	}
+
+	if patchType == ShorthandPatch && l.MaxDepth > 0 {
+		var shorthand map[string]json.RawMessage
+		if json.Unmarshal(patch, &shorthand) != nil {
+			return nil
+		}
+		for key := range shorthand {
+			if segments := strings.Count(key, ".") + 1; segments > l.MaxDepth {
+				return fmt.Errorf("%w: a key has %d segments, limit is %d", ErrPatchTooLarge, segments, l.MaxDepth)
+			}
+		}
+	}
+	return nil
+}
+
+// scanPatch counts the top-level elements of a patch document (array
+// elements or object members) and measures its nesting depth without
+// decoding it. ok is false if patch is not valid JSON.
+func scanPatch(patch []byte) (elements, depth int, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(patch))
+	level := 0
+	tokens := 0 // Tokens starting at level 1: values, and keys in an object
+	var top json.Delim
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, 0, false
+		}
+		switch tok {
+		case json.Delim('}'), json.Delim(']'):
+			level--
+			continue
+		}
+		if level == 1 {
+			tokens++
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			if level == 0 {
+				top = tok.(json.Delim)
+			}
+			level++
+			depth = max(depth, level)
+		}
+	}
+	if top == '{' {
+		// Each member is a key followed by the token starting its value
+		return tokens / 2, depth, true
+	}
+	return tokens, depth, true
+}
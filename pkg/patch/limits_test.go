@@ -0,0 +1,82 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	previous := CurrentLimits()
+	t.Cleanup(func() { SetLimits(previous) })
+	SetLimits(Limits{MaxBytes: 200, MaxOperations: 3, MaxDepth: 4})
+
+	original := []byte(`{"name":"r1","nodes":["a","b"]}`)
+	op := `{"op":"add","path":"/nodes/-","value":"c"}`
+
+	tests := []struct {
+		name      string
+		patch     string
+		patchType PatchType
+		tooLarge  bool
+	}{
+		{"operations at the limit", "[" + strings.Repeat(op+",", 2) + op + "]", JSONPatch, false},
+		{"too many operations", "[" + strings.Repeat(op+",", 3) + op + "]", JSONPatch, true},
+		{"too many bytes", `{"name":"` + strings.Repeat("x", 200) + `"}`, JSONMergePatch, true},
+		{"merge patch at the depth limit", `{"a":{"b":{"c":[1]}}}`, JSONMergePatch, false},
+		{"merge patch too deep", `{"a":{"b":{"c":{"d":[1]}}}}`, JSONMergePatch, true},
+		{"JSON Patch value too deep", `[{"op":"add","path":"/a","value":{"b":{"c":{}}}}]`, JSONPatch, true},
+		{"shorthand keys at the limit", `{"a":1,"b":2,"c":3}`, ShorthandPatch, false},
+		{"too many shorthand keys", `{"a":1,"b":2,"c":3,"d":4}`, ShorthandPatch, true},
+		{"shorthand key at the depth limit", `{"a.b.c.d":1}`, ShorthandPatch, false},
+		{"shorthand key too deep", `{"a.b.c.d.e":1}`, ShorthandPatch, true},
+		{"merge patch members are not operations", `{"a":1,"b":2,"c":3,"d":4}`, JSONMergePatch, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyPatch(original, []byte(tt.patch), tt.patchType)
+			if got := errors.Is(err, ErrPatchTooLarge); got != tt.tooLarge {
+				t.Errorf("ApplyPatch err = %v, want ErrPatchTooLarge %v", err, tt.tooLarge)
+			}
+			if tt.patchType != JSONPatch {
+				return
+			}
+			if got := errors.Is(ValidateJSONPatch([]byte(tt.patch)), ErrPatchTooLarge); got != tt.tooLarge {
+				t.Errorf("ValidateJSONPatch too large = %v, want %v", got, tt.tooLarge)
+			}
+		})
+	}
+
+	SetLimits(Limits{})
+	huge := "[" + strings.Repeat(op+",", 100) + op + "]"
+	if _, err := ApplyPatch(original, []byte(huge), JSONPatch); err != nil {
+		t.Errorf("zero limits should disable the checks: %v", err)
+	}
+}
+
+func TestLimits_Middleware(t *testing.T) {
+	previous := CurrentLimits()
+	t.Cleanup(func() { SetLimits(previous) })
+	SetLimits(Limits{MaxOperations: 1})
+
+	handler := &PatchHandler{
+		GetResource: func(*http.Request) ([]byte, error) { return []byte(`{"a":1}`), nil },
+		SaveResource: func(*http.Request, []byte) error {
+			t.Error("a patch over the limit should not be saved")
+			return nil
+		},
+	}
+	r := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`[{"op":"remove","path":"/a"},{"op":"add","path":"/b","value":2}]`))
+	r.Header.Set("Content-Type", string(JSONPatch))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413: %s", w.Code, w.Body.String())
+	}
+}
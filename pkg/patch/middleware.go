@@ -7,9 +7,12 @@ package patch
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/openchami/fabrica/pkg/httperror"
 )
 
 // PatchHandler wraps a handler to provide PATCH support
@@ -26,14 +29,14 @@ type PatchHandler struct {
 func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Only handle PATCH requests
 	if r.Method != http.MethodPatch {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
 		return
 	}
 
 	// Get current resource state
 	original, err := ph.GetResource(r)
 	if err != nil {
-		respondError(w, http.StatusNotFound, fmt.Errorf("resource not found: %w", err))
+		respondError(w, r, http.StatusNotFound, fmt.Errorf("resource not found: %w", err))
 		return
 	}
 
@@ -41,14 +44,14 @@ func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if ph.Options.RequireETag {
 		etag := r.Header.Get("If-Match")
 		if etag == "" {
-			respondError(w, http.StatusPreconditionRequired, fmt.Errorf("If-Match header required"))
+			respondError(w, r, http.StatusPreconditionRequired, fmt.Errorf("If-Match header required"))
 			return
 		}
 
 		// Validate ETag
 		currentETag := ph.ETagGenerator(original)
 		if etag != currentETag {
-			respondError(w, http.StatusPreconditionFailed, fmt.Errorf("ETag mismatch"))
+			respondError(w, r, http.StatusPreconditionFailed, fmt.Errorf("ETag mismatch"))
 			return
 		}
 	}
@@ -56,7 +59,7 @@ func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Read patch document
 	patchData, err := io.ReadAll(r.Body)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		respondError(w, r, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
 		return
 	}
 	if closeErr := r.Body.Close(); closeErr != nil {
@@ -70,7 +73,7 @@ func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Validate patch based on type
 	if patchType == JSONPatch {
 		if err := ValidateJSONPatch(patchData); err != nil {
-			respondError(w, http.StatusBadRequest, err)
+			respondPatchError(w, r, http.StatusBadRequest, err)
 			return
 		}
 	}
@@ -78,7 +81,7 @@ func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Apply patch with options
 	result, err := ApplyPatchWithOptions(original, patchData, patchType, ph.Options)
 	if err != nil {
-		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch: %w", err))
+		respondPatchError(w, r, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch: %w", err))
 		return
 	}
 
@@ -87,7 +90,7 @@ func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		if encErr := json.NewEncoder(w).Encode(result); encErr != nil {
-			respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to encode result: %w", encErr))
+			respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to encode result: %w", encErr))
 		}
 		return
 	}
@@ -95,7 +98,7 @@ func (ph *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Save updated resource
 	if result.Modified {
 		if err := ph.SaveResource(r, result.Updated); err != nil {
-			respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save resource: %w", err))
+			respondError(w, r, http.StatusInternalServerError, fmt.Errorf("failed to save resource: %w", err))
 			return
 		}
 	}
@@ -141,13 +144,18 @@ func PatchMiddleware(getFunc func(*http.Request) ([]byte, error), saveFunc func(
 	}
 }
 
-// respondError sends an error response
-func respondError(w http.ResponseWriter, status int, err error) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(map[string]string{
-		"error": err.Error(),
-	})
+// respondError sends an application/problem+json error response
+func respondError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	httperror.WriteError(w, r, status, err)
+}
+
+// respondPatchError reports a patch that could not be applied: 413 for
+// ErrPatchTooLarge, status otherwise.
+func respondPatchError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if errors.Is(err, ErrPatchTooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	respondError(w, r, status, err)
 }
 
 // AutoPatchMiddleware automatically generates PATCH from existing GET and PUT handlers
@@ -164,7 +172,7 @@ func AutoPatchMiddleware(_ string) func(http.Handler) http.Handler {
 			// Read patch document
 			patchData, err := io.ReadAll(r.Body)
 			if err != nil {
-				respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch: %w", err))
+				respondError(w, r, http.StatusBadRequest, fmt.Errorf("failed to read patch: %w", err))
 				return
 			}
 			_ = r.Body.Close()
@@ -199,7 +207,7 @@ func AutoPatchMiddleware(_ string) func(http.Handler) http.Handler {
 			patchType := DetectPatchType(r.Header.Get("Content-Type"))
 			updated, err := ApplyPatch(original, patchData, patchType)
 			if err != nil {
-				respondError(w, http.StatusUnprocessableEntity, err)
+				respondPatchError(w, r, http.StatusUnprocessableEntity, err)
 				return
 			}
 
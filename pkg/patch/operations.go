@@ -105,6 +105,12 @@ func ApplyMergePatch(original, patch []byte) ([]byte, error) {
 }
 
 // ApplyJSONPatch applies a JSON Patch (RFC 6902)
+//
+// Operations are applied in order, and each path and from pointer is
+// resolved against the document as the earlier operations left it. A
+// pointer that does not resolve, such as an array index past the end or a
+// move from a missing field, fails with a *PointerError naming the
+// operation.
 func ApplyJSONPatch(original, patch []byte) ([]byte, error) {
 	if len(original) == 0 {
 		return nil, fmt.Errorf("original document is empty")
@@ -120,87 +126,125 @@ func ApplyJSONPatch(original, patch []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to decode JSON Patch: %w", err)
 	}
 
-	// Apply patch
-	modified, err := patchObj.Apply(original)
-	if err != nil {
-		return nil, fmt.Errorf("failed to apply JSON Patch: %w", err)
+	options := jsonpatch.NewApplyOptions()
+	options.SupportNegativeIndices = false
+
+	modified := original
+	for i, op := range patchObj {
+		var doc interface{}
+		if err := json.Unmarshal(modified, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse document: %w", err)
+		}
+		if err := checkOperation(doc, i, op); err != nil {
+			return nil, fmt.Errorf("failed to apply JSON Patch: %w", err)
+		}
+
+		modified, err = jsonpatch.Patch{op}.ApplyWithOptions(modified, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply JSON Patch: operation %d (%s): %w", i, op.Kind(), err)
+		}
 	}
 
 	return modified, nil
 }
 
-// ApplyShorthandPatch applies a simplified patch format
-// Shorthand format: {"field.path": "value", "other.field": null}
-// null values remove the field
-func ApplyShorthandPatch(original, patch []byte) ([]byte, error) {
-	// Parse shorthand into standard operations
-	var shorthand map[string]interface{}
-	if err := json.Unmarshal(patch, &shorthand); err != nil {
-		return nil, fmt.Errorf("invalid shorthand patch: %w", err)
+// checkOperation resolves the pointers of the i-th operation of a patch
+// against doc before it is applied.
+func checkOperation(doc interface{}, i int, op jsonpatch.Operation) error {
+	kind := op.Kind()
+	path, err := op.Path()
+	if err != nil {
+		return fmt.Errorf("operation %d (%s): %w", i, kind, err)
 	}
 
-	// Parse original to check which paths exist
-	var originalDoc interface{}
-	if err := json.Unmarshal(original, &originalDoc); err != nil {
-		return nil, fmt.Errorf("failed to parse original document: %w", err)
+	switch kind {
+	case "move", "copy":
+		from, err := op.From()
+		if err != nil {
+			return fmt.Errorf("operation %d (%s): %w", i, kind, err)
+		}
+		if err := checkFrom(kind, from, path); err != nil {
+			return &PointerError{Index: i, Op: kind, Field: "from", Pointer: from, Err: err}
+		}
+		if err := resolvePointer(doc, from, true); err != nil {
+			return &PointerError{Index: i, Op: kind, Field: "from", Pointer: from, Err: err}
+		}
+		if err := resolvePointer(doc, path, false); err != nil {
+			return &PointerError{Index: i, Op: kind, Field: "path", Pointer: path, Err: err}
+		}
+	case "add":
+		if err := resolvePointer(doc, path, false); err != nil {
+			return &PointerError{Index: i, Op: kind, Field: "path", Pointer: path, Err: err}
+		}
+	case "remove", "replace", "test":
+		if err := resolvePointer(doc, path, true); err != nil {
+			return &PointerError{Index: i, Op: kind, Field: "path", Pointer: path, Err: err}
+		}
 	}
+	return nil
+}
 
-	// Convert shorthand to JSON Patch operations
-	var ops []Operation
-	for path, value := range shorthand {
-		// Convert dot notation to JSON Pointer
-		pointer := "/" + strings.ReplaceAll(path, ".", "/")
-
-		if value == nil {
-			// null means remove
-			ops = append(ops, Operation{
-				Op:   "remove",
-				Path: pointer,
-			})
-		} else {
-			// Check if path exists in original - use add if not, replace if yes
-			exists := pathExists(originalDoc, strings.Split(path, "."))
-			op := "replace"
-			if !exists {
-				op = "add"
-			}
-			ops = append(ops, Operation{
-				Op:    op,
-				Path:  pointer,
-				Value: value,
-			})
-		}
+// checkFrom checks the from pointer of a move or copy without a document:
+// it must be a valid JSON Pointer, and a value cannot be moved into one of
+// its own children.
+func checkFrom(kind, from, path string) error {
+	if _, err := pointerTokens(from); err != nil {
+		return err
+	}
+	if kind == "move" && strings.HasPrefix(path, from+"/") {
+		return fmt.Errorf("%w: cannot move a value into one of its children (%s)", ErrInvalidPointer, path)
 	}
+	return nil
+}
 
-	// Convert operations to JSON and apply as JSON Patch
-	opsJSON, err := json.Marshal(ops)
+// ApplyShorthandPatch applies a simplified patch format
+// Shorthand format: {"field.path": "value", "other.field": null}
+// null values remove the field. Keys can also index and modify arrays:
+//
+//	{"ports[0].speed": 100}  // set a field of the first element
+//	{"tags[]": "gpu"}        // append "gpu" to tags
+//	{"tags[-]": "old"}       // remove every "old" from tags
+//
+// Keys are applied in document order, each to the result of the previous
+// ones.
+func ApplyShorthandPatch(original, patch []byte) ([]byte, error) {
+	// Parse shorthand keys in order
+	keys, values, err := decodeShorthand(patch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal operations: %w", err)
+		return nil, fmt.Errorf("invalid shorthand patch: %w", err)
 	}
 
-	return ApplyJSONPatch(original, opsJSON)
-}
+	modified := original
+	for i, key := range keys {
+		parsed, err := parseShorthandKey(key)
+		if err != nil {
+			return nil, err
+		}
 
-// pathExists checks if a path exists in a document
-func pathExists(doc interface{}, path []string) bool {
-	if len(path) == 0 {
-		return true
-	}
+		// Parse the current document to see which paths exist
+		var doc interface{}
+		if err := json.Unmarshal(modified, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse original document: %w", err)
+		}
 
-	switch v := doc.(type) {
-	case map[string]interface{}:
-		if val, ok := v[path[0]]; ok {
-			if len(path) == 1 {
-				return true
-			}
-			return pathExists(val, path[1:])
+		// Convert the key to JSON Patch operations and apply them
+		ops, err := shorthandOperations(doc, parsed, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("shorthand key %q: %w", key, err)
+		}
+		if len(ops) == 0 {
+			continue
+		}
+		opsJSON, err := json.Marshal(ops)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal operations: %w", err)
+		}
+		if modified, err = ApplyJSONPatch(modified, opsJSON); err != nil {
+			return nil, fmt.Errorf("shorthand key %q: %w", key, err)
 		}
-	case []interface{}:
-		// Array index - not supporting in shorthand for simplicity
-		return false
 	}
 
-	return false
+	return modified, nil
 }
 
 // DetectPatchType determines the patch type from Content-Type header
@@ -227,8 +271,13 @@ func DetectPatchType(contentType string) PatchType {
 	}
 }
 
-// ApplyPatch applies the appropriate patch based on the patch type
+// ApplyPatch applies the appropriate patch based on the patch type. Patches
+// over the current Limits fail with ErrPatchTooLarge before being parsed.
 func ApplyPatch(original []byte, patchData []byte, patchType PatchType) ([]byte, error) {
+	if err := checkLimits(patchData, patchType); err != nil {
+		return nil, err
+	}
+
 	switch patchType {
 	case JSONMergePatch:
 		return ApplyMergePatch(original, patchData)
@@ -241,8 +290,17 @@ func ApplyPatch(original []byte, patchData []byte, patchType PatchType) ([]byte,
 	}
 }
 
-// ValidateJSONPatch validates JSON Patch operations
+// ValidateJSONPatch validates JSON Patch operations without a document. It
+// checks that every operation has a known op, a path and the value or from
+// it needs, and that the from of move and copy is a valid JSON Pointer a
+// move can take its value from. Pointers are resolved against the document
+// when the patch is applied. Patches over the current Limits fail with
+// ErrPatchTooLarge.
 func ValidateJSONPatch(patch []byte) error {
+	if err := checkLimits(patch, JSONPatch); err != nil {
+		return err
+	}
+
 	var ops []Operation
 	if err := json.Unmarshal(patch, &ops); err != nil {
 		return fmt.Errorf("invalid JSON Patch format: %w", err)
@@ -277,6 +335,9 @@ func ValidateJSONPatch(patch []byte) error {
 			if op.From == "" {
 				return fmt.Errorf("missing from field for %s operation at index %d", op.Op, i)
 			}
+			if err := checkFrom(op.Op, op.From, op.Path); err != nil {
+				return &PointerError{Index: i, Op: op.Op, Field: "from", Pointer: op.From, Err: err}
+			}
 		}
 	}
 
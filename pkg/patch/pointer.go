@@ -0,0 +1,163 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPointer is wrapped by PointerError for a path or from that
+	// is not a valid JSON Pointer (RFC 6901), or cannot be used with its
+	// operation.
+	ErrInvalidPointer = errors.New("invalid JSON Pointer")
+
+	// ErrPathNotFound is wrapped by PointerError for a pointer naming a
+	// missing object member, or descending into a string, number, boolean
+	// or null.
+	ErrPathNotFound = errors.New("path does not exist")
+
+	// ErrIndexOutOfRange is wrapped by PointerError for an array index past
+	// the end of the array, a negative index, or "-" where an existing
+	// element is required.
+	ErrIndexOutOfRange = errors.New("array index out of range")
+)
+
+// PointerError reports a JSON Patch operation whose path or from pointer
+// does not resolve against the document. It wraps one of the sentinel
+// errors above, so callers can tell a bad index from a missing field:
+//
+//	_, err := patch.ApplyJSONPatch(doc, ops)
+//	var ptrErr *patch.PointerError
+//	if errors.As(err, &ptrErr) && errors.Is(err, patch.ErrIndexOutOfRange) {
+//		log.Printf("operation %d: %s %s is out of range", ptrErr.Index, ptrErr.Field, ptrErr.Pointer)
+//	}
+type PointerError struct {
+	Index   int    // Position of the operation in the patch
+	Op      string // Operation, e.g. "move"
+	Field   string // "path" or "from"
+	Pointer string // The pointer that failed to resolve
+	Err     error  // Cause, one of the sentinel errors above
+}
+
+func (e *PointerError) Error() string {
+	return fmt.Sprintf("operation %d (%s): %s %q: %v", e.Index, e.Op, e.Field, e.Pointer, e.Err)
+}
+
+func (e *PointerError) Unwrap() error {
+	return e.Err
+}
+
+// pointerTokens splits a JSON Pointer into its unescaped reference tokens.
+// The empty pointer names the whole document and has no tokens.
+func pointerTokens(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: must be empty or start with /", ErrInvalidPointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] != '~' {
+				continue
+			}
+			if j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1') {
+				return nil, fmt.Errorf("%w: ~ must be followed by 0 or 1", ErrInvalidPointer)
+			}
+			j++
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// resolvePointer checks that pointer can be used against doc, a document
+// decoded with encoding/json. With existing set, the pointer must name a
+// value in doc, as remove, replace, test and the from of move and copy
+// require. Otherwise only its parent must exist, and the last token may
+// name a new object member, the position after the last array element, or
+// "-", as for the path of add, move and copy.
+func resolvePointer(doc interface{}, pointer string, existing bool) error {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return err
+	}
+
+	current := doc
+	for i, token := range tokens {
+		last := i == len(tokens)-1
+		switch v := current.(type) {
+		case map[string]interface{}:
+			member, ok := v[token]
+			if !ok {
+				if last && !existing {
+					return nil
+				}
+				return fmt.Errorf("%w: no member %q", ErrPathNotFound, token)
+			}
+			current = member
+		case []interface{}:
+			if token == "-" {
+				if last && !existing {
+					return nil
+				}
+				return fmt.Errorf("%w: \"-\" names the position after the last element", ErrIndexOutOfRange)
+			}
+			index, err := arrayIndex(token)
+			if err != nil {
+				return err
+			}
+			limit := len(v)
+			if last && !existing {
+				limit++
+			}
+			if index >= limit {
+				return fmt.Errorf("%w: index %d, array has %d elements", ErrIndexOutOfRange, index, len(v))
+			}
+			if index < len(v) {
+				current = v[index]
+			}
+		default:
+			return fmt.Errorf("%w: %q is below a %s", ErrPathNotFound, token, jsonTypeName(v))
+		}
+	}
+	return nil
+}
+
+// arrayIndex parses an array index token. RFC 6901 allows only decimal
+// digits without leading zeros; negative indices are rejected rather than
+// counted from the end.
+func arrayIndex(token string) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: array index %q is not a number", ErrInvalidPointer, token)
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("%w: negative index %d", ErrIndexOutOfRange, index)
+	}
+	if token != strconv.Itoa(index) {
+		return 0, fmt.Errorf("%w: array index %q has leading zeros or a sign", ErrInvalidPointer, token)
+	}
+	return index, nil
+}
+
+// jsonTypeName names the JSON type of a decoded scalar for error messages.
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return "number"
+	}
+}
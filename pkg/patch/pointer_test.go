@@ -0,0 +1,130 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyJSONPatch_NestedArrays(t *testing.T) {
+	original := []byte(`{"racks":[{"name":"r1","nodes":["a","b","c"]},{"name":"r2","nodes":[]}]}`)
+
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "move between nested arrays",
+			patch: `[{"op":"move","from":"/racks/0/nodes/1","path":"/racks/1/nodes/-"}]`,
+			want:  `{"racks":[{"name":"r1","nodes":["a","c"]},{"name":"r2","nodes":["b"]}]}`,
+		},
+		{
+			name:  "copy to the end of the same array",
+			patch: `[{"op":"copy","from":"/racks/0/nodes/0","path":"/racks/0/nodes/3"}]`,
+			want:  `{"racks":[{"name":"r1","nodes":["a","b","c","a"]},{"name":"r2","nodes":[]}]}`,
+		},
+		{
+			name:  "move within an array",
+			patch: `[{"op":"move","from":"/racks/0/nodes/2","path":"/racks/0/nodes/0"}]`,
+			want:  `{"racks":[{"name":"r1","nodes":["c","a","b"]},{"name":"r2","nodes":[]}]}`,
+		},
+		{
+			name:  "later operations see earlier ones",
+			patch: `[{"op":"add","path":"/racks/1/nodes/0","value":"d"},{"op":"move","from":"/racks/1/nodes/0","path":"/racks/0/nodes/-"}]`,
+			want:  `{"racks":[{"name":"r1","nodes":["a","b","c","d"]},{"name":"r2","nodes":[]}]}`,
+		},
+		{
+			name:  "copy a whole element",
+			patch: `[{"op":"copy","from":"/racks/0","path":"/racks/-"},{"op":"replace","path":"/racks/2/name","value":"r3"}]`,
+			want:  `{"racks":[{"name":"r1","nodes":["a","b","c"]},{"name":"r2","nodes":[]},{"name":"r3","nodes":["a","b","c"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch(original, []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("ApplyJSONPatch failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch_PointerErrors(t *testing.T) {
+	original := []byte(`{"name":"r1","nodes":["a","b","c"],"slots":[[1,2],[3]]}`)
+
+	tests := []struct {
+		name    string
+		patch   string
+		index   int
+		field   string
+		pointer string
+		err     error
+	}{
+		{"from past the end", `[{"op":"move","from":"/nodes/3","path":"/nodes/0"}]`, 0, "from", "/nodes/3", ErrIndexOutOfRange},
+		{"nested from past the end", `[{"op":"copy","from":"/slots/1/1","path":"/slots/0/-"}]`, 0, "from", "/slots/1/1", ErrIndexOutOfRange},
+		{"from dash", `[{"op":"move","from":"/nodes/-","path":"/name"}]`, 0, "from", "/nodes/-", ErrIndexOutOfRange},
+		{"negative from", `[{"op":"copy","from":"/nodes/-1","path":"/nodes/-"}]`, 0, "from", "/nodes/-1", ErrIndexOutOfRange},
+		{"missing from", `[{"op":"move","from":"/owner","path":"/name"}]`, 0, "from", "/owner", ErrPathNotFound},
+		{"from below a string", `[{"op":"copy","from":"/name/first","path":"/alias"}]`, 0, "from", "/name/first", ErrPathNotFound},
+		{"from without slash", `[{"op":"move","from":"nodes/0","path":"/name"}]`, 0, "from", "nodes/0", ErrInvalidPointer},
+		{"from with leading zero", `[{"op":"copy","from":"/nodes/01","path":"/alias"}]`, 0, "from", "/nodes/01", ErrInvalidPointer},
+		{"move into its own child", `[{"op":"move","from":"/slots","path":"/slots/0/-"}]`, 0, "from", "/slots", ErrInvalidPointer},
+		{"path past the end", `[{"op":"move","from":"/name","path":"/nodes/4"}]`, 0, "path", "/nodes/4", ErrIndexOutOfRange},
+		{"add past the end", `[{"op":"add","path":"/slots/1/2","value":4}]`, 0, "path", "/slots/1/2", ErrIndexOutOfRange},
+		{"negative add", `[{"op":"add","path":"/nodes/-1","value":"d"}]`, 0, "path", "/nodes/-1", ErrIndexOutOfRange},
+		{"remove dash", `[{"op":"remove","path":"/nodes/-"}]`, 0, "path", "/nodes/-", ErrIndexOutOfRange},
+		{"replace past the end", `[{"op":"replace","path":"/nodes/3","value":"d"}]`, 0, "path", "/nodes/3", ErrIndexOutOfRange},
+		{"index after earlier remove", `[{"op":"remove","path":"/nodes/0"},{"op":"move","from":"/nodes/2","path":"/name"}]`, 1, "from", "/nodes/2", ErrIndexOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyJSONPatch(original, []byte(tt.patch))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			var ptrErr *PointerError
+			if !errors.As(err, &ptrErr) {
+				t.Fatalf("err = %v, want a *PointerError", err)
+			}
+			if ptrErr.Index != tt.index || ptrErr.Field != tt.field || ptrErr.Pointer != tt.pointer {
+				t.Errorf("PointerError = operation %d %s %q, want operation %d %s %q",
+					ptrErr.Index, ptrErr.Field, ptrErr.Pointer, tt.index, tt.field, tt.pointer)
+			}
+		})
+	}
+}
+
+func TestValidateJSONPatch_From(t *testing.T) {
+	valid := []byte(`[{"op":"move","from":"/nodes/0","path":"/nodes/-"},{"op":"copy","from":"/a~1b","path":"/c"}]`)
+	if err := ValidateJSONPatch(valid); err != nil {
+		t.Errorf("valid move and copy should not error: %v", err)
+	}
+
+	tests := map[string]string{
+		"missing from":      `[{"op":"move","path":"/name"}]`,
+		"relative from":     `[{"op":"copy","from":"name","path":"/alias"}]`,
+		"bad escape":        `[{"op":"copy","from":"/a~2b","path":"/alias"}]`,
+		"move into a child": `[{"op":"move","from":"/spec","path":"/spec/nested"}]`,
+		"second op from":    `[{"op":"remove","path":"/a"},{"op":"move","from":"a","path":"/b"}]`,
+		"trailing tilde":    `[{"op":"copy","from":"/a~","path":"/b"}]`,
+		"empty from":        `[{"op":"move","from":"","path":"/b"}]`,
+	}
+	for name, patch := range tests {
+		if err := ValidateJSONPatch([]byte(patch)); err == nil {
+			t.Errorf("%s: ValidateJSONPatch accepted %s", name, patch)
+		}
+	}
+
+	var ptrErr *PointerError
+	err := ValidateJSONPatch([]byte(`[{"op":"remove","path":"/a"},{"op":"move","from":"a","path":"/b"}]`))
+	if !errors.As(err, &ptrErr) || ptrErr.Index != 1 || !errors.Is(err, ErrInvalidPointer) {
+		t.Errorf("err = %v, want an invalid from at operation 1", err)
+	}
+}
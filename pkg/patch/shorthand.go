@@ -0,0 +1,187 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Array operators a shorthand key can end with
+const (
+	shorthandSet    = ""    // "field" or "tags[0]": add or replace the value
+	shorthandAppend = "[]"  // "tags[]": append the value
+	shorthandRemove = "[-]" // "tags[-]": remove every element equal to the value
+)
+
+// shorthandKey is a parsed shorthand patch key
+type shorthandKey struct {
+	pointer  string // JSON Pointer of the field, or of the array for [] and [-]
+	operator string // One of the shorthand operators
+}
+
+// parseShorthandKey parses a shorthand patch key. The grammar is:
+//
+//	key      = segment *( "." segment ) [ "[]" / "[-]" ]
+//	segment  = name *( "[" index "]" )
+//	index    = 1*DIGIT
+//
+// so "spec.ports[0].name" names a field of the first port, and "tags[]" and
+// "tags[-]" append to and remove from the tags array.
+func parseShorthandKey(key string) (shorthandKey, error) {
+	parsed := shorthandKey{operator: shorthandSet}
+	for _, operator := range []string{shorthandAppend, shorthandRemove} {
+		if strings.HasSuffix(key, operator) {
+			parsed.operator = operator
+			key = strings.TrimSuffix(key, operator)
+			break
+		}
+	}
+
+	var pointer strings.Builder
+	for _, segment := range strings.Split(key, ".") {
+		name, indices, hasIndices := strings.Cut(segment, "[")
+		if name == "" {
+			return parsed, fmt.Errorf("invalid shorthand key %q: empty field name", key)
+		}
+		pointer.WriteString("/" + escapePointerToken(name))
+		if !hasIndices {
+			continue
+		}
+		for indices = "[" + indices; indices != ""; {
+			end := strings.IndexByte(indices, ']')
+			if indices[0] != '[' || end == -1 {
+				return parsed, fmt.Errorf("invalid shorthand key %q: malformed array index in %q", key, segment)
+			}
+			if _, err := arrayIndex(indices[1:end]); err != nil {
+				return parsed, fmt.Errorf("invalid shorthand key %q: %w", key, err)
+			}
+			pointer.WriteString("/" + indices[1:end])
+			indices = indices[end+1:]
+		}
+	}
+	parsed.pointer = pointer.String()
+	return parsed, nil
+}
+
+// escapePointerToken escapes a field name for use in a JSON Pointer
+func escapePointerToken(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// shorthandOperations translates one shorthand key and its value into JSON
+// Patch operations against doc, the document as the earlier keys left it.
+func shorthandOperations(doc interface{}, key shorthandKey, value interface{}) ([]Operation, error) {
+	exists := resolvePointer(doc, key.pointer, true) == nil
+
+	switch key.operator {
+	case shorthandAppend:
+		if value == nil {
+			return nil, fmt.Errorf("cannot append null to %s", key.pointer)
+		}
+		if !exists {
+			// Appending to a missing array creates it
+			return []Operation{{Op: "add", Path: key.pointer, Value: []interface{}{value}}}, nil
+		}
+		return []Operation{{Op: "add", Path: key.pointer + "/-", Value: value}}, nil
+
+	case shorthandRemove:
+		if value == nil {
+			return nil, fmt.Errorf("cannot remove null from %s", key.pointer)
+		}
+		if !exists {
+			return nil, nil
+		}
+		array, ok := lookupPointer(doc, key.pointer).([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot remove a value from %s: not an array", key.pointer)
+		}
+		// Remove from the end so the earlier indices stay valid
+		var ops []Operation
+		for i := len(array) - 1; i >= 0; i-- {
+			if reflect.DeepEqual(array[i], value) {
+				ops = append(ops, Operation{Op: "remove", Path: key.pointer + "/" + strconv.Itoa(i)})
+			}
+		}
+		return ops, nil
+
+	default:
+		if value == nil {
+			// null means remove
+			return []Operation{{Op: "remove", Path: key.pointer}}, nil
+		}
+		// Use add if the path does not exist yet, replace if it does. An
+		// array index must name an existing element either way.
+		op := "replace"
+		if !exists && !isIndexPointer(doc, key.pointer) {
+			op = "add"
+		}
+		return []Operation{{Op: op, Path: key.pointer, Value: value}}, nil
+	}
+}
+
+// isIndexPointer reports whether the last token of pointer indexes an array
+// in doc
+func isIndexPointer(doc interface{}, pointer string) bool {
+	parent := pointer[:strings.LastIndex(pointer, "/")]
+	_, ok := lookupPointer(doc, parent).([]interface{})
+	return ok
+}
+
+// lookupPointer returns the value pointer names in doc, or nil if it names
+// nothing.
+func lookupPointer(doc interface{}, pointer string) interface{} {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return nil
+	}
+	current := doc
+	for _, token := range tokens {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			current = v[token]
+		case []interface{}:
+			index, err := arrayIndex(token)
+			if err != nil || index >= len(v) {
+				return nil
+			}
+			current = v[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// decodeShorthand decodes a shorthand patch into its keys and values, in
+// document order
+func decodeShorthand(patch []byte) ([]string, []interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(patch))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("shorthand patch must be a JSON object")
+	}
+	var keys []string
+	var values []interface{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, tok.(string))
+		values = append(values, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyShorthandPatch_Arrays(t *testing.T) {
+	original := []byte(`{"tags":["a","b","a"],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"matrix":[[1,2],[3]]}`)
+
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "append",
+			patch: `{"tags[]":"c"}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["a","b","a","c"]}`,
+		},
+		{
+			name:  "append an object",
+			patch: `{"ports[]":{"name":"eth2"}}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10},{"name":"eth2"}],"tags":["a","b","a"]}`,
+		},
+		{
+			name:  "append creates a missing array",
+			patch: `{"aliases[]":"x"}`,
+			want:  `{"aliases":["x"],"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["a","b","a"]}`,
+		},
+		{
+			name:  "remove every match",
+			patch: `{"tags[-]":"a"}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["b"]}`,
+		},
+		{
+			name:  "remove an object by value",
+			patch: `{"ports[-]":{"name":"eth0","speed":10}}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth1","speed":10}],"tags":["a","b","a"]}`,
+		},
+		{
+			name:  "remove a missing value",
+			patch: `{"tags[-]":"z","aliases[-]":"z"}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["a","b","a"]}`,
+		},
+		{
+			name:  "set by index",
+			patch: `{"tags[1]":"B"}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["a","B","a"]}`,
+		},
+		{
+			name:  "set a field of an element",
+			patch: `{"ports[1].speed":100,"ports[0].mtu":9000}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"mtu":9000,"name":"eth0","speed":10},{"name":"eth1","speed":100}],"tags":["a","b","a"]}`,
+		},
+		{
+			name:  "nested arrays",
+			patch: `{"matrix[1][]":4,"matrix[0][0]":0}`,
+			want:  `{"matrix":[[0,2],[3,4]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["a","b","a"]}`,
+		},
+		{
+			name:  "null removes an element",
+			patch: `{"tags[0]":null}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["b","a"]}`,
+		},
+		{
+			name:  "keys apply in order",
+			patch: `{"tags[-]":"a","tags[]":"a","tags[0]":"first"}`,
+			want:  `{"matrix":[[1,2],[3]],"ports":[{"name":"eth0","speed":10},{"name":"eth1","speed":10}],"tags":["first","a"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyShorthandPatch(original, []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("ApplyShorthandPatch failed: %v", err)
+			}
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyShorthandPatch_ArrayErrors(t *testing.T) {
+	original := []byte(`{"name":"r1","tags":["a","b"]}`)
+
+	tests := map[string]string{
+		"index past the end":    `{"tags[2]":"c"}`,
+		"negative index":        `{"tags[-1]":"c"}`,
+		"append null":           `{"tags[]":null}`,
+		"remove null":           `{"tags[-]":null}`,
+		"remove from a string":  `{"name[-]":"r"}`,
+		"index into a string":   `{"name[0]":"R"}`,
+		"unclosed bracket":      `{"tags[0":"c"}`,
+		"text after a bracket":  `{"tags[0]x":"c"}`,
+		"append in the middle":  `{"tags[].name":"c"}`,
+		"empty field name":      `{"[0]":"c"}`,
+		"non-numeric index":     `{"tags[first]":"c"}`,
+		"not an object":         `["tags[]"]`,
+		"index with leading 0s": `{"tags[01]":"c"}`,
+	}
+	for name, patch := range tests {
+		if _, err := ApplyShorthandPatch(original, []byte(patch)); err == nil {
+			t.Errorf("%s: ApplyShorthandPatch accepted %s", name, patch)
+		}
+	}
+
+	_, err := ApplyShorthandPatch(original, []byte(`{"tags[2]":"c"}`))
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("err = %v, want ErrIndexOutOfRange", err)
+	}
+}
+
+func TestParseShorthandKey(t *testing.T) {
+	tests := []struct {
+		key, pointer, operator string
+	}{
+		{"spec.name", "/spec/name", shorthandSet},
+		{"spec.tags[]", "/spec/tags", shorthandAppend},
+		{"spec.tags[-]", "/spec/tags", shorthandRemove},
+		{"spec.ports[10].name", "/spec/ports/10/name", shorthandSet},
+		{"matrix[0][1][]", "/matrix/0/1", shorthandAppend},
+		{"metadata.labels.app~io/tier", "/metadata/labels/app~0io~1tier", shorthandSet},
+	}
+	for _, tt := range tests {
+		got, err := parseShorthandKey(tt.key)
+		if err != nil {
+			t.Errorf("parseShorthandKey(%q) failed: %v", tt.key, err)
+			continue
+		}
+		if got.pointer != tt.pointer || got.operator != tt.operator {
+			t.Errorf("parseShorthandKey(%q) = %q %q, want %q %q", tt.key, got.pointer, got.operator, tt.pointer, tt.operator)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// Package quota limits how many resources may exist within a label scope.
+//
+// A Rule caps the number of resources of one type whose labels match a
+// selector, optionally counted separately for each value of a label
+// (e.g., at most 500 Devices per datacenter). The generated create handlers
+// consult the default Enforcer before saving a new resource.
+//
+// Quotas are best-effort under concurrency: the count and the save are
+// separate steps, so simultaneous creates can each see room for one more and
+// together exceed the limit. Strict enforcement needs a transactional count
+// in the storage backend.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrQuotaExceeded is returned (wrapped in an *ExceededError) when creating
+// a resource would exceed a quota rule.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Rule limits the number of resources of one type within a label scope.
+type Rule struct {
+	// Name identifies the rule in error messages (defaults to a description
+	// of the scope)
+	Name string `yaml:"name,omitempty"`
+
+	// ResourceType is the resource kind the rule applies to (e.g., "Device")
+	ResourceType string `yaml:"resourceType"`
+
+	// Selector restricts the rule to resources carrying all of these labels.
+	// An empty selector matches every resource of the type.
+	Selector map[string]string `yaml:"selector,omitempty"`
+
+	// PerLabel, if set, counts resources separately for each value of this
+	// label key. Resources without the label are not limited by the rule.
+	PerLabel string `yaml:"perLabel,omitempty"`
+
+	// Max is the maximum number of matching resources
+	Max int `yaml:"max"`
+}
+
+// Validate reports whether the rule is well-formed.
+func (r Rule) Validate() error {
+	if r.ResourceType == "" {
+		return fmt.Errorf("quota rule %q: resourceType is required", r.Name)
+	}
+	if r.Max < 0 {
+		return fmt.Errorf("quota rule %q: max must not be negative", r.Name)
+	}
+	return nil
+}
+
+// scope returns the selector that counts resources sharing a scope with a
+// resource carrying labels, and whether the rule applies to it at all.
+func (r Rule) scope(labels map[string]string) (map[string]string, bool) {
+	if !matches(labels, r.Selector) {
+		return nil, false
+	}
+	if r.PerLabel == "" {
+		return r.Selector, true
+	}
+
+	value, ok := labels[r.PerLabel]
+	if !ok {
+		return nil, false
+	}
+	selector := make(map[string]string, len(r.Selector)+1)
+	for key, v := range r.Selector {
+		selector[key] = v
+	}
+	selector[r.PerLabel] = value
+	return selector, true
+}
+
+// describe returns the rule name, or a description of the scope for a
+// resource carrying labels.
+func (r Rule) describe(scope map[string]string) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if len(scope) == 0 {
+		return r.ResourceType
+	}
+	keys := make([]string, 0, len(scope))
+	for key := range scope {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + scope[key]
+	}
+	return fmt.Sprintf("%s{%s}", r.ResourceType, strings.Join(pairs, ","))
+}
+
+// ExceededError reports the rule a create would have violated.
+type ExceededError struct {
+	// Rule is the violated rule
+	Rule Rule
+
+	// Scope is the label selector the count was taken over
+	Scope map[string]string
+
+	// Count is the number of existing resources in the scope
+	Count int
+}
+
+// Error implements error.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota %s exceeded: %d of %d %s resources already exist",
+		e.Rule.describe(e.Scope), e.Count, e.Rule.Max, e.Rule.ResourceType)
+}
+
+// Unwrap returns ErrQuotaExceeded so callers can use errors.Is.
+func (e *ExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Labeled is implemented by resources that carry labels. resource.Resource
+// implements it, so every embedded resource does.
+type Labeled interface {
+	GetLabels() map[string]string
+}
+
+// Enforcer checks creates against a set of quota rules.
+type Enforcer struct {
+	rules []Rule
+}
+
+// NewEnforcer creates an enforcer for the given rules.
+func NewEnforcer(rules ...Rule) (*Enforcer, error) {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &Enforcer{rules: append([]Rule(nil), rules...)}, nil
+}
+
+// Config is the format of a quota file.
+//
+// Example quota.yaml:
+//
+//	rules:
+//	  - name: devices-per-datacenter
+//	    resourceType: Device
+//	    perLabel: datacenter
+//	    max: 500
+//	  - resourceType: Device
+//	    selector:
+//	      role: gateway
+//	    max: 4
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadFile reads quota rules from a YAML file.
+func LoadFile(path string) (*Enforcer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quota file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse quota file %s: %w", path, err)
+	}
+	return NewEnforcer(config.Rules...)
+}
+
+// Rules returns the enforcer's rules.
+func (e *Enforcer) Rules() []Rule {
+	if e == nil {
+		return nil
+	}
+	return append([]Rule(nil), e.rules...)
+}
+
+// Enforce checks whether a new resource of resourceType carrying labels may
+// be created. loadAll is only called if a rule applies, so resource types
+// without quotas cost nothing.
+//
+// Returns:
+//   - an *ExceededError wrapping ErrQuotaExceeded if a rule's limit is reached
+//   - the error from loadAll if existing resources cannot be counted
+//   - nil if every applicable rule has room (or e is nil)
+func Enforce[T Labeled](ctx context.Context, e *Enforcer, resourceType string, labels map[string]string, loadAll func(context.Context) ([]T, error)) error {
+	if e == nil {
+		return nil
+	}
+
+	var existing []T
+	loaded := false
+	for _, rule := range e.rules {
+		if rule.ResourceType != resourceType {
+			continue
+		}
+		scope, ok := rule.scope(labels)
+		if !ok {
+			continue
+		}
+
+		if !loaded {
+			var err error
+			if existing, err = loadAll(ctx); err != nil {
+				return fmt.Errorf("failed to count %s resources for quota: %w", resourceType, err)
+			}
+			loaded = true
+		}
+
+		count := 0
+		for _, res := range existing {
+			if matches(res.GetLabels(), scope) {
+				count++
+			}
+		}
+		if count >= rule.Max {
+			return &ExceededError{Rule: rule, Scope: scope, Count: count}
+		}
+	}
+	return nil
+}
+
+func matches(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultEnforcer is consulted by Check.
+var defaultEnforcer *Enforcer
+var defaultEnforcerMutex sync.RWMutex
+
+// SetDefault installs the enforcer used by Check. Pass nil to disable quotas.
+//
+// Example:
+//
+//	enforcer, err := quota.LoadFile("quota.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	quota.SetDefault(enforcer)
+func SetDefault(e *Enforcer) {
+	defaultEnforcerMutex.Lock()
+	defer defaultEnforcerMutex.Unlock()
+	defaultEnforcer = e
+}
+
+// Default returns the enforcer used by Check, or nil if none is set.
+func Default() *Enforcer {
+	defaultEnforcerMutex.RLock()
+	defer defaultEnforcerMutex.RUnlock()
+	return defaultEnforcer
+}
+
+// Check enforces the default enforcer's rules; see Enforce.
+//
+// The generated create handlers call this after validation, passing the
+// resource type's LoadAll storage function.
+func Check[T Labeled](ctx context.Context, resourceType string, labels map[string]string, loadAll func(context.Context) ([]T, error)) error {
+	return Enforce(ctx, Default(), resourceType, labels, loadAll)
+}
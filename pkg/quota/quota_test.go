@@ -0,0 +1,148 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type labeled map[string]string
+
+func (l labeled) GetLabels() map[string]string { return l }
+
+func devices(labelSets ...labeled) func(context.Context) ([]labeled, error) {
+	return func(context.Context) ([]labeled, error) { return labelSets, nil }
+}
+
+func TestEnforce_PerLabel(t *testing.T) {
+	enforcer, err := NewEnforcer(Rule{ResourceType: "Device", PerLabel: "datacenter", Max: 2})
+	if err != nil {
+		t.Fatalf("NewEnforcer failed: %v", err)
+	}
+	loadAll := devices(
+		labeled{"datacenter": "dc1"},
+		labeled{"datacenter": "dc1"},
+		labeled{"datacenter": "dc2"},
+	)
+	ctx := context.Background()
+
+	err = Enforce(ctx, enforcer, "Device", map[string]string{"datacenter": "dc1"}, loadAll)
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) || !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Expected ExceededError for dc1, got %v", err)
+	}
+	if exceeded.Count != 2 || exceeded.Scope["datacenter"] != "dc1" {
+		t.Errorf("Unexpected error details: %+v", exceeded)
+	}
+	if got := err.Error(); got != "quota Device{datacenter=dc1} exceeded: 2 of 2 Device resources already exist" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+
+	if err := Enforce(ctx, enforcer, "Device", map[string]string{"datacenter": "dc2"}, loadAll); err != nil {
+		t.Errorf("dc2 has room, got %v", err)
+	}
+	// Resources without the scoping label are not limited
+	if err := Enforce(ctx, enforcer, "Device", nil, loadAll); err != nil {
+		t.Errorf("Unlabeled resource should be allowed, got %v", err)
+	}
+}
+
+func TestEnforce_Selector(t *testing.T) {
+	enforcer, _ := NewEnforcer(Rule{
+		Name:         "gateways",
+		ResourceType: "Device",
+		Selector:     map[string]string{"role": "gateway"},
+		Max:          1,
+	})
+	loadAll := devices(labeled{"role": "gateway"}, labeled{"role": "compute"})
+	ctx := context.Background()
+
+	err := Enforce(ctx, enforcer, "Device", map[string]string{"role": "gateway", "rack": "r1"}, loadAll)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+	}
+	if got := err.Error(); got != "quota gateways exceeded: 1 of 1 Device resources already exist" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+	if err := Enforce(ctx, enforcer, "Device", map[string]string{"role": "compute"}, loadAll); err != nil {
+		t.Errorf("Non-matching resource should be allowed, got %v", err)
+	}
+}
+
+func TestEnforce_SkipsLoadWithoutApplicableRule(t *testing.T) {
+	enforcer, _ := NewEnforcer(Rule{ResourceType: "Device", Max: 1})
+	loadAll := func(context.Context) ([]labeled, error) {
+		t.Error("loadAll should not be called")
+		return nil, nil
+	}
+
+	if err := Enforce(context.Background(), enforcer, "Rack", nil, loadAll); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := Enforce[labeled](context.Background(), nil, "Device", nil, loadAll); err != nil {
+		t.Errorf("Nil enforcer should allow everything, got %v", err)
+	}
+}
+
+func TestEnforce_LoadError(t *testing.T) {
+	enforcer, _ := NewEnforcer(Rule{ResourceType: "Device", Max: 1})
+	loadErr := errors.New("disk on fire")
+	loadAll := func(context.Context) ([]labeled, error) { return nil, loadErr }
+
+	err := Enforce(context.Background(), enforcer, "Device", nil, loadAll)
+	if !errors.Is(err, loadErr) || errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected wrapped load error, got %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.yaml")
+	content := `rules:
+  - name: devices-per-datacenter
+    resourceType: Device
+    perLabel: datacenter
+    max: 500
+  - resourceType: Device
+    selector:
+      role: gateway
+    max: 4
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	enforcer, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	rules := enforcer.Rules()
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].PerLabel != "datacenter" || rules[0].Max != 500 || rules[1].Selector["role"] != "gateway" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n  - max: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("Expected error for rule without resourceType")
+	}
+}
+
+func TestCheck_UsesDefault(t *testing.T) {
+	enforcer, _ := NewEnforcer(Rule{ResourceType: "Device", Max: 0})
+	SetDefault(enforcer)
+	defer SetDefault(nil)
+
+	if err := Check(context.Background(), "Device", nil, devices()); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded from default enforcer, got %v", err)
+	}
+}
@@ -6,14 +6,20 @@ package reconcile
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/logging"
 	"github.com/openchami/fabrica/pkg/storage"
 )
 
+// transientRetryDelay is how long the controller waits before retrying a
+// request whose resource failed to load with a transient storage error.
+const transientRetryDelay = 5 * time.Second
+
 // Controller manages the lifecycle of reconcilers.
 //
 // The controller:
@@ -24,6 +30,9 @@ import (
 //   - Handles requeueing for periodic reconciliation
 type Controller struct {
 	reconcilers map[string]Reconciler
+	predicates  map[string][]Predicate
+	snapshots   map[string]json.RawMessage // last seen resource per kind/UID, for predicates
+	snapshotMu  sync.Mutex
 	queue       *WorkQueue
 	eventBus    events.EventBus
 	storage     storage.StorageBackend
@@ -32,6 +41,10 @@ type Controller struct {
 	wg          sync.WaitGroup
 	logger      Logger
 	workerCount int
+	limiter     ControllerRateLimiter
+	elector     LeaderElector
+	leaderMu    sync.Mutex
+	leaderCh    chan struct{} // closed while this replica may process work
 }
 
 // NewController creates a new reconciliation controller.
@@ -45,9 +58,16 @@ type Controller struct {
 func NewController(eventBus events.EventBus, storage storage.StorageBackend) *Controller {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Without a leader elector the controller always processes work
+	leaderCh := make(chan struct{})
+	close(leaderCh)
+
 	return &Controller{
+		leaderCh:    leaderCh,
 		reconcilers: make(map[string]Reconciler),
-		queue:       NewWorkQueue(),
+		predicates:  make(map[string][]Predicate),
+		snapshots:   make(map[string]json.RawMessage),
+		queue:       NewWorkQueueWithKeyFunc(requestKey),
 		eventBus:    eventBus,
 		storage:     storage,
 		ctx:         ctx,
@@ -59,12 +79,16 @@ func NewController(eventBus events.EventBus, storage storage.StorageBackend) *Co
 
 // RegisterReconciler registers a reconciler for a resource kind.
 //
+// Optional predicates filter which events enqueue a reconcile; all must
+// pass. Use GenerationChangedPredicate to ignore status-only updates.
+//
 // Parameters:
 //   - reconciler: Reconciler implementation for a specific resource type
+//   - predicates: Event filters evaluated before enqueueing (optional)
 //
 // Returns:
 //   - error: If reconciler for this kind is already registered
-func (c *Controller) RegisterReconciler(reconciler Reconciler) error {
+func (c *Controller) RegisterReconciler(reconciler Reconciler, predicates ...Predicate) error {
 	kind := reconciler.GetResourceKind()
 
 	if _, exists := c.reconcilers[kind]; exists {
@@ -72,17 +96,128 @@ func (c *Controller) RegisterReconciler(reconciler Reconciler) error {
 	}
 
 	c.reconcilers[kind] = reconciler
+	if len(predicates) > 0 {
+		c.predicates[kind] = predicates
+	}
 	c.logger.Infof("Registered reconciler for %s", kind)
 
 	return nil
 }
 
+// SetRateLimiter sets a global rate limiter shared by all workers.
+//
+// Every worker acquires a token from the limiter before loading and
+// reconciling a resource, capping total reconcile throughput regardless of
+// worker count. Pass nil to disable global rate limiting. Must be called
+// before Start.
+//
+// Example:
+//
+//	limiter, err := NewTokenBucketRateLimiter(20, 5) // 20/s, bursts of 5
+//	if err != nil {
+//	    return err
+//	}
+//	controller.SetRateLimiter(limiter)
+func (c *Controller) SetRateLimiter(limiter ControllerRateLimiter) {
+	c.limiter = limiter
+}
+
+// SetLeaderElector enables leader election for this controller.
+//
+// With an elector configured, Start still subscribes to events and queues
+// requests, but workers stay idle until this replica holds leadership. If
+// leadership is lost, workers finish their current request and pause until
+// it is regained. Must be called before Start.
+//
+// Example:
+//
+//	config := DefaultLeaderElectionConfig()
+//	config.LeaseName = "inventory-reconcilers"
+//	elector, err := NewStorageLeaderElector(backend, config)
+//	if err != nil {
+//	    return err
+//	}
+//	controller.SetLeaderElector(elector)
+func (c *Controller) SetLeaderElector(elector LeaderElector) {
+	c.elector = elector
+	if elector != nil {
+		c.setLeading(false)
+	}
+}
+
+// IsLeader reports whether this controller is processing work.
+//
+// Always true when no leader elector is configured.
+func (c *Controller) IsLeader() bool {
+	select {
+	case <-c.leadership():
+		return true
+	default:
+		return false
+	}
+}
+
+// leadership returns a channel that is closed while this replica leads.
+func (c *Controller) leadership() <-chan struct{} {
+	c.leaderMu.Lock()
+	defer c.leaderMu.Unlock()
+	return c.leaderCh
+}
+
+// setLeading opens or closes the gate that workers wait on.
+func (c *Controller) setLeading(leading bool) {
+	c.leaderMu.Lock()
+	defer c.leaderMu.Unlock()
+
+	select {
+	case <-c.leaderCh:
+		if !leading {
+			c.leaderCh = make(chan struct{})
+		}
+	default:
+		if leading {
+			close(c.leaderCh)
+		}
+	}
+}
+
+// ControllerMetrics is a point-in-time snapshot of controller state.
+type ControllerMetrics struct {
+	// QueueDepth is the number of requests waiting in the work queue
+	QueueDepth int
+
+	// Processing is the number of requests currently being handled
+	Processing int
+
+	// RateLimit holds the global rate limiter state (nil if not configured)
+	RateLimit *RateLimiterStats
+}
+
+// Metrics returns a snapshot of queue and rate limiter state.
+//
+// Operators can export these values to tune worker count and rate limits
+// (MetricsCollectors exports them for running controllers):
+// a saturation near 1 means the rate limit, not the worker count, is the
+// bottleneck.
+func (c *Controller) Metrics() ControllerMetrics {
+	metrics := ControllerMetrics{
+		QueueDepth: c.queue.Len(),
+		Processing: c.queue.ProcessingCount(),
+	}
+	if c.limiter != nil {
+		stats := c.limiter.Stats()
+		metrics.RateLimit = &stats
+	}
+	return metrics
+}
+
 // Start begins the reconciliation controller.
 //
 // This:
 //   - Starts worker goroutines
 //   - Subscribes to resource change events
-//   - Begins processing the work queue
+//   - Begins processing the work queue (once leadership is held, if a
+//     leader elector is configured)
 //
 // Parameters:
 //   - ctx: Context for cancellation
@@ -98,12 +233,31 @@ func (c *Controller) Start(_ context.Context) error {
 		return fmt.Errorf("failed to subscribe to events: %w", err)
 	}
 
+	// Campaign for leadership; workers wait on the result
+	if c.elector != nil {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			c.elector.Run(c.ctx, LeaderCallbacks{
+				OnStartedLeading: func() {
+					c.logger.Infof("Acquired leadership, processing reconciliation requests")
+					c.setLeading(true)
+				},
+				OnStoppedLeading: func() {
+					c.logger.Infof("Lost leadership, pausing reconciliation")
+					c.setLeading(false)
+				},
+			})
+		}()
+	}
+
 	// Start worker goroutines
 	for i := 0; i < c.workerCount; i++ {
 		c.wg.Add(1)
 		go c.worker(i)
 	}
 
+	registerRunning(c)
 	c.logger.Infof("Reconciliation controller started")
 
 	return nil
@@ -114,6 +268,7 @@ func (c *Controller) Start(_ context.Context) error {
 // This waits for all workers to finish processing their current items.
 func (c *Controller) Stop() error {
 	c.logger.Infof("Stopping reconciliation controller")
+	unregisterRunning(c)
 
 	c.cancel()
 	c.queue.ShutDown()
@@ -126,6 +281,10 @@ func (c *Controller) Stop() error {
 
 // Enqueue adds a reconciliation request to the work queue.
 //
+// Requests are coalesced per resource: at most one request per kind/UID is
+// queued, and a request for a resource that is currently being reconciled
+// schedules exactly one follow-up reconcile.
+//
 // Parameters:
 //   - request: Reconciliation request
 //
@@ -142,17 +301,59 @@ func (c *Controller) Enqueue(request ReconcileRequest) error {
 //   - request: Reconciliation request
 //   - delay: Duration to wait before processing
 func (c *Controller) EnqueueAfter(request ReconcileRequest, delay time.Duration) {
-	go func() {
-		timer := time.NewTimer(delay)
-		defer timer.Stop()
+	c.queue.AddAfter(request, delay)
+}
 
-		select {
-		case <-timer.C:
-			c.queue.Add(request)
-		case <-c.ctx.Done():
-			return
+// ResyncAll enqueues a reconcile for every stored resource of a kind, e.g.
+// after a configuration change or a change to the reconciler's logic.
+//
+// Requests are coalesced as with Enqueue: a resource that already has a
+// request queued is not queued again, and one being reconciled gets a single
+// follow-up reconcile. Only the leader processes the queued requests.
+//
+// Parameters:
+//   - ctx: Context for listing the resources
+//   - kind: Resource kind with a registered reconciler (e.g., "Device")
+//
+// Returns:
+//   - int: Number of requests queued; resources with a request already
+//     pending are not counted
+//   - error: If no reconciler is registered for kind, or listing fails
+//
+// Example:
+//
+//	queued, err := controller.ResyncAll(ctx, "Device")
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("Resyncing %d devices", queued)
+func (c *Controller) ResyncAll(ctx context.Context, kind string) (int, error) {
+	if _, exists := c.reconcilers[kind]; !exists {
+		return 0, fmt.Errorf("no reconciler registered for kind %s", kind)
+	}
+
+	uids, err := c.storage.List(ctx, kind)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list %s resources: %w", kind, err)
+	}
+
+	// Reconciles log under the ID of the request that asked for the resync
+	requestID, _ := logging.RequestIDFromContext(ctx)
+	queued := 0
+	for _, uid := range uids {
+		request := ReconcileRequest{
+			ResourceKind: kind,
+			ResourceUID:  uid,
+			Reason:       "Resync",
+			RequestID:    requestID,
 		}
-	}()
+		if c.queue.add(request) {
+			queued++
+		}
+	}
+	c.logger.Infof("Resync of %s queued %d of %d resources", kind, queued, len(uids))
+
+	return queued, nil
 }
 
 // worker processes items from the work queue.
@@ -162,6 +363,14 @@ func (c *Controller) worker(id int) {
 	c.logger.Debugf("Worker %d started", id)
 
 	for {
+		// Stay idle until this replica is allowed to process work
+		select {
+		case <-c.leadership():
+		case <-c.ctx.Done():
+			c.logger.Debugf("Worker %d shutting down", id)
+			return
+		}
+
 		item, ok := c.queue.Get()
 		if !ok {
 			// Queue is shutting down
@@ -176,6 +385,13 @@ func (c *Controller) worker(id int) {
 			continue
 		}
 
+		// Leadership may have been lost while blocked on the queue
+		if !c.IsLeader() {
+			c.queue.Done(item)
+			c.queue.Add(item)
+			continue
+		}
+
 		c.processRequest(request)
 		c.queue.Done(item)
 	}
@@ -185,6 +401,11 @@ func (c *Controller) worker(id int) {
 func (c *Controller) processRequest(request ReconcileRequest) {
 	ctx := context.Background() // TODO: Add timeout/deadline
 
+	// Continue the trace of the request that triggered this reconciliation
+	ctx = events.WithTraceContext(ctx, request.Trace.TraceParent, request.Trace.TraceState)
+	// and log under its request ID (see logging.FromContext)
+	ctx = logging.WithRequestID(ctx, request.RequestID)
+
 	c.logger.Debugf("Processing reconciliation for %s/%s (reason: %s)",
 		request.ResourceKind, request.ResourceUID, request.Reason)
 
@@ -195,9 +416,26 @@ func (c *Controller) processRequest(request ReconcileRequest) {
 		return
 	}
 
+	// Acquire a global token before touching storage
+	if c.limiter != nil {
+		if err := c.limiter.Wait(c.ctx); err != nil {
+			c.logger.Debugf("Rate limiter wait aborted for %s/%s: %v",
+				request.ResourceKind, request.ResourceUID, err)
+			return
+		}
+	}
+
 	// Load resource from storage
 	resource, err := c.loadResource(ctx, request.ResourceKind, request.ResourceUID)
 	if err != nil {
+		// Retry failures that may clear, such as a dropped database
+		// connection; permanent ones would fail the same way again
+		if storage.IsTransient(err) {
+			c.logger.Warnf("Transient error loading resource %s/%s, retrying in %s: %v",
+				request.ResourceKind, request.ResourceUID, transientRetryDelay, err)
+			c.EnqueueAfter(request, transientRetryDelay)
+			return
+		}
 		c.logger.Errorf("Failed to load resource %s/%s: %v",
 			request.ResourceKind, request.ResourceUID, err)
 		return
@@ -269,6 +507,14 @@ func (c *Controller) handleEvent(_ context.Context, event events.Event) error {
 		return nil
 	}
 
+	// Let the reconciler's predicates filter out changes it doesn't care about
+	if predicates := c.predicates[resourceKind]; len(predicates) > 0 {
+		if !c.evaluatePredicates(event, predicates) {
+			c.logger.Debugf("Predicates filtered %s for %s/%s", event.Type(), resourceKind, resourceUID)
+			return nil
+		}
+	}
+
 	// Determine reason from event type
 	reason := fmt.Sprintf("Event: %s", event.Type())
 
@@ -278,10 +524,56 @@ func (c *Controller) handleEvent(_ context.Context, event events.Event) error {
 		ResourceUID:  resourceUID,
 		Reason:       reason,
 	}
+	if trace, ok := event.TraceContext(); ok {
+		request.Trace = trace
+	}
+	request.RequestID = event.RequestID()
 
 	return c.Enqueue(request)
 }
 
+// evaluatePredicates compares the event's resource snapshot with the last one
+// seen for the same resource and records the new snapshot.
+func (c *Controller) evaluatePredicates(event events.Event, predicates []Predicate) bool {
+	key := fmt.Sprintf("%s/%s", event.ResourceKind(), event.ResourceUID())
+	current := eventResource(event)
+
+	c.snapshotMu.Lock()
+	previous, seen := c.snapshots[key]
+	if action, _ := event.Extensions()["action"].(string); action == "deleted" {
+		// Predicates see deletions as new == nil, even with a final snapshot
+		delete(c.snapshots, key)
+		current = nil
+	} else if current != nil {
+		c.snapshots[key] = current
+	}
+	c.snapshotMu.Unlock()
+
+	// Pass untyped nils so predicates can compare against nil
+	var oldValue, newValue interface{}
+	if seen {
+		oldValue = previous
+	}
+	if current != nil {
+		newValue = current
+	}
+	return shouldReconcile(predicates, oldValue, newValue)
+}
+
+// eventResource extracts the resource snapshot from a resource event payload.
+func eventResource(event events.Event) json.RawMessage {
+	var payload struct {
+		Resource json.RawMessage `json:"resource"`
+	}
+	if err := json.Unmarshal(event.Data(), &payload); err != nil {
+		return nil
+	}
+	if len(payload.Resource) == 0 || string(payload.Resource) == "null" {
+		return nil
+	}
+	return payload.Resource
+}
+
 // ReconcileRequest represents a request to reconcile a resource.
 //
 //nolint:revive // "ReconcileRequest" name is intentional; "Request" alone would be ambiguous
@@ -294,6 +586,21 @@ type ReconcileRequest struct {
 
 	// Reason explains why this reconciliation was triggered
 	Reason string
+
+	// Trace is the trace context of the event that triggered this request, if any
+	Trace events.TraceContext
+
+	// RequestID is the ID of the HTTP request that published the triggering
+	// event, if any
+	RequestID string
+}
+
+// requestKey coalesces queued requests for the same resource.
+func requestKey(item interface{}) interface{} {
+	if request, ok := item.(ReconcileRequest); ok {
+		return request.String()
+	}
+	return item
 }
 
 // String returns a string representation of the request.
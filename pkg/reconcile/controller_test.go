@@ -6,13 +6,16 @@ package reconcile
 
 import (
 	"context"
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/logging"
 	"github.com/openchami/fabrica/pkg/storage"
 )
 
@@ -433,3 +436,214 @@ func TestController_GracefulShutdown(t *testing.T) {
 		t.Fatal("Controller.Stop() did not complete within timeout")
 	}
 }
+
+// slowReconciler holds each reconcile open so events arrive mid-flight
+type slowReconciler struct {
+	mockReconciler
+	delay time.Duration
+}
+
+func (s *slowReconciler) Reconcile(ctx context.Context, resource interface{}) (Result, error) {
+	time.Sleep(s.delay)
+	return s.mockReconciler.Reconcile(ctx, resource)
+}
+
+func TestController_CoalescesEventBurst(t *testing.T) {
+	ctx := context.Background()
+
+	eventBus := events.NewInMemoryEventBus(100, 1)
+	eventBus.Start()
+	defer eventBus.Close() //nolint:errcheck
+
+	fileStorage, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	resourceData, _ := json.Marshal(map[string]interface{}{"kind": "TestResource"})
+	if err := fileStorage.Save(ctx, "TestResource", "test-123", resourceData); err != nil {
+		t.Fatalf("Failed to save test resource: %v", err)
+	}
+
+	controller := NewController(eventBus, fileStorage)
+	reconciler := &slowReconciler{delay: 50 * time.Millisecond}
+	if err := controller.RegisterReconciler(reconciler); err != nil {
+		t.Fatalf("Failed to register reconciler: %v", err)
+	}
+	if err := controller.Start(ctx); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	defer controller.Stop() //nolint:errcheck
+
+	// First event starts a reconcile; the rest arrive while it is in flight
+	const burst = 50
+	for i := 0; i < burst; i++ {
+		err := controller.Enqueue(ReconcileRequest{
+			ResourceKind: "TestResource",
+			ResourceUID:  "test-123",
+			Reason:       fmt.Sprintf("Event %d", i),
+		})
+		if err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+		if i == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	calls := reconciler.GetCallCount()
+	if calls < 1 || calls > 2 {
+		t.Errorf("Reconciler call count = %d for a burst of %d events, want 1 or 2", calls, burst)
+	}
+}
+
+type traceRecordingReconciler struct {
+	mockReconciler
+	traces     chan events.TraceContext
+	requestIDs chan string
+}
+
+func (r *traceRecordingReconciler) Reconcile(ctx context.Context, resource interface{}) (Result, error) {
+	tc, _ := events.TraceContextFromContext(ctx)
+	r.traces <- tc
+	id, _ := logging.RequestIDFromContext(ctx)
+	r.requestIDs <- id
+	return r.mockReconciler.Reconcile(ctx, resource)
+}
+
+func TestController_PropagatesEventTrace(t *testing.T) {
+	ctx := context.Background()
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	eventBus := events.NewInMemoryEventBus(100, 1)
+	eventBus.Start()
+	defer eventBus.Close() //nolint:errcheck
+
+	fileStorage, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	resourceData, _ := json.Marshal(map[string]interface{}{"kind": "TestResource"})
+	if err := fileStorage.Save(ctx, "TestResource", "test-789", resourceData); err != nil {
+		t.Fatalf("Failed to save test resource: %v", err)
+	}
+
+	controller := NewController(eventBus, fileStorage)
+	reconciler := &traceRecordingReconciler{
+		traces:     make(chan events.TraceContext, 1),
+		requestIDs: make(chan string, 1),
+	}
+	if err := controller.RegisterReconciler(reconciler); err != nil {
+		t.Fatalf("Failed to register reconciler: %v", err)
+	}
+	if err := controller.Start(ctx); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	defer controller.Stop() //nolint:errcheck
+
+	event, err := events.NewResourceEvent("created", "TestResource", "test-789", nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	event.SetTraceContext(events.TraceContext{TraceParent: traceparent})
+	event.SetExtension(events.ExtensionRequestID, "req-789")
+	if err := eventBus.Publish(ctx, *event); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+
+	select {
+	case tc := <-reconciler.traces:
+		if tc.TraceParent != traceparent {
+			t.Errorf("Reconcile ctx traceparent = %q, want %q", tc.TraceParent, traceparent)
+		}
+		if id := <-reconciler.requestIDs; id != "req-789" {
+			t.Errorf("Reconcile ctx request ID = %q, want req-789", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for reconciliation")
+	}
+}
+
+// failingStorage fails every Load with err
+type failingStorage struct {
+	storage.StorageBackend
+	err error
+}
+
+func (s failingStorage) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) { //nolint:revive
+	return nil, s.err
+}
+
+func TestController_RetriesOnlyTransientLoadErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry bool
+	}{
+		{"transient", storage.ClassifyError(fmt.Errorf("query: %w", driver.ErrBadConn)), true},
+		{"not found", storage.ErrNotFound, false},
+		{"permanent", fmt.Errorf("permission denied"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(events.NewInMemoryEventBus(10, 1), failingStorage{err: tt.err})
+			reconciler := &mockReconciler{BaseReconciler: BaseReconciler{Logger: NewDefaultLogger()}}
+			if err := controller.RegisterReconciler(reconciler); err != nil {
+				t.Fatal(err)
+			}
+			defer controller.queue.ShutDown()
+
+			controller.processRequest(ReconcileRequest{ResourceKind: "TestResource", ResourceUID: "test-123"})
+
+			controller.queue.mu.RLock()
+			_, retry := controller.queue.waiting[requestKey(ReconcileRequest{ResourceKind: "TestResource", ResourceUID: "test-123"})]
+			controller.queue.mu.RUnlock()
+			if retry != tt.wantRetry {
+				t.Errorf("retry scheduled = %v, want %v", retry, tt.wantRetry)
+			}
+			if reconciler.GetCallCount() != 0 {
+				t.Error("reconciler called without a loaded resource")
+			}
+		})
+	}
+}
+
+func TestController_ResyncAll(t *testing.T) {
+	ctx := context.Background()
+
+	fileStorage, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	resourceData, _ := json.Marshal(map[string]interface{}{"kind": "TestResource"})
+	for _, uid := range []string{"test-1", "test-2", "test-3"} {
+		if err := fileStorage.Save(ctx, "TestResource", uid, resourceData); err != nil {
+			t.Fatalf("Failed to save test resource: %v", err)
+		}
+	}
+
+	controller := NewController(events.NewInMemoryEventBus(10, 1), fileStorage)
+	defer controller.queue.ShutDown()
+	if _, err := controller.ResyncAll(ctx, "TestResource"); err == nil {
+		t.Error("ResyncAll succeeded for a kind without a reconciler")
+	}
+	if err := controller.RegisterReconciler(&mockReconciler{}); err != nil {
+		t.Fatalf("Failed to register reconciler: %v", err)
+	}
+
+	// test-2 already has a request pending, so the resync does not queue it again
+	if err := controller.Enqueue(ReconcileRequest{ResourceKind: "TestResource", ResourceUID: "test-2"}); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+	queued, err := controller.ResyncAll(ctx, "TestResource")
+	if err != nil {
+		t.Fatalf("ResyncAll failed: %v", err)
+	}
+	if queued != 2 {
+		t.Errorf("ResyncAll queued %d, want 2", queued)
+	}
+	if n := controller.queue.Len(); n != 3 {
+		t.Errorf("queue length = %d, want 3", n)
+	}
+}
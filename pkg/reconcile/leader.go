@@ -0,0 +1,285 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/storage"
+)
+
+// LeaseResourceType is the storage resource type used for leader election leases.
+const LeaseResourceType = "Lease"
+
+// LeaderElector decides which replica runs reconcilers.
+//
+// When several server replicas share storage, only the leader should process
+// the work queue. Non-leaders keep watching events so they are ready to take
+// over as soon as leadership is acquired.
+type LeaderElector interface {
+	// Run campaigns for leadership until ctx is done, invoking the callbacks
+	// on each transition. Run blocks and should release leadership on return.
+	Run(ctx context.Context, callbacks LeaderCallbacks)
+
+	// IsLeader reports whether this replica currently holds leadership.
+	IsLeader() bool
+}
+
+// LeaderCallbacks are invoked by a LeaderElector on leadership transitions.
+type LeaderCallbacks struct {
+	// OnStartedLeading is called when leadership is acquired
+	OnStartedLeading func()
+
+	// OnStoppedLeading is called when leadership is lost or released
+	OnStoppedLeading func()
+}
+
+// LeaderElectionConfig configures a StorageLeaderElector.
+type LeaderElectionConfig struct {
+	// LeaseName identifies the lease record (must be a valid UID)
+	LeaseName string
+
+	// Identity uniquely identifies this replica (defaults to hostname + PID)
+	Identity string
+
+	// LeaseDuration is how long a lease is valid without renewal (TTL)
+	LeaseDuration time.Duration
+
+	// RenewInterval is how often the leader renews and followers retry.
+	// Must be shorter than LeaseDuration.
+	RenewInterval time.Duration
+}
+
+// DefaultLeaderElectionConfig returns a config with a 15s TTL renewed every 5s.
+func DefaultLeaderElectionConfig() LeaderElectionConfig {
+	return LeaderElectionConfig{
+		LeaseName:     "reconcile-controller",
+		Identity:      defaultIdentity(),
+		LeaseDuration: 15 * time.Second,
+		RenewInterval: 5 * time.Second,
+	}
+}
+
+// Lease is the record stored for leader election.
+type Lease struct {
+	HolderIdentity string        `json:"holderIdentity"`
+	AcquireTime    time.Time     `json:"acquireTime"`
+	RenewTime      time.Time     `json:"renewTime"`
+	LeaseDuration  time.Duration `json:"leaseDuration"`
+}
+
+// Expired reports whether the lease has lapsed at the given time.
+func (l *Lease) Expired(now time.Time) bool {
+	return l.HolderIdentity == "" || now.After(l.RenewTime.Add(l.LeaseDuration))
+}
+
+// StorageLeaderElector implements LeaderElector using a lease record in a
+// StorageBackend.
+//
+// The leader renews the lease every RenewInterval. Followers poll at the same
+// interval and take over once the lease has gone LeaseDuration without
+// renewal. Each write is a CompareAndSwap against the lease as read, so two
+// replicas cannot both take an expired lease; keep RenewInterval well below
+// LeaseDuration and replica clocks roughly in sync.
+//
+// Election is only as safe as the backend's CompareAndSwap. FileBackend swaps
+// under an in-process lock, so it elects a single leader among electors in
+// one process but not among replicas sharing a data directory; use a
+// database backend for multi-replica deployments.
+type StorageLeaderElector struct {
+	backend storage.StorageBackend
+	config  LeaderElectionConfig
+	logger  Logger
+	leader  bool
+	mu      sync.RWMutex
+}
+
+// NewStorageLeaderElector creates a storage-backed leader elector.
+//
+// Parameters:
+//   - backend: Storage shared by all replicas
+//   - config: Lease name, identity, TTL and renewal interval
+//
+// Returns:
+//   - *StorageLeaderElector: Initialized elector
+//   - error: If the configuration is invalid
+func NewStorageLeaderElector(backend storage.StorageBackend, config LeaderElectionConfig) (*StorageLeaderElector, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("storage backend is required")
+	}
+	if config.Identity == "" {
+		config.Identity = defaultIdentity()
+	}
+	if err := storage.ValidateUID(config.LeaseName); err != nil {
+		return nil, fmt.Errorf("invalid lease name: %w", err)
+	}
+	if config.LeaseDuration <= 0 || config.RenewInterval <= 0 {
+		return nil, fmt.Errorf("lease duration and renew interval must be positive")
+	}
+	if config.RenewInterval >= config.LeaseDuration {
+		return nil, fmt.Errorf("renew interval (%v) must be shorter than lease duration (%v)",
+			config.RenewInterval, config.LeaseDuration)
+	}
+
+	return &StorageLeaderElector{
+		backend: backend,
+		config:  config,
+		logger:  NewDefaultLogger(),
+	}, nil
+}
+
+// Identity returns the identity this elector campaigns with.
+func (e *StorageLeaderElector) Identity() string {
+	return e.config.Identity
+}
+
+// IsLeader reports whether this replica currently holds the lease.
+func (e *StorageLeaderElector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Run campaigns for the lease until ctx is done.
+//
+// Leadership is checked immediately and then every RenewInterval. On return
+// the lease is released so another replica can take over without waiting
+// for it to expire.
+func (e *StorageLeaderElector) Run(ctx context.Context, callbacks LeaderCallbacks) {
+	ticker := time.NewTicker(e.config.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		held, err := e.tryAcquireOrRenew(ctx)
+		if err != nil {
+			e.logger.Warnf("Leader election for %s failed: %v", e.config.LeaseName, err)
+		}
+		e.setLeader(held, callbacks)
+
+		select {
+		case <-ctx.Done():
+			if e.IsLeader() {
+				releaseCtx, cancel := context.WithTimeout(context.Background(), e.config.RenewInterval)
+				if err := e.release(releaseCtx); err != nil {
+					e.logger.Warnf("Failed to release lease %s: %v", e.config.LeaseName, err)
+				}
+				cancel()
+			}
+			e.setLeader(false, callbacks)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setLeader records the leadership state and fires callbacks on transitions.
+func (e *StorageLeaderElector) setLeader(leader bool, callbacks LeaderCallbacks) {
+	e.mu.Lock()
+	changed := e.leader != leader
+	e.leader = leader
+	e.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if leader {
+		e.logger.Infof("%s acquired lease %s", e.config.Identity, e.config.LeaseName)
+		if callbacks.OnStartedLeading != nil {
+			callbacks.OnStartedLeading()
+		}
+	} else {
+		e.logger.Infof("%s lost lease %s", e.config.Identity, e.config.LeaseName)
+		if callbacks.OnStoppedLeading != nil {
+			callbacks.OnStoppedLeading()
+		}
+	}
+}
+
+// tryAcquireOrRenew takes the lease if it is free, expired, or already ours.
+func (e *StorageLeaderElector) tryAcquireOrRenew(ctx context.Context) (bool, error) {
+	now := time.Now()
+
+	current, stored, err := e.loadLease(ctx)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return false, err
+	}
+
+	lease := Lease{
+		HolderIdentity: e.config.Identity,
+		AcquireTime:    now,
+		RenewTime:      now,
+		LeaseDuration:  e.config.LeaseDuration,
+	}
+
+	if current != nil {
+		if current.HolderIdentity != e.config.Identity && !current.Expired(now) {
+			return false, nil
+		}
+		if current.HolderIdentity == e.config.Identity {
+			lease.AcquireTime = current.AcquireTime
+		}
+	}
+
+	// Fails if a concurrent writer changed the lease since it was read
+	return e.swapLease(ctx, stored, &lease)
+}
+
+// release clears the lease if this replica still holds it.
+func (e *StorageLeaderElector) release(ctx context.Context) error {
+	current, stored, err := e.loadLease(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if current.HolderIdentity != e.config.Identity {
+		return nil
+	}
+
+	// A lease taken over in the meantime is left alone
+	_, err = e.swapLease(ctx, stored, &Lease{LeaseDuration: e.config.LeaseDuration})
+	return err
+}
+
+// loadLease returns the stored lease and its raw data for swapLease.
+func (e *StorageLeaderElector) loadLease(ctx context.Context) (*Lease, json.RawMessage, error) {
+	data, err := e.backend.Load(ctx, LeaseResourceType, e.config.LeaseName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lease Lease
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal lease: %w", err)
+	}
+	return &lease, data, nil
+}
+
+// swapLease stores lease if the stored lease is still expected (nil for
+// none), and reports whether it did.
+func (e *StorageLeaderElector) swapLease(ctx context.Context, expected json.RawMessage, lease *Lease) (bool, error) {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal lease: %w", err)
+	}
+	return e.backend.CompareAndSwap(ctx, LeaseResourceType, e.config.LeaseName, expected, data)
+}
+
+// defaultIdentity returns hostname-pid, which is unique per replica.
+func defaultIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -0,0 +1,221 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/storage"
+)
+
+func newTestElector(t *testing.T, backend storage.StorageBackend, identity string) *StorageLeaderElector {
+	t.Helper()
+
+	elector, err := NewStorageLeaderElector(backend, LeaderElectionConfig{
+		LeaseName:     "test-lease",
+		Identity:      identity,
+		LeaseDuration: 150 * time.Millisecond,
+		RenewInterval: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create elector: %v", err)
+	}
+	return elector
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestNewStorageLeaderElector_Invalid(t *testing.T) {
+	backend, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config := DefaultLeaderElectionConfig()
+	config.LeaseName = "../escape"
+	if _, err := NewStorageLeaderElector(backend, config); err == nil {
+		t.Error("Expected error for invalid lease name")
+	}
+
+	config = DefaultLeaderElectionConfig()
+	config.RenewInterval = config.LeaseDuration
+	if _, err := NewStorageLeaderElector(backend, config); err == nil {
+		t.Error("Expected error when renew interval is not shorter than lease duration")
+	}
+}
+
+func TestStorageLeaderElector_SingleLeaderAndFailover(t *testing.T) {
+	backend, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	first := newTestElector(t, backend, "replica-a")
+	second := newTestElector(t, backend, "replica-b")
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan struct{})
+	var stoppedA atomic.Bool
+	go func() {
+		first.Run(ctxA, LeaderCallbacks{OnStoppedLeading: func() { stoppedA.Store(true) }})
+		close(doneA)
+	}()
+
+	if !waitFor(t, time.Second, first.IsLeader) {
+		t.Fatal("First replica should acquire leadership")
+	}
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	go second.Run(ctxB, LeaderCallbacks{})
+
+	// Second replica stays a follower while the lease is renewed
+	time.Sleep(200 * time.Millisecond)
+	if second.IsLeader() {
+		t.Fatal("Second replica should not lead while the lease is held")
+	}
+
+	// Releasing on shutdown lets the follower take over
+	cancelA()
+	<-doneA
+	if !stoppedA.Load() {
+		t.Error("OnStoppedLeading should fire when the leader shuts down")
+	}
+	if !waitFor(t, time.Second, second.IsLeader) {
+		t.Fatal("Second replica should take over after the leader releases")
+	}
+}
+
+func TestStorageLeaderElector_ConcurrentAcquire(t *testing.T) {
+	backend, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	ctx := context.Background()
+
+	// Replicas racing for a free lease: only one compare-and-swap wins
+	const replicas = 8
+	var wg sync.WaitGroup
+	var winners atomic.Int32
+	for i := 0; i < replicas; i++ {
+		elector := newTestElector(t, backend, fmt.Sprintf("replica-%d", i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			held, err := elector.tryAcquireOrRenew(ctx)
+			if err != nil {
+				t.Errorf("tryAcquireOrRenew failed: %v", err)
+			}
+			if held {
+				winners.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if winners.Load() != 1 {
+		t.Errorf("Expected exactly one replica to acquire the lease, got %d", winners.Load())
+	}
+}
+
+func TestLease_Expired(t *testing.T) {
+	now := time.Now()
+	lease := Lease{HolderIdentity: "a", RenewTime: now, LeaseDuration: time.Second}
+
+	if lease.Expired(now.Add(500 * time.Millisecond)) {
+		t.Error("Lease should be valid within its duration")
+	}
+	if !lease.Expired(now.Add(2 * time.Second)) {
+		t.Error("Lease should expire after its duration")
+	}
+	if !(&Lease{}).Expired(now) {
+		t.Error("Released lease should be treated as expired")
+	}
+}
+
+func TestController_IdleUntilLeader(t *testing.T) {
+	ctx := context.Background()
+
+	eventBus := events.NewInMemoryEventBus(100, 1)
+	eventBus.Start()
+	defer eventBus.Close() //nolint:errcheck
+
+	backend, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	// Another replica holds the lease
+	holder := newTestElector(t, backend, "other-replica")
+	holderCtx, cancelHolder := context.WithCancel(context.Background())
+	holderDone := make(chan struct{})
+	go func() {
+		holder.Run(holderCtx, LeaderCallbacks{})
+		close(holderDone)
+	}()
+	if !waitFor(t, time.Second, holder.IsLeader) {
+		t.Fatal("Holder should acquire leadership")
+	}
+
+	controller := NewController(eventBus, backend)
+	controller.SetLeaderElector(newTestElector(t, backend, "this-replica"))
+
+	reconciler := &mockReconciler{}
+	if err := controller.RegisterReconciler(reconciler); err != nil {
+		t.Fatalf("Failed to register reconciler: %v", err)
+	}
+	if err := controller.Start(ctx); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	defer controller.Stop() //nolint:errcheck
+
+	if err := controller.Enqueue(ReconcileRequest{ResourceKind: "TestResource", ResourceUID: "res-1"}); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if controller.IsLeader() {
+		t.Fatal("Controller should not lead while another replica holds the lease")
+	}
+	if controller.queue.Len() != 1 {
+		t.Errorf("Request should stay queued while idle, queue length = %d", controller.queue.Len())
+	}
+
+	// Other replica shuts down; this controller takes over and drains the queue
+	cancelHolder()
+	<-holderDone
+
+	if !waitFor(t, time.Second, controller.IsLeader) {
+		t.Fatal("Controller should take over leadership")
+	}
+	if !waitFor(t, time.Second, func() bool { return controller.queue.Len() == 0 }) {
+		t.Error("Controller should process queued requests after acquiring leadership")
+	}
+}
+
+func TestController_IsLeaderWithoutElector(t *testing.T) {
+	controller := NewController(events.NewInMemoryEventBus(10, 1), nil)
+	if !controller.IsLeader() {
+		t.Error("Controller without an elector should always process work")
+	}
+}
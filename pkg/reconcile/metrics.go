@@ -0,0 +1,117 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"sync"
+
+	"github.com/openchami/fabrica/pkg/metrics"
+)
+
+// Running controllers, registered by Start and removed by Stop, so the
+// gauges need no reference to a controller
+var (
+	runningMu          sync.Mutex
+	runningControllers = make(map[*Controller]struct{})
+)
+
+// MetricsCollectors returns the reconciliation metrics, summed over the
+// running controllers (a generated server runs one):
+//   - fabrica_reconcile_queue_depth: requests waiting in the work queue
+//   - fabrica_reconcile_processing: requests being reconciled
+//   - fabrica_reconcile_rate_limit_rate: configured reconciles per second
+//   - fabrica_reconcile_rate_limit_saturation: RateLimiterStats.Saturation,
+//     the highest over the running controllers
+//   - fabrica_reconcile_rate_limit_waiting: workers blocked on a token
+//
+// The rate limit gauges are 0 for controllers without a rate limiter.
+//
+// Example:
+//
+//	http.Handle("/metrics", metrics.Handler(reconcile.MetricsCollectors()...))
+func MetricsCollectors() []metrics.Collector {
+	return []metrics.Collector{
+		metrics.NewGaugeFunc("fabrica_reconcile_queue_depth",
+			"Reconciliation requests waiting in the work queue.",
+			func() float64 { return sumMetrics(func(m ControllerMetrics) float64 { return float64(m.QueueDepth) }) }),
+		metrics.NewGaugeFunc("fabrica_reconcile_processing",
+			"Reconciliation requests currently being handled.",
+			func() float64 { return sumMetrics(func(m ControllerMetrics) float64 { return float64(m.Processing) }) }),
+		metrics.NewGaugeFunc("fabrica_reconcile_rate_limit_rate",
+			"Configured global reconcile rate limit in reconciles per second.",
+			func() float64 {
+				return sumMetrics(func(m ControllerMetrics) float64 {
+					if m.RateLimit == nil {
+						return 0
+					}
+					return m.RateLimit.Rate
+				})
+			}),
+		metrics.NewGaugeFunc("fabrica_reconcile_rate_limit_saturation",
+			"Global rate limiter saturation: 0 is idle, 1 is no tokens left, above 1 means workers are waiting.",
+			maxSaturation),
+		metrics.NewGaugeFunc("fabrica_reconcile_rate_limit_waiting",
+			"Workers blocked waiting for a rate limiter token.",
+			func() float64 {
+				return sumMetrics(func(m ControllerMetrics) float64 {
+					if m.RateLimit == nil {
+						return 0
+					}
+					return float64(m.RateLimit.Waiting)
+				})
+			}),
+	}
+}
+
+// registerRunning adds c to the controllers reported by MetricsCollectors
+func registerRunning(c *Controller) {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+	runningControllers[c] = struct{}{}
+}
+
+// unregisterRunning removes c from the controllers reported by MetricsCollectors
+func unregisterRunning(c *Controller) {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+	delete(runningControllers, c)
+}
+
+// snapshotRunning returns the metrics of every running controller
+func snapshotRunning() []ControllerMetrics {
+	runningMu.Lock()
+	controllers := make([]*Controller, 0, len(runningControllers))
+	for c := range runningControllers {
+		controllers = append(controllers, c)
+	}
+	runningMu.Unlock()
+
+	snapshots := make([]ControllerMetrics, 0, len(controllers))
+	for _, c := range controllers {
+		snapshots = append(snapshots, c.Metrics())
+	}
+	return snapshots
+}
+
+// sumMetrics sums value over the running controllers
+func sumMetrics(value func(ControllerMetrics) float64) float64 {
+	var total float64
+	for _, m := range snapshotRunning() {
+		total += value(m)
+	}
+	return total
+}
+
+// maxSaturation returns the highest rate limiter saturation of the running
+// controllers; summing saturations would not stay on the 0-1 scale
+func maxSaturation() float64 {
+	var highest float64
+	for _, m := range snapshotRunning() {
+		if m.RateLimit != nil && m.RateLimit.Saturation() > highest {
+			highest = m.RateLimit.Saturation()
+		}
+	}
+	return highest
+}
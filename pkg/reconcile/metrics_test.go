@@ -0,0 +1,89 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/metrics"
+	"github.com/openchami/fabrica/pkg/storage"
+)
+
+func TestMetricsCollectors(t *testing.T) {
+	fileStorage, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	// Registered without Start, so no worker drains the queue
+	controller := NewController(events.NewInMemoryEventBus(10, 1), fileStorage)
+	defer controller.queue.ShutDown()
+	limiter, err := NewTokenBucketRateLimiter(20, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller.SetRateLimiter(limiter)
+	for _, uid := range []string{"test-1", "test-2"} {
+		if err := controller.Enqueue(ReconcileRequest{ResourceKind: "TestResource", ResourceUID: uid}); err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+	}
+	registerRunning(controller)
+	defer unregisterRunning(controller)
+
+	var out strings.Builder
+	if err := metrics.WriteText(&out, MetricsCollectors()...); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"fabrica_reconcile_queue_depth 2",
+		"fabrica_reconcile_processing 0",
+		"fabrica_reconcile_rate_limit_rate 20",
+		"fabrica_reconcile_rate_limit_saturation 0",
+		"fabrica_reconcile_rate_limit_waiting 0",
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("exposition is missing %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestMetricsCollectors_TracksRunningControllers(t *testing.T) {
+	fileStorage, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	eventBus := events.NewInMemoryEventBus(10, 1)
+	eventBus.Start()
+	defer eventBus.Close() //nolint:errcheck
+
+	controller := NewController(eventBus, fileStorage)
+	running := func() bool {
+		runningMu.Lock()
+		defer runningMu.Unlock()
+		_, ok := runningControllers[controller]
+		return ok
+	}
+
+	if running() {
+		t.Fatal("controller is reported before Start")
+	}
+	if err := controller.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	if !running() {
+		t.Error("controller is not reported after Start")
+	}
+	if err := controller.Stop(); err != nil {
+		t.Fatalf("Failed to stop controller: %v", err)
+	}
+	if running() {
+		t.Error("controller is still reported after Stop")
+	}
+}
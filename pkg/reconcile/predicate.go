@@ -0,0 +1,102 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"encoding/json"
+)
+
+// Predicate filters resource events before they are enqueued.
+//
+// Predicates are supplied when registering a reconciler and are evaluated by
+// the controller for every event of that reconciler's kind. All predicates
+// must return true for a reconcile to be queued. Manual Enqueue calls and
+// requeues from Result bypass predicates.
+type Predicate interface {
+	// ShouldReconcile compares the previous and current resource snapshots.
+	//
+	// Both values are json.RawMessage holding the serialized resource. old is
+	// nil the first time the controller sees a resource; new is nil for
+	// deletions and for events that carry no resource snapshot (e.g.
+	// condition events).
+	ShouldReconcile(old, new interface{}) bool
+}
+
+// PredicateFunc adapts a function to the Predicate interface.
+type PredicateFunc func(old, new interface{}) bool
+
+// ShouldReconcile calls f(old, new).
+func (f PredicateFunc) ShouldReconcile(old, new interface{}) bool {
+	return f(old, new)
+}
+
+// GenerationChangedPredicate only reconciles when metadata.generation changes.
+//
+// Because the API bumps generation on spec changes only, this skips
+// status-only updates, including the ones a reconciler makes itself, which
+// would otherwise cause reconcile loops. Like controller-runtime's predicate
+// of the same name, it only filters updates: creations, deletions and events
+// without a snapshot always pass.
+//
+// Behavior:
+//   - First snapshot of a resource (old is nil): reconcile
+//   - Deletion or no current snapshot (new is nil): reconcile
+//   - Generation missing on either side: reconcile (cannot tell)
+//   - Otherwise: reconcile only if the generations differ
+//
+// Example:
+//
+//	controller.RegisterReconciler(reconciler, GenerationChangedPredicate{})
+type GenerationChangedPredicate struct{}
+
+// ShouldReconcile reports whether the generation differs between snapshots.
+func (GenerationChangedPredicate) ShouldReconcile(old, new interface{}) bool {
+	if old == nil || new == nil {
+		return true
+	}
+
+	oldGen, oldOK := resourceGeneration(old)
+	newGen, newOK := resourceGeneration(new)
+	if !oldOK || !newOK {
+		return true
+	}
+	return oldGen != newGen
+}
+
+// resourceGeneration extracts metadata.generation from a resource snapshot.
+func resourceGeneration(resource interface{}) (int64, bool) {
+	var data []byte
+	switch v := resource.(type) {
+	case json.RawMessage:
+		data = v
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return 0, false
+		}
+	}
+
+	var snapshot struct {
+		Metadata struct {
+			Generation int64 `json:"generation"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil || snapshot.Metadata.Generation == 0 {
+		return 0, false
+	}
+	return snapshot.Metadata.Generation, true
+}
+
+// shouldReconcile reports whether all predicates pass.
+func shouldReconcile(predicates []Predicate, old, new interface{}) bool {
+	for _, predicate := range predicates {
+		if !predicate.ShouldReconcile(old, new) {
+			return false
+		}
+	}
+	return true
+}
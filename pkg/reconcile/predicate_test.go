@@ -0,0 +1,129 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+func snapshot(generation int64, phase string) json.RawMessage {
+	data, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"uid": "res-1", "generation": generation},
+		"status":   map[string]interface{}{"phase": phase},
+	})
+	return data
+}
+
+func TestGenerationChangedPredicate(t *testing.T) {
+	p := GenerationChangedPredicate{}
+
+	tests := []struct {
+		name string
+		old  interface{}
+		new  interface{}
+		want bool
+	}{
+		{"first sight", nil, snapshot(1, ""), true},
+		{"deletion or no current snapshot", snapshot(1, ""), nil, true},
+		{"status-only change", snapshot(1, "Pending"), snapshot(1, "Ready"), false},
+		{"spec change", snapshot(1, "Ready"), snapshot(2, "Ready"), true},
+		{"generation missing", json.RawMessage(`{"metadata":{}}`), snapshot(1, ""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ShouldReconcile(tt.old, tt.new); got != tt.want {
+				t.Errorf("ShouldReconcile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredicateFunc(t *testing.T) {
+	var called bool
+	p := PredicateFunc(func(_, _ interface{}) bool {
+		called = true
+		return false
+	})
+
+	if p.ShouldReconcile(nil, nil) || !called {
+		t.Error("PredicateFunc should delegate to the wrapped function")
+	}
+}
+
+func TestController_PredicatesFilterEvents(t *testing.T) {
+	ctx := context.Background()
+	events.SetEventConfig(&events.EventConfig{
+		Enabled:                true,
+		EventTypePrefix:        "io.fabrica",
+		LifecycleEventsEnabled: true,
+		ConditionEventsEnabled: true,
+	})
+
+	controller := NewController(events.NewInMemoryEventBus(10, 1), nil)
+	if err := controller.RegisterReconciler(&mockReconciler{}, GenerationChangedPredicate{}); err != nil {
+		t.Fatalf("Failed to register reconciler: %v", err)
+	}
+
+	publish := func(action string, resource interface{}) {
+		t.Helper()
+		event, err := events.NewResourceEvent(action, "TestResource", "res-1", events.ResourceChangeData{
+			Action:   action,
+			Resource: resource,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		if err := controller.handleEvent(ctx, *event); err != nil {
+			t.Fatalf("handleEvent failed: %v", err)
+		}
+	}
+	drain := func() int {
+		n := controller.queue.Len()
+		for controller.queue.Len() > 0 {
+			item, _ := controller.queue.Get()
+			controller.queue.Done(item)
+		}
+		return n
+	}
+
+	publish("created", snapshot(1, "Pending"))
+	if n := drain(); n != 1 {
+		t.Errorf("Create should enqueue, got %d", n)
+	}
+
+	// Reconciler's own status update must not trigger another reconcile
+	publish("updated", snapshot(1, "Ready"))
+	if n := drain(); n != 0 {
+		t.Errorf("Status-only update should be filtered, got %d", n)
+	}
+
+	publish("updated", snapshot(2, "Ready"))
+	if n := drain(); n != 1 {
+		t.Errorf("Spec update should enqueue, got %d", n)
+	}
+
+	// Deletions pass whether or not the event carries the final snapshot
+	publish("deleted", snapshot(2, "Ready"))
+	if n := drain(); n != 1 {
+		t.Errorf("Delete should enqueue, got %d", n)
+	}
+	publish("created", snapshot(1, ""))
+	drain()
+	publish("deleted", nil)
+	if n := drain(); n != 1 {
+		t.Errorf("Delete without snapshot should enqueue, got %d", n)
+	}
+
+	// After deletion, the resource is treated as new again
+	publish("created", snapshot(1, ""))
+	if n := drain(); n != 1 {
+		t.Errorf("Recreate should enqueue, got %d", n)
+	}
+}
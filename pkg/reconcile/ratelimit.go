@@ -0,0 +1,165 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ControllerRateLimiter caps overall reconcile throughput across all workers.
+//
+// This is distinct from RateLimiter, which computes per-item backoff for
+// failing or frequently updated resources. A ControllerRateLimiter is shared
+// by every worker of a Controller and protects downstream systems (such as a
+// shared database) from bursts of reconciliation. The two compose: per-item
+// backoff decides when an item re-enters the queue, and the controller limiter
+// decides how fast queued items are processed.
+type ControllerRateLimiter interface {
+	// Wait blocks until a token is available or ctx is done.
+	Wait(ctx context.Context) error
+
+	// Stats returns a snapshot of the limiter state for metrics.
+	Stats() RateLimiterStats
+}
+
+// RateLimiterStats describes the state of a ControllerRateLimiter.
+type RateLimiterStats struct {
+	// Rate is the configured number of reconciles per second
+	Rate float64
+
+	// Burst is the maximum number of tokens the bucket can hold
+	Burst int
+
+	// Available is the number of tokens currently available
+	Available float64
+
+	// Waiting is the number of workers currently blocked on a token
+	Waiting int
+
+	// Throttled is the total number of acquisitions that had to wait
+	Throttled uint64
+}
+
+// Saturation returns how close the limiter is to its ceiling, from 0 (idle,
+// bucket full) to 1 (no tokens left). Values above 1 mean workers are queued
+// waiting for tokens.
+func (s RateLimiterStats) Saturation() float64 {
+	if s.Burst <= 0 {
+		return 0
+	}
+	return 1 - s.Available/float64(s.Burst) + float64(s.Waiting)/float64(s.Burst)
+}
+
+// TokenBucketRateLimiter implements ControllerRateLimiter with a token bucket.
+//
+// The bucket starts full and refills continuously at the configured rate.
+// Tokens are reserved in arrival order, so waiting workers are served fairly.
+type TokenBucketRateLimiter struct {
+	rate      float64
+	burst     int
+	tokens    float64
+	last      time.Time
+	waiting   int
+	throttled uint64
+	mu        sync.Mutex
+}
+
+// NewTokenBucketRateLimiter creates a token bucket limiter.
+//
+// Parameters:
+//   - ratePerSecond: Sustained reconciles per second (must be > 0)
+//   - burst: Maximum reconciles allowed in a burst (must be >= 1)
+//
+// Returns:
+//   - *TokenBucketRateLimiter: Initialized limiter with a full bucket
+//   - error: If rate or burst is invalid
+func NewTokenBucketRateLimiter(ratePerSecond float64, burst int) (*TokenBucketRateLimiter, error) {
+	if ratePerSecond <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %v", ratePerSecond)
+	}
+	if burst < 1 {
+		return nil, fmt.Errorf("burst must be at least 1, got %d", burst)
+	}
+
+	return &TokenBucketRateLimiter{
+		rate:   ratePerSecond,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// Wait reserves a token and blocks until it becomes available.
+//
+// If ctx is done before the token is available, the reservation is returned
+// to the bucket and ctx.Err() is returned.
+func (l *TokenBucketRateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.refill(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.waiting++
+	l.throttled++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		l.waiting--
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.waiting--
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the limiter state.
+func (l *TokenBucketRateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	available := l.tokens
+	if available < 0 {
+		available = 0
+	}
+
+	return RateLimiterStats{
+		Rate:      l.rate,
+		Burst:     l.burst,
+		Available: available,
+		Waiting:   l.waiting,
+		Throttled: l.throttled,
+	}
+}
+
+// refill adds tokens accrued since the last refill. Caller must hold mu.
+func (l *TokenBucketRateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+
+	l.tokens += elapsed * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/storage"
+)
+
+func TestNewTokenBucketRateLimiter_Invalid(t *testing.T) {
+	if _, err := NewTokenBucketRateLimiter(0, 1); err == nil {
+		t.Error("Expected error for zero rate")
+	}
+	if _, err := NewTokenBucketRateLimiter(1, 0); err == nil {
+		t.Error("Expected error for zero burst")
+	}
+}
+
+func TestTokenBucketRateLimiter_Burst(t *testing.T) {
+	limiter, err := NewTokenBucketRateLimiter(10, 3)
+	if err != nil {
+		t.Fatalf("Failed to create limiter: %v", err)
+	}
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Burst should not block, took %v", elapsed)
+	}
+
+	// Fourth token requires a refill (~100ms at 10/s)
+	start = time.Now()
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected Wait to block after burst, took %v", elapsed)
+	}
+
+	stats := limiter.Stats()
+	if stats.Throttled != 1 {
+		t.Errorf("Throttled = %d, want 1", stats.Throttled)
+	}
+	if stats.Rate != 10 || stats.Burst != 3 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestTokenBucketRateLimiter_ContextCancel(t *testing.T) {
+	limiter, err := NewTokenBucketRateLimiter(0.1, 1)
+	if err != nil {
+		t.Fatalf("Failed to create limiter: %v", err)
+	}
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	// Cancelled reservation is returned, so nobody is left waiting
+	if stats := limiter.Stats(); stats.Waiting != 0 {
+		t.Errorf("Waiting = %d, want 0", stats.Waiting)
+	}
+}
+
+func TestRateLimiterStats_Saturation(t *testing.T) {
+	tests := []struct {
+		stats RateLimiterStats
+		want  float64
+	}{
+		{RateLimiterStats{Burst: 4, Available: 4}, 0},
+		{RateLimiterStats{Burst: 4, Available: 1}, 0.75},
+		{RateLimiterStats{Burst: 4, Available: 0, Waiting: 2}, 1.5},
+		{RateLimiterStats{}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.stats.Saturation(); got != tt.want {
+			t.Errorf("Saturation(%+v) = %v, want %v", tt.stats, got, tt.want)
+		}
+	}
+}
+
+func TestController_RateLimiterSharedAcrossWorkers(t *testing.T) {
+	ctx := context.Background()
+
+	eventBus := events.NewInMemoryEventBus(100, 1)
+	eventBus.Start()
+	defer eventBus.Close() //nolint:errcheck
+
+	fileStorage, err := storage.NewFileBackend(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	limiter, err := NewTokenBucketRateLimiter(20, 1)
+	if err != nil {
+		t.Fatalf("Failed to create limiter: %v", err)
+	}
+
+	controller := NewController(eventBus, fileStorage)
+	controller.SetRateLimiter(limiter)
+
+	reconciler := &mockReconciler{}
+	if err := controller.RegisterReconciler(reconciler); err != nil {
+		t.Fatalf("Failed to register reconciler: %v", err)
+	}
+
+	if err := controller.Start(ctx); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	defer controller.Stop() //nolint:errcheck
+
+	// Five workers, five distinct requests, but only 20/s with burst 1
+	for _, uid := range []string{"a", "b", "c", "d", "e"} {
+		if err := controller.Enqueue(ReconcileRequest{ResourceKind: "TestResource", ResourceUID: uid}); err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	metrics := controller.Metrics()
+	if metrics.RateLimit == nil {
+		t.Fatal("Expected rate limit metrics")
+	}
+	if metrics.RateLimit.Throttled == 0 {
+		t.Error("Expected workers to be throttled by the shared limiter")
+	}
+	if metrics.RateLimit.Rate != 20 {
+		t.Errorf("Rate = %v, want 20", metrics.RateLimit.Rate)
+	}
+}
+
+func TestController_MetricsWithoutRateLimiter(t *testing.T) {
+	controller := NewController(events.NewInMemoryEventBus(10, 1), nil)
+
+	metrics := controller.Metrics()
+	if metrics.RateLimit != nil {
+		t.Error("Expected no rate limit metrics when limiter is not configured")
+	}
+	if metrics.QueueDepth != 0 || metrics.Processing != 0 {
+		t.Errorf("Unexpected metrics: %+v", metrics)
+	}
+}
@@ -14,22 +14,54 @@ import (
 // Features:
 //   - Thread-safe
 //   - Automatic deduplication
+//   - Coalescing of changes that arrive while an item is processing
 //   - Rate limiting
 //   - Delayed requeueing
 //   - Graceful shutdown
+//
+// Items are tracked by key (see KeyFunc). At most one entry per key is ever
+// queued, and a key is never handed to two workers at once. If a key is
+// added while it is being processed, it is marked dirty and requeued exactly
+// once when Done is called, so the latest change is never lost.
 type WorkQueue struct {
-	queue        []interface{}
+	queue        []interface{}               // keys, in FIFO order
+	dirty        map[interface{}]interface{} // key -> latest item awaiting processing
 	processing   map[interface{}]struct{}
+	waiting      map[interface{}]time.Time // key -> earliest pending AddAfter
+	timers       map[*time.Timer]struct{}
+	keyFunc      KeyFunc
 	mu           sync.RWMutex
 	cond         *sync.Cond
 	shuttingDown bool
 }
 
-// NewWorkQueue creates a new work queue.
+// KeyFunc maps a queue item to the key used for deduplication.
+//
+// Items with equal keys are coalesced; the most recently added item is the
+// one returned by Get.
+type KeyFunc func(item interface{}) interface{}
+
+// NewWorkQueue creates a new work queue that deduplicates equal items.
 func NewWorkQueue() *WorkQueue {
+	return NewWorkQueueWithKeyFunc(func(item interface{}) interface{} { return item })
+}
+
+// NewWorkQueueWithKeyFunc creates a work queue that deduplicates items by key.
+//
+// Example:
+//
+//	// Coalesce requests for the same resource regardless of Reason
+//	q := NewWorkQueueWithKeyFunc(func(item interface{}) interface{} {
+//	    return item.(ReconcileRequest).String()
+//	})
+func NewWorkQueueWithKeyFunc(keyFunc KeyFunc) *WorkQueue {
 	wq := &WorkQueue{
 		queue:      []interface{}{},
+		dirty:      make(map[interface{}]interface{}),
 		processing: make(map[interface{}]struct{}),
+		waiting:    make(map[interface{}]time.Time),
+		timers:     make(map[*time.Timer]struct{}),
+		keyFunc:    keyFunc,
 	}
 	wq.cond = sync.NewCond(&wq.mu)
 	return wq
@@ -37,50 +69,92 @@ func NewWorkQueue() *WorkQueue {
 
 // Add adds an item to the queue.
 //
-// If the item is already in the queue or being processed, it won't be added again.
-// This provides automatic deduplication.
+// If an item with the same key is already queued, it is replaced by this one
+// and keeps its position. If the key is currently being processed, the item
+// is held until Done and then queued once.
 //
 // Parameters:
 //   - item: Item to add to the queue
 func (q *WorkQueue) Add(item interface{}) {
+	q.add(item)
+}
+
+// add adds an item as Add does and reports whether it created new work,
+// false if an item with the same key was already pending.
+func (q *WorkQueue) add(item interface{}) bool {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	return q.addLocked(item)
+}
+
+func (q *WorkQueue) addLocked(item interface{}) bool {
 	if q.shuttingDown {
-		return
+		return false
 	}
 
-	// Check if already processing
-	if _, exists := q.processing[item]; exists {
-		// Item is already being processed, skip
-		return
+	key := q.keyFunc(item)
+
+	// Already pending: keep the latest item, don't queue twice
+	if _, exists := q.dirty[key]; exists {
+		q.dirty[key] = item
+		return false
 	}
+	q.dirty[key] = item
 
-	// Check if already in queue
-	for _, existing := range q.queue {
-		if existing == item {
-			// Item already in queue, skip
-			return
-		}
+	// Being processed: requeued by Done
+	if _, exists := q.processing[key]; exists {
+		return true
 	}
 
-	// Add to queue
-	q.queue = append(q.queue, item)
+	q.queue = append(q.queue, key)
 	q.cond.Signal()
+	return true
 }
 
 // AddAfter adds an item to the queue after a delay.
 //
 // This is useful for requeueing items that should be processed later.
+// Repeated delayed adds for the same key are coalesced into the earliest one.
 //
 // Parameters:
 //   - item: Item to add
 //   - delay: Duration to wait before adding
 func (q *WorkQueue) AddAfter(item interface{}, delay time.Duration) {
-	go func() {
-		time.Sleep(delay)
+	if delay <= 0 {
 		q.Add(item)
-	}()
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.shuttingDown {
+		return
+	}
+
+	key := q.keyFunc(item)
+	readyAt := time.Now().Add(delay)
+	if existing, ok := q.waiting[key]; ok && !existing.After(readyAt) {
+		// An earlier delayed add is already scheduled
+		return
+	}
+	q.waiting[key] = readyAt
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		delete(q.timers, timer)
+		// Skip if superseded by an earlier AddAfter for the same key
+		if q.waiting[key] != readyAt {
+			return
+		}
+		delete(q.waiting, key)
+		q.addLocked(item)
+	})
+	q.timers[timer] = struct{}{}
 }
 
 // Get retrieves an item from the queue.
@@ -102,19 +176,22 @@ func (q *WorkQueue) Get() (interface{}, bool) {
 		return nil, false
 	}
 
-	// Get first item
-	item := q.queue[0]
+	// Get first key
+	key := q.queue[0]
 	q.queue = q.queue[1:]
 
-	// Mark as processing
-	q.processing[item] = struct{}{}
+	// Move from dirty to processing
+	item := q.dirty[key]
+	delete(q.dirty, key)
+	q.processing[key] = struct{}{}
 
 	return item, true
 }
 
 // Done marks an item as finished processing.
 //
-// This removes the item from the processing set, allowing it to be added again.
+// This removes the item from the processing set. If the item's key was added
+// again while processing, it is requeued now.
 //
 // Parameters:
 //   - item: Item that finished processing
@@ -122,24 +199,31 @@ func (q *WorkQueue) Done(item interface{}) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	delete(q.processing, item)
+	key := q.keyFunc(item)
+	delete(q.processing, key)
+
+	if _, dirty := q.dirty[key]; dirty && !q.shuttingDown {
+		q.queue = append(q.queue, key)
+		q.cond.Signal()
+	}
 }
 
 // ShutDown initiates graceful shutdown of the queue.
 //
 // After shutdown:
 //   - No new items can be added
-//   - Get() will return false once the queue is empty
+//   - Pending delayed adds are cancelled
+//   - Get() returns false
 //   - Workers should stop after processing their current item
 func (q *WorkQueue) ShutDown() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	// Debug: print stack trace to see who's calling ShutDown
-	// fmt.Printf("⚠️  WorkQueue.ShutDown() called!\n")
-	// debug.PrintStack()
-
 	q.shuttingDown = true
+	for timer := range q.timers {
+		timer.Stop()
+	}
+	q.timers = make(map[*time.Timer]struct{})
 	q.cond.Broadcast()
 }
 
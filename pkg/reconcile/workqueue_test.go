@@ -301,3 +301,90 @@ func TestWorkQueue_AddAfter(t *testing.T) {
 		t.Errorf("Queue length = %d after delay, want 1", q.Len())
 	}
 }
+
+func TestWorkQueue_AddWhileProcessingRequeuesOnce(t *testing.T) {
+	q := NewWorkQueue()
+
+	item := "test-item"
+	q.Add(item)
+	got, _ := q.Get()
+
+	// Burst of changes while the item is in flight
+	for i := 0; i < 10; i++ {
+		q.Add(item)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Queue length = %d while item is processing, want 0", q.Len())
+	}
+
+	// Done schedules exactly one follow-up
+	q.Done(got)
+	if q.Len() != 1 {
+		t.Fatalf("Queue length = %d after Done(), want 1", q.Len())
+	}
+
+	got, _ = q.Get()
+	q.Done(got)
+	if q.Len() != 0 {
+		t.Errorf("Queue length = %d after follow-up, want 0", q.Len())
+	}
+}
+
+func TestWorkQueue_KeyFuncCoalesces(t *testing.T) {
+	type request struct {
+		uid    string
+		reason string
+	}
+	q := NewWorkQueueWithKeyFunc(func(item interface{}) interface{} {
+		return item.(request).uid
+	})
+
+	q.Add(request{uid: "a", reason: "created"})
+	q.Add(request{uid: "b", reason: "created"})
+	q.Add(request{uid: "a", reason: "updated"})
+
+	if q.Len() != 2 {
+		t.Fatalf("Queue length = %d, want 2", q.Len())
+	}
+
+	// First key keeps its position but carries the latest item
+	got, _ := q.Get()
+	if got != (request{uid: "a", reason: "updated"}) {
+		t.Errorf("Get() = %v, want latest item for key a", got)
+	}
+	q.Done(got)
+}
+
+func TestWorkQueue_AddAfterCoalesces(t *testing.T) {
+	q := NewWorkQueue()
+
+	q.AddAfter("item", 200*time.Millisecond)
+	q.AddAfter("item", 20*time.Millisecond)
+	q.AddAfter("item", 100*time.Millisecond)
+
+	time.Sleep(60 * time.Millisecond)
+	if q.Len() != 1 {
+		t.Fatalf("Queue length = %d after earliest delay, want 1", q.Len())
+	}
+
+	got, _ := q.Get()
+	q.Done(got)
+
+	// Later, superseded delays must not fire again
+	time.Sleep(200 * time.Millisecond)
+	if q.Len() != 0 {
+		t.Errorf("Queue length = %d, superseded AddAfter fired", q.Len())
+	}
+}
+
+func TestWorkQueue_ShutDownCancelsAddAfter(t *testing.T) {
+	q := NewWorkQueue()
+
+	q.AddAfter("item", 20*time.Millisecond)
+	q.ShutDown()
+
+	time.Sleep(50 * time.Millisecond)
+	if q.Len() != 0 {
+		t.Errorf("Queue length = %d after shutdown, want 0", q.Len())
+	}
+}
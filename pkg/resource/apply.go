@@ -0,0 +1,113 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// ApplyModeMerge is the applyMode query value that selects ApplyMerge
+// semantics for PUT requests.
+const ApplyModeMerge = "merge"
+
+// systemMetadataFields are metadata fields owned by the server. Clients
+// cannot change them through ApplyMerge.
+var systemMetadataFields = []string{"uid", "createdAt", "updatedAt", "generation", "managedFields"}
+
+// ApplyMerge merges an incoming resource document into the stored one.
+//
+// Unlike a full replacement, fields the client does not send are kept, so
+// a PUT cannot wipe out values written by other clients or controllers:
+//   - spec is merged with JSON Merge Patch (RFC 7386) semantics: keys present
+//     in the incoming spec replace stored values, nested objects are merged,
+//     null removes a key, and absent keys are left untouched
+//   - metadata name, labels and annotations are merged the same way
+//   - status is always kept from the stored object
+//   - metadata uid, createdAt, updatedAt, generation and managedFields, as
+//     well as apiVersion, kind and schemaVersion, are kept from the stored
+//     object
+//
+// Both documents must be JSON objects in the resource envelope format
+// (apiVersion, kind, metadata, spec, status).
+//
+// Parameters:
+//   - stored: The current persisted resource
+//   - incoming: The client's desired state (may omit any field)
+//
+// Returns:
+//   - json.RawMessage: The merged resource
+//   - error: If either document is not a JSON object or merging fails
+//
+// Example:
+//
+//	stored, _ := json.Marshal(device)
+//	merged, err := resource.ApplyMerge(stored, json.RawMessage(`{"spec":{"location":"rack-2"}}`))
+//	if err != nil {
+//	    return err
+//	}
+//	var updated Device
+//	err = json.Unmarshal(merged, &updated)
+func ApplyMerge(stored, incoming json.RawMessage) (json.RawMessage, error) {
+	var storedDoc, incomingDoc map[string]json.RawMessage
+	if err := json.Unmarshal(stored, &storedDoc); err != nil {
+		return nil, fmt.Errorf("stored resource is not a JSON object: %w", err)
+	}
+	if err := json.Unmarshal(incoming, &incomingDoc); err != nil {
+		return nil, fmt.Errorf("incoming resource is not a JSON object: %w", err)
+	}
+	if storedDoc == nil {
+		storedDoc = map[string]json.RawMessage{}
+	}
+
+	if spec, ok := incomingDoc["spec"]; ok {
+		merged, err := mergeJSON(storedDoc["spec"], spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge spec: %w", err)
+		}
+		setOrDelete(storedDoc, "spec", merged)
+	}
+
+	if metadata, ok := incomingDoc["metadata"]; ok {
+		var metadataPatch map[string]json.RawMessage
+		if err := json.Unmarshal(metadata, &metadataPatch); err != nil {
+			return nil, fmt.Errorf("incoming metadata is not a JSON object: %w", err)
+		}
+		for _, field := range systemMetadataFields {
+			delete(metadataPatch, field)
+		}
+		patch, err := json.Marshal(metadataPatch)
+		if err != nil {
+			return nil, err
+		}
+		merged, err := mergeJSON(storedDoc["metadata"], patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge metadata: %w", err)
+		}
+		setOrDelete(storedDoc, "metadata", merged)
+	}
+
+	return json.Marshal(storedDoc)
+}
+
+// mergeJSON applies patch to original with RFC 7386 semantics. A missing
+// original is treated as an empty object.
+func mergeJSON(original, patch json.RawMessage) (json.RawMessage, error) {
+	if len(original) == 0 || string(original) == "null" {
+		original = json.RawMessage("{}")
+	}
+	return jsonpatch.MergePatch(original, patch)
+}
+
+// setOrDelete stores value under key, removing the key when value is null.
+func setOrDelete(doc map[string]json.RawMessage, key string, value json.RawMessage) {
+	if string(value) == "null" {
+		delete(doc, key)
+		return
+	}
+	doc[key] = value
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const storedDevice = `{
+	"apiVersion": "v1",
+	"kind": "Device",
+	"metadata": {
+		"name": "dev-a",
+		"uid": "dev-12345678",
+		"labels": {"rack": "r1", "zone": "z1"},
+		"createdAt": "2025-01-01T00:00:00Z",
+		"updatedAt": "2025-01-02T00:00:00Z",
+		"generation": 3
+	},
+	"spec": {"location": "rack-1", "model": "x100", "ports": {"mgmt": 22, "api": 443}},
+	"status": {"phase": "Ready", "observedGeneration": 3}
+}`
+
+func applyMerge(t *testing.T, incoming string) map[string]interface{} {
+	t.Helper()
+
+	merged, err := ApplyMerge(json.RawMessage(storedDevice), json.RawMessage(incoming))
+	if err != nil {
+		t.Fatalf("ApplyMerge failed: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(merged, &result); err != nil {
+		t.Fatalf("ApplyMerge returned invalid JSON: %v", err)
+	}
+	return result
+}
+
+func TestApplyMerge_MergesSpecAndPreservesStatus(t *testing.T) {
+	result := applyMerge(t, `{
+		"spec": {"location": "rack-2", "ports": {"api": null, "ipmi": 623}},
+		"status": {"phase": "Failed"}
+	}`)
+
+	spec := result["spec"].(map[string]interface{})
+	if spec["location"] != "rack-2" {
+		t.Errorf("spec.location = %v, want rack-2", spec["location"])
+	}
+	if spec["model"] != "x100" {
+		t.Errorf("spec.model = %v, want x100 (absent fields must be kept)", spec["model"])
+	}
+	ports := spec["ports"].(map[string]interface{})
+	if _, ok := ports["api"]; ok {
+		t.Error("spec.ports.api should be removed by null")
+	}
+	if ports["mgmt"] != float64(22) || ports["ipmi"] != float64(623) {
+		t.Errorf("spec.ports = %v", ports)
+	}
+
+	status := result["status"].(map[string]interface{})
+	if status["phase"] != "Ready" {
+		t.Errorf("status.phase = %v, want Ready (status must be preserved)", status["phase"])
+	}
+}
+
+func TestApplyMerge_ProtectsSystemMetadata(t *testing.T) {
+	result := applyMerge(t, `{
+		"apiVersion": "v2",
+		"kind": "Other",
+		"metadata": {
+			"name": "dev-b",
+			"uid": "dev-evil",
+			"createdAt": "1999-01-01T00:00:00Z",
+			"generation": 99,
+			"labels": {"zone": null, "env": "prod"}
+		}
+	}`)
+
+	if result["apiVersion"] != "v1" || result["kind"] != "Device" {
+		t.Errorf("apiVersion/kind changed: %v/%v", result["apiVersion"], result["kind"])
+	}
+	metadata := result["metadata"].(map[string]interface{})
+	if metadata["name"] != "dev-b" {
+		t.Errorf("metadata.name = %v, want dev-b", metadata["name"])
+	}
+	if metadata["uid"] != "dev-12345678" || metadata["createdAt"] != "2025-01-01T00:00:00Z" || metadata["generation"] != float64(3) {
+		t.Errorf("System metadata was modified: %v", metadata)
+	}
+	labels := metadata["labels"].(map[string]interface{})
+	if labels["rack"] != "r1" || labels["env"] != "prod" {
+		t.Errorf("metadata.labels = %v", labels)
+	}
+	if _, ok := labels["zone"]; ok {
+		t.Error("metadata.labels.zone should be removed by null")
+	}
+	if _, ok := result["spec"]; !ok {
+		t.Error("spec should be kept when incoming omits it")
+	}
+}
+
+func TestApplyMerge_InvalidInput(t *testing.T) {
+	if _, err := ApplyMerge(json.RawMessage(`[]`), json.RawMessage(`{}`)); err == nil {
+		t.Error("Expected error for non-object stored document")
+	}
+	if _, err := ApplyMerge(json.RawMessage(storedDevice), json.RawMessage(`"spec"`)); err == nil {
+		t.Error("Expected error for non-object incoming document")
+	}
+	if _, err := ApplyMerge(json.RawMessage(storedDevice), json.RawMessage(`{"metadata": 1}`)); err == nil {
+		t.Error("Expected error for non-object incoming metadata")
+	}
+}
@@ -10,6 +10,28 @@ import (
 	"time"
 )
 
+// Condition status values.
+//
+// Use these instead of string literals so every resource reports
+// status with the same spelling.
+const (
+	ConditionTrue    = "True"
+	ConditionFalse   = "False"
+	ConditionUnknown = "Unknown"
+)
+
+// Common condition types.
+//
+// Reconcilers should prefer these over ad-hoc strings to avoid mismatches
+// such as "Ready" vs "ready" across resources.
+const (
+	ConditionTypeReady       = "Ready"
+	ConditionTypeHealthy     = "Healthy"
+	ConditionTypeReachable   = "Reachable"
+	ConditionTypeProgressing = "Progressing"
+	ConditionTypeDegraded    = "Degraded"
+)
+
 // Condition represents a specific condition of a resource.
 //
 // Conditions follow the Kubernetes pattern for representing the status of
@@ -27,32 +49,35 @@ import (
 //   - Reason: Machine-readable reason for the condition's last transition
 //   - Message: Human-readable message explaining the condition
 //   - LastTransitionTime: When the condition last changed status
+//   - ObservedGeneration: The metadata.generation the condition was computed from
 //
 // Example Usage:
 //
 //	// Create a new condition
-//	condition := NewCondition("Ready", "True", "Healthy", "All health checks passed")
+//	condition := NewCondition(ConditionTypeReady, ConditionTrue, "Healthy", "All health checks passed")
 //
 //	// Add to a conditions slice
 //	var conditions []Condition
-//	SetCondition(&conditions, "Ready", "True", "Healthy", "Resource is operational")
+//	SetCondition(&conditions, ConditionTypeReady, ConditionTrue, "Healthy", "Resource is operational")
 //
 //	// Check condition status
-//	if IsConditionTrue(conditions, "Ready") {
+//	if IsConditionTrue(conditions, ConditionTypeReady) {
 //	    // Handle ready state
 //	}
 //
 // Common Condition Types:
-//   - "Ready": Resource is ready for use
-//   - "Healthy": Resource is functioning properly
-//   - "Reachable": Resource can be contacted
-//   - "Progressing": Resource is making progress toward desired state
+//   - ConditionTypeReady: Resource is ready for use
+//   - ConditionTypeHealthy: Resource is functioning properly
+//   - ConditionTypeReachable: Resource can be contacted
+//   - ConditionTypeProgressing: Resource is making progress toward desired state
+//   - ConditionTypeDegraded: Resource is operating with reduced functionality
 type Condition struct {
 	Type               string    `json:"type" yaml:"type"`
 	Status             string    `json:"status" yaml:"status"` // "True", "False", "Unknown"
 	Reason             string    `json:"reason,omitempty" yaml:"reason,omitempty"`
 	Message            string    `json:"message,omitempty" yaml:"message,omitempty"`
 	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty" yaml:"lastTransitionTime,omitempty"`
+	ObservedGeneration int64     `json:"observedGeneration,omitempty" yaml:"observedGeneration,omitempty"`
 }
 
 // NewCondition creates a new condition with the specified parameters.
@@ -89,21 +114,21 @@ func NewCondition(conditionType, status, reason, message string) Condition {
 //	    // Handle true condition
 //	}
 func (c *Condition) IsTrue() bool {
-	return c.Status == "True"
+	return c.Status == ConditionTrue
 }
 
 // IsFalse checks if condition status is "False".
 //
 // This is a convenience method for checking if a condition is in the "False" state.
 func (c *Condition) IsFalse() bool {
-	return c.Status == "False"
+	return c.Status == ConditionFalse
 }
 
 // IsUnknown checks if condition status is "Unknown".
 //
 // This is a convenience method for checking if a condition is in the "Unknown" state.
 func (c *Condition) IsUnknown() bool {
-	return c.Status == "Unknown"
+	return c.Status == ConditionUnknown
 }
 
 // Update updates the condition if status, reason, or message changed.
@@ -161,6 +186,7 @@ func (c *Condition) Clone() Condition {
 		Reason:             c.Reason,
 		Message:            c.Message,
 		LastTransitionTime: c.LastTransitionTime,
+		ObservedGeneration: c.ObservedGeneration,
 	}
 }
 
@@ -187,6 +213,25 @@ func FindCondition(conditions []Condition, conditionType string) *Condition {
 	return nil
 }
 
+// GetCondition returns a copy of the condition with the given type.
+//
+// Unlike FindCondition, the returned value is detached from the slice, so it
+// is safe to keep after the slice is modified. The boolean reports whether
+// the condition was found.
+//
+// Example:
+//
+//	if ready, ok := GetCondition(resource.Status.Conditions, ConditionTypeReady); ok {
+//	    log.Printf("Ready=%s since %s", ready.Status, ready.LastTransitionTime)
+//	}
+func GetCondition(conditions []Condition, conditionType string) (Condition, bool) {
+	condition := FindCondition(conditions, conditionType)
+	if condition == nil {
+		return Condition{}, false
+	}
+	return condition.Clone(), true
+}
+
 // SetCondition sets or updates a condition in a slice.
 //
 // If a condition with the specified type already exists, it will be updated.
@@ -262,7 +307,7 @@ func SetConditionWithEvents(ctx context.Context, conditions *[]Condition, condit
 
 	// Publish event for new condition if we have resource info
 	if resourceKind != "" && resourceUID != "" {
-		publishConditionEvent(ctx, conditionType, status, "Unknown", resourceKind, resourceUID, reason, message)
+		publishConditionEvent(ctx, conditionType, status, ConditionUnknown, resourceKind, resourceUID, reason, message)
 	}
 
 	return true
@@ -342,11 +387,47 @@ func IsConditionTrue(conditions []Condition, conditionType string) bool {
 func GetConditionStatus(conditions []Condition, conditionType string) string {
 	condition := FindCondition(conditions, conditionType)
 	if condition == nil {
-		return "Unknown"
+		return ConditionUnknown
 	}
 	return condition.Status
 }
 
+// SetConditionObservedGeneration records the spec generation a condition was
+// computed from.
+//
+// Reconcilers should call this after setting a condition so clients can tell
+// whether the condition reflects the latest spec. Returns true if the
+// condition exists and its ObservedGeneration changed.
+//
+// Example:
+//
+//	SetCondition(&res.Status.Conditions, ConditionTypeReady, ConditionTrue, "Healthy", "")
+//	SetConditionObservedGeneration(res.Status.Conditions, ConditionTypeReady, res.Metadata.Generation)
+func SetConditionObservedGeneration(conditions []Condition, conditionType string, generation int64) bool {
+	condition := FindCondition(conditions, conditionType)
+	if condition == nil || condition.ObservedGeneration == generation {
+		return false
+	}
+	condition.ObservedGeneration = generation
+	return true
+}
+
+// IsConditionCurrent checks if a condition was computed from the given
+// generation or a later one.
+//
+// Returns false if the condition doesn't exist. A stale condition means the
+// spec changed after the reconciler last evaluated it.
+//
+// Example:
+//
+//	if !IsConditionCurrent(res.Status.Conditions, ConditionTypeReady, res.Metadata.Generation) {
+//	    // Ready reflects an older spec; reconcile again
+//	}
+func IsConditionCurrent(conditions []Condition, conditionType string, generation int64) bool {
+	condition := FindCondition(conditions, conditionType)
+	return condition != nil && condition.ObservedGeneration >= generation
+}
+
 // ConditionEventPublisher is a function type for publishing condition change events.
 // This allows the conditions package to publish events without directly depending
 // on the events package, maintaining clean separation of concerns.
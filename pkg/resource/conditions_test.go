@@ -0,0 +1,128 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetCondition_TransitionTime(t *testing.T) {
+	var conditions []Condition
+
+	if !SetCondition(&conditions, ConditionTypeReady, ConditionFalse, "Starting", "Waiting for device") {
+		t.Fatal("Expected first SetCondition to report a change")
+	}
+	first, ok := GetCondition(conditions, ConditionTypeReady)
+	if !ok {
+		t.Fatal("Expected Ready condition to be present")
+	}
+	if first.LastTransitionTime.IsZero() {
+		t.Fatal("Expected LastTransitionTime to be set")
+	}
+
+	// Same status, new reason: changed, but transition time is preserved
+	time.Sleep(time.Millisecond)
+	if !SetCondition(&conditions, ConditionTypeReady, ConditionFalse, "Probing", "Probing device") {
+		t.Error("Expected reason change to report a change")
+	}
+	updated, _ := GetCondition(conditions, ConditionTypeReady)
+	if !updated.LastTransitionTime.Equal(first.LastTransitionTime) {
+		t.Error("LastTransitionTime should not change when status is unchanged")
+	}
+	if updated.Reason != "Probing" {
+		t.Errorf("Expected reason Probing, got %s", updated.Reason)
+	}
+
+	// Identical update: no change
+	if SetCondition(&conditions, ConditionTypeReady, ConditionFalse, "Probing", "Probing device") {
+		t.Error("Expected identical SetCondition to report no change")
+	}
+
+	// Status change: transition time moves forward
+	time.Sleep(time.Millisecond)
+	SetCondition(&conditions, ConditionTypeReady, ConditionTrue, "Healthy", "All checks passed")
+	ready, _ := GetCondition(conditions, ConditionTypeReady)
+	if !ready.LastTransitionTime.After(first.LastTransitionTime) {
+		t.Error("LastTransitionTime should advance when status changes")
+	}
+
+	if len(conditions) != 1 {
+		t.Errorf("Expected 1 condition, got %d", len(conditions))
+	}
+}
+
+func TestGetCondition(t *testing.T) {
+	conditions := []Condition{
+		NewCondition(ConditionTypeReady, ConditionTrue, "Healthy", ""),
+	}
+
+	if _, ok := GetCondition(conditions, ConditionTypeReachable); ok {
+		t.Error("Expected missing condition to report not found")
+	}
+
+	ready, ok := GetCondition(conditions, ConditionTypeReady)
+	if !ok {
+		t.Fatal("Expected Ready condition to be found")
+	}
+
+	// The returned value is a copy
+	ready.Status = ConditionFalse
+	if conditions[0].Status != ConditionTrue {
+		t.Error("Modifying the returned condition should not affect the slice")
+	}
+}
+
+func TestConditionStatusHelpers(t *testing.T) {
+	var conditions []Condition
+	SetCondition(&conditions, ConditionTypeReady, ConditionTrue, "Healthy", "")
+	SetCondition(&conditions, ConditionTypeReachable, ConditionFalse, "Timeout", "")
+
+	if !IsConditionTrue(conditions, ConditionTypeReady) {
+		t.Error("Expected Ready to be true")
+	}
+	if IsConditionTrue(conditions, ConditionTypeReachable) {
+		t.Error("Expected Reachable to be false")
+	}
+	if IsConditionTrue(conditions, ConditionTypeHealthy) {
+		t.Error("Missing condition should not be true")
+	}
+	if status := GetConditionStatus(conditions, ConditionTypeHealthy); status != ConditionUnknown {
+		t.Errorf("Expected missing condition status %s, got %s", ConditionUnknown, status)
+	}
+}
+
+func TestConditionClone_ObservedGeneration(t *testing.T) {
+	condition := NewCondition(ConditionTypeReady, ConditionTrue, "Healthy", "")
+	condition.ObservedGeneration = 3
+
+	if clone := condition.Clone(); clone.ObservedGeneration != 3 {
+		t.Errorf("Expected ObservedGeneration 3, got %d", clone.ObservedGeneration)
+	}
+}
+
+func TestConditionObservedGeneration(t *testing.T) {
+	var conditions []Condition
+	SetCondition(&conditions, ConditionTypeReady, ConditionTrue, "Healthy", "")
+
+	if IsConditionCurrent(conditions, ConditionTypeReady, 1) {
+		t.Error("Condition without observed generation should not be current")
+	}
+	if !SetConditionObservedGeneration(conditions, ConditionTypeReady, 1) {
+		t.Error("Expected observed generation to change")
+	}
+	if SetConditionObservedGeneration(conditions, ConditionTypeReady, 1) {
+		t.Error("Expected no change when generation is unchanged")
+	}
+	if !IsConditionCurrent(conditions, ConditionTypeReady, 1) {
+		t.Error("Expected condition to be current for generation 1")
+	}
+	if IsConditionCurrent(conditions, ConditionTypeReady, 2) {
+		t.Error("Expected condition to be stale for generation 2")
+	}
+	if SetConditionObservedGeneration(conditions, ConditionTypeHealthy, 1) {
+		t.Error("Missing condition should not report a change")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DefaultTag is the struct tag holding a field's default value.
+const DefaultTag = "default"
+
+// ApplyDefaults sets zero-valued fields of obj to the value of their
+// `default` struct tag. Fields that already have a non-zero value are left
+// alone.
+//
+// Supported field types are strings, integers, floats, bools (including
+// named types such as `type Phase string`) and slices of those, whose
+// default is a comma-separated list. Nested structs and non-nil struct
+// pointers are defaulted recursively.
+//
+// The generated create handlers call ApplyDefaults before mutators and
+// validation, so defaulted values are validated like client-supplied ones.
+//
+// Example:
+//
+//	type DeviceStatus struct {
+//	    Phase string   `json:"phase" default:"pending"`
+//	    Ports []int    `json:"ports,omitempty" default:"22,443"`
+//	}
+//
+//	device := &Device{}
+//	err := ApplyDefaults(device) // device.Status.Phase == "pending"
+//
+// Returns an error if obj is not a non-nil pointer to a struct, or if a
+// default cannot be parsed as its field's type.
+func ApplyDefaults(obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot apply defaults to %T: expected a non-nil pointer to a struct", obj)
+	}
+	return applyStructDefaults(v.Elem())
+}
+
+// applyStructDefaults sets the defaults of a settable struct value's fields.
+func applyStructDefaults(v reflect.Value) error {
+	t := v.Type()
+	for _, field := range structFields(t) {
+		value := v.Field(field.index)
+
+		if field.hasDefault {
+			if !value.IsZero() {
+				continue
+			}
+			if err := setDefault(value, field.defaultValue); err != nil {
+				return fmt.Errorf("invalid default for field %s: %w", t.Field(field.index).Name, err)
+			}
+			continue
+		}
+
+		for value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Struct {
+			if err := applyStructDefaults(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDefault parses def as the type of v and stores it in v.
+func setDefault(v reflect.Value, def string) error {
+	if v.Kind() != reflect.Slice {
+		return setScalar(v, def)
+	}
+
+	if def == "" {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		return nil
+	}
+	items := strings.Split(def, ",")
+	slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := setScalar(slice.Index(i), strings.TrimSpace(item)); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// setScalar parses s as the scalar type of v and stores it in v.
+func setScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+type leasePhase string
+
+type leaseNetwork struct {
+	MTU int `json:"mtu" default:"1500"`
+}
+
+type leaseSpec struct {
+	Owner    string       `json:"owner" default:"system"`
+	Phase    leasePhase   `json:"phase" default:"pending"`
+	Seconds  int          `json:"seconds" default:"3600"`
+	Priority uint8        `json:"priority" default:"5"`
+	Weight   float64      `json:"weight" default:"0.5"`
+	Renew    bool         `json:"renew" default:"true"`
+	Tags     []string     `json:"tags" default:"a, b"`
+	Ports    []int        `json:"ports" default:"22,443"`
+	Network  leaseNetwork `json:"network"`
+	Backup   *leaseNetwork
+	Comment  string `json:"comment"`
+}
+
+type lease struct {
+	Resource
+	Spec leaseSpec `json:"spec"`
+}
+
+func TestApplyDefaults_FillsZeroValues(t *testing.T) {
+	obj := &lease{Spec: leaseSpec{Backup: &leaseNetwork{}}}
+	if err := ApplyDefaults(obj); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+
+	want := leaseSpec{
+		Owner:    "system",
+		Phase:    "pending",
+		Seconds:  3600,
+		Priority: 5,
+		Weight:   0.5,
+		Renew:    true,
+		Tags:     []string{"a", "b"},
+		Ports:    []int{22, 443},
+		Network:  leaseNetwork{MTU: 1500},
+		Backup:   &leaseNetwork{MTU: 1500},
+	}
+	if !reflect.DeepEqual(obj.Spec, want) {
+		t.Errorf("Spec = %+v, want %+v", obj.Spec, want)
+	}
+}
+
+func TestApplyDefaults_KeepsSetValues(t *testing.T) {
+	spec := leaseSpec{
+		Owner:    "alice",
+		Phase:    "active",
+		Seconds:  60,
+		Priority: 1,
+		Weight:   2,
+		Tags:     []string{},
+		Ports:    []int{8080},
+		Network:  leaseNetwork{MTU: 9000},
+	}
+	obj := &lease{Spec: spec}
+	if err := ApplyDefaults(obj); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+
+	// Renew is false, which is its zero value, so it is defaulted
+	spec.Renew = true
+	if !reflect.DeepEqual(obj.Spec, spec) {
+		t.Errorf("Spec = %+v, want %+v", obj.Spec, spec)
+	}
+}
+
+func TestApplyDefaults_Errors(t *testing.T) {
+	if err := ApplyDefaults(lease{}); err == nil {
+		t.Error("Expected error for non-pointer value")
+	}
+	if err := ApplyDefaults((*lease)(nil)); err == nil {
+		t.Error("Expected error for nil pointer")
+	}
+
+	badInt := &struct {
+		Count int `default:"many"`
+	}{}
+	if err := ApplyDefaults(badInt); err == nil {
+		t.Error("Expected error for unparsable int default")
+	}
+
+	overflow := &struct {
+		Count int8 `default:"300"`
+	}{}
+	if err := ApplyDefaults(overflow); err == nil {
+		t.Error("Expected error for out-of-range default")
+	}
+
+	unsupported := &struct {
+		Labels map[string]string `default:"a=b"`
+	}{}
+	if err := ApplyDefaults(unsupported); err == nil {
+		t.Error("Expected error for unsupported field type")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeType says how a field differs between two versions of a resource.
+type ChangeType string
+
+const (
+	// ChangeAdded is a field that is only present in the new version
+	ChangeAdded ChangeType = "added"
+
+	// ChangeRemoved is a field that is only present in the old version
+	ChangeRemoved ChangeType = "removed"
+
+	// ChangeModified is a field present in both versions with different values
+	ChangeModified ChangeType = "modified"
+)
+
+// FieldChange is one difference found by Diff.
+type FieldChange struct {
+	// Path locates the field in the resource's JSON form, in the syntax of
+	// pkg/fieldpath (e.g. "spec.network.ip", "spec.ports[2]",
+	// "metadata.labels['app.io/tier']")
+	Path string `json:"path"`
+
+	// Type is whether the field was added, removed or modified
+	Type ChangeType `json:"type"`
+
+	// Before is the old value, decoded from JSON; nil if the field was added
+	Before interface{} `json:"before,omitempty"`
+
+	// After is the new value, decoded from JSON; nil if the field was removed
+	After interface{} `json:"after,omitempty"`
+}
+
+// diffIgnoredFields are system fields that change on every write and would
+// otherwise appear in every diff
+var diffIgnoredFields = map[string]bool{
+	"metadata.createdAt":       true,
+	"metadata.updatedAt":       true,
+	"metadata.generation":      true,
+	"metadata.resourceVersion": true,
+	"metadata.managedFields":   true,
+}
+
+// Diff compares two versions of a resource and returns every field that
+// changed, with its value before and after.
+//
+// Both values are compared in their JSON form, so old and new may be
+// resources, specs, maps, or already-encoded JSON ([]byte or
+// json.RawMessage). Objects and maps are compared key by key and arrays
+// element by element; a field added or removed as a whole (say, a new nested
+// object) is reported once, at its own path. JSON null counts as absent.
+// System fields that change on every write (metadata.createdAt, updatedAt,
+// generation, resourceVersion and managedFields) are ignored.
+//
+// Changes are ordered by path, with object keys sorted and array elements in
+// index order.
+//
+// Example:
+//
+//	changes, err := resource.Diff(previous, device)
+//	for _, c := range changes {
+//	    log.Printf("%s %s: %v -> %v", c.Type, c.Path, c.Before, c.After)
+//	}
+//	// modified spec.network.ip: 10.0.0.1 -> 10.0.0.2
+func Diff(old, new interface{}) ([]FieldChange, error) {
+	oldDoc, err := decodeForDiff(old)
+	if err != nil {
+		return nil, fmt.Errorf("old version: %w", err)
+	}
+	newDoc, err := decodeForDiff(new)
+	if err != nil {
+		return nil, fmt.Errorf("new version: %w", err)
+	}
+
+	var changes []FieldChange
+	diffValues("", oldDoc, newDoc, &changes)
+	return changes, nil
+}
+
+// ChangedPaths returns the paths of changes, in order.
+func ChangedPaths(changes []FieldChange) []string {
+	paths := make([]string, len(changes))
+	for i, change := range changes {
+		paths[i] = change.Path
+	}
+	return paths
+}
+
+// decodeForDiff returns v's JSON form decoded into interface{}, keeping
+// numbers as json.Number so large integers compare exactly
+func decodeForDiff(v interface{}) (interface{}, error) {
+	var data []byte
+	switch raw := v.(type) {
+	case json.RawMessage:
+		data = raw
+	case []byte:
+		data = raw
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode: %w", err)
+		}
+		data = encoded
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return doc, nil
+}
+
+// diffValues appends the differences between a and b, found at path, to changes
+func diffValues(path string, a, b interface{}, changes *[]FieldChange) {
+	if diffIgnoredFields[path] {
+		return
+	}
+
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil:
+		*changes = append(*changes, FieldChange{Path: path, Type: ChangeAdded, After: b})
+		return
+	case b == nil:
+		*changes = append(*changes, FieldChange{Path: path, Type: ChangeRemoved, Before: a})
+		return
+	}
+
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := make([]string, 0, len(aMap)+len(bMap))
+		for key := range aMap {
+			keys = append(keys, key)
+		}
+		for key := range bMap {
+			if _, ok := aMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffValues(joinKey(path, key), aMap[key], bMap[key], changes)
+		}
+		return
+	}
+
+	aList, aIsList := a.([]interface{})
+	bList, bIsList := b.([]interface{})
+	if aIsList && bIsList {
+		for i := 0; i < len(aList) || i < len(bList); i++ {
+			var aItem, bItem interface{}
+			if i < len(aList) {
+				aItem = aList[i]
+			}
+			if i < len(bList) {
+				bItem = bList[i]
+			}
+			diffValues(path+"["+strconv.Itoa(i)+"]", aItem, bItem, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, FieldChange{Path: path, Type: ChangeModified, Before: a, After: b})
+	}
+}
+
+// joinKey appends an object key to a path, quoting keys that contain path
+// syntax, as in labels['app.io/tier']
+func joinKey(path, key string) string {
+	if key != "" && !strings.ContainsAny(key, ".[]'\"") {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	quote := "'"
+	if strings.Contains(key, "'") {
+		quote = `"`
+	}
+	return path + "[" + quote + key + quote + "]"
+}
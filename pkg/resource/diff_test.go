@@ -0,0 +1,89 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/fieldpath"
+)
+
+func TestDiff(t *testing.T) {
+	old := json.RawMessage(`{
+		"metadata": {"labels": {"app.io/tier": "gold", "zone": "a"}},
+		"spec": {"location": "r1", "network": {"ip": "10.0.0.1", "mask": 24}, "ports": [22, 80], "old": true}
+	}`)
+	updated := json.RawMessage(`{
+		"metadata": {"labels": {"app.io/tier": "silver", "zone": "a"}},
+		"spec": {"location": "r1", "network": {"ip": "10.0.0.2", "mask": 24}, "ports": [22, 443, 8080], "rack": {"u": 4}}
+	}`)
+
+	changes, err := Diff(old, updated)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	want := []FieldChange{
+		{Path: "metadata.labels['app.io/tier']", Type: ChangeModified, Before: "gold", After: "silver"},
+		{Path: "spec.network.ip", Type: ChangeModified, Before: "10.0.0.1", After: "10.0.0.2"},
+		{Path: "spec.old", Type: ChangeRemoved, Before: true},
+		{Path: "spec.ports[1]", Type: ChangeModified, Before: json.Number("80"), After: json.Number("443")},
+		{Path: "spec.ports[2]", Type: ChangeAdded, After: json.Number("8080")},
+		{Path: "spec.rack", Type: ChangeAdded, After: map[string]interface{}{"u": json.Number("4")}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Expected %+v, got %+v", want, changes)
+	}
+
+	// Paths can be read back with pkg/fieldpath
+	var doc interface{}
+	_ = json.Unmarshal(updated, &doc)
+	if v, ok := fieldpath.Lookup(doc, changes[0].Path); !ok || v != "silver" {
+		t.Errorf("Expected %s to resolve to silver, got %v, %v", changes[0].Path, v, ok)
+	}
+}
+
+func TestDiff_Structs(t *testing.T) {
+	type spec struct {
+		Name  string            `json:"name"`
+		Tags  map[string]string `json:"tags,omitempty"`
+		Count int64             `json:"count"`
+	}
+	type res struct {
+		Metadata Metadata `json:"metadata"`
+		Spec     spec     `json:"spec"`
+	}
+
+	old := res{Metadata: Metadata{UID: "x", CreatedAt: time.Unix(1, 0), Generation: 1}, Spec: spec{Name: "a", Count: 1 << 60}}
+	updated := old
+	updated.Metadata.UpdatedAt = time.Now()
+	updated.Metadata.Generation = 2
+	updated.Metadata.ResourceVersion = "7"
+	updated.Spec.Tags = map[string]string{"k": "v"}
+	updated.Spec.Count = 1<<60 + 1
+
+	changes, err := Diff(&old, &updated)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	got := ChangedPaths(changes)
+	want := []string{"spec.count", "spec.tags"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected system fields to be ignored and large integers compared exactly: want %v, got %v", want, got)
+	}
+
+	if changes, err := Diff(old, old); err != nil || len(changes) != 0 {
+		t.Errorf("Expected no changes for equal values, got %v, %v", changes, err)
+	}
+}
+
+func TestDiff_InvalidJSON(t *testing.T) {
+	if _, err := Diff(json.RawMessage(`{`), json.RawMessage(`{}`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
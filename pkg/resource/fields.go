@@ -0,0 +1,56 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structField describes an exported struct field for the reflection-based
+// helpers that run on every request (CheckImmutable, ApplyDefaults)
+type structField struct {
+	index int
+
+	// jsonName is the field's JSON name, "" for inlined embedded structs
+	jsonName string
+
+	// immutable is set for fields tagged `validate:"immutable"`
+	immutable bool
+
+	// defaultValue is the `default` tag, if hasDefault
+	defaultValue string
+	hasDefault   bool
+}
+
+// structFieldCache maps a struct reflect.Type to its []structField, so tags
+// are parsed once per type rather than once per request
+var structFieldCache sync.Map
+
+// structFields returns the exported fields of struct type t
+func structFields(t reflect.Type) []structField {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	fields := make([]structField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		def, hasDefault := field.Tag.Lookup(DefaultTag)
+		fields = append(fields, structField{
+			index:        i,
+			jsonName:     jsonFieldName(field),
+			immutable:    isImmutable(field),
+			defaultValue: def,
+			hasDefault:   hasDefault,
+		})
+	}
+
+	cached, _ := structFieldCache.LoadOrStore(t, fields)
+	return cached.([]structField)
+}
@@ -0,0 +1,117 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ImmutableTag is the validate tag option that marks a field as immutable.
+const ImmutableTag = "immutable"
+
+// CheckImmutable compares two values of the same struct type and returns the
+// JSON paths of fields tagged `validate:"immutable"` whose value changed.
+//
+// Setting an immutable field that was previously zero is allowed, so a value
+// can be filled in after creation; changing or clearing a non-zero value is
+// not. Nested structs and non-nil struct pointers are checked recursively,
+// and embedded (inline) structs contribute no path segment.
+//
+// The generated update and patch handlers call CheckImmutable with the stored
+// and incoming spec and reject the request with 422 if any field changed.
+//
+// Example:
+//
+//	type DeviceSpec struct {
+//	    Serial string `json:"serial" validate:"required,immutable"`
+//	}
+//
+//	changed, err := CheckImmutable(previous.Spec, device.Spec)
+//	// changed == []string{"serial"} if the serial number was modified
+//
+// Returns an error if old and new are not structs (or pointers to structs) of
+// the same type.
+func CheckImmutable(old, new interface{}) ([]string, error) {
+	oldValue := reflect.ValueOf(old)
+	newValue := reflect.ValueOf(new)
+	if !oldValue.IsValid() || !newValue.IsValid() || oldValue.Type() != newValue.Type() {
+		return nil, fmt.Errorf("cannot compare %T with %T", old, new)
+	}
+
+	for oldValue.Kind() == reflect.Ptr {
+		if oldValue.IsNil() || newValue.IsNil() {
+			return nil, nil
+		}
+		oldValue, newValue = oldValue.Elem(), newValue.Elem()
+	}
+	if oldValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot check immutable fields of non-struct type %T", old)
+	}
+
+	return changedImmutableFields(oldValue, newValue, "", nil), nil
+}
+
+// changedImmutableFields appends the paths of changed immutable fields of two
+// struct values to changed.
+func changedImmutableFields(oldValue, newValue reflect.Value, prefix string, changed []string) []string {
+	for _, field := range structFields(oldValue.Type()) {
+		path := prefix
+		if field.jsonName != "" {
+			if path != "" {
+				path += "."
+			}
+			path += field.jsonName
+		}
+
+		oldField, newField := oldValue.Field(field.index), newValue.Field(field.index)
+		if field.immutable {
+			if !oldField.IsZero() && !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+				changed = append(changed, path)
+			}
+			continue
+		}
+
+		// Look for immutable fields in nested structs
+		for oldField.Kind() == reflect.Ptr && !oldField.IsNil() && !newField.IsNil() {
+			oldField, newField = oldField.Elem(), newField.Elem()
+		}
+		if oldField.Kind() == reflect.Struct {
+			changed = changedImmutableFields(oldField, newField, path, changed)
+		}
+	}
+	return changed
+}
+
+// isImmutable reports whether a field's validate tag includes the immutable
+// option. Options after "dive" apply to elements and are ignored.
+func isImmutable(field reflect.StructField) bool {
+	for _, option := range strings.Split(field.Tag.Get("validate"), ",") {
+		switch strings.TrimSpace(option) {
+		case ImmutableTag:
+			return true
+		case "dive":
+			return false
+		}
+	}
+	return false
+}
+
+// jsonFieldName returns the JSON name of a field, or "" for embedded structs
+// that are inlined into their parent.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return field.Name
+	}
+	if name == "" {
+		if field.Anonymous {
+			return ""
+		}
+		return field.Name
+	}
+	return name
+}
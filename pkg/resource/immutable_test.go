@@ -0,0 +1,125 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+type hardwareInfo struct {
+	Serial string `json:"serial" validate:"required,immutable"`
+	Vendor string `json:"vendor,omitempty"`
+}
+
+// BoardInfo is exported so its promoted fields are visible to reflection
+type BoardInfo struct {
+	Model string `json:"model" validate:"immutable"`
+}
+
+type deviceSpec struct {
+	BoardInfo
+	Hostname string            `json:"hostname"`
+	MAC      string            `json:"mac,omitempty" validate:"omitempty,immutable"`
+	Hardware hardwareInfo      `json:"hardware"`
+	Parent   *hardwareInfo     `json:"parent,omitempty"`
+	Ports    []int             `json:"ports,omitempty" validate:"immutable"`
+	Tags     []string          `json:"tags,omitempty" validate:"dive,immutable"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+func TestCheckImmutable(t *testing.T) {
+	base := deviceSpec{
+		BoardInfo: BoardInfo{Model: "r650"},
+		Hostname:  "node-1",
+		MAC:       "aa:bb",
+		Hardware:  hardwareInfo{Serial: "SN1", Vendor: "acme"},
+		Parent:    &hardwareInfo{Serial: "P1"},
+		Ports:     []int{1, 2},
+		Tags:      []string{"a"},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(s *deviceSpec)
+		want   []string
+	}{
+		{"no change", func(s *deviceSpec) {}, nil},
+		{"mutable fields", func(s *deviceSpec) {
+			s.Hostname = "node-2"
+			s.Hardware.Vendor = "other"
+			s.Tags = []string{"b"}
+			s.Labels = map[string]string{"k": "v"}
+		}, nil},
+		{"top-level field", func(s *deviceSpec) { s.MAC = "cc:dd" }, []string{"mac"}},
+		{"cleared field", func(s *deviceSpec) { s.MAC = "" }, []string{"mac"}},
+		{"nested field", func(s *deviceSpec) { s.Hardware.Serial = "SN2" }, []string{"hardware.serial"}},
+		{"pointer field", func(s *deviceSpec) { s.Parent = &hardwareInfo{Serial: "P2"} }, []string{"parent.serial"}},
+		{"embedded field", func(s *deviceSpec) { s.Model = "r750" }, []string{"model"}},
+		{"slice field", func(s *deviceSpec) { s.Ports = []int{1, 3} }, []string{"ports"}},
+		{"multiple fields", func(s *deviceSpec) {
+			s.MAC = "cc:dd"
+			s.Hardware.Serial = "SN2"
+		}, []string{"mac", "hardware.serial"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base
+			updated.Parent = &hardwareInfo{Serial: base.Parent.Serial}
+			tt.mutate(&updated)
+
+			changed, err := CheckImmutable(base, updated)
+			if err != nil {
+				t.Fatalf("CheckImmutable failed: %v", err)
+			}
+			if !reflect.DeepEqual(changed, tt.want) {
+				t.Errorf("changed = %v, want %v", changed, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckImmutable_SettingZeroValueAllowed(t *testing.T) {
+	old := deviceSpec{Hostname: "node-1"}
+	updated := deviceSpec{
+		Hostname: "node-1",
+		MAC:      "aa:bb",
+		Hardware: hardwareInfo{Serial: "SN1"},
+	}
+
+	changed, err := CheckImmutable(&old, &updated)
+	if err != nil {
+		t.Fatalf("CheckImmutable failed: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("changed = %v, want none", changed)
+	}
+}
+
+func TestCheckImmutable_InvalidArguments(t *testing.T) {
+	if _, err := CheckImmutable(deviceSpec{}, hardwareInfo{}); err == nil {
+		t.Error("Expected error for mismatched types")
+	}
+	if _, err := CheckImmutable("a", "b"); err == nil {
+		t.Error("Expected error for non-struct values")
+	}
+	if _, err := CheckImmutable(nil, deviceSpec{}); err == nil {
+		t.Error("Expected error for nil value")
+	}
+}
+
+func BenchmarkCheckImmutable(b *testing.B) {
+	old := deviceSpec{Hostname: "node-1", MAC: "aa:bb", Hardware: hardwareInfo{Serial: "S1"}, Parent: &hardwareInfo{Serial: "P1"}}
+	updated := old
+	updated.Hostname = "node-2"
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := CheckImmutable(old, updated); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownKind is returned by NewOfKind for a kind that was never registered.
+var ErrUnknownKind = errors.New("unknown resource kind")
+
+// registeredKind is a kind's constructor and the Go type it returns
+type registeredKind struct {
+	constructor func() interface{}
+	goType      reflect.Type
+}
+
+// kinds holds the registered resource kinds.
+var kinds = make(map[string]registeredKind)
+var kindsMutex sync.RWMutex
+
+// RegisterKind registers the constructor for a resource kind, so code that
+// only knows the kind by name (audit or event middleware, a generic
+// GET /{kind}/{uid} dispatcher) can create and decode values of it.
+//
+// The constructor must return a new, empty pointer to the resource type on
+// every call. Generated servers register every resource kind during package
+// initialization.
+//
+// Parameters:
+//   - kind: The Kind field of the resource (e.g., "Device")
+//   - constructor: Returns a new, empty resource (e.g., &Device{})
+//
+// Panics:
+//   - If kind is empty or constructor is nil
+//   - If kind is already registered
+//   - If constructor returns nil
+//
+// Example:
+//
+//	func init() {
+//	    resource.RegisterKind("Device", func() interface{} { return &Device{} })
+//	}
+func RegisterKind(kind string, constructor func() interface{}) {
+	if kind == "" {
+		panic("resource kind cannot be empty")
+	}
+	if constructor == nil {
+		panic("constructor cannot be nil")
+	}
+	sample := constructor()
+	if sample == nil {
+		panic(fmt.Sprintf("constructor for resource kind '%s' returned nil", kind))
+	}
+
+	kindsMutex.Lock()
+	defer kindsMutex.Unlock()
+
+	if existing, exists := kinds[kind]; exists {
+		panic(fmt.Sprintf("resource kind '%s' is already registered with type %s", kind, existing.goType))
+	}
+	kinds[kind] = registeredKind{constructor: constructor, goType: reflect.TypeOf(sample)}
+}
+
+// NewOfKind returns a new, empty value of a registered resource kind, as
+// returned by its constructor.
+//
+// Example:
+//
+//	obj, err := resource.NewOfKind("Device")
+//	if err != nil {
+//	    return err
+//	}
+//	if err := json.Unmarshal(body, obj); err != nil {
+//	    return err
+//	}
+func NewOfKind(kind string) (interface{}, error) {
+	kindsMutex.RLock()
+	registered, exists := kinds[kind]
+	kindsMutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+	return registered.constructor(), nil
+}
+
+// KindOf returns the kind registered for obj's Go type, the reverse of
+// NewOfKind. It reports false if the type was not registered.
+func KindOf(obj interface{}) (string, bool) {
+	goType := reflect.TypeOf(obj)
+
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+
+	for kind, registered := range kinds {
+		if registered.goType == goType {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// RegisteredKinds returns the names of all registered resource kinds, sorted.
+func RegisteredKinds() []string {
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+
+	result := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		result = append(result, kind)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,103 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func resetKinds(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		kindsMutex.Lock()
+		for _, name := range names {
+			delete(kinds, name)
+		}
+		kindsMutex.Unlock()
+	})
+}
+
+func TestNewOfKind(t *testing.T) {
+	resetKinds(t, "Rack")
+	RegisterKind("Rack", func() interface{} { return &rack{} })
+
+	obj, err := NewOfKind("Rack")
+	if err != nil {
+		t.Fatalf("NewOfKind failed: %v", err)
+	}
+	r, ok := obj.(*rack)
+	if !ok {
+		t.Fatalf("NewOfKind returned %T, want *rack", obj)
+	}
+	if err := json.Unmarshal([]byte(`{"Spec":{"Datacenter":"uswest-dc2"}}`), obj); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if r.Spec.Datacenter != "uswest-dc2" {
+		t.Errorf("Spec.Datacenter = %q, want uswest-dc2", r.Spec.Datacenter)
+	}
+
+	// Each call returns a fresh value
+	again, _ := NewOfKind("Rack")
+	if again.(*rack).Spec.Datacenter != "" {
+		t.Error("NewOfKind returned a value shared with an earlier call")
+	}
+
+	if kind, ok := KindOf(r); !ok || kind != "Rack" {
+		t.Errorf("KindOf(*rack) = %q, %v; want Rack, true", kind, ok)
+	}
+	if _, ok := KindOf(rack{}); ok {
+		t.Error("KindOf should not match the non-pointer type")
+	}
+}
+
+func TestNewOfKind_Unknown(t *testing.T) {
+	if _, err := NewOfKind("NoSuchKind"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("NewOfKind error = %v, want ErrUnknownKind", err)
+	}
+}
+
+func TestRegisteredKinds_Sorted(t *testing.T) {
+	resetKinds(t, "Zone", "Aisle")
+	RegisterKind("Zone", func() interface{} { return &rack{} })
+	RegisterKind("Aisle", func() interface{} { return &Resource{} })
+
+	var got []string
+	for _, kind := range RegisteredKinds() {
+		if kind == "Zone" || kind == "Aisle" {
+			got = append(got, kind)
+		}
+	}
+	if want := []string{"Aisle", "Zone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RegisteredKinds() = %v, want %v in order", got, want)
+	}
+}
+
+func TestRegisterKind_Panics(t *testing.T) {
+	resetKinds(t, "Rack")
+	RegisterKind("Rack", func() interface{} { return &rack{} })
+
+	tests := []struct {
+		name        string
+		kind        string
+		constructor func() interface{}
+	}{
+		{"empty kind", "", func() interface{} { return &rack{} }},
+		{"nil constructor", "Shelf", nil},
+		{"nil value", "Shelf", func() interface{} { return nil }},
+		{"duplicate", "Rack", func() interface{} { return &rack{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterKind should panic")
+				}
+			}()
+			RegisterKind(tt.kind, tt.constructor)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+// ValidateLabels checks label keys and values against the rules of the
+// validation package's labelkey and labelvalue validators: keys are
+// [prefix/]name with a DNS subdomain prefix, and names and values are at
+// most 63 lowercase alphanumeric characters, '-', '_' or '.', starting and
+// ending alphanumeric. Values may be empty.
+//
+// SetLabel accepts any key and value; the generated create and update
+// handlers call ValidateLabels before storing a resource and reject it with
+// 422 if any label is invalid.
+//
+// Returns:
+//   - nil if every label is valid
+//   - validation.ValidationErrors with one FieldError per invalid key or
+//     value, keyed like metadata.labels[app.kubernetes.io/name], in key order
+//
+// Example:
+//
+//	err := ValidateLabels(map[string]string{"rack": "r 12"})
+//	// err lists metadata.labels[rack]: the value contains a space
+func ValidateLabels(labels map[string]string) error {
+	return validateMetadataMap("labels", labels, true)
+}
+
+// ValidateAnnotations checks annotation keys against the labelkey rule, as
+// ValidateLabels does. Annotation values are free-form and not checked.
+//
+// Returns nil or validation.ValidationErrors, as ValidateLabels does.
+func ValidateAnnotations(annotations map[string]string) error {
+	return validateMetadataMap("annotations", annotations, false)
+}
+
+// validateMetadataMap checks the keys, and optionally the values, of the
+// labels or annotations map of a resource's metadata
+func validateMetadataMap(name string, values map[string]string, checkValues bool) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []validation.FieldError
+	for _, key := range keys {
+		field := fmt.Sprintf("metadata.%s[%s]", name, key)
+		if !validation.IsValidLabelKey(key) {
+			errs = append(errs, validation.FieldError{
+				Field:   field,
+				Tag:     "labelkey",
+				Value:   key,
+				Message: fmt.Sprintf("%s key %q is invalid: must be [prefix/]name with a DNS subdomain prefix and a name of at most 63 lowercase alphanumeric characters, '-', '_' or '.'", name[:len(name)-1], key),
+			})
+			continue
+		}
+		if checkValues && !validation.IsValidLabelValue(values[key]) {
+			errs = append(errs, validation.FieldError{
+				Field:   field,
+				Tag:     "labelvalue",
+				Value:   values[key],
+				Message: fmt.Sprintf("label value %q is invalid: must be empty or at most 63 lowercase alphanumeric characters, '-', '_' or '.', starting and ending alphanumeric", values[key]),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return validation.ValidationErrors{Errors: errs}
+	}
+	return nil
+}
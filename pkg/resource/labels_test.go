@@ -0,0 +1,68 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+func TestValidateLabels(t *testing.T) {
+	valid := map[string]string{
+		"env":                    "production",
+		"app.kubernetes.io/name": "node-exporter",
+		"rack":                   "r12_a.1",
+		"empty":                  "",
+	}
+	if err := ValidateLabels(valid); err != nil {
+		t.Errorf("ValidateLabels(%v) = %v", valid, err)
+	}
+	if err := ValidateLabels(nil); err != nil {
+		t.Errorf("ValidateLabels(nil) = %v", err)
+	}
+
+	err := ValidateLabels(map[string]string{
+		"env":         "prod uction",
+		"-bad":        "x",
+		"Bad_Prefix/": "x",
+		"ok":          "fine",
+	})
+	var fieldErrs validation.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("err = %v, want validation.ValidationErrors", err)
+	}
+
+	want := []struct{ field, tag string }{
+		{"metadata.labels[-bad]", "labelkey"},
+		{"metadata.labels[Bad_Prefix/]", "labelkey"},
+		{"metadata.labels[env]", "labelvalue"},
+	}
+	if len(fieldErrs.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(fieldErrs.Errors), len(want), err)
+	}
+	for i, w := range want {
+		if got := fieldErrs.Errors[i]; got.Field != w.field || got.Tag != w.tag {
+			t.Errorf("error %d = %s (%s), want %s (%s)", i, got.Field, got.Tag, w.field, w.tag)
+		}
+	}
+}
+
+func TestValidateAnnotations(t *testing.T) {
+	// Annotation values are free-form
+	if err := ValidateAnnotations(map[string]string{"example.com/note": "Any text, even {\"json\": true}"}); err != nil {
+		t.Errorf("ValidateAnnotations = %v", err)
+	}
+
+	err := ValidateAnnotations(map[string]string{"not a key": "x"})
+	var fieldErrs validation.ValidationErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs.Errors) != 1 {
+		t.Fatalf("err = %v, want one field error", err)
+	}
+	if got := fieldErrs.Errors[0].Field; got != "metadata.annotations[not a key]" {
+		t.Errorf("Field = %q", got)
+	}
+}
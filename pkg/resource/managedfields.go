@@ -0,0 +1,193 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// FieldManagerHeader names the writer of an update or patch. Generated
+// handlers record which manager last changed each spec field and reject
+// changes to fields owned by another manager.
+const FieldManagerHeader = "X-Field-Manager"
+
+// FieldConflict is a spec field a request changed that another manager owns.
+type FieldConflict struct {
+	// Field is the dotted spec path that was changed (e.g. "network.ip")
+	Field string `json:"field"`
+
+	// Manager currently owns the field
+	Manager string `json:"manager"`
+}
+
+// ChangedSpecFields returns the dotted paths of spec fields that differ
+// between two resource documents, sorted.
+//
+// Objects are compared key by key; any other value, including arrays, is
+// compared as a whole, so a changed list element reports the list's path.
+// Removed fields are reported too.
+//
+// Example:
+//
+//	stored, _ := json.Marshal(previous)
+//	current, _ := json.Marshal(device)
+//	changed, err := resource.ChangedSpecFields(stored, current)
+//	// changed == []string{"location", "network.ip"}
+func ChangedSpecFields(stored, incoming json.RawMessage) ([]string, error) {
+	storedSpec, err := specOf(stored)
+	if err != nil {
+		return nil, fmt.Errorf("stored resource: %w", err)
+	}
+	incomingSpec, err := specOf(incoming)
+	if err != nil {
+		return nil, fmt.Errorf("incoming resource: %w", err)
+	}
+
+	patch, err := jsonpatch.CreateMergePatch(storedSpec, incomingSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare specs: %w", err)
+	}
+	var changes map[string]interface{}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("failed to compare specs: %w", err)
+	}
+
+	var paths []string
+	collectLeafPaths(changes, "", &paths)
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// DetectFieldConflicts returns the spec fields changed between stored and
+// incoming that are owned by a manager other than manager, according to the
+// stored resource's metadata.managedFields.
+//
+// A field conflicts if it, one of its parents or one of its children is owned
+// by another manager. The result is sorted by field, then manager.
+//
+// Example:
+//
+//	conflicts, err := resource.DetectFieldConflicts(stored, current, r.Header.Get(resource.FieldManagerHeader))
+//	if len(conflicts) > 0 && r.URL.Query().Get("force") != "true" {
+//	    // respond 409 Conflict
+//	}
+func DetectFieldConflicts(stored, incoming json.RawMessage, manager string) ([]FieldConflict, error) {
+	changed, err := ChangedSpecFields(stored, incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Metadata struct {
+			ManagedFields map[string][]string `json:"managedFields"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(stored, &doc); err != nil {
+		return nil, fmt.Errorf("stored resource is not a JSON object: %w", err)
+	}
+
+	var conflicts []FieldConflict
+	for _, field := range changed {
+		for owner, owned := range doc.Metadata.ManagedFields {
+			if owner == manager {
+				continue
+			}
+			for _, path := range owned {
+				if pathsOverlap(field, path) {
+					conflicts = append(conflicts, FieldConflict{Field: field, Manager: owner})
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Field != conflicts[j].Field {
+			return conflicts[i].Field < conflicts[j].Field
+		}
+		return conflicts[i].Manager < conflicts[j].Manager
+	})
+	return conflicts, nil
+}
+
+// ClaimFields records manager as the owner of fields, removing them (and any
+// overlapping parent or child paths) from every other manager. Managers left
+// owning nothing are dropped.
+//
+// Example:
+//
+//	changed, _ := resource.ChangedSpecFields(stored, current)
+//	device.Metadata.ClaimFields("rack-controller", changed)
+func (m *Metadata) ClaimFields(manager string, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	for owner, owned := range m.ManagedFields {
+		kept := owned[:0]
+		for _, path := range owned {
+			overlaps := false
+			for _, field := range fields {
+				if pathsOverlap(field, path) {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				kept = append(kept, path)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m.ManagedFields, owner)
+		} else {
+			m.ManagedFields[owner] = kept
+		}
+	}
+
+	if m.ManagedFields == nil {
+		m.ManagedFields = make(map[string][]string)
+	}
+	owned := append(m.ManagedFields[manager], fields...)
+	sort.Strings(owned)
+	m.ManagedFields[manager] = owned
+}
+
+// specOf returns the spec of a resource document, or null if it has none
+func specOf(doc json.RawMessage) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, fmt.Errorf("not a JSON object: %w", err)
+	}
+	spec, ok := fields["spec"]
+	if !ok || len(spec) == 0 || string(spec) == "null" {
+		return []byte("{}"), nil
+	}
+	return spec, nil
+}
+
+// collectLeafPaths appends the dotted paths of the non-object values in a
+// merge patch
+func collectLeafPaths(changes map[string]interface{}, prefix string, paths *[]string) {
+	for key, value := range changes {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			collectLeafPaths(nested, path, paths)
+			continue
+		}
+		*paths = append(*paths, path)
+	}
+}
+
+// pathsOverlap reports whether two dotted paths are equal or one contains the other
+func pathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
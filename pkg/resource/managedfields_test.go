@@ -0,0 +1,105 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestChangedSpecFields(t *testing.T) {
+	stored := json.RawMessage(`{"spec":{"location":"r1","network":{"ip":"10.0.0.1","mask":24},"tags":["a"],"old":1}}`)
+	incoming := json.RawMessage(`{"spec":{"location":"r2","network":{"ip":"10.0.0.1","mask":16},"tags":["a","b"]}}`)
+
+	changed, err := ChangedSpecFields(stored, incoming)
+	if err != nil {
+		t.Fatalf("ChangedSpecFields failed: %v", err)
+	}
+	want := []string{"location", "network.mask", "old", "tags"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("Expected %v, got %v", want, changed)
+	}
+
+	if _, err := ChangedSpecFields(json.RawMessage(`[]`), incoming); err == nil {
+		t.Error("Expected error for non-object document")
+	}
+}
+
+func TestDetectFieldConflicts(t *testing.T) {
+	stored := json.RawMessage(`{
+		"metadata": {"managedFields": {"rack-controller": ["network"], "ops": ["location"]}},
+		"spec": {"location": "r1", "network": {"ip": "10.0.0.1"}, "notes": ""}
+	}`)
+
+	tests := []struct {
+		name     string
+		incoming string
+		manager  string
+		want     []FieldConflict
+	}{
+		{
+			name:     "child of owned field",
+			incoming: `{"spec":{"location":"r1","network":{"ip":"10.0.0.2"},"notes":""}}`,
+			manager:  "ops",
+			want:     []FieldConflict{{Field: "network.ip", Manager: "rack-controller"}},
+		},
+		{
+			name:     "own field",
+			incoming: `{"spec":{"location":"r2","network":{"ip":"10.0.0.1"},"notes":""}}`,
+			manager:  "ops",
+		},
+		{
+			name:     "unowned field",
+			incoming: `{"spec":{"location":"r1","network":{"ip":"10.0.0.1"},"notes":"x"}}`,
+			manager:  "cli",
+		},
+		{
+			name:     "several owners",
+			incoming: `{"spec":{"location":"r2","network":null,"notes":""}}`,
+			manager:  "cli",
+			want: []FieldConflict{
+				{Field: "location", Manager: "ops"},
+				{Field: "network", Manager: "rack-controller"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := DetectFieldConflicts(stored, json.RawMessage(tt.incoming), tt.manager)
+			if err != nil {
+				t.Fatalf("DetectFieldConflicts failed: %v", err)
+			}
+			if !reflect.DeepEqual(conflicts, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, conflicts)
+			}
+		})
+	}
+}
+
+func TestMetadata_ClaimFields(t *testing.T) {
+	m := Metadata{ManagedFields: map[string][]string{
+		"rack-controller": {"network"},
+		"ops":             {"location", "notes"},
+	}}
+
+	m.ClaimFields("ops", []string{"network.ip"})
+	want := map[string][]string{"ops": {"location", "network.ip", "notes"}}
+	if !reflect.DeepEqual(m.ManagedFields, want) {
+		t.Errorf("Expected %v, got %v", want, m.ManagedFields)
+	}
+
+	m.ClaimFields("cli", []string{"network"})
+	want = map[string][]string{"ops": {"location", "notes"}, "cli": {"network"}}
+	if !reflect.DeepEqual(m.ManagedFields, want) {
+		t.Errorf("Expected %v, got %v", want, m.ManagedFields)
+	}
+
+	var empty Metadata
+	empty.ClaimFields("cli", nil)
+	if empty.ManagedFields != nil {
+		t.Errorf("Claiming nothing should not allocate, got %v", empty.ManagedFields)
+	}
+}
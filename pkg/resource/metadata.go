@@ -4,7 +4,12 @@
 
 package resource
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+)
 
 // Metadata contains common metadata for all resources.
 //
@@ -24,6 +29,24 @@ import "time"
 //   - Annotations: Key-value pairs for arbitrary metadata
 //   - CreatedAt: Resource creation timestamp
 //   - UpdatedAt: Last modification timestamp
+//   - Generation: Sequence number of the spec, incremented on each spec change
+//   - ExpiresAt: Optional expiry time after which the resource may be deleted
+//   - ResourceVersion: Opaque storage version, bumped on every write through
+//     storage.ResourceStorage and checked to detect concurrent modification
+//   - ManagedFields: Spec field paths owned by each field manager (see
+//     FieldManagerHeader and ClaimFields)
+//   - Finalizers: Names of controllers that must clean up before the
+//     resource is garbage collected (see storage.GarbageCollector)
+//
+// Generation vs ObservedGeneration:
+//
+// Generation is bumped by the API only when the spec actually changes, never
+// on status-only updates. Reconcilers record the generation they acted on in
+// status (ObservedGeneration) and can skip work when the two match:
+//
+//	if res.Status.ObservedGeneration == res.Metadata.Generation {
+//	    return nil // Already reconciled this spec
+//	}
 //
 // Example Labels:
 //
@@ -42,6 +65,12 @@ type Metadata struct {
 	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	CreatedAt   time.Time         `json:"createdAt" yaml:"createdAt"`
 	UpdatedAt   time.Time         `json:"updatedAt" yaml:"updatedAt"`
+	Generation  int64             `json:"generation,omitempty" yaml:"generation,omitempty"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+
+	ResourceVersion string              `json:"resourceVersion,omitempty" yaml:"resourceVersion,omitempty"`
+	ManagedFields   map[string][]string `json:"managedFields,omitempty" yaml:"managedFields,omitempty"`
+	Finalizers      []string            `json:"finalizers,omitempty" yaml:"finalizers,omitempty"`
 }
 
 // Metadata helper methods
@@ -63,8 +92,8 @@ func (m *Metadata) IsEmpty() bool {
 // Initialize sets up metadata with required fields and initializes maps.
 //
 // This is the recommended way to initialize metadata for a new resource.
-// Sets CreatedAt and UpdatedAt to current time and initializes empty
-// labels and annotations maps.
+// Sets CreatedAt and UpdatedAt to current time, sets Generation to 1, and
+// initializes empty labels and annotations maps.
 //
 // Parameters:
 //   - name: Human-readable name for the resource
@@ -80,6 +109,7 @@ func (m *Metadata) Initialize(name, uid string) {
 	m.UID = uid
 	m.CreatedAt = now
 	m.UpdatedAt = now
+	m.Generation = 1
 	if m.Labels == nil {
 		m.Labels = make(map[string]string)
 	}
@@ -103,10 +133,18 @@ func (m *Metadata) Initialize(name, uid string) {
 //	metadataCopy.Name = "new-name" // Won't affect original
 func (m *Metadata) Clone() *Metadata {
 	clone := &Metadata{
-		Name:      m.Name,
-		UID:       m.UID,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		Name:       m.Name,
+		UID:        m.UID,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		Generation: m.Generation,
+
+		ResourceVersion: m.ResourceVersion,
+	}
+
+	if m.ExpiresAt != nil {
+		expiresAt := *m.ExpiresAt
+		clone.ExpiresAt = &expiresAt
 	}
 
 	if m.Labels != nil {
@@ -123,5 +161,111 @@ func (m *Metadata) Clone() *Metadata {
 		}
 	}
 
+	if m.ManagedFields != nil {
+		clone.ManagedFields = make(map[string][]string, len(m.ManagedFields))
+		for manager, fields := range m.ManagedFields {
+			clone.ManagedFields[manager] = append([]string(nil), fields...)
+		}
+	}
+
+	if m.Finalizers != nil {
+		clone.Finalizers = append([]string(nil), m.Finalizers...)
+	}
+
 	return clone
 }
+
+// AddFinalizer adds a finalizer, unless the resource already has it.
+//
+// A controller that must clean up external state before the resource goes
+// away adds its finalizer, and removes it with RemoveFinalizer once done.
+// The garbage collector does not delete orphans that still have finalizers.
+//
+// Example:
+//
+//	node.Metadata.AddFinalizer("dhcp.example.com/lease")
+func (m *Metadata) AddFinalizer(finalizer string) {
+	if !m.HasFinalizer(finalizer) {
+		m.Finalizers = append(m.Finalizers, finalizer)
+	}
+}
+
+// RemoveFinalizer removes a finalizer, if present
+func (m *Metadata) RemoveFinalizer(finalizer string) {
+	for i, existing := range m.Finalizers {
+		if existing == finalizer {
+			m.Finalizers = append(m.Finalizers[:i:i], m.Finalizers[i+1:]...)
+			break
+		}
+	}
+	if len(m.Finalizers) == 0 {
+		m.Finalizers = nil
+	}
+}
+
+// HasFinalizer reports whether the resource has the finalizer
+func (m *Metadata) HasFinalizer(finalizer string) bool {
+	for _, existing := range m.Finalizers {
+		if existing == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTTL makes the resource expire ttl after now.
+//
+// Expired resources of types with the expiry reaper enabled are deleted
+// automatically (see storage.Reaper).
+//
+// Example:
+//
+//	lease.Metadata.SetTTL(5 * time.Minute)
+func (m *Metadata) SetTTL(ttl time.Duration) {
+	expiresAt := time.Now().Add(ttl)
+	m.ExpiresAt = &expiresAt
+}
+
+// Expired reports whether the resource has an expiry at or before now.
+// Resources without ExpiresAt never expire.
+func (m *Metadata) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.After(now)
+}
+
+// IncrementGeneration advances the spec generation.
+//
+// Call this only when the spec has changed (see SpecChanged). Status-only
+// updates must leave the generation untouched so reconcilers can detect
+// pending spec work.
+//
+// Example:
+//
+//	if SpecChanged(oldSpec, resource.Spec) {
+//	    resource.Metadata.IncrementGeneration()
+//	}
+func (m *Metadata) IncrementGeneration() {
+	m.Generation++
+}
+
+// SpecChanged reports whether two spec values differ.
+//
+// Specs are compared by their JSON encoding, so values that serialize
+// identically (e.g. a nil and an empty omitempty slice) are considered equal.
+// Either argument may be a json.RawMessage holding a previously marshaled
+// spec. If either value cannot be marshaled, reflect.DeepEqual is used.
+//
+// Example:
+//
+//	previous := device.Spec
+//	device.Spec = req.DeviceSpec
+//	if SpecChanged(previous, device.Spec) {
+//	    device.Metadata.IncrementGeneration()
+//	}
+func SpecChanged(old, new interface{}) bool {
+	oldJSON, oldErr := json.Marshal(old)
+	newJSON, newErr := json.Marshal(new)
+	if oldErr != nil || newErr != nil {
+		return !reflect.DeepEqual(old, new)
+	}
+	return !bytes.Equal(oldJSON, newJSON)
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type testSpec struct {
+	Description string            `json:"description,omitempty"`
+	Ports       []int             `json:"ports,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+func TestMetadataGeneration(t *testing.T) {
+	var m Metadata
+	m.Initialize("device-001", "dev-1")
+	if m.Generation != 1 {
+		t.Fatalf("Expected initial generation 1, got %d", m.Generation)
+	}
+
+	m.IncrementGeneration()
+	if m.Generation != 2 {
+		t.Errorf("Expected generation 2, got %d", m.Generation)
+	}
+	if clone := m.Clone(); clone.Generation != 2 {
+		t.Errorf("Expected cloned generation 2, got %d", clone.Generation)
+	}
+}
+
+func TestMetadataExpiry(t *testing.T) {
+	var m Metadata
+	now := time.Now()
+	if m.Expired(now) {
+		t.Error("Metadata without ExpiresAt should never expire")
+	}
+
+	m.SetTTL(time.Minute)
+	if m.Expired(now) {
+		t.Error("Expected resource not to be expired before its TTL")
+	}
+	if !m.Expired(now.Add(2 * time.Minute)) {
+		t.Error("Expected resource to be expired after its TTL")
+	}
+
+	clone := m.Clone()
+	clone.ExpiresAt = nil
+	if m.ExpiresAt == nil {
+		t.Error("Clone should not share ExpiresAt with the original")
+	}
+}
+
+func TestMetadataFinalizers(t *testing.T) {
+	var m Metadata
+	m.AddFinalizer("dhcp")
+	m.AddFinalizer("dns")
+	m.AddFinalizer("dhcp")
+	if len(m.Finalizers) != 2 || !m.HasFinalizer("dns") {
+		t.Fatalf("Finalizers = %v, want [dhcp dns]", m.Finalizers)
+	}
+
+	clone := m.Clone()
+	m.RemoveFinalizer("dhcp")
+	if len(m.Finalizers) != 1 || m.HasFinalizer("dhcp") {
+		t.Errorf("Finalizers after remove = %v, want [dns]", m.Finalizers)
+	}
+	if len(clone.Finalizers) != 2 || clone.Finalizers[0] != "dhcp" {
+		t.Errorf("Clone should not share Finalizers with the original, got %v", clone.Finalizers)
+	}
+
+	m.RemoveFinalizer("dns")
+	if m.Finalizers != nil {
+		t.Errorf("Finalizers = %v, want nil once empty", m.Finalizers)
+	}
+}
+
+func TestSpecChanged(t *testing.T) {
+	base := testSpec{Description: "rack", Tags: map[string]string{"a": "1", "b": "2"}}
+
+	tests := []struct {
+		name    string
+		old     interface{}
+		new     interface{}
+		changed bool
+	}{
+		{"identical", base, testSpec{Description: "rack", Tags: map[string]string{"b": "2", "a": "1"}}, false},
+		{"nil vs empty slice", testSpec{}, testSpec{Ports: []int{}}, false},
+		{"field changed", base, testSpec{Description: "node", Tags: base.Tags}, true},
+		{"slice changed", testSpec{Ports: []int{80}}, testSpec{Ports: []int{443}}, true},
+		{"raw json equal", json.RawMessage(`{"description":"rack"}`), testSpec{Description: "rack"}, false},
+		{"raw json differs", json.RawMessage(`{"description":"rack"}`), testSpec{Description: "node"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SpecChanged(tt.old, tt.new); got != tt.changed {
+				t.Errorf("SpecChanged() = %v, want %v", got, tt.changed)
+			}
+		})
+	}
+}
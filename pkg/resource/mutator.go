@@ -0,0 +1,97 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Mutator modifies a resource before it is validated and stored.
+//
+// Mutators are the in-process equivalent of Kubernetes mutating admission
+// webhooks: use them to default fields or derive values from other fields.
+// The generated create and update handlers run all mutators registered for
+// the resource kind before validation, so defaulted values are validated
+// like any client-supplied value.
+//
+// obj is a pointer to the resource being created or updated (e.g. *Device).
+// Returning an error rejects the request.
+type Mutator interface {
+	Mutate(ctx context.Context, obj interface{}) error
+}
+
+// MutatorFunc adapts a function to the Mutator interface.
+type MutatorFunc func(ctx context.Context, obj interface{}) error
+
+// Mutate calls f(ctx, obj).
+func (f MutatorFunc) Mutate(ctx context.Context, obj interface{}) error {
+	return f(ctx, obj)
+}
+
+// mutators holds registered mutators per resource kind, in registration order.
+var mutators = make(map[string][]Mutator)
+var mutatorsMutex sync.RWMutex
+
+// RegisterMutator registers a mutator for a resource kind.
+//
+// Mutators for a kind run in the order they were registered; each one sees
+// the changes made by those before it. Register mutators during package
+// initialization, typically in init() functions.
+//
+// Parameters:
+//   - resourceKind: The Kind field of the resource (e.g., "Device")
+//   - mutator: The mutator to run on create and update
+//
+// Panics:
+//   - If resourceKind is empty or mutator is nil
+//
+// Example:
+//
+//	func init() {
+//	    resource.RegisterMutator("Device", resource.MutatorFunc(func(ctx context.Context, obj interface{}) error {
+//	        device := obj.(*Device)
+//	        if region := regionForDatacenter(device.Spec.Datacenter); region != "" {
+//	            device.SetLabel("region", region)
+//	        }
+//	        return nil
+//	    }))
+//	}
+func RegisterMutator(resourceKind string, mutator Mutator) {
+	if resourceKind == "" {
+		panic("resource kind cannot be empty")
+	}
+	if mutator == nil {
+		panic("mutator cannot be nil")
+	}
+
+	mutatorsMutex.Lock()
+	defer mutatorsMutex.Unlock()
+	mutators[resourceKind] = append(mutators[resourceKind], mutator)
+}
+
+// RunMutators runs the mutators registered for a resource kind, in
+// registration order, stopping at the first error.
+//
+// Parameters:
+//   - ctx: Request context
+//   - resourceKind: The Kind field of the resource
+//   - obj: Pointer to the resource to mutate
+//
+// Returns:
+//   - error: The first mutator error, or nil if all mutators succeeded
+func RunMutators(ctx context.Context, resourceKind string, obj interface{}) error {
+	mutatorsMutex.RLock()
+	registered := append([]Mutator(nil), mutators[resourceKind]...)
+	mutatorsMutex.RUnlock()
+
+	for i, mutator := range registered {
+		if err := mutator.Mutate(ctx, obj); err != nil {
+			return fmt.Errorf("mutator %d for %s failed: %w", i, resourceKind, err)
+		}
+	}
+	return nil
+}
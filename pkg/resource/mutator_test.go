@@ -0,0 +1,86 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type rackSpec struct {
+	Datacenter string
+	Region     string
+}
+
+// rack is a test resource whose region is derived from its datacenter
+type rack struct {
+	Resource
+	Spec rackSpec
+}
+
+func resetMutators(t *testing.T, kind string) {
+	t.Cleanup(func() {
+		mutatorsMutex.Lock()
+		delete(mutators, kind)
+		mutatorsMutex.Unlock()
+	})
+}
+
+func TestRunMutators_FillsDerivedField(t *testing.T) {
+	resetMutators(t, "Rack")
+
+	RegisterMutator("Rack", MutatorFunc(func(_ context.Context, obj interface{}) error {
+		r := obj.(*rack)
+		if r.Spec.Region == "" {
+			r.Spec.Region, _, _ = strings.Cut(r.Spec.Datacenter, "-")
+		}
+		return nil
+	}))
+	RegisterMutator("Rack", MutatorFunc(func(_ context.Context, obj interface{}) error {
+		// Runs second, so it sees the derived region
+		r := obj.(*rack)
+		r.SetLabel("region", r.Spec.Region)
+		return nil
+	}))
+
+	obj := &rack{Spec: rackSpec{Datacenter: "uswest-dc2"}}
+	if err := RunMutators(context.Background(), "Rack", obj); err != nil {
+		t.Fatalf("RunMutators failed: %v", err)
+	}
+	if obj.Spec.Region != "uswest" {
+		t.Errorf("Spec.Region = %q, want uswest", obj.Spec.Region)
+	}
+	if region, _ := obj.GetLabel("region"); region != "uswest" {
+		t.Errorf("region label = %q, want uswest", region)
+	}
+}
+
+func TestRunMutators_StopsOnError(t *testing.T) {
+	resetMutators(t, "Rack")
+
+	wantErr := errors.New("datacenter is required")
+	ran := false
+	RegisterMutator("Rack", MutatorFunc(func(context.Context, interface{}) error { return wantErr }))
+	RegisterMutator("Rack", MutatorFunc(func(context.Context, interface{}) error {
+		ran = true
+		return nil
+	}))
+
+	err := RunMutators(context.Background(), "Rack", &rack{})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("RunMutators error = %v, want %v", err, wantErr)
+	}
+	if ran {
+		t.Error("Mutators after a failing one must not run")
+	}
+}
+
+func TestRunMutators_NoMutators(t *testing.T) {
+	if err := RunMutators(context.Background(), "Unregistered", &rack{}); err != nil {
+		t.Errorf("RunMutators with no mutators = %v, want nil", err)
+	}
+}
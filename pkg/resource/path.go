@@ -0,0 +1,224 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/fieldpath"
+)
+
+// GetFieldByPath returns the value at path in the JSON representation of
+// obj, decoded as encoding/json decodes into interface{} (objects as
+// map[string]interface{}, arrays as []interface{}, numbers as float64).
+//
+// path is either an RFC 6901 JSON Pointer, when it starts with "/", or a
+// dotted path in the syntax of pkg/fieldpath:
+//
+//	/spec/ports/0                  spec.ports[0]
+//	/metadata/labels/app.io~1name  metadata.labels['app.io/name']
+//
+// The empty path selects the whole object. ok is false if the path is
+// invalid, if a step is missing, or if an array index is out of range.
+//
+// Example:
+//
+//	location, ok := resource.GetFieldByPath(device, "spec.location")
+func GetFieldByPath(obj interface{}, path string) (value interface{}, ok bool) {
+	tokens, err := pathTokens(path)
+	if err != nil {
+		return nil, false
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, false
+	}
+	var current interface{}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil, false
+	}
+
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if current, ok = node[token]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			index, err := arrayIndex(token, len(node))
+			if err != nil || index == len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// SetFieldByPath sets the value at path in obj, a non-nil pointer, by
+// editing its JSON representation and decoding the result back into obj.
+// path has the same syntax as for GetFieldByPath; value is anything that
+// encodes to JSON the field can decode.
+//
+// Missing intermediate objects are created, so "metadata.labels.tier" works
+// on a resource without labels. Array elements must already exist, except
+// that the index one past the end, or "-" in a JSON Pointer, appends.
+//
+// obj is replaced by the result decoded into a zero value, so setting a map
+// replaces it rather than adding keys, setting a zero struct or slice
+// clears it, and fields left out when setting a whole object (e.g.
+// omitempty fields of "/spec") are reset. Fields that do not appear in JSON
+// (json:"-" or unexported) are reset too.
+//
+// Returns:
+//   - nil on success
+//   - an error if the path is invalid, runs through a value that is not an
+//     object or array, names a field obj does not have, or value does not
+//     decode into the field's type; obj is unchanged
+//
+// Example:
+//
+//	err := resource.SetFieldByPath(&device, "/spec/ports/-", 8443)
+func SetFieldByPath(obj interface{}, path string, value interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("cannot set %s on %T: expected a non-nil pointer", path, obj)
+	}
+	tokens, err := pathTokens(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to encode %T: %w", obj, err)
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // keep int64 values exact through the round trip
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode %T: %w", obj, err)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value for %s: %w", path, err)
+	}
+	doc, err = setToken(doc, tokens, json.RawMessage(encoded))
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	updated, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode %T: %w", obj, err)
+	}
+
+	// Decode into a zero value, so a failure leaves obj as it was and maps,
+	// slices and structs are replaced rather than merged into; unknown
+	// fields mean the path named a field obj does not have
+	fresh := reflect.New(v.Elem().Type())
+	decoder = json.NewDecoder(bytes.NewReader(updated))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(fresh.Interface()); err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	v.Elem().Set(fresh.Elem())
+	return nil
+}
+
+// setToken returns node with value set at the path given by tokens,
+// creating missing objects along the way
+func setToken(node interface{}, tokens []string, value json.RawMessage) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	token, rest := tokens[0], tokens[1:]
+
+	switch n := node.(type) {
+	case nil:
+		child, err := setToken(nil, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{token: child}, nil
+	case map[string]interface{}:
+		child, err := setToken(n[token], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(n))
+		if err != nil {
+			return nil, err
+		}
+		if index == len(n) {
+			n = append(n, nil)
+		}
+		child, err := setToken(n[index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = child
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%q is below a value that is not an object or array", token)
+	}
+}
+
+// arrayIndex parses token as an index into an array of length n. The index
+// n itself, also written "-", is one past the end.
+func arrayIndex(token string, n int) (int, error) {
+	if token == "-" {
+		return n, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%q is not an array index", token)
+	}
+	if index > n {
+		return 0, fmt.Errorf("array index %d is out of range (length %d)", index, n)
+	}
+	return index, nil
+}
+
+// pathTokens splits a JSON Pointer or fieldpath expression into unescaped
+// reference tokens
+func pathTokens(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") {
+		parsed, err := fieldpath.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		return parsed.Tokens(), nil
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		if strings.Contains(pointerEscapes.Replace(token), "~") {
+			return nil, fmt.Errorf("invalid JSON Pointer %q: ~ must be followed by 0 or 1", path)
+		}
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
+
+var (
+	// pointerEscapes removes the escapes a JSON Pointer token may contain
+	pointerEscapes = strings.NewReplacer("~0", "", "~1", "")
+
+	// pointerUnescaper decodes ~1 to / and ~0 to ~, in that order per RFC 6901
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
@@ -0,0 +1,143 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+type pathSpec struct {
+	Location string            `json:"location"`
+	Ports    []int             `json:"ports,omitempty"`
+	Counter  int64             `json:"counter,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Internal string            `json:"-"`
+}
+
+type pathDevice struct {
+	Resource
+	Spec pathSpec `json:"spec"`
+}
+
+func newPathDevice() *pathDevice {
+	d := &pathDevice{Spec: pathSpec{
+		Location: "dc1",
+		Ports:    []int{22, 443},
+		Labels:   map[string]string{"app.io/name": "bmc"},
+	}}
+	d.Metadata.Initialize("dev-1", "dev-1")
+	return d
+}
+
+func TestGetFieldByPath(t *testing.T) {
+	device := newPathDevice()
+
+	tests := []struct {
+		path   string
+		want   interface{}
+		wantOK bool
+	}{
+		{"spec.location", "dc1", true},
+		{"/spec/location", "dc1", true},
+		{"spec.ports[1]", float64(443), true},
+		{"/spec/ports/1", float64(443), true},
+		{"spec.labels['app.io/name']", "bmc", true},
+		{"/spec/labels/app.io~1name", "bmc", true},
+		{"metadata.name", "dev-1", true},
+		{"spec.ports[2]", nil, false},
+		{"/spec/ports/-", nil, false},
+		{"/spec/ports/01", nil, false},
+		{"spec.missing.deeper", nil, false},
+		{"spec.location.deeper", nil, false},
+		{"/spec/bad~2escape", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := GetFieldByPath(device, tt.path)
+		if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetFieldByPath(%q) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if doc, ok := GetFieldByPath(device, ""); !ok || doc.(map[string]interface{})["spec"] == nil {
+		t.Errorf("Empty path should select the whole object, got %v, %v", doc, ok)
+	}
+}
+
+func TestSetFieldByPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		value interface{}
+		check func(d *pathDevice) bool
+	}{
+		{"dotted", "spec.location", "dc2", func(d *pathDevice) bool { return d.Spec.Location == "dc2" }},
+		{"pointer", "/spec/location", "dc3", func(d *pathDevice) bool { return d.Spec.Location == "dc3" }},
+		{"array index", "spec.ports[0]", 2222, func(d *pathDevice) bool { return reflect.DeepEqual(d.Spec.Ports, []int{2222, 443}) }},
+		{"append with -", "/spec/ports/-", 8443, func(d *pathDevice) bool { return reflect.DeepEqual(d.Spec.Ports, []int{22, 443, 8443}) }},
+		{"append past the end", "spec.ports[2]", 8443, func(d *pathDevice) bool { return reflect.DeepEqual(d.Spec.Ports, []int{22, 443, 8443}) }},
+		{"escaped key", "/spec/labels/tier~1zone", "a", func(d *pathDevice) bool { return d.Spec.Labels["tier/zone"] == "a" }},
+		{"missing intermediate", "metadata.labels.tier", "gold", func(d *pathDevice) bool { return d.Metadata.Labels["tier"] == "gold" }},
+		{"large integer", "spec.counter", int64(1) << 60, func(d *pathDevice) bool { return d.Spec.Counter == 1<<60 }},
+		{"whole object", "/spec", pathSpec{Location: "dc9"}, func(d *pathDevice) bool {
+			// Replaced, not merged: the omitted ports are gone
+			return d.Spec.Location == "dc9" && len(d.Spec.Ports) == 0
+		}},
+		{"map replaced", "/spec/labels", map[string]string{"b": "2"}, func(d *pathDevice) bool {
+			return reflect.DeepEqual(d.Spec.Labels, map[string]string{"b": "2"})
+		}},
+		{"slice zeroed", "spec.ports", []int(nil), func(d *pathDevice) bool { return d.Spec.Ports == nil }},
+		{"struct zeroed", "/spec", pathSpec{}, func(d *pathDevice) bool {
+			return d.Spec.Location == "" && d.Spec.Ports == nil && d.Spec.Labels == nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newPathDevice()
+			if err := SetFieldByPath(device, tt.path, tt.value); err != nil {
+				t.Fatalf("SetFieldByPath failed: %v", err)
+			}
+			if !tt.check(device) {
+				t.Errorf("unexpected result: %+v", device.Spec)
+			}
+			if device.Metadata.UID != "dev-1" {
+				t.Errorf("fields outside the path changed: %+v", device)
+			}
+		})
+	}
+}
+
+func TestSetFieldByPath_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		value interface{}
+	}{
+		{"unknown field", "spec.nothing", "x"},
+		{"wrong type", "spec.ports[0]", "not a number"},
+		{"index out of range", "spec.ports[5]", 1},
+		{"not an index", "/spec/ports/first", 1},
+		{"below a scalar", "spec.location.city", "x"},
+		{"invalid pointer", "/spec/~", "x"},
+		{"invalid dotted path", "spec..location", "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newPathDevice()
+			want := *device
+			want.Spec.Ports = append([]int(nil), device.Spec.Ports...)
+			if err := SetFieldByPath(device, tt.path, tt.value); err == nil {
+				t.Fatal("SetFieldByPath succeeded, want an error")
+			}
+			if !reflect.DeepEqual(*device, want) {
+				t.Errorf("device changed on error: %+v", device.Spec)
+			}
+		})
+	}
+
+	if err := SetFieldByPath(pathDevice{}, "spec.location", "x"); err == nil {
+		t.Error("SetFieldByPath on a non-pointer succeeded, want an error")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultProtectedKeyPrefix is the label and annotation key prefix reserved
+// for the framework and reconcilers, e.g. fabrica.io/managed-by.
+const DefaultProtectedKeyPrefix = "fabrica.io/"
+
+// ProtectedTag is the FieldError tag reported for changes to protected keys
+const ProtectedTag = "protected"
+
+var protectedKeyPrefix atomic.Pointer[string]
+
+func init() {
+	SetProtectedKeyPrefix(DefaultProtectedKeyPrefix)
+}
+
+// SetProtectedKeyPrefix changes the protected key prefix. Call it at
+// startup; an empty prefix protects no keys.
+func SetProtectedKeyPrefix(prefix string) {
+	protectedKeyPrefix.Store(&prefix)
+}
+
+// ProtectedKeyPrefix returns the protected key prefix
+func ProtectedKeyPrefix() string {
+	return *protectedKeyPrefix.Load()
+}
+
+// IsProtectedLabelKey reports whether a label or annotation key starts with
+// the protected key prefix.
+//
+// Protected keys carry invariants the framework and reconcilers depend on.
+// The generated create and update handlers reject requests that add, change
+// or remove them with 422; code in the server (reconcilers, mutators, or
+// requests with WithSystemAccess) sets them freely.
+//
+// Example:
+//
+//	resource.IsProtectedLabelKey("fabrica.io/managed-by") // true
+//	resource.IsProtectedLabelKey("environment")           // false
+func IsProtectedLabelKey(key string) bool {
+	prefix := ProtectedKeyPrefix()
+	return prefix != "" && strings.HasPrefix(key, prefix)
+}
+
+// CheckProtectedKeys compares the labels and annotations of a resource
+// before and after a client's change and returns the paths, like
+// metadata.labels[fabrica.io/managed-by], of protected keys that were added,
+// changed or removed, sorted. old is nil on create.
+//
+// Example:
+//
+//	previous := device.Metadata.Clone()
+//	device.SetLabel("fabrica.io/managed-by", "me")
+//	changed := CheckProtectedKeys(previous, &device.Metadata)
+//	// changed == []string{"metadata.labels[fabrica.io/managed-by]"}
+func CheckProtectedKeys(old, new *Metadata) []string {
+	if old == nil {
+		old = &Metadata{}
+	}
+	changed := protectedKeyChanges("labels", old.Labels, new.Labels)
+	changed = append(changed, protectedKeyChanges("annotations", old.Annotations, new.Annotations)...)
+	return changed
+}
+
+// protectedKeyChanges returns the paths of the protected keys that differ
+// between two labels or annotations maps
+func protectedKeyChanges(name string, old, new map[string]string) []string {
+	keys := map[string]bool{}
+	for key, value := range new {
+		if previous, ok := old[key]; IsProtectedLabelKey(key) && (!ok || previous != value) {
+			keys[key] = true
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; IsProtectedLabelKey(key) && !ok {
+			keys[key] = true
+		}
+	}
+
+	paths := make([]string, 0, len(keys))
+	for key := range keys {
+		paths = append(paths, fmt.Sprintf("metadata.%s[%s]", name, key))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+type systemAccessKey struct{}
+
+// WithSystemAccess marks a request as coming from the system rather than a
+// client, so the generated handlers let it set protected keys. Set it in
+// server middleware for trusted callers only.
+func WithSystemAccess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemAccessKey{}, true)
+}
+
+// HasSystemAccess reports whether ctx was marked by WithSystemAccess
+func HasSystemAccess(ctx context.Context) bool {
+	access, _ := ctx.Value(systemAccessKey{}).(bool)
+	return access
+}
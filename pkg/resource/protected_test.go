@@ -0,0 +1,73 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestIsProtectedLabelKey(t *testing.T) {
+	t.Cleanup(func() { SetProtectedKeyPrefix(DefaultProtectedKeyPrefix) })
+
+	if !IsProtectedLabelKey("fabrica.io/managed-by") || IsProtectedLabelKey("environment") || IsProtectedLabelKey("example.com/fabrica.io") {
+		t.Error("IsProtectedLabelKey does not match the default prefix")
+	}
+
+	SetProtectedKeyPrefix("example.com/")
+	if !IsProtectedLabelKey("example.com/owner") || IsProtectedLabelKey("fabrica.io/managed-by") {
+		t.Error("IsProtectedLabelKey does not follow SetProtectedKeyPrefix")
+	}
+
+	SetProtectedKeyPrefix("")
+	if IsProtectedLabelKey("example.com/owner") {
+		t.Error("an empty prefix should protect no keys")
+	}
+}
+
+func TestCheckProtectedKeys(t *testing.T) {
+	old := &Metadata{
+		Labels:      map[string]string{"fabrica.io/managed-by": "rack-controller", "fabrica.io/zone": "a", "env": "prod"},
+		Annotations: map[string]string{"fabrica.io/last-sync": "t1"},
+	}
+
+	unchanged := old.Clone()
+	unchanged.Labels["env"] = "dev"
+	unchanged.Annotations["note"] = "free to change"
+	if changed := CheckProtectedKeys(old, unchanged); len(changed) != 0 {
+		t.Errorf("unprotected changes reported: %v", changed)
+	}
+
+	updated := old.Clone()
+	updated.Labels["fabrica.io/managed-by"] = "me"
+	delete(updated.Labels, "fabrica.io/zone")
+	updated.Labels["fabrica.io/new"] = "x"
+	updated.Annotations = nil
+
+	want := []string{
+		"metadata.labels[fabrica.io/managed-by]",
+		"metadata.labels[fabrica.io/new]",
+		"metadata.labels[fabrica.io/zone]",
+		"metadata.annotations[fabrica.io/last-sync]",
+	}
+	if changed := CheckProtectedKeys(old, updated); !reflect.DeepEqual(changed, want) {
+		t.Errorf("CheckProtectedKeys = %v, want %v", changed, want)
+	}
+
+	created := &Metadata{Labels: map[string]string{"fabrica.io/managed-by": "me", "env": "prod"}}
+	if changed := CheckProtectedKeys(nil, created); !reflect.DeepEqual(changed, []string{"metadata.labels[fabrica.io/managed-by]"}) {
+		t.Errorf("CheckProtectedKeys on create = %v", changed)
+	}
+}
+
+func TestSystemAccess(t *testing.T) {
+	if HasSystemAccess(context.Background()) {
+		t.Error("a plain context should not have system access")
+	}
+	if !HasSystemAccess(WithSystemAccess(context.Background())) {
+		t.Error("WithSystemAccess should grant system access")
+	}
+}
@@ -73,6 +73,29 @@ func (r *Resource) SetUID(uid string) {
 	r.Metadata.UID = uid
 }
 
+// GetResourceVersion returns the storage version of the resource.
+//
+// The version is managed by storage.ResourceStorage for optimistic locking;
+// it is empty for resources that have never been saved through it.
+func (r *Resource) GetResourceVersion() string {
+	return r.Metadata.ResourceVersion
+}
+
+// SetResourceVersion sets the storage version of the resource.
+//
+// Storage sets this on save; application code should not normally call it.
+func (r *Resource) SetResourceVersion(version string) {
+	r.Metadata.ResourceVersion = version
+}
+
+// GetMetadata returns a pointer to the resource's metadata.
+//
+// Types that embed Resource inherit it, which lets generic code (such as
+// pkg/handlers) initialize and update metadata without knowing the type.
+func (r *Resource) GetMetadata() *Metadata {
+	return &r.Metadata
+}
+
 // GetName returns the resource name.
 //
 // Names should be human-readable and unique within their scope/namespace.
@@ -296,6 +319,14 @@ func (r *Resource) LastUpdated() time.Duration {
 	return time.Since(r.Metadata.UpdatedAt)
 }
 
+// GetModifiedAt returns the time the resource was last modified.
+//
+// This satisfies conditional.Taggable so collections of resources can
+// be summarized into a single collection ETag.
+func (r *Resource) GetModifiedAt() time.Time {
+	return r.Metadata.UpdatedAt
+}
+
 // UID Generation Helpers
 //
 // These functions provide structured, human-readable UIDs instead of UUIDs.
@@ -0,0 +1,45 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseLabelSelector parses an equality label selector such as
+// "environment=production,role=server" into the map form accepted by
+// MatchesLabels. An empty string selects everything and returns nil.
+//
+// Example:
+//
+//	selector, err := resource.ParseLabelSelector(r.URL.Query().Get("labelSelector"))
+//	if err != nil {
+//	    return err
+//	}
+//	if device.MatchesLabels(selector) {
+//	    // Device matches
+//	}
+func ParseLabelSelector(s string) (map[string]string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	selector := make(map[string]string)
+	for _, term := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(term, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label selector term %q: expected key=value", term)
+		}
+		value = strings.TrimSpace(value)
+		if existing, dup := selector[key]; dup && existing != value {
+			return nil, fmt.Errorf("label selector requires %s to be both %q and %q", key, existing, value)
+		}
+		selector[key] = value
+	}
+	return selector, nil
+}
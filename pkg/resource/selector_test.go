@@ -0,0 +1,39 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{input: "", want: nil},
+		{input: "env=prod", want: map[string]string{"env": "prod"}},
+		{input: " env = prod , role=server ", want: map[string]string{"env": "prod", "role": "server"}},
+		{input: "env=", want: map[string]string{"env": ""}},
+		{input: "env=prod,env=prod", want: map[string]string{"env": "prod"}},
+		{input: "env", wantErr: true},
+		{input: "=prod", wantErr: true},
+		{input: "env=prod,", wantErr: true},
+		{input: "env=prod,env=dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLabelSelector(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLabelSelector(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLabelSelector(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of resources a CachingBackend holds when
+// CacheOptions.MaxSize is zero.
+const DefaultCacheSize = 1024
+
+// WatchBackend is implemented by backends that can report changes made by
+// other processes, such as a database shared by several servers.
+type WatchBackend interface {
+	StorageBackend
+
+	// Watch starts calling onChange with the type and UID of each resource
+	// created, updated or deleted outside this backend, until ctx is done.
+	// It returns once watching has started.
+	Watch(ctx context.Context, onChange func(resourceType, uid string)) error
+}
+
+// CacheOptions configures NewCachingBackend. Zero values select the defaults.
+type CacheOptions struct {
+	// MaxSize bounds the number of cached resources (default
+	// DefaultCacheSize). The least recently used entry is evicted first.
+	MaxSize int
+
+	// TTL bounds how long an entry is served before it is loaded again.
+	// Zero keeps entries until they are evicted or invalidated.
+	TTL time.Duration
+}
+
+// CacheStats reports the activity of a CachingBackend
+type CacheStats struct {
+	Hits      uint64 // Load and Exists calls answered from the cache
+	Misses    uint64 // Load and Exists calls passed to the wrapped backend
+	Evictions uint64 // Entries dropped to stay within MaxSize
+	Entries   int    // Entries currently cached
+}
+
+// CachingBackend wraps a StorageBackend with a bounded LRU cache for Load and
+// Exists. Every write through the CachingBackend invalidates the written
+// resource, and changes reported by a WatchBackend invalidate theirs.
+//
+// Writes made to the wrapped backend by other processes are not seen until
+// the entry expires, unless the wrapped backend implements WatchBackend. Use
+// the cache only when this backend is the sole writer (e.g. file storage in
+// one server) or with a TTL short enough for the staleness to be acceptable.
+//
+// Reads whose context is marked with WithPrimary bypass the cache, so the
+// load of a load-modify-save cycle always sees the stored resource.
+//
+// A CachingBackend is safe for concurrent use.
+type CachingBackend struct {
+	inner StorageBackend
+	opts  CacheOptions
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List // front is most recently used
+	gen     uint64     // bumped by every invalidation
+	stats   CacheStats
+
+	stopWatch context.CancelFunc
+}
+
+type cacheKey struct {
+	resourceType, uid string
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	exists  bool
+	data    json.RawMessage // nil if only existence is known
+	expires time.Time       // zero if entries do not expire
+}
+
+// NewCachingBackend wraps inner with an LRU cache.
+//
+// Example:
+//
+//	backend, _ := storage.NewFileBackend("./data")
+//	cached, err := storage.NewCachingBackend(backend, storage.CacheOptions{
+//		MaxSize: 10000,
+//		TTL:     time.Minute,
+//	})
+//
+// Returns:
+//   - *CachingBackend: The caching backend; closing it closes inner
+//   - error: ErrInvalidData for negative options, or the error of
+//     WatchBackend.Watch if inner implements it
+func NewCachingBackend(inner StorageBackend, opts CacheOptions) (*CachingBackend, error) {
+	if opts.MaxSize < 0 || opts.TTL < 0 {
+		return nil, fmt.Errorf("cache size and TTL must not be negative: %w", ErrInvalidData)
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultCacheSize
+	}
+
+	c := &CachingBackend{
+		inner:   inner,
+		opts:    opts,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+
+	if watcher, ok := inner.(WatchBackend); ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := watcher.Watch(ctx, c.Invalidate); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to watch storage for changes: %w", err)
+		}
+		c.stopWatch = cancel
+	}
+
+	return c, nil
+}
+
+// Stats returns the cache counters
+func (c *CachingBackend) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Invalidate drops the cached entry for a resource, if any. Use it when the
+// resource was changed without going through this backend.
+func (c *CachingBackend) Invalidate(resourceType, uid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if elem, ok := c.entries[cacheKey{resourceType, uid}]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// Purge drops all cached entries
+func (c *CachingBackend) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.entries = make(map[cacheKey]*list.Element)
+	c.lru.Init()
+}
+
+// lookup returns the live entry for key, counting a hit or a miss. A miss
+// also returns the generation to pass to store.
+func (c *CachingBackend) lookup(key cacheKey, needData bool) (cacheEntry, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		switch {
+		case !entry.expires.IsZero() && time.Now().After(entry.expires):
+			c.removeElement(elem)
+		case needData && entry.exists && entry.data == nil:
+			// Only existence is known
+		default:
+			c.lru.MoveToFront(elem)
+			c.stats.Hits++
+			return *entry, true, 0
+		}
+	}
+
+	c.stats.Misses++
+	return cacheEntry{}, false, c.gen
+}
+
+// store caches what was read for key, unless an invalidation happened since
+// the read started, in which case the read may be stale.
+func (c *CachingBackend) store(key cacheKey, gen uint64, exists bool, data json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+
+	entry := &cacheEntry{key: key, exists: exists, data: data}
+	if c.opts.TTL > 0 {
+		entry.expires = time.Now().Add(c.opts.TTL)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.opts.MaxSize {
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *CachingBackend) removeElement(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// LoadAll implements StorageBackend.LoadAll. It is not cached.
+func (c *CachingBackend) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	return c.inner.LoadAll(ctx, resourceType)
+}
+
+// Load implements StorageBackend.Load. Missing resources are cached too.
+func (c *CachingBackend) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	if ReadFromPrimary(ctx) {
+		return c.inner.Load(ctx, resourceType, uid)
+	}
+
+	key := cacheKey{resourceType, uid}
+	entry, ok, gen := c.lookup(key, true)
+	if ok {
+		if !entry.exists {
+			return nil, NewStorageError("load", resourceType, uid, ErrNotFound)
+		}
+		return cloneRaw(entry.data), nil
+	}
+
+	data, err := c.inner.Load(ctx, resourceType, uid)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		c.store(key, gen, false, nil)
+	case err == nil:
+		c.store(key, gen, true, cloneRaw(data))
+	}
+	return data, err
+}
+
+// LoadMany implements StorageBackend.LoadMany. It is not cached.
+func (c *CachingBackend) LoadMany(ctx context.Context, resourceType string, uids []string) (map[string]json.RawMessage, error) {
+	return c.inner.LoadMany(ctx, resourceType, uids)
+}
+
+// Save implements StorageBackend.Save
+func (c *CachingBackend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	defer c.Invalidate(resourceType, uid)
+	return c.inner.Save(ctx, resourceType, uid, data)
+}
+
+// SaveIfVersion implements ConditionalSaver.SaveIfVersion, using the wrapped
+// backend's implementation if it has one. The version check always reads
+// the stored resource, not the cache.
+func (c *CachingBackend) SaveIfVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, expectedVersion string) error {
+	defer c.Invalidate(resourceType, uid)
+	return saveIfVersion(WithPrimary(ctx), c.inner, nil, resourceType, uid, data, expectedVersion)
+}
+
+// CompareAndSwap implements StorageBackend.CompareAndSwap
+func (c *CachingBackend) CompareAndSwap(ctx context.Context, resourceType, uid string, expected, data json.RawMessage) (bool, error) {
+	defer c.Invalidate(resourceType, uid)
+	return c.inner.CompareAndSwap(ctx, resourceType, uid, expected, data)
+}
+
+// Delete implements StorageBackend.Delete
+func (c *CachingBackend) Delete(ctx context.Context, resourceType, uid string) error {
+	defer c.Invalidate(resourceType, uid)
+	return c.inner.Delete(ctx, resourceType, uid)
+}
+
+// Exists implements StorageBackend.Exists
+func (c *CachingBackend) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	if ReadFromPrimary(ctx) {
+		return c.inner.Exists(ctx, resourceType, uid)
+	}
+
+	key := cacheKey{resourceType, uid}
+	entry, ok, gen := c.lookup(key, false)
+	if ok {
+		return entry.exists, nil
+	}
+
+	exists, err := c.inner.Exists(ctx, resourceType, uid)
+	if err == nil {
+		c.store(key, gen, exists, nil)
+	}
+	return exists, err
+}
+
+// List implements StorageBackend.List. It is not cached.
+func (c *CachingBackend) List(ctx context.Context, resourceType string) ([]string, error) {
+	return c.inner.List(ctx, resourceType)
+}
+
+// Count implements StorageBackend.Count. It is not cached.
+func (c *CachingBackend) Count(ctx context.Context, resourceType string) (int, error) {
+	return c.inner.Count(ctx, resourceType)
+}
+
+// Stat implements StatBackend.Stat using the wrapped backend. It is not
+// cached.
+func (c *CachingBackend) Stat(ctx context.Context, resourceType, uid string) (StatInfo, error) {
+	return Stat(ctx, c.inner, resourceType, uid)
+}
+
+// Snapshot implements SnapshotBackend.Snapshot using the wrapped backend. It
+// is not cached.
+func (c *CachingBackend) Snapshot(ctx context.Context, resourceType string) (Iterator, error) {
+	return Snapshot(ctx, c.inner, resourceType)
+}
+
+// FindByField implements FieldLookupBackend.FindByField using the wrapped
+// backend. It is not cached.
+func (c *CachingBackend) FindByField(ctx context.Context, resourceType, field, value string) (string, error) {
+	return FindByField(ctx, c.inner, resourceType, field, value)
+}
+
+// FindByName implements NameLookupBackend.FindByName using the wrapped
+// backend. It is not cached.
+func (c *CachingBackend) FindByName(ctx context.Context, resourceType, name string) (string, error) {
+	return FindByName(ctx, c.inner, resourceType, name)
+}
+
+// Close stops watching for changes, drops the cache and closes the wrapped
+// backend
+func (c *CachingBackend) Close() error {
+	if c.stopWatch != nil {
+		c.stopWatch()
+	}
+	c.Purge()
+	return c.inner.Close()
+}
+
+// LoadWithVersion implements StorageBackend.LoadWithVersion. It is not cached.
+func (c *CachingBackend) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (json.RawMessage, string, error) {
+	return c.inner.LoadWithVersion(ctx, resourceType, uid, version)
+}
+
+// LoadAllWithVersion implements StorageBackend.LoadAllWithVersion. It is not
+// cached.
+func (c *CachingBackend) LoadAllWithVersion(ctx context.Context, resourceType, version string) ([]json.RawMessage, error) {
+	return c.inner.LoadAllWithVersion(ctx, resourceType, version)
+}
+
+// SaveWithVersion implements StorageBackend.SaveWithVersion
+func (c *CachingBackend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	defer c.Invalidate(resourceType, uid)
+	return c.inner.SaveWithVersion(ctx, resourceType, uid, data, version)
+}
+
+// cloneRaw copies data so callers cannot modify cached bytes
+func cloneRaw(data json.RawMessage) json.RawMessage {
+	if data == nil {
+		return nil
+	}
+	return append(json.RawMessage(nil), data...)
+}
@@ -0,0 +1,260 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingBackend counts the Load and Exists calls that reach the backend
+type countingBackend struct {
+	StorageBackend
+	mu     sync.Mutex
+	loads  int
+	exists int
+}
+
+func (b *countingBackend) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	b.mu.Lock()
+	b.loads++
+	b.mu.Unlock()
+	return b.StorageBackend.Load(ctx, resourceType, uid)
+}
+
+func (b *countingBackend) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	b.mu.Lock()
+	b.exists++
+	b.mu.Unlock()
+	return b.StorageBackend.Exists(ctx, resourceType, uid)
+}
+
+// watchingBackend reports changes through the callback passed to Watch
+type watchingBackend struct {
+	*countingBackend
+	onChange func(resourceType, uid string)
+}
+
+func (b *watchingBackend) Watch(ctx context.Context, onChange func(resourceType, uid string)) error {
+	b.onChange = onChange
+	return nil
+}
+
+func newTestCachingBackend(t *testing.T, opts CacheOptions) (*CachingBackend, *countingBackend) {
+	t.Helper()
+
+	backend, _ := newTestFileBackend(t)
+	counting := &countingBackend{StorageBackend: backend}
+	cache, err := NewCachingBackend(counting, opts)
+	if err != nil {
+		t.Fatalf("NewCachingBackend failed: %v", err)
+	}
+	return cache, counting
+}
+
+func TestCachingBackend_LoadIsCached(t *testing.T) {
+	cache, counting := newTestCachingBackend(t, CacheOptions{})
+	ctx := context.Background()
+
+	if err := cache.Save(ctx, "Device", "dev-1", json.RawMessage(`{"v":1}`)); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		data, err := cache.Load(ctx, "Device", "dev-1")
+		if err != nil || string(data) != `{"v":1}` {
+			t.Fatalf("Load = %s, %v", data, err)
+		}
+		// Callers cannot change the cached copy
+		data[0] = 'x'
+	}
+	if counting.loads != 1 {
+		t.Errorf("Expected 1 backend load, got %d", counting.loads)
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("Stats = %+v", stats)
+	}
+
+	// A marked context reads through
+	if _, err := cache.Load(WithPrimary(ctx), "Device", "dev-1"); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if counting.loads != 2 {
+		t.Errorf("Expected WithPrimary to bypass the cache, got %d loads", counting.loads)
+	}
+}
+
+func TestCachingBackend_WritesInvalidate(t *testing.T) {
+	cache, _ := newTestCachingBackend(t, CacheOptions{})
+	ctx := context.Background()
+
+	if _, err := cache.Load(ctx, "Device", "dev-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	if exists, _ := cache.Exists(ctx, "Device", "dev-1"); exists {
+		t.Fatal("Expected dev-1 not to exist")
+	}
+
+	if err := cache.Save(ctx, "Device", "dev-1", json.RawMessage(`{"v":1}`)); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if exists, _ := cache.Exists(ctx, "Device", "dev-1"); !exists {
+		t.Error("Save did not invalidate the cached absence")
+	}
+
+	current, _ := cache.Load(ctx, "Device", "dev-1")
+	if swapped, err := cache.CompareAndSwap(ctx, "Device", "dev-1", current, json.RawMessage(`{"v":2}`)); !swapped || err != nil {
+		t.Fatalf("CompareAndSwap = %v, %v", swapped, err)
+	}
+	if data, _ := cache.Load(ctx, "Device", "dev-1"); string(data) != `{"v":2}` {
+		t.Errorf("Load after CompareAndSwap = %s", data)
+	}
+
+	if err := cache.Delete(ctx, "Device", "dev-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := cache.Load(ctx, "Device", "dev-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after Delete, got %v", err)
+	}
+}
+
+func TestCachingBackend_SaveIfVersion(t *testing.T) {
+	cache, _ := newTestCachingBackend(t, CacheOptions{})
+	devices := NewResourceStorage[*versionedDevice](cache, "Device")
+	ctx := context.Background()
+
+	device := &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	stale, err := devices.Load(ctx, "dev-1")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	device.Hostname = "node-1"
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatalf("Second Save failed: %v", err)
+	}
+	if err := devices.Save(ctx, stale); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict for a stale save, got %v", err)
+	}
+
+	loaded, _ := devices.Load(ctx, "dev-1")
+	if loaded.Metadata.ResourceVersion != "2" || loaded.Hostname != "node-1" {
+		t.Errorf("Unexpected stored resource: %+v", loaded)
+	}
+}
+
+func TestCachingBackend_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, counting := newTestCachingBackend(t, CacheOptions{MaxSize: 2})
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		uid := fmt.Sprintf("dev-%d", i)
+		if err := cache.Save(ctx, "Device", uid, json.RawMessage(`{}`)); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	cache.Load(ctx, "Device", "dev-1")
+	cache.Load(ctx, "Device", "dev-2")
+	cache.Load(ctx, "Device", "dev-1") // dev-2 is now least recently used
+	cache.Load(ctx, "Device", "dev-3") // evicts dev-2
+
+	counting.loads = 0
+	cache.Load(ctx, "Device", "dev-1")
+	cache.Load(ctx, "Device", "dev-3")
+	if counting.loads != 0 {
+		t.Errorf("Expected dev-1 and dev-3 to be cached, got %d loads", counting.loads)
+	}
+	cache.Load(ctx, "Device", "dev-2")
+	if counting.loads != 1 {
+		t.Errorf("Expected dev-2 to be evicted, got %d loads", counting.loads)
+	}
+	if stats := cache.Stats(); stats.Entries != 2 || stats.Evictions != 2 {
+		t.Errorf("Stats = %+v", stats)
+	}
+}
+
+func TestCachingBackend_TTL(t *testing.T) {
+	cache, counting := newTestCachingBackend(t, CacheOptions{TTL: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	cache.Exists(ctx, "Device", "dev-1")
+	cache.Exists(ctx, "Device", "dev-1")
+	if counting.exists != 1 {
+		t.Fatalf("Expected 1 backend Exists, got %d", counting.exists)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	cache.Exists(ctx, "Device", "dev-1")
+	if counting.exists != 2 {
+		t.Errorf("Expected the entry to expire, got %d backend Exists", counting.exists)
+	}
+}
+
+func TestCachingBackend_Watch(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	watching := &watchingBackend{countingBackend: &countingBackend{StorageBackend: backend}}
+	cache, err := NewCachingBackend(watching, CacheOptions{})
+	if err != nil {
+		t.Fatalf("NewCachingBackend failed: %v", err)
+	}
+	ctx := context.Background()
+
+	cache.Save(ctx, "Device", "dev-1", json.RawMessage(`{"v":1}`))
+	cache.Load(ctx, "Device", "dev-1")
+
+	// Another process changes the resource
+	backend.Save(ctx, "Device", "dev-1", json.RawMessage(`{"v":2}`))
+	watching.onChange("Device", "dev-1")
+
+	if data, _ := cache.Load(ctx, "Device", "dev-1"); string(data) != `{"v":2}` {
+		t.Errorf("Load after change = %s", data)
+	}
+}
+
+func TestCachingBackend_Concurrent(t *testing.T) {
+	cache, _ := newTestCachingBackend(t, CacheOptions{MaxSize: 4})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				uid := fmt.Sprintf("dev-%d", i%6)
+				if w%2 == 0 {
+					cache.Save(ctx, "Device", uid, json.RawMessage(fmt.Sprintf(`{"w":%d}`, w)))
+				} else {
+					cache.Load(ctx, "Device", uid)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Once writers stop, every cached entry matches the backend
+	for i := 0; i < 6; i++ {
+		uid := fmt.Sprintf("dev-%d", i)
+		cached, _ := cache.Load(ctx, "Device", uid)
+		stored, _ := cache.Load(WithPrimary(ctx), "Device", uid)
+		if string(cached) != string(stored) {
+			t.Errorf("%s: cached %s, stored %s", uid, cached, stored)
+		}
+	}
+}
+
+func TestNewCachingBackend_RejectsNegativeOptions(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	if _, err := NewCachingBackend(backend, CacheOptions{MaxSize: -1}); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultConnectTimeout is how long the generated server waits for its
+// database at startup before giving up.
+const DefaultConnectTimeout = 60 * time.Second
+
+// ConnectOptions configures RetryConnect. Zero values select the defaults.
+type ConnectOptions struct {
+	// Timeout bounds the total time spent retrying, measured from the first
+	// attempt. Zero or a negative value makes a single attempt.
+	Timeout time.Duration
+
+	// InitialDelay is the wait after the first failure (default 500ms).
+	// The wait doubles after each further failure, up to MaxDelay.
+	InitialDelay time.Duration
+
+	// MaxDelay caps the wait between attempts (default 10s)
+	MaxDelay time.Duration
+
+	// Logf reports each failed attempt, e.g. log.Printf. Nil disables it.
+	Logf func(format string, args ...interface{})
+}
+
+// RetryConnect calls connect until it succeeds, waiting with exponential
+// backoff between attempts until opts.Timeout has passed. Use it at startup
+// when the database may not be ready yet, as is common when containers start
+// together:
+//
+//	db, _ := sql.Open("postgres", dsn)
+//	err := storage.RetryConnect(ctx, storage.ConnectOptions{
+//		Timeout: storage.DefaultConnectTimeout,
+//		Logf:    log.Printf,
+//	}, db.PingContext)
+//
+// Errors wrapping ErrInvalidData are returned at once, since retrying cannot
+// fix them. Each attempt gets a context that expires with the overall
+// timeout.
+//
+// Returns:
+//   - error: nil once connect succeeds; otherwise the last error of connect,
+//     wrapped with the number of attempts once retrying stops
+func RetryConnect(ctx context.Context, opts ConnectOptions, connect func(ctx context.Context) error) error {
+	if opts.InitialDelay <= 0 {
+		opts.InitialDelay = 500 * time.Millisecond
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 10 * time.Second
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	delay := opts.InitialDelay
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			return nil
+		}
+		if opts.Timeout <= 0 || errors.Is(err, ErrInvalidData) {
+			return err
+		}
+
+		deadline, _ := ctx.Deadline()
+		if time.Until(deadline) < delay {
+			return fmt.Errorf("gave up after %d attempts in %s: %w", attempt, opts.Timeout, err)
+		}
+		if opts.Logf != nil {
+			opts.Logf("Storage connection attempt %d failed: %v (retrying in %s)", attempt, err, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > opts.MaxDelay {
+			delay = opts.MaxDelay
+		}
+	}
+}
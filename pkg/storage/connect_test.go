@@ -0,0 +1,75 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryConnect_SucceedsAfterFailures(t *testing.T) {
+	attempts := 0
+	var logged []string
+	err := RetryConnect(context.Background(), ConnectOptions{
+		Timeout:      time.Second,
+		InitialDelay: time.Millisecond,
+		Logf: func(format string, args ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		},
+	}, func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RetryConnect failed: %v", err)
+	}
+	if attempts != 3 || len(logged) != 2 {
+		t.Errorf("attempts = %d, logged %d failures; want 3 and 2", attempts, len(logged))
+	}
+}
+
+func TestRetryConnect_GivesUpAtTimeout(t *testing.T) {
+	refused := errors.New("connection refused")
+	start := time.Now()
+	err := RetryConnect(context.Background(), ConnectOptions{
+		Timeout:      50 * time.Millisecond,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+	}, func(context.Context) error { return refused })
+	if !errors.Is(err, refused) {
+		t.Fatalf("Expected the last connect error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RetryConnect took %s, want about the 50ms timeout", elapsed)
+	}
+}
+
+func TestRetryConnect_NoRetry(t *testing.T) {
+	for name, tt := range map[string]struct {
+		timeout time.Duration
+		err     error
+	}{
+		"zero timeout": {0, errors.New("connection refused")},
+		"invalid data": {time.Second, fmt.Errorf("%w: bad DSN", ErrInvalidData)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			err := RetryConnect(context.Background(), ConnectOptions{Timeout: tt.timeout, InitialDelay: time.Millisecond},
+				func(context.Context) error {
+					attempts++
+					return tt.err
+				})
+			if !errors.Is(err, tt.err) || attempts != 1 {
+				t.Errorf("RetryConnect = %v after %d attempts; want %v after 1", err, attempts, tt.err)
+			}
+		})
+	}
+}
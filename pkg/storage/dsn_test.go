@@ -0,0 +1,123 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEntDriverName(t *testing.T) {
+	for driver, expected := range map[string]string{
+		"sqlite":     "sqlite3",
+		"sqlite3":    "sqlite3",
+		"postgres":   "postgres",
+		"postgresql": "postgres",
+		"mysql":      "mysql",
+		"MySQL":      "mysql",
+	} {
+		name, err := EntDriverName(driver)
+		if err != nil || name != expected {
+			t.Errorf("EntDriverName(%q) = %q, %v; want %q", driver, name, err, expected)
+		}
+	}
+
+	if _, err := EntDriverName("oracle"); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for an unsupported driver, got %v", err)
+	}
+}
+
+func TestEntDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   string
+		dsn      string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "sqlite gets foreign keys",
+			driver:   "sqlite",
+			dsn:      "file:./app.db",
+			expected: "file:./app.db?_fk=1",
+		},
+		{
+			name:     "postgres URL unchanged",
+			driver:   "postgres",
+			dsn:      "postgres://app@db/inventory?sslmode=disable",
+			expected: "postgres://app@db/inventory?sslmode=disable",
+		},
+		{
+			name:     "postgres key-value unchanged",
+			driver:   "postgresql",
+			dsn:      "host=db dbname=inventory",
+			expected: "host=db dbname=inventory",
+		},
+		{
+			name:    "postgres requires a DSN",
+			driver:  "postgres",
+			wantErr: true,
+		},
+		{
+			name:     "mysql native DSN gets parseTime",
+			driver:   "mysql",
+			dsn:      "root:secret@tcp(db:3306)/inventory",
+			expected: "root:secret@tcp(db:3306)/inventory?parseTime=true",
+		},
+		{
+			name:     "mysql keeps parseTime",
+			driver:   "mysql",
+			dsn:      "root@tcp(db:3306)/inventory?parseTime=true&loc=Local",
+			expected: "root@tcp(db:3306)/inventory?loc=Local&parseTime=true",
+		},
+		{
+			name:     "mysql URL is converted",
+			driver:   "mysql",
+			dsn:      "mysql://root:secret@db/inventory?loc=Local",
+			expected: "root:secret@tcp(db:3306)/inventory?loc=Local&parseTime=true",
+		},
+		{
+			name:     "mysql URL password is decoded",
+			driver:   "mysql",
+			dsn:      "mysql://app:p%40ss%3Aw%2Frd@db:3307/inventory",
+			expected: "app:p@ss:w/rd@tcp(db:3307)/inventory?parseTime=true",
+		},
+		{
+			name:    "mysql rejects parseTime=false",
+			driver:  "mysql",
+			dsn:     "root@tcp(db:3306)/inventory?parseTime=false",
+			wantErr: true,
+		},
+		{
+			name:    "mysql requires a DSN",
+			driver:  "mysql",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported driver",
+			driver:  "oracle",
+			dsn:     "oracle://db",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dsn, err := EntDSN(test.driver, test.dsn)
+			if test.wantErr {
+				if !errors.Is(err, ErrInvalidData) {
+					t.Fatalf("Expected ErrInvalidData, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if dsn != test.expected {
+				t.Errorf("Expected DSN %q, got %q", test.expected, dsn)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+// StorageError records the operation and resource a storage error occurred
+// on. It wraps the cause, so errors.Is(err, ErrNotFound) and the other
+// sentinel checks keep working:
+//
+//	_, err := backend.Load(ctx, "Device", "dev-1")
+//	var storageErr *storage.StorageError
+//	if errors.Is(err, storage.ErrNotFound) && errors.As(err, &storageErr) {
+//		log.Printf("%s %s is gone", storageErr.ResourceType, storageErr.UID)
+//	}
+type StorageError struct {
+	Op           string // Operation that failed, e.g. "load", "save" or "delete"
+	ResourceType string // Type name, e.g. "Device"
+	UID          string // Resource UID; empty for operations on a whole type
+	Err          error  // Cause, usually one of the sentinel errors
+}
+
+func (e *StorageError) Error() string {
+	resource := e.ResourceType
+	if e.UID != "" {
+		resource += " " + e.UID
+	}
+	return e.Op + " " + resource + ": " + e.Err.Error()
+}
+
+func (e *StorageError) Unwrap() error {
+	return e.Err
+}
+
+// NewStorageError wraps err in a StorageError. It returns nil for a nil err.
+func NewStorageError(op, resourceType, uid string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &StorageError{Op: op, ResourceType: resourceType, UID: uid, Err: err}
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestStorageError(t *testing.T) {
+	err := NewStorageError("load", "Device", "dev-1", ErrNotFound)
+	if err.Error() != "load Device dev-1: resource not found" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, ErrNotFound) || IsTransient(err) {
+		t.Errorf("Expected a permanent ErrNotFound, got %v", err)
+	}
+
+	if err := NewStorageError("list", "Device", "", ErrInvalidData); err.Error() != "list Device: invalid data" {
+		t.Errorf("Error() without UID = %q", err.Error())
+	}
+	if NewStorageError("load", "Device", "dev-1", nil) != nil {
+		t.Error("Expected nil for a nil cause")
+	}
+
+	// A transient cause stays transient
+	transient := NewStorageError("save", "Device", "dev-1", &TransientError{Err: errors.New("busy")})
+	if !IsTransient(transient) {
+		t.Error("Expected the wrapped TransientError to be found")
+	}
+}
+
+func TestFileBackend_ErrorsCarryResource(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+
+	for name, err := range map[string]error{
+		"load":   func() error { _, err := backend.Load(ctx, "Device", "dev-1"); return err }(),
+		"delete": backend.Delete(ctx, "Device", "dev-1"),
+	} {
+		var storageErr *StorageError
+		if !errors.Is(err, ErrNotFound) || !errors.As(err, &storageErr) {
+			t.Fatalf("%s: expected a StorageError wrapping ErrNotFound, got %v", name, err)
+		}
+		if storageErr.Op != name || storageErr.ResourceType != "Device" || storageErr.UID != "dev-1" {
+			t.Errorf("%s: StorageError = %+v", name, storageErr)
+		}
+	}
+
+	if err := backend.Save(ctx, "Device", "dev-1", json.RawMessage(`{"metadata":{"resourceVersion":"2"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	err := backend.SaveIfVersion(ctx, "Device", "dev-1", json.RawMessage(`{}`), "1")
+	var storageErr *StorageError
+	if !errors.Is(err, ErrConflict) || !errors.As(err, &storageErr) || storageErr.UID != "dev-1" {
+		t.Errorf("Expected a StorageError wrapping ErrConflict, got %v", err)
+	}
+}
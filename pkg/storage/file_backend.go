@@ -5,11 +5,14 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -33,7 +36,8 @@ import (
 //   - Thread-safe: Uses file locking for concurrent access
 //   - Atomic writes: Uses temp files + rename for atomicity
 //   - Auto-creation: Creates directories as needed
-//   - Validation: Checks JSON format before saving
+//   - Validation: Checks the data format before saving and rejects unsafe UIDs
+//   - Serialization: JSON by default; see SetSerializer
 //   - Error recovery: Continues operation even if some files are corrupted
 //
 // Limitations:
@@ -42,6 +46,13 @@ import (
 //   - Consistency: No transactions across multiple resources
 //   - Locking: File locking may not work on all file systems
 //
+// Transient errors:
+//
+//	Filesystem failures that may clear on retry (EINTR, EAGAIN, EBUSY,
+//	EMFILE, ENFILE, ETIMEDOUT, e.g. a busy or briefly unreachable network
+//	mount) are wrapped in TransientError; see IsTransient. Permission
+//	errors, a full disk and corrupt files are permanent.
+//
 // This backend is suitable for:
 //   - Development and testing
 //   - Small to medium deployments
@@ -52,6 +63,7 @@ type FileBackend struct {
 	mu              sync.RWMutex
 	closed          bool
 	versionRegistry VersionRegistry // Version registry for conversion support
+	serializer      Serializer      // Format of stored data; nil means JSON
 }
 
 // VersionRegistry is an interface for version conversion support
@@ -93,7 +105,7 @@ type VersionConverter interface {
 func NewFileBackend(baseDir string) (*FileBackend, error) {
 	// Create base directory if it doesn't exist
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create base directory %s: %w", baseDir, err)
+		return nil, ClassifyError(fmt.Errorf("failed to create base directory %s: %w", baseDir, err))
 	}
 
 	backend := &FileBackend{
@@ -113,10 +125,19 @@ func (f *FileBackend) resourceTypeToDir(resourceType string) string {
 	return dir
 }
 
-// getFilePath returns the file path for a specific resource
-func (f *FileBackend) getFilePath(resourceType, uid string) string {
-	dir := f.resourceTypeToDir(resourceType)
-	return filepath.Join(f.baseDir, dir, uid+".json")
+// getFilePath returns the file path for a specific resource.
+// The UID is validated and the resulting path must stay inside the type directory.
+func (f *FileBackend) getFilePath(resourceType, uid string) (string, error) {
+	if err := ValidateUID(uid); err != nil {
+		return "", err
+	}
+
+	dirPath := f.getDirPath(resourceType)
+	filePath := filepath.Join(dirPath, uid+".json")
+	if filepath.Dir(filePath) != filepath.Clean(dirPath) {
+		return "", fmt.Errorf("%w: uid %q resolves outside storage directory", ErrInvalidData, uid)
+	}
+	return filePath, nil
 }
 
 // getDirPath returns the directory path for a resource type
@@ -156,7 +177,7 @@ func (f *FileBackend) LoadAll(ctx context.Context, resourceType string) ([]json.
 		if os.IsNotExist(err) {
 			return []json.RawMessage{}, nil // Empty slice, not an error
 		}
-		return nil, fmt.Errorf("failed to read directory %s: %w", dirPath, err)
+		return nil, ClassifyError(fmt.Errorf("failed to read directory %s: %w", dirPath, err))
 	}
 
 	var resources []json.RawMessage
@@ -179,8 +200,8 @@ func (f *FileBackend) LoadAll(ctx context.Context, resourceType string) ([]json.
 			continue
 		}
 
-		// Validate JSON format
-		if !json.Valid(data) {
+		// Validate format
+		if !f.codec().Valid(data) {
 			// Log warning but continue with other files
 			continue
 		}
@@ -207,24 +228,33 @@ func (f *FileBackend) Load(ctx context.Context, resourceType, uid string) (json.
 	default:
 	}
 
-	filePath := f.getFilePath(resourceType, uid)
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return nil, err
+	}
 
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, ErrNotFound
+			return nil, NewStorageError("load", resourceType, uid, ErrNotFound)
 		}
-		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+		return nil, ClassifyError(fmt.Errorf("failed to read file %s: %w", filePath, err))
 	}
 
-	// Validate JSON format
-	if !json.Valid(data) {
-		return nil, fmt.Errorf("invalid JSON in file %s: %w", filePath, ErrInvalidData)
+	// Validate format
+	if !f.codec().Valid(data) {
+		return nil, NewStorageError("load", resourceType, uid, fmt.Errorf("invalid data in file %s: %w", filePath, ErrInvalidData))
 	}
 
 	return json.RawMessage(data), nil
 }
 
+// LoadMany implements StorageBackend.LoadMany
+func (f *FileBackend) LoadMany(ctx context.Context, resourceType string, uids []string) (map[string]json.RawMessage, error) {
+	// Each file is read independently, so there is nothing to batch
+	return DefaultLoadMany(ctx, f, resourceType, uids)
+}
+
 // Save implements StorageBackend.Save
 func (f *FileBackend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
 	f.mu.Lock()
@@ -234,6 +264,11 @@ func (f *FileBackend) Save(ctx context.Context, resourceType, uid string, data j
 		return err
 	}
 
+	return f.saveLocked(ctx, resourceType, uid, data)
+}
+
+// saveLocked writes a resource file. The caller must hold the write lock.
+func (f *FileBackend) saveLocked(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
 	// Check if context is cancelled
 	select {
 	case <-ctx.Done():
@@ -241,35 +276,110 @@ func (f *FileBackend) Save(ctx context.Context, resourceType, uid string, data j
 	default:
 	}
 
-	// Validate JSON format
-	if !json.Valid(data) {
-		return fmt.Errorf("invalid JSON data: %w", ErrInvalidData)
+	// Validate format
+	if !f.codec().Valid(data) {
+		return NewStorageError("save", resourceType, uid, fmt.Errorf("invalid data: %w", ErrInvalidData))
 	}
 
-	filePath := f.getFilePath(resourceType, uid)
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return err
+	}
 
 	// Ensure directory exists
 	dirPath := filepath.Dir(filePath)
 	if err := os.MkdirAll(dirPath, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+		return ClassifyError(fmt.Errorf("failed to create directory %s: %w", dirPath, err))
 	}
 
 	// Use atomic write: write to temp file, then rename
 	tempPath := filePath + ".tmp"
 
 	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file %s: %w", tempPath, err)
+		return ClassifyError(fmt.Errorf("failed to write temp file %s: %w", tempPath, err))
 	}
 
 	if err := os.Rename(tempPath, filePath); err != nil {
 		// Clean up temp file on error
 		_ = os.Remove(tempPath)
-		return fmt.Errorf("failed to rename temp file %s to %s: %w", tempPath, filePath, err)
+		return ClassifyError(fmt.Errorf("failed to rename temp file %s to %s: %w", tempPath, filePath, err))
 	}
 
 	return nil
 }
 
+// SaveIfVersion implements ConditionalSaver.SaveIfVersion. The stored
+// version is read from the stored resource under the backend's write lock,
+// so concurrent conditional saves through the same backend are serialized.
+func (f *FileBackend) SaveIfVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, expectedVersion string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.checkClosed(); err != nil {
+		return err
+	}
+
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return err
+	}
+
+	storedVersion := ""
+	stored, err := os.ReadFile(filePath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return ClassifyError(fmt.Errorf("failed to read file %s: %w", filePath, err))
+	default:
+		if storedVersion, err = resourceVersionOf(f.codec(), stored); err != nil {
+			return err
+		}
+	}
+
+	if storedVersion != expectedVersion {
+		return conflictError(resourceType, uid, expectedVersion, storedVersion)
+	}
+
+	return f.saveLocked(ctx, resourceType, uid, data)
+}
+
+// CompareAndSwap implements StorageBackend.CompareAndSwap. The stored file is
+// compared and replaced under the backend's write lock, so the swap is atomic
+// for all writers sharing this FileBackend, but not across processes using
+// the same directory.
+func (f *FileBackend) CompareAndSwap(ctx context.Context, resourceType, uid string, expected, data json.RawMessage) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.checkClosed(); err != nil {
+		return false, err
+	}
+
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return false, err
+	}
+
+	stored, err := os.ReadFile(filePath)
+	switch {
+	case os.IsNotExist(err):
+		if expected != nil {
+			return false, nil
+		}
+	case err != nil:
+		return false, ClassifyError(fmt.Errorf("failed to read file %s: %w", filePath, err))
+	default:
+		if expected == nil || !bytes.Equal(stored, expected) {
+			return false, nil
+		}
+	}
+
+	if err := f.saveLocked(ctx, resourceType, uid, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Delete implements StorageBackend.Delete
 func (f *FileBackend) Delete(ctx context.Context, resourceType, uid string) error {
 	f.mu.Lock()
@@ -286,18 +396,21 @@ func (f *FileBackend) Delete(ctx context.Context, resourceType, uid string) erro
 	default:
 	}
 
-	filePath := f.getFilePath(resourceType, uid)
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return err
+	}
 
 	// Check if file exists
 	if _, err := os.Stat(filePath); err != nil {
 		if os.IsNotExist(err) {
-			return ErrNotFound
+			return NewStorageError("delete", resourceType, uid, ErrNotFound)
 		}
-		return fmt.Errorf("failed to stat file %s: %w", filePath, err)
+		return ClassifyError(fmt.Errorf("failed to stat file %s: %w", filePath, err))
 	}
 
 	if err := os.Remove(filePath); err != nil {
-		return fmt.Errorf("failed to delete file %s: %w", filePath, err)
+		return ClassifyError(fmt.Errorf("failed to delete file %s: %w", filePath, err))
 	}
 
 	return nil
@@ -319,19 +432,54 @@ func (f *FileBackend) Exists(ctx context.Context, resourceType, uid string) (boo
 	default:
 	}
 
-	filePath := f.getFilePath(resourceType, uid)
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return false, err
+	}
 
-	_, err := os.Stat(filePath)
+	_, err = os.Stat(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return false, nil
 		}
-		return false, fmt.Errorf("failed to stat file %s: %w", filePath, err)
+		return false, ClassifyError(fmt.Errorf("failed to stat file %s: %w", filePath, err))
 	}
 
 	return true, nil
 }
 
+// Stat implements StatBackend.Stat using the resource file's size and mtime
+func (f *FileBackend) Stat(ctx context.Context, resourceType, uid string) (StatInfo, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if err := f.checkClosed(); err != nil {
+		return StatInfo{}, err
+	}
+
+	// Check if context is cancelled
+	select {
+	case <-ctx.Done():
+		return StatInfo{}, ctx.Err()
+	default:
+	}
+
+	filePath, err := f.getFilePath(resourceType, uid)
+	if err != nil {
+		return StatInfo{}, err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StatInfo{}, NewStorageError("stat", resourceType, uid, ErrNotFound)
+		}
+		return StatInfo{}, ClassifyError(fmt.Errorf("failed to stat file %s: %w", filePath, err))
+	}
+
+	return StatInfo{Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
 // List implements StorageBackend.List
 func (f *FileBackend) List(ctx context.Context, resourceType string) ([]string, error) {
 	f.mu.RLock()
@@ -355,7 +503,7 @@ func (f *FileBackend) List(ctx context.Context, resourceType string) ([]string,
 		if os.IsNotExist(err) {
 			return []string{}, nil // Empty slice, not an error
 		}
-		return nil, fmt.Errorf("failed to read directory %s: %w", dirPath, err)
+		return nil, ClassifyError(fmt.Errorf("failed to read directory %s: %w", dirPath, err))
 	}
 
 	var uids []string
@@ -379,6 +527,74 @@ func (f *FileBackend) List(ctx context.Context, resourceType string) ([]string,
 	return uids, nil
 }
 
+// Count implements StorageBackend.Count by counting the resource files in
+// the type's directory, without reading them. Corrupt files that LoadAll
+// would skip are still counted.
+func (f *FileBackend) Count(ctx context.Context, resourceType string) (int, error) {
+	uids, err := f.List(ctx, resourceType)
+	if err != nil {
+		return 0, err
+	}
+	return len(uids), nil
+}
+
+// Snapshot implements SnapshotBackend.Snapshot. The directory is listed
+// under the backend's lock and each file is read when the iterator reaches
+// it; files deleted since are skipped, as are corrupt files, as in LoadAll.
+func (f *FileBackend) Snapshot(ctx context.Context, resourceType string) (Iterator, error) {
+	uids, err := f.List(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	// Directory order is filename order, where "a-b.json" sorts before "a.json"
+	sort.Strings(uids)
+	return &fileIterator{ctx: ctx, backend: f, resourceType: resourceType, uids: uids}, nil
+}
+
+// fileIterator reads the files of a FileBackend snapshot one at a time
+type fileIterator struct {
+	ctx          context.Context
+	backend      *FileBackend
+	resourceType string
+	uids         []string
+	current      json.RawMessage
+	err          error
+}
+
+func (it *fileIterator) Next() bool {
+	it.current = nil
+	for it.err == nil && len(it.uids) > 0 {
+		uid := it.uids[0]
+		it.uids = it.uids[1:]
+
+		data, err := it.backend.Load(it.ctx, it.resourceType, uid)
+		switch {
+		case err == nil:
+			it.current = data
+			return true
+		case errors.Is(err, ErrNotFound), errors.Is(err, ErrInvalidData):
+			continue
+		default:
+			it.err = err
+		}
+	}
+	return false
+}
+
+func (it *fileIterator) Resource() json.RawMessage {
+	return it.current
+}
+
+func (it *fileIterator) Err() error {
+	return it.err
+}
+
+func (it *fileIterator) Close() error {
+	it.uids = nil
+	it.current = nil
+	return nil
+}
+
 // Close implements StorageBackend.Close
 func (f *FileBackend) Close() error {
 	f.mu.Lock()
@@ -388,6 +604,26 @@ func (f *FileBackend) Close() error {
 	return nil
 }
 
+// SetSerializer sets the format of stored data (JSON if never called). Set
+// it before storing anything: data already stored in another format becomes
+// unreadable. ResourceStorage instances writing to this backend must use the
+// same serializer.
+//
+// Example:
+//
+//	backend, _ := storage.NewFileBackend("./data")
+//	backend.SetSerializer(cborSerializer{})
+func (f *FileBackend) SetSerializer(serializer Serializer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.serializer = serializer
+}
+
+// codec returns the backend's serializer
+func (f *FileBackend) codec() Serializer {
+	return serializerOrDefault(f.serializer)
+}
+
 // SetVersionRegistry sets the version registry for version-aware operations.
 // This must be called before using version-aware methods.
 func (f *FileBackend) SetVersionRegistry(registry VersionRegistry) {
@@ -447,7 +683,7 @@ func (f *FileBackend) LoadWithVersion(ctx context.Context, resourceType, uid, ve
 
 	// Unmarshal into default version
 	defaultResource := defaultTypeInfo.Constructor()
-	if err := json.Unmarshal(rawData, defaultResource); err != nil {
+	if err := f.codec().Unmarshal(rawData, defaultResource); err != nil {
 		return nil, "", fmt.Errorf("failed to unmarshal resource: %w", err)
 	}
 
@@ -459,7 +695,7 @@ func (f *FileBackend) LoadWithVersion(ctx context.Context, resourceType, uid, ve
 		}
 
 		// Marshal the converted resource
-		convertedData, err := json.Marshal(converted)
+		convertedData, err := f.codec().Marshal(converted)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to marshal converted resource: %w", err)
 		}
@@ -535,7 +771,7 @@ func (f *FileBackend) LoadAllWithVersion(ctx context.Context, resourceType, vers
 
 		// Unmarshal into default version
 		defaultResource := defaultTypeInfo.Constructor()
-		if err := json.Unmarshal(rawData, defaultResource); err != nil {
+		if err := f.codec().Unmarshal(rawData, defaultResource); err != nil {
 			// Skip corrupted resources
 			continue
 		}
@@ -548,7 +784,7 @@ func (f *FileBackend) LoadAllWithVersion(ctx context.Context, resourceType, vers
 		}
 
 		// Marshal the converted resource
-		convertedData, err := json.Marshal(converted)
+		convertedData, err := f.codec().Marshal(converted)
 		if err != nil {
 			// Skip resources that fail marshaling
 			continue
@@ -584,12 +820,12 @@ func (f *FileBackend) SaveWithVersion(ctx context.Context, resourceType, uid str
 	defaultVersion := f.versionRegistry.GetDefaultVersion(resourceType)
 	if defaultVersion == "" {
 		// No versioning configured, save as-is
-		return f.Save(ctx, resourceType, uid, data)
+		return f.saveLocked(ctx, resourceType, uid, data)
 	}
 
 	// If data is already in default version, save as-is
 	if version == "" || version == defaultVersion {
-		return f.Save(ctx, resourceType, uid, data)
+		return f.saveLocked(ctx, resourceType, uid, data)
 	}
 
 	// Need to convert to storage version
@@ -604,7 +840,7 @@ func (f *FileBackend) SaveWithVersion(ctx context.Context, resourceType, uid str
 
 	// Unmarshal into provided version
 	resource := typeInfo.Constructor()
-	if err := json.Unmarshal(data, resource); err != nil {
+	if err := f.codec().Unmarshal(data, resource); err != nil {
 		return fmt.Errorf("failed to unmarshal resource: %w", err)
 	}
 
@@ -615,11 +851,11 @@ func (f *FileBackend) SaveWithVersion(ctx context.Context, resourceType, uid str
 	}
 
 	// Marshal to storage format
-	storageData, err := json.Marshal(converted)
+	storageData, err := f.codec().Marshal(converted)
 	if err != nil {
 		return fmt.Errorf("failed to marshal converted resource: %w", err)
 	}
 
 	// Save in storage version
-	return f.Save(ctx, resourceType, uid, json.RawMessage(storageData))
+	return f.saveLocked(ctx, resourceType, uid, json.RawMessage(storageData))
 }
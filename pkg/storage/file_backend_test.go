@@ -0,0 +1,277 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestFileBackend(t *testing.T) (*FileBackend, string) {
+	t.Helper()
+
+	root := t.TempDir()
+	baseDir := filepath.Join(root, "data")
+	backend, err := NewFileBackend(baseDir)
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend, root
+}
+
+func TestFileBackend_SaveLoad(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+	data := json.RawMessage(`{"name":"test"}`)
+
+	if err := backend.Save(ctx, "Device", "dev-1", data); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := backend.Load(ctx, "Device", "dev-1")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if string(loaded) != string(data) {
+		t.Errorf("Expected %s, got %s", data, loaded)
+	}
+
+	if _, err := backend.Load(ctx, "Device", "dev-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestValidateUID(t *testing.T) {
+	valid := []string{"dev-1a2b3c4d", "node_01", "rack.a1", "x"}
+	for _, uid := range valid {
+		if err := ValidateUID(uid); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", uid, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		".",
+		"..",
+		"...",
+		"../escape",
+		"a/b",
+		`a\\b`,
+		"dev\x00-1",
+		"dev\n1",
+		strings.Repeat("a", MaxUIDLength+1),
+	}
+	for _, uid := range invalid {
+		if err := ValidateUID(uid); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Expected %q to be rejected with ErrInvalidData, got %v", uid, err)
+		}
+	}
+}
+
+func TestSetUIDValidator(t *testing.T) {
+	t.Cleanup(func() { SetUIDValidator(nil) })
+
+	SetUIDValidator(func(uid string) error {
+		if !strings.HasPrefix(uid, "dev-") {
+			return ErrInvalidData
+		}
+		return DefaultUIDValidator(uid)
+	})
+
+	if err := ValidateUID("node-1"); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected custom validator to reject node-1, got %v", err)
+	}
+	if err := ValidateUID("dev-1"); err != nil {
+		t.Errorf("Expected custom validator to accept dev-1, got %v", err)
+	}
+
+	SetUIDValidator(nil)
+	if err := ValidateUID("node-1"); err != nil {
+		t.Errorf("Expected default validator to accept node-1, got %v", err)
+	}
+}
+
+func TestFileBackend_RejectsPathTraversal(t *testing.T) {
+	backend, root := newTestFileBackend(t)
+	ctx := context.Background()
+	data := json.RawMessage(`{"name":"evil"}`)
+
+	attempts := []string{
+		"../escape",
+		"../../escape",
+		"..",
+		"sub/../../escape",
+		"/etc/passwd",
+		`..\escape`,
+	}
+
+	for _, uid := range attempts {
+		if err := backend.Save(ctx, "Device", uid, data); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Save(%q): expected ErrInvalidData, got %v", uid, err)
+		}
+		if _, err := backend.Load(ctx, "Device", uid); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Load(%q): expected ErrInvalidData, got %v", uid, err)
+		}
+		if _, err := backend.Exists(ctx, "Device", uid); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Exists(%q): expected ErrInvalidData, got %v", uid, err)
+		}
+		if err := backend.Delete(ctx, "Device", uid); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Delete(%q): expected ErrInvalidData, got %v", uid, err)
+		}
+	}
+
+	// Nothing should have been written outside the base directory
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatalf("Failed to read root: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "data" {
+			t.Errorf("Unexpected file outside base directory: %s", entry.Name())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape.json")); !os.IsNotExist(err) {
+		t.Error("Traversal attempt created a file outside the base directory")
+	}
+}
+
+type testResource struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
+func (r *testResource) GetUID() string { return r.UID }
+
+func TestFileBackend_LoadMany(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+
+	for _, uid := range []string{"dev-1", "dev-2"} {
+		data := json.RawMessage(`{"uid":"` + uid + `","name":"` + uid + `"}`)
+		if err := backend.Save(ctx, "Device", uid, data); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	loaded, err := backend.LoadMany(ctx, "Device", []string{"dev-1", "dev-missing", "dev-2", "dev-1"})
+	if err != nil {
+		t.Fatalf("LoadMany failed: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("Expected 2 resources, got %d", len(loaded))
+	}
+	if _, ok := loaded["dev-missing"]; ok {
+		t.Error("Missing UID should be absent from the result")
+	}
+
+	if _, err := backend.LoadMany(ctx, "Device", []string{"../escape"}); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for invalid UID, got %v", err)
+	}
+
+	devices := NewResourceStorage[*testResource](backend, "Device")
+	typed, err := devices.LoadMany(ctx, []string{"dev-2", "dev-missing"})
+	if err != nil {
+		t.Fatalf("ResourceStorage.LoadMany failed: %v", err)
+	}
+	if len(typed) != 1 || typed["dev-2"].Name != "dev-2" {
+		t.Errorf("Unexpected typed result: %+v", typed)
+	}
+}
+
+func TestFileBackend_CompareAndSwap(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+	v1 := json.RawMessage(`{"holder":"a"}`)
+	v2 := json.RawMessage(`{"holder":"b"}`)
+
+	// nil expected only creates
+	if swapped, err := backend.CompareAndSwap(ctx, "Lease", "l-1", nil, v1); err != nil || !swapped {
+		t.Fatalf("create: swapped=%v err=%v, want true", swapped, err)
+	}
+	if swapped, err := backend.CompareAndSwap(ctx, "Lease", "l-1", nil, v2); err != nil || swapped {
+		t.Fatalf("create over existing: swapped=%v err=%v, want false", swapped, err)
+	}
+
+	// Stale expected data is not replaced
+	if swapped, err := backend.CompareAndSwap(ctx, "Lease", "l-1", v2, v2); err != nil || swapped {
+		t.Fatalf("stale expected: swapped=%v err=%v, want false", swapped, err)
+	}
+	if swapped, err := backend.CompareAndSwap(ctx, "Lease", "l-1", v1, v2); err != nil || !swapped {
+		t.Fatalf("current expected: swapped=%v err=%v, want true", swapped, err)
+	}
+	if loaded, _ := backend.Load(ctx, "Lease", "l-1"); string(loaded) != string(v2) {
+		t.Errorf("stored %s, want %s", loaded, v2)
+	}
+
+	if swapped, err := backend.CompareAndSwap(ctx, "Lease", "l-missing", v1, v2); err != nil || swapped {
+		t.Errorf("missing resource: swapped=%v err=%v, want false", swapped, err)
+	}
+	if _, err := backend.CompareAndSwap(ctx, "Lease", "l-1", v2, json.RawMessage(`{`)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for invalid JSON, got %v", err)
+	}
+}
+
+func TestFileBackend_CompareAndSwapConcurrent(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+	initial := json.RawMessage(`{"count":0}`)
+	if err := backend.Save(ctx, "Counter", "c-1", initial); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	var swaps atomic.Int32
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			swapped, err := backend.CompareAndSwap(ctx, "Counter", "c-1", initial, json.RawMessage(`{"count":1}`))
+			if err != nil {
+				t.Errorf("CompareAndSwap failed: %v", err)
+			}
+			if swapped {
+				swaps.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if swaps.Load() != 1 {
+		t.Errorf("Expected exactly one swap, got %d", swaps.Load())
+	}
+}
+
+func TestFileBackend_Count(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+
+	if n, err := backend.Count(ctx, "Device"); err != nil || n != 0 {
+		t.Fatalf("Count of missing type = %d, %v; want 0, nil", n, err)
+	}
+	for _, uid := range []string{"dev-1", "dev-2", "dev-3"} {
+		if err := backend.Save(ctx, "Device", uid, json.RawMessage(`{"uid":"`+uid+`"}`)); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if err := backend.Save(ctx, "Rack", "rack-1", json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := backend.Delete(ctx, "Device", "dev-2"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if n, err := backend.Count(ctx, "Device"); err != nil || n != 2 {
+		t.Errorf("Count = %d, %v; want 2, nil", n, err)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+// DefaultGarbageCollectorInterval is the scan interval used when
+// NewGarbageCollector is given a non-positive interval.
+const DefaultGarbageCollectorInterval = 5 * time.Minute
+
+// GarbageCollector periodically deletes orphaned resources: resources with
+// owner references whose owners no longer exist.
+//
+// Owner references are fields tagged ref:"Kind,owner" (see
+// validation.Reference). A resource is an orphan once none of the owners it
+// names exist; resources without owner references are never collected.
+// Resources with finalizers (see resource.Metadata.AddFinalizer) are left
+// alone until their finalizers are removed. An orphan is loaded and checked
+// again just before it is deleted, so a resource given a new owner or a
+// finalizer during the scan is kept; the backend has no conditional delete,
+// so a change landing between that check and the delete is not seen. Each deletion publishes a
+// "deleted" event with reason "orphaned", so reconcilers and subscribers
+// see it like any other delete.
+//
+// Only the resource types given to NewGarbageCollector are scanned, and
+// each must be registered with resource.RegisterKind (generated servers
+// register every kind) so its owner references can be read. Types are
+// scanned in the order given: list owners before the resources they own
+// and a whole hierarchy is collected in one scan, otherwise one level per
+// scan.
+//
+// Example:
+//
+//	gc := storage.NewGarbageCollector(backend, time.Minute, "Chassis", "Blade", "Node")
+//	gc.Start(ctx)
+//	defer gc.Stop()
+type GarbageCollector struct {
+	backend       StorageBackend
+	serializer    Serializer // Format of stored data; nil means the backend's
+	interval      time.Duration
+	resourceTypes []string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// metadataObject is a resource whose metadata can be read, as every type
+// embedding resource.Resource is
+type metadataObject interface {
+	GetMetadata() *resource.Metadata
+}
+
+// NewGarbageCollector creates a garbage collector that scans the given
+// resource types every interval. A non-positive interval uses
+// DefaultGarbageCollectorInterval.
+func NewGarbageCollector(backend StorageBackend, interval time.Duration, resourceTypes ...string) *GarbageCollector {
+	if interval <= 0 {
+		interval = DefaultGarbageCollectorInterval
+	}
+	return &GarbageCollector{
+		backend:       backend,
+		interval:      interval,
+		resourceTypes: append([]string(nil), resourceTypes...),
+	}
+}
+
+// SetSerializer sets the format the garbage collector decodes stored data
+// with, if the backend stores something other than JSON and is not itself
+// a FileBackend or MemoryBackend with that serializer. Call it before Start.
+func (gc *GarbageCollector) SetSerializer(serializer Serializer) {
+	gc.serializer = serializer
+}
+
+// ResourceTypes returns the resource types the garbage collector scans.
+func (gc *GarbageCollector) ResourceTypes() []string {
+	return append([]string(nil), gc.resourceTypes...)
+}
+
+// Interval returns the time between scans.
+func (gc *GarbageCollector) Interval() time.Duration {
+	return gc.interval
+}
+
+// Start scans once immediately and then every interval in a background
+// goroutine, until ctx is cancelled or Stop is called. Scan errors are
+// logged and retried on the next scan. Calling Start on a running garbage
+// collector does nothing.
+func (gc *GarbageCollector) Start(ctx context.Context) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	if gc.cancel != nil {
+		return
+	}
+
+	ctx, gc.cancel = context.WithCancel(ctx)
+	gc.done = make(chan struct{})
+
+	go func(done chan struct{}) {
+		defer close(done)
+
+		ticker := time.NewTicker(gc.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := gc.Collect(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Warning: garbage collector scan failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}(gc.done)
+}
+
+// Stop stops a running garbage collector and waits for an in-progress scan
+// to finish.
+func (gc *GarbageCollector) Stop() {
+	gc.mu.Lock()
+	cancel, done := gc.cancel, gc.done
+	gc.cancel, gc.done = nil, nil
+	gc.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Collect performs a single scan, deleting every orphan of the configured
+// types. It returns the number of resources deleted.
+//
+// A failure for one resource type does not stop the scan of the others; all
+// errors are returned together.
+func (gc *GarbageCollector) Collect(ctx context.Context) (int, error) {
+	deleted := 0
+	var errs []error
+
+	for _, resourceType := range gc.resourceTypes {
+		n, err := gc.collectType(ctx, resourceType)
+		deleted += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// collectType deletes the orphans of one type.
+func (gc *GarbageCollector) collectType(ctx context.Context, resourceType string) (int, error) {
+	sample, err := resource.NewOfKind(resourceType)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := sample.(metadataObject); !ok {
+		return 0, fmt.Errorf("%s resources have no metadata: %T does not embed resource.Resource", resourceType, sample)
+	}
+
+	rawResources, err := gc.backend.LoadAll(ctx, resourceType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load %s resources: %w", resourceType, err)
+	}
+
+	// Owners looked up during this scan, by kind/uid
+	ownerExists := make(map[string]bool)
+
+	deleted := 0
+	var errs []error
+	serializer := gc.codec()
+	for _, raw := range rawResources {
+		obj, _ := resource.NewOfKind(resourceType)
+		if err := serializer.Unmarshal(raw, obj); err != nil {
+			errs = append(errs, fmt.Errorf("failed to decode %s resource: %w", resourceType, err))
+			continue
+		}
+		meta := obj.(metadataObject).GetMetadata()
+		if meta.UID == "" || len(meta.Finalizers) > 0 {
+			continue
+		}
+
+		orphaned, owners, err := gc.orphaned(ctx, obj, ownerExists)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check owners of %s %s: %w", resourceType, meta.UID, err))
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+
+		// The resource may have been given a new owner or a finalizer since
+		// LoadAll; check the stored copy again just before deleting it
+		orphaned, owners, err = gc.stillOrphaned(ctx, resourceType, meta.UID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check owners of %s %s: %w", resourceType, meta.UID, err))
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+
+		if err := gc.backend.Delete(ctx, resourceType, meta.UID); err != nil {
+			// Another replica may have collected it first
+			if !errors.Is(err, ErrNotFound) {
+				errs = append(errs, fmt.Errorf("failed to delete orphaned %s %s: %w", resourceType, meta.UID, err))
+			}
+			continue
+		}
+		deleted++
+		ownerExists[resourceType+"/"+meta.UID] = false
+
+		metadata := map[string]interface{}{
+			"reason": "orphaned",
+			"owners": owners,
+		}
+		if err := events.PublishResourceDeleted(ctx, resourceType, meta.UID, meta.Name, metadata); err != nil {
+			// Events are non-critical; the resource is already gone
+			log.Printf("Warning: failed to publish deleted event for orphaned %s %s: %v", resourceType, meta.UID, err)
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// stillOrphaned loads the stored resource again and reports whether it is
+// still an orphan without finalizers, looking up its owners afresh. A
+// resource deleted in the meantime is not an orphan.
+func (gc *GarbageCollector) stillOrphaned(ctx context.Context, resourceType, uid string) (bool, []string, error) {
+	raw, err := gc.backend.Load(ctx, resourceType, uid)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	obj, _ := resource.NewOfKind(resourceType)
+	if err := gc.codec().Unmarshal(raw, obj); err != nil {
+		return false, nil, err
+	}
+	if len(obj.(metadataObject).GetMetadata().Finalizers) > 0 {
+		return false, nil, nil
+	}
+	return gc.orphaned(ctx, obj, make(map[string]bool))
+}
+
+// orphaned reports whether obj has owner references and none of its owners
+// exist. It also returns the owners, as kind/uid. ownerExists caches
+// lookups across calls.
+func (gc *GarbageCollector) orphaned(ctx context.Context, obj interface{}, ownerExists map[string]bool) (bool, []string, error) {
+	var owners []string
+	for _, ref := range validation.FindReferences(obj) {
+		if !ref.Owner {
+			continue
+		}
+		key := ref.Kind + "/" + ref.UID
+		owners = append(owners, key)
+
+		exists, checked := ownerExists[key]
+		if !checked {
+			var err error
+			if exists, err = gc.backend.Exists(ctx, ref.Kind, ref.UID); err != nil {
+				return false, nil, err
+			}
+			ownerExists[key] = exists
+		}
+		if exists {
+			return false, owners, nil
+		}
+	}
+	return len(owners) > 0, owners, nil
+}
+
+// codec returns the serializer stored data is decoded with
+func (gc *GarbageCollector) codec() Serializer {
+	if gc.serializer != nil {
+		return gc.serializer
+	}
+	return serializerOf(gc.backend)
+}
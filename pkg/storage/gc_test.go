@@ -0,0 +1,185 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+type gcChassis struct {
+	resource.Resource
+}
+
+type gcBladeSpec struct {
+	ChassisUID string `json:"chassisUID,omitempty" ref:"Chassis,owner"`
+	PeerUID    string `json:"peerUID,omitempty" ref:"GCBlade"`
+}
+
+type gcBlade struct {
+	resource.Resource
+	Spec gcBladeSpec `json:"spec"`
+}
+
+func init() {
+	resource.RegisterKind("GCChassis", func() interface{} { return &gcChassis{} })
+	resource.RegisterKind("GCBlade", func() interface{} { return &gcBlade{} })
+}
+
+func saveBlade(t *testing.T, backend StorageBackend, uid string, spec gcBladeSpec, finalizers ...string) {
+	t.Helper()
+
+	blade := gcBlade{Spec: spec}
+	blade.Metadata.Initialize(uid, uid)
+	blade.Metadata.Finalizers = finalizers
+	data, err := json.Marshal(blade)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(context.Background(), "GCBlade", uid, data); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+}
+
+func TestGarbageCollector_CollectDeletesOrphans(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+
+	if err := backend.Save(ctx, "Chassis", "chs-1", []byte(`{"metadata":{"uid":"chs-1"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	saveBlade(t, backend, "blade-owned", gcBladeSpec{ChassisUID: "chs-1"})
+	saveBlade(t, backend, "blade-orphan", gcBladeSpec{ChassisUID: "chs-gone"})
+	saveBlade(t, backend, "blade-finalized", gcBladeSpec{ChassisUID: "chs-gone"}, "dhcp")
+	saveBlade(t, backend, "blade-unowned", gcBladeSpec{})
+	// A missing non-owner reference does not make a resource an orphan
+	saveBlade(t, backend, "blade-loose", gcBladeSpec{PeerUID: "blade-gone"})
+
+	gc := NewGarbageCollector(backend, time.Minute, "GCBlade")
+	deleted, err := gc.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted, got %d", deleted)
+	}
+
+	for uid, wantExists := range map[string]bool{
+		"blade-owned":     true,
+		"blade-orphan":    false,
+		"blade-finalized": true,
+		"blade-unowned":   true,
+		"blade-loose":     true,
+	} {
+		exists, err := backend.Exists(ctx, "GCBlade", uid)
+		if err != nil {
+			t.Fatalf("Exists failed: %v", err)
+		}
+		if exists != wantExists {
+			t.Errorf("%s: exists = %v, want %v", uid, exists, wantExists)
+		}
+	}
+}
+
+// staleListBackend serves LoadAll from a snapshot, as if the resources
+// changed while a scan was running
+type staleListBackend struct {
+	StorageBackend
+	snapshot []json.RawMessage
+}
+
+func (b *staleListBackend) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	return b.snapshot, nil
+}
+
+func TestGarbageCollector_RechecksBeforeDelete(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+
+	if err := backend.Save(ctx, "Chassis", "chs-1", []byte(`{"metadata":{"uid":"chs-1"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	saveBlade(t, backend, "blade-adopted", gcBladeSpec{ChassisUID: "chs-gone"})
+	saveBlade(t, backend, "blade-finalized", gcBladeSpec{ChassisUID: "chs-gone"})
+	saveBlade(t, backend, "blade-orphan", gcBladeSpec{ChassisUID: "chs-gone"})
+	snapshot, err := backend.LoadAll(ctx, "GCBlade")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changed after the scan listed them
+	saveBlade(t, backend, "blade-adopted", gcBladeSpec{ChassisUID: "chs-1"})
+	saveBlade(t, backend, "blade-finalized", gcBladeSpec{ChassisUID: "chs-gone"}, "dhcp")
+
+	gc := NewGarbageCollector(&staleListBackend{StorageBackend: backend, snapshot: snapshot}, time.Minute, "GCBlade")
+	deleted, err := gc.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted, got %d", deleted)
+	}
+	for uid, wantExists := range map[string]bool{
+		"blade-adopted":   true,
+		"blade-finalized": true,
+		"blade-orphan":    false,
+	} {
+		if exists, _ := backend.Exists(ctx, "GCBlade", uid); exists != wantExists {
+			t.Errorf("%s: exists = %v, want %v", uid, exists, wantExists)
+		}
+	}
+}
+
+func TestGarbageCollector_UnregisteredKind(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+
+	gc := NewGarbageCollector(backend, time.Minute, "Unregistered", "GCChassis")
+	if _, err := gc.Collect(context.Background()); err == nil {
+		t.Error("Expected an error for an unregistered kind")
+	}
+}
+
+func TestGarbageCollector_StartStop(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	gc := NewGarbageCollector(backend, 10*time.Millisecond, "GCBlade")
+
+	gc.Start(context.Background())
+	gc.Start(context.Background()) // no-op while running
+	defer gc.Stop()
+
+	saveBlade(t, backend, "blade-orphan", gcBladeSpec{ChassisUID: "chs-gone"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		exists, err := backend.Exists(context.Background(), "GCBlade", "blade-orphan")
+		if err != nil {
+			t.Fatalf("Exists failed: %v", err)
+		}
+		if !exists {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Orphan not collected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	gc.Stop()
+	gc.Stop() // safe to call twice
+}
+
+func TestNewGarbageCollector_DefaultInterval(t *testing.T) {
+	gc := NewGarbageCollector(nil, 0, "GCBlade")
+	if gc.Interval() != DefaultGarbageCollectorInterval {
+		t.Errorf("Expected default interval %v, got %v", DefaultGarbageCollectorInterval, gc.Interval())
+	}
+	if types := gc.ResourceTypes(); len(types) != 1 || types[0] != "GCBlade" {
+		t.Errorf("Unexpected resource types: %v", types)
+	}
+}
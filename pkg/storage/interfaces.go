@@ -39,12 +39,22 @@
 //	- ErrNotFound: Resource doesn't exist
 //	- ErrAlreadyExists: Resource already exists (for Create operations)
 //	- ErrInvalidData: Data validation failed
+//	- ErrConflict: Resource was modified concurrently (optimistic locking)
 //	- Backend-specific errors (e.g., file permissions, network issues)
+//
+//	Backends return these sentinels inside a StorageError recording the
+//	operation, resource type and UID, so use errors.Is to test for them and
+//	errors.As to recover the resource.
+//
+//	Backends wrap failures that may succeed on retry (a dropped connection,
+//	a lock timeout, a busy filesystem) in TransientError via ClassifyError.
+//	Use IsTransient to retry only those; the errors above are permanent.
 package storage
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -53,6 +63,7 @@ var (
 	ErrNotFound      = fmt.Errorf("resource not found")
 	ErrAlreadyExists = fmt.Errorf("resource already exists")
 	ErrInvalidData   = fmt.Errorf("invalid data")
+	ErrConflict      = fmt.Errorf("resource version conflict")
 )
 
 // StorageBackend defines the core storage operations that any storage implementation must provide.
@@ -69,6 +80,7 @@ var (
 //   - Atomic operations: Save/Delete operations should be atomic where possible
 //   - Consistent: Operations should be consistent across multiple calls
 //   - Resilient: Should handle and recover from transient failures
+//   - Validated: UIDs must be checked with ValidateUID before use
 //
 // Resource Identification:
 //
@@ -124,6 +136,33 @@ type StorageBackend interface {
 	//   }
 	Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error)
 
+	// LoadMany retrieves several resources by UID in a single call.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeouts
+	//   - resourceType: Type name (e.g., "User", "Product", "Order")
+	//   - uids: Unique identifiers of the resources to load
+	//
+	// Returns:
+	//   - map[string]json.RawMessage: Serialized resources keyed by UID
+	//   - error: Any error other than a missing resource
+	//
+	// Behavior:
+	//   - Missing UIDs are absent from the map (not an error), so callers
+	//     can detect dangling references
+	//   - Duplicate UIDs are loaded once
+	//   - Backends that cannot batch may delegate to DefaultLoadMany
+	//   - Respects context cancellation
+	//
+	// Example:
+	//   rawNodes, err := backend.LoadMany(ctx, "Node", rack.Spec.NodeUIDs)
+	//   for _, uid := range rack.Spec.NodeUIDs {
+	//       if _, ok := rawNodes[uid]; !ok {
+	//           // Dangling reference
+	//       }
+	//   }
+	LoadMany(ctx context.Context, resourceType string, uids []string) (map[string]json.RawMessage, error)
+
 	// Save stores a resource, creating or updating as needed.
 	//
 	// Parameters:
@@ -147,6 +186,38 @@ type StorageBackend interface {
 	//   err := backend.Save(ctx, "User", user.GetUID(), data)
 	Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error
 
+	// CompareAndSwap atomically stores data if the stored resource is still
+	// expected, for coordination primitives such as leases and counters.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeouts
+	//   - resourceType: Type name (e.g., "User", "Product", "Order")
+	//   - uid: Unique identifier of the resource
+	//   - expected: Stored data as last read by Load, or nil to require
+	//     that the resource does not exist
+	//   - data: Serialized resource data to store
+	//
+	// Returns:
+	//   - bool: true if data was stored, false if the stored resource differed
+	//   - error: Any error that occurred; a mismatch is not an error
+	//
+	// Behavior:
+	//   - Compares the stored bytes with expected; backends that normalize
+	//     JSON (e.g. PostgreSQL JSONB) may compare the documents instead
+	//   - The comparison and write are atomic with respect to other writers
+	//     of the same backend (file backends: within one process; database
+	//     backends: a conditional UPDATE or INSERT)
+	//   - Validates data format before saving
+	//   - Respects context cancellation
+	//
+	// Example:
+	//   current, _ := backend.Load(ctx, "Lease", "controller")
+	//   swapped, err := backend.CompareAndSwap(ctx, "Lease", "controller", current, renewed)
+	//   if err == nil && !swapped {
+	//       // Another writer updated the lease first
+	//   }
+	CompareAndSwap(ctx context.Context, resourceType, uid string, expected, data json.RawMessage) (bool, error)
+
 	// Delete removes a resource by UID.
 	//
 	// Parameters:
@@ -215,6 +286,26 @@ type StorageBackend interface {
 	//   fmt.Printf("Found %d Users\n", len(uids))
 	List(ctx context.Context, resourceType string) ([]string, error)
 
+	// Count returns the number of resources of the specified type.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeouts
+	//   - resourceType: Type name (e.g., "User", "Product", "Order")
+	//
+	// Returns:
+	//   - int: Number of stored resources
+	//   - error: Any error that occurred while counting
+	//
+	// Behavior:
+	//   - Returns 0 if no resources exist (not an error)
+	//   - Does not load resource data; database backends run SELECT COUNT
+	//   - Respects context cancellation
+	//
+	// Example:
+	//   total, err := backend.Count(ctx, "User")
+	//   fmt.Printf("%d Users\n", total)
+	Count(ctx context.Context, resourceType string) (int, error)
+
 	// Close releases any resources held by the backend.
 	//
 	// Returns:
@@ -301,6 +392,37 @@ type StorageBackend interface {
 	SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error
 }
 
+// DefaultLoadMany implements StorageBackend.LoadMany by calling Load for each UID.
+//
+// Backends that cannot batch lookups (such as FileBackend) use this directly.
+// ErrNotFound results are skipped so missing UIDs are simply absent from the map;
+// any other error aborts the load.
+func DefaultLoadMany(ctx context.Context, backend StorageBackend, resourceType string, uids []string) (map[string]json.RawMessage, error) {
+	resources := make(map[string]json.RawMessage, len(uids))
+	for _, uid := range uids {
+		if _, seen := resources[uid]; seen {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		raw, err := backend.Load(ctx, resourceType, uid)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load %s %s: %w", resourceType, uid, err)
+		}
+		resources[uid] = raw
+	}
+
+	return resources, nil
+}
+
 // ResourceStorage provides type-safe storage operations for a specific resource type.
 //
 // This interface wraps StorageBackend to provide type safety and convenience
@@ -351,6 +473,21 @@ type ResourceStorage[T any] interface {
 	//   - Returns ErrInvalidData if unmarshaling fails
 	Load(ctx context.Context, uid string) (T, error)
 
+	// LoadMany retrieves several resources by UID.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeouts
+	//   - uids: Unique identifiers of the resources to load
+	//
+	// Returns:
+	//   - map[string]T: Strongly-typed resources keyed by UID
+	//   - error: Any error other than a missing resource
+	//
+	// Behavior:
+	//   - Missing UIDs are absent from the map
+	//   - Returns ErrInvalidData if any resource fails to unmarshal
+	LoadMany(ctx context.Context, uids []string) (map[string]T, error)
+
 	// Save stores a resource.
 	//
 	// Parameters:
@@ -358,14 +495,40 @@ type ResourceStorage[T any] interface {
 	//   - resource: Strongly-typed resource to save
 	//
 	// Returns:
-	//   - error: Any error that occurred during saving
+	//   - error: ErrConflict if the resource was modified since it was loaded
 	//
 	// Behavior:
 	//   - Marshals resource to JSON
 	//   - Extracts UID from resource
 	//   - Creates or updates as needed
+	//   - For Versioned resources, equivalent to SaveIfUnchanged with the
+	//     resource's own version: a read-modify-write fails with ErrConflict
+	//     if another writer saved in between
 	Save(ctx context.Context, resource T) error
 
+	// SaveIfUnchanged stores a resource only if the stored resource version
+	// equals expectedVersion (compare-and-swap).
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeouts
+	//   - resource: Strongly-typed resource to save; must implement Versioned
+	//   - expectedVersion: Version the stored resource must have ("" if it
+	//     must not exist yet)
+	//
+	// Returns:
+	//   - error: ErrConflict if the stored version differs
+	//
+	// Behavior:
+	//   - On success, sets the resource's version to the new stored version
+	//   - On failure, leaves the resource's version unchanged
+	//
+	// Example:
+	//   err := deviceStorage.SaveIfUnchanged(ctx, device, loadedVersion)
+	//   if errors.Is(err, storage.ErrConflict) {
+	//       // Reload and retry
+	//   }
+	SaveIfUnchanged(ctx context.Context, resource T, expectedVersion string) error
+
 	// Delete removes a resource by UID.
 	//
 	// Parameters:
@@ -457,6 +620,7 @@ type Resource interface {
 type resourceStorage[T Resource] struct {
 	backend      StorageBackend
 	resourceType string
+	serializer   Serializer
 }
 
 // NewResourceStorage creates a new type-safe storage for a specific resource type.
@@ -472,9 +636,30 @@ type resourceStorage[T Resource] struct {
 //
 //	userStorage := NewResourceStorage[*User](backend, "User")
 func NewResourceStorage[T Resource](backend StorageBackend, resourceType string) ResourceStorage[T] {
+	return NewResourceStorageWithSerializer[T](backend, resourceType, JSONSerializer{})
+}
+
+// NewResourceStorageWithSerializer creates a type-safe storage that encodes
+// resources with serializer instead of JSON. The backend must store the same
+// format (see FileBackend.SetSerializer).
+//
+// Parameters:
+//   - backend: The storage backend to use
+//   - resourceType: The name of the resource type (e.g., "User", "Product")
+//   - serializer: The format of stored resources; nil means JSON
+//
+// Returns:
+//   - ResourceStorage[T]: Type-safe storage interface
+//
+// Example:
+//
+//	backend.SetSerializer(cborSerializer{})
+//	userStorage := NewResourceStorageWithSerializer[*User](backend, "User", cborSerializer{})
+func NewResourceStorageWithSerializer[T Resource](backend StorageBackend, resourceType string, serializer Serializer) ResourceStorage[T] {
 	return &resourceStorage[T]{
 		backend:      backend,
 		resourceType: resourceType,
+		serializer:   serializerOrDefault(serializer),
 	}
 }
 
@@ -488,7 +673,7 @@ func (s *resourceStorage[T]) LoadAll(ctx context.Context) ([]T, error) {
 	var resources []T
 	for _, raw := range rawResources {
 		var resource T
-		if err := json.Unmarshal(raw, &resource); err != nil {
+		if err := s.serializer.Unmarshal(raw, &resource); err != nil {
 			// Log warning but continue processing other resources
 			continue
 		}
@@ -508,16 +693,39 @@ func (s *resourceStorage[T]) Load(ctx context.Context, uid string) (T, error) {
 	}
 
 	var resource T
-	if err := json.Unmarshal(raw, &resource); err != nil {
+	if err := s.serializer.Unmarshal(raw, &resource); err != nil {
 		return zero, fmt.Errorf("failed to unmarshal %s %s: %w", s.resourceType, uid, ErrInvalidData)
 	}
 
 	return resource, nil
 }
 
+// LoadMany implements ResourceStorage.LoadMany
+func (s *resourceStorage[T]) LoadMany(ctx context.Context, uids []string) (map[string]T, error) {
+	rawResources, err := s.backend.LoadMany(ctx, s.resourceType, uids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", s.resourceType, err)
+	}
+
+	resources := make(map[string]T, len(rawResources))
+	for uid, raw := range rawResources {
+		var resource T
+		if err := s.serializer.Unmarshal(raw, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s %s: %w", s.resourceType, uid, ErrInvalidData)
+		}
+		resources[uid] = resource
+	}
+
+	return resources, nil
+}
+
 // Save implements ResourceStorage.Save
 func (s *resourceStorage[T]) Save(ctx context.Context, resource T) error {
-	data, err := json.Marshal(resource)
+	if versioned, ok := any(resource).(Versioned); ok {
+		return s.SaveIfUnchanged(ctx, resource, versioned.GetResourceVersion())
+	}
+
+	data, err := s.serializer.Marshal(resource)
 	if err != nil {
 		return fmt.Errorf("failed to marshal %s: %w", s.resourceType, err)
 	}
@@ -534,6 +742,41 @@ func (s *resourceStorage[T]) Save(ctx context.Context, resource T) error {
 	return nil
 }
 
+// SaveIfUnchanged implements ResourceStorage.SaveIfUnchanged
+func (s *resourceStorage[T]) SaveIfUnchanged(ctx context.Context, resource T, expectedVersion string) error {
+	versioned, ok := any(resource).(Versioned)
+	if !ok {
+		return fmt.Errorf("%s does not implement Versioned: %w", s.resourceType, ErrInvalidData)
+	}
+
+	uid := resource.GetUID()
+	if uid == "" {
+		return fmt.Errorf("resource has empty UID: %w", ErrInvalidData)
+	}
+
+	nextVersion, err := nextResourceVersion(expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	// Save with the bumped version; restore the caller's version on failure
+	previousVersion := versioned.GetResourceVersion()
+	versioned.SetResourceVersion(nextVersion)
+
+	data, err := s.serializer.Marshal(resource)
+	if err != nil {
+		versioned.SetResourceVersion(previousVersion)
+		return fmt.Errorf("failed to marshal %s: %w", s.resourceType, err)
+	}
+
+	if err := saveIfVersion(ctx, s.backend, s.serializer, s.resourceType, uid, data, expectedVersion); err != nil {
+		versioned.SetResourceVersion(previousVersion)
+		return fmt.Errorf("failed to save %s %s: %w", s.resourceType, uid, err)
+	}
+
+	return nil
+}
+
 // Delete implements ResourceStorage.Delete
 func (s *resourceStorage[T]) Delete(ctx context.Context, uid string) error {
 	if err := s.backend.Delete(ctx, s.resourceType, uid); err != nil {
@@ -569,7 +812,7 @@ func (s *resourceStorage[T]) LoadWithVersion(ctx context.Context, uid string, ve
 
 	// Unmarshal into interface{} - caller must type assert
 	var resource interface{}
-	if err := json.Unmarshal(rawData, &resource); err != nil {
+	if err := s.serializer.Unmarshal(rawData, &resource); err != nil {
 		return nil, "", fmt.Errorf("failed to unmarshal %s %s: %w", s.resourceType, uid, ErrInvalidData)
 	}
 
@@ -586,7 +829,7 @@ func (s *resourceStorage[T]) LoadAllWithVersion(ctx context.Context, version str
 	var resources []interface{}
 	for _, raw := range rawResources {
 		var resource interface{}
-		if err := json.Unmarshal(raw, &resource); err != nil {
+		if err := s.serializer.Unmarshal(raw, &resource); err != nil {
 			// Log warning but continue processing other resources
 			continue
 		}
@@ -610,7 +853,7 @@ func (s *resourceStorage[T]) SaveWithVersion(ctx context.Context, resource inter
 		return fmt.Errorf("resource has empty UID: %w", ErrInvalidData)
 	}
 
-	data, err := json.Marshal(resource)
+	data, err := s.serializer.Marshal(resource)
 	if err != nil {
 		return fmt.Errorf("failed to marshal %s: %w", s.resourceType, err)
 	}
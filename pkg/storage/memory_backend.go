@@ -0,0 +1,235 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryBackend implements StorageBackend in process memory.
+//
+// Resources are lost when the process exits, so it suits tests, benchmarks
+// and throwaway servers. It validates UIDs and JSON like FileBackend and
+// returns resources in UID order, but does not convert between versions:
+// the version-aware methods store and return data as given.
+//
+// A MemoryBackend is safe for concurrent use.
+type MemoryBackend struct {
+	mu         sync.RWMutex
+	closed     bool
+	serializer Serializer                            // Format of stored data; nil means JSON
+	resources  map[string]map[string]json.RawMessage // resource type -> UID -> data
+}
+
+// NewMemoryBackend creates an empty in-memory storage backend.
+//
+// Example:
+//
+//	backend := storage.NewMemoryBackend()
+//	defer backend.Close()
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{resources: make(map[string]map[string]json.RawMessage)}
+}
+
+// checkOpen returns an error if the backend has been closed or ctx is done.
+// The caller must hold the lock.
+func (m *MemoryBackend) checkOpen(ctx context.Context) error {
+	if m.closed {
+		return fmt.Errorf("storage backend has been closed")
+	}
+	return ctx.Err()
+}
+
+// sortedUIDs returns the UIDs of resourceType in order. The caller must hold
+// the lock.
+func (m *MemoryBackend) sortedUIDs(resourceType string) []string {
+	uids := make([]string, 0, len(m.resources[resourceType]))
+	for uid := range m.resources[resourceType] {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// LoadAll implements StorageBackend.LoadAll
+func (m *MemoryBackend) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return nil, err
+	}
+
+	resources := make([]json.RawMessage, 0, len(m.resources[resourceType]))
+	for _, uid := range m.sortedUIDs(resourceType) {
+		resources = append(resources, bytes.Clone(m.resources[resourceType][uid]))
+	}
+	return resources, nil
+}
+
+// Load implements StorageBackend.Load
+func (m *MemoryBackend) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return nil, err
+	}
+
+	data, ok := m.resources[resourceType][uid]
+	if !ok {
+		return nil, NewStorageError("load", resourceType, uid, ErrNotFound)
+	}
+	return bytes.Clone(data), nil
+}
+
+// LoadMany implements StorageBackend.LoadMany
+func (m *MemoryBackend) LoadMany(ctx context.Context, resourceType string, uids []string) (map[string]json.RawMessage, error) {
+	return DefaultLoadMany(ctx, m, resourceType, uids)
+}
+
+// Save implements StorageBackend.Save
+func (m *MemoryBackend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return err
+	}
+	return m.saveLocked(resourceType, uid, data)
+}
+
+// SetSerializer sets the format of stored data (JSON if never called), as
+// FileBackend.SetSerializer does.
+func (m *MemoryBackend) SetSerializer(serializer Serializer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.serializer = serializer
+}
+
+// codec returns the backend's serializer
+func (m *MemoryBackend) codec() Serializer {
+	return serializerOrDefault(m.serializer)
+}
+
+// saveLocked stores a copy of data. The caller must hold the write lock.
+func (m *MemoryBackend) saveLocked(resourceType, uid string, data json.RawMessage) error {
+	if err := ValidateUID(uid); err != nil {
+		return err
+	}
+	if !m.codec().Valid(data) {
+		return NewStorageError("save", resourceType, uid, fmt.Errorf("invalid data: %w", ErrInvalidData))
+	}
+
+	if m.resources[resourceType] == nil {
+		m.resources[resourceType] = make(map[string]json.RawMessage)
+	}
+	m.resources[resourceType][uid] = bytes.Clone(data)
+	return nil
+}
+
+// CompareAndSwap implements StorageBackend.CompareAndSwap
+func (m *MemoryBackend) CompareAndSwap(ctx context.Context, resourceType, uid string, expected, data json.RawMessage) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return false, err
+	}
+
+	stored, ok := m.resources[resourceType][uid]
+	if ok != (expected != nil) || (ok && !bytes.Equal(stored, expected)) {
+		return false, nil
+	}
+	if err := m.saveLocked(resourceType, uid, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete implements StorageBackend.Delete
+func (m *MemoryBackend) Delete(ctx context.Context, resourceType, uid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return err
+	}
+
+	if _, ok := m.resources[resourceType][uid]; !ok {
+		return NewStorageError("delete", resourceType, uid, ErrNotFound)
+	}
+	delete(m.resources[resourceType], uid)
+	return nil
+}
+
+// Exists implements StorageBackend.Exists
+func (m *MemoryBackend) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return false, err
+	}
+
+	_, ok := m.resources[resourceType][uid]
+	return ok, nil
+}
+
+// List implements StorageBackend.List
+func (m *MemoryBackend) List(ctx context.Context, resourceType string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return nil, err
+	}
+	return m.sortedUIDs(resourceType), nil
+}
+
+// Count implements StorageBackend.Count
+func (m *MemoryBackend) Count(ctx context.Context, resourceType string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkOpen(ctx); err != nil {
+		return 0, err
+	}
+	return len(m.resources[resourceType]), nil
+}
+
+// Close implements StorageBackend.Close and drops all resources
+func (m *MemoryBackend) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.resources = nil
+	return nil
+}
+
+// LoadWithVersion implements StorageBackend.LoadWithVersion, returning the
+// resource as stored and the requested version
+func (m *MemoryBackend) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (json.RawMessage, string, error) {
+	data, err := m.Load(ctx, resourceType, uid)
+	return data, version, err
+}
+
+// LoadAllWithVersion implements StorageBackend.LoadAllWithVersion, returning
+// the resources as stored
+func (m *MemoryBackend) LoadAllWithVersion(ctx context.Context, resourceType, _ string) ([]json.RawMessage, error) {
+	return m.LoadAll(ctx, resourceType)
+}
+
+// SaveWithVersion implements StorageBackend.SaveWithVersion, storing data as
+// given
+func (m *MemoryBackend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, _ string) error {
+	return m.Save(ctx, resourceType, uid, data)
+}
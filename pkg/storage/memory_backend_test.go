@@ -0,0 +1,84 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	backend := NewMemoryBackend()
+	defer backend.Close()
+	ctx := context.Background()
+
+	for _, uid := range []string{"dev-2", "dev-1"} {
+		if err := backend.Save(ctx, "Device", uid, json.RawMessage(`{"metadata":{"uid":"`+uid+`"}}`)); err != nil {
+			t.Fatalf("Save %s failed: %v", uid, err)
+		}
+	}
+	if err := backend.Save(ctx, "Device", "dev-3", json.RawMessage(`{`)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Save of invalid JSON = %v, want ErrInvalidData", err)
+	}
+	if err := backend.Save(ctx, "Device", "../escape", json.RawMessage(`{}`)); err == nil {
+		t.Error("Save accepted an unsafe UID")
+	}
+
+	uids, err := backend.List(ctx, "Device")
+	if err != nil || !reflect.DeepEqual(uids, []string{"dev-1", "dev-2"}) {
+		t.Errorf("List = %v, %v, want UIDs in order", uids, err)
+	}
+	if count, _ := backend.Count(ctx, "Device"); count != 2 {
+		t.Errorf("Count = %d, want 2", count)
+	}
+
+	// Loaded data is a copy
+	data, err := backend.Load(ctx, "Device", "dev-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[0] = 'x'
+	if again, _ := backend.Load(ctx, "Device", "dev-1"); string(again) != `{"metadata":{"uid":"dev-1"}}` {
+		t.Errorf("stored data changed to %s", again)
+	}
+
+	if swapped, err := backend.CompareAndSwap(ctx, "Device", "dev-1", json.RawMessage(`{}`), json.RawMessage(`{"v":2}`)); err != nil || swapped {
+		t.Errorf("CompareAndSwap with a stale value = %v, %v", swapped, err)
+	}
+	if swapped, err := backend.CompareAndSwap(ctx, "Device", "dev-4", nil, json.RawMessage(`{"v":1}`)); err != nil || !swapped {
+		t.Errorf("CompareAndSwap creating a resource = %v, %v", swapped, err)
+	}
+
+	if err := backend.Delete(ctx, "Device", "dev-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Load(ctx, "Device", "dev-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load after Delete = %v, want ErrNotFound", err)
+	}
+	if err := backend.Delete(ctx, "Device", "dev-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryBackend_ResourceStorage(t *testing.T) {
+	devices := NewResourceStorage[*versionedDevice](NewMemoryBackend(), "Device")
+	ctx := context.Background()
+
+	device := &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatal(err)
+	}
+	stale := *device
+	device.Hostname = "node-1"
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatal(err)
+	}
+	if err := devices.Save(ctx, &stale); !errors.Is(err, ErrConflict) {
+		t.Errorf("Save of a stale resource = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/metrics"
+)
+
+var (
+	operationsTotal = metrics.NewCounterVec(
+		"fabrica_storage_operations_total",
+		"Storage operations by resource type, operation and result (ok, not_found, conflict, timeout, error).",
+		"resource_type", "operation", "result")
+
+	operationDuration = metrics.NewHistogramVec(
+		"fabrica_storage_operation_duration_seconds",
+		"Storage operation latency by resource type and operation.",
+		nil, "resource_type", "operation")
+)
+
+// MetricsCollectors returns the storage metrics recorded by MetricsBackend:
+//   - fabrica_storage_operations_total{resource_type, operation, result}
+//   - fabrica_storage_operation_duration_seconds{resource_type, operation}
+//
+// Example:
+//
+//	http.Handle("/metrics", metrics.Handler(storage.MetricsCollectors()...))
+func MetricsCollectors() []metrics.Collector {
+	return []metrics.Collector{operationsTotal, operationDuration}
+}
+
+// MetricsBackend wraps a StorageBackend and records the count, result and
+// latency of each operation for MetricsCollectors. Operations on every
+// MetricsBackend are recorded in the same metrics.
+//
+// Wrap the outermost backend (e.g. a CachingBackend) so the latencies are
+// the ones callers see. A MetricsBackend is safe for concurrent use if inner
+// is.
+type MetricsBackend struct {
+	inner StorageBackend
+}
+
+// NewMetricsBackend wraps inner so its operations are recorded
+//
+// Example:
+//
+//	backend, _ := storage.NewFileBackend("./data")
+//	instrumented := storage.NewMetricsBackend(backend)
+func NewMetricsBackend(inner StorageBackend) *MetricsBackend {
+	return &MetricsBackend{inner: inner}
+}
+
+// ObserveOperation records one operation of storage that is not a
+// StorageBackend, such as generated Ent storage, in the metrics
+// MetricsBackend records: operation started at start and ended with err.
+func ObserveOperation(resourceType, operation string, start time.Time, err error) {
+	observe(resourceType, operation, start, err)
+}
+
+// observe records one operation that started at start and ended with err
+func observe(resourceType, operation string, start time.Time, err error) {
+	operationDuration.Observe(time.Since(start).Seconds(), resourceType, operation)
+	operationsTotal.Inc(resourceType, operation, operationResult(err))
+}
+
+// operationResult classifies err for the result label
+func operationResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrConflict):
+		return "conflict"
+	case IsTimeout(err):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
+
+// LoadAll implements StorageBackend.LoadAll
+func (m *MetricsBackend) LoadAll(ctx context.Context, resourceType string) (_ []json.RawMessage, err error) {
+	defer func(start time.Time) { observe(resourceType, "LoadAll", start, err) }(time.Now())
+	return m.inner.LoadAll(ctx, resourceType)
+}
+
+// Load implements StorageBackend.Load
+func (m *MetricsBackend) Load(ctx context.Context, resourceType, uid string) (_ json.RawMessage, err error) {
+	defer func(start time.Time) { observe(resourceType, "Load", start, err) }(time.Now())
+	return m.inner.Load(ctx, resourceType, uid)
+}
+
+// LoadMany implements StorageBackend.LoadMany
+func (m *MetricsBackend) LoadMany(ctx context.Context, resourceType string, uids []string) (_ map[string]json.RawMessage, err error) {
+	defer func(start time.Time) { observe(resourceType, "LoadMany", start, err) }(time.Now())
+	return m.inner.LoadMany(ctx, resourceType, uids)
+}
+
+// Save implements StorageBackend.Save
+func (m *MetricsBackend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) (err error) {
+	defer func(start time.Time) { observe(resourceType, "Save", start, err) }(time.Now())
+	return m.inner.Save(ctx, resourceType, uid, data)
+}
+
+// SaveIfVersion implements ConditionalSaver.SaveIfVersion, using the wrapped
+// backend's implementation if it has one
+func (m *MetricsBackend) SaveIfVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, expectedVersion string) (err error) {
+	defer func(start time.Time) { observe(resourceType, "SaveIfVersion", start, err) }(time.Now())
+	return saveIfVersion(ctx, m.inner, nil, resourceType, uid, data, expectedVersion)
+}
+
+// CompareAndSwap implements StorageBackend.CompareAndSwap. A swap that did
+// not happen because the stored data differed is recorded as a conflict.
+func (m *MetricsBackend) CompareAndSwap(ctx context.Context, resourceType, uid string, expected, data json.RawMessage) (swapped bool, err error) {
+	defer func(start time.Time) {
+		if err == nil && !swapped {
+			observe(resourceType, "CompareAndSwap", start, ErrConflict)
+			return
+		}
+		observe(resourceType, "CompareAndSwap", start, err)
+	}(time.Now())
+	return m.inner.CompareAndSwap(ctx, resourceType, uid, expected, data)
+}
+
+// Delete implements StorageBackend.Delete
+func (m *MetricsBackend) Delete(ctx context.Context, resourceType, uid string) (err error) {
+	defer func(start time.Time) { observe(resourceType, "Delete", start, err) }(time.Now())
+	return m.inner.Delete(ctx, resourceType, uid)
+}
+
+// Exists implements StorageBackend.Exists
+func (m *MetricsBackend) Exists(ctx context.Context, resourceType, uid string) (_ bool, err error) {
+	defer func(start time.Time) { observe(resourceType, "Exists", start, err) }(time.Now())
+	return m.inner.Exists(ctx, resourceType, uid)
+}
+
+// List implements StorageBackend.List
+func (m *MetricsBackend) List(ctx context.Context, resourceType string) (_ []string, err error) {
+	defer func(start time.Time) { observe(resourceType, "List", start, err) }(time.Now())
+	return m.inner.List(ctx, resourceType)
+}
+
+// Count implements StorageBackend.Count
+func (m *MetricsBackend) Count(ctx context.Context, resourceType string) (_ int, err error) {
+	defer func(start time.Time) { observe(resourceType, "Count", start, err) }(time.Now())
+	return m.inner.Count(ctx, resourceType)
+}
+
+// Stat implements StatBackend.Stat using the wrapped backend
+func (m *MetricsBackend) Stat(ctx context.Context, resourceType, uid string) (_ StatInfo, err error) {
+	defer func(start time.Time) { observe(resourceType, "Stat", start, err) }(time.Now())
+	return Stat(ctx, m.inner, resourceType, uid)
+}
+
+// Snapshot implements SnapshotBackend.Snapshot using the wrapped backend.
+// Only taking the snapshot is timed, not iterating over it.
+func (m *MetricsBackend) Snapshot(ctx context.Context, resourceType string) (_ Iterator, err error) {
+	defer func(start time.Time) { observe(resourceType, "Snapshot", start, err) }(time.Now())
+	return Snapshot(ctx, m.inner, resourceType)
+}
+
+// FindByField implements FieldLookupBackend.FindByField using the wrapped
+// backend
+func (m *MetricsBackend) FindByField(ctx context.Context, resourceType, field, value string) (_ string, err error) {
+	defer func(start time.Time) { observe(resourceType, "FindByField", start, err) }(time.Now())
+	return FindByField(ctx, m.inner, resourceType, field, value)
+}
+
+// FindByName implements NameLookupBackend.FindByName using the wrapped
+// backend
+func (m *MetricsBackend) FindByName(ctx context.Context, resourceType, name string) (_ string, err error) {
+	defer func(start time.Time) { observe(resourceType, "FindByName", start, err) }(time.Now())
+	return FindByName(ctx, m.inner, resourceType, name)
+}
+
+// Close closes the wrapped backend
+func (m *MetricsBackend) Close() error {
+	return m.inner.Close()
+}
+
+// LoadWithVersion implements StorageBackend.LoadWithVersion
+func (m *MetricsBackend) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (_ json.RawMessage, _ string, err error) {
+	defer func(start time.Time) { observe(resourceType, "LoadWithVersion", start, err) }(time.Now())
+	return m.inner.LoadWithVersion(ctx, resourceType, uid, version)
+}
+
+// LoadAllWithVersion implements StorageBackend.LoadAllWithVersion
+func (m *MetricsBackend) LoadAllWithVersion(ctx context.Context, resourceType, version string) (_ []json.RawMessage, err error) {
+	defer func(start time.Time) { observe(resourceType, "LoadAllWithVersion", start, err) }(time.Now())
+	return m.inner.LoadAllWithVersion(ctx, resourceType, version)
+}
+
+// SaveWithVersion implements StorageBackend.SaveWithVersion
+func (m *MetricsBackend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) (err error) {
+	defer func(start time.Time) { observe(resourceType, "SaveWithVersion", start, err) }(time.Now())
+	return m.inner.SaveWithVersion(ctx, resourceType, uid, data, version)
+}
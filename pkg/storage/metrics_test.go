@@ -0,0 +1,79 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/metrics"
+)
+
+func TestMetricsBackend(t *testing.T) {
+	inner, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := NewMetricsBackend(inner)
+	defer backend.Close()
+	ctx := context.Background()
+
+	// Other tests may use the same kind; count from here
+	before := func(operation, result string) float64 {
+		return operationsTotal.Value("MetricsProbe", operation, result)
+	}
+	saves, loads, missing, conflicts := before("Save", "ok"), before("Load", "ok"), before("Load", "not_found"), before("CompareAndSwap", "conflict")
+	timed := operationDuration.Count("MetricsProbe", "Load")
+
+	data := json.RawMessage(`{"metadata":{"uid":"probe-1"}}`)
+	if err := backend.Save(ctx, "MetricsProbe", "probe-1", data); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Load(ctx, "MetricsProbe", "probe-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Load(ctx, "MetricsProbe", "missing"); err == nil {
+		t.Fatal("Load of a missing resource succeeded")
+	}
+	if swapped, err := backend.CompareAndSwap(ctx, "MetricsProbe", "probe-1", json.RawMessage(`{}`), data); err != nil || swapped {
+		t.Fatalf("CompareAndSwap = %v, %v, want a failed swap", swapped, err)
+	}
+
+	for _, check := range []struct {
+		operation, result string
+		was               float64
+	}{
+		{"Save", "ok", saves},
+		{"Load", "ok", loads},
+		{"Load", "not_found", missing},
+		{"CompareAndSwap", "conflict", conflicts},
+	} {
+		if got := before(check.operation, check.result); got != check.was+1 {
+			t.Errorf("%s %s count = %v, want %v", check.operation, check.result, got, check.was+1)
+		}
+	}
+	if got := operationDuration.Count("MetricsProbe", "Load"); got != timed+2 {
+		t.Errorf("Load latency observations = %d, want %d", got, timed+2)
+	}
+
+	var out strings.Builder
+	if err := metrics.WriteText(&out, MetricsCollectors()...); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `fabrica_storage_operations_total{resource_type="MetricsProbe",operation="Save",result="ok"}`) {
+		t.Errorf("exposition is missing the save count:\n%s", out.String())
+	}
+}
+
+func TestObserveOperation(t *testing.T) {
+	was := operationsTotal.Value("ObserveProbe", "Load", "not_found")
+	ObserveOperation("ObserveProbe", "Load", time.Now(), NewStorageError("load", "ObserveProbe", "probe-1", ErrNotFound))
+	if got := operationsTotal.Value("ObserveProbe", "Load", "not_found"); got != was+1 {
+		t.Errorf("Load not_found count = %v, want %v", got, was+1)
+	}
+}
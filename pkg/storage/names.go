@@ -0,0 +1,57 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+)
+
+// NameField is the field path of a resource's name, as indexed by
+// NewNameIndexBackend
+const NameField = "metadata.name"
+
+// NameLookupBackend is implemented by backends that can find a resource by
+// metadata.name without reading every resource of its type.
+type NameLookupBackend interface {
+	// FindByName returns the UID of the resource of resourceType whose
+	// metadata.name is name. It returns ErrNotFound if there is none and
+	// ErrConflict if more than one resource has the name.
+	FindByName(ctx context.Context, resourceType, name string) (string, error)
+}
+
+// FindByName returns the UID of the resource of resourceType named name,
+// using backend's NameLookupBackend implementation. Other backends fall back
+// to LoadAll, which reads every resource of the type.
+//
+// Returns:
+//   - string: The UID of the named resource
+//   - error: ErrNotFound if no resource has the name, ErrConflict if several
+//     do, other errors for failures
+//
+// Example:
+//
+//	uid, err := storage.FindByName(ctx, backend, "Device", "switch-01")
+func FindByName(ctx context.Context, backend StorageBackend, resourceType, name string) (string, error) {
+	if finder, ok := backend.(NameLookupBackend); ok {
+		return finder.FindByName(ctx, resourceType, name)
+	}
+	return scanByField(ctx, backend, resourceType, NameField, name)
+}
+
+// NewNameIndexBackend wraps inner so the names of resourceTypes are indexed
+// and unique: it is NewUniqueIndexBackend with NameField for each type.
+//
+// Example:
+//
+//	backend, _ := storage.NewFileBackend("./data")
+//	named := storage.NewNameIndexBackend(backend, "Device", "Rack")
+//	uid, err := storage.FindByName(ctx, named, "Device", "switch-01")
+func NewNameIndexBackend(inner StorageBackend, resourceTypes ...string) *UniqueIndexBackend {
+	fields := make(map[string][]string, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		fields[resourceType] = []string{NameField}
+	}
+	return NewUniqueIndexBackend(inner, fields)
+}
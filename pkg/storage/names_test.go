@@ -0,0 +1,108 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func namedDevice(uid, name string) json.RawMessage {
+	return json.RawMessage(`{"metadata":{"uid":"` + uid + `","name":"` + name + `"}}`)
+}
+
+func TestFindByName(t *testing.T) {
+	backend := NewMemoryBackend()
+	ctx := context.Background()
+	backend.Save(ctx, "Device", "dev-1", namedDevice("dev-1", "switch-01"))
+	backend.Save(ctx, "Device", "dev-2", namedDevice("dev-2", "switch-02"))
+	backend.Save(ctx, "Device", "dev-3", namedDevice("dev-3", "switch-02"))
+
+	if uid, err := FindByName(ctx, backend, "Device", "switch-01"); err != nil || uid != "dev-1" {
+		t.Errorf("FindByName = %q, %v, want dev-1", uid, err)
+	}
+	if _, err := FindByName(ctx, backend, "Device", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByName of a missing name = %v, want ErrNotFound", err)
+	}
+	if _, err := FindByName(ctx, backend, "Device", "switch-02"); !errors.Is(err, ErrConflict) {
+		t.Errorf("FindByName of a duplicate name = %v, want ErrConflict", err)
+	}
+}
+
+func TestNameIndexBackend(t *testing.T) {
+	inner := NewMemoryBackend()
+	ctx := context.Background()
+	inner.Save(ctx, "Device", "dev-1", namedDevice("dev-1", "switch-01"))
+	inner.Save(ctx, "Device", "dev-2", namedDevice("dev-2", "dup"))
+	inner.Save(ctx, "Device", "dev-3", namedDevice("dev-3", "dup"))
+	backend := NewNameIndexBackend(inner, "Device")
+
+	if uid, err := FindByName(ctx, backend, "Device", "switch-01"); err != nil || uid != "dev-1" {
+		t.Errorf("FindByName = %q, %v, want dev-1", uid, err)
+	}
+	if _, err := FindByName(ctx, backend, "Device", "dup"); !errors.Is(err, ErrConflict) {
+		t.Errorf("FindByName of a duplicate name = %v, want ErrConflict", err)
+	}
+
+	// Names are unique on create and rename, but a resource keeps its own
+	if err := backend.Save(ctx, "Device", "dev-4", namedDevice("dev-4", "switch-01")); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Save of a taken name = %v, want ErrAlreadyExists", err)
+	}
+	if _, err := backend.CompareAndSwap(ctx, "Device", "dev-4", nil, namedDevice("dev-4", "dup")); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("CompareAndSwap to a taken name = %v, want ErrAlreadyExists", err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-1", namedDevice("dev-1", "switch-01")); err != nil {
+		t.Errorf("Save keeping the name failed: %v", err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-2", namedDevice("dev-2", "dup")); err != nil {
+		t.Errorf("Save keeping a duplicate name failed: %v", err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-1", namedDevice("dev-1", "switch-11")); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-4", namedDevice("dev-4", "switch-01")); err != nil {
+		t.Errorf("Save of a freed name failed: %v", err)
+	}
+	if uid, _ := FindByName(ctx, backend, "Device", "switch-11"); uid != "dev-1" {
+		t.Errorf("FindByName after rename = %q, want dev-1", uid)
+	}
+
+	// Deleting one of the duplicates leaves the name to the other
+	if err := backend.Delete(ctx, "Device", "dev-3"); err != nil {
+		t.Fatal(err)
+	}
+	if uid, err := FindByName(ctx, backend, "Device", "dup"); err != nil || uid != "dev-2" {
+		t.Errorf("FindByName after Delete = %q, %v, want dev-2", uid, err)
+	}
+	if err := backend.Delete(ctx, "Device", "dev-2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindByName(ctx, backend, "Device", "dup"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByName of a deleted name = %v, want ErrNotFound", err)
+	}
+
+	// Types that are not indexed may share names
+	for _, uid := range []string{"rack-1", "rack-2"} {
+		if err := backend.Save(ctx, "Rack", uid, namedDevice(uid, "rack")); err != nil {
+			t.Errorf("Save of an unindexed type failed: %v", err)
+		}
+	}
+}
+
+func TestNameIndexBackend_ResourceStorage(t *testing.T) {
+	devices := NewResourceStorage[*versionedDevice](NewNameIndexBackend(NewMemoryBackend(), "Device"), "Device")
+	ctx := context.Background()
+
+	device := &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatal(err)
+	}
+	device.Hostname = "node-1"
+	if err := devices.Save(ctx, device); err != nil {
+		t.Errorf("update through ResourceStorage failed: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Versioned is implemented by resources that carry a resource version for
+// optimistic locking. resource.Resource implements it through
+// Metadata.ResourceVersion, so every embedded resource does.
+//
+// ResourceStorage.Save checks the version against the stored resource and
+// returns ErrConflict if another writer saved it in between; on success it
+// bumps the version.
+type Versioned interface {
+	GetResourceVersion() string
+	SetResourceVersion(version string)
+}
+
+// ConditionalSaver is implemented by backends that can atomically save a
+// resource only if its stored resource version matches.
+//
+// Backends that do not implement it fall back to loading the resource,
+// comparing its version and writing it back with CompareAndSwap, which is as
+// atomic as the backend's CompareAndSwap. Database backends may implement it
+// with a conditional UPDATE (WHERE resource_version = ?) to save the load.
+type ConditionalSaver interface {
+	// SaveIfVersion stores data if the stored resource's version equals
+	// expectedVersion. A missing resource has version "". It returns
+	// ErrConflict if the versions differ.
+	SaveIfVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, expectedVersion string) error
+}
+
+// ResourceVersionOf returns metadata.resourceVersion from serialized resource
+// data, or "" if it has none.
+func ResourceVersionOf(data json.RawMessage) (string, error) {
+	return resourceVersionOf(JSONSerializer{}, data)
+}
+
+// resourceVersionOf is ResourceVersionOf for data in serializer's format
+func resourceVersionOf(serializer Serializer, data []byte) (string, error) {
+	var res struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	if err := serializer.Unmarshal(data, &res); err != nil {
+		return "", fmt.Errorf("failed to read resource version: %w", ErrInvalidData)
+	}
+	return res.Metadata.ResourceVersion, nil
+}
+
+// nextResourceVersion returns the version following current. Versions are
+// decimal counters starting at "1".
+func nextResourceVersion(current string) (string, error) {
+	if current == "" {
+		return "1", nil
+	}
+	n, err := strconv.ParseUint(current, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid resource version %q: %w", current, ErrInvalidData)
+	}
+	return strconv.FormatUint(n+1, 10), nil
+}
+
+// saveIfVersion saves data through backend if the stored version matches
+// expectedVersion, using ConditionalSaver if the backend implements it and
+// CompareAndSwap otherwise. The fallback reads the stored version with
+// serializer (JSON if nil).
+func saveIfVersion(ctx context.Context, backend StorageBackend, serializer Serializer, resourceType, uid string, data json.RawMessage, expectedVersion string) error {
+	if saver, ok := backend.(ConditionalSaver); ok {
+		return saver.SaveIfVersion(ctx, resourceType, uid, data, expectedVersion)
+	}
+
+	stored, err := backend.Load(ctx, resourceType, uid)
+	storedVersion := ""
+	switch {
+	case errors.Is(err, ErrNotFound):
+		stored = nil
+	case err != nil:
+		return err
+	default:
+		if storedVersion, err = resourceVersionOf(serializerOrDefault(serializer), stored); err != nil {
+			return err
+		}
+	}
+
+	if storedVersion != expectedVersion {
+		return conflictError(resourceType, uid, expectedVersion, storedVersion)
+	}
+
+	swapped, err := backend.CompareAndSwap(ctx, resourceType, uid, stored, data)
+	if err != nil {
+		return err
+	}
+	if !swapped {
+		// Another writer saved the resource after it was loaded
+		return NewStorageError("save", resourceType, uid, fmt.Errorf("modified concurrently, expected resource version %q: %w", expectedVersion, ErrConflict))
+	}
+	return nil
+}
+
+// conflictError returns a StorageError wrapping ErrConflict with the versions
+// that did not match.
+func conflictError(resourceType, uid, expectedVersion, storedVersion string) error {
+	return NewStorageError("save", resourceType, uid, fmt.Errorf("stored resource version %q, expected %q: %w", storedVersion, expectedVersion, ErrConflict))
+}
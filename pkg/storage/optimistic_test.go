@@ -0,0 +1,177 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type versionedMetadata struct {
+	UID             string `json:"uid"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type versionedDevice struct {
+	Metadata versionedMetadata `json:"metadata"`
+	Hostname string            `json:"hostname"`
+}
+
+func (d *versionedDevice) GetUID() string                    { return d.Metadata.UID }
+func (d *versionedDevice) GetResourceVersion() string        { return d.Metadata.ResourceVersion }
+func (d *versionedDevice) SetResourceVersion(version string) { d.Metadata.ResourceVersion = version }
+
+// loadOnlyBackend hides FileBackend's ConditionalSaver implementation to
+// exercise the CompareAndSwap fallback.
+type loadOnlyBackend struct {
+	StorageBackend
+}
+
+func TestResourceStorage_SaveBumpsVersion(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	devices := NewResourceStorage[*versionedDevice](backend, "Device")
+	ctx := context.Background()
+
+	device := &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if device.Metadata.ResourceVersion != "1" {
+		t.Errorf("Expected version 1 after create, got %q", device.Metadata.ResourceVersion)
+	}
+
+	device.Hostname = "node-1"
+	if err := devices.Save(ctx, device); err != nil {
+		t.Fatalf("Second Save failed: %v", err)
+	}
+
+	loaded, err := devices.Load(ctx, "dev-1")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Metadata.ResourceVersion != "2" || loaded.Hostname != "node-1" {
+		t.Errorf("Unexpected stored resource: %+v", loaded)
+	}
+}
+
+func TestResourceStorage_SaveDetectsConflict(t *testing.T) {
+	for name, backend := range map[string]func(*FileBackend) StorageBackend{
+		"conditional": func(f *FileBackend) StorageBackend { return f },
+		"fallback":    func(f *FileBackend) StorageBackend { return loadOnlyBackend{f} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			fileBackend, _ := newTestFileBackend(t)
+			devices := NewResourceStorage[*versionedDevice](backend(fileBackend), "Device")
+			ctx := context.Background()
+
+			if err := devices.Save(ctx, &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			first, _ := devices.Load(ctx, "dev-1")
+			second, _ := devices.Load(ctx, "dev-1")
+
+			first.Hostname = "first"
+			if err := devices.Save(ctx, first); err != nil {
+				t.Fatalf("First writer failed: %v", err)
+			}
+
+			second.Hostname = "second"
+			err := devices.Save(ctx, second)
+			if !errors.Is(err, ErrConflict) {
+				t.Fatalf("Expected ErrConflict for stale writer, got %v", err)
+			}
+			if second.Metadata.ResourceVersion != "1" {
+				t.Errorf("Failed save should keep the caller's version, got %q", second.Metadata.ResourceVersion)
+			}
+
+			// Creating over an existing resource is also a conflict
+			err = devices.Save(ctx, &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}})
+			if !errors.Is(err, ErrConflict) {
+				t.Errorf("Expected ErrConflict for create over existing resource, got %v", err)
+			}
+		})
+	}
+}
+
+func TestResourceStorage_SaveIfUnchanged(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	devices := NewResourceStorage[*versionedDevice](backend, "Device")
+	ctx := context.Background()
+
+	device := &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}
+	if err := devices.SaveIfUnchanged(ctx, device, ""); err != nil {
+		t.Fatalf("SaveIfUnchanged create failed: %v", err)
+	}
+
+	if err := devices.SaveIfUnchanged(ctx, device, "7"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict for wrong expected version, got %v", err)
+	}
+	if err := devices.SaveIfUnchanged(ctx, device, "1"); err != nil {
+		t.Fatalf("SaveIfUnchanged with current version failed: %v", err)
+	}
+	if device.Metadata.ResourceVersion != "2" {
+		t.Errorf("Expected version 2, got %q", device.Metadata.ResourceVersion)
+	}
+}
+
+func TestResourceStorage_ConcurrentSaves(t *testing.T) {
+	for name, backend := range map[string]func(*FileBackend) StorageBackend{
+		"conditional": func(f *FileBackend) StorageBackend { return f },
+		"fallback":    func(f *FileBackend) StorageBackend { return loadOnlyBackend{f} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			fileBackend, _ := newTestFileBackend(t)
+			devices := NewResourceStorage[*versionedDevice](backend(fileBackend), "Device")
+			ctx := context.Background()
+
+			if err := devices.Save(ctx, &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			const writers = 8
+			var wg sync.WaitGroup
+			results := make(chan error, writers)
+			for i := 0; i < writers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results <- devices.SaveIfUnchanged(ctx, &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}}, "1")
+				}()
+			}
+			wg.Wait()
+			close(results)
+
+			succeeded := 0
+			for err := range results {
+				switch {
+				case err == nil:
+					succeeded++
+				case !errors.Is(err, ErrConflict):
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+			if succeeded != 1 {
+				t.Errorf("Expected exactly one writer to succeed, got %d", succeeded)
+			}
+		})
+	}
+}
+
+func TestResourceVersionOf(t *testing.T) {
+	version, err := ResourceVersionOf(json.RawMessage(`{"metadata":{"resourceVersion":"42"}}`))
+	if err != nil || version != "42" {
+		t.Errorf("Expected version 42, got %q (err %v)", version, err)
+	}
+	if version, err := ResourceVersionOf(json.RawMessage(`{"metadata":{}}`)); err != nil || version != "" {
+		t.Errorf("Expected empty version, got %q (err %v)", version, err)
+	}
+	if _, err := ResourceVersionOf(json.RawMessage(`not json`)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// DefaultMaxPageSize is the largest list page PageLimit allows until
+// SetMaxPageSize is called.
+const DefaultMaxPageSize = 500
+
+var maxPageSize atomic.Int64
+
+func init() {
+	maxPageSize.Store(DefaultMaxPageSize)
+}
+
+// SetMaxPageSize sets the largest list page PageLimit allows. Zero or a
+// negative value removes the cap, so lists without a limit return every
+// resource at once.
+//
+// The generated server calls this at startup with the list_max_page_size
+// setting.
+func SetMaxPageSize(n int) {
+	maxPageSize.Store(int64(n))
+}
+
+// MaxPageSize returns the largest list page PageLimit allows, or a value
+// <= 0 if pages are unbounded.
+func MaxPageSize() int {
+	return int(maxPageSize.Load())
+}
+
+// PageLimit returns the number of items to read for a list page given the
+// client's limit (0 if it did not send one): the limit capped at
+// MaxPageSize, or MaxPageSize itself when there is no limit. Capping every
+// page keeps the memory a list request uses bounded however large the
+// collection grows; clients read the rest by following the next cursor.
+func PageLimit(limit int) int {
+	maxSize := MaxPageSize()
+	if maxSize <= 0 {
+		return limit
+	}
+	if limit <= 0 || limit > maxSize {
+		return maxSize
+	}
+	return limit
+}
+
+// Identified is implemented by resources that have a UID. resource.Resource
+// implements it, so every embedded resource does.
+type Identified interface {
+	GetUID() string
+}
+
+// Paginate returns one page of items ordered by UID.
+//
+// cursor is the value returned as next by the previous call ("" for the
+// first page). A non-positive limit returns every item after the cursor.
+// next is "" on the last page.
+//
+// Cursors encode the last UID returned, so paging stays consistent while
+// resources are created or deleted: each resource appears at most once, and
+// resources created behind the cursor are skipped.
+//
+// Example:
+//
+//	page, next, err := storage.Paginate(devices, r.URL.Query().Get("cursor"), 50)
+//	if err != nil {
+//	    return err // wraps ErrInvalidData
+//	}
+func Paginate[T Identified](items []T, cursor string, limit int) (page []T, next string, err error) {
+	return PaginateSorted(items, nil, cursor, limit)
+}
+
+// PaginateEach is Paginate over the items each yields, which must come in
+// UID order, such as the resources of a Snapshot. Items match rejects (if
+// match is not nil) are left out. Reading stops as soon as the page is full,
+// so at most limit items are held in memory however many each could yield.
+//
+// each must call yield for every item and return the first error yield
+// returns.
+//
+// Example:
+//
+//	each := func(yield func(*Device) error) error {
+//	    return storage.ForEachResource(ctx, backend, "Device", func(data json.RawMessage) error {
+//	        var device Device
+//	        if err := json.Unmarshal(data, &device); err != nil {
+//	            return err
+//	        }
+//	        return yield(&device)
+//	    })
+//	}
+//	page, next, err := storage.PaginateEach(each, nil, r.URL.Query().Get("cursor"), 50)
+func PaginateEach[T Identified](each func(yield func(T) error) error, match func(T) bool, cursor string, limit int) (page []T, next string, err error) {
+	after, err := CursorUID(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Never nil, so an empty page encodes as [] rather than null
+	page = []T{}
+	err = each(func(item T) error {
+		if item.GetUID() <= after || (match != nil && !match(item)) {
+			return nil
+		}
+		if limit > 0 && len(page) == limit {
+			// Another item exists, so the page is not the last one
+			next = encodeUIDCursor(page[limit-1].GetUID())
+			return errPageFull
+		}
+		page = append(page, item)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPageFull) {
+		return nil, "", err
+	}
+	return page, next, nil
+}
+
+// errPageFull stops PaginateEach's iteration once it has a full page
+var errPageFull = errors.New("page full")
+
+// CursorUID returns the UID an unsorted Paginate cursor continues after, so
+// callers reading resources in UID order can skip to it. The empty cursor
+// returns "". Errors wrap ErrInvalidData.
+func CursorUID(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	return decodeUIDCursor(cursor)
+}
+
+// encodeUIDCursor encodes a cursor value as unpadded base64url
+func encodeUIDCursor(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeUIDCursor reverses encodeUIDCursor. Errors wrap ErrInvalidData.
+func decodeUIDCursor(cursor string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(decoded) == 0 {
+		return "", fmt.Errorf("invalid cursor %q: %w", cursor, ErrInvalidData)
+	}
+	return string(decoded), nil
+}
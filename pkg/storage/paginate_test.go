@@ -0,0 +1,148 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+type uidItem string
+
+func (u uidItem) GetUID() string { return string(u) }
+
+func TestPaginate_WalksAllPages(t *testing.T) {
+	items := []uidItem{"dev-4", "dev-1", "dev-5", "dev-3", "dev-2"}
+
+	var seen []uidItem
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(items) {
+			t.Fatal("Pagination did not terminate")
+		}
+		page, next, err := Paginate(items, cursor, 2)
+		if err != nil {
+			t.Fatalf("Paginate failed: %v", err)
+		}
+		seen = append(seen, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	want := []uidItem{"dev-1", "dev-2", "dev-3", "dev-4", "dev-5"}
+	if len(seen) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, seen)
+		}
+	}
+}
+
+func TestPaginate_NoLimit(t *testing.T) {
+	page, next, err := Paginate([]uidItem{"b", "a"}, "", 0)
+	if err != nil || next != "" || len(page) != 2 || page[0] != "a" {
+		t.Errorf("Unexpected result: %v, %q, %v", page, next, err)
+	}
+
+	if page, _, _ := Paginate[uidItem](nil, "", 10); page == nil {
+		t.Error("Empty page should be non-nil")
+	}
+
+	// An exact final page has no next cursor
+	page, next, _ = Paginate([]uidItem{"b", "a"}, "", 2)
+	if len(page) != 2 || next != "" {
+		t.Errorf("Expected full page without cursor, got %v, %q", page, next)
+	}
+}
+
+func TestPaginate_CursorSurvivesDeletion(t *testing.T) {
+	_, next, _ := Paginate([]uidItem{"a", "b", "c"}, "", 2)
+
+	// "b" was the last item returned; deleting it must not repeat or skip "c"
+	page, _, err := Paginate([]uidItem{"a", "c"}, next, 2)
+	if err != nil || len(page) != 1 || page[0] != "c" {
+		t.Errorf("Expected [c], got %v (err %v)", page, err)
+	}
+}
+
+func TestPaginate_InvalidCursor(t *testing.T) {
+	if _, _, err := Paginate([]uidItem{"a"}, "not base64!", 1); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData, got %v", err)
+	}
+}
+
+func TestPaginateEach(t *testing.T) {
+	items := []uidItem{"a", "b", "c", "d", "e"}
+	read := 0
+	each := func(yield func(uidItem) error) error {
+		for _, item := range items {
+			read++
+			if err := yield(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	notC := func(item uidItem) bool { return item != "c" }
+
+	page, next, err := PaginateEach(each, notC, "", 2)
+	if err != nil || len(page) != 2 || page[1] != "b" || next == "" {
+		t.Fatalf("First page = %v, %q, %v", page, next, err)
+	}
+	// c is filtered out, so d is the item that shows another page exists
+	if read != 4 {
+		t.Errorf("Read %d items for a page of 2, want 4", read)
+	}
+
+	// The cursor matches Paginate's, and filtered items are skipped
+	if _, want, _ := Paginate(items, "", 2); next != want {
+		t.Errorf("Cursor = %q, want Paginate's %q", next, want)
+	}
+	page, next, err = PaginateEach(each, notC, next, 2)
+	if err != nil || len(page) != 2 || page[0] != "d" || page[1] != "e" || next != "" {
+		t.Errorf("Last page = %v, %q, %v", page, next, err)
+	}
+
+	if page, _, _ := PaginateEach(each, nil, "", 0); len(page) != len(items) {
+		t.Errorf("Unlimited page = %v", page)
+	}
+
+	stop := errors.New("storage failed")
+	failing := func(yield func(uidItem) error) error { return stop }
+	if _, _, err := PaginateEach(failing, nil, "", 2); !errors.Is(err, stop) {
+		t.Errorf("Expected the iteration error, got %v", err)
+	}
+	if _, _, err := PaginateEach(each, nil, "not base64!", 2); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData, got %v", err)
+	}
+}
+
+func TestPageLimit(t *testing.T) {
+	t.Cleanup(func() { SetMaxPageSize(DefaultMaxPageSize) })
+
+	tests := []struct {
+		max, limit, want int
+	}{
+		{DefaultMaxPageSize, 0, DefaultMaxPageSize},
+		{DefaultMaxPageSize, 50, 50},
+		{DefaultMaxPageSize, DefaultMaxPageSize + 1, DefaultMaxPageSize},
+		{10, 0, 10},
+		{10, 20, 10},
+		// No cap: the client's limit, or everything
+		{0, 0, 0},
+		{0, 20, 20},
+		{-1, 0, 0},
+	}
+	for _, tt := range tests {
+		SetMaxPageSize(tt.max)
+		if got := PageLimit(tt.limit); got != tt.want {
+			t.Errorf("max %d: PageLimit(%d) = %d, want %d", tt.max, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// DefaultReaperInterval is the scan interval used when NewReaper is given a
+// non-positive interval.
+const DefaultReaperInterval = time.Minute
+
+// Reaper periodically deletes expired resources.
+//
+// A resource is expired once its metadata.expiresAt is in the past (see
+// resource.Metadata.ExpiresAt). Resources without an expiry are never
+// deleted. Each deletion publishes a "deleted" event with reason "expired",
+// so reconcilers and subscribers see it like any other delete.
+//
+// Only the resource types given to NewReaper are scanned, so short-lived
+// resources (leases, tokens) opt in explicitly.
+//
+// Example:
+//
+//	reaper := storage.NewReaper(backend, 30*time.Second, "Lease", "Token")
+//	reaper.Start(ctx)
+//	defer reaper.Stop()
+type Reaper struct {
+	backend       StorageBackend
+	serializer    Serializer // Format of stored data; nil means the backend's
+	interval      time.Duration
+	resourceTypes []string
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// expiringResource is the part of a stored resource the reaper needs
+type expiringResource struct {
+	Metadata struct {
+		Name      string     `json:"name"`
+		UID       string     `json:"uid"`
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	} `json:"metadata"`
+}
+
+// NewReaper creates a reaper that scans the given resource types every
+// interval. A non-positive interval uses DefaultReaperInterval.
+func NewReaper(backend StorageBackend, interval time.Duration, resourceTypes ...string) *Reaper {
+	if interval <= 0 {
+		interval = DefaultReaperInterval
+	}
+	return &Reaper{
+		backend:       backend,
+		interval:      interval,
+		resourceTypes: append([]string(nil), resourceTypes...),
+		now:           time.Now,
+	}
+}
+
+// SetSerializer sets the format the reaper decodes stored data with, if the
+// backend stores something other than JSON and is not itself a FileBackend
+// or MemoryBackend with that serializer. Call it before Start.
+func (r *Reaper) SetSerializer(serializer Serializer) {
+	r.serializer = serializer
+}
+
+// ResourceTypes returns the resource types the reaper scans.
+func (r *Reaper) ResourceTypes() []string {
+	return append([]string(nil), r.resourceTypes...)
+}
+
+// Interval returns the time between scans.
+func (r *Reaper) Interval() time.Duration {
+	return r.interval
+}
+
+// Start scans once immediately and then every interval in a background
+// goroutine, until ctx is cancelled or Stop is called. Scan errors are
+// logged and retried on the next scan. Calling Start on a running reaper
+// does nothing.
+func (r *Reaper) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+
+	go func(done chan struct{}) {
+		defer close(done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := r.Reap(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Warning: expiry reaper scan failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.done)
+}
+
+// Stop stops a running reaper and waits for an in-progress scan to finish.
+func (r *Reaper) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Reap performs a single scan, deleting every expired resource of the
+// configured types. It returns the number of resources deleted.
+//
+// A failure for one resource type does not stop the scan of the others; all
+// errors are returned together.
+func (r *Reaper) Reap(ctx context.Context) (int, error) {
+	now := r.now()
+	deleted := 0
+	var errs []error
+
+	for _, resourceType := range r.resourceTypes {
+		n, err := r.reapType(ctx, resourceType, now)
+		deleted += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// reapType deletes the expired resources of one type.
+func (r *Reaper) reapType(ctx context.Context, resourceType string, now time.Time) (int, error) {
+	rawResources, err := r.backend.LoadAll(ctx, resourceType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load %s resources: %w", resourceType, err)
+	}
+
+	deleted := 0
+	var errs []error
+	serializer := r.serializer
+	if serializer == nil {
+		serializer = serializerOf(r.backend)
+	}
+	for _, raw := range rawResources {
+		var res expiringResource
+		if err := serializer.Unmarshal(raw, &res); err != nil {
+			errs = append(errs, fmt.Errorf("failed to decode %s resource: %w", resourceType, err))
+			continue
+		}
+
+		expiresAt := res.Metadata.ExpiresAt
+		if expiresAt == nil || expiresAt.After(now) || res.Metadata.UID == "" {
+			continue
+		}
+
+		if err := r.backend.Delete(ctx, resourceType, res.Metadata.UID); err != nil {
+			// Another replica may have reaped it first
+			if !errors.Is(err, ErrNotFound) {
+				errs = append(errs, fmt.Errorf("failed to delete expired %s %s: %w", resourceType, res.Metadata.UID, err))
+			}
+			continue
+		}
+		deleted++
+
+		metadata := map[string]interface{}{
+			"reason":    "expired",
+			"expiresAt": *expiresAt,
+		}
+		if err := events.PublishResourceDeleted(ctx, resourceType, res.Metadata.UID, res.Metadata.Name, metadata); err != nil {
+			// Events are non-critical; the resource is already gone
+			log.Printf("Warning: failed to publish deleted event for expired %s %s: %v", resourceType, res.Metadata.UID, err)
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func saveExpiring(t *testing.T, backend StorageBackend, resourceType, uid string, expiresAt *time.Time) {
+	t.Helper()
+
+	metadata := map[string]interface{}{"name": uid, "uid": uid}
+	if expiresAt != nil {
+		metadata["expiresAt"] = expiresAt
+	}
+	data, err := json.Marshal(map[string]interface{}{"kind": resourceType, "metadata": metadata})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(context.Background(), resourceType, uid, data); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+}
+
+func TestReaper_ReapDeletesExpired(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	saveExpiring(t, backend, "Lease", "lease-expired", &past)
+	saveExpiring(t, backend, "Lease", "lease-exact", &now)
+	saveExpiring(t, backend, "Lease", "lease-active", &future)
+	saveExpiring(t, backend, "Lease", "lease-forever", nil)
+	// Not scanned: only Lease is configured
+	saveExpiring(t, backend, "Device", "dev-expired", &past)
+
+	reaper := NewReaper(backend, time.Minute, "Lease")
+	reaper.now = func() time.Time { return now }
+
+	deleted, err := reaper.Reap(ctx)
+	if err != nil {
+		t.Fatalf("Reap failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Expected 2 deleted, got %d", deleted)
+	}
+
+	for uid, wantExists := range map[string]bool{
+		"lease-expired": false,
+		"lease-exact":   false,
+		"lease-active":  true,
+		"lease-forever": true,
+	} {
+		exists, err := backend.Exists(ctx, "Lease", uid)
+		if err != nil {
+			t.Fatalf("Exists failed: %v", err)
+		}
+		if exists != wantExists {
+			t.Errorf("%s: exists = %v, want %v", uid, exists, wantExists)
+		}
+	}
+	if exists, _ := backend.Exists(ctx, "Device", "dev-expired"); !exists {
+		t.Error("Resources of unconfigured types should not be reaped")
+	}
+}
+
+func TestReaper_StartStop(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	past := time.Now().Add(-time.Hour)
+	reaper := NewReaper(backend, 10*time.Millisecond, "Lease")
+
+	reaper.Start(context.Background())
+	reaper.Start(context.Background()) // no-op while running
+	defer reaper.Stop()
+
+	// Resources created after start are picked up by later scans
+	for i := 0; i < 3; i++ {
+		saveExpiring(t, backend, "Lease", fmt.Sprintf("lease-%d", i), &past)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		uids, err := backend.List(context.Background(), "Lease")
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(uids) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expired resources not reaped: %v", uids)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	reaper.Stop()
+	reaper.Stop() // safe to call twice
+}
+
+func TestNewReaper_DefaultInterval(t *testing.T) {
+	reaper := NewReaper(nil, 0, "Lease")
+	if reaper.Interval() != DefaultReaperInterval {
+		t.Errorf("Expected default interval %v, got %v", DefaultReaperInterval, reaper.Interval())
+	}
+	if types := reaper.ResourceTypes(); len(types) != 1 || types[0] != "Lease" {
+		t.Errorf("Unexpected resource types: %v", types)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import "context"
+
+type readFromPrimaryKey struct{}
+
+// WithPrimary marks ctx so that database backends with a read replica serve
+// its reads from the primary. Use it to read your own writes, and for the
+// load of a load-modify-save cycle, so the save is not based on a stale
+// replica copy:
+//
+//	ctx = storage.WithPrimary(ctx)
+//	device, err := storage.LoadDevice(ctx, uid)
+//	// modify device ...
+//	err = storage.SaveDevice(ctx, device)
+//
+// Backends without a replica ignore the mark.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readFromPrimaryKey{}, true)
+}
+
+// ReadFromPrimary reports whether ctx was marked with WithPrimary
+func ReadFromPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(readFromPrimaryKey{}).(bool)
+	return primary
+}
@@ -0,0 +1,27 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithPrimary(t *testing.T) {
+	ctx := context.Background()
+	if ReadFromPrimary(ctx) {
+		t.Error("Unmarked context reads from the primary")
+	}
+	if !ReadFromPrimary(WithPrimary(ctx)) {
+		t.Error("Context marked with WithPrimary does not read from the primary")
+	}
+
+	// The mark survives derived contexts, such as the operation timeout
+	derived, cancel := WithOperationTimeout(WithPrimary(ctx))
+	defer cancel()
+	if !ReadFromPrimary(derived) {
+		t.Error("Derived context lost the primary mark")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Serializer converts resources to and from the bytes a backend stores.
+//
+// StorageBackend methods pass resources as json.RawMessage, but the bytes
+// are whatever the serializer produces: a ResourceStorage and the backend it
+// writes to must use the same serializer. JSONSerializer is the default.
+//
+// MemoryBackend, GarbageCollector, Reaper and UniqueIndexBackend decode
+// stored data too, and take the serializer through SetSerializer. Other
+// code that reads stored data directly (generated storage, caches of
+// decoded resources, backups) still expects JSON, so alternate formats suit
+// applications that go through ResourceStorage.
+//
+// Example:
+//
+//	backend, _ := storage.NewFileBackend("./data")
+//	backend.SetSerializer(cborSerializer{})
+//	devices := storage.NewResourceStorageWithSerializer[*Device](backend, "Device", cborSerializer{})
+type Serializer interface {
+	// Marshal encodes v
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes data into v
+	Unmarshal(data []byte, v any) error
+
+	// Valid reports whether data is well-formed, without decoding it into
+	// a resource
+	Valid(data []byte) bool
+}
+
+// JSONSerializer is the default Serializer, using encoding/json
+type JSONSerializer struct{}
+
+// Marshal implements Serializer.Marshal
+func (JSONSerializer) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements Serializer.Unmarshal
+func (JSONSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// Valid implements Serializer.Valid
+func (JSONSerializer) Valid(data []byte) bool {
+	return json.Valid(data)
+}
+
+// serializerOrDefault returns s, or JSONSerializer if s is nil
+func serializerOrDefault(s Serializer) Serializer {
+	if s == nil {
+		return JSONSerializer{}
+	}
+	return s
+}
+
+// serializerOf returns the serializer of backend if it has one, as
+// FileBackend and MemoryBackend do, and JSONSerializer otherwise
+func serializerOf(backend StorageBackend) Serializer {
+	if b, ok := backend.(interface{ codec() Serializer }); ok {
+		return b.codec()
+	}
+	return JSONSerializer{}
+}
+
+// decodeDocument decodes stored data into a generic document for field
+// paths. JSON is decoded as is. Other formats are decoded into the kind
+// registered for resourceType (see resource.RegisterKind) and converted
+// through JSON, since field paths name JSON fields.
+func decodeDocument(serializer Serializer, resourceType string, data []byte) (interface{}, error) {
+	var doc interface{}
+	if _, ok := serializer.(JSONSerializer); ok {
+		err := json.Unmarshal(data, &doc)
+		return doc, err
+	}
+
+	obj, err := resource.NewOfKind(resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s data without JSON: %w", resourceType, err)
+	}
+	if err := serializer.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(encoded, &doc)
+	return doc, err
+}
@@ -0,0 +1,214 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// prefixSerializer stores JSON behind a magic prefix, so data written with
+// it is not valid JSON
+type prefixSerializer struct{}
+
+var serializerPrefix = []byte("FAB1")
+
+func (prefixSerializer) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte(nil), serializerPrefix...), data...), nil
+}
+
+func (prefixSerializer) Unmarshal(data []byte, v any) error {
+	if !bytes.HasPrefix(data, serializerPrefix) {
+		return errors.New("missing prefix")
+	}
+	return json.Unmarshal(data[len(serializerPrefix):], v)
+}
+
+func (prefixSerializer) Valid(data []byte) bool {
+	return bytes.HasPrefix(data, serializerPrefix) && json.Valid(data[len(serializerPrefix):])
+}
+
+func TestResourceStorage_Serializer(t *testing.T) {
+	backend, root := newTestFileBackend(t)
+	backend.SetSerializer(prefixSerializer{})
+	ctx := context.Background()
+
+	for name, store := range map[string]StorageBackend{
+		"conditional saver":     backend,
+		"compare-and-swap only": loadOnlyBackend{backend},
+	} {
+		t.Run(name, func(t *testing.T) {
+			devices := NewResourceStorageWithSerializer[*versionedDevice](store, "Device", prefixSerializer{})
+			device := &versionedDevice{Metadata: versionedMetadata{UID: "dev-1"}, Hostname: "node-1"}
+			if err := devices.Save(ctx, device); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			// Updates read the stored version through the serializer
+			device.Hostname = "node-2"
+			if err := devices.Save(ctx, device); err != nil {
+				t.Fatalf("Second Save failed: %v", err)
+			}
+
+			loaded, err := devices.Load(ctx, "dev-1")
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if loaded.Hostname != "node-2" {
+				t.Errorf("Hostname = %q, want node-2", loaded.Hostname)
+			}
+			if err := devices.Delete(ctx, "dev-1"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	devices := NewResourceStorageWithSerializer[*versionedDevice](backend, "Device", prefixSerializer{})
+	if err := devices.Save(ctx, &versionedDevice{Metadata: versionedMetadata{UID: "dev-2"}}); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(root, "data", "devices", "dev-2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(raw, serializerPrefix) {
+		t.Errorf("stored data = %q, want the serializer's format", raw)
+	}
+
+	// JSON is rejected by the backend, and the default storage cannot read
+	// the serializer's format
+	if err := backend.Save(ctx, "Device", "dev-3", json.RawMessage(`{"metadata":{"uid":"dev-3"}}`)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Save of JSON = %v, want ErrInvalidData", err)
+	}
+	if _, err := NewResourceStorage[*versionedDevice](backend, "Device").Load(ctx, "dev-2"); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("JSON Load = %v, want ErrInvalidData", err)
+	}
+}
+
+// renamedDevice is versionedDevice in a "v2" that calls Hostname Name
+type renamedDevice struct {
+	Metadata versionedMetadata `json:"metadata"`
+	Name     string            `json:"name"`
+}
+
+func (d *renamedDevice) GetUID() string { return d.Metadata.UID }
+
+type testVersionRegistry struct{}
+
+func (testVersionRegistry) GetDefaultVersion(string) string { return "v1" }
+
+func (testVersionRegistry) GetVersion(_, version string) (VersionInfo, bool) {
+	return testVersionInfo(version), version == "v1" || version == "v2"
+}
+
+type testVersionInfo string
+
+func (v testVersionInfo) Constructor() interface{} {
+	if v == "v2" {
+		return &renamedDevice{}
+	}
+	return &versionedDevice{}
+}
+
+func (testVersionInfo) Converter() VersionConverter { return testConverter{} }
+
+type testConverter struct{}
+
+func (testConverter) Convert(resource interface{}, from, to string) (interface{}, error) {
+	switch r := resource.(type) {
+	case *versionedDevice:
+		return &renamedDevice{Metadata: r.Metadata, Name: r.Hostname}, nil
+	case *renamedDevice:
+		return &versionedDevice{Metadata: r.Metadata, Hostname: r.Name}, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T from %s to %s", resource, from, to)
+}
+
+func TestFileBackend_VersionedSerializer(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	backend.SetSerializer(prefixSerializer{})
+	backend.SetVersionRegistry(testVersionRegistry{})
+	devices := NewResourceStorageWithSerializer[*versionedDevice](backend, "Device", prefixSerializer{})
+	ctx := context.Background()
+
+	if err := devices.SaveWithVersion(ctx, &renamedDevice{Metadata: versionedMetadata{UID: "dev-1"}, Name: "node-1"}, "v2"); err != nil {
+		t.Fatalf("SaveWithVersion failed: %v", err)
+	}
+
+	stored, err := devices.Load(ctx, "dev-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Hostname != "node-1" {
+		t.Errorf("stored Hostname = %q, want node-1", stored.Hostname)
+	}
+
+	loaded, version, err := devices.LoadWithVersion(ctx, "dev-1", "v2")
+	if err != nil {
+		t.Fatalf("LoadWithVersion failed: %v", err)
+	}
+	if fields, ok := loaded.(map[string]interface{}); version != "v2" || !ok || fields["name"] != "node-1" {
+		t.Errorf("LoadWithVersion = %v, %s", loaded, version)
+	}
+}
+
+func TestSerializer_BackendReaders(t *testing.T) {
+	backend := NewMemoryBackend()
+	backend.SetSerializer(prefixSerializer{})
+	ctx := context.Background()
+	save := func(store StorageBackend, resourceType, uid string, v any) error {
+		data, err := prefixSerializer{}.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store.Save(ctx, resourceType, uid, data)
+	}
+
+	// The memory backend validates with the serializer
+	if err := backend.Save(ctx, "Lease", "lease-json", json.RawMessage(`{}`)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Save of JSON = %v, want ErrInvalidData", err)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	if err := save(backend, "Lease", "lease-1", map[string]any{"metadata": map[string]any{"uid": "lease-1", "expiresAt": past}}); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := NewReaper(backend, time.Minute, "Lease").Reap(ctx); err != nil || deleted != 1 {
+		t.Errorf("Reap = %d, %v, want 1 deleted", deleted, err)
+	}
+
+	orphan := gcBlade{Spec: gcBladeSpec{ChassisUID: "chs-gone"}}
+	orphan.Metadata.Initialize("blade-1", "blade-1")
+	if err := save(backend, "GCBlade", "blade-1", orphan); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := NewGarbageCollector(backend, time.Minute, "GCBlade").Collect(ctx); err != nil || deleted != 1 {
+		t.Errorf("Collect = %d, %v, want 1 deleted", deleted, err)
+	}
+
+	// The unique index reads the serializer of the backend it wraps
+	unique := NewUniqueIndexBackend(backend, map[string][]string{"GCBlade": {"spec.peerUID"}})
+	for _, uid := range []string{"blade-2", "blade-3"} {
+		blade := gcBlade{Spec: gcBladeSpec{PeerUID: "peer-1"}}
+		blade.Metadata.Initialize(uid, uid)
+		err := save(unique, "GCBlade", uid, blade)
+		if want := uid == "blade-2"; (err == nil) != want {
+			t.Errorf("Save %s = %v", uid, err)
+		}
+	}
+	if uid, err := FindByField(ctx, unique, "GCBlade", "spec.peerUID", "peer-1"); err != nil || uid != "blade-2" {
+		t.Errorf("FindByField = %q, %v, want blade-2", uid, err)
+	}
+}
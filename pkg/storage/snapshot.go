@@ -0,0 +1,127 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Iterator yields the resources of a snapshot one at a time:
+//
+//	it, err := storage.Snapshot(ctx, backend, "Device")
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//	for it.Next() {
+//		var device Device
+//		if err := json.Unmarshal(it.Resource(), &device); err != nil {
+//			return err
+//		}
+//	}
+//	return it.Err()
+type Iterator interface {
+	// Next advances to the next resource. It returns false once the
+	// snapshot is exhausted or iteration failed; check Err afterwards.
+	Next() bool
+
+	// Resource returns the resource Next advanced to
+	Resource() json.RawMessage
+
+	// Err returns the error that stopped iteration, or nil once the
+	// snapshot was exhausted
+	Err() error
+
+	// Close releases the snapshot. It is safe to call more than once.
+	Close() error
+}
+
+// SnapshotBackend is implemented by backends that can iterate over the
+// resources of a type as of one point in time, so that concurrent writes
+// do not produce a torn view.
+//
+// Guarantees per backend:
+//   - FileBackend: the set of resources is fixed when the snapshot is taken
+//     (the directory is listed under the backend's lock). Each resource is
+//     read whole, as of when the iterator reaches it; resources deleted since
+//     the snapshot was taken are skipped.
+//   - Ent (generated storage.Snapshot): all resources are read in one
+//     read-only, repeatable-read transaction, so they reflect one point in
+//     time. The transaction is held open until Close.
+type SnapshotBackend interface {
+	// Snapshot returns an iterator over the resources of resourceType in UID
+	// order. The iterator stops with ctx.Err() if ctx is done.
+	Snapshot(ctx context.Context, resourceType string) (Iterator, error)
+}
+
+// Snapshot iterates over the resources of resourceType using backend's
+// SnapshotBackend implementation. Other backends fall back to LoadAll, which
+// is as consistent as the backend's LoadAll and holds every resource in
+// memory.
+//
+// Returns:
+//   - Iterator: The resources; callers must Close it
+//   - error: Any error that occurred while taking the snapshot
+func Snapshot(ctx context.Context, backend StorageBackend, resourceType string) (Iterator, error) {
+	if snapshotter, ok := backend.(SnapshotBackend); ok {
+		return snapshotter.Snapshot(ctx, resourceType)
+	}
+
+	resources, err := backend.LoadAll(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	return &sliceIterator{resources: resources, index: -1}, nil
+}
+
+// ForEachResource calls fn with each resource of a Snapshot of resourceType,
+// stopping at the first error.
+func ForEachResource(ctx context.Context, backend StorageBackend, resourceType string, fn func(data json.RawMessage) error) error {
+	it, err := Snapshot(ctx, backend, resourceType)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if err := fn(it.Resource()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// sliceIterator iterates over resources already in memory
+type sliceIterator struct {
+	resources []json.RawMessage
+	index     int
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.index+1 >= len(it.resources) {
+		it.index = len(it.resources)
+		return false
+	}
+	it.index++
+	return true
+}
+
+func (it *sliceIterator) Resource() json.RawMessage {
+	if it.index < 0 || it.index >= len(it.resources) {
+		return nil
+	}
+	return it.resources[it.index]
+}
+
+func (it *sliceIterator) Err() error {
+	return nil
+}
+
+func (it *sliceIterator) Close() error {
+	it.resources = nil
+	it.index = 0
+	return nil
+}
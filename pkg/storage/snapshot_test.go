@@ -0,0 +1,114 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBackend_Snapshot(t *testing.T) {
+	backend, root := newTestFileBackend(t)
+	ctx := context.Background()
+
+	for _, uid := range []string{"dev-3", "dev-1", "dev-2"} {
+		if err := backend.Save(ctx, "Device", uid, json.RawMessage(`{"uid":"`+uid+`"}`)); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "data", "devices", "bad.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := Snapshot(ctx, backend, "Device")
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	defer it.Close()
+
+	// Writes after the snapshot do not change its membership
+	if err := backend.Save(ctx, "Device", "dev-4", json.RawMessage(`{"uid":"dev-4"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Delete(ctx, "Device", "dev-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for it.Next() {
+		var device struct {
+			UID string `json:"uid"`
+		}
+		if err := json.Unmarshal(it.Resource(), &device); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		got = append(got, device.UID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iteration failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != "dev-1" || got[1] != "dev-3" {
+		t.Errorf("Snapshot yielded %v, want [dev-1 dev-3]", got)
+	}
+	if it.Next() {
+		t.Error("Next after the end returned true")
+	}
+}
+
+func TestFileBackend_SnapshotStopsOnCancel(t *testing.T) {
+	backend, _ := newTestFileBackend(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := backend.Save(ctx, "Device", "dev-1", json.RawMessage(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	it, err := backend.Snapshot(ctx, "Device")
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	defer it.Close()
+
+	cancel()
+	if it.Next() {
+		t.Error("Next succeeded after cancellation")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestForEachResource_FallsBackToLoadAll(t *testing.T) {
+	file, _ := newTestFileBackend(t)
+	// Hide FileBackend's SnapshotBackend implementation
+	backend := loadOnlyBackend{file}
+	ctx := context.Background()
+
+	for _, uid := range []string{"dev-1", "dev-2"} {
+		if err := backend.Save(ctx, "Device", uid, json.RawMessage(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count := 0
+	err := ForEachResource(ctx, backend, "Device", func(data json.RawMessage) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 2 {
+		t.Errorf("ForEachResource = %v after %d resources, want 2", err, count)
+	}
+
+	stop := errors.New("stop")
+	err = ForEachResource(ctx, backend, "Device", func(data json.RawMessage) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected the callback error, got %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/fieldpath"
+)
+
+// SortKey orders resources by the value at a field path.
+type SortKey struct {
+	// Path selects the value to compare (e.g. metadata.createdAt)
+	Path fieldpath.Path
+
+	// Descending reverses the order for this key
+	Descending bool
+}
+
+// String formats the key as it appears in a sort expression.
+func (k SortKey) String() string {
+	if k.Descending {
+		return k.Path.String() + ":desc"
+	}
+	return k.Path.String()
+}
+
+// ParseSort parses a comma-separated list of sort keys, each a field path
+// with an optional ":asc" or ":desc" suffix:
+//
+//	metadata.createdAt:desc,metadata.name
+//
+// The empty expression returns no keys, which sorts by UID alone. Errors wrap
+// ErrInvalidData.
+func ParseSort(expr string) ([]SortKey, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	var keys []SortKey
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		key := SortKey{}
+		if i := strings.LastIndexByte(part, ':'); i >= 0 {
+			switch strings.ToLower(part[i+1:]) {
+			case "asc":
+			case "desc":
+				key.Descending = true
+			default:
+				return nil, fmt.Errorf("invalid sort direction in %q (want asc or desc): %w", part, ErrInvalidData)
+			}
+			part = part[:i]
+		}
+		if part == "" {
+			return nil, fmt.Errorf("invalid sort expression %q: empty field path: %w", expr, ErrInvalidData)
+		}
+		path, err := fieldpath.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sort expression %q: %v: %w", expr, err, ErrInvalidData)
+		}
+		key.Path = path
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Sort returns a copy of items ordered by keys. UID breaks ties, so the order
+// is total and repeatable; with no keys items are ordered by UID alone.
+//
+// Values are read from each item's JSON encoding. Missing fields sort before
+// present ones (after them when descending), and values of different JSON
+// types order as null < false < true < numbers < strings < objects/arrays.
+//
+// Example:
+//
+//	keys, err := storage.ParseSort("metadata.createdAt:desc")
+//	if err != nil {
+//	    return err
+//	}
+//	devices = storage.Sort(devices, keys)
+func Sort[T Identified](items []T, keys []SortKey) []T {
+	rows := sortRows(items, keys)
+	sorted := make([]T, len(rows))
+	for i, row := range rows {
+		sorted[i] = row.item
+	}
+	return sorted
+}
+
+// PaginateSorted is Paginate with the items ordered by keys rather than by
+// UID alone. Cursors returned for one sort expression are only valid with the
+// same expression.
+//
+// Example:
+//
+//	keys, err := storage.ParseSort(r.URL.Query().Get("sort"))
+//	if err != nil {
+//	    return err
+//	}
+//	page, next, err := storage.PaginateSorted(devices, keys, r.URL.Query().Get("cursor"), 50)
+func PaginateSorted[T Identified](items []T, keys []SortKey, cursor string, limit int) (page []T, next string, err error) {
+	var after []interface{}
+	if cursor != "" {
+		if after, err = decodeCursor(cursor, len(keys)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rows := sortRows(items, keys)
+	start := 0
+	if after != nil {
+		start = sort.Search(len(rows), func(i int) bool {
+			return compareTuples(rows[i].values, after, keys) > 0
+		})
+	}
+	rows = rows[start:]
+
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+		next = encodeCursor(rows[limit-1].values, len(keys))
+	}
+
+	// Never nil, so an empty page encodes as [] rather than null
+	page = make([]T, len(rows))
+	for i, row := range rows {
+		page[i] = row.item
+	}
+	return page, next, nil
+}
+
+// sortRow is an item with the values it sorts by: one per key, then its UID
+type sortRow[T Identified] struct {
+	item   T
+	values []interface{}
+}
+
+// sortRows extracts sort values from items and orders them
+func sortRows[T Identified](items []T, keys []SortKey) []sortRow[T] {
+	rows := make([]sortRow[T], len(items))
+	for i, item := range items {
+		values := make([]interface{}, 0, len(keys)+1)
+		if len(keys) > 0 {
+			var doc interface{}
+			if data, err := json.Marshal(item); err == nil {
+				_ = json.Unmarshal(data, &doc)
+			}
+			for _, key := range keys {
+				value, _ := key.Path.Get(doc)
+				values = append(values, value)
+			}
+		}
+		rows[i] = sortRow[T]{item: item, values: append(values, item.GetUID())}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareTuples(rows[i].values, rows[j].values, keys) < 0
+	})
+	return rows
+}
+
+// compareTuples compares sort values key by key, then by the trailing UID
+func compareTuples(a, b []interface{}, keys []SortKey) int {
+	for i, key := range keys {
+		if c := compareValues(a[i], b[i]); c != 0 {
+			if key.Descending {
+				return -c
+			}
+			return c
+		}
+	}
+	return compareValues(a[len(keys)], b[len(keys)])
+}
+
+// compareValues orders decoded JSON values, first by type rank, then by
+// value. Strings that both parse as RFC 3339 timestamps compare as times, so
+// offsets and fractional seconds order correctly.
+func compareValues(a, b interface{}) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+
+	switch av := a.(type) {
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		}
+		return 1
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	case string:
+		bv := b.(string)
+		if at, err := time.Parse(time.RFC3339Nano, av); err == nil {
+			if bt, err := time.Parse(time.RFC3339Nano, bv); err == nil {
+				return at.Compare(bt)
+			}
+		}
+		return strings.Compare(av, bv)
+	case nil:
+		return 0
+	}
+	return strings.Compare(fieldpath.Format(a), fieldpath.Format(b))
+}
+
+// typeRank orders JSON types: null, booleans, numbers, strings, then the rest
+func typeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case float64:
+		return 2
+	case string:
+		return 3
+	}
+	return 4
+}
+
+// encodeCursor encodes a row's sort values. Without sort keys the cursor is
+// the bare UID, as Paginate has always produced.
+func encodeCursor(values []interface{}, keys int) string {
+	if keys == 0 {
+		return encodeUIDCursor(values[0].(string))
+	}
+	data, _ := json.Marshal(values)
+	return encodeUIDCursor(string(data))
+}
+
+// decodeCursor reverses encodeCursor, checking it carries one value per key
+// plus the UID
+func decodeCursor(cursor string, keys int) ([]interface{}, error) {
+	raw, err := decodeUIDCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if keys == 0 {
+		return []interface{}{raw}, nil
+	}
+
+	var values []interface{}
+	if err := json.Unmarshal([]byte(raw), &values); err != nil || len(values) != keys+1 {
+		return nil, fmt.Errorf("invalid cursor %q for this sort order: %w", cursor, ErrInvalidData)
+	}
+	if _, ok := values[keys].(string); !ok {
+		return nil, fmt.Errorf("invalid cursor %q for this sort order: %w", cursor, ErrInvalidData)
+	}
+	return values, nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type sortItem struct {
+	UID  string `json:"uid"`
+	Rack string `json:"rack,omitempty"`
+	Slot int    `json:"slot"`
+}
+
+func (s sortItem) GetUID() string { return s.UID }
+
+func uids(items []sortItem) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.UID
+	}
+	return out
+}
+
+var sortItems = []sortItem{
+	{UID: "dev-3", Rack: "r2", Slot: 1},
+	{UID: "dev-1", Rack: "r1", Slot: 10},
+	{UID: "dev-5", Slot: 4},
+	{UID: "dev-2", Rack: "r1", Slot: 2},
+	{UID: "dev-4", Rack: "r2", Slot: 1},
+}
+
+func TestParseSort(t *testing.T) {
+	keys, err := ParseSort("rack:desc, slot ,metadata.labels['app.io/name']:ASC")
+	if err != nil {
+		t.Fatalf("ParseSort failed: %v", err)
+	}
+	got := []string{keys[0].String(), keys[1].String(), keys[2].String()}
+	want := []string{"rack:desc", "slot", "metadata.labels['app.io/name']"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if keys, err := ParseSort(""); err != nil || keys != nil {
+		t.Errorf("Expected no keys for empty expression, got %v, %v", keys, err)
+	}
+
+	for _, expr := range []string{"rack:sideways", ":desc", "rack,,slot", "spec..name"} {
+		if _, err := ParseSort(expr); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("ParseSort(%q): expected ErrInvalidData, got %v", expr, err)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"dev-1", "dev-2", "dev-3", "dev-4", "dev-5"}},
+		{"slot", []string{"dev-3", "dev-4", "dev-2", "dev-5", "dev-1"}},
+		{"slot:desc", []string{"dev-1", "dev-5", "dev-2", "dev-3", "dev-4"}},
+		// Missing rack sorts first; UID breaks the remaining ties
+		{"rack", []string{"dev-5", "dev-1", "dev-2", "dev-3", "dev-4"}},
+		{"rack:desc,slot:desc", []string{"dev-3", "dev-4", "dev-1", "dev-2", "dev-5"}},
+	}
+	for _, tt := range tests {
+		keys, err := ParseSort(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseSort(%q) failed: %v", tt.expr, err)
+		}
+		if got := uids(Sort(sortItems, keys)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Sort(%q): expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestPaginateSorted_WalksAllPages(t *testing.T) {
+	keys, _ := ParseSort("rack:desc,slot")
+
+	var seen []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(sortItems) {
+			t.Fatal("Pagination did not terminate")
+		}
+		page, next, err := PaginateSorted(sortItems, keys, cursor, 2)
+		if err != nil {
+			t.Fatalf("PaginateSorted failed: %v", err)
+		}
+		seen = append(seen, uids(page)...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	want := uids(Sort(sortItems, keys))
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("Expected %v, got %v", want, seen)
+	}
+}
+
+func TestPaginateSorted_RejectsCursorFromOtherOrder(t *testing.T) {
+	_, next, err := Paginate(sortItems, "", 2)
+	if err != nil || next == "" {
+		t.Fatalf("Paginate failed: %v", err)
+	}
+
+	keys, _ := ParseSort("slot")
+	if _, _, err := PaginateSorted(sortItems, keys, next, 2); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for a UID cursor, got %v", err)
+	}
+}
+
+func TestCompareValues_Timestamps(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// Fewer fractional digits sort after more as strings
+		{"2025-01-01T00:00:00Z", "2025-01-01T00:00:00.5Z", -1},
+		{"2025-01-01T00:00:00.5Z", "2025-01-01T00:00:00.25Z", 1},
+		// Offsets name the same instant
+		{"2025-01-01T02:00:00+02:00", "2025-01-01T00:00:00Z", 0},
+		{"2025-01-01T01:00:00+02:00", "2025-01-01T00:00:00Z", -1},
+		// Strings that are not both timestamps compare as strings
+		{"2025-01-01T00:00:00Z", "abc", -1},
+		{"r2", "r10", 1},
+	}
+	for _, tt := range tests {
+		if got := compareValues(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareValues(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSQLiteDSN is the default connection string for Ent projects using the
+// mattn/go-sqlite3 driver. Foreign keys must be enabled for Ent migrations.
+const DefaultSQLiteDSN = "file:./data.db?cache=shared&_fk=1"
+
+// SQLiteDSN validates a SQLite connection string and enables foreign keys.
+//
+// Ent refuses to migrate a SQLite database unless the foreign_keys pragma is on,
+// which fails at startup with a cryptic error. SQLiteDSN appends _fk=1 when it
+// is missing so user-supplied DSNs work. Generated projects use the
+// mattn/go-sqlite3 driver for both the "sqlite" and "sqlite3" settings.
+//
+// Parameters:
+//   - driver: The configured driver name ("sqlite" or "sqlite3" for SQLite)
+//   - dsn: The connection string; empty selects the driver's default DSN
+//
+// Returns:
+//   - string: The connection string with foreign keys enabled
+//   - error: ErrInvalidData if the DSN explicitly disables foreign keys
+//
+// DSNs for non-SQLite drivers are returned unchanged.
+//
+// Example:
+//
+//	dsn, err := storage.SQLiteDSN("sqlite3", "file:./app.db")
+//	// dsn == "file:./app.db?_fk=1"
+func SQLiteDSN(driver, dsn string) (string, error) {
+	if driver != "sqlite" && driver != "sqlite3" {
+		return dsn, nil
+	}
+	if dsn == "" {
+		return DefaultSQLiteDSN, nil
+	}
+
+	if SQLiteForeignKeysDisabled(dsn) {
+		return "", fmt.Errorf("%w: SQLite DSN %q disables foreign keys, which Ent requires", ErrInvalidData, dsn)
+	}
+	if SQLiteForeignKeysEnabled(dsn) {
+		return dsn, nil
+	}
+
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+		if strings.HasSuffix(dsn, "?") || strings.HasSuffix(dsn, "&") {
+			separator = ""
+		}
+	}
+	return dsn + separator + "_fk=1", nil
+}
+
+// SQLiteForeignKeysEnabled reports whether a mattn/go-sqlite3 DSN turns on foreign keys.
+func SQLiteForeignKeysEnabled(dsn string) bool {
+	for _, value := range sqliteQueryParams(dsn) {
+		switch strings.ToLower(value) {
+		case "_fk=1", "_fk=true", "_foreign_keys=1", "_foreign_keys=true":
+			return true
+		}
+	}
+	return false
+}
+
+// SQLiteForeignKeysDisabled reports whether a SQLite DSN explicitly turns off foreign keys.
+func SQLiteForeignKeysDisabled(dsn string) bool {
+	for _, value := range sqliteQueryParams(dsn) {
+		switch strings.ToLower(value) {
+		case "_fk=0", "_fk=false", "_foreign_keys=0", "_foreign_keys=false":
+			return true
+		}
+	}
+	return false
+}
+
+// sqliteQueryParams splits the query portion of a DSN into key=value pairs
+func sqliteQueryParams(dsn string) []string {
+	_, query, found := strings.Cut(dsn, "?")
+	if !found {
+		return nil
+	}
+	return strings.Split(query, "&")
+}
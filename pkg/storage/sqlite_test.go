@@ -0,0 +1,88 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSQLiteDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   string
+		dsn      string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "empty mattn dsn uses default",
+			driver:   "sqlite3",
+			expected: DefaultSQLiteDSN,
+		},
+		{
+			name:     "sqlite is an alias for the mattn driver",
+			driver:   "sqlite",
+			expected: DefaultSQLiteDSN,
+		},
+		{
+			name:     "appends _fk without query",
+			driver:   "sqlite3",
+			dsn:      "file:./app.db",
+			expected: "file:./app.db?_fk=1",
+		},
+		{
+			name:     "appends _fk to existing query",
+			driver:   "sqlite3",
+			dsn:      "file:./app.db?cache=shared",
+			expected: "file:./app.db?cache=shared&_fk=1",
+		},
+		{
+			name:     "keeps existing _fk",
+			driver:   "sqlite3",
+			dsn:      "file:./app.db?_fk=1&cache=shared",
+			expected: "file:./app.db?_fk=1&cache=shared",
+		},
+		{
+			name:     "appends _fk for sqlite alias",
+			driver:   "sqlite",
+			dsn:      "file:./app.db?cache=shared",
+			expected: "file:./app.db?cache=shared&_fk=1",
+		},
+		{
+			name:    "rejects disabled foreign keys",
+			driver:  "sqlite3",
+			dsn:     "file:./app.db?_fk=0",
+			wantErr: true,
+		},
+		{
+			name:     "leaves other drivers alone",
+			driver:   "postgres",
+			dsn:      "postgres://localhost/app?sslmode=disable",
+			expected: "postgres://localhost/app?sslmode=disable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dsn, err := SQLiteDSN(test.driver, test.dsn)
+			if test.wantErr {
+				if !errors.Is(err, ErrInvalidData) {
+					t.Fatalf("Expected ErrInvalidData, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if dsn != test.expected {
+				t.Errorf("Expected DSN %q, got %q", test.expected, dsn)
+			}
+			if test.driver != "postgres" && !SQLiteForeignKeysEnabled(dsn) {
+				t.Errorf("Expected foreign keys to be enabled in %q", dsn)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// StatInfo describes a stored resource without its data
+type StatInfo struct {
+	// Size is the stored size in bytes
+	Size int64
+
+	// ModTime is when the backend last wrote the resource (file mtime, row
+	// updated_at). Zero if the backend does not know.
+	ModTime time.Time
+}
+
+// StatBackend is implemented by backends that can report when a resource was
+// last written, independently of any timestamp in the resource itself.
+type StatBackend interface {
+	// Stat returns the size and modification time of a stored resource, or
+	// ErrNotFound if it does not exist.
+	Stat(ctx context.Context, resourceType, uid string) (StatInfo, error)
+}
+
+// Stat returns the size and modification time of a stored resource using
+// backend's StatBackend implementation. Other backends fall back to Load,
+// which gives the size and a zero ModTime.
+//
+// Returns:
+//   - StatInfo: Size and modification time
+//   - error: ErrNotFound if the resource doesn't exist, other errors for failures
+func Stat(ctx context.Context, backend StorageBackend, resourceType, uid string) (StatInfo, error) {
+	if statter, ok := backend.(StatBackend); ok {
+		return statter.Stat(ctx, resourceType, uid)
+	}
+
+	data, err := backend.Load(ctx, resourceType, uid)
+	if err != nil {
+		return StatInfo{}, err
+	}
+	return StatInfo{Size: int64(len(data))}, nil
+}
@@ -0,0 +1,58 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileBackend_Stat(t *testing.T) {
+	backend, root := newTestFileBackend(t)
+	ctx := context.Background()
+
+	if _, err := backend.Stat(ctx, "Device", "dev-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	data := json.RawMessage(`{"hostname":"node-1"}`)
+	if err := backend.Save(ctx, "Device", "dev-1", data); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(filepath.Join(root, "data", "devices", "dev-1.json"), modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := backend.Stat(ctx, "Device", "dev-1")
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Size != int64(len(data)) || !info.ModTime.Equal(modTime) {
+		t.Errorf("Stat = %+v, want size %d and mtime %s", info, len(data), modTime)
+	}
+}
+
+func TestStat_FallsBackToLoad(t *testing.T) {
+	file, _ := newTestFileBackend(t)
+	backend := loadOnlyBackend{file}
+	ctx := context.Background()
+
+	if err := backend.Save(ctx, "Device", "dev-1", json.RawMessage(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	info, err := Stat(ctx, backend, "Device", "dev-1")
+	if err != nil || info.Size != 2 || !info.ModTime.IsZero() {
+		t.Errorf("Stat = %+v, %v; want size 2 and no mtime", info, err)
+	}
+	if _, err := Stat(ctx, backend, "Device", "dev-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
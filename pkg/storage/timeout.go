@@ -0,0 +1,60 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultOperationTimeout bounds the storage calls of one request until
+// SetOperationTimeout is called.
+const DefaultOperationTimeout = 30 * time.Second
+
+var operationTimeout atomic.Int64
+
+func init() {
+	operationTimeout.Store(int64(DefaultOperationTimeout))
+}
+
+// SetOperationTimeout sets the deadline applied by WithOperationTimeout.
+// Zero or a negative value disables it.
+//
+// The generated server calls this at startup with the storage_timeout
+// setting.
+func SetOperationTimeout(d time.Duration) {
+	operationTimeout.Store(int64(d))
+}
+
+// OperationTimeout returns the deadline applied by WithOperationTimeout, or a
+// value <= 0 if storage calls are unbounded.
+func OperationTimeout() time.Duration {
+	return time.Duration(operationTimeout.Load())
+}
+
+// WithOperationTimeout derives a context for storage calls that expires after
+// OperationTimeout. The caller must call cancel to release its timer, even
+// when the timeout is disabled:
+//
+//	ctx, cancel := storage.WithOperationTimeout(r.Context())
+//	defer cancel()
+//	item, err := backend.Load(ctx, resourceType, uid)
+//	if storage.IsTimeout(err) {
+//	    // 504 Gateway Timeout
+//	}
+func WithOperationTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := OperationTimeout(); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// IsTimeout reports whether err was caused by a storage call running past its
+// context deadline.
+func IsTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
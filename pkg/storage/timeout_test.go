@@ -0,0 +1,72 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithOperationTimeout(t *testing.T) {
+	defer SetOperationTimeout(DefaultOperationTimeout)
+
+	SetOperationTimeout(10 * time.Millisecond)
+	ctx, cancel := WithOperationTimeout(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > 10*time.Millisecond {
+		t.Errorf("deadline %v away, want <= 10ms", remaining)
+	}
+
+	<-ctx.Done()
+	if !IsTimeout(ctx.Err()) {
+		t.Errorf("IsTimeout(%v) = false", ctx.Err())
+	}
+}
+
+func TestWithOperationTimeout_Disabled(t *testing.T) {
+	defer SetOperationTimeout(DefaultOperationTimeout)
+
+	SetOperationTimeout(0)
+	ctx, cancel := WithOperationTimeout(context.Background())
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline with the timeout disabled")
+	}
+	cancel()
+	if ctx.Err() != context.Canceled {
+		t.Errorf("ctx.Err() = %v after cancel, want context.Canceled", ctx.Err())
+	}
+}
+
+func TestWithOperationTimeout_FileBackend(t *testing.T) {
+	defer SetOperationTimeout(DefaultOperationTimeout)
+
+	backend, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend: %v", err)
+	}
+	defer backend.Close()
+
+	SetOperationTimeout(time.Nanosecond)
+	ctx, cancel := WithOperationTimeout(context.Background())
+	defer cancel()
+	<-ctx.Done()
+
+	err = backend.Save(ctx, "Device", "dev-1", json.RawMessage(`{}`))
+	if !IsTimeout(err) {
+		t.Errorf("Save error = %v, want a timeout", err)
+	}
+	if IsTimeout(fmt.Errorf("failed to save: %w", ErrNotFound)) {
+		t.Error("IsTimeout true for a non-deadline error")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// TransientError marks a storage failure that may succeed if the operation is
+// retried, such as a dropped database connection or a lock timeout.
+//
+// Backends wrap such failures with ClassifyError; callers test for them with
+// IsTransient rather than matching the type directly.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient reports whether err is a storage failure worth retrying.
+//
+// ErrNotFound, ErrAlreadyExists, ErrInvalidData and ErrConflict are never
+// transient, even when wrapped together with a transient cause: retrying the
+// same operation would fail the same way.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, permanent := range []error{ErrNotFound, ErrAlreadyExists, ErrInvalidData, ErrConflict} {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+	var transient *TransientError
+	return errors.As(err, &transient)
+}
+
+// ClassifyError wraps err in a TransientError if it was caused by a
+// condition that may clear on retry, and returns it unchanged otherwise.
+// Backends call it on the errors they return; it is safe to call on nil or
+// already-classified errors.
+//
+// Transient conditions:
+//   - Filesystem: EINTR, EAGAIN, EBUSY, EMFILE, ENFILE and ETIMEDOUT
+//   - Network: connection reset, refused or aborted, broken pipe, unexpected
+//     EOF, and net.Error timeouts
+//   - database/sql: driver.ErrBadConn
+//   - PostgreSQL: SQLSTATE class 08 (connection), 40001 (serialization
+//     failure), 40P01 (deadlock), 55P03 (lock not available), 57P03 (cannot
+//     connect now)
+//   - MySQL: errors 1205 (lock wait timeout) and 1213 (deadlock)
+//   - SQLite: SQLITE_BUSY and SQLITE_LOCKED ("database is locked")
+//
+// A context deadline or cancellation is not transient: the caller's budget
+// for the operation is spent (see IsTimeout).
+func ClassifyError(err error) error {
+	if err == nil || IsTransient(err) {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	if isTransientCause(err) {
+		return &TransientError{Err: err}
+	}
+	return err
+}
+
+// sqlStateError is implemented by PostgreSQL driver errors (pgx and lib/pq).
+type sqlStateError interface {
+	SQLState() string
+}
+
+// transientMessages are matched against the error text for drivers whose
+// error types fabrica does not import.
+var transientMessages = []string{
+	"database is locked",       // SQLite SQLITE_BUSY
+	"database table is locked", // SQLite SQLITE_LOCKED
+	"sqlite_busy",
+	"error 1205",       // MySQL lock wait timeout
+	"error 1213",       // MySQL deadlock
+	"bad connection",   // database/sql driver.ErrBadConn
+	"connection reset", // lost connection
+	"broken pipe",      // lost connection
+	"server closed the connection unexpectedly",
+}
+
+func isTransientCause(err error) bool {
+	for _, errno := range []syscall.Errno{
+		syscall.EINTR, syscall.EAGAIN, syscall.EBUSY, syscall.EMFILE, syscall.ENFILE, syscall.ETIMEDOUT,
+		syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED, syscall.EPIPE,
+	} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		switch state := stateErr.SQLState(); {
+		case strings.HasPrefix(state, "08"), state == "40001", state == "40P01", state == "55P03", state == "57P03":
+			return true
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range transientMessages {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+)
+
+type pgError struct{ code string }
+
+func (e pgError) Error() string    { return "pg error " + e.code }
+func (e pgError) SQLState() string { return e.code }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"busy file", &os.PathError{Op: "open", Path: "x", Err: syscall.EBUSY}, true},
+		{"too many open files", fmt.Errorf("failed to read file: %w", &os.PathError{Op: "open", Path: "x", Err: syscall.EMFILE}), true},
+		{"permission denied", &os.PathError{Op: "open", Path: "x", Err: syscall.EACCES}, false},
+		{"disk full", &os.PathError{Op: "write", Path: "x", Err: syscall.ENOSPC}, false},
+		{"bad connection", fmt.Errorf("query: %w", driver.ErrBadConn), true},
+		{"connection reset", errors.New("read tcp 10.0.0.1:5432: connection reset by peer"), true},
+		{"postgres serialization failure", pgError{"40001"}, true},
+		{"postgres connection failure", pgError{"08006"}, true},
+		{"postgres unique violation", pgError{"23505"}, false},
+		{"mysql lock wait timeout", errors.New("Error 1205 (HY000): Lock wait timeout exceeded"), true},
+		{"sqlite busy", errors.New("database is locked (5) (SQLITE_BUSY)"), true},
+		{"deadline", fmt.Errorf("load: %w", context.DeadlineExceeded), false},
+		{"invalid data", fmt.Errorf("%w: bad json", ErrInvalidData), false},
+		{"not found on a busy mount", fmt.Errorf("%w: %w", ErrNotFound, syscall.EBUSY), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ClassifyError(tt.err)
+			if got := IsTransient(err); got != tt.want {
+				t.Errorf("IsTransient(ClassifyError(%v)) = %v, want %v", tt.err, got, tt.want)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("ClassifyError(%v) lost the original error", tt.err)
+			}
+		})
+	}
+}
+
+func TestClassifyError_Idempotent(t *testing.T) {
+	err := ClassifyError(ClassifyError(driver.ErrBadConn))
+	var transient *TransientError
+	if !errors.As(err, &transient) {
+		t.Fatalf("expected a TransientError, got %T", err)
+	}
+	if _, nested := transient.Err.(*TransientError); nested {
+		t.Error("ClassifyError wrapped an already transient error again")
+	}
+	if err.Error() != driver.ErrBadConn.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), driver.ErrBadConn.Error())
+	}
+}
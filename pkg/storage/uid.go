@@ -0,0 +1,87 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MaxUIDLength is the longest UID accepted by DefaultUIDValidator.
+// It keeps file names well under common filesystem limits.
+const MaxUIDLength = 200
+
+// UIDValidator checks that a UID is safe to use as a storage key.
+// Implementations should return an error wrapping ErrInvalidData.
+type UIDValidator func(uid string) error
+
+var (
+	uidValidatorMu sync.RWMutex
+	uidValidator   UIDValidator = DefaultUIDValidator
+)
+
+// DefaultUIDValidator rejects UIDs that could escape a storage directory
+// or produce invalid keys.
+//
+// A UID is rejected if it:
+//   - is empty or longer than MaxUIDLength
+//   - consists only of dots (".", "..", ...)
+//   - contains a path separator ('/' or '\')
+//   - contains a NUL byte or other control character
+func DefaultUIDValidator(uid string) error {
+	if uid == "" {
+		return fmt.Errorf("%w: uid is empty", ErrInvalidData)
+	}
+	if len(uid) > MaxUIDLength {
+		return fmt.Errorf("%w: uid exceeds %d characters", ErrInvalidData, MaxUIDLength)
+	}
+	if strings.Trim(uid, ".") == "" {
+		return fmt.Errorf("%w: uid %q is not allowed", ErrInvalidData, uid)
+	}
+	if strings.ContainsAny(uid, `/\`) {
+		return fmt.Errorf("%w: uid %q contains a path separator", ErrInvalidData, uid)
+	}
+	for _, r := range uid {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: uid %q contains a control character", ErrInvalidData, uid)
+		}
+	}
+	return nil
+}
+
+// SetUIDValidator replaces the validator used by ValidateUID and the
+// built-in backends. Passing nil restores DefaultUIDValidator.
+//
+// Custom validators can tighten the rules (e.g. require a resource prefix)
+// but should still reject path separators for file-based storage.
+func SetUIDValidator(validator UIDValidator) {
+	uidValidatorMu.Lock()
+	defer uidValidatorMu.Unlock()
+
+	if validator == nil {
+		validator = DefaultUIDValidator
+	}
+	uidValidator = validator
+}
+
+// ValidateUID checks a UID with the configured validator.
+//
+// FileBackend and the generated Ent storage call this before touching
+// storage, custom backends must do the same, and handlers can call it to
+// reject bad input early:
+//
+//	if err := storage.ValidateUID(uid); err != nil {
+//	    respondError(w, http.StatusBadRequest, err)
+//	    return
+//	}
+func ValidateUID(uid string) error {
+	uidValidatorMu.RLock()
+	validator := uidValidator
+	uidValidatorMu.RUnlock()
+
+	return validator(uid)
+}
@@ -0,0 +1,433 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/openchami/fabrica/pkg/fieldpath"
+)
+
+// FieldLookupBackend is implemented by backends that can find a resource by
+// the value of a field without reading every resource of its type.
+type FieldLookupBackend interface {
+	// FindByField returns the UID of the resource of resourceType whose
+	// field (a path such as "spec.serial") has value, compared as
+	// fieldpath.Format renders it. It returns ErrNotFound if there is none
+	// and ErrConflict if more than one resource has the value.
+	FindByField(ctx context.Context, resourceType, field, value string) (string, error)
+}
+
+// FindByField returns the UID of the resource of resourceType whose field
+// has value, using backend's FieldLookupBackend implementation. Other
+// backends fall back to LoadAll, which reads every resource of the type.
+//
+// field is a path into the stored JSON ("spec.serial", "metadata.name") and
+// values are compared as fieldpath.Format renders them, so numbers and
+// booleans are matched by their JSON text ("42", "true"). Resources whose
+// field is missing or has its zero value ("", 0, false, null) are never
+// matched.
+//
+// Returns:
+//   - string: The UID of the matching resource
+//   - error: ErrNotFound if no resource has the value, ErrConflict if
+//     several do, other errors for failures
+//
+// Example:
+//
+//	uid, err := storage.FindByField(ctx, backend, "Device", "spec.serial", "SN-1234")
+func FindByField(ctx context.Context, backend StorageBackend, resourceType, field, value string) (string, error) {
+	if finder, ok := backend.(FieldLookupBackend); ok {
+		return finder.FindByField(ctx, resourceType, field, value)
+	}
+	return scanByField(ctx, backend, resourceType, field, value)
+}
+
+// scanByField implements FindByField with LoadAll
+func scanByField(ctx context.Context, backend StorageBackend, resourceType, field, value string) (string, error) {
+	path, err := fieldpath.Parse(field)
+	if err != nil {
+		return "", fmt.Errorf("invalid field %q: %w", field, err)
+	}
+	resources, err := backend.LoadAll(ctx, resourceType)
+	if err != nil {
+		return "", err
+	}
+
+	serializer := serializerOf(backend)
+	found := ""
+	for _, data := range resources {
+		uid, values, err := fieldValuesOf(serializer, resourceType, data, []fieldpath.Path{path})
+		if err != nil {
+			return "", err
+		}
+		if values[0] == "" || values[0] != value {
+			continue
+		}
+		if found != "" {
+			return "", sharedValueError(resourceType, field, value)
+		}
+		found = uid
+	}
+	if found == "" {
+		return "", NewStorageError("find", resourceType, value, ErrNotFound)
+	}
+	return found, nil
+}
+
+// fieldValuesOf returns metadata.uid and the values at paths of resource
+// data stored by serializer, as fieldpath.Format renders them. Missing
+// fields and zero values are returned as "".
+func fieldValuesOf(serializer Serializer, resourceType string, data json.RawMessage, paths []fieldpath.Path) (string, []string, error) {
+	doc, err := decodeDocument(serializer, resourceType, data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read resource fields: %w", ErrInvalidData)
+	}
+
+	values := make([]string, len(paths))
+	for i, path := range paths {
+		value, ok := path.Get(doc)
+		if !ok || value == false || value == float64(0) {
+			continue
+		}
+		values[i] = fieldpath.Format(value)
+	}
+	uid, _ := fieldpath.Lookup(doc, "metadata.uid")
+	uidString, _ := uid.(string)
+	return uidString, values, nil
+}
+
+// sharedValueError is returned when a lookup finds several resources with
+// the same value, which happens if they were stored before it was unique
+func sharedValueError(resourceType, field, value string) error {
+	return NewStorageError("find", resourceType, value, fmt.Errorf("%s is used by more than one resource: %w", field, ErrConflict))
+}
+
+// valueIndex maps the values of one field to UIDs
+type valueIndex struct {
+	uids   map[string]string // value -> UID
+	values map[string]string // UID -> value
+	shared map[string]bool   // values stored on more than one resource
+}
+
+// UniqueIndexBackend wraps a StorageBackend, keeps an in-memory index of
+// the given fields of each resource type and enforces that their values are
+// unique within the type.
+//
+// Saving a resource whose indexed field has a value another resource of the
+// type has fails with ErrAlreadyExists. Missing fields and zero values are
+// not indexed, so any number of resources may leave a unique field empty.
+// The check and the write happen under one lock, so concurrent writes cannot
+// both take a value, as long as every write goes through the same
+// UniqueIndexBackend. Resources that shared a value before the index existed
+// can still be saved, but looking the value up returns ErrConflict.
+//
+// The index of a type is built from LoadAll on its first use. Stored data
+// is decoded with the serializer of inner if it is a FileBackend or
+// MemoryBackend, or the one given to SetSerializer; formats other than JSON
+// need the type registered with resource.RegisterKind. Writes to indexed types are serialized; other types pass
+// straight through. A UniqueIndexBackend is safe for concurrent use if inner
+// is.
+type UniqueIndexBackend struct {
+	inner      StorageBackend
+	serializer Serializer                  // Format of stored data; nil means inner's
+	fields     map[string][]string         // resource type -> field paths
+	paths      map[string][]fieldpath.Path // resource type -> parsed fields
+
+	mu      sync.Mutex
+	indexes map[string][]*valueIndex // resource type -> index per field, built lazily
+}
+
+// NewUniqueIndexBackend wraps inner so the fields listed for each resource
+// type are indexed and unique. Fields are paths into the stored JSON, such
+// as "spec.serial"; it panics if one is invalid.
+//
+// Example:
+//
+//	backend, _ := storage.NewFileBackend("./data")
+//	unique := storage.NewUniqueIndexBackend(backend, map[string][]string{
+//	    "Device": {"spec.serial", "spec.macAddress"},
+//	})
+//	uid, err := storage.FindByField(ctx, unique, "Device", "spec.serial", "SN-1234")
+func NewUniqueIndexBackend(inner StorageBackend, fields map[string][]string) *UniqueIndexBackend {
+	u := &UniqueIndexBackend{
+		inner:   inner,
+		fields:  make(map[string][]string, len(fields)),
+		paths:   make(map[string][]fieldpath.Path, len(fields)),
+		indexes: make(map[string][]*valueIndex),
+	}
+	for resourceType, typeFields := range fields {
+		for _, field := range typeFields {
+			if slices.Contains(u.fields[resourceType], field) {
+				continue
+			}
+			u.fields[resourceType] = append(u.fields[resourceType], field)
+			u.paths[resourceType] = append(u.paths[resourceType], fieldpath.MustParse(field))
+		}
+	}
+	return u
+}
+
+// SetSerializer sets the format the index decodes stored data with, if
+// inner stores something other than JSON and is not itself a FileBackend or
+// MemoryBackend with that serializer. Call it before the backend is used.
+func (u *UniqueIndexBackend) SetSerializer(serializer Serializer) {
+	u.serializer = serializer
+}
+
+// codec returns the serializer of the stored data, so backends wrapping u
+// can find it
+func (u *UniqueIndexBackend) codec() Serializer {
+	if u.serializer != nil {
+		return u.serializer
+	}
+	return serializerOf(u.inner)
+}
+
+// indexLocked returns the field indexes of resourceType, building them if
+// needed. The caller must hold the lock.
+func (u *UniqueIndexBackend) indexLocked(ctx context.Context, resourceType string) ([]*valueIndex, error) {
+	if indexes, ok := u.indexes[resourceType]; ok {
+		return indexes, nil
+	}
+
+	resources, err := u.inner.LoadAll(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]*valueIndex, len(u.paths[resourceType]))
+	for i := range indexes {
+		indexes[i] = &valueIndex{uids: make(map[string]string), values: make(map[string]string), shared: make(map[string]bool)}
+	}
+	for _, data := range resources {
+		uid, values, err := fieldValuesOf(u.codec(), resourceType, data, u.paths[resourceType])
+		if err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if value == "" {
+				continue
+			}
+			if _, taken := indexes[i].uids[value]; taken {
+				indexes[i].shared[value] = true
+			}
+			indexes[i].uids[value] = uid
+			indexes[i].values[uid] = value
+		}
+	}
+	u.indexes[resourceType] = indexes
+	return indexes, nil
+}
+
+// FindByField implements FieldLookupBackend.FindByField. Fields that are not
+// indexed are looked up in the wrapped backend.
+func (u *UniqueIndexBackend) FindByField(ctx context.Context, resourceType, field, value string) (string, error) {
+	i := slices.Index(u.fields[resourceType], field)
+	if i < 0 {
+		return FindByField(ctx, u.inner, resourceType, field, value)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	indexes, err := u.indexLocked(ctx, resourceType)
+	if err != nil {
+		return "", err
+	}
+	if indexes[i].shared[value] {
+		return "", sharedValueError(resourceType, field, value)
+	}
+	uid, ok := indexes[i].uids[value]
+	if !ok || value == "" {
+		return "", NewStorageError("find", resourceType, value, ErrNotFound)
+	}
+	return uid, nil
+}
+
+// FindByName implements NameLookupBackend.FindByName, using the index if
+// NameField is indexed for resourceType
+func (u *UniqueIndexBackend) FindByName(ctx context.Context, resourceType, name string) (string, error) {
+	if !slices.Contains(u.fields[resourceType], NameField) {
+		return FindByName(ctx, u.inner, resourceType, name)
+	}
+	return u.FindByField(ctx, resourceType, NameField, name)
+}
+
+// write runs save if the indexed values in data are free for uid, and
+// records them if save stored the data. Types without indexed fields are
+// saved directly.
+func (u *UniqueIndexBackend) write(ctx context.Context, resourceType, uid string, data json.RawMessage, save func() (bool, error)) error {
+	if len(u.paths[resourceType]) == 0 {
+		_, err := save()
+		return err
+	}
+
+	_, values, err := fieldValuesOf(u.codec(), resourceType, data, u.paths[resourceType])
+	if err != nil {
+		return NewStorageError("save", resourceType, uid, err)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	indexes, err := u.indexLocked(ctx, resourceType)
+	if err != nil {
+		return err
+	}
+	for i, value := range values {
+		// A resource keeps its value even if others share it from before the index
+		if owner, taken := indexes[i].uids[value]; value != "" && taken && indexes[i].values[uid] != value {
+			return NewStorageError("save", resourceType, uid, fmt.Errorf("%s %q is already used by %s: %w", u.fields[resourceType][i], value, owner, ErrAlreadyExists))
+		}
+	}
+
+	saved, err := save()
+	if err != nil || !saved {
+		return err
+	}
+	for i, value := range values {
+		if old, ok := indexes[i].values[uid]; ok && old != value {
+			if indexes[i].shared[old] {
+				// Another resource still has the old value; rebuild on next use
+				delete(u.indexes, resourceType)
+				return nil
+			}
+			delete(indexes[i].uids, old)
+			delete(indexes[i].values, uid)
+		}
+		if value != "" {
+			indexes[i].uids[value] = uid
+			indexes[i].values[uid] = value
+		}
+	}
+	return nil
+}
+
+// LoadAll implements StorageBackend.LoadAll
+func (u *UniqueIndexBackend) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	return u.inner.LoadAll(ctx, resourceType)
+}
+
+// Load implements StorageBackend.Load
+func (u *UniqueIndexBackend) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	return u.inner.Load(ctx, resourceType, uid)
+}
+
+// LoadMany implements StorageBackend.LoadMany
+func (u *UniqueIndexBackend) LoadMany(ctx context.Context, resourceType string, uids []string) (map[string]json.RawMessage, error) {
+	return u.inner.LoadMany(ctx, resourceType, uids)
+}
+
+// Save implements StorageBackend.Save, returning ErrAlreadyExists if another
+// resource has the value of a unique field
+func (u *UniqueIndexBackend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	return u.write(ctx, resourceType, uid, data, func() (bool, error) {
+		return true, u.inner.Save(ctx, resourceType, uid, data)
+	})
+}
+
+// SaveIfVersion implements ConditionalSaver.SaveIfVersion, using the wrapped
+// backend's implementation if it has one
+func (u *UniqueIndexBackend) SaveIfVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, expectedVersion string) error {
+	return u.write(ctx, resourceType, uid, data, func() (bool, error) {
+		return true, saveIfVersion(ctx, u.inner, nil, resourceType, uid, data, expectedVersion)
+	})
+}
+
+// CompareAndSwap implements StorageBackend.CompareAndSwap, returning
+// ErrAlreadyExists if another resource has the value of a unique field
+func (u *UniqueIndexBackend) CompareAndSwap(ctx context.Context, resourceType, uid string, expected, data json.RawMessage) (bool, error) {
+	swapped := false
+	err := u.write(ctx, resourceType, uid, data, func() (bool, error) {
+		var err error
+		swapped, err = u.inner.CompareAndSwap(ctx, resourceType, uid, expected, data)
+		return swapped, err
+	})
+	return swapped, err
+}
+
+// Delete implements StorageBackend.Delete
+func (u *UniqueIndexBackend) Delete(ctx context.Context, resourceType, uid string) error {
+	if len(u.paths[resourceType]) == 0 {
+		return u.inner.Delete(ctx, resourceType, uid)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := u.inner.Delete(ctx, resourceType, uid); err != nil {
+		return err
+	}
+	indexes, ok := u.indexes[resourceType]
+	if !ok {
+		return nil
+	}
+	for _, index := range indexes {
+		value, ok := index.values[uid]
+		if !ok {
+			continue
+		}
+		if index.shared[value] {
+			// Another resource may still have the value; rebuild on next use
+			delete(u.indexes, resourceType)
+			return nil
+		}
+		delete(index.uids, value)
+		delete(index.values, uid)
+	}
+	return nil
+}
+
+// Exists implements StorageBackend.Exists
+func (u *UniqueIndexBackend) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	return u.inner.Exists(ctx, resourceType, uid)
+}
+
+// List implements StorageBackend.List
+func (u *UniqueIndexBackend) List(ctx context.Context, resourceType string) ([]string, error) {
+	return u.inner.List(ctx, resourceType)
+}
+
+// Count implements StorageBackend.Count
+func (u *UniqueIndexBackend) Count(ctx context.Context, resourceType string) (int, error) {
+	return u.inner.Count(ctx, resourceType)
+}
+
+// Stat implements StatBackend.Stat using the wrapped backend
+func (u *UniqueIndexBackend) Stat(ctx context.Context, resourceType, uid string) (StatInfo, error) {
+	return Stat(ctx, u.inner, resourceType, uid)
+}
+
+// Snapshot implements SnapshotBackend.Snapshot using the wrapped backend
+func (u *UniqueIndexBackend) Snapshot(ctx context.Context, resourceType string) (Iterator, error) {
+	return Snapshot(ctx, u.inner, resourceType)
+}
+
+// Close closes the wrapped backend
+func (u *UniqueIndexBackend) Close() error {
+	return u.inner.Close()
+}
+
+// LoadWithVersion implements StorageBackend.LoadWithVersion
+func (u *UniqueIndexBackend) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (json.RawMessage, string, error) {
+	return u.inner.LoadWithVersion(ctx, resourceType, uid, version)
+}
+
+// LoadAllWithVersion implements StorageBackend.LoadAllWithVersion
+func (u *UniqueIndexBackend) LoadAllWithVersion(ctx context.Context, resourceType, version string) ([]json.RawMessage, error) {
+	return u.inner.LoadAllWithVersion(ctx, resourceType, version)
+}
+
+// SaveWithVersion implements StorageBackend.SaveWithVersion, returning
+// ErrAlreadyExists if another resource has the value of a unique field
+func (u *UniqueIndexBackend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	return u.write(ctx, resourceType, uid, data, func() (bool, error) {
+		return true, u.inner.SaveWithVersion(ctx, resourceType, uid, data, version)
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func serialDevice(uid, serial string, slot int) json.RawMessage {
+	data, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]string{"uid": uid},
+		"spec":     map[string]interface{}{"serial": serial, "slot": slot},
+	})
+	return data
+}
+
+func TestFindByField(t *testing.T) {
+	backend := NewMemoryBackend()
+	ctx := context.Background()
+	backend.Save(ctx, "Device", "dev-1", serialDevice("dev-1", "SN-1", 1))
+	backend.Save(ctx, "Device", "dev-2", serialDevice("dev-2", "SN-2", 2))
+	backend.Save(ctx, "Device", "dev-3", serialDevice("dev-3", "SN-2", 0))
+
+	if uid, err := FindByField(ctx, backend, "Device", "spec.serial", "SN-1"); err != nil || uid != "dev-1" {
+		t.Errorf("FindByField = %q, %v, want dev-1", uid, err)
+	}
+	if uid, err := FindByField(ctx, backend, "Device", "spec.slot", "2"); err != nil || uid != "dev-2" {
+		t.Errorf("FindByField of a number = %q, %v, want dev-2", uid, err)
+	}
+	if _, err := FindByField(ctx, backend, "Device", "spec.serial", "SN-2"); !errors.Is(err, ErrConflict) {
+		t.Errorf("FindByField of a shared value = %v, want ErrConflict", err)
+	}
+	for _, value := range []string{"SN-9", "", "0"} {
+		field := "spec.serial"
+		if value == "0" {
+			field = "spec.slot"
+		}
+		if _, err := FindByField(ctx, backend, "Device", field, value); !errors.Is(err, ErrNotFound) {
+			t.Errorf("FindByField(%s, %q) = %v, want ErrNotFound", field, value, err)
+		}
+	}
+}
+
+func TestUniqueIndexBackend(t *testing.T) {
+	inner := NewMemoryBackend()
+	ctx := context.Background()
+	inner.Save(ctx, "Device", "dev-1", serialDevice("dev-1", "SN-1", 1))
+	backend := NewUniqueIndexBackend(inner, map[string][]string{"Device": {"spec.serial", "spec.slot"}})
+
+	if err := backend.Save(ctx, "Device", "dev-2", serialDevice("dev-2", "SN-1", 2)); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Save of a taken serial = %v, want ErrAlreadyExists", err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-2", serialDevice("dev-2", "SN-2", 1)); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Save of a taken slot = %v, want ErrAlreadyExists", err)
+	}
+	if exists, _ := inner.Exists(ctx, "Device", "dev-2"); exists {
+		t.Error("rejected resource was saved")
+	}
+
+	// Zero values are not unique
+	for _, uid := range []string{"dev-2", "dev-3"} {
+		if err := backend.Save(ctx, "Device", uid, serialDevice(uid, "", 0)); err != nil {
+			t.Errorf("Save with empty unique fields failed: %v", err)
+		}
+	}
+
+	// Changing a value frees the old one
+	if err := backend.Save(ctx, "Device", "dev-1", serialDevice("dev-1", "SN-10", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-2", serialDevice("dev-2", "SN-1", 2)); err != nil {
+		t.Errorf("Save of a freed serial failed: %v", err)
+	}
+	if uid, err := FindByField(ctx, backend, "Device", "spec.serial", "SN-10"); err != nil || uid != "dev-1" {
+		t.Errorf("FindByField = %q, %v, want dev-1", uid, err)
+	}
+
+	if err := backend.Delete(ctx, "Device", "dev-2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(ctx, "Device", "dev-3", serialDevice("dev-3", "SN-1", 2)); err != nil {
+		t.Errorf("Save of a deleted resource's values failed: %v", err)
+	}
+
+	// Fields that are not indexed are looked up in the wrapped backend
+	if uid, err := FindByField(ctx, backend, "Device", "metadata.uid", "dev-3"); err != nil || uid != "dev-3" {
+		t.Errorf("FindByField of an unindexed field = %q, %v, want dev-3", uid, err)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrBrokenReference is matched by errors.Is for errors returned by
+// CheckReferences when a referenced resource does not exist
+var ErrBrokenReference = errors.New("broken reference")
+
+// Reference is a field of a resource holding the UID of another resource,
+// declared with a ref struct tag:
+//
+//	type NodeSpec struct {
+//	    ChassisUID string   `json:"chassisUID" ref:"Chassis"`
+//	    NICUIDs    []string `json:"nicUIDs" ref:"NIC"`
+//	    RackUID    string   `json:"rackUID" ref:"Rack,owner"`
+//	}
+//
+// The owner option marks the referenced resource as the owner of the
+// referencing one.
+type Reference struct {
+	// Field is the JSON path of the field, e.g. spec.nicUIDs[1]
+	Field string
+
+	// Kind is the referenced resource kind
+	Kind string
+
+	// UID is the referenced resource's UID
+	UID string
+
+	// Owner is set for ref:"Kind,owner" fields
+	Owner bool
+}
+
+// ReferenceResolver reports whether a resource exists.
+// storage.StorageBackend implements it.
+type ReferenceResolver interface {
+	Exists(ctx context.Context, resourceType, uid string) (bool, error)
+}
+
+// ReferenceResolverFunc adapts a function to a ReferenceResolver
+type ReferenceResolverFunc func(ctx context.Context, resourceType, uid string) (bool, error)
+
+// Exists calls f
+func (f ReferenceResolverFunc) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	return f(ctx, resourceType, uid)
+}
+
+// ReferenceErrors lists the broken references found by CheckReferences.
+// It matches ErrBrokenReference.
+type ReferenceErrors struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (re ReferenceErrors) Error() string {
+	var msgs []string
+	for _, err := range re.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrBrokenReference
+func (re ReferenceErrors) Is(target error) bool {
+	return target == ErrBrokenReference
+}
+
+// FindReferences returns the non-empty references in obj, a struct or a
+// pointer to one, in field order. Nested structs, pointers to structs and
+// slices of structs are searched too; embedded structs are inlined as
+// encoding/json does.
+func FindReferences(obj interface{}) []Reference {
+	var refs []Reference
+	findReferences(reflect.ValueOf(obj), "", &refs)
+	return refs
+}
+
+func findReferences(v reflect.Value, path string, refs *[]Reference) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			findReferences(v.Index(i), fmt.Sprintf("%s[%d]", path, i), refs)
+		}
+		return
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldPath := path
+		if !field.Anonymous || name != "" {
+			if name == "" {
+				name = field.Name
+			}
+			fieldPath = joinPath(path, name)
+		}
+
+		ref, ok := field.Tag.Lookup("ref")
+		if !ok {
+			findReferences(v.Field(i), fieldPath, refs)
+			continue
+		}
+		kind, options, _ := strings.Cut(ref, ",")
+		appendReferences(v.Field(i), fieldPath, Reference{Kind: kind, Owner: options == "owner"}, refs)
+	}
+}
+
+// appendReferences appends a reference to ref.Kind for each non-empty UID in
+// a ref field: a string, a pointer to one, or a slice of strings
+func appendReferences(v reflect.Value, path string, ref Reference, refs *[]Reference) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if v.String() != "" {
+			ref.Field, ref.UID = path, v.String()
+			*refs = append(*refs, ref)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			appendReferences(v.Index(i), fmt.Sprintf("%s[%d]", path, i), ref, refs)
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// CheckReferences verifies that every resource obj references through a ref
+// struct tag exists in backend. Empty UIDs are not references and are
+// skipped, so optional references can be left unset.
+//
+// Returns:
+//   - nil if every reference resolves
+//   - ReferenceErrors, matching ErrBrokenReference, listing each broken reference
+//   - the backend's error if a lookup fails
+func CheckReferences(ctx context.Context, backend ReferenceResolver, obj interface{}) error {
+	return checkReferences(ctx, backend, FindReferences(obj))
+}
+
+// CheckChangedReferences is CheckReferences for an update of obj whose
+// stored spec was previousSpec. Only references in obj's spec that
+// previousSpec does not already hold are checked: references in status, and
+// ones that were already there, are left alone, so a resource whose
+// referent was deleted can still be updated, e.g. to remove the reference.
+func CheckChangedReferences(ctx context.Context, backend ReferenceResolver, previousSpec, obj interface{}) error {
+	held := make(map[string]bool) // kind/uid
+	for _, ref := range FindReferences(previousSpec) {
+		held[ref.Kind+"/"+ref.UID] = true
+	}
+
+	var added []Reference
+	for _, ref := range FindReferences(obj) {
+		inSpec := strings.HasPrefix(ref.Field, "spec.") || strings.HasPrefix(ref.Field, "spec[")
+		if inSpec && !held[ref.Kind+"/"+ref.UID] {
+			added = append(added, ref)
+		}
+	}
+	return checkReferences(ctx, backend, added)
+}
+
+func checkReferences(ctx context.Context, backend ReferenceResolver, refs []Reference) error {
+	var broken ReferenceErrors
+	exists := make(map[string]bool) // kind/uid -> found
+
+	for _, ref := range refs {
+		key := ref.Kind + "/" + ref.UID
+		found, checked := exists[key]
+		if !checked {
+			var err error
+			found, err = backend.Exists(ctx, ref.Kind, ref.UID)
+			if err != nil {
+				return fmt.Errorf("failed to check %s reference %s: %w", ref.Field, ref.UID, err)
+			}
+			exists[key] = found
+		}
+		if !found {
+			broken.Errors = append(broken.Errors, FieldError{
+				Field:   ref.Field,
+				Tag:     "ref",
+				Value:   ref.UID,
+				Message: fmt.Sprintf("%s references %s %s, which does not exist", ref.Field, ref.Kind, ref.UID),
+			})
+		}
+	}
+
+	if len(broken.Errors) > 0 {
+		return broken
+	}
+	return nil
+}
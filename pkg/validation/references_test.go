@@ -0,0 +1,146 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type refMeta struct {
+	UID string `json:"uid"`
+}
+
+type refPort struct {
+	SwitchUID string `json:"switchUID" ref:"Switch"`
+}
+
+type refSpec struct {
+	ChassisUID string    `json:"chassisUID" ref:"Chassis"`
+	RackUID    *string   `json:"rackUID,omitempty" ref:"Rack,owner"`
+	NICUIDs    []string  `json:"nicUIDs" ref:"NIC"`
+	Ports      []refPort `json:"ports"`
+	Ignored    string    `json:"-" ref:"Chassis"`
+	Loose      string    `json:"loose"`
+}
+
+type refNode struct {
+	refMeta `json:"metadata"`
+	Spec    refSpec `json:"spec"`
+}
+
+type existsMap map[string]bool
+
+func (m existsMap) Exists(_ context.Context, resourceType, uid string) (bool, error) {
+	return m[resourceType+"/"+uid], nil
+}
+
+func TestFindReferences(t *testing.T) {
+	rack := "rack-1"
+	node := &refNode{Spec: refSpec{
+		ChassisUID: "chs-1",
+		RackUID:    &rack,
+		NICUIDs:    []string{"nic-1", "", "nic-2"},
+		Ports:      []refPort{{SwitchUID: "sw-1"}, {}},
+		Ignored:    "chs-2",
+		Loose:      "anything",
+	}}
+
+	want := []Reference{
+		{Field: "spec.chassisUID", Kind: "Chassis", UID: "chs-1"},
+		{Field: "spec.rackUID", Kind: "Rack", UID: "rack-1", Owner: true},
+		{Field: "spec.nicUIDs[0]", Kind: "NIC", UID: "nic-1"},
+		{Field: "spec.nicUIDs[2]", Kind: "NIC", UID: "nic-2"},
+		{Field: "spec.ports[0].switchUID", Kind: "Switch", UID: "sw-1"},
+	}
+	if got := FindReferences(node); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindReferences = %+v, want %+v", got, want)
+	}
+
+	if refs := FindReferences(&refNode{}); len(refs) != 0 {
+		t.Errorf("Unset references should be skipped, got %+v", refs)
+	}
+}
+
+func TestCheckReferences(t *testing.T) {
+	backend := existsMap{"Chassis/chs-1": true, "NIC/nic-1": true}
+	ctx := context.Background()
+
+	valid := &refNode{Spec: refSpec{ChassisUID: "chs-1", NICUIDs: []string{"nic-1"}}}
+	if err := CheckReferences(ctx, backend, valid); err != nil {
+		t.Errorf("CheckReferences = %v, want nil", err)
+	}
+
+	broken := &refNode{Spec: refSpec{ChassisUID: "chs-9", NICUIDs: []string{"nic-1", "nic-9"}}}
+	err := CheckReferences(ctx, backend, broken)
+	if !errors.Is(err, ErrBrokenReference) {
+		t.Fatalf("CheckReferences = %v, want ErrBrokenReference", err)
+	}
+	var refErrs ReferenceErrors
+	if !errors.As(err, &refErrs) || len(refErrs.Errors) != 2 {
+		t.Fatalf("Expected 2 broken references, got %v", err)
+	}
+	if fe := refErrs.Errors[1]; fe.Field != "spec.nicUIDs[1]" || fe.Tag != "ref" || fe.Value != "nic-9" {
+		t.Errorf("Unexpected field error: %+v", fe)
+	}
+}
+
+func TestCheckReferences_LookupError(t *testing.T) {
+	unavailable := errors.New("database unavailable")
+	lookups := 0
+	backend := ReferenceResolverFunc(func(context.Context, string, string) (bool, error) {
+		lookups++
+		return false, unavailable
+	})
+
+	node := &refNode{Spec: refSpec{ChassisUID: "chs-1", NICUIDs: []string{"nic-1"}}}
+	err := CheckReferences(context.Background(), backend, node)
+	if !errors.Is(err, unavailable) || errors.Is(err, ErrBrokenReference) {
+		t.Errorf("CheckReferences = %v, want the lookup error", err)
+	}
+	if lookups != 1 {
+		t.Errorf("lookups = %d, want 1", lookups)
+	}
+}
+
+func TestCheckReferences_LooksUpEachUIDOnce(t *testing.T) {
+	lookups := 0
+	backend := ReferenceResolverFunc(func(context.Context, string, string) (bool, error) {
+		lookups++
+		return true, nil
+	})
+
+	node := &refNode{Spec: refSpec{NICUIDs: []string{"nic-1", "nic-1", "nic-1"}}}
+	if err := CheckReferences(context.Background(), backend, node); err != nil {
+		t.Fatal(err)
+	}
+	if lookups != 1 {
+		t.Errorf("lookups = %d, want 1", lookups)
+	}
+}
+
+func TestCheckChangedReferences(t *testing.T) {
+	backend := existsMap{"NIC/nic-1": true}
+	ctx := context.Background()
+
+	// chs-9 and nic-9 were deleted after they were stored
+	previous := refSpec{ChassisUID: "chs-9", NICUIDs: []string{"nic-9", "nic-1"}}
+
+	// Dropping a broken reference, or keeping one, is allowed
+	kept := &refNode{Spec: refSpec{ChassisUID: "chs-9", NICUIDs: []string{"nic-1"}}}
+	if err := CheckChangedReferences(ctx, backend, previous, kept); err != nil {
+		t.Errorf("CheckChangedReferences = %v, want nil", err)
+	}
+
+	// New references are still checked
+	added := &refNode{Spec: refSpec{ChassisUID: "chs-8", NICUIDs: []string{"nic-9"}}}
+	err := CheckChangedReferences(ctx, backend, previous, added)
+	var refErrs ReferenceErrors
+	if !errors.As(err, &refErrs) || len(refErrs.Errors) != 1 || refErrs.Errors[0].Field != "spec.chassisUID" {
+		t.Errorf("CheckChangedReferences = %v, want only spec.chassisUID broken", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -83,6 +84,11 @@ func init() {
 	_ = validate.RegisterValidation("labelvalue", validateLabelValue)
 	_ = validate.RegisterValidation("dnssubdomain", validateDNSSubdomain)
 	_ = validate.RegisterValidation("dnslabel", validateDNSLabel)
+	_ = validate.RegisterValidation("pattern", validatePattern)
+
+	// immutable is enforced on update by resource.CheckImmutable, which
+	// compares against the stored value; a single value is always valid
+	_ = validate.RegisterValidation("immutable", func(validator.FieldLevel) bool { return true })
 }
 
 // ValidateResource validates a resource using struct tags
@@ -175,6 +181,8 @@ func getErrorMessage(err validator.FieldError) string {
 		return fmt.Sprintf("%s must be a valid DNS subdomain", field)
 	case "dnslabel":
 		return fmt.Sprintf("%s must be a valid DNS label", field)
+	case "pattern":
+		return fmt.Sprintf("%s must match the required pattern", field)
 	default:
 		return fmt.Sprintf("%s failed validation (%s)", field, err.Tag())
 	}
@@ -206,9 +214,19 @@ func validateK8sName(fl validator.FieldLevel) bool {
 }
 
 // validateLabelKey validates a Kubernetes label key
-// Format: [prefix/]name where name is required and prefix is optional
 func validateLabelKey(fl validator.FieldLevel) bool {
-	key := fl.Field().String()
+	return IsValidLabelKey(fl.Field().String())
+}
+
+// validateLabelValue validates a Kubernetes label value
+func validateLabelValue(fl validator.FieldLevel) bool {
+	return IsValidLabelValue(fl.Field().String())
+}
+
+// IsValidLabelKey reports whether key is a valid Kubernetes label key, the
+// rule of the "labelkey" validator.
+// Format: [prefix/]name where name is required and prefix is optional
+func IsValidLabelKey(key string) bool {
 	if len(key) == 0 {
 		return false
 	}
@@ -233,11 +251,10 @@ func validateLabelKey(fl validator.FieldLevel) bool {
 	return isValidLabelName(key)
 }
 
-// validateLabelValue validates a Kubernetes label value
+// IsValidLabelValue reports whether value is a valid Kubernetes label value,
+// the rule of the "labelvalue" validator.
 // Must be empty or 1-63 alphanumeric characters, dashes, underscores, or dots
-func validateLabelValue(fl validator.FieldLevel) bool {
-	value := fl.Field().String()
-
+func IsValidLabelValue(value string) bool {
 	// Empty is valid
 	if len(value) == 0 {
 		return true
@@ -346,6 +363,65 @@ func isValidDNSLabel(label string) bool {
 	return true
 }
 
+// PatternTag is the struct tag holding the regular expression checked by the
+// "pattern" validator. Regular expressions cannot be written inside a
+// validate tag (commas and pipes are separators there), so they live in
+// their own tag:
+//
+//	Serial string `json:"serial" validate:"required,pattern" pattern:"^[A-Z]{2}[0-9]{6}$"`
+const PatternTag = "pattern"
+
+// patternCache holds compiled PatternTag expressions, keyed by source
+var patternCache sync.Map
+
+// validatePattern checks a string field against its PatternTag expression.
+// Fields without the tag, or with an invalid expression, fail validation.
+func validatePattern(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr || parent.Kind() == reflect.Interface {
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return false
+	}
+
+	// Elements validated with dive are named like "Tags[0]"
+	name := fl.StructFieldName()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	field, ok := parent.Type().FieldByName(name)
+	if !ok {
+		return false
+	}
+	expr, ok := field.Tag.Lookup(PatternTag)
+	if !ok {
+		return false
+	}
+
+	re, err := compilePattern(expr)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(fl.Field().String())
+}
+
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if cached, ok := patternCache.Load(expr); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(expr, re)
+	return re, nil
+}
+
 // RegisterCustomValidator registers a custom validation function
 func RegisterCustomValidator(tag string, fn validator.Func) error {
 	return validate.RegisterValidation(tag, fn)
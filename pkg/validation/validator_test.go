@@ -100,6 +100,16 @@ func TestValidateResource_InvalidEmail(t *testing.T) {
 	}
 }
 
+func TestValidateResource_ImmutableTag(t *testing.T) {
+	resource := struct {
+		Serial string `json:"serial" validate:"required,immutable"`
+	}{Serial: "SN1"}
+
+	if err := ValidateResource(&resource); err != nil {
+		t.Errorf("Expected immutable tag to pass validation, got: %v", err)
+	}
+}
+
 // Test ValidateWithContext
 
 func TestValidateWithContext_Valid(t *testing.T) {
@@ -460,3 +470,40 @@ func TestRegisterCustomValidator(t *testing.T) {
 		t.Error("Expected validation error for invalid custom validation")
 	}
 }
+
+func TestValidatePattern(t *testing.T) {
+	type PatternResource struct {
+		Serial string   `json:"serial" validate:"required,pattern" pattern:"^[A-Z]{2}[0-9]{4}$"`
+		Rack   string   `json:"rack,omitempty" validate:"omitempty,pattern" pattern:"^r[0-9]+$"`
+		Tags   []string `json:"tags,omitempty" validate:"dive,pattern" pattern:"^[a-z]+$"`
+		NoTag  string   `json:"noTag,omitempty" validate:"omitempty,pattern"`
+	}
+
+	tests := []struct {
+		name     string
+		resource PatternResource
+		wantErr  bool
+	}{
+		{"valid", PatternResource{Serial: "AB1234", Rack: "r12", Tags: []string{"gpu"}}, false},
+		{"optional empty", PatternResource{Serial: "AB1234"}, false},
+		{"mismatch", PatternResource{Serial: "ab1234"}, true},
+		{"optional mismatch", PatternResource{Serial: "AB1234", Rack: "rack1"}, true},
+		{"dive mismatch", PatternResource{Serial: "AB1234", Tags: []string{"gpu", "GPU"}}, true},
+		{"missing pattern tag", PatternResource{Serial: "AB1234", NoTag: "x"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResource(&tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := ValidateResource(&PatternResource{Serial: "nope"})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs.Errors[0].Message != "serial must match the required pattern" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
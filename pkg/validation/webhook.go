@@ -0,0 +1,220 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWebhookTimeout bounds a single webhook call when no timeout is set.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// ErrWebhookUnavailable is returned (wrapped) when a fail-closed webhook
+// cannot be reached or returns an unusable response. Handlers should report
+// it as a server-side failure rather than a client validation error.
+var ErrWebhookUnavailable = errors.New("validation webhook unavailable")
+
+// WebhookRequest is the body POSTed to a validation webhook.
+type WebhookRequest struct {
+	// Kind is the resource kind (e.g., "Device")
+	Kind string `json:"kind"`
+
+	// Operation is the API operation being admitted ("CREATE" or "UPDATE")
+	Operation string `json:"operation"`
+
+	// Object is the resource after mutation and in-process validation
+	Object interface{} `json:"object"`
+}
+
+// WebhookResponse is the structured verdict a webhook may return.
+//
+// A 2xx response with "allowed": false denies the request. A 2xx response
+// with an empty body, or without the allowed field, admits it.
+type WebhookResponse struct {
+	// Allowed reports whether the resource is admitted
+	Allowed *bool `json:"allowed,omitempty"`
+
+	// Message explains a denial
+	Message string `json:"message,omitempty"`
+
+	// Errors optionally lists per-field failures
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// WebhookValidator validates resources by POSTing them to an external service.
+//
+// A non-2xx status or a structured deny response is a validation failure,
+// returned as ValidationErrors carrying the webhook's message. Transport
+// errors, timeouts and unparseable responses fail closed by default
+// (the request is rejected with ErrWebhookUnavailable); set FailOpen to
+// admit requests when the webhook is down instead.
+type WebhookValidator struct {
+	// Name identifies the webhook in error messages (defaults to URL)
+	Name string
+
+	// URL is the webhook endpoint
+	URL string
+
+	// Timeout bounds each call (default DefaultWebhookTimeout)
+	Timeout time.Duration
+
+	// FailOpen admits requests when the webhook cannot be reached
+	FailOpen bool
+
+	// Client is the HTTP client to use (default http.DefaultClient)
+	Client *http.Client
+}
+
+// NewWebhookValidator creates a fail-closed webhook validator with the default timeout.
+func NewWebhookValidator(url string) (*WebhookValidator, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("webhook URL must be http or https: %q", url)
+	}
+	return &WebhookValidator{URL: url, Timeout: DefaultWebhookTimeout}, nil
+}
+
+// Validate sends the resource to the webhook and interprets its verdict.
+func (v *WebhookValidator) Validate(ctx context.Context, kind, operation string, obj interface{}) error {
+	verdict, err := v.call(ctx, kind, operation, obj)
+	if err != nil {
+		if v.FailOpen {
+			return nil
+		}
+		return fmt.Errorf("%w: %s: %v", ErrWebhookUnavailable, v.name(), err)
+	}
+	return verdict
+}
+
+// call performs the HTTP round trip. It returns a non-nil error only for
+// failures of the webhook itself; a denial is returned as the verdict.
+func (v *WebhookValidator) call(ctx context.Context, kind, operation string, obj interface{}) (verdict error, err error) {
+	body, err := json.Marshal(WebhookRequest{Kind: kind, Operation: operation, Object: obj})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	timeout := v.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var verdictBody WebhookResponse
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		if jsonErr := json.Unmarshal(respBody, &verdictBody); jsonErr != nil {
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return nil, fmt.Errorf("invalid response: %w", jsonErr)
+			}
+			// Plain-text denial
+			verdictBody.Message = strings.TrimSpace(string(respBody))
+		}
+	}
+
+	denied := resp.StatusCode < 200 || resp.StatusCode >= 300
+	if verdictBody.Allowed != nil && !*verdictBody.Allowed {
+		denied = true
+	}
+	if !denied {
+		return nil, nil
+	}
+
+	if len(verdictBody.Errors) > 0 {
+		return ValidationErrors{Errors: verdictBody.Errors}, nil
+	}
+	message := verdictBody.Message
+	if message == "" {
+		message = fmt.Sprintf("denied by %s (HTTP %d)", v.name(), resp.StatusCode)
+	}
+	return ValidationErrors{Errors: []FieldError{{Tag: "webhook", Message: message}}}, nil
+}
+
+func (v *WebhookValidator) name() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.URL
+}
+
+// webhooks holds registered webhook validators per resource kind.
+var webhooks = make(map[string][]*WebhookValidator)
+var webhooksMutex sync.RWMutex
+
+// RegisterWebhookValidator registers a webhook validator for a resource kind.
+// Webhooks for a kind are called in registration order.
+//
+// Example:
+//
+//	webhook, err := validation.NewWebhookValidator("https://policy.example.com/validate/device")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	webhook.Timeout = 2 * time.Second
+//	validation.RegisterWebhookValidator("Device", webhook)
+func RegisterWebhookValidator(resourceKind string, webhook *WebhookValidator) {
+	if resourceKind == "" {
+		panic("resource kind cannot be empty")
+	}
+	if webhook == nil {
+		panic("webhook validator cannot be nil")
+	}
+
+	webhooksMutex.Lock()
+	defer webhooksMutex.Unlock()
+	webhooks[resourceKind] = append(webhooks[resourceKind], webhook)
+}
+
+// ValidateWithWebhooks calls the webhook validators registered for a
+// resource kind, stopping at the first denial or failure.
+//
+// The generated create, update, patch and scale handlers call this after
+// struct tag and custom validation have passed. Patches are sent as UPDATE.
+//
+// Returns:
+//   - ValidationErrors if a webhook denied the resource
+//   - an error wrapping ErrWebhookUnavailable if a fail-closed webhook failed
+//   - nil if all webhooks admitted the resource (or none are registered)
+func ValidateWithWebhooks(ctx context.Context, resourceKind, operation string, obj interface{}) error {
+	webhooksMutex.RLock()
+	registered := append([]*WebhookValidator(nil), webhooks[resourceKind]...)
+	webhooksMutex.RUnlock()
+
+	for _, webhook := range registered {
+		if err := webhook.Validate(ctx, resourceKind, operation, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newWebhookServer(t *testing.T, handler http.HandlerFunc) *WebhookValidator {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	webhook, err := NewWebhookValidator(server.URL)
+	if err != nil {
+		t.Fatalf("NewWebhookValidator failed: %v", err)
+	}
+	return webhook
+}
+
+func TestWebhookValidator_Allows(t *testing.T) {
+	var got WebhookRequest
+	webhook := newWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"allowed": true}`))
+	})
+
+	obj := TestResource{Name: "dev-1", Email: "a@example.com"}
+	if err := webhook.Validate(context.Background(), "Device", "CREATE", obj); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+	if got.Kind != "Device" || got.Operation != "CREATE" || got.Object == nil {
+		t.Errorf("Webhook received %+v", got)
+	}
+}
+
+func TestWebhookValidator_StructuredDeny(t *testing.T) {
+	webhook := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"allowed": false, "message": "devices in rack r9 are frozen"}`))
+	})
+
+	err := webhook.Validate(context.Background(), "Device", "UPDATE", map[string]string{})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Validate() = %v, want ValidationErrors", err)
+	}
+	if validationErrs.Errors[0].Message != "devices in rack r9 are frozen" {
+		t.Errorf("Message = %q", validationErrs.Errors[0].Message)
+	}
+}
+
+func TestWebhookValidator_FieldErrors(t *testing.T) {
+	webhook := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors": [{"field": "location", "tag": "policy", "message": "location must be in us-west"}]}`))
+	})
+
+	err := webhook.Validate(context.Background(), "Device", "CREATE", map[string]string{})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs.Errors[0].Field != "location" {
+		t.Fatalf("Validate() = %v, want field error on location", err)
+	}
+}
+
+func TestWebhookValidator_Non2xxPlainText(t *testing.T) {
+	webhook := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusUnprocessableEntity)
+	})
+
+	err := webhook.Validate(context.Background(), "Device", "CREATE", map[string]string{})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs.Errors[0].Message != "quota exceeded" {
+		t.Fatalf("Validate() = %v, want denial with webhook message", err)
+	}
+}
+
+func TestWebhookValidator_FailClosedAndOpen(t *testing.T) {
+	webhook := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"allowed": true}`))
+	})
+	webhook.Timeout = 20 * time.Millisecond
+
+	err := webhook.Validate(context.Background(), "Device", "CREATE", map[string]string{})
+	if !errors.Is(err, ErrWebhookUnavailable) {
+		t.Errorf("Fail-closed Validate() = %v, want ErrWebhookUnavailable", err)
+	}
+
+	webhook.FailOpen = true
+	if err := webhook.Validate(context.Background(), "Device", "CREATE", map[string]string{}); err != nil {
+		t.Errorf("Fail-open Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateWithWebhooks(t *testing.T) {
+	t.Cleanup(func() {
+		webhooksMutex.Lock()
+		delete(webhooks, "Rack")
+		webhooksMutex.Unlock()
+	})
+
+	calls := 0
+	allow := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	})
+	deny := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	if err := ValidateWithWebhooks(context.Background(), "Rack", "CREATE", nil); err != nil {
+		t.Errorf("No webhooks registered: got %v", err)
+	}
+
+	RegisterWebhookValidator("Rack", allow)
+	RegisterWebhookValidator("Rack", deny)
+	err := ValidateWithWebhooks(context.Background(), "Rack", "CREATE", map[string]string{})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Errorf("ValidateWithWebhooks() = %v, want denial", err)
+	}
+	if calls != 1 {
+		t.Errorf("Allowing webhook called %d times, want 1", calls)
+	}
+}
+
+func TestNewWebhookValidator_InvalidURL(t *testing.T) {
+	if _, err := NewWebhookValidator("ftp://policy"); err == nil {
+		t.Error("Expected error for non-HTTP URL")
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/openchami/fabrica/pkg/httperror"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -102,12 +103,8 @@ func VersionNegotiationMiddleware(registry *VersionRegistry, mapper ResourceMapp
 
 			// If version negotiation failed (client requested unsupported version), return 406
 			if ctx.ServeVersion == "" && ctx.RequestedVersion != "" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusNotAcceptable)
-				errorMsg := fmt.Sprintf(`{"error":"Unsupported version","requested":"%s","supported":%v}`,
-					ctx.RequestedVersion,
-					registry.ListVersions(ctx.ResourceKind))
-				_, _ = w.Write([]byte(errorMsg))
+				httperror.WriteError(w, r, http.StatusNotAcceptable,
+					fmt.Errorf("unsupported version %q (supported versions: %v)", ctx.RequestedVersion, registry.ListVersions(ctx.ResourceKind)))
 				return
 			}
 
@@ -11,6 +11,11 @@
 // Test Coverage:
 //   - Basic file storage API generation and building
 //   - Ent database storage backend generation
+//   - Ent + SQLite server startup with foreign keys enabled
+//   - Struct-tag validation of mutated fields on update and patch
+//   - Validation webhooks denying a PATCH
+//   - Dry-run create, update and delete leaving storage unchanged
+//   - Idempotency-Key replaying a create instead of duplicating it
 //   - Multiple resource support in single projects
 //   - PATCH functionality generation
 //   - CRUD operation code generation
@@ -29,8 +34,14 @@
 package integration
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -126,6 +137,346 @@ func (s *FabricaTestSuite) TestEntStorageGeneration() {
 	s.Require().NoError(err)
 }
 
+func (s *FabricaTestSuite) TestEntSQLiteForeignKeys() {
+	project := s.createProject("ent-sqlite-test", "github.com/test/entsqlite", "ent")
+
+	err := project.Initialize(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.AddResource(s.fabricaBinary, "Device")
+	s.Require().NoError(err)
+
+	err = project.Generate(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	// The default DSN must enable foreign keys or Ent migrations fail at startup
+	mainGo, err := os.ReadFile(filepath.Join(project.Dir, "cmd", "server", "main.go"))
+	s.Require().NoError(err)
+	s.Require().Contains(string(mainGo), "_fk=1")
+
+	err = project.Build()
+	s.Require().NoError(err)
+
+	// Server exits during schema migration if the foreign_keys pragma is off
+	err = project.StartServer()
+	s.Require().NoError(err, "server should start without a foreign_keys error")
+}
+
+func (s *FabricaTestSuite) TestEntPostgresDriver() {
+	project := s.createProject("ent-postgres-test", "github.com/test/entpostgres", "ent")
+	project.InitArgs = []string{"--db", "postgres"}
+
+	err := project.Initialize(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.AddResource(s.fabricaBinary, "Device")
+	s.Require().NoError(err)
+
+	err = project.Generate(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	config, err := os.ReadFile(filepath.Join(project.Dir, ".fabrica.yaml"))
+	s.Require().NoError(err)
+	s.Require().Contains(string(config), "db_driver: postgres")
+
+	// The server opens Postgres with the lib/pq driver and a Postgres DSN
+	mainGo, err := os.ReadFile(filepath.Join(project.Dir, "cmd", "server", "main.go"))
+	s.Require().NoError(err)
+	s.Require().Contains(string(mainGo), `_ "github.com/lib/pq"`)
+	s.Require().Contains(string(mainGo), "postgres://localhost/ent-postgres-test?sslmode=disable")
+	s.Require().Contains(string(mainGo), `fabricastorage.EntDSN("postgres", config.DatabaseURL)`)
+	s.Require().Contains(string(mainGo), `ent.Open("postgres", databaseURL)`)
+	s.Require().NotContains(string(mainGo), "go-sqlite3")
+
+	err = project.Build()
+	s.Require().NoError(err)
+}
+
+// mutatorSource registers a Device mutator that turns a marker description
+// into one longer than the generated validate:"max=200" tag allows.
+const mutatorSource = `package device
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+func init() {
+	resource.RegisterMutator("Device", resource.MutatorFunc(func(_ context.Context, obj interface{}) error {
+		device := obj.(*Device)
+		if device.Spec.Description == "too-long" {
+			device.Spec.Description = strings.Repeat("x", 201)
+		}
+		return nil
+	}))
+}
+`
+
+func (s *FabricaTestSuite) TestUpdateValidatesMutatedFields() {
+	project := s.createProject("mutator-test", "github.com/test/mutator", "file")
+
+	err := project.Initialize(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.AddResource(s.fabricaBinary, "Device")
+	s.Require().NoError(err)
+
+	mutatorFile := filepath.Join(project.Dir, "pkg", "resources", "device", "mutator.go")
+	s.Require().NoError(os.WriteFile(mutatorFile, []byte(mutatorSource), 0644))
+
+	err = project.Generate(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.Build()
+	s.Require().NoError(err)
+
+	err = project.StartServer()
+	s.Require().NoError(err)
+
+	created, err := project.CreateResource("device", map[string]interface{}{"description": "valid"})
+	s.Require().NoError(err)
+	metadata, ok := created["metadata"].(map[string]interface{})
+	s.Require().True(ok, "create response should include metadata")
+	uid, ok := metadata["uid"].(string)
+	s.Require().True(ok, "create response should include a UID")
+
+	// The mutator makes the description violate max=200 after decoding
+	body, err := json.Marshal(map[string]interface{}{"description": "too-long"})
+	s.Require().NoError(err)
+	req, err := http.NewRequest(http.MethodPut, "http://localhost:8080/devices/"+uid, bytes.NewReader(body))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close() //nolint:errcheck
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode, "update with a mutated tag-invalid value should be rejected")
+
+	stored, err := project.GetResource("device", uid)
+	s.Require().NoError(err)
+	s.Equal(map[string]interface{}{"description": "valid"}, stored["spec"], "rejected update must not be saved")
+
+	// The same change sent as a merge patch is rejected too
+	req, err = http.NewRequest(http.MethodPatch, "http://localhost:8080/devices/"+uid, bytes.NewReader(body))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	patchResp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer patchResp.Body.Close() //nolint:errcheck
+
+	s.Equal(http.StatusBadRequest, patchResp.StatusCode, "patch with a mutated tag-invalid value should be rejected")
+
+	stored, err = project.GetResource("device", uid)
+	s.Require().NoError(err)
+	s.Equal(map[string]interface{}{"description": "valid"}, stored["spec"], "rejected patch must not be saved")
+}
+
+// webhookSource registers a Device validation webhook at the URL in
+// DEVICE_WEBHOOK_URL, read when the server starts.
+const webhookSource = `package device
+
+import (
+	"os"
+
+	"github.com/openchami/fabrica/pkg/validation"
+)
+
+func init() {
+	if url := os.Getenv("DEVICE_WEBHOOK_URL"); url != "" {
+		webhook, err := validation.NewWebhookValidator(url)
+		if err != nil {
+			panic(err)
+		}
+		validation.RegisterWebhookValidator("Device", webhook)
+	}
+}
+`
+
+func (s *FabricaTestSuite) TestPatchCallsValidationWebhooks() {
+	// The policy denies any device described as "forbidden"
+	var mu sync.Mutex
+	var operations []string
+	policy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review struct {
+			Operation string `json:"operation"`
+			Object    struct {
+				Spec struct {
+					Description string `json:"description"`
+				} `json:"spec"`
+			} `json:"object"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&review)
+		mu.Lock()
+		operations = append(operations, review.Operation)
+		mu.Unlock()
+		if review.Object.Spec.Description == "forbidden" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"allowed": false, "message": "forbidden by policy"}`))
+		}
+	}))
+	defer policy.Close()
+	s.T().Setenv("DEVICE_WEBHOOK_URL", policy.URL)
+
+	project := s.createProject("webhook-test", "github.com/test/webhook", "file")
+
+	err := project.Initialize(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.AddResource(s.fabricaBinary, "Device")
+	s.Require().NoError(err)
+
+	webhookFile := filepath.Join(project.Dir, "pkg", "resources", "device", "webhook.go")
+	s.Require().NoError(os.WriteFile(webhookFile, []byte(webhookSource), 0644))
+
+	err = project.Generate(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.Build()
+	s.Require().NoError(err)
+
+	err = project.StartServer()
+	s.Require().NoError(err)
+
+	created, err := project.CreateResource("device", map[string]interface{}{"description": "allowed"})
+	s.Require().NoError(err)
+	uid := created["metadata"].(map[string]interface{})["uid"].(string)
+
+	req, err := http.NewRequest(http.MethodPatch, "http://localhost:8080/devices/"+uid, bytes.NewReader([]byte(`{"description": "forbidden"}`)))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode, "patch denied by the webhook should be rejected")
+	s.Contains(string(body), "forbidden by policy")
+	mu.Lock()
+	s.Equal([]string{"CREATE", "UPDATE"}, operations, "the patch should reach the webhook as an UPDATE")
+	mu.Unlock()
+
+	stored, err := project.GetResource("device", uid)
+	s.Require().NoError(err)
+	s.Equal(map[string]interface{}{"description": "allowed"}, stored["spec"], "denied patch must not be saved")
+}
+
+func (s *FabricaTestSuite) TestDryRunDoesNotPersist() {
+	project := s.createProject("dryrun-test", "github.com/test/dryrun", "file")
+
+	err := project.Initialize(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.AddResource(s.fabricaBinary, "Device")
+	s.Require().NoError(err)
+
+	err = project.Generate(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.Build()
+	s.Require().NoError(err)
+
+	err = project.StartServer()
+	s.Require().NoError(err)
+
+	send := func(method, path string, body interface{}) (*http.Response, map[string]interface{}) {
+		var reader io.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			s.Require().NoError(err)
+			reader = bytes.NewReader(data)
+		}
+		req, err := http.NewRequest(method, "http://localhost:8080"+path, reader)
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		defer resp.Body.Close() //nolint:errcheck
+		var decoded map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&decoded)
+		return resp, decoded
+	}
+
+	resp, preview := send(http.MethodPost, "/devices?dryRun=All", map[string]interface{}{"name": "preview", "description": "dry"})
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.Equal("All", resp.Header.Get("X-Dry-Run"))
+	s.Equal(map[string]interface{}{"description": "dry"}, preview["spec"], "dry-run create should return the object")
+
+	listed, err := project.ListResources("device")
+	s.Require().NoError(err)
+	s.Empty(listed, "dry-run create must not be saved")
+
+	created, err := project.CreateResource("device", map[string]interface{}{"description": "real"})
+	s.Require().NoError(err)
+	uid := created["metadata"].(map[string]interface{})["uid"].(string)
+
+	resp, updated := send(http.MethodPut, "/devices/"+uid+"?dryRun=All", map[string]interface{}{"description": "changed"})
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(map[string]interface{}{"description": "changed"}, updated["spec"])
+
+	resp, _ = send(http.MethodDelete, "/devices/"+uid+"?dryRun=All", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, _ = send(http.MethodDelete, "/devices/"+uid+"?dryRun=Some", nil)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	stored, err := project.GetResource("device", uid)
+	s.Require().NoError(err, "dry-run delete must not delete")
+	s.Equal(map[string]interface{}{"description": "real"}, stored["spec"], "dry-run update must not be saved")
+}
+
+func (s *FabricaTestSuite) TestIdempotentCreate() {
+	project := s.createProject("idempotency-test", "github.com/test/idempotency", "file")
+
+	err := project.Initialize(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.AddResource(s.fabricaBinary, "Device")
+	s.Require().NoError(err)
+
+	err = project.Generate(s.fabricaBinary)
+	s.Require().NoError(err)
+
+	err = project.Build()
+	s.Require().NoError(err)
+
+	err = project.StartServer()
+	s.Require().NoError(err)
+
+	create := func(key string, body map[string]interface{}) (*http.Response, map[string]interface{}) {
+		data, err := json.Marshal(body)
+		s.Require().NoError(err)
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:8080/devices", bytes.NewReader(data))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		defer resp.Body.Close() //nolint:errcheck
+		var decoded map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&decoded)
+		return resp, decoded
+	}
+
+	body := map[string]interface{}{"name": "node-1", "description": "first"}
+	resp, first := create("retry-1", body)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	s.Empty(resp.Header.Get("Idempotent-Replayed"))
+
+	resp, replayed := create("retry-1", body)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.Equal("true", resp.Header.Get("Idempotent-Replayed"))
+	s.Equal(first["metadata"].(map[string]interface{})["uid"], replayed["metadata"].(map[string]interface{})["uid"])
+
+	resp, _ = create("retry-1", map[string]interface{}{"name": "node-1", "description": "second"})
+	s.Equal(http.StatusUnprocessableEntity, resp.StatusCode, "reusing a key with a different body should be rejected")
+
+	listed, err := project.ListResources("device")
+	s.Require().NoError(err)
+	s.Len(listed, 1, "a replayed create must not be saved again")
+}
+
 func (s *FabricaTestSuite) TestCRUDOperations() {
 	// Create project focused on testing that we can build and generate correctly
 	project := s.createProject("crud-test", "github.com/test/crud", "file")
@@ -23,6 +23,7 @@ type TestProject struct {
 	Dir       string
 	Module    string
 	Storage   string
+	InitArgs  []string // Extra 'fabrica init' flags, e.g. --db postgres
 	Resources []string
 	serverCmd *exec.Cmd
 	suite     *suite.Suite
@@ -41,7 +42,8 @@ func NewTestProject(s *suite.Suite, tempDir, name, module, storage string) *Test
 
 // Initialize creates and initializes the fabrica project
 func (p *TestProject) Initialize(fabricaBinary string) error {
-	cmd := exec.Command(fabricaBinary, "init", p.Name, "--module", p.Module, "--storage-type", p.Storage, "--storage")
+	args := append([]string{"init", p.Name, "--module", p.Module, "--storage-type", p.Storage, "--storage"}, p.InitArgs...)
+	cmd := exec.Command(fabricaBinary, args...)
 	cmd.Dir = filepath.Dir(p.Dir)
 	output, err := cmd.CombinedOutput()
 	if err != nil {